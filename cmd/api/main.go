@@ -15,6 +15,7 @@ import (
 	"syscall"
 	"time"
 
+	lkauth "github.com/livekit/protocol/auth"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
@@ -27,15 +28,21 @@ import (
 	"github.com/onnwee/subcults/internal/db"
 	"github.com/onnwee/subcults/internal/health"
 	"github.com/onnwee/subcults/internal/idempotency"
+	"github.com/onnwee/subcults/internal/identity"
 	"github.com/onnwee/subcults/internal/jobs"
 	"github.com/onnwee/subcults/internal/livekit"
 	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/notification"
 	"github.com/onnwee/subcults/internal/payment"
 	"github.com/onnwee/subcults/internal/post"
 	"github.com/onnwee/subcults/internal/ranking"
+	"github.com/onnwee/subcults/internal/replay"
 	"github.com/onnwee/subcults/internal/retention"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/selfcheck"
+	"github.com/onnwee/subcults/internal/slo"
+	"github.com/onnwee/subcults/internal/storage"
 	"github.com/onnwee/subcults/internal/stream"
 	"github.com/onnwee/subcults/internal/telemetry"
 	"github.com/onnwee/subcults/internal/tracing"
@@ -152,33 +159,30 @@ func main() {
 	trust.SetRankingEnabled(rankTrustEnabled)
 	logger.Info("trust ranking enabled", "component", "trust", "state", rankTrustEnabled)
 
-	// Load ranking calibration if file path is provided
+	// Load ranking calibration if file path is provided, and keep reloading it
+	// on change so weights can be tuned without a restart
 	rankingCalibrationPath := os.Getenv("RANKING_CALIBRATION_PATH")
+	var calibrationWatcher *ranking.CalibrationWatcher
 	if rankingCalibrationPath != "" {
-		// Load calibration weights from file
-		weights, err := ranking.LoadCalibration(rankingCalibrationPath)
-		if err != nil {
-			logger.Warn("failed to load ranking calibration file, using defaults",
-				"path", rankingCalibrationPath,
-				"error", err)
-		} else {
-			// Apply loaded weights as the active ranking configuration
-			ranking.SetActiveWeights(weights)
-			// Log loaded weights for verification
-			logger.Info("ranking calibration loaded",
-				"path", rankingCalibrationPath,
-				"scene_weights", map[string]float64{
-					"text_match": weights.Scene.TextMatch,
-					"proximity":  weights.Scene.Proximity,
-					"trust":      weights.Scene.Trust,
-				},
-				"event_weights", map[string]float64{
-					"recency":    weights.Event.Recency,
-					"text_match": weights.Event.TextMatch,
-					"proximity":  weights.Event.Proximity,
-					"trust":      weights.Event.Trust,
-				})
-		}
+		// Falls back to default weights (and logs why) if the file is missing or invalid
+		calibrationWatcher = ranking.NewCalibrationWatcher(rankingCalibrationPath)
+		ranking.SetActiveWatcher(calibrationWatcher)
+		weights := calibrationWatcher.Weights()
+		// Log loaded weights for verification
+		logger.Info("ranking calibration loaded",
+			"path", rankingCalibrationPath,
+			"poll_interval", ranking.DefaultCalibrationPollInterval,
+			"scene_weights", map[string]float64{
+				"text_match": weights.Scene.TextMatch,
+				"proximity":  weights.Scene.Proximity,
+				"trust":      weights.Scene.Trust,
+			},
+			"event_weights", map[string]float64{
+				"recency":    weights.Event.Recency,
+				"text_match": weights.Event.TextMatch,
+				"proximity":  weights.Event.Proximity,
+				"trust":      weights.Event.Trust,
+			})
 	} else {
 		logger.Info("ranking calibration path not set, using default weights",
 			"help", "Set RANKING_CALIBRATION_PATH environment variable to load custom weights")
@@ -186,15 +190,17 @@ func main() {
 
 	// Initialize repositories
 	eventRepo := scene.NewInMemoryEventRepository()
-	sceneRepo := scene.NewInMemorySceneRepository()
+	sceneStore := scene.NewInMemorySceneRepository()
 	auditRepo := audit.NewInMemoryRepository()
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	blockRepo := scene.NewInMemoryBlockRepository()
 	streamRepo := stream.NewInMemorySessionRepository()
-	participantRepo := stream.NewInMemoryParticipantRepository(streamRepo)
 	analyticsRepo := stream.NewInMemoryAnalyticsRepository(streamRepo)
 	postRepo := post.NewInMemoryPostRepository()
+	reactionRepo := post.NewInMemoryReactionRepository()
 	membershipRepo := membership.NewInMemoryMembershipRepository()
 	allianceRepo := alliance.NewInMemoryAllianceRepository()
+	notificationOutbox := notification.NewInMemoryOutbox()
 
 	// Initialize event broadcaster for WebSocket participant updates
 	eventBroadcaster := stream.NewEventBroadcaster()
@@ -229,6 +235,26 @@ func main() {
 	}
 	logger.Info("trust metrics registered")
 
+	// Initialize ranking metrics (counts NaN/Inf inputs replaced during scoring)
+	rankingMetrics := ranking.NewMetrics()
+	if err := rankingMetrics.Register(promRegistry); err != nil {
+		logger.Error("failed to register ranking metrics", "error", err)
+		os.Exit(1)
+	}
+	ranking.SetMetrics(rankingMetrics)
+	logger.Info("ranking metrics registered")
+
+	// Initialize latency SLO trackers (targets are the p95 budgets in docs/PERFORMANCE_GUIDE.md)
+	searchSLO := slo.NewTracker("search", 300*time.Millisecond, slo.DefaultWindow)
+	feedSLO := slo.NewTracker("feed", 250*time.Millisecond, slo.DefaultWindow)
+	for _, tracker := range []*slo.Tracker{searchSLO, feedSLO} {
+		if err := tracker.Register(promRegistry); err != nil {
+			logger.Error("failed to register SLO metrics", "error", err)
+			os.Exit(1)
+		}
+	}
+	logger.Info("SLO metrics registered")
+
 	// Parse trust recompute job configuration
 	recomputeInterval := trust.DefaultRecomputeInterval
 	if val := os.Getenv("TRUST_RECOMPUTE_INTERVAL"); val != "" {
@@ -305,6 +331,10 @@ func main() {
 		}
 	}
 
+	// Cache scene ownership checks; writes through sceneRepo invalidate the affected scene
+	sceneRepo := scene.NewOwnershipCache(sceneStore, time.Duration(cfg.OwnershipCacheTTLSeconds)*time.Second)
+	logger.Info("scene ownership cache configured", "ttl_seconds", cfg.OwnershipCacheTTLSeconds)
+
 	canaryConfig := middleware.CanaryConfig{
 		Enabled:            cfg.CanaryEnabled,
 		TrafficPercent:     cfg.CanaryTrafficPercent,
@@ -388,6 +418,7 @@ func main() {
 			os.Exit(1)
 		}
 		livekitHandlers = api.NewLiveKitHandlers(tokenService, auditRepo)
+		livekitHandlers.SetBlockRepo(blockRepo, streamRepo, eventRepo)
 		logger.Info("LiveKit token service initialized")
 
 		// Initialize LiveKit room service for organizer controls
@@ -403,6 +434,21 @@ func main() {
 		logger.Warn("LiveKit credentials not configured, token endpoint will not be available")
 	}
 
+	// Verify critical dependencies before serving traffic: abort in production,
+	// warn in development
+	selfcheckDeps := selfcheck.Deps{
+		Env:             env,
+		CalibrationPath: rankingCalibrationPath,
+		Logger:          logger,
+	}
+	if roomService != nil {
+		selfcheckDeps.LiveKit = roomService
+	}
+	if _, err := selfcheck.Run(context.Background(), selfcheckDeps); err != nil {
+		logger.Error("startup self-check failed", "error", err)
+		os.Exit(1)
+	}
+
 	// Initialize Upload service for R2 signed URLs
 	// Get R2 credentials from environment variables
 	r2BucketName := os.Getenv("R2_BUCKET_NAME")
@@ -438,6 +484,18 @@ func main() {
 		logger.Warn("R2 credentials not configured, upload endpoint will not be available")
 	}
 
+	// Initialize attachment object store (if upload service is configured)
+	var attachmentHandlers *api.AttachmentHandlers
+	if uploadService != nil {
+		attachmentStore, err := storage.NewS3Store(uploadService.GetS3Client(), uploadService.GetBucketName())
+		if err != nil {
+			logger.Error("failed to initialize attachment store", "error", err)
+			os.Exit(1)
+		}
+		attachmentHandlers = api.NewAttachmentHandlers(attachmentStore, int64(r2MaxSizeMB)<<20, 5*time.Minute)
+		logger.Info("attachment store initialized", "bucket", r2BucketName)
+	}
+
 	// Initialize attachment metadata service (if upload service is configured)
 	var metadataService *attachment.MetadataService
 	if uploadService != nil {
@@ -479,6 +537,10 @@ func main() {
 	var webhookHandlers *api.WebhookHandlers
 	var idempotencyMiddleware func(http.Handler) http.Handler
 	stripeWebhookSecret := os.Getenv("STRIPE_WEBHOOK_SECRET")
+	stopPaymentExpiry := make(chan struct{})
+
+	// Shared by webhook receivers to reject stale and replayed deliveries
+	webhookReplayGuard := replay.NewGuard(time.Duration(cfg.WebhookReplayWindowSeconds) * time.Second)
 
 	if stripeAPIKey != "" && stripeOnboardingReturnURL != "" && stripeOnboardingRefreshURL != "" {
 		stripeClient := payment.NewStripeClient(stripeAPIKey)
@@ -501,8 +563,17 @@ func main() {
 			stripeOnboardingRefreshURL,
 			stripeApplicationFeePercent,
 		)
+		paymentMetrics := payment.NewMetrics()
+		if err := paymentMetrics.Register(promRegistry); err != nil {
+			logger.Error("failed to register payment metrics", "error", err)
+			os.Exit(1)
+		}
+		paymentHandlers.SetMetrics(paymentMetrics)
 		logger.Info("Stripe payment handlers initialized", "application_fee_percent", stripeApplicationFeePercent)
 
+		// Cancel checkouts abandoned past Stripe's session lifetime
+		go payment.RunPeriodicExpiry(paymentRepo, stripeClient, time.Hour, payment.DefaultPendingExpiry, stopPaymentExpiry)
+
 		// Initialize webhook handler if secret is configured
 		if stripeWebhookSecret != "" {
 			webhookHandlers = api.NewWebhookHandlers(
@@ -511,6 +582,7 @@ func main() {
 				webhookRepo,
 				sceneRepo,
 			)
+			webhookHandlers.SetReplayGuard(webhookReplayGuard)
 			logger.Info("Stripe webhook handler initialized")
 		} else {
 			logger.Warn("STRIPE_WEBHOOK_SECRET not configured, webhook endpoint will not be available")
@@ -523,14 +595,93 @@ func main() {
 	// Pass trustScoreStore to eventHandlers to enable trust-weighted ranking
 	trustStoreAdapter := api.NewTrustScoreStoreAdapter(trustScoreStore)
 	sceneHandlers := api.NewSceneHandlers(sceneRepo, membershipRepo, streamRepo)
+	if cfg.SceneCreationGateEnabled {
+		var allowlist []string
+		if cfg.SceneCreationAllowlist != "" {
+			for _, did := range strings.Split(cfg.SceneCreationAllowlist, ",") {
+				if did = strings.TrimSpace(did); did != "" {
+					allowlist = append(allowlist, did)
+				}
+			}
+		}
+		sceneHandlers.SetCreationGate(identity.NewGate(identity.GateConfig{
+			Enabled:       true,
+			MinAccountAge: time.Duration(cfg.SceneCreationMinAccountAgeHours) * time.Hour,
+			Allowlist:     allowlist,
+		}, identity.NewPLCResolver(cfg.PLCDirectoryURL, nil)))
+		logger.Info("scene creation gate enabled",
+			"min_account_age_hours", cfg.SceneCreationMinAccountAgeHours,
+			"allowlisted_dids", len(allowlist))
+	} else {
+		logger.Warn("scene creation gate disabled; any account may create scenes")
+	}
+	sceneHandlers.SetAuditRepo(auditRepo)
+	sceneHandlers.SetMaxDescriptionLength(cfg.DescriptionMaxLength)
+	sceneHandlers.SetBlockRepo(blockRepo)
+	moderationLogHandlers := api.NewModerationLogHandlers(sceneRepo, auditRepo)
 	membershipHandlers := api.NewMembershipHandlers(membershipRepo, sceneRepo, auditRepo)
+	membershipHandlers.SetNotificationOutbox(notificationOutbox)
+	sceneBlockHandlers := api.NewSceneBlockHandlers(blockRepo, sceneRepo, auditRepo)
 	eventHandlers := api.NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo, trustStoreAdapter)
+	eventHandlers.SetMaxScheduleHorizon(time.Duration(cfg.MaxScheduleHorizonDays) * 24 * time.Hour)
+	eventHandlers.SetEditGracePeriod(time.Duration(cfg.EventEditGraceMinutes) * time.Minute)
+	eventHandlers.SetMaxTags(cfg.EventMaxTags)
+	eventHandlers.SetMaxDescriptionLength(cfg.DescriptionMaxLength)
+	eventHandlers.SetSearchLatencySLO(searchSLO)
+	eventHandlers.SetMembershipRepo(membershipRepo)
+	eventHandlers.SetBlockRepo(blockRepo)
+	eventSeriesHandlers := api.NewEventSeriesHandlers(eventRepo, sceneRepo, rsvpRepo, auditRepo, notificationOutbox)
+	eventSeriesHandlers.SetMembershipRepo(membershipRepo)
 	rsvpHandlers := api.NewRSVPHandlers(rsvpRepo, eventRepo)
+	rsvpHandlers.SetBlockRepo(blockRepo)
+	rsvpHandlers.SetAuditRepo(auditRepo)
+	// Re-joins within the debounce window reopen the participant's previous record
+	participantRepo := stream.NewInMemoryParticipantRepository(streamRepo,
+		stream.WithReconnectionGrace(time.Duration(cfg.StreamJoinDebounceSeconds)*time.Second))
 	streamHandlers := api.NewStreamHandlers(streamRepo, participantRepo, analyticsRepo, sceneRepo, eventRepo, auditRepo, streamMetrics, eventBroadcaster, roomService)
+	streamHandlers.SetMembershipRepo(membershipRepo)
+	streamHandlers.SetBlockRepo(blockRepo)
+	if cfg.StreamJoinDebounceSeconds > 0 {
+		streamHandlers.SetJoinDebouncer(stream.NewJoinDebouncer(time.Duration(cfg.StreamJoinDebounceSeconds) * time.Second))
+	}
+	if regions := cfg.LiveKitRegionList(); len(regions) > 0 {
+		streamHandlers.SetRegions(regions, cfg.LiveKitHomeRegion)
+		logger.Info("LiveKit region selection enabled", "regions", regions, "home_region", cfg.LiveKitHomeRegion)
+	}
+	var streamLimitExempt []string
+	if cfg.StreamHostLimitExemptDIDs != "" {
+		for _, did := range strings.Split(cfg.StreamHostLimitExemptDIDs, ",") {
+			if did = strings.TrimSpace(did); did != "" {
+				streamLimitExempt = append(streamLimitExempt, did)
+			}
+		}
+	}
+	streamHandlers.SetHostStreamLimit(cfg.StreamMaxActivePerHost, streamLimitExempt)
+	streamHandlers.SetAdminDIDs(cfg.AdminDIDList())
+	if livekitAPIKey != "" && livekitAPISecret != "" {
+		streamHandlers.SetWebhookKeyProvider(lkauth.NewSimpleKeyProvider(livekitAPIKey, livekitAPISecret))
+		streamHandlers.SetReplayGuard(webhookReplayGuard)
+	}
+	eventBroadcaster.SetMaxSubscribersPerStream(cfg.StreamMaxSubscribers)
+	eventBroadcaster.SetMetrics(streamMetrics)
+	eventBroadcaster.SetEndedRetention(time.Duration(cfg.StreamEndedRetentionSeconds) * time.Second)
 	postHandlers := api.NewPostHandlers(postRepo, sceneRepo, membershipRepo, metadataService)
+	postHandlers.SetFeedLatencySLO(feedSLO)
+	postHandlers.SetAuditRepo(auditRepo)
+	postHandlers.SetBlockRepo(blockRepo, eventRepo)
+	postHandlers.SetMaxAttachmentBytes(int64(r2MaxSizeMB) << 20)
+	if cfg.ContentClassifierURL != "" {
+		postHandlers.SetContentClassifier(post.NewHTTPClassifier(cfg.ContentClassifierURL, nil))
+	}
+	postHandlers.SetReactionRepo(reactionRepo)
+	postHandlers.SetDefaultFeedWindow(time.Duration(cfg.PostFeedDefaultWindowDays) * 24 * time.Hour)
 	trustHandlers := api.NewTrustHandlers(sceneRepo, trustDataSource, trustScoreStore, trustDirtyTracker)
 	allianceHandlers := api.NewAllianceHandlers(allianceRepo, sceneRepo, trustDataSource, trustDirtyTracker)
 	searchHandlers := api.NewSearchHandlers(sceneRepo, postRepo, trustStoreAdapter, eventRepo)
+	searchHandlers.SetLatencySLO(searchSLO)
+	searchHandlers.SetMembershipRepo(membershipRepo)
+	identityHandlers := api.NewIdentityHandlers(identity.NewCachingHandleResolver(
+		identity.NewATProtoHandleResolver(cfg.PLCDirectoryURL, nil), identity.DefaultHandleCacheTTL))
 
 	// Initialize retention and account handlers
 	retentionRepo := retention.NewInMemoryRepository(logger)
@@ -588,21 +739,83 @@ func main() {
 
 	mux.HandleFunc("/events/", func(w http.ResponseWriter, r *http.Request) {
 		// Parse path to check for special endpoints
-		// Expected patterns: /events/{id}, /events/{id}/cancel, /events/{id}/rsvp, /events/{id}/feed
+		// Expected patterns: /events/{id}, /events/{id}/cancel, /events/{id}/approve, /events/{id}/reject,
+		// /events/{id}/rsvp, /events/{id}/rsvps/summary, /events/{id}/feed, /events/{id}/permissions, /events/{id}/calendar.ics,
+		// /events/series/{seriesId}/cancel, /events/series/{seriesId}/next
 		pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
 
+		// Series bulk cancel: /events/series/{seriesId}/cancel
+		if len(pathParts) == 3 && pathParts[0] == "series" && pathParts[1] != "" && pathParts[2] == "cancel" {
+			if r.Method != http.MethodPost {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				return
+			}
+			eventSeriesHandlers.CancelSeries(w, r)
+			return
+		}
+
+		// Series next occurrence: /events/series/{seriesId}/next
+		if len(pathParts) == 3 && pathParts[0] == "series" && pathParts[1] != "" && pathParts[2] == "next" {
+			if r.Method != http.MethodGet {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				return
+			}
+			eventSeriesHandlers.GetNextOccurrence(w, r)
+			return
+		}
+
 		// Check if this is a feed request: /events/{id}/feed
 		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "feed" && r.Method == http.MethodGet {
 			postHandlers.GetEventFeed(w, r)
 			return
 		}
 
+		// Caller's capabilities on the event: /events/{id}/permissions
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "permissions" && r.Method == http.MethodGet {
+			eventHandlers.GetEventPermissions(w, r)
+			return
+		}
+
+		// iCalendar export: /events/{id}/calendar.ics
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "calendar.ics" && r.Method == http.MethodGet {
+			eventHandlers.GetEventCalendar(w, r)
+			return
+		}
+
+		// RSVP counts by status: /events/{id}/rsvps/summary
+		if len(pathParts) == 3 && pathParts[0] != "" && pathParts[1] == "rsvps" && pathParts[2] == "summary" {
+			if r.Method != http.MethodGet {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				return
+			}
+			eventHandlers.GetEventRSVPSummary(w, r)
+			return
+		}
+
 		// Check if this is a cancel request: /events/{id}/cancel
 		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "cancel" && r.Method == http.MethodPost {
 			eventHandlers.CancelEvent(w, r)
 			return
 		}
 
+		// Owner review of member-proposed events: /events/{id}/approve, /events/{id}/reject
+		if len(pathParts) == 2 && pathParts[0] != "" && (pathParts[1] == "approve" || pathParts[1] == "reject") {
+			if r.Method != http.MethodPost {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				return
+			}
+			if pathParts[1] == "approve" {
+				eventHandlers.ApproveEvent(w, r)
+			} else {
+				eventHandlers.RejectEvent(w, r)
+			}
+			return
+		}
+
 		// Check if this is an RSVP request: /events/{id}/rsvp
 		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "rsvp" {
 			switch r.Method {
@@ -653,6 +866,42 @@ func main() {
 		sceneHandlers.ListOwnedScenes(w, r)
 	})
 
+	mux.HandleFunc("/scenes/spotlight", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			return
+		}
+		sceneHandlers.GetSpotlight(w, r)
+	})
+
+	mux.HandleFunc("/me/scenes", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			return
+		}
+		sceneHandlers.ListOwnedScenes(w, r)
+	})
+
+	mux.HandleFunc("/me/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			return
+		}
+		eventHandlers.ListMyEvents(w, r)
+	})
+
+	mux.HandleFunc("/me/rsvps", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			return
+		}
+		rsvpHandlers.ListMyRSVPs(w, r)
+	})
+
 	// Ensure trailing-slash variant /scenes/owned/ does not fall through to the
 	// /scenes/ catch-all, where "owned" would be treated as a scene ID.
 	mux.HandleFunc("/scenes/owned/", func(w http.ResponseWriter, r *http.Request) {
@@ -660,7 +909,7 @@ func main() {
 		http.Redirect(w, r, "/scenes/owned", http.StatusMovedPermanently)
 	})
 
-	// Scene resource routes: /scenes/{id}, /scenes/{id}/feed, /scenes/{id}/palette, /scenes/{id}/membership/*
+	// Scene resource routes: /scenes/{id}, /scenes/{id}/feed, /scenes/{id}/search, /scenes/{id}/announcements, /scenes/{id}/moderation_log, /scenes/{id}/palette, /scenes/{id}/blocks, /scenes/{id}/permissions, /scenes/{id}/membership/*
 	mux.HandleFunc("/scenes/", func(w http.ResponseWriter, r *http.Request) {
 		// Parse path to determine which endpoint to route to
 		pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
@@ -677,12 +926,104 @@ func main() {
 			return
 		}
 
+		// Scene announcements: /scenes/{id}/announcements
+		if len(pathParts) == 2 && pathParts[1] == "announcements" && r.Method == http.MethodGet {
+			postHandlers.GetSceneAnnouncements(w, r)
+			return
+		}
+
+		// Scene events: /scenes/{id}/events
+		if len(pathParts) == 2 && pathParts[1] == "events" && r.Method == http.MethodGet {
+			eventHandlers.ListSceneEvents(w, r)
+			return
+		}
+
+		// Scene-scoped search: /scenes/{id}/search
+		if len(pathParts) == 2 && pathParts[1] == "search" && r.Method == http.MethodGet {
+			searchHandlers.SearchScene(w, r)
+			return
+		}
+
+		// Scene live and scheduled streams: /scenes/{id}/streams
+		if len(pathParts) == 2 && pathParts[1] == "streams" && r.Method == http.MethodGet {
+			streamHandlers.ListSceneStreams(w, r)
+			return
+		}
+
+		// Scene stream history: /scenes/{id}/streams/history
+		if len(pathParts) == 3 && pathParts[1] == "streams" && pathParts[2] == "history" && r.Method == http.MethodGet {
+			streamHandlers.ListSceneStreamHistory(w, r)
+			return
+		}
+
+		// Scene stream recordings: /scenes/{id}/recordings
+		if len(pathParts) == 2 && pathParts[1] == "recordings" && r.Method == http.MethodGet {
+			streamHandlers.ListSceneRecordings(w, r)
+			return
+		}
+
+		// Caller's capabilities in the scene: /scenes/{id}/permissions
+		if len(pathParts) == 2 && pathParts[1] == "permissions" && r.Method == http.MethodGet {
+			sceneHandlers.GetScenePermissions(w, r)
+			return
+		}
+
+		// Scene RSVP trends: /scenes/{id}/rsvp_trends
+		if len(pathParts) == 2 && pathParts[1] == "rsvp_trends" && r.Method == http.MethodGet {
+			eventHandlers.GetSceneRSVPTrends(w, r)
+			return
+		}
+
+		// Scene pinned event: /scenes/{id}/pinned_event
+		if len(pathParts) == 2 && pathParts[1] == "pinned_event" {
+			switch r.Method {
+			case http.MethodPut:
+				eventHandlers.SetPinnedEvent(w, r)
+			case http.MethodDelete:
+				eventHandlers.ClearPinnedEvent(w, r)
+			default:
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			}
+			return
+		}
+
+		// Scene moderator purge: /scenes/{id}/moderation/purge
+		if len(pathParts) == 3 && pathParts[1] == "moderation" && pathParts[2] == "purge" && r.Method == http.MethodPost {
+			postHandlers.PurgeAuthorPosts(w, r)
+			return
+		}
+
+		// Scene moderation log: /scenes/{id}/moderation_log
+		if len(pathParts) == 2 && pathParts[1] == "moderation_log" && r.Method == http.MethodGet {
+			moderationLogHandlers.GetSceneModerationLog(w, r)
+			return
+		}
+
 		// Scene palette: /scenes/{id}/palette
 		if len(pathParts) == 2 && pathParts[1] == "palette" && r.Method == http.MethodPatch {
 			sceneHandlers.UpdateScenePalette(w, r)
 			return
 		}
 
+		// Scene block list: /scenes/{id}/blocks, /scenes/{id}/blocks/{did}
+		if len(pathParts) == 2 && pathParts[1] == "blocks" {
+			switch r.Method {
+			case http.MethodGet:
+				sceneBlockHandlers.ListBlocks(w, r)
+			case http.MethodPost:
+				sceneBlockHandlers.BlockUser(w, r)
+			default:
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			}
+			return
+		}
+		if len(pathParts) == 3 && pathParts[1] == "blocks" && r.Method == http.MethodDelete {
+			sceneBlockHandlers.UnblockUser(w, r)
+			return
+		}
+
 		// Membership request: /scenes/{id}/membership/request
 		if len(pathParts) == 3 && pathParts[1] == "membership" && pathParts[2] == "request" && r.Method == http.MethodPost {
 			membershipHandlers.RequestMembership(w, r)
@@ -774,6 +1115,34 @@ func main() {
 	)
 	mux.Handle("/search/global", searchGlobalHandler)
 
+	// Scene name suggestions (rate limited: 30 req/min per user to slow scraping)
+	searchSuggestHandler := middleware.RateLimiter(rateLimitStore, api.SuggestRateLimit, middleware.UserKeyFunc(), rateLimitMetrics)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				searchHandlers.SuggestScenes(w, r)
+			default:
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			}
+		}),
+	)
+	mux.Handle("/search/suggest", searchSuggestHandler)
+
+	// Handle resolution for mention linking (rate limited: 30 req/min per user to slow enumeration)
+	resolveHandleHandler := middleware.RateLimiter(rateLimitStore, api.HandleResolveRateLimit, middleware.UserKeyFunc(), rateLimitMetrics)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				identityHandlers.ResolveHandle(w, r)
+			default:
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			}
+		}),
+	)
+	mux.Handle("/identity/resolve", resolveHandleHandler)
+
 	// Stream join handler (with rate limiting: 10 req/min per user)
 	streamJoinHandler := middleware.RateLimiter(rateLimitStore, streamJoinLimit, middleware.UserKeyFunc(), rateLimitMetrics)(
 		http.HandlerFunc(streamHandlers.JoinStream),
@@ -789,16 +1158,47 @@ func main() {
 			}
 			livekitHandlers.IssueToken(w, r)
 		})
+
+		// LiveKit webhook - the webhook signature serves as authentication
+		mux.HandleFunc("/livekit/webhook", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				return
+			}
+			streamHandlers.HandleLiveKitWebhook(w, r)
+		})
 	}
 
 	// Stream session routes
 	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			streamHandlers.ListStreams(w, r)
+		case http.MethodPost:
+			streamHandlers.CreateStream(w, r)
+		default:
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+		}
+	})
+
+	mux.HandleFunc("/admin/streams/recompute_analytics", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
 			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
 			return
 		}
-		streamHandlers.CreateStream(w, r)
+		streamHandlers.RecomputeAnalytics(w, r)
+	})
+
+	mux.HandleFunc("/me/streams/end_all", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+			api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			return
+		}
+		streamHandlers.EndAllStreams(w, r)
 	})
 
 	mux.HandleFunc("/streams/", func(w http.ResponseWriter, r *http.Request) {
@@ -841,6 +1241,32 @@ func main() {
 			return
 		}
 
+		// Check if this is a mute all request: /streams/{id}/mute_all
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "mute_all" && r.Method == http.MethodPost {
+			streamHandlers.MuteAll(w, r)
+			return
+		}
+
+		// Host-only participant roster: /streams/{id}/roster
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "roster" && r.Method == http.MethodGet {
+			streamHandlers.GetRoster(w, r)
+			return
+		}
+
+		// Check if this is a raise hand request: /streams/{id}/raise_hand
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "raise_hand" {
+			switch r.Method {
+			case http.MethodPost:
+				streamHandlers.RaiseHand(w, r)
+			case http.MethodDelete:
+				streamHandlers.LowerHand(w, r)
+			default:
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			}
+			return
+		}
+
 		// Check if this is a kick request: /streams/{id}/participants/{participant_id}/kick
 		if len(pathParts) == 4 && pathParts[0] != "" && pathParts[1] == "participants" && pathParts[2] != "" && pathParts[3] == "kick" && r.Method == http.MethodPost {
 			streamHandlers.KickParticipant(w, r)
@@ -853,12 +1279,24 @@ func main() {
 			return
 		}
 
+		// Check if this is a start request for a scheduled stream: /streams/{id}/start
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "start" && r.Method == http.MethodPost {
+			streamHandlers.StartStream(w, r)
+			return
+		}
+
 		// Check if this is a lock request: /streams/{id}/lock
 		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "lock" && r.Method == http.MethodPatch {
 			streamHandlers.LockStream(w, r)
 			return
 		}
 
+		// Check if this is a join policy request: /streams/{id}/join_policy
+		if len(pathParts) == 2 && pathParts[0] != "" && pathParts[1] == "join_policy" && r.Method == http.MethodPatch {
+			streamHandlers.SetJoinPolicy(w, r)
+			return
+		}
+
 		// Check if this is a GET request for stream details: /streams/{id}
 		if len(pathParts) == 1 && pathParts[0] != "" && r.Method == http.MethodGet {
 			streamHandlers.GetStream(w, r)
@@ -915,6 +1353,45 @@ func main() {
 	})
 
 	mux.HandleFunc("/posts/", func(w http.ResponseWriter, r *http.Request) {
+		// Thread replies: /posts/{id}/replies
+		if strings.HasSuffix(r.URL.Path, "/replies") {
+			if r.Method != http.MethodGet {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				return
+			}
+			postHandlers.GetPostReplies(w, r)
+			return
+		}
+
+		// Scene moderator actions: /posts/{id}/moderation
+		if strings.HasSuffix(r.URL.Path, "/moderation") {
+			switch r.Method {
+			case http.MethodPost:
+				postHandlers.ApplyModerationLabel(w, r)
+			case http.MethodDelete:
+				postHandlers.RemovePostAsModerator(w, r)
+			default:
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			}
+			return
+		}
+
+		// Emoji reactions: /posts/{id}/reactions
+		if strings.HasSuffix(r.URL.Path, "/reactions") {
+			switch r.Method {
+			case http.MethodPost:
+				postHandlers.AddReaction(w, r)
+			case http.MethodDelete:
+				postHandlers.RemoveReaction(w, r)
+			default:
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+			}
+			return
+		}
+
 		switch r.Method {
 		case http.MethodPatch:
 			postHandlers.UpdatePost(w, r)
@@ -926,6 +1403,19 @@ func main() {
 		}
 	})
 
+	// Attachment presign route (if configured). Registered explicitly so it takes
+	// precedence over the /posts/ catch-all.
+	if attachmentHandlers != nil {
+		mux.HandleFunc("/posts/attachments/presign", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				return
+			}
+			attachmentHandlers.PresignUpload(w, r)
+		})
+	}
+
 	// Upload routes (if configured)
 	if uploadHandlers != nil {
 		mux.HandleFunc("/uploads/sign", func(w http.ResponseWriter, r *http.Request) {
@@ -940,13 +1430,17 @@ func main() {
 
 	// Payment routes (if configured)
 	if paymentHandlers != nil {
+		// Onboarding (with rate limiting: 5 req/hour per user)
+		onboardHandler := middleware.RateLimiter(rateLimitStore, api.OnboardingRateLimit, middleware.UserKeyFunc(), rateLimitMetrics)(
+			http.HandlerFunc(paymentHandlers.OnboardScene),
+		)
 		mux.HandleFunc("/payments/onboard", func(w http.ResponseWriter, r *http.Request) {
 			if r.Method != http.MethodPost {
 				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
 				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
 				return
 			}
-			paymentHandlers.OnboardScene(w, r)
+			onboardHandler.ServeHTTP(w, r)
 		})
 
 		// Wrap checkout handler with idempotency middleware
@@ -974,6 +1468,30 @@ func main() {
 			}
 			paymentHandlers.GetPaymentStatus(w, r)
 		})
+
+		mux.HandleFunc("/payments/status/batch", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				return
+			}
+			paymentHandlers.GetPaymentStatusBatch(w, r)
+		})
+
+		// Payer-initiated checkout cancellation: /payments/{sessionId}/cancel
+		mux.HandleFunc("/payments/", func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/cancel") {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeNotFound)
+				api.WriteError(w, ctx, http.StatusNotFound, api.ErrCodeNotFound, "The requested resource was not found")
+				return
+			}
+			if r.Method != http.MethodPost {
+				ctx := middleware.SetErrorCode(r.Context(), api.ErrCodeBadRequest)
+				api.WriteError(w, ctx, http.StatusMethodNotAllowed, api.ErrCodeBadRequest, "Method not allowed")
+				return
+			}
+			paymentHandlers.CancelCheckoutSession(w, r)
+		})
 	}
 
 	// Webhook endpoint (if configured) - must be before auth middleware
@@ -1207,12 +1725,18 @@ func main() {
 	// Request flow (what executes first to last):
 	// 1. Tracing - OpenTelemetry instrumentation (if enabled)
 	// 2. CORS - Cross-origin resource sharing (if configured)
-	// 3. General rate limiting (1000 req/min per IP) - blocks excessive requests early
+	// 3. RealIP - resolves the client IP, honoring forwarding headers only from trusted proxies
+	//    General rate limiting (1000 req/min per IP) - blocks excessive requests early
 	// 4. HTTP metrics - captures request duration, sizes, and counts
 	// 5. RequestID - generates/extracts request IDs for tracing
 	// 6. Logging - logs requests with all context
 	var handler http.Handler = mux
 
+	// Removed endpoints answer 410 Gone with replacement guidance instead of 404.
+	// Register each path here when its route is deleted from the mux.
+	removedEndpoints := api.NewRemovedEndpointRegistry()
+	handler = removedEndpoints.Middleware(handler)
+
 	// Apply middleware in reverse order of execution
 	// Logging is applied first (innermost, executes last)
 	handler = middleware.Logging(logger)(handler)
@@ -1237,6 +1761,14 @@ func main() {
 		handler = canaryRouter.Middleware(handler)
 	}
 
+	// Then client IP resolution, ahead of rate limiting, canary cohorts and audit logging
+	trustedProxies, err := cfg.TrustedProxyList()
+	if err != nil {
+		logger.Error("invalid trusted proxies", "error", err)
+		os.Exit(1)
+	}
+	handler = middleware.RealIP(trustedProxies)(handler)
+
 	// Then CORS (if configured)
 	if cfg.CORSAllowedOrigins != "" {
 		// Parse comma-separated origins
@@ -1320,6 +1852,13 @@ func main() {
 	}
 	logger.Info("trust recompute job started")
 
+	// Start sweeping the stream event subscriber registry
+	stopEventSweeper := make(chan struct{})
+	if cfg.StreamEventSweepSeconds > 0 {
+		go eventBroadcaster.RunSweeper(time.Duration(cfg.StreamEventSweepSeconds)*time.Second, stopEventSweeper)
+		logger.Info("stream event registry sweeper started", "interval_seconds", cfg.StreamEventSweepSeconds)
+	}
+
 	// Wait for interrupt signal for graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -1331,6 +1870,17 @@ func main() {
 	trustRecomputeJob.Stop()
 	logger.Info("trust recompute job stopped")
 
+	// Stop watching the ranking calibration file
+	if calibrationWatcher != nil {
+		calibrationWatcher.Close()
+	}
+
+	// Stop sweeping the stream event subscriber registry
+	close(stopEventSweeper)
+
+	// Stop expiring abandoned payments
+	close(stopPaymentExpiry)
+
 	// Create context with timeout for shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
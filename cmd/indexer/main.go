@@ -17,6 +17,7 @@ import (
 	"github.com/onnwee/subcults/internal/db"
 	"github.com/onnwee/subcults/internal/indexer"
 	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/selfcheck"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -114,6 +115,7 @@ func main() {
 	// Initialize repository based on DATABASE_URL environment variable
 	var repo indexer.RecordRepository
 	var sequenceTracker indexer.SequenceTracker
+	var deadLetters indexer.DeadLetterStore
 	var cleanupService interface {
 		Start(context.Context)
 		Stop()
@@ -129,15 +131,16 @@ func main() {
 		}
 		defer db.Close()
 
-		// Test connection
-		if err := db.Ping(); err != nil {
-			logger.Error("failed to ping database", "error", err)
+		// Verify the connection: abort in production, warn in development
+		if _, err := selfcheck.Run(context.Background(), selfcheck.Deps{Env: env, DB: db, Logger: logger}); err != nil {
+			logger.Error("startup self-check failed", "error", err)
 			os.Exit(1)
 		}
 
 		logger.Info("using Postgres repository", "database_url", databaseURL)
 		repo = indexer.NewPostgresRecordRepository(db, logger)
 		sequenceTracker = indexer.NewPostgresSequenceTracker(db, logger)
+		deadLetters = indexer.NewPostgresDeadLetterStore(db)
 
 		// Use Postgres cleanup service
 		cleanupConfig := indexer.DefaultCleanupConfig()
@@ -148,6 +151,7 @@ func main() {
 		memRepo := indexer.NewInMemoryRecordRepository(logger)
 		repo = memRepo
 		sequenceTracker = indexer.NewInMemorySequenceTracker(logger)
+		deadLetters = indexer.NewInMemoryDeadLetterStore()
 		cleanupConfig := indexer.DefaultCleanupConfig()
 		cleanupService = indexer.NewInMemoryCleanupService(memRepo, logger, cleanupConfig)
 	}
@@ -162,136 +166,19 @@ func main() {
 	// Start cleanup service with app context
 	cleanupService.Start(appCtx)
 
-	// Message handler - now with transactional database persistence and sequence tracking
-	handler := func(messageType int, payload []byte) error {
-		start := time.Now()
-
-		// Decode CBOR message to extract timestamp for lag calculation
-		msg, err := indexer.DecodeCBORMessage(payload)
-		if err != nil {
-			// Log error with context but don't fail - continue processing
-			logger.Debug("failed to decode message for lag calculation",
-				slog.String("error", err.Error()))
-		} else if msg != nil && msg.TimeUS > 0 {
-			// Calculate processing lag: current time - message timestamp
-			messageTime := time.Unix(0, msg.TimeUS*1000) // Convert microseconds to nanoseconds
-			lag := time.Since(messageTime)
-			metrics.SetProcessingLag(lag.Seconds())
-
-			logger.Debug("processing message",
-				slog.String("kind", msg.Kind),
-				slog.Duration("lag", lag))
-		}
-
-		// Filter and validate the record
-		result := filter.FilterCBOR(payload)
-		metrics.IncMessagesProcessed()
-
-		// If record doesn't match our lexicon, skip (but still update sequence)
-		if !result.Matched {
-			// Update sequence even for non-matched records to avoid re-processing
-			if msg != nil && msg.TimeUS > 0 {
-				if err := sequenceTracker.UpdateSequence(appCtx, msg.TimeUS); err != nil {
-					logger.Warn("failed to update sequence for non-matched record",
-						slog.String("error", err.Error()))
-				}
-			}
-			return nil
-		}
+	// Message handler - now with transactional database persistence and sequence tracking.
+	// Each message's log lines share a processing_id for correlation.
+	processor := indexer.NewProcessor(filter, repo, sequenceTracker, metrics, logger)
+	processor.SetDeadLetterStore(deadLetters)
 
-		// If record failed validation, log and increment error counter
-		if !result.Valid {
-			metrics.IncMessagesError()
-			logger.Warn("record validation failed",
-				slog.String("collection", result.Collection),
-				slog.String("did", result.DID),
-				slog.String("rkey", result.RKey),
-				slog.String("error", result.Error.Error()))
-			// Update sequence even for invalid records to avoid re-processing
-			if msg != nil && msg.TimeUS > 0 {
-				if err := sequenceTracker.UpdateSequence(appCtx, msg.TimeUS); err != nil {
-					logger.Warn("failed to update sequence for invalid record",
-						slog.String("error", err.Error()))
-				}
-			}
-			return nil // Don't fail the entire stream for validation errors
-		}
-
-		// Handle delete operations
-		if result.Operation == "delete" {
-			if err := repo.DeleteRecord(appCtx, result.DID, result.Collection, result.RKey); err != nil {
-				metrics.IncDatabaseWritesFailed()
-				logger.Error("failed to delete record",
-					slog.String("collection", result.Collection),
-					slog.String("did", result.DID),
-					slog.String("rkey", result.RKey),
-					slog.String("error", err.Error()))
-				return nil // Don't fail stream on delete errors
-			}
-			logger.Info("record deleted",
-				slog.String("collection", result.Collection),
-				slog.String("did", result.DID),
-				slog.String("rkey", result.RKey))
-
-			// Update sequence after successful delete
-			if msg != nil && msg.TimeUS > 0 {
-				if err := sequenceTracker.UpdateSequence(appCtx, msg.TimeUS); err != nil {
-					logger.Error("failed to update sequence after delete",
-						slog.String("error", err.Error()))
-				}
-			}
-			return nil
-		}
-
-		// Upsert record with transaction support
-		recordID, isNew, err := repo.UpsertRecord(appCtx, &result)
-		if err != nil {
-			metrics.IncDatabaseWritesFailed()
-			logger.Error("failed to upsert record",
-				slog.String("collection", result.Collection),
-				slog.String("did", result.DID),
-				slog.String("rkey", result.RKey),
-				slog.String("error", err.Error()))
-			return nil // Don't fail stream on upsert errors
-		}
-
-		// If record was skipped due to idempotency, don't count as upsert
-		if recordID == "" {
-			logger.Debug("record skipped (idempotent)",
-				slog.String("collection", result.Collection),
-				slog.String("did", result.DID),
-				slog.String("rkey", result.RKey))
-			// Update sequence even for idempotent records to avoid re-processing
-			if msg != nil && msg.TimeUS > 0 {
-				if err := sequenceTracker.UpdateSequence(appCtx, msg.TimeUS); err != nil {
-					logger.Warn("failed to update sequence for idempotent record",
-						slog.String("error", err.Error()))
-				}
-			}
-			return nil
-		}
-
-		// Record successful upsert
-		metrics.IncUpserts()
-		logger.Info("record upserted",
-			slog.String("record_id", recordID),
-			slog.String("collection", result.Collection),
-			slog.String("did", result.DID),
-			slog.String("rkey", result.RKey),
-			slog.Bool("is_new", isNew))
-
-		// Record ingestion latency
-		metrics.ObserveIngestLatency(time.Since(start).Seconds())
-
-		// Update sequence after successful processing
-		if msg != nil && msg.TimeUS > 0 {
-			if err := sequenceTracker.UpdateSequence(appCtx, msg.TimeUS); err != nil {
-				logger.Error("failed to update sequence after upsert",
-					slog.String("error", err.Error()))
-			}
-		}
-
-		return nil
+	// Dead-letter replay is an admin operation; never expose it without a token
+	if internalToken != "" {
+		mux.Handle("/admin/indexer/deadletter/", indexer.InternalAuthMiddleware(internalToken)(indexer.DeadLetterReplayHandler(processor)))
+	} else {
+		logger.Warn("INTERNAL_AUTH_TOKEN not set, dead-letter replay endpoint disabled")
+	}
+	handler := func(messageType int, payload []byte) error {
+		return processor.Handle(appCtx, payload)
 	}
 
 	client, err := indexer.NewClientWithSequenceTracker(config, handler, logger, metrics, sequenceTracker)
@@ -0,0 +1,59 @@
+// Package analytics provides an enforcement layer for analytics responses.
+// Analytics endpoints promise aggregate-only output; Redact makes that promise
+// hold even when new fields are added to the underlying models.
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// StreamAnalyticsFields is the allowlist of aggregate fields that may be
+// returned from stream analytics endpoints.
+var StreamAnalyticsFields = map[string]bool{
+	"id":                             true,
+	"stream_session_id":              true,
+	"peak_concurrent_listeners":      true,
+	"total_unique_participants":      true,
+	"total_join_attempts":            true,
+	"stream_duration_seconds":        true,
+	"engagement_lag_seconds":         true,
+	"avg_listen_duration_seconds":    true,
+	"median_listen_duration_seconds": true,
+	"avg_watch_time_seconds":         true,
+	"median_watch_time_seconds":      true,
+	"geographic_distribution":        true,
+	"computed_at":                    true,
+}
+
+// didMarker matches the start of a JSON string holding a DID, e.g. "did:plc:...".
+var didMarker = []byte(`"did:`)
+
+// Redact serializes v and returns only the top-level fields present in allowed.
+// Any other field, and any allowlisted field whose value contains a DID, is
+// stripped; the names of stripped fields are returned sorted so callers can log them.
+// The result encodes with encoding/json like the original value.
+func Redact(v any, allowed map[string]bool) (map[string]json.RawMessage, []string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal analytics: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, nil, fmt.Errorf("analytics must serialize to a JSON object: %w", err)
+	}
+
+	var stripped []string
+	for name, raw := range fields {
+		if !allowed[name] || bytes.Contains(raw, didMarker) {
+			delete(fields, name)
+			stripped = append(stripped, name)
+		}
+	}
+	sort.Strings(stripped)
+
+	return fields, stripped, nil
+}
@@ -0,0 +1,127 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+func TestRedact_StreamAnalyticsPassThrough(t *testing.T) {
+	lag := 12
+	avg := 300.5
+	input := &stream.Analytics{
+		ID:                       "analytics-1",
+		StreamSessionID:          "session-1",
+		PeakConcurrentListeners:  42,
+		TotalUniqueParticipants:  57,
+		TotalJoinAttempts:        63,
+		StreamDurationSeconds:    3600,
+		EngagementLagSeconds:     &lag,
+		AvgListenDurationSeconds: &avg,
+		GeographicDistribution:   map[string]int{"dr5r": 10, "9q8y": 5},
+		ComputedAt:               time.Now(),
+	}
+
+	fields, stripped, err := Redact(input, StreamAnalyticsFields)
+	if err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+	if len(stripped) != 0 {
+		t.Errorf("expected no stripped fields, got %v", stripped)
+	}
+
+	var peak int
+	if err := json.Unmarshal(fields["peak_concurrent_listeners"], &peak); err != nil || peak != 42 {
+		t.Errorf("peak_concurrent_listeners = %d (err %v), want 42", peak, err)
+	}
+	var geo map[string]int
+	if err := json.Unmarshal(fields["geographic_distribution"], &geo); err != nil || geo["dr5r"] != 10 {
+		t.Errorf("geographic_distribution = %v (err %v), want dr5r=10", geo, err)
+	}
+}
+
+// TestRedact_StreamAnalyticsNeverSerializesDIDs guards the stream.Analytics model:
+// every serialized field must be allowlisted and no DID may appear in the output.
+func TestRedact_StreamAnalyticsNeverSerializesDIDs(t *testing.T) {
+	input := stream.Analytics{GeographicDistribution: map[string]int{}}
+
+	var raw map[string]json.RawMessage
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	for name := range raw {
+		if !StreamAnalyticsFields[name] {
+			t.Errorf("stream.Analytics serializes non-allowlisted field %q; add it to StreamAnalyticsFields only if it is an aggregate", name)
+		}
+	}
+
+	fields, _, err := Redact(input, StreamAnalyticsFields)
+	if err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+	out, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if bytes.Contains(out, didMarker) {
+		t.Errorf("redacted output contains a DID: %s", out)
+	}
+}
+
+func TestRedact_StripsDIDField(t *testing.T) {
+	// Simulates a future model change that accidentally exposes a DID
+	type leakyAnalytics struct {
+		stream.Analytics
+		HostDID string `json:"host_did"`
+	}
+	input := leakyAnalytics{
+		Analytics: stream.Analytics{PeakConcurrentListeners: 7},
+		HostDID:   "did:plc:host123",
+	}
+
+	fields, stripped, err := Redact(input, StreamAnalyticsFields)
+	if err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+	if !reflect.DeepEqual(stripped, []string{"host_did"}) {
+		t.Errorf("stripped = %v, want [host_did]", stripped)
+	}
+	if _, ok := fields["host_did"]; ok {
+		t.Error("expected host_did to be stripped")
+	}
+	if _, ok := fields["peak_concurrent_listeners"]; !ok {
+		t.Error("expected aggregate fields to pass through")
+	}
+}
+
+func TestRedact_StripsAllowlistedFieldContainingDID(t *testing.T) {
+	input := map[string]any{
+		"total_join_attempts":     3,
+		"geographic_distribution": map[string]string{"dr5r": "did:plc:leak"},
+	}
+
+	fields, stripped, err := Redact(input, StreamAnalyticsFields)
+	if err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+	if !reflect.DeepEqual(stripped, []string{"geographic_distribution"}) {
+		t.Errorf("stripped = %v, want [geographic_distribution]", stripped)
+	}
+	if _, ok := fields["total_join_attempts"]; !ok {
+		t.Error("expected total_join_attempts to pass through")
+	}
+}
+
+func TestRedact_NonObject(t *testing.T) {
+	if _, _, err := Redact([]int{1, 2}, StreamAnalyticsFields); err == nil {
+		t.Error("expected error for non-object input")
+	}
+}
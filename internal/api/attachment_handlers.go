@@ -0,0 +1,128 @@
+// Package api provides HTTP handlers for post attachment storage.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/storage"
+	"github.com/onnwee/subcults/internal/upload"
+)
+
+// Attachment presign defaults
+const (
+	DefaultAttachmentMaxSizeBytes = 15 << 20 // 15MB, matches R2_MAX_UPLOAD_SIZE_MB default
+	DefaultAttachmentURLExpiry    = 5 * time.Minute
+)
+
+// PresignAttachmentRequest represents the request body for POST /posts/attachments/presign.
+type PresignAttachmentRequest struct {
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+	// Key optionally re-presigns an existing key; it must lie within the caller's namespace.
+	Key string `json:"key,omitempty"`
+}
+
+// PresignAttachmentResponse represents the response for POST /posts/attachments/presign.
+type PresignAttachmentResponse struct {
+	URL       string    `json:"url"`
+	Key       string    `json:"key"`
+	Method    string    `json:"method"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AttachmentHandlers holds dependencies for attachment storage HTTP handlers.
+type AttachmentHandlers struct {
+	store        storage.Store
+	maxSizeBytes int64
+	urlExpiry    time.Duration
+}
+
+// NewAttachmentHandlers creates a new AttachmentHandlers instance.
+// Non-positive maxSizeBytes or urlExpiry fall back to the package defaults.
+func NewAttachmentHandlers(store storage.Store, maxSizeBytes int64, urlExpiry time.Duration) *AttachmentHandlers {
+	if maxSizeBytes <= 0 {
+		maxSizeBytes = DefaultAttachmentMaxSizeBytes
+	}
+	if urlExpiry <= 0 {
+		urlExpiry = DefaultAttachmentURLExpiry
+	}
+	return &AttachmentHandlers{
+		store:        store,
+		maxSizeBytes: maxSizeBytes,
+		urlExpiry:    urlExpiry,
+	}
+}
+
+// PresignUpload handles POST /posts/attachments/presign - returns a presigned upload URL
+// for an object key scoped to the authenticated DID.
+func (h *AttachmentHandlers) PresignUpload(w http.ResponseWriter, r *http.Request) {
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	var req PresignAttachmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+		return
+	}
+
+	ext, ok := upload.AllowedMIMETypes[req.ContentType]
+	if !ok {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeUnsupportedType)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeUnsupportedType,
+			"Unsupported content type. Allowed types: image/jpeg, image/png, audio/mpeg, audio/wav")
+		return
+	}
+
+	if req.SizeBytes <= 0 || req.SizeBytes > h.maxSizeBytes {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "size_bytes must be positive and within the maximum allowed")
+		return
+	}
+
+	key := req.Key
+	if key == "" {
+		key = storage.NamespaceForDID(userDID) + uuid.New().String() + ext
+	} else if err := storage.ValidateKeyOwnership(userDID, key); err != nil {
+		if errors.Is(err, storage.ErrKeyOutsideNamespace) {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+			WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Key is outside your upload namespace")
+			return
+		}
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid key")
+		return
+	}
+
+	presigned, err := h.store.PresignUpload(r.Context(), key, req.ContentType, req.SizeBytes, h.urlExpiry)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to presign attachment upload", "error", err, "key", key)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to generate upload URL")
+		return
+	}
+
+	response := PresignAttachmentResponse{
+		URL:       presigned.URL,
+		Key:       presigned.Key,
+		Method:    presigned.Method,
+		ExpiresAt: presigned.ExpiresAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		return
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/storage"
+)
+
+// newPresignRequest builds an authenticated presign request.
+func newPresignRequest(t *testing.T, did string, body PresignAttachmentRequest) *http.Request {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/posts/attachments/presign", bytes.NewReader(data))
+	if did != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), did))
+	}
+	return req
+}
+
+// TestPresignAttachment_Success tests presigned URL generation scoped to the caller.
+func TestPresignAttachment_Success(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	handlers := NewAttachmentHandlers(store, 0, 0)
+	did := "did:plc:alice"
+
+	req := newPresignRequest(t, did, PresignAttachmentRequest{ContentType: "image/jpeg", SizeBytes: 1024})
+	w := httptest.NewRecorder()
+	handlers.PresignUpload(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp PresignAttachmentResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if !strings.HasPrefix(resp.Key, storage.NamespaceForDID(did)) {
+		t.Errorf("expected key in caller namespace, got %s", resp.Key)
+	}
+	if !strings.HasSuffix(resp.Key, ".jpg") {
+		t.Errorf("expected .jpg extension, got %s", resp.Key)
+	}
+	if resp.URL == "" || resp.Method != http.MethodPut {
+		t.Errorf("unexpected presigned URL response: %+v", resp)
+	}
+	if resp.ExpiresAt.IsZero() {
+		t.Error("expected expires_at to be set")
+	}
+}
+
+// TestPresignAttachment_KeyOutsideNamespace tests that another DID's key is rejected.
+func TestPresignAttachment_KeyOutsideNamespace(t *testing.T) {
+	handlers := NewAttachmentHandlers(storage.NewInMemoryStore(), 0, 0)
+
+	req := newPresignRequest(t, "did:plc:alice", PresignAttachmentRequest{
+		ContentType: "image/jpeg",
+		SizeBytes:   1024,
+		Key:         storage.NamespaceForDID("did:plc:bob") + "photo.jpg",
+	})
+	w := httptest.NewRecorder()
+	handlers.PresignUpload(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeForbidden {
+		t.Errorf("expected error code %s, got %s", ErrCodeForbidden, errResp.Error.Code)
+	}
+}
+
+// TestPresignAttachment_Validation tests auth, content type and size validation.
+func TestPresignAttachment_Validation(t *testing.T) {
+	handlers := NewAttachmentHandlers(storage.NewInMemoryStore(), 2048, 0)
+
+	tests := []struct {
+		name       string
+		did        string
+		body       PresignAttachmentRequest
+		wantStatus int
+		wantCode   string
+	}{
+		{"unauthenticated", "", PresignAttachmentRequest{ContentType: "image/jpeg", SizeBytes: 1}, http.StatusUnauthorized, ErrCodeAuthFailed},
+		{"unsupported type", "did:plc:alice", PresignAttachmentRequest{ContentType: "text/html", SizeBytes: 1}, http.StatusBadRequest, ErrCodeUnsupportedType},
+		{"too large", "did:plc:alice", PresignAttachmentRequest{ContentType: "image/png", SizeBytes: 4096}, http.StatusBadRequest, ErrCodeValidation},
+		{"traversal key", "did:plc:alice", PresignAttachmentRequest{ContentType: "image/png", SizeBytes: 1, Key: "attachments/did:plc:alice/../x.png"}, http.StatusBadRequest, ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handlers.PresignUpload(w, newPresignRequest(t, tt.did, tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("expected error code %s, got %s", tt.wantCode, errResp.Error.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"time"
+)
+
+// checkNotModified applies "not modified since" semantics to list endpoints.
+// It sets the Last-Modified header from lastModified and, when the request's
+// If-Modified-Since is not older than lastModified, writes 304 Not Modified.
+// Returns true if a 304 was written and the handler should stop.
+//
+// HTTP dates have one-second precision, so lastModified is truncated to the
+// second before comparing. A zero lastModified (no recorded activity) disables
+// the guard and always returns false.
+func checkNotModified(w http.ResponseWriter, r *http.Request, lastModified time.Time) bool {
+	if lastModified.IsZero() {
+		return false
+	}
+
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		// Unparseable dates are ignored per RFC 9110
+		return false
+	}
+	if lastModified.After(since) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// MatureContentHeader is the request header a viewer sets to "true" to opt in
+// to seeing mature scenes. Without it, mature scene content is replaced with a
+// redacted stub carrying only the rating.
+const MatureContentHeader = "X-Mature-Content-Opt-In"
+
+// SceneStubResponse is returned in place of a mature scene's content when the
+// viewer has not opted in.
+type SceneStubResponse struct {
+	ID              string `json:"id"`
+	ContentRating   string `json:"content_rating"`
+	ContentRedacted bool   `json:"content_redacted"`
+}
+
+// newRedactedEventResponse builds the stand-in for an event in mature scene s
+// when the viewer has not opted in: only the event's ID, scene, timing and
+// status are kept.
+func newRedactedEventResponse(event *scene.Event, s *scene.Scene) EventResponse {
+	response := newEventResponse(&scene.Event{
+		ID:          event.ID,
+		SceneID:     event.SceneID,
+		Status:      event.Status,
+		StartsAt:    event.StartsAt,
+		EndsAt:      event.EndsAt,
+		CancelledAt: event.CancelledAt,
+	})
+	response.ContentRating = s.ContentRating
+	response.ContentRedacted = true
+	return response
+}
+
+// validateContentRating validates the content rating.
+func validateContentRating(rating string) string {
+	if rating == "" {
+		return "" // Empty is OK, will default to "general"
+	}
+	if rating != scene.ContentRatingGeneral && rating != scene.ContentRatingMature {
+		return "content_rating must be 'general' or 'mature'"
+	}
+	return ""
+}
+
+// matureContentOptIn reports whether the request carries the mature content opt-in.
+func matureContentOptIn(r *http.Request) bool {
+	optIn, err := strconv.ParseBool(r.Header.Get(MatureContentHeader))
+	return err == nil && optIn
+}
+
+// redactMatureScene reports whether s's content must be withheld from the
+// requester: the scene is mature, the requester is not its owner, and the
+// request has not opted in.
+func redactMatureScene(r *http.Request, s *scene.Scene, requesterDID string) bool {
+	return s.IsMature() && !s.IsOwner(requesterDID) && !matureContentOptIn(r)
+}
+
+// newSceneStubResponse builds the redacted stub for a mature scene.
+func newSceneStubResponse(s *scene.Scene) SceneStubResponse {
+	return SceneStubResponse{
+		ID:              s.ID,
+		ContentRating:   s.ContentRating,
+		ContentRedacted: true,
+	}
+}
@@ -0,0 +1,352 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+const (
+	matureSceneID  = "scene-mature"
+	generalSceneID = "scene-general"
+	matureOwnerDID = "did:plc:mature-owner"
+)
+
+// newContentRatingRepos creates a scene repository with one mature and one
+// general public scene near NYC, and a post repository with a post in each.
+func newContentRatingRepos(t *testing.T) (*scene.InMemorySceneRepository, *post.InMemoryPostRepository) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	postRepo := post.NewInMemoryPostRepository()
+	for _, s := range []*scene.Scene{
+		{ID: matureSceneID, Name: "Afterhours Fetish Night", ContentRating: scene.ContentRatingMature},
+		{ID: generalSceneID, Name: "Afterhours Jazz Night", ContentRating: scene.ContentRatingGeneral},
+	} {
+		s.Description = "Afterhours gathering"
+		s.OwnerDID = matureOwnerDID
+		s.AllowPrecise = true
+		s.PrecisePoint = &scene.Point{Lat: 40.7128, Lng: -74.0060}
+		s.CoarseGeohash = "dr5regw"
+		s.Tags = []string{"afterhours"}
+		s.Visibility = scene.VisibilityPublic
+		if err := sceneRepo.Insert(s); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+
+		sceneID := s.ID
+		if err := postRepo.Create(&post.Post{SceneID: &sceneID, AuthorDID: matureOwnerDID, Text: "Afterhours lineup for " + s.Name}); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+	return sceneRepo, postRepo
+}
+
+// ratedRequest builds a GET request as viewerDID, opting in to mature content if optIn.
+func ratedRequest(target, viewerDID string, optIn bool) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if optIn {
+		req.Header.Set(MatureContentHeader, "true")
+	}
+	if viewerDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), viewerDID))
+	}
+	return req
+}
+
+func TestGetScene_ContentRating(t *testing.T) {
+	sceneRepo, _ := newContentRatingRepos(t)
+	handlers := NewSceneHandlers(sceneRepo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	tests := []struct {
+		name         string
+		sceneID      string
+		viewerDID    string
+		optIn        bool
+		wantRedacted bool
+	}{
+		{name: "mature without opt-in", sceneID: matureSceneID, viewerDID: "did:plc:viewer", wantRedacted: true},
+		{name: "mature anonymous without opt-in", sceneID: matureSceneID, wantRedacted: true},
+		{name: "mature with opt-in", sceneID: matureSceneID, viewerDID: "did:plc:viewer", optIn: true},
+		{name: "mature viewed by owner", sceneID: matureSceneID, viewerDID: matureOwnerDID},
+		{name: "general without opt-in", sceneID: generalSceneID, viewerDID: "did:plc:viewer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handlers.GetScene(w, ratedRequest("/scenes/"+tt.sceneID, tt.viewerDID, tt.optIn))
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if tt.wantRedacted {
+				if body["content_redacted"] != true || body["content_rating"] != scene.ContentRatingMature {
+					t.Errorf("expected redacted mature stub, got %v", body)
+				}
+				for _, field := range []string{"name", "description", "precise_point", "tags", "coarse_geohash"} {
+					if _, ok := body[field]; ok {
+						t.Errorf("stub leaked %q: %v", field, body)
+					}
+				}
+				return
+			}
+			if _, ok := body["content_redacted"]; ok {
+				t.Errorf("expected full scene, got stub: %v", body)
+			}
+			if body["name"] == nil || body["name"] == "" {
+				t.Errorf("expected full scene with a name, got %v", body)
+			}
+		})
+	}
+}
+
+func TestGetSceneFeed_MatureSceneRequiresOptIn(t *testing.T) {
+	sceneRepo, postRepo := newContentRatingRepos(t)
+	handlers := NewPostHandlers(postRepo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil)
+
+	feed := func(sceneID string, optIn bool) FeedResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handlers.GetSceneFeed(w, ratedRequest("/scenes/"+sceneID+"/feed", "did:plc:viewer", optIn))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response FeedResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	if stub := feed(matureSceneID, false); !stub.ContentRedacted || stub.ContentRating != scene.ContentRatingMature || len(stub.Posts) != 0 {
+		t.Errorf("expected redacted empty feed for mature scene, got %+v", stub)
+	}
+	if full := feed(matureSceneID, true); full.ContentRedacted || len(full.Posts) != 1 {
+		t.Errorf("expected 1 post with opt-in, got %+v", full)
+	}
+	if general := feed(generalSceneID, false); general.ContentRedacted || len(general.Posts) != 1 {
+		t.Errorf("expected general feed unaffected, got %+v", general)
+	}
+}
+
+func TestSearch_MatureScenesRedactedWithoutOptIn(t *testing.T) {
+	sceneRepo, postRepo := newContentRatingRepos(t)
+	handlers := NewSearchHandlers(sceneRepo, postRepo, nil, scene.NewInMemoryEventRepository())
+
+	for _, optIn := range []bool{false, true} {
+		w := httptest.NewRecorder()
+		handlers.SearchScenes(w, ratedRequest("/search/scenes?q=afterhours&bbox=-74.1,40.6,-73.9,40.8", "", optIn))
+		if w.Code != http.StatusOK {
+			t.Fatalf("SearchScenes: expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var scenes SceneSearchResponse
+		if err := json.NewDecoder(w.Body).Decode(&scenes); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if scenes.Count != 2 {
+			t.Fatalf("opt-in=%v: expected 2 scenes, got %d", optIn, scenes.Count)
+		}
+		for _, result := range scenes.Results {
+			redacted := result.ID == matureSceneID && !optIn
+			if result.ContentRedacted != redacted || (result.Name == "") != redacted || (result.JitteredPoint == nil) != redacted {
+				t.Errorf("opt-in=%v: scene %s = %+v, want redacted=%v", optIn, result.ID, result, redacted)
+			}
+		}
+
+		w = httptest.NewRecorder()
+		handlers.SearchPosts(w, ratedRequest("/search/posts?q=afterhours", "", optIn))
+		if w.Code != http.StatusOK {
+			t.Fatalf("SearchPosts: expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var posts PostSearchResponse
+		if err := json.NewDecoder(w.Body).Decode(&posts); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if posts.Count != 2 {
+			t.Fatalf("opt-in=%v: expected 2 posts, got %d", optIn, posts.Count)
+		}
+		for _, result := range posts.Results {
+			redacted := *result.SceneID == matureSceneID && !optIn
+			if result.ContentRedacted != redacted || (result.Excerpt == "") != redacted {
+				t.Errorf("opt-in=%v: post in %s = %+v, want redacted=%v", optIn, *result.SceneID, result, redacted)
+			}
+		}
+	}
+}
+
+func TestGetSpotlight_MatureScenesRedactedWithoutOptIn(t *testing.T) {
+	sceneRepo, _ := newContentRatingRepos(t)
+	handlers := NewSceneHandlers(sceneRepo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	for _, optIn := range []bool{false, true} {
+		w := httptest.NewRecorder()
+		handlers.GetSpotlight(w, ratedRequest("/scenes/spotlight?limit=2", "did:plc:viewer", optIn))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if got := w.Header().Get("Vary"); got != MatureContentHeader {
+			t.Errorf("opt-in=%v: Vary = %q, want %s", optIn, got, MatureContentHeader)
+		}
+		var response SpotlightResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Scenes) != 2 {
+			t.Fatalf("opt-in=%v: expected 2 scenes, got %d", optIn, len(response.Scenes))
+		}
+		for _, sc := range response.Scenes {
+			redacted := sc.ID == matureSceneID && !optIn
+			if sc.ContentRedacted != redacted || (sc.Name == "") != redacted || (sc.CoarseGeohash == "") != redacted {
+				t.Errorf("opt-in=%v: scene %s = %+v, want redacted=%v", optIn, sc.ID, sc, redacted)
+			}
+		}
+	}
+}
+
+// newContentRatingEvents adds an event to each content rating test scene,
+// "event-mature" and "event-general", each with a post in its feed.
+func newContentRatingEvents(t *testing.T, postRepo *post.InMemoryPostRepository) *scene.InMemoryEventRepository {
+	t.Helper()
+	eventRepo := scene.NewInMemoryEventRepository()
+	for eventID, sceneID := range map[string]string{"event-mature": matureSceneID, "event-general": generalSceneID} {
+		if err := eventRepo.Insert(&scene.Event{
+			ID:            eventID,
+			SceneID:       sceneID,
+			Title:         "Afterhours Party",
+			Description:   "Afterhours lineup",
+			AllowPrecise:  true,
+			PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060},
+			CoarseGeohash: "dr5regw",
+			StartsAt:      time.Now().Add(24 * time.Hour),
+		}); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+		id := eventID
+		if err := postRepo.Create(&post.Post{EventID: &id, AuthorDID: matureOwnerDID, Text: "See you there"}); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+	return eventRepo
+}
+
+func TestEvents_MatureSceneRequiresOptIn(t *testing.T) {
+	sceneRepo, postRepo := newContentRatingRepos(t)
+	eventRepo := newContentRatingEvents(t, postRepo)
+	eventHandlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	postHandlers := NewPostHandlers(postRepo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil)
+	postHandlers.SetBlockRepo(nil, eventRepo)
+
+	for _, optIn := range []bool{false, true} {
+		for _, eventID := range []string{"event-mature", "event-general"} {
+			redacted := eventID == "event-mature" && !optIn
+			wantVary := ""
+			if eventID == "event-mature" {
+				wantVary = MatureContentHeader
+			}
+
+			w := httptest.NewRecorder()
+			eventHandlers.GetEvent(w, ratedRequest("/events/"+eventID, "did:plc:viewer", optIn))
+			if w.Code != http.StatusOK {
+				t.Fatalf("GetEvent: expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("Vary"); got != wantVary {
+				t.Errorf("GetEvent %s opt-in=%v: Vary = %q, want %q", eventID, optIn, got, wantVary)
+			}
+			var event map[string]interface{}
+			if err := json.NewDecoder(w.Body).Decode(&event); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if (event["content_redacted"] == true) != redacted || (event["title"] == "") != redacted {
+				t.Errorf("GetEvent %s opt-in=%v = %v, want redacted=%v", eventID, optIn, event, redacted)
+			}
+			if _, ok := event["precise_point"]; ok == redacted {
+				t.Errorf("GetEvent %s opt-in=%v: precise_point present=%v, want %v", eventID, optIn, ok, !redacted)
+			}
+
+			w = httptest.NewRecorder()
+			eventHandlers.GetEventCalendar(w, ratedRequest("/events/"+eventID+"/calendar.ics", "did:plc:viewer", optIn))
+			if w.Code != http.StatusOK {
+				t.Fatalf("GetEventCalendar: expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if leaked := strings.Contains(w.Body.String(), "Afterhours"); leaked == redacted {
+				t.Errorf("GetEventCalendar %s opt-in=%v: title present=%v, want %v", eventID, optIn, leaked, !redacted)
+			}
+
+			w = httptest.NewRecorder()
+			postHandlers.GetEventFeed(w, ratedRequest("/events/"+eventID+"/feed", "did:plc:viewer", optIn))
+			if w.Code != http.StatusOK {
+				t.Fatalf("GetEventFeed: expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("Vary"); got != wantVary {
+				t.Errorf("GetEventFeed %s opt-in=%v: Vary = %q, want %q", eventID, optIn, got, wantVary)
+			}
+			var feed FeedResponse
+			if err := json.NewDecoder(w.Body).Decode(&feed); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if feed.ContentRedacted != redacted || (len(feed.Posts) == 0) != redacted {
+				t.Errorf("GetEventFeed %s opt-in=%v = %+v, want redacted=%v", eventID, optIn, feed, redacted)
+			}
+		}
+
+		from := time.Now().Format(time.RFC3339)
+		to := time.Now().Add(48 * time.Hour).Format(time.RFC3339)
+		w := httptest.NewRecorder()
+		eventHandlers.SearchEvents(w, ratedRequest("/search/events?bbox=-74.1,40.6,-73.9,40.8&from="+url.QueryEscape(from)+"&to="+url.QueryEscape(to), "", optIn))
+		if w.Code != http.StatusOK {
+			t.Fatalf("SearchEvents: expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if got := w.Header().Get("Vary"); got != MatureContentHeader {
+			t.Errorf("SearchEvents opt-in=%v: Vary = %q, want %s", optIn, got, MatureContentHeader)
+		}
+		var search SearchEventsResponse
+		if err := json.NewDecoder(w.Body).Decode(&search); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(search.Events) != 2 {
+			t.Fatalf("SearchEvents opt-in=%v: expected 2 events, got %d", optIn, len(search.Events))
+		}
+		for _, result := range search.Events {
+			redacted := result.ID == "event-mature" && !optIn
+			if result.ContentRedacted != redacted || (result.Title == "") != redacted || (result.Scene.Name == "") != redacted {
+				t.Errorf("SearchEvents opt-in=%v: event %s = %+v, want redacted=%v", optIn, result.ID, result, redacted)
+			}
+		}
+	}
+}
+
+func TestCreateScene_InvalidContentRating(t *testing.T) {
+	handlers := NewSceneHandlers(scene.NewInMemorySceneRepository(), membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	body, err := json.Marshal(CreateSceneRequest{
+		Name:          "Rated Scene",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		ContentRating: "explicit",
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	handlers.CreateScene(w, httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
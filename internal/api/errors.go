@@ -74,6 +74,21 @@ const (
 
 	// ErrCodePaymentNotFound indicates the payment record was not found.
 	ErrCodePaymentNotFound = "payment_not_found"
+
+	// ErrCodeGone indicates the endpoint existed but has been removed.
+	ErrCodeGone = "gone"
+
+	// ErrCodeEventTimeLocked indicates an attempt to change an event's times after it started.
+	ErrCodeEventTimeLocked = "event_time_locked"
+
+	// ErrCodeEventEditWindowClosed indicates an attempt to edit an event after its post-start grace period.
+	ErrCodeEventEditWindowClosed = "event_edit_window_closed"
+
+	// ErrCodeInvalidGeohash indicates a geohash that is too long or contains invalid characters.
+	ErrCodeInvalidGeohash = "invalid_geohash"
+
+	// ErrCodeStreamSubscribersFull indicates a stream already has the maximum number of real-time subscribers.
+	ErrCodeStreamSubscribersFull = "stream_subscribers_full"
 )
 
 // ErrorResponse represents the standard error response format.
@@ -86,6 +101,9 @@ type ErrorResponse struct {
 type ErrorDetail struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+
+	// Replacement points to the endpoint superseding a removed one (ErrCodeGone only).
+	Replacement string `json:"replacement,omitempty"`
 }
 
 // WriteError writes a standardized JSON error response.
@@ -109,16 +127,16 @@ type ErrorDetail struct {
 //	    api.WriteError(w, ctx, http.StatusNotFound, api.ErrCodeNotFound, "Scene not found")
 //	}
 func WriteError(w http.ResponseWriter, ctx context.Context, status int, code, message string) {
+	writeErrorDetail(w, ctx, status, ErrorDetail{Code: code, Message: message})
+}
+
+// writeErrorDetail writes detail in the standard error envelope.
+func writeErrorDetail(w http.ResponseWriter, ctx context.Context, status int, detail ErrorDetail) {
 	// Update the context in the response writer if supported (for logging middleware)
 	middleware.UpdateResponseContext(w, ctx)
 
 	// Create error response
-	errResp := ErrorResponse{
-		Error: ErrorDetail{
-			Code:    code,
-			Message: message,
-		},
-	}
+	errResp := ErrorResponse{Error: detail}
 
 	// Marshal to JSON
 	data, err := json.Marshal(errResp)
@@ -155,6 +173,8 @@ func StatusCodeMapping(code string) int {
 		return http.StatusForbidden
 	case ErrCodeConflict:
 		return http.StatusConflict
+	case ErrCodeGone:
+		return http.StatusGone
 	case ErrCodeBadRequest:
 		return http.StatusBadRequest
 	case ErrCodeInternal:
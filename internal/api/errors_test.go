@@ -233,6 +233,7 @@ func TestStatusCodeMapping(t *testing.T) {
 		{ErrCodeRateLimited, http.StatusTooManyRequests},
 		{ErrCodeForbidden, http.StatusForbidden},
 		{ErrCodeConflict, http.StatusConflict},
+		{ErrCodeGone, http.StatusGone},
 		{ErrCodeBadRequest, http.StatusBadRequest},
 		{ErrCodeInternal, http.StatusInternalServerError},
 		{"unknown_code", http.StatusInternalServerError}, // default
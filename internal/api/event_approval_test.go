@@ -0,0 +1,297 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+const (
+	approvalOwnerDID  = "did:plc:owner"
+	approvalMemberDID = "did:plc:member"
+)
+
+// newEventApprovalTest creates a public scene that requires event approval,
+// with an active member, and returns handlers wired to it.
+func newEventApprovalTest(t *testing.T) (*EventHandlers, scene.EventRepository, *audit.InMemoryRepository, *scene.Scene) {
+	t.Helper()
+
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	handlers.SetMembershipRepo(membershipRepo)
+
+	s := &scene.Scene{
+		ID:                    "scene-approval",
+		Name:                  "Approval Scene",
+		OwnerDID:              approvalOwnerDID,
+		CoarseGeohash:         "dr5regw",
+		Visibility:            scene.VisibilityPublic,
+		EventApprovalRequired: true,
+	}
+	if err := sceneRepo.Insert(s); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	if _, err := membershipRepo.Upsert(&membership.Membership{
+		SceneID:     s.ID,
+		UserDID:     approvalMemberDID,
+		Role:        "member",
+		Status:      "active",
+		TrustWeight: 0.5,
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	return handlers, eventRepo, auditRepo, s
+}
+
+// doEventRequest runs handler with body as JSON and userDID as the caller.
+func doEventRequest(t *testing.T, handler http.HandlerFunc, method, path, userDID string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to marshal request: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w
+}
+
+// createApprovalEvent creates an event in the approval scene as userDID and
+// returns the decoded response.
+func createApprovalEvent(t *testing.T, h *EventHandlers, sceneID, userDID string) EventResponse {
+	t.Helper()
+	w := doEventRequest(t, h.CreateEvent, http.MethodPost, "/events", userDID, CreateEventRequest{
+		SceneID:       sceneID,
+		Title:         "Warehouse Rave",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: 40.7, Lng: -74.0},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp EventResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+// listedEventIDs returns the IDs of the scene's events as seen by userDID.
+func listedEventIDs(t *testing.T, h *EventHandlers, sceneID, userDID string) []string {
+	t.Helper()
+	w := doEventRequest(t, h.ListSceneEvents, http.MethodGet, "/scenes/"+sceneID+"/events", userDID, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SceneEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	ids := make([]string, len(resp.Events))
+	for i, e := range resp.Events {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// searchableEventIDs returns the IDs of the scene's events found by discovery search.
+func searchableEventIDs(t *testing.T, eventRepo scene.EventRepository, sceneID string) []string {
+	t.Helper()
+	events, _, err := eventRepo.SearchEvents(scene.EventSearchOptions{
+		MinLng: -180, MinLat: -90, MaxLng: 180, MaxLat: 90,
+		From:    time.Now(),
+		To:      time.Now().Add(48 * time.Hour),
+		SceneID: sceneID,
+		Limit:   10,
+	})
+	if err != nil {
+		t.Fatalf("SearchEvents() error = %v", err)
+	}
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+func assertReviewAudit(t *testing.T, auditRepo *audit.InMemoryRepository, eventID, wantAction string) {
+	t.Helper()
+	logs, err := auditRepo.QueryByEntity("event", eventID, 0)
+	if err != nil {
+		t.Fatalf("failed to get audit logs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Action != wantAction || logs[0].UserDID != approvalOwnerDID {
+		t.Errorf("audit logs = %+v, want one %s entry by the owner", logs, wantAction)
+	}
+}
+
+func TestEventApproval_MemberEventPending(t *testing.T) {
+	h, eventRepo, _, s := newEventApprovalTest(t)
+
+	created := createApprovalEvent(t, h, s.ID, approvalMemberDID)
+	if created.Status != scene.EventStatusPendingApproval {
+		t.Errorf("status = %q, want %q", created.Status, scene.EventStatusPendingApproval)
+	}
+	if created.ProposedByDID == nil || *created.ProposedByDID != approvalMemberDID {
+		t.Errorf("proposed_by_did = %v, want %s", created.ProposedByDID, approvalMemberDID)
+	}
+
+	// Excluded from discovery
+	if ids := searchableEventIDs(t, eventRepo, s.ID); len(ids) != 0 {
+		t.Errorf("search returned %v, want pending event excluded", ids)
+	}
+	if ids := listedEventIDs(t, h, s.ID, ""); len(ids) != 0 {
+		t.Errorf("anonymous listing = %v, want pending event hidden", ids)
+	}
+
+	// Visible to the owner and the proposer only
+	for _, tt := range []struct {
+		userDID    string
+		wantStatus int
+	}{
+		{"", http.StatusNotFound},
+		{"did:plc:stranger", http.StatusNotFound},
+		{approvalMemberDID, http.StatusOK},
+		{approvalOwnerDID, http.StatusOK},
+	} {
+		if w := doEventRequest(t, h.GetEvent, http.MethodGet, "/events/"+created.ID, tt.userDID, nil); w.Code != tt.wantStatus {
+			t.Errorf("GetEvent as %q: expected status %d, got %d", tt.userDID, tt.wantStatus, w.Code)
+		}
+	}
+	if ids := listedEventIDs(t, h, s.ID, approvalOwnerDID); len(ids) != 1 {
+		t.Errorf("owner listing = %v, want the pending event", ids)
+	}
+}
+
+func TestEventApproval_OwnerApproves(t *testing.T) {
+	h, eventRepo, auditRepo, s := newEventApprovalTest(t)
+	created := createApprovalEvent(t, h, s.ID, approvalMemberDID)
+
+	// Only the owner may review
+	if w := doEventRequest(t, h.ApproveEvent, http.MethodPost, "/events/"+created.ID+"/approve", approvalMemberDID, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("member approve: expected status 403, got %d", w.Code)
+	}
+
+	w := doEventRequest(t, h.ApproveEvent, http.MethodPost, "/events/"+created.ID+"/approve", approvalOwnerDID, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp EventResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "scheduled" {
+		t.Errorf("status = %q, want scheduled", resp.Status)
+	}
+
+	if ids := searchableEventIDs(t, eventRepo, s.ID); len(ids) != 1 || ids[0] != created.ID {
+		t.Errorf("search returned %v, want the approved event", ids)
+	}
+	if ids := listedEventIDs(t, h, s.ID, ""); len(ids) != 1 {
+		t.Errorf("anonymous listing = %v, want the approved event", ids)
+	}
+	assertReviewAudit(t, auditRepo, created.ID, "event_approve")
+
+	// Already reviewed
+	if w := doEventRequest(t, h.ApproveEvent, http.MethodPost, "/events/"+created.ID+"/approve", approvalOwnerDID, nil); w.Code != http.StatusConflict {
+		t.Errorf("second approve: expected status 409, got %d", w.Code)
+	}
+}
+
+func TestEventApproval_OwnerRejects(t *testing.T) {
+	h, eventRepo, auditRepo, s := newEventApprovalTest(t)
+	created := createApprovalEvent(t, h, s.ID, approvalMemberDID)
+
+	reason := "Clashes with our anniversary show"
+	w := doEventRequest(t, h.RejectEvent, http.MethodPost, "/events/"+created.ID+"/reject", approvalOwnerDID, RejectEventRequest{Reason: &reason})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp EventResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != scene.EventStatusRejected {
+		t.Errorf("status = %q, want %q", resp.Status, scene.EventStatusRejected)
+	}
+	if resp.RejectionReason == nil || *resp.RejectionReason != reason {
+		t.Errorf("rejection_reason = %v, want %q", resp.RejectionReason, reason)
+	}
+
+	if ids := searchableEventIDs(t, eventRepo, s.ID); len(ids) != 0 {
+		t.Errorf("search returned %v, want rejected event excluded", ids)
+	}
+	if w := doEventRequest(t, h.GetEvent, http.MethodGet, "/events/"+created.ID, approvalMemberDID, nil); w.Code != http.StatusOK {
+		t.Errorf("proposer GetEvent: expected status 200, got %d", w.Code)
+	}
+	assertReviewAudit(t, auditRepo, created.ID, "event_reject")
+
+	if w := doEventRequest(t, h.ApproveEvent, http.MethodPost, "/events/"+created.ID+"/approve", approvalOwnerDID, nil); w.Code != http.StatusConflict {
+		t.Errorf("approve after reject: expected status 409, got %d", w.Code)
+	}
+}
+
+func TestEventApproval_OwnerBypassesApproval(t *testing.T) {
+	h, eventRepo, _, s := newEventApprovalTest(t)
+
+	created := createApprovalEvent(t, h, s.ID, approvalOwnerDID)
+	if created.Status != "scheduled" {
+		t.Errorf("status = %q, want scheduled", created.Status)
+	}
+	if created.ProposedByDID != nil {
+		t.Errorf("proposed_by_did = %q, want unset for owner events", *created.ProposedByDID)
+	}
+	if ids := searchableEventIDs(t, eventRepo, s.ID); len(ids) != 1 {
+		t.Errorf("search returned %v, want the owner's event", ids)
+	}
+	if w := doEventRequest(t, h.ApproveEvent, http.MethodPost, "/events/"+created.ID+"/approve", approvalOwnerDID, nil); w.Code != http.StatusConflict {
+		t.Errorf("approve published event: expected status 409, got %d", w.Code)
+	}
+}
+
+func TestEventApproval_ProposalNotAllowed(t *testing.T) {
+	h, _, _, s := newEventApprovalTest(t)
+
+	request := CreateEventRequest{
+		SceneID:       s.ID,
+		Title:         "Warehouse Rave",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}
+	if w := doEventRequest(t, h.CreateEvent, http.MethodPost, "/events", "did:plc:stranger", request); w.Code != http.StatusForbidden {
+		t.Errorf("non-member: expected status 403, got %d", w.Code)
+	}
+
+	// Members cannot create events when the scene does not require approval
+	s.EventApprovalRequired = false
+	if err := h.sceneRepo.Update(s); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+	if w := doEventRequest(t, h.CreateEvent, http.MethodPost, "/events", approvalMemberDID, request); w.Code != http.StatusForbidden {
+		t.Errorf("member without approval workflow: expected status 403, got %d", w.Code)
+	}
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// icsTimeFormat is the RFC 5545 UTC date-time format.
+const icsTimeFormat = "20060102T150405Z"
+
+// icsMaxLineOctets is the longest content line RFC 5545 allows before folding.
+const icsMaxLineOctets = 75
+
+// GetEventCalendar handles GET /events/{id}/calendar.ics - exports an event as
+// an iCalendar file with a single VEVENT, so users can add it to their own
+// calendars. Recurring events carry their RRULE and cancelled events are
+// exported with STATUS:CANCELLED. The precise point is only included, as GEO,
+// when the event allows it; otherwise the coarse geohash is the only location.
+// Unpublished events are visible to the same callers as GET /events/{id}, and
+// events in mature scenes export only their timing and status until the caller
+// opts in.
+func (h *EventHandlers) GetEventCalendar(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "calendar.ics" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
+		return
+	}
+	eventID := pathParts[0]
+
+	foundEvent, parentScene := h.getVisibleEvent(w, r, eventID)
+	if foundEvent == nil {
+		return
+	}
+	if parentScene.IsMature() {
+		w.Header().Add("Vary", MatureContentHeader)
+	}
+	if redactMatureScene(r, parentScene, middleware.GetUserDID(r.Context())) {
+		foundEvent = newRedactedEventResponse(foundEvent, parentScene).Event
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"event-%s.ics\"", eventID))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(eventICS(foundEvent, h.timeNow()))); err != nil {
+		slog.ErrorContext(r.Context(), "failed to write event calendar", "error", err, "event_id", eventID)
+	}
+}
+
+// getVisibleEvent loads an event for a read-only sub-resource, applying the
+// same visibility rules as GET /events/{id}: the caller must be able to see the
+// event's scene, and unpublished events are only visible to the scene owner
+// and the proposer. Writes the error response and returns nil when the event
+// is missing or hidden from the caller. The event's scene is returned with it.
+func (h *EventHandlers) getVisibleEvent(w http.ResponseWriter, r *http.Request, eventID string) (*scene.Event, *scene.Scene) {
+	foundEvent, err := h.eventRepo.GetByID(eventID)
+	if err != nil {
+		if err == scene.ErrEventNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+			return nil, nil
+		}
+		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		return nil, nil
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	parentScene, err := h.sceneRepo.GetByID(foundEvent.SceneID)
+	if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+		slog.ErrorContext(r.Context(), "failed to get scene", "error", err, "scene_id", foundEvent.SceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		return nil, nil
+	}
+	visible := false
+	if err == nil {
+		visible, err = sceneVisibleTo(h.membershipRepo, parentScene, userDID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", parentScene.ID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check access permissions")
+			return nil, nil
+		}
+	}
+	// Use uniform error message - same as "not found" to prevent enumeration
+	if !visible || (!foundEvent.IsPublished() && !canSeeUnpublished(foundEvent, parentScene, userDID)) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+		return nil, nil
+	}
+	return foundEvent, parentScene
+}
+
+// eventICS renders event as an iCalendar (RFC 5545) document stamped at now.
+func eventICS(event *scene.Event, now time.Time) string {
+	var b strings.Builder
+	writeLine := func(name, value string) {
+		b.WriteString(foldICSLine(name + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN", "VCALENDAR")
+	writeLine("VERSION", "2.0")
+	writeLine("PRODID", "-//Subcults//Events//EN")
+	writeLine("CALSCALE", "GREGORIAN")
+	writeLine("METHOD", "PUBLISH")
+	writeLine("BEGIN", "VEVENT")
+	writeLine("UID", event.ID+"@subcults")
+	writeLine("DTSTAMP", now.UTC().Format(icsTimeFormat))
+	writeLine("DTSTART", event.StartsAt.UTC().Format(icsTimeFormat))
+	if event.EndsAt != nil {
+		writeLine("DTEND", event.EndsAt.UTC().Format(icsTimeFormat))
+	}
+	if event.RRule != nil {
+		writeLine("RRULE", strings.TrimPrefix(*event.RRule, "RRULE:"))
+	}
+	writeLine("SUMMARY", escapeICSText(event.Title))
+	if event.Description != "" {
+		writeLine("DESCRIPTION", escapeICSText(event.Description))
+	}
+	if event.CoarseGeohash != "" {
+		writeLine("LOCATION", escapeICSText(event.CoarseGeohash))
+	}
+	// Only disclose the precise point with the host's consent
+	if event.AllowPrecise && event.PrecisePoint != nil {
+		writeLine("GEO", strconv.FormatFloat(event.PrecisePoint.Lat, 'f', -1, 64)+";"+strconv.FormatFloat(event.PrecisePoint.Lng, 'f', -1, 64))
+	}
+	writeLine("STATUS", icsStatus(event))
+	if event.UpdatedAt != nil {
+		writeLine("LAST-MODIFIED", event.UpdatedAt.UTC().Format(icsTimeFormat))
+	}
+	writeLine("END", "VEVENT")
+	writeLine("END", "VCALENDAR")
+	return b.String()
+}
+
+// icsStatus maps an event's status to a VEVENT STATUS value. Rejected
+// proposals will not take place, so they export as cancelled.
+func icsStatus(event *scene.Event) string {
+	switch {
+	case event.IsCancelled() || event.Status == scene.EventStatusRejected:
+		return "CANCELLED"
+	case event.Status == scene.EventStatusPendingApproval:
+		return "TENTATIVE"
+	default:
+		return "CONFIRMED"
+	}
+}
+
+// escapeICSText escapes a TEXT value: backslashes, semicolons, commas and
+// newlines.
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// foldICSLine splits a content line longer than icsMaxLineOctets into
+// continuation lines starting with a space, without splitting a UTF-8 sequence.
+func foldICSLine(line string) string {
+	if len(line) <= icsMaxLineOctets {
+		return line
+	}
+	var b strings.Builder
+	limit := icsMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines lose an octet to the leading space
+		limit = icsMaxLineOctets - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+func newCalendarTest(t *testing.T, event *scene.Event) *EventHandlers {
+	t.Helper()
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:host")
+	if err := eventRepo.Insert(event); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	handlers.timeNow = func() time.Time { return time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC) }
+	return handlers
+}
+
+func getEventCalendar(handlers *EventHandlers, eventID, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/events/"+eventID+"/calendar.ics", nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.GetEventCalendar(w, req)
+	return w
+}
+
+func TestGetEventCalendar(t *testing.T) {
+	startsAt := time.Date(2026, 1, 8, 20, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+	endsAt := startsAt.Add(3 * time.Hour)
+	rrule := "FREQ=WEEKLY;BYDAY=TH"
+	handlers := newCalendarTest(t, &scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Noise; Night, Vol. 2",
+		Description:   "Doors at 8\nBring earplugs",
+		CoarseGeohash: "dr5regw",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.006},
+		Status:        "scheduled",
+		StartsAt:      startsAt,
+		EndsAt:        &endsAt,
+		RRule:         &rrule,
+	})
+
+	w := getEventCalendar(handlers, "event-1", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != "text/calendar; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="event-event-1.ics"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+
+	body := w.Body.String()
+	if !strings.HasPrefix(body, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(body, "END:VCALENDAR\r\n") {
+		t.Errorf("expected a CRLF-delimited VCALENDAR, got %q", body)
+	}
+	for _, line := range []string{
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:event-1@subcults",
+		"DTSTAMP:20260101T090000Z",
+		"DTSTART:20260109T010000Z",
+		"DTEND:20260109T040000Z",
+		"RRULE:FREQ=WEEKLY;BYDAY=TH",
+		`SUMMARY:Noise\; Night\, Vol. 2`,
+		`DESCRIPTION:Doors at 8\nBring earplugs`,
+		"LOCATION:dr5regw",
+		"GEO:40.7128;-74.006",
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+	} {
+		if !strings.Contains(body, "\r\n"+line+"\r\n") {
+			t.Errorf("expected line %q in:\n%s", line, body)
+		}
+	}
+}
+
+func TestGetEventCalendar_OmitsPrecisePointWithoutConsent(t *testing.T) {
+	handlers := newCalendarTest(t, &scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Warehouse Show",
+		CoarseGeohash: "dr5regw",
+		AllowPrecise:  false,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.006},
+		Status:        "scheduled",
+		StartsAt:      time.Date(2026, 1, 8, 20, 0, 0, 0, time.UTC),
+	})
+
+	body := getEventCalendar(handlers, "event-1", "").Body.String()
+	if strings.Contains(body, "GEO:") || strings.Contains(body, "40.7128") {
+		t.Errorf("expected no precise location, got:\n%s", body)
+	}
+	if !strings.Contains(body, "\r\nLOCATION:dr5regw\r\n") {
+		t.Errorf("expected the coarse location, got:\n%s", body)
+	}
+	if strings.Contains(body, "DTEND:") || strings.Contains(body, "RRULE:") {
+		t.Errorf("expected no DTEND or RRULE, got:\n%s", body)
+	}
+}
+
+func TestGetEventCalendar_Cancelled(t *testing.T) {
+	cancelledAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	handlers := newCalendarTest(t, &scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Warehouse Show",
+		CoarseGeohash: "dr5regw",
+		Status:        "cancelled",
+		CancelledAt:   &cancelledAt,
+		StartsAt:      time.Date(2026, 1, 8, 20, 0, 0, 0, time.UTC),
+	})
+
+	if body := getEventCalendar(handlers, "event-1", "").Body.String(); !strings.Contains(body, "\r\nSTATUS:CANCELLED\r\n") {
+		t.Errorf("expected STATUS:CANCELLED, got:\n%s", body)
+	}
+}
+
+func TestGetEventCalendar_Visibility(t *testing.T) {
+	proposer := "did:plc:member"
+	handlers := newCalendarTest(t, &scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Proposed Show",
+		CoarseGeohash: "dr5regw",
+		Status:        scene.EventStatusPendingApproval,
+		ProposedByDID: &proposer,
+		StartsAt:      time.Date(2026, 1, 8, 20, 0, 0, 0, time.UTC),
+	})
+
+	assertErrorResponse(t, getEventCalendar(handlers, "event-1", "did:plc:stranger"), http.StatusNotFound, ErrCodeNotFound)
+	assertErrorResponse(t, getEventCalendar(handlers, "missing", ""), http.StatusNotFound, ErrCodeNotFound)
+
+	w := getEventCalendar(handlers, "event-1", proposer)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for the proposer, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "\r\nSTATUS:TENTATIVE\r\n") {
+		t.Errorf("expected STATUS:TENTATIVE, got:\n%s", w.Body.String())
+	}
+}
+
+func TestGetEventCalendar_HiddenScene(t *testing.T) {
+	handlers := newCalendarTest(t, &scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Secret Show",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Date(2026, 1, 8, 20, 0, 0, 0, time.UTC),
+	})
+	hidden, err := handlers.sceneRepo.GetByID("scene-1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	hidden.Visibility = scene.VisibilityHidden
+	if err := handlers.sceneRepo.Update(hidden); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	assertErrorResponse(t, getEventCalendar(handlers, "event-1", ""), http.StatusNotFound, ErrCodeNotFound)
+	assertErrorResponse(t, getEventCalendar(handlers, "event-1", "did:plc:stranger"), http.StatusNotFound, ErrCodeNotFound)
+	if w := getEventCalendar(handlers, "event-1", "did:plc:host"); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for the scene owner, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 100)
+	folded := foldICSLine(line)
+
+	parts := strings.Split(folded, "\r\n")
+	if len(parts) < 2 {
+		t.Fatalf("expected the line to be folded, got %q", folded)
+	}
+	for i, part := range parts {
+		if len(part) > icsMaxLineOctets {
+			t.Errorf("line %d is %d octets, want at most %d", i, len(part), icsMaxLineOctets)
+		}
+		if i > 0 && !strings.HasPrefix(part, " ") {
+			t.Errorf("continuation line %d does not start with a space", i)
+		}
+		if !utf8.ValidString(part) {
+			t.Errorf("line %d splits a UTF-8 sequence", i)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(folded, "\r\n ", "")
+	if unfolded != line {
+		t.Errorf("unfolding did not restore the line")
+	}
+	if short := "SUMMARY:Show"; foldICSLine(short) != short {
+		t.Errorf("expected a short line to be unchanged")
+	}
+}
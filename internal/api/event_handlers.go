@@ -4,6 +4,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -14,8 +15,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/jsontime"
+	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/slo"
 	"github.com/onnwee/subcults/internal/stream"
 	"github.com/onnwee/subcults/internal/validate"
 )
@@ -31,6 +36,9 @@ type CreateEventRequest struct {
 	Tags          []string     `json:"tags,omitempty"`
 	StartsAt      time.Time    `json:"starts_at"`
 	EndsAt        *time.Time   `json:"ends_at,omitempty"`
+	SeriesID      *string      `json:"series_id,omitempty"` // Optional recurring series to join
+	RRule         *string      `json:"rrule,omitempty"`     // Optional recurrence rule, see scene.ParseRRule
+	Capacity      *int         `json:"capacity,omitempty"`  // Optional cap on going RSVPs; 0 means unlimited
 }
 
 // UpdateEventRequest represents the request body for updating an event.
@@ -44,6 +52,50 @@ type UpdateEventRequest struct {
 	CoarseGeohash *string      `json:"coarse_geohash,omitempty"`
 	StartsAt      *time.Time   `json:"starts_at,omitempty"`
 	EndsAt        *time.Time   `json:"ends_at,omitempty"`
+	Capacity      *int         `json:"capacity,omitempty"` // 0 removes the cap
+}
+
+// SetPinnedEventRequest represents the request body for pinning a scene's event.
+type SetPinnedEventRequest struct {
+	EventID string `json:"event_id"`
+}
+
+// SceneEventsResponse is a scene's event listing. When the scene has a pinned
+// event that is neither cancelled nor deleted, PinnedEventID is set and that
+// event comes first; the rest follow in starts_at order.
+type SceneEventsResponse struct {
+	PinnedEventID   *string         `json:"pinned_event_id"`
+	Events          []EventResponse `json:"events"`
+	ContentRating   string          `json:"content_rating,omitempty"`   // Set when events were withheld from a mature scene
+	ContentRedacted bool            `json:"content_redacted,omitempty"` // True when the viewer has not opted in to mature content
+}
+
+// RSVP trend bucket sizes accepted by GET /scenes/{id}/rsvp_trends.
+const (
+	RSVPTrendBucketDay   = "day"
+	RSVPTrendBucketWeek  = "week"
+	RSVPTrendBucketMonth = "month"
+)
+
+// MaxRSVPTrendsWindow is the longest from/to range GetSceneRSVPTrends accepts.
+const MaxRSVPTrendsWindow = 366 * 24 * time.Hour
+
+// RSVPTrendBucket holds RSVP counts summed over the events starting within one bucket.
+type RSVPTrendBucket struct {
+	Start  time.Time `json:"start"`  // Bucket start, UTC; weeks start on Monday
+	Events int       `json:"events"` // Events starting in the bucket
+	Going  int       `json:"going"`
+	Maybe  int       `json:"maybe"`
+}
+
+// RSVPTrendsResponse is the response for GET /scenes/{id}/rsvp_trends. Buckets
+// cover the whole from/to range in order, including buckets with no events.
+type RSVPTrendsResponse struct {
+	SceneID string            `json:"scene_id"`
+	From    time.Time         `json:"from"`
+	To      time.Time         `json:"to"`
+	Bucket  string            `json:"bucket"`
+	Buckets []RSVPTrendBucket `json:"buckets"`
 }
 
 // CancelEventRequest represents the request body for cancelling an event.
@@ -51,6 +103,11 @@ type CancelEventRequest struct {
 	Reason *string `json:"reason,omitempty"`
 }
 
+// RejectEventRequest represents the request body for rejecting a proposed event.
+type RejectEventRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
 // EventHandlers holds dependencies for event HTTP handlers.
 type EventHandlers struct {
 	eventRepo       scene.EventRepository
@@ -58,9 +115,39 @@ type EventHandlers struct {
 	auditRepo       audit.Repository
 	rsvpRepo        scene.RSVPRepository
 	streamRepo      stream.SessionRepository
-	trustScoreStore TrustScoreStore // Optional, can be nil
+	trustScoreStore TrustScoreStore                 // Optional, can be nil
+	latencySLO      *slo.Tracker                    // Optional; records search latency against its SLO
+	membershipRepo  membership.MembershipRepository // Optional; lets members propose events for approval
+	blockRepo       scene.BlockRepository           // Optional; blocked DIDs lose posting and RSVP permissions
+
+	maxScheduleHorizon time.Duration    // Latest start time accepted, relative to now
+	editGracePeriod    time.Duration    // How long after start non-time fields stay editable
+	maxTags            int              // Most tags an event may carry after deduplication
+	maxDescLength      int              // Longest description accepted, in characters
+	timeNow            func() time.Time // For testability
 }
 
+// DefaultMaxScheduleHorizon is how far in the future an event may start unless
+// overridden with SetMaxScheduleHorizon.
+const DefaultMaxScheduleHorizon = 365 * 24 * time.Hour
+
+// MaxEventOccurrences is the most upcoming occurrences GET /events/{id} returns
+// for a recurring event.
+const MaxEventOccurrences = 50
+
+// occurrenceLookahead bounds how far ahead GET /events/{id} expands a recurring
+// event. A daily rule yields at most 367 occurrences within it, under
+// scene.MaxExpandedOccurrences.
+const occurrenceLookahead = 366 * 24 * time.Hour
+
+// DefaultEventEditGracePeriod is how long after an event starts its details
+// remain editable unless overridden with SetEditGracePeriod.
+const DefaultEventEditGracePeriod = 30 * time.Minute
+
+// DefaultMaxEventTags is how many tags an event may carry unless overridden
+// with SetMaxTags.
+const DefaultMaxEventTags = 10
+
 // TrustScoreStore defines the interface for retrieving trust scores.
 // This avoids importing the trust package directly.
 type TrustScoreStore interface {
@@ -83,15 +170,108 @@ func NewEventHandlers(eventRepo scene.EventRepository, sceneRepo scene.SceneRepo
 		rsvpRepo:        rsvpRepo,
 		streamRepo:      streamRepo,
 		trustScoreStore: trustScoreStore,
+
+		maxScheduleHorizon: DefaultMaxScheduleHorizon,
+		editGracePeriod:    DefaultEventEditGracePeriod,
+		maxTags:            DefaultMaxEventTags,
+		maxDescLength:      validate.MaxDescriptionLength,
+		timeNow:            time.Now,
+	}
+}
+
+// SetMaxScheduleHorizon overrides how far in the future event start times may be scheduled.
+// Non-positive values are ignored.
+func (h *EventHandlers) SetMaxScheduleHorizon(horizon time.Duration) {
+	if horizon > 0 {
+		h.maxScheduleHorizon = horizon
+	}
+}
+
+// SetEditGracePeriod overrides how long after an event starts its title,
+// description and other non-time fields remain editable. Zero locks events
+// as soon as they start; negative values are ignored.
+func (h *EventHandlers) SetEditGracePeriod(grace time.Duration) {
+	if grace >= 0 {
+		h.editGracePeriod = grace
+	}
+}
+
+// SetMaxTags overrides how many tags an event may carry. Non-positive values
+// are ignored.
+func (h *EventHandlers) SetMaxTags(n int) {
+	if n > 0 {
+		h.maxTags = n
+	}
+}
+
+// SetMaxDescriptionLength overrides the longest event description accepted, in
+// characters. Non-positive values are ignored.
+func (h *EventHandlers) SetMaxDescriptionLength(n int) {
+	if n > 0 {
+		h.maxDescLength = n
+	}
+}
+
+// SetMembershipRepo installs the membership repository used to let active
+// members propose events in scenes that require approval. Without one, only
+// scene owners can create events.
+func (h *EventHandlers) SetMembershipRepo(repo membership.MembershipRepository) {
+	h.membershipRepo = repo
+}
+
+// SetBlockRepo installs the scene block list consulted when reporting a
+// caller's permissions on an event.
+func (h *EventHandlers) SetBlockRepo(repo scene.BlockRepository) {
+	h.blockRepo = repo
+}
+
+// SetSearchLatencySLO installs the tracker that records event search latencies
+// for error budget burn alerting.
+func (h *EventHandlers) SetSearchLatencySLO(tracker *slo.Tracker) {
+	h.latencySLO = tracker
+}
+
+// EventResponse is the JSON representation of an event. Its timestamps shadow
+// the embedded event's so they serialize as RFC3339 UTC with zero values omitted,
+// and Tags shadows the event's so it is always an array.
+type EventResponse struct {
+	*scene.Event
+	Tags        []string      `json:"tags"`
+	StartsAt    jsontime.Time `json:"starts_at"`
+	EndsAt      jsontime.Time `json:"ends_at,omitzero"`
+	CreatedAt   jsontime.Time `json:"created_at,omitzero"`
+	UpdatedAt   jsontime.Time `json:"updated_at,omitzero"`
+	DeletedAt   jsontime.Time `json:"deleted_at,omitzero"`
+	CancelledAt jsontime.Time `json:"cancelled_at,omitzero"`
+	Warnings    []Warning     `json:"warnings,omitempty"` // Soft validation findings on create/update
+
+	// Set when the event's content was withheld because its scene is mature
+	// and the viewer has not opted in
+	ContentRating   string `json:"content_rating,omitempty"`
+	ContentRedacted bool   `json:"content_redacted,omitempty"`
+}
+
+// newEventResponse builds the JSON representation of event.
+func newEventResponse(event *scene.Event) EventResponse {
+	return EventResponse{
+		Event:       event,
+		Tags:        emptyIfNil(event.Tags),
+		StartsAt:    jsontime.New(event.StartsAt),
+		EndsAt:      jsontime.FromPtr(event.EndsAt),
+		CreatedAt:   jsontime.FromPtr(event.CreatedAt),
+		UpdatedAt:   jsontime.FromPtr(event.UpdatedAt),
+		DeletedAt:   jsontime.FromPtr(event.DeletedAt),
+		CancelledAt: jsontime.FromPtr(event.CancelledAt),
 	}
 }
 
 // EventWithRSVPCounts represents an event with aggregated RSVP counts and active stream info.
 type EventWithRSVPCounts struct {
-	*scene.Event
+	EventResponse
 	RSVPCounts   *scene.RSVPCounts        `json:"rsvp_counts"`
 	Scene        *SceneSearchResult       `json:"scene,omitempty"`
 	ActiveStream *stream.ActiveStreamInfo `json:"active_stream,omitempty"`
+	Occurrences  []EventResponse          `json:"occurrences,omitempty"` // Upcoming occurrences of a recurring event, when requested
 }
 
 // sceneBatchFetcher is an optional repository capability for batch scene lookups.
@@ -111,7 +291,7 @@ func toSceneSearchResult(parentScene *scene.Scene) *SceneSearchResult {
 		Name:          parentScene.Name,
 		Description:   parentScene.Description,
 		CoarseGeohash: parentScene.CoarseGeohash,
-		Tags:          parentScene.Tags,
+		Tags:          emptyIfNil(parentScene.Tags),
 		Visibility:    parentScene.Visibility,
 	}
 	if parentScene.PrecisePoint != nil {
@@ -129,13 +309,54 @@ func validateTimeWindow(startsAt time.Time, endsAt *time.Time) string {
 	return ""
 }
 
+// validateScheduleHorizon rejects start times further than horizon from now, so
+// far-future content cannot squat on discovery. The boundary itself is accepted.
+func validateScheduleHorizon(startsAt, now time.Time, horizon time.Duration) string {
+	if startsAt.After(now.Add(horizon)) {
+		return fmt.Sprintf("start time must be within %d days from now", int(horizon.Hours()/24))
+	}
+	return ""
+}
+
 // isSceneOwner checks if the given userDID owns the scene.
 func (h *EventHandlers) isSceneOwner(ctx context.Context, sceneID, userDID string) (bool, error) {
-	foundScene, err := h.sceneRepo.GetByID(sceneID)
+	return scene.CheckOwnership(h.sceneRepo, sceneID, userDID)
+}
+
+// canProposeEvent reports whether a non-owner may propose an event in the
+// scene: the scene must require event approval and the user must be an active
+// member.
+func (h *EventHandlers) canProposeEvent(sceneID, userDID string) (bool, error) {
+	if h.membershipRepo == nil {
+		return false, nil
+	}
+	s, err := h.sceneRepo.GetByID(sceneID)
 	if err != nil {
 		return false, err
 	}
-	return foundScene.IsOwner(userDID), nil
+	if !s.EventApprovalRequired {
+		return false, nil
+	}
+	m, err := h.membershipRepo.GetBySceneAndUser(sceneID, userDID)
+	if err != nil {
+		if errors.Is(err, membership.ErrMembershipNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return m.Status == "active", nil
+}
+
+// canSeeUnpublished reports whether userDID may see an event that is pending
+// approval or was rejected: only the scene owner and the member who proposed it.
+func canSeeUnpublished(event *scene.Event, s *scene.Scene, userDID string) bool {
+	if userDID == "" {
+		return false
+	}
+	if s != nil && s.IsOwner(userDID) {
+		return true
+	}
+	return event.ProposedByDID != nil && *event.ProposedByDID == userDID
 }
 
 // CreateEvent handles POST /events - creates a new event.
@@ -169,6 +390,18 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "coarse_geohash is required")
 		return
 	}
+	if errMsg := validateGeohash("coarse_geohash", req.CoarseGeohash); errMsg != "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidGeohash)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidGeohash, errMsg)
+		return
+	}
+
+	// Validate precise_point
+	if errMsg := validatePrecisePoint(req.PrecisePoint); errMsg != "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+		return
+	}
 
 	// Validate time window
 	if errMsg := validateTimeWindow(req.StartsAt, req.EndsAt); errMsg != "" {
@@ -177,6 +410,36 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Reject start times beyond the scheduling horizon
+	if errMsg := validateScheduleHorizon(req.StartsAt, h.timeNow(), h.maxScheduleHorizon); errMsg != "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+		return
+	}
+
+	// Validate the recurrence rule. A recurring event expands into its own
+	// occurrences, so it cannot also join a stored series.
+	if req.RRule != nil {
+		if req.SeriesID != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "rrule cannot be combined with series_id")
+			return
+		}
+		trimmed := strings.TrimSpace(*req.RRule)
+		rule, err := scene.ParseRRule(trimmed)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+		if rule.Until != nil && rule.Until.Before(req.StartsAt) {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "rrule UNTIL must not be before starts_at")
+			return
+		}
+		req.RRule = &trimmed
+	}
+
 	// Get user DID from context (set by auth middleware)
 	userDID := middleware.GetUserDID(r.Context())
 	if userDID == "" {
@@ -198,14 +461,50 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify scene ownership")
 		return
 	}
+	// Members of scenes that require approval may propose events, which stay
+	// out of discovery until the owner approves them
+	status := "scheduled"
+	var proposedBy *string
 	if !isOwner {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to create events for this scene")
-		return
+		canPropose, err := h.canProposeEvent(req.SceneID, userDID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check scene membership", "error", err, "scene_id", req.SceneID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify scene membership")
+			return
+		}
+		if !canPropose {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+			WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to create events for this scene")
+			return
+		}
+		status = scene.EventStatusPendingApproval
+		proposedBy = &userDID
+	}
+
+	// A series is bound to the scene of its first occurrence
+	if req.SeriesID != nil {
+		if strings.TrimSpace(*req.SeriesID) == "" {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "series_id cannot be empty")
+			return
+		}
+		occurrences, err := h.eventRepo.ListBySeries(*req.SeriesID)
+		if err != nil && err != scene.ErrSeriesNotFound {
+			slog.ErrorContext(r.Context(), "failed to look up series", "error", err, "series_id", *req.SeriesID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to create event")
+			return
+		}
+		if len(occurrences) > 0 && occurrences[0].SceneID != req.SceneID {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "series_id belongs to a different scene")
+			return
+		}
 	}
 
 	// Validate and sanitize description
-	validatedDesc, err := validate.Description(req.Description)
+	validatedDesc, err := validate.DescriptionWithMax(req.Description, h.maxDescLength)
 	if err != nil {
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid description: %v", err))
@@ -213,10 +512,19 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	}
 	req.Description = validatedDesc
 
-	// Sanitize tags to prevent HTML injection
-	sanitizedTags := make([]string, len(req.Tags))
-	for i, tag := range req.Tags {
-		sanitizedTags[i] = validate.SanitizeHTML(tag)
+	// Validate and normalize tags
+	tags, err := validate.Tags(req.Tags, h.maxTags)
+	if err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid tags: %v", err))
+		return
+	}
+
+	capacity, ok := eventCapacity(req.Capacity)
+	if !ok {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "capacity must be a non-negative integer")
+		return
 	}
 
 	// Create event
@@ -229,10 +537,14 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		AllowPrecise:  req.AllowPrecise,
 		PrecisePoint:  req.PrecisePoint,
 		CoarseGeohash: req.CoarseGeohash,
-		Tags:          sanitizedTags,
-		Status:        "scheduled", // Default status
+		Tags:          tags,
+		Status:        status,
 		StartsAt:      req.StartsAt,
 		EndsAt:        req.EndsAt,
+		SeriesID:      req.SeriesID,
+		RRule:         req.RRule,
+		Capacity:      capacity,
+		ProposedByDID: proposedBy,
 		CreatedAt:     &now,
 		UpdatedAt:     &now,
 	}
@@ -255,15 +567,41 @@ func (h *EventHandlers) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Return created event
+	// Return created event with any soft validation warnings
+	response := newEventResponse(stored)
+	response.Warnings = eventWarnings(stored)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(stored); err != nil {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		// Log error but response already started
 		slog.ErrorContext(r.Context(), "failed to encode event response", "error", err)
 	}
 }
 
+// eventAuditFields snapshots the host-editable fields of an event for diffing
+// in the audit log. Times are normalized to RFC 3339 UTC so an unchanged time
+// sent back in another zone doesn't register as a change.
+func eventAuditFields(e *scene.Event) map[string]any {
+	var endsAt, capacity any
+	if e.EndsAt != nil {
+		endsAt = e.EndsAt.UTC().Format(time.RFC3339Nano)
+	}
+	if e.Capacity != nil {
+		capacity = *e.Capacity
+	}
+	return map[string]any{
+		"title":          e.Title,
+		"description":    e.Description,
+		"tags":           e.Tags,
+		"allow_precise":  e.AllowPrecise,
+		"precise_point":  e.PrecisePoint,
+		"coarse_geohash": e.CoarseGeohash,
+		"starts_at":      e.StartsAt.UTC().Format(time.RFC3339Nano),
+		"ends_at":        endsAt,
+		"capacity":       capacity,
+	}
+}
+
 // UpdateEvent handles PATCH /events/{id} - updates an existing event.
 func (h *EventHandlers) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	// Extract event ID from URL path
@@ -296,7 +634,523 @@ func (h *EventHandlers) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get user DID from context (set by auth middleware)
+	// Get user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Check if user is scene owner (authorization)
+	isOwner, err := h.isSceneOwner(r.Context(), existingEvent.SceneID, userDID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to check scene ownership", "error", err, "scene_id", existingEvent.SceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify scene ownership")
+		return
+	}
+	if !isOwner {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to update this event")
+		return
+	}
+
+	// Once an event starts its times are locked; other details stay editable
+	// for a short grace period so hosts can fix typos, then the event is frozen.
+	if startedFor := h.timeNow().Sub(existingEvent.StartsAt); startedFor >= 0 {
+		if startedFor > h.editGracePeriod {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeEventEditWindowClosed)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeEventEditWindowClosed, "Event can no longer be edited after it has started")
+			return
+		}
+		if req.StartsAt != nil || req.EndsAt != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeEventTimeLocked)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeEventTimeLocked, "Cannot change event times after the event has started")
+			return
+		}
+	}
+
+	// Apply updates to existing event
+	updatedEvent := *existingEvent
+
+	if req.Title != nil {
+		// Validate and sanitize title
+		validatedTitle, err := validate.EventTitle(*req.Title)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid event title: %v", err))
+			return
+		}
+		updatedEvent.Title = validatedTitle
+	}
+
+	if req.Description != nil {
+		validatedDesc, err := validate.DescriptionWithMax(*req.Description, h.maxDescLength)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid description: %v", err))
+			return
+		}
+		updatedEvent.Description = validatedDesc
+	}
+
+	if req.Tags != nil {
+		tags, err := validate.Tags(req.Tags, h.maxTags)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid tags: %v", err))
+			return
+		}
+		updatedEvent.Tags = tags
+	}
+
+	if req.AllowPrecise != nil {
+		updatedEvent.AllowPrecise = *req.AllowPrecise
+	}
+
+	if req.PrecisePoint != nil {
+		if errMsg := validatePrecisePoint(req.PrecisePoint); errMsg != "" {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+			return
+		}
+		updatedEvent.PrecisePoint = req.PrecisePoint
+	}
+
+	if req.CoarseGeohash != nil {
+		if strings.TrimSpace(*req.CoarseGeohash) == "" {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "coarse_geohash cannot be empty")
+			return
+		}
+		if errMsg := validateGeohash("coarse_geohash", *req.CoarseGeohash); errMsg != "" {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidGeohash)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidGeohash, errMsg)
+			return
+		}
+		updatedEvent.CoarseGeohash = *req.CoarseGeohash
+	}
+
+	// Lowering the capacity never removes going RSVPs; it only stops new ones
+	// until attendance drops below it
+	if req.Capacity != nil {
+		capacity, ok := eventCapacity(req.Capacity)
+		if !ok {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "capacity must be a non-negative integer")
+			return
+		}
+		updatedEvent.Capacity = capacity
+	}
+
+	// Handle time updates with validation
+	startsAt := updatedEvent.StartsAt
+	endsAt := updatedEvent.EndsAt
+
+	if req.StartsAt != nil {
+		if errMsg := validateScheduleHorizon(*req.StartsAt, h.timeNow(), h.maxScheduleHorizon); errMsg != "" {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+			return
+		}
+		startsAt = *req.StartsAt
+	}
+
+	if req.EndsAt != nil {
+		endsAt = req.EndsAt
+	}
+
+	// Validate time window after applying updates
+	if errMsg := validateTimeWindow(startsAt, endsAt); errMsg != "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidTimeRange)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidTimeRange, errMsg)
+		return
+	}
+
+	updatedEvent.StartsAt = startsAt
+	updatedEvent.EndsAt = endsAt
+
+	// Update timestamp
+	now := time.Now()
+	updatedEvent.UpdatedAt = &now
+
+	// Update in repository (will automatically enforce location consent)
+	if err := h.eventRepo.Update(&updatedEvent); err != nil {
+		slog.ErrorContext(r.Context(), "failed to update event", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update event")
+		return
+	}
+
+	// Retrieve the stored event to get privacy-enforced version
+	stored, err := h.eventRepo.GetByID(eventID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to retrieve updated event", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve updated event")
+		return
+	}
+
+	changes := audit.Diff(eventAuditFields(existingEvent), eventAuditFields(stored))
+	if err := audit.LogUpdateFromRequest(r, h.auditRepo, "event", eventID, "event_update", changes); err != nil {
+		slog.ErrorContext(r.Context(), "failed to log event update", "error", err, "event_id", eventID)
+		// Don't fail the request, but log the error
+	}
+
+	// A raised or removed capacity makes room for the waitlist
+	if req.Capacity != nil && h.rsvpRepo != nil {
+		promoteWaitlist(r.Context(), h.rsvpRepo, h.auditRepo, stored)
+	}
+
+	// Return updated event with any soft validation warnings
+	response := newEventResponse(stored)
+	response.Warnings = eventWarnings(stored)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		// Log error but response already started
+		slog.ErrorContext(r.Context(), "failed to encode event response", "error", err)
+	}
+}
+
+// GetEvent handles GET /events/{id} - retrieves an event.
+func (h *EventHandlers) GetEvent(w http.ResponseWriter, r *http.Request) {
+	// Extract event ID from URL path
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
+	if len(pathParts) == 0 || pathParts[0] == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
+		return
+	}
+	eventID := pathParts[0]
+
+	// Optionally include the next N occurrences of a recurring event
+	occurrenceCount := 0
+	if raw := r.URL.Query().Get("occurrences"); raw != "" {
+		n, err := parseIntInRange(raw, "occurrences", 1, MaxEventOccurrences)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+		occurrenceCount = n
+	}
+
+	// Get the event; hidden from callers who can't see its scene, and
+	// unpublished events from all but the scene owner and the proposer
+	foundEvent, parentScene := h.getVisibleEvent(w, r, eventID)
+	if foundEvent == nil {
+		return
+	}
+
+	// Mature scenes return only a stub until the viewer opts in
+	if parentScene.IsMature() {
+		w.Header().Add("Vary", MatureContentHeader)
+	}
+	if redactMatureScene(r, parentScene, middleware.GetUserDID(r.Context())) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(EventWithRSVPCounts{EventResponse: newRedactedEventResponse(foundEvent, parentScene)}); err != nil {
+			slog.ErrorContext(r.Context(), "failed to encode event response", "error", err)
+		}
+		return
+	}
+
+	// Privacy enforcement is handled by the repository
+	// The repository automatically enforces location consent via EnforceLocationConsent()
+
+	// Get RSVP counts for the event
+	rsvpCounts, err := h.rsvpRepo.GetCountsByEvent(eventID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get RSVP counts", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVP counts")
+		return
+	}
+
+	// Get active stream for the event
+	activeStream, err := h.streamRepo.GetActiveStreamForEvent(eventID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get active stream", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve active stream")
+		return
+	}
+
+	// Create response with event, RSVP counts, and active stream
+	response := EventWithRSVPCounts{
+		EventResponse: newEventResponse(foundEvent),
+		RSVPCounts:    rsvpCounts,
+		ActiveStream:  activeStream,
+	}
+
+	if occurrenceCount > 0 && foundEvent.RRule != nil {
+		now := h.timeNow()
+		occurrences, err := scene.ExpandRecurrence(foundEvent, now, now.Add(occurrenceLookahead))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to expand event recurrence", "error", err, "event_id", eventID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to expand event occurrences")
+			return
+		}
+		if len(occurrences) > occurrenceCount {
+			occurrences = occurrences[:occurrenceCount]
+		}
+		response.Occurrences = make([]EventResponse, 0, len(occurrences))
+		for i := range occurrences {
+			response.Occurrences = append(response.Occurrences, newEventResponse(&occurrences[i]))
+		}
+	}
+
+	// Return event with RSVP counts
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		// Log error but response already started
+		slog.ErrorContext(r.Context(), "failed to encode event response", "error", err)
+	}
+}
+
+// ListMyEvents handles GET /me/events - lists events in scenes owned by the authenticated user.
+// Pass include_deleted=true to include the user's soft-deleted events and the events of
+// their soft-deleted scenes.
+func (h *EventHandlers) ListMyEvents(w http.ResponseWriter, r *http.Request) {
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	scope, err := ownerScopeFromRequest(r, userDID)
+	if err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	scenes, err := h.sceneRepo.ListByOwnerScope(scope)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list owned scenes", "error", err, "user_did", userDID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve events")
+		return
+	}
+
+	events, err := h.eventRepo.ListByOwnerScope(scope, scenes)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list owned events", "error", err, "user_did", userDID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve events")
+		return
+	}
+
+	response := make([]EventResponse, len(events))
+	for i, event := range events {
+		response[i] = newEventResponse(event)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode events response", "error", err)
+	}
+}
+
+// getSceneForEvents loads the scene named in a /scenes/{id}/... path, writing
+// an error response and returning nil if it cannot. Scenes the caller cannot
+// see are reported as not found.
+func (h *EventHandlers) getSceneForEvents(w http.ResponseWriter, r *http.Request) *scene.Scene {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) < 2 || pathParts[0] == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+		return nil
+	}
+	sceneID := pathParts[0]
+
+	foundScene, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			return nil
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		return nil
+	}
+
+	visible, err := sceneVisibleTo(h.membershipRepo, foundScene, middleware.GetUserDID(r.Context()))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check access permissions")
+		return nil
+	}
+	// Use uniform error message - same as "not found" to prevent enumeration
+	if !visible {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+		return nil
+	}
+	return foundScene
+}
+
+// ListSceneEvents handles GET /scenes/{id}/events - lists a scene's events in
+// starts_at order, with the scene's pinned event first while it is neither
+// cancelled nor deleted. Events pending approval or rejected are listed only
+// for the scene owner and the member who proposed them. Scenes the caller
+// cannot see return 404, and mature scenes withhold their events until the
+// caller opts in, as the scene feed does.
+func (h *EventHandlers) ListSceneEvents(w http.ResponseWriter, r *http.Request) {
+	foundScene := h.getSceneForEvents(w, r)
+	if foundScene == nil {
+		return
+	}
+
+	userDID := middleware.GetUserDID(r.Context())
+	if foundScene.IsMature() {
+		w.Header().Add("Vary", MatureContentHeader)
+	}
+	if redactMatureScene(r, foundScene, userDID) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(SceneEventsResponse{
+			Events:          []EventResponse{},
+			ContentRating:   foundScene.ContentRating,
+			ContentRedacted: true,
+		}); err != nil {
+			slog.ErrorContext(r.Context(), "failed to encode scene events response", "error", err)
+		}
+		return
+	}
+
+	events, err := h.eventRepo.ListByScene(foundScene.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list scene events", "error", err, "scene_id", foundScene.ID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve events")
+		return
+	}
+
+	response := SceneEventsResponse{Events: make([]EventResponse, 0, len(events))}
+	for _, event := range events {
+		if !event.IsPublished() && !canSeeUnpublished(event, foundScene, userDID) {
+			continue
+		}
+		// A cancelled pinned event is demoted to its chronological position
+		if foundScene.PinnedEventID != nil && event.ID == *foundScene.PinnedEventID && !event.IsCancelled() {
+			response.PinnedEventID = &event.ID
+			response.Events = append([]EventResponse{newEventResponse(event)}, response.Events...)
+			continue
+		}
+		response.Events = append(response.Events, newEventResponse(event))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode scene events response", "error", err)
+	}
+}
+
+// SetPinnedEvent handles PUT /scenes/{id}/pinned_event - pins one of the
+// scene's events so it is listed first. Only the scene owner may pin, and
+// cancelled or unpublished events cannot be pinned.
+func (h *EventHandlers) SetPinnedEvent(w http.ResponseWriter, r *http.Request) {
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	var req SetPinnedEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if strings.TrimSpace(req.EventID) == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "event_id is required")
+		return
+	}
+
+	foundScene := h.getSceneForEvents(w, r)
+	if foundScene == nil {
+		return
+	}
+	if !foundScene.IsOwner(userDID) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the scene owner can pin events")
+		return
+	}
+
+	event, err := h.eventRepo.GetByID(req.EventID)
+	if err != nil && err != scene.ErrEventNotFound {
+		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", req.EventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+	if err != nil || event.SceneID != foundScene.ID || event.DeletedAt != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "event_id must be an event in this scene")
+		return
+	}
+	if event.IsCancelled() {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Cannot pin a cancelled event")
+		return
+	}
+	if !event.IsPublished() {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Cannot pin an event that has not been approved")
+		return
+	}
+
+	foundScene.PinnedEventID = &event.ID
+	h.savePinnedEvent(w, r, foundScene)
+}
+
+// ClearPinnedEvent handles DELETE /scenes/{id}/pinned_event - unpins the
+// scene's pinned event. Idempotent; only the scene owner may unpin.
+func (h *EventHandlers) ClearPinnedEvent(w http.ResponseWriter, r *http.Request) {
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	foundScene := h.getSceneForEvents(w, r)
+	if foundScene == nil {
+		return
+	}
+	if !foundScene.IsOwner(userDID) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the scene owner can unpin events")
+		return
+	}
+
+	foundScene.PinnedEventID = nil
+	h.savePinnedEvent(w, r, foundScene)
+}
+
+// GetSceneRSVPTrends handles GET /scenes/{id}/rsvp_trends?from=&to=&bucket= -
+// returns going and maybe RSVP counts summed across the scene's events, bucketed
+// by event start time. Only published, non-cancelled events starting in
+// [from, to) are counted. bucket is day, week (default) or month. Owner-only.
+func (h *EventHandlers) GetSceneRSVPTrends(w http.ResponseWriter, r *http.Request) {
 	userDID := middleware.GetUserDID(r.Context())
 	if userDID == "" {
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
@@ -304,144 +1158,166 @@ func (h *EventHandlers) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if user is scene owner (authorization)
-	isOwner, err := h.isSceneOwner(r.Context(), existingEvent.SceneID, userDID)
+	query := r.URL.Query()
+	fromStr := strings.TrimSpace(query.Get("from"))
+	toStr := strings.TrimSpace(query.Get("to"))
+	if fromStr == "" || toStr == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "both 'from' and 'to' parameters are required")
+		return
+	}
+	from, err := time.Parse(time.RFC3339, fromStr)
 	if err != nil {
-		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
-			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
-			return
-		}
-		slog.ErrorContext(r.Context(), "failed to check scene ownership", "error", err, "scene_id", existingEvent.SceneID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify scene ownership")
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "invalid 'from' timestamp, must be RFC3339 format")
 		return
 	}
-	if !isOwner {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to update this event")
+	to, err := time.Parse(time.RFC3339, toStr)
+	if err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "invalid 'to' timestamp, must be RFC3339 format")
 		return
 	}
-
-	// Apply updates to existing event
-	updatedEvent := *existingEvent
-
-	if req.Title != nil {
-		// Validate and sanitize title
-		validatedTitle, err := validate.EventTitle(*req.Title)
-		if err != nil {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid event title: %v", err))
-			return
-		}
-		updatedEvent.Title = validatedTitle
+	if !from.Before(to) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidTimeRange)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidTimeRange, "'from' must be before 'to'")
+		return
 	}
-
-	if req.Description != nil {
-		validatedDesc, err := validate.Description(*req.Description)
-		if err != nil {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid description: %v", err))
-			return
-		}
-		updatedEvent.Description = validatedDesc
+	if to.Sub(from) > MaxRSVPTrendsWindow {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "time window cannot exceed 366 days")
+		return
 	}
-
-	if req.Tags != nil {
-		sanitizedTags := make([]string, len(req.Tags))
-		for i, tag := range req.Tags {
-			sanitizedTags[i] = validate.SanitizeHTML(tag)
-		}
-		updatedEvent.Tags = sanitizedTags
+	bucket := strings.ToLower(strings.TrimSpace(query.Get("bucket")))
+	if bucket == "" {
+		bucket = RSVPTrendBucketWeek
+	}
+	if bucket != RSVPTrendBucketDay && bucket != RSVPTrendBucketWeek && bucket != RSVPTrendBucketMonth {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "bucket must be one of: day, week, month")
+		return
 	}
 
-	if req.AllowPrecise != nil {
-		updatedEvent.AllowPrecise = *req.AllowPrecise
+	foundScene := h.getSceneForEvents(w, r)
+	if foundScene == nil {
+		return
+	}
+	if !foundScene.IsOwner(userDID) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the scene owner can view RSVP trends")
+		return
 	}
 
-	if req.PrecisePoint != nil {
-		updatedEvent.PrecisePoint = req.PrecisePoint
+	events, err := h.eventRepo.ListByScene(foundScene.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list scene events", "error", err, "scene_id", foundScene.ID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve events")
+		return
 	}
 
-	if req.CoarseGeohash != nil {
-		if strings.TrimSpace(*req.CoarseGeohash) == "" {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "coarse_geohash cannot be empty")
-			return
+	// Map each counted event to its bucket so RSVPs are summed in one query
+	from, to = from.UTC(), to.UTC()
+	groups := make(map[string]string)
+	eventsPerBucket := make(map[string]int)
+	for _, event := range events {
+		if !event.IsPublished() || event.IsCancelled() || event.StartsAt.Before(from) || !event.StartsAt.Before(to) {
+			continue
 		}
-		updatedEvent.CoarseGeohash = *req.CoarseGeohash
+		key := rsvpTrendBucketStart(event.StartsAt, bucket).Format(time.RFC3339)
+		groups[event.ID] = key
+		eventsPerBucket[key]++
 	}
 
-	// Handle time updates with validation
-	startsAt := updatedEvent.StartsAt
-	endsAt := updatedEvent.EndsAt
+	counts, err := h.rsvpRepo.GetCountsByGroup(groups)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to aggregate rsvp counts", "error", err, "scene_id", foundScene.ID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVP trends")
+		return
+	}
 
-	if req.StartsAt != nil {
-		// Only allow updates if event is still in the future
-		if existingEvent.StartsAt.Before(time.Now()) {
-			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Cannot update start time for past events")
-			return
+	response := RSVPTrendsResponse{
+		SceneID: foundScene.ID,
+		From:    from,
+		To:      to,
+		Bucket:  bucket,
+		Buckets: make([]RSVPTrendBucket, 0),
+	}
+	for start := rsvpTrendBucketStart(from, bucket); start.Before(to); start = nextRSVPTrendBucket(start, bucket) {
+		key := start.Format(time.RFC3339)
+		b := RSVPTrendBucket{Start: start, Events: eventsPerBucket[key]}
+		if c := counts[key]; c != nil {
+			b.Going, b.Maybe = c.Going, c.Maybe
 		}
-		startsAt = *req.StartsAt
+		response.Buckets = append(response.Buckets, b)
 	}
 
-	if req.EndsAt != nil {
-		endsAt = req.EndsAt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode rsvp trends response", "error", err)
 	}
+}
 
-	// Validate time window after applying updates
-	if errMsg := validateTimeWindow(startsAt, endsAt); errMsg != "" {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidTimeRange)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidTimeRange, errMsg)
-		return
+// rsvpTrendBucketStart returns the start of the UTC day, Monday-based week, or
+// month containing t.
+func rsvpTrendBucketStart(t time.Time, bucket string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch bucket {
+	case RSVPTrendBucketDay:
+		return day
+	case RSVPTrendBucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
 	}
+}
 
-	updatedEvent.StartsAt = startsAt
-	updatedEvent.EndsAt = endsAt
-
-	// Update timestamp
-	now := time.Now()
-	updatedEvent.UpdatedAt = &now
-
-	// Update in repository (will automatically enforce location consent)
-	if err := h.eventRepo.Update(&updatedEvent); err != nil {
-		slog.ErrorContext(r.Context(), "failed to update event", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update event")
-		return
+// nextRSVPTrendBucket returns the start of the bucket after start.
+func nextRSVPTrendBucket(start time.Time, bucket string) time.Time {
+	switch bucket {
+	case RSVPTrendBucketDay:
+		return start.AddDate(0, 0, 1)
+	case RSVPTrendBucketMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 7)
 	}
+}
 
-	// Retrieve the stored event to get privacy-enforced version
-	stored, err := h.eventRepo.GetByID(eventID)
-	if err != nil {
-		slog.ErrorContext(r.Context(), "failed to retrieve updated event", "error", err, "event_id", eventID)
+// savePinnedEvent persists a scene whose pinned event changed and writes it as the response.
+func (h *EventHandlers) savePinnedEvent(w http.ResponseWriter, r *http.Request, updated *scene.Scene) {
+	now := h.timeNow()
+	updated.UpdatedAt = &now
+	if err := h.sceneRepo.Update(updated); err != nil {
+		slog.ErrorContext(r.Context(), "failed to update pinned event", "error", err, "scene_id", updated.ID)
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve updated event")
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update pinned event")
 		return
 	}
 
-	// Return updated event
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(stored); err != nil {
-		// Log error but response already started
-		slog.ErrorContext(r.Context(), "failed to encode event response", "error", err)
+	if err := json.NewEncoder(w).Encode(newSceneResponse(updated)); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode scene response", "error", err)
 	}
 }
 
-// GetEvent handles GET /events/{id} - retrieves an event.
-func (h *EventHandlers) GetEvent(w http.ResponseWriter, r *http.Request) {
-	// Extract event ID from URL path
+// GetEventPermissions handles GET /events/{id}/permissions - reports what the
+// caller may do with an event, computed with the same checks the event, RSVP,
+// post and stream handlers enforce. Callers who cannot see the event get the
+// same 404 as GET /events/{id}.
+func (h *EventHandlers) GetEventPermissions(w http.ResponseWriter, r *http.Request) {
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
-	if len(pathParts) == 0 || pathParts[0] == "" {
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "permissions" {
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
 		return
 	}
 	eventID := pathParts[0]
 
-	// Get the event
 	foundEvent, err := h.eventRepo.GetByID(eventID)
 	if err != nil {
 		if err == scene.ErrEventNotFound {
@@ -455,43 +1331,79 @@ func (h *EventHandlers) GetEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Privacy enforcement is handled by the repository
-	// The repository automatically enforces location consent via EnforceLocationConsent()
-
-	// Get RSVP counts for the event
-	rsvpCounts, err := h.rsvpRepo.GetCountsByEvent(eventID)
+	parentScene, err := h.sceneRepo.GetByID(foundEvent.SceneID)
 	if err != nil {
-		slog.ErrorContext(r.Context(), "failed to get RSVP counts", "error", err, "event_id", eventID)
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get scene", "error", err, "scene_id", foundEvent.SceneID)
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVP counts")
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
 		return
 	}
 
-	// Get active stream for the event
-	activeStream, err := h.streamRepo.GetActiveStreamForEvent(eventID)
-	if err != nil {
-		slog.ErrorContext(r.Context(), "failed to get active stream", "error", err, "event_id", eventID)
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve active stream")
+	userDID := middleware.GetUserDID(r.Context())
+	if !foundEvent.IsPublished() && !canSeeUnpublished(foundEvent, parentScene, userDID) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
 		return
 	}
 
-	// Create response with event, RSVP counts, and active stream
-	response := EventWithRSVPCounts{
-		Event:        foundEvent,
-		RSVPCounts:   rsvpCounts,
-		ActiveStream: activeStream,
+	response, err := h.eventPermissions(foundEvent, parentScene, userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to compute event permissions", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check permissions")
+		return
 	}
 
-	// Return event with RSVP counts
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		// Log error but response already started
-		slog.ErrorContext(r.Context(), "failed to encode event response", "error", err)
+		slog.ErrorContext(r.Context(), "failed to encode event permissions response", "error", err)
 	}
 }
 
+// eventPermissions lists the capabilities userDID holds on an event it can see.
+func (h *EventHandlers) eventPermissions(event *scene.Event, s *scene.Scene, userDID string) (PermissionsResponse, error) {
+	response := PermissionsResponse{Capabilities: []string{CapabilityView}}
+	if userDID == "" {
+		return response, nil
+	}
+
+	role, err := sceneRole(h.membershipRepo, s, userDID)
+	if err != nil {
+		return response, err
+	}
+	response.Role = role
+
+	if s.IsOwner(userDID) {
+		// Details freeze once the edit grace period after the start has passed
+		if h.timeNow().Sub(event.StartsAt) <= h.editGracePeriod {
+			response.Capabilities = append(response.Capabilities, CapabilityEdit)
+		}
+		response.Capabilities = append(response.Capabilities, CapabilityCancel, CapabilityStream)
+		if event.Status == scene.EventStatusPendingApproval {
+			response.Capabilities = append(response.Capabilities, CapabilityReview)
+		}
+	}
+
+	blocked, err := isSceneBlocked(h.blockRepo, s.ID, userDID)
+	if err != nil {
+		return response, err
+	}
+	if !blocked {
+		response.Capabilities = append(response.Capabilities, CapabilityPost)
+		// RSVPs close once the event starts
+		if event.StartsAt.After(h.timeNow()) {
+			response.Capabilities = append(response.Capabilities, CapabilityRSVP)
+		}
+	}
+	return response, nil
+}
+
 // CancelEvent handles POST /events/{id}/cancel - cancels an event.
 func (h *EventHandlers) CancelEvent(w http.ResponseWriter, r *http.Request) {
 	// Extract event ID from URL path
@@ -591,12 +1503,124 @@ func (h *EventHandlers) CancelEvent(w http.ResponseWriter, r *http.Request) {
 	// Return cancelled event
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(cancelledEvent); err != nil {
+	if err := json.NewEncoder(w).Encode(newEventResponse(cancelledEvent)); err != nil {
 		// Log error but response already started
 		slog.ErrorContext(r.Context(), "failed to encode event response", "error", err)
 	}
 }
 
+// ApproveEvent handles POST /events/{id}/approve - publishes an event a member
+// proposed. Only the scene owner may approve, and only pending events.
+func (h *EventHandlers) ApproveEvent(w http.ResponseWriter, r *http.Request) {
+	h.reviewEvent(w, r, true)
+}
+
+// RejectEvent handles POST /events/{id}/reject - declines an event a member
+// proposed, with an optional reason shown to the proposer. Only the scene
+// owner may reject, and only pending events.
+func (h *EventHandlers) RejectEvent(w http.ResponseWriter, r *http.Request) {
+	h.reviewEvent(w, r, false)
+}
+
+// reviewEvent approves or rejects a pending event and records the decision in
+// the audit log.
+func (h *EventHandlers) reviewEvent(w http.ResponseWriter, r *http.Request, approve bool) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
+	if len(pathParts) == 0 || pathParts[0] == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
+		return
+	}
+	eventID := pathParts[0]
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Parse request body (optional rejection reason)
+	var req RejectEventRequest
+	if !approve {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+			return
+		}
+		if req.Reason != nil {
+			sanitized := validate.SanitizeHTML(*req.Reason)
+			req.Reason = &sanitized
+		}
+	}
+
+	event, err := h.eventRepo.GetByID(eventID)
+	if err != nil {
+		if err == scene.ErrEventNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+
+	isOwner, err := h.isSceneOwner(r.Context(), event.SceneID, userDID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to check scene ownership", "error", err, "scene_id", event.SceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify scene ownership")
+		return
+	}
+	if !isOwner {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the scene owner can review proposed events")
+		return
+	}
+
+	if event.Status != scene.EventStatusPendingApproval {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeConflict)
+		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Event is not pending approval")
+		return
+	}
+
+	action := "event_approve"
+	if approve {
+		event.Status = "scheduled"
+	} else {
+		action = "event_reject"
+		event.Status = scene.EventStatusRejected
+		event.RejectionReason = req.Reason
+	}
+	now := h.timeNow()
+	event.UpdatedAt = &now
+
+	if err := h.eventRepo.Update(event); err != nil {
+		slog.ErrorContext(r.Context(), "failed to update event", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to review event")
+		return
+	}
+
+	if err := audit.LogAccessFromRequest(r, h.auditRepo, "event", eventID, action, audit.OutcomeSuccess); err != nil {
+		slog.ErrorContext(r.Context(), "failed to log event review", "error", err, "event_id", eventID, "action", action)
+		// Don't fail the request, but log the error
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(newEventResponse(event)); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode event response", "error", err)
+	}
+}
+
 // SearchEventsResponse represents the response for event search with active stream info.
 type SearchEventsResponse struct {
 	Events     []*EventWithRSVPCounts `json:"events"`
@@ -606,6 +1630,10 @@ type SearchEventsResponse struct {
 // SearchEvents handles GET /search/events - searches events by bbox and time range.
 // Supports optional text search (q parameter) and trust-weighted ranking.
 func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
+	if h.latencySLO != nil {
+		defer h.latencySLO.ObserveSince(time.Now())
+	}
+
 	// Parse query parameters
 	query := r.URL.Query()
 
@@ -650,12 +1678,12 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate bbox ranges
-	if minLng < -180 || minLng > 180 || maxLng < -180 || maxLng > 180 {
+	if geo.ValidateLongitude(minLng) != nil || geo.ValidateLongitude(maxLng) != nil {
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "longitude must be between -180 and 180")
 		return
 	}
-	if minLat < -90 || minLat > 90 || maxLat < -90 || maxLat > 90 {
+	if geo.ValidateLatitude(minLat) != nil || geo.ValidateLatitude(maxLat) != nil {
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "latitude must be between -90 and 90")
 		return
@@ -826,17 +1854,13 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 			sceneIDs[event.SceneID] = true
 		}
 
-		// Fetch trust scores for all scenes
+		// Fetch trust scores for all scenes; on failure keep the trust-free ranking
+		sceneIDList := make([]string, 0, len(sceneIDs))
 		for sceneID := range sceneIDs {
-			score, err := h.trustScoreStore.GetScore(sceneID)
-			if err != nil {
-				// Log error but don't fail the request
-				slog.WarnContext(r.Context(), "failed to get trust score", "scene_id", sceneID, "error", err)
-				continue
-			}
-			if score != nil {
-				trustScores[sceneID] = score.Score
-			}
+			sceneIDList = append(sceneIDList, sceneID)
+		}
+		if scores, ok := lookupTrustScores(r.Context(), h.trustScoreStore, sceneIDList, "events"); ok {
+			trustScores = scores
 		}
 
 		// If we got trust scores, re-run the search with them
@@ -888,7 +1912,7 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sceneMap := make(map[string]*SceneSearchResult)
+	sceneMap := make(map[string]*scene.Scene)
 	if len(events) > 0 {
 		sceneIDs := make(map[string]struct{}, len(events))
 		orderedSceneIDs := make([]string, 0)
@@ -906,7 +1930,7 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 				slog.WarnContext(r.Context(), "failed to batch fetch scenes for event search response; falling back to individual fetches", "error", err)
 			} else {
 				for _, parentScene := range parentScenes {
-					sceneMap[parentScene.ID] = toSceneSearchResult(parentScene)
+					sceneMap[parentScene.ID] = parentScene
 				}
 			}
 		}
@@ -920,18 +1944,32 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 				slog.WarnContext(r.Context(), "failed to fetch scene for event search response", "scene_id", sceneID, "error", err)
 				continue
 			}
-			sceneMap[sceneID] = toSceneSearchResult(parentScene)
+			sceneMap[sceneID] = parentScene
 		}
 	}
 
-	// Build response with events, RSVP counts, and active streams
+	// Build response with events, RSVP counts, and active streams. Events in
+	// mature scenes are reduced to stubs until the viewer opts in
+	requesterDID := middleware.GetUserDID(r.Context())
+	hasMature := false
 	eventsWithData := make([]*EventWithRSVPCounts, len(events))
 	for i, event := range events {
+		parentScene := sceneMap[event.SceneID]
+		if parentScene != nil && parentScene.IsMature() {
+			hasMature = true
+		}
+		if parentScene != nil && redactMatureScene(r, parentScene, requesterDID) {
+			eventsWithData[i] = &EventWithRSVPCounts{
+				EventResponse: newRedactedEventResponse(event, parentScene),
+				Scene:         newRedactedSceneSearchResult(parentScene),
+			}
+			continue
+		}
 		eventsWithData[i] = &EventWithRSVPCounts{
-			Event:        event,
-			RSVPCounts:   rsvpCountsMap[event.ID],
-			Scene:        sceneMap[event.SceneID],
-			ActiveStream: activeStreamsMap[event.ID], // nil if no active stream
+			EventResponse: newEventResponse(event),
+			RSVPCounts:    rsvpCountsMap[event.ID],
+			Scene:         toSceneSearchResult(parentScene),
+			ActiveStream:  activeStreamsMap[event.ID], // nil if no active stream
 		}
 	}
 
@@ -941,6 +1979,9 @@ func (h *EventHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
 		NextCursor: nextCursor,
 	}
 
+	if hasMature {
+		w.Header().Add("Vary", MatureContentHeader)
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -17,12 +17,18 @@
 // - TestCreateEvent_InvalidTimeWindow: Tests start_at >= end_at rejection
 // - TestUpdateEvent_CannotUpdatePastEvent: Tests past event time update prevention
 // - TestUpdateEvent_TimeWindowValidation: Tests time window validation on updates
+// - TestCreateEvent_ScheduleHorizon: Tests far-future start time rejection and boundary
+// - TestUpdateEvent_ScheduleHorizon: Tests far-future start time rejection on updates
 //
 // ### Location & Privacy
 // - TestCreateEvent_MissingCoarseGeohash: Tests coarse_geohash requirement
 // - TestCreateEvent_PrivacyEnforcement: Tests precise_point clearing without consent
 // - TestGetEvent_PrivacyEnforcement: Tests privacy enforcement on retrieval
 // - TestUpdateEvent_EmptyCoarseGeohash: Tests rejection of empty geohash in updates
+// - TestEvent_InvalidCoarseGeohash: Tests malformed geohash rejection on create and update
+//
+// ### Tag Validation
+// - TestEvent_TagValidation: Tests tag normalization, deduplication, count cap and length on create and update
 //
 // ### Authorization
 // - TestCreateEvent_UnauthorizedCreate: Tests non-owner scene linkage rejection
@@ -40,6 +46,9 @@
 // - TestCancelEvent_Success: Baseline successful cancellation test
 // - TestGetEvent_Success: Baseline successful retrieval test
 //
+// ### Audit
+// - TestUpdateEvent_AuditChanges: Tests update audit entries record only changed fields
+//
 // ### Error Code Consistency
 // All tests verify that error responses include:
 // - Correct HTTP status code
@@ -50,6 +59,7 @@
 // - validation_error: Input validation failures (400)
 // - forbidden: Authorization failures (403)
 // - invalid_time_range: Time window validation (400)
+// - invalid_geohash: Malformed coarse_geohash (400)
 // - not_found: Resource not found (404)
 // - bad_request: Malformed requests (400)
 // - auth_failed: Authentication required (401)
@@ -60,6 +70,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -69,6 +80,7 @@ import (
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
 	"github.com/onnwee/subcults/internal/stream"
+	"github.com/onnwee/subcults/internal/validate"
 )
 
 // assertErrorResponse is a test helper that verifies error response structure and codes.
@@ -249,6 +261,78 @@ func TestCreateEvent_InvalidTimeWindow(t *testing.T) {
 	}
 }
 
+// TestCreateEvent_ScheduleHorizon tests that start times beyond the configured
+// scheduling horizon are rejected while the boundary itself is accepted.
+func TestCreateEvent_ScheduleHorizon(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	horizon := 30 * 24 * time.Hour
+	tests := []struct {
+		name     string
+		startsAt time.Time
+		wantCode int
+	}{
+		{name: "within horizon", startsAt: now.Add(horizon - time.Hour), wantCode: http.StatusCreated},
+		{name: "at boundary", startsAt: now.Add(horizon), wantCode: http.StatusCreated},
+		{name: "beyond horizon", startsAt: now.Add(horizon + time.Second), wantCode: http.StatusBadRequest},
+		{name: "far future", startsAt: now.AddDate(50, 0, 0), wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := scene.NewInMemoryEventRepository()
+			sceneRepo := scene.NewInMemorySceneRepository()
+			auditRepo := audit.NewInMemoryRepository()
+			rsvpRepo := scene.NewInMemoryRSVPRepository()
+			streamRepo := stream.NewInMemorySessionRepository()
+			handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo, nil)
+			handlers.SetMaxScheduleHorizon(horizon)
+			handlers.timeNow = func() time.Time { return now }
+
+			testScene := &scene.Scene{
+				ID:            uuid.New().String(),
+				Name:          "Test Scene",
+				OwnerDID:      "did:plc:test123",
+				CoarseGeohash: "dr5regw",
+			}
+			if err := sceneRepo.Insert(testScene); err != nil {
+				t.Fatalf("failed to insert scene: %v", err)
+			}
+
+			body, err := json.Marshal(CreateEventRequest{
+				SceneID:       testScene.ID,
+				Title:         "Test Event",
+				CoarseGeohash: "dr5regw",
+				StartsAt:      tt.startsAt,
+			})
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+			w := httptest.NewRecorder()
+
+			handlers.CreateEvent(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode != http.StatusBadRequest {
+				return
+			}
+
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != ErrCodeValidation {
+				t.Errorf("expected error code '%s', got '%s'", ErrCodeValidation, errResp.Error.Code)
+			}
+		})
+	}
+}
+
 // TestCreateEvent_MissingCoarseGeohash tests rejection when coarse_geohash is missing.
 func TestCreateEvent_MissingCoarseGeohash(t *testing.T) {
 	eventRepo := scene.NewInMemoryEventRepository()
@@ -303,6 +387,31 @@ func TestCreateEvent_MissingCoarseGeohash(t *testing.T) {
 	}
 }
 
+// TestCreateEvent_InvalidPrecisePoint tests that a precise point off the globe
+// is rejected.
+func TestCreateEvent_InvalidPrecisePoint(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:test123")
+
+	body, _ := json.Marshal(CreateEventRequest{
+		SceneID:       "scene-1",
+		Title:         "Test Event",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: -90.5, Lng: 10},
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.CreateEvent(w, req)
+
+	assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+}
+
 // TestCreateEvent_UnauthorizedCreate tests rejection when user doesn't own the scene.
 func TestCreateEvent_UnauthorizedCreate(t *testing.T) {
 	eventRepo := scene.NewInMemoryEventRepository()
@@ -579,6 +688,65 @@ func TestUpdateEvent_Success(t *testing.T) {
 	}
 }
 
+// TestUpdateEvent_AuditChanges tests that an event update is audited with the
+// fields it changed, and only those.
+func TestUpdateEvent_AuditChanges(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+
+	if err := sceneRepo.Insert(&scene.Scene{
+		ID:            "scene-audit",
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	if err := eventRepo.Insert(&scene.Event{
+		ID:            "event-audit",
+		SceneID:       "scene-audit",
+		Title:         "Original Title",
+		Description:   "Unchanged description",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060},
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	// Resending the current description must not register as a change
+	body := `{"title": "Updated Title", "description": "Unchanged description", "precise_point": {"lat": 40.7306, "lng": -73.9352}}`
+	req := httptest.NewRequest(http.MethodPatch, "/events/event-audit", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateEvent(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	logs, err := auditRepo.QueryByEntity("event", "event-audit", 0)
+	if err != nil {
+		t.Fatalf("failed to query audit logs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Action != "event_update" {
+		t.Fatalf("expected one event_update audit entry, got %+v", logs)
+	}
+
+	want := map[string]audit.ChangePair{
+		"title":         {Old: "Original Title", New: "Updated Title"},
+		"precise_point": {Old: audit.RedactedValue, New: audit.RedactedValue},
+	}
+	if !reflect.DeepEqual(logs[0].Changes, want) {
+		t.Errorf("audit changes = %+v, want %+v", logs[0].Changes, want)
+	}
+}
+
 // TestUpdateEvent_CannotUpdatePastEvent tests that past events cannot have time updated.
 func TestUpdateEvent_CannotUpdatePastEvent(t *testing.T) {
 	eventRepo := scene.NewInMemoryEventRepository()
@@ -642,9 +810,156 @@ func TestUpdateEvent_CannotUpdatePastEvent(t *testing.T) {
 		t.Fatalf("failed to decode error response: %v", err)
 	}
 
+	if errResp.Error.Code != ErrCodeEventEditWindowClosed {
+		t.Errorf("expected error code '%s', got '%s'", ErrCodeEventEditWindowClosed, errResp.Error.Code)
+	}
+}
+
+// TestUpdateEvent_EditGracePeriod tests that a started event's details stay
+// editable within the grace period while its times are locked, and that every
+// edit is rejected once the grace period has passed.
+func TestUpdateEvent_EditGracePeriod(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	grace := 15 * time.Minute
+	newTitle := "Fixed Typo Night"
+	newEndsAt := now.Add(3 * time.Hour)
+
+	tests := []struct {
+		name       string
+		startedAgo time.Duration
+		req        UpdateEventRequest
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "title edit within grace", startedAgo: 5 * time.Minute, req: UpdateEventRequest{Title: &newTitle}, wantStatus: http.StatusOK},
+		{name: "title edit at grace boundary", startedAgo: grace, req: UpdateEventRequest{Title: &newTitle}, wantStatus: http.StatusOK},
+		{name: "time edit within grace", startedAgo: 5 * time.Minute, req: UpdateEventRequest{EndsAt: &newEndsAt}, wantStatus: http.StatusBadRequest, wantCode: ErrCodeEventTimeLocked},
+		{name: "title edit past grace", startedAgo: 2 * time.Hour, req: UpdateEventRequest{Title: &newTitle}, wantStatus: http.StatusBadRequest, wantCode: ErrCodeEventEditWindowClosed},
+		{name: "time edit past grace", startedAgo: 2 * time.Hour, req: UpdateEventRequest{EndsAt: &newEndsAt}, wantStatus: http.StatusBadRequest, wantCode: ErrCodeEventEditWindowClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := scene.NewInMemoryEventRepository()
+			sceneRepo := scene.NewInMemorySceneRepository()
+			handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+			handlers.SetEditGracePeriod(grace)
+			handlers.timeNow = func() time.Time { return now }
+
+			testScene := &scene.Scene{
+				ID:            uuid.New().String(),
+				Name:          "Test Scene",
+				OwnerDID:      "did:plc:test123",
+				CoarseGeohash: "dr5regw",
+			}
+			if err := sceneRepo.Insert(testScene); err != nil {
+				t.Fatalf("failed to insert scene: %v", err)
+			}
+			startedEvent := &scene.Event{
+				ID:            uuid.New().String(),
+				SceneID:       testScene.ID,
+				Title:         "Fixed Typo Nihgt",
+				CoarseGeohash: "dr5regw",
+				StartsAt:      now.Add(-tt.startedAgo),
+			}
+			if err := eventRepo.Insert(startedEvent); err != nil {
+				t.Fatalf("failed to insert event: %v", err)
+			}
+
+			body, err := json.Marshal(tt.req)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPatch, "/events/"+startedEvent.ID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+			w := httptest.NewRecorder()
+
+			handlers.UpdateEvent(w, req)
+
+			if tt.wantCode != "" {
+				assertErrorResponse(t, w, tt.wantStatus, tt.wantCode)
+				return
+			}
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			stored, err := eventRepo.GetByID(startedEvent.ID)
+			if err != nil {
+				t.Fatalf("failed to get event: %v", err)
+			}
+			if stored.Title != newTitle {
+				t.Errorf("expected title %q, got %q", newTitle, stored.Title)
+			}
+		})
+	}
+}
+
+// TestUpdateEvent_ScheduleHorizon tests that rescheduling cannot bypass the horizon.
+func TestUpdateEvent_ScheduleHorizon(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo, nil)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	now := time.Now()
+	existingEvent := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       testScene.ID,
+		Title:         "Upcoming Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      now.Add(24 * time.Hour),
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	if err := eventRepo.Insert(existingEvent); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	newStartsAt := now.Add(DefaultMaxScheduleHorizon + 24*time.Hour)
+	body, err := json.Marshal(UpdateEventRequest{StartsAt: &newStartsAt})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/events/"+existingEvent.ID, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+	w := httptest.NewRecorder()
+
+	handlers.UpdateEvent(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
 	if errResp.Error.Code != ErrCodeValidation {
 		t.Errorf("expected error code '%s', got '%s'", ErrCodeValidation, errResp.Error.Code)
 	}
+
+	unchanged, err := eventRepo.GetByID(existingEvent.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !unchanged.StartsAt.Equal(existingEvent.StartsAt) {
+		t.Errorf("expected start time to remain %v, got %v", existingEvent.StartsAt, unchanged.StartsAt)
+	}
 }
 
 // TestUpdateEvent_TimeWindowValidation tests time window validation on update.
@@ -731,7 +1046,7 @@ func TestGetEvent_Success(t *testing.T) {
 	now := time.Now()
 	testEvent := &scene.Event{
 		ID:            uuid.New().String(),
-		SceneID:       uuid.New().String(),
+		SceneID:       createTestScene(t, sceneRepo, uuid.New().String(), "did:plc:owner").ID,
 		Title:         "Test Event",
 		CoarseGeohash: "dr5regw",
 		StartsAt:      now.Add(24 * time.Hour),
@@ -810,7 +1125,7 @@ func TestGetEvent_PrivacyEnforcement(t *testing.T) {
 	now := time.Now()
 	testEvent := &scene.Event{
 		ID:            uuid.New().String(),
-		SceneID:       uuid.New().String(),
+		SceneID:       createTestScene(t, sceneRepo, uuid.New().String(), "did:plc:owner").ID,
 		Title:         "Private Event",
 		CoarseGeohash: "dr5regw",
 		StartsAt:      now.Add(24 * time.Hour),
@@ -1596,6 +1911,342 @@ func TestUpdateEvent_EmptyCoarseGeohash(t *testing.T) {
 	assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
 }
 
+// TestEvent_InvalidCoarseGeohash tests that create and update reject malformed
+// coarse geohashes with a dedicated error code.
+func TestEvent_InvalidCoarseGeohash(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo, nil)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	now := time.Now()
+	testEvent := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       testScene.ID,
+		Title:         "Test Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      now.Add(24 * time.Hour),
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	if err := eventRepo.Insert(testEvent); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	invalid := "dr5r!gw"
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    any
+		handler http.HandlerFunc
+	}{
+		{
+			name:   "create",
+			method: http.MethodPost,
+			path:   "/events",
+			body: CreateEventRequest{
+				SceneID:       testScene.ID,
+				Title:         "Test Event",
+				CoarseGeohash: invalid,
+				StartsAt:      now.Add(24 * time.Hour),
+			},
+			handler: handlers.CreateEvent,
+		},
+		{
+			name:    "update",
+			method:  http.MethodPatch,
+			path:    "/events/" + testEvent.ID,
+			body:    UpdateEventRequest{CoarseGeohash: &invalid},
+			handler: handlers.UpdateEvent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.body)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewReader(body))
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+			w := httptest.NewRecorder()
+
+			tt.handler(w, req)
+
+			assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeInvalidGeohash)
+		})
+	}
+
+	stored, err := eventRepo.GetByID(testEvent.ID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if stored.CoarseGeohash != "dr5regw" {
+		t.Errorf("coarse_geohash = %q, want it unchanged", stored.CoarseGeohash)
+	}
+}
+
+// TestEvent_TagValidation tests tag normalization and limits on create and update.
+func TestEvent_TagValidation(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, streamRepo, nil)
+	handlers.SetMaxTags(3)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	now := time.Now()
+	testEvent := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       testScene.ID,
+		Title:         "Test Event",
+		CoarseGeohash: "dr5regw",
+		Tags:          []string{"techno"},
+		StartsAt:      now.Add(24 * time.Hour),
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	if err := eventRepo.Insert(testEvent); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	longTag := strings.Repeat("a", validate.MaxTagLength+1)
+	tests := []struct {
+		name        string
+		tags        []string
+		wantStatus  int
+		wantTags    []string
+		wantMessage string // Substring of the error message
+	}{
+		{
+			name:       "valid tags are normalized",
+			tags:       []string{"  Drum  and Bass ", "house", "lo-fi_beats"},
+			wantStatus: http.StatusOK,
+			wantTags:   []string{"drum and bass", "house", "lo-fi_beats"},
+		},
+		{
+			name:       "duplicates dropped before counting",
+			tags:       []string{"House", "techno", "house", "TECHNO", "ambient"},
+			wantStatus: http.StatusOK,
+			wantTags:   []string{"house", "techno", "ambient"},
+		},
+		{
+			name:        "too many tags",
+			tags:        []string{"house", "techno", "ambient", "dub"},
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "too many tags",
+		},
+		{
+			name:        "over-long tag",
+			tags:        []string{"house", longTag},
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: longTag,
+		},
+		{
+			name:        "invalid characters",
+			tags:        []string{"<script>"},
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "<script>",
+		},
+		{
+			name:        "empty tag",
+			tags:        []string{"house", "   "},
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "string is empty",
+		},
+	}
+
+	do := func(t *testing.T, method, path string, body any, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		t.Helper()
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to marshal request: %v", err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantCreate := tt.wantStatus
+			if wantCreate == http.StatusOK {
+				wantCreate = http.StatusCreated
+			}
+
+			create := do(t, http.MethodPost, "/events", CreateEventRequest{
+				SceneID:       testScene.ID,
+				Title:         "Tagged Event",
+				CoarseGeohash: "dr5regw",
+				Tags:          tt.tags,
+				StartsAt:      now.Add(24 * time.Hour),
+			}, handlers.CreateEvent)
+			update := do(t, http.MethodPatch, "/events/"+testEvent.ID, UpdateEventRequest{Tags: tt.tags}, handlers.UpdateEvent)
+
+			for _, got := range []struct {
+				op         string
+				w          *httptest.ResponseRecorder
+				wantStatus int
+			}{
+				{"create", create, wantCreate},
+				{"update", update, tt.wantStatus},
+			} {
+				if got.w.Code != got.wantStatus {
+					t.Fatalf("%s: expected status %d, got %d: %s", got.op, got.wantStatus, got.w.Code, got.w.Body.String())
+				}
+				if tt.wantMessage != "" {
+					var errResp ErrorResponse
+					if err := json.NewDecoder(got.w.Body).Decode(&errResp); err != nil {
+						t.Fatalf("%s: failed to decode error response: %v", got.op, err)
+					}
+					if errResp.Error.Code != ErrCodeValidation {
+						t.Errorf("%s: error code = %q, want %q", got.op, errResp.Error.Code, ErrCodeValidation)
+					}
+					if !strings.Contains(errResp.Error.Message, tt.wantMessage) {
+						t.Errorf("%s: error message %q does not mention %q", got.op, errResp.Error.Message, tt.wantMessage)
+					}
+					continue
+				}
+				var resp EventResponse
+				if err := json.NewDecoder(got.w.Body).Decode(&resp); err != nil {
+					t.Fatalf("%s: failed to decode response: %v", got.op, err)
+				}
+				if !reflect.DeepEqual(resp.Tags, tt.wantTags) {
+					t.Errorf("%s: tags = %q, want %q", got.op, resp.Tags, tt.wantTags)
+				}
+			}
+		})
+	}
+}
+
+// TestEvent_DescriptionValidation tests description escaping and the configured
+// length cap on create and update.
+func TestEvent_DescriptionValidation(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	handlers.SetMaxDescriptionLength(20)
+
+	testScene := &scene.Scene{
+		ID:            uuid.New().String(),
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+	}
+	if err := sceneRepo.Insert(testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	now := time.Now()
+	testEvent := &scene.Event{
+		ID:            uuid.New().String(),
+		SceneID:       testScene.ID,
+		Title:         "Test Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      now.Add(24 * time.Hour),
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	if err := eventRepo.Insert(testEvent); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		wantStatus  int
+		wantDesc    string
+	}{
+		{name: "normal description preserved", description: "Bring earplugs", wantStatus: http.StatusOK, wantDesc: "Bring earplugs"},
+		{name: "HTML escaped", description: "<b>loud</b> & late", wantStatus: http.StatusOK, wantDesc: "&lt;b&gt;loud&lt;/b&gt; &amp; late"},
+		{name: "over-length rejected", description: strings.Repeat("a", 21), wantStatus: http.StatusBadRequest},
+	}
+
+	do := func(t *testing.T, method, path string, body any, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		t.Helper()
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to marshal request: %v", err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantCreate := tt.wantStatus
+			if wantCreate == http.StatusOK {
+				wantCreate = http.StatusCreated
+			}
+
+			create := do(t, http.MethodPost, "/events", CreateEventRequest{
+				SceneID:       testScene.ID,
+				Title:         "Described Event",
+				Description:   tt.description,
+				CoarseGeohash: "dr5regw",
+				StartsAt:      now.Add(24 * time.Hour),
+			}, handlers.CreateEvent)
+			description := tt.description
+			update := do(t, http.MethodPatch, "/events/"+testEvent.ID, UpdateEventRequest{Description: &description}, handlers.UpdateEvent)
+
+			for _, got := range []struct {
+				op         string
+				w          *httptest.ResponseRecorder
+				wantStatus int
+			}{
+				{"create", create, wantCreate},
+				{"update", update, tt.wantStatus},
+			} {
+				if got.w.Code != got.wantStatus {
+					t.Fatalf("%s: expected status %d, got %d: %s", got.op, got.wantStatus, got.w.Code, got.w.Body.String())
+				}
+				if tt.wantStatus != http.StatusOK {
+					var errResp ErrorResponse
+					if err := json.NewDecoder(got.w.Body).Decode(&errResp); err != nil {
+						t.Fatalf("%s: failed to decode error response: %v", got.op, err)
+					}
+					if errResp.Error.Code != ErrCodeValidation {
+						t.Errorf("%s: error code = %q, want %q", got.op, errResp.Error.Code, ErrCodeValidation)
+					}
+					continue
+				}
+				var resp EventResponse
+				if err := json.NewDecoder(got.w.Body).Decode(&resp); err != nil {
+					t.Fatalf("%s: failed to decode response: %v", got.op, err)
+				}
+				if resp.Description != tt.wantDesc {
+					t.Errorf("%s: description = %q, want %q", got.op, resp.Description, tt.wantDesc)
+				}
+			}
+		})
+	}
+}
+
 // TestUpdateEvent_TitleValidation tests title validation on update.
 func TestUpdateEvent_TitleValidation(t *testing.T) {
 	tests := []struct {
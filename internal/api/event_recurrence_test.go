@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+func newRecurrenceTest(t *testing.T) (*EventHandlers, *scene.InMemoryEventRepository) {
+	t.Helper()
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:host")
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	return handlers, eventRepo
+}
+
+func createRecurringEvent(handlers *EventHandlers, rrule string, seriesID *string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(CreateEventRequest{
+		SceneID:       "scene-1",
+		Title:         "Weekly Meetup",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+		SeriesID:      seriesID,
+		RRule:         &rrule,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:host"))
+	w := httptest.NewRecorder()
+	handlers.CreateEvent(w, req)
+	return w
+}
+
+func TestCreateEvent_RRule(t *testing.T) {
+	handlers, eventRepo := newRecurrenceTest(t)
+
+	w := createRecurringEvent(handlers, " FREQ=WEEKLY;BYDAY=TU,TH ", nil)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created scene.Event
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	stored, err := eventRepo.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if stored.RRule == nil || *stored.RRule != "FREQ=WEEKLY;BYDAY=TU,TH" {
+		t.Errorf("expected the trimmed rrule to be stored, got %v", stored.RRule)
+	}
+}
+
+func TestCreateEvent_InvalidRRule(t *testing.T) {
+	handlers, _ := newRecurrenceTest(t)
+
+	for _, rrule := range []string{"", "FREQ=HOURLY", "FREQ=WEEKLY;BYDAY=XX", "FREQ=WEEKLY;UNTIL=20200101"} {
+		w := createRecurringEvent(handlers, rrule, nil)
+		assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+	}
+
+	w := createRecurringEvent(handlers, "FREQ=WEEKLY", strPtr("series-1"))
+	assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+}
+
+func TestGetEvent_Occurrences(t *testing.T) {
+	handlers, eventRepo := newRecurrenceTest(t)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	handlers.timeNow = func() time.Time { return now }
+
+	rrule := "FREQ=WEEKLY"
+	start := now.AddDate(0, 0, -14).Add(8 * time.Hour)
+	if err := eventRepo.Insert(&scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Weekly Meetup",
+		CoarseGeohash: "dr5regw",
+		Status:        "scheduled",
+		StartsAt:      start,
+		RRule:         &rrule,
+	}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	getEvent := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handlers.GetEvent(w, httptest.NewRequest(http.MethodGet, "/events/event-1"+query, nil))
+		return w
+	}
+
+	w := getEvent("?occurrences=3")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		ID          string `json:"id"`
+		Occurrences []struct {
+			ID       string    `json:"id"`
+			StartsAt time.Time `json:"starts_at"`
+			RRule    *string   `json:"rrule"`
+		} `json:"occurrences"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Occurrences) != 3 {
+		t.Fatalf("expected 3 occurrences, got %d", len(response.Occurrences))
+	}
+	for i, occurrence := range response.Occurrences {
+		if want := start.AddDate(0, 0, 7*(i+2)); !occurrence.StartsAt.Equal(want) {
+			t.Errorf("occurrence %d starts at %v, want %v", i, occurrence.StartsAt, want)
+		}
+		if occurrence.ID != "event-1" || occurrence.RRule != nil {
+			t.Errorf("unexpected occurrence %+v", occurrence)
+		}
+	}
+
+	// Occurrences are only included on request
+	w = getEvent("")
+	var plain map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&plain); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := plain["occurrences"]; ok {
+		t.Error("expected no occurrences without the query parameter")
+	}
+
+	for _, query := range []string{"?occurrences=0", "?occurrences=51", "?occurrences=many"} {
+		assertErrorResponse(t, getEvent(query), http.StatusBadRequest, ErrCodeValidation)
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// rsvpSummaryStatuses are the statuses always present in an RSVP summary,
+// reported as zero when an event has none.
+var rsvpSummaryStatuses = []string{scene.RSVPStatusGoing, scene.RSVPStatusMaybe, scene.RSVPStatusNotGoing, scene.RSVPStatusWaitlisted}
+
+// RSVPSummaryResponse is the response body for GET /events/{id}/rsvps/summary.
+type RSVPSummaryResponse struct {
+	EventID string         `json:"event_id"`
+	Counts  map[string]int `json:"counts"` // RSVP count per status
+	Total   int            `json:"total"`  // Sum of all counts
+}
+
+// GetEventRSVPSummary handles GET /events/{id}/rsvps/summary - returns the
+// event's RSVP counts grouped by status, aggregated by the repository so the
+// RSVPs themselves are never loaded. Visible to the same callers as
+// GET /events/{id}.
+func (h *EventHandlers) GetEventRSVPSummary(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
+	if len(pathParts) != 3 || pathParts[0] == "" || pathParts[1] != "rsvps" || pathParts[2] != "summary" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Event ID is required")
+		return
+	}
+	eventID := pathParts[0]
+
+	if foundEvent, _ := h.getVisibleEvent(w, r, eventID); foundEvent == nil {
+		return
+	}
+
+	counts, err := h.rsvpRepo.CountByStatus(eventID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to count RSVPs", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVP counts")
+		return
+	}
+
+	response := RSVPSummaryResponse{
+		EventID: eventID,
+		Counts:  make(map[string]int, len(rsvpSummaryStatuses)),
+	}
+	for _, status := range rsvpSummaryStatuses {
+		response.Counts[status] = 0
+	}
+	for status, count := range counts {
+		response.Counts[status] = count
+		response.Total += count
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// getRSVPSummary requests event-1's RSVP summary as userDID.
+func getRSVPSummary(handlers *EventHandlers, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/events/event-1/rsvps/summary", nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.GetEventRSVPSummary(w, req)
+	return w
+}
+
+// decodeRSVPSummary fetches and decodes event-1's RSVP summary.
+func decodeRSVPSummary(t *testing.T, handlers *EventHandlers) RSVPSummaryResponse {
+	t.Helper()
+	w := getRSVPSummary(handlers, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response RSVPSummaryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return response
+}
+
+func TestGetEventRSVPSummary_TracksRSVPChanges(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), rsvpRepo, stream.NewInMemorySessionRepository(), nil)
+	rsvpHandlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:owner")
+	insertCappedEvent(t, eventRepo, "scene-1", 2)
+
+	assertSummary := func(step string, going, maybe, notGoing, waitlisted int) {
+		t.Helper()
+		response := decodeRSVPSummary(t, handlers)
+		want := map[string]int{"going": going, "maybe": maybe, "not_going": notGoing, "waitlisted": waitlisted}
+		for status, count := range want {
+			if response.Counts[status] != count {
+				t.Errorf("%s: expected %d %s, got %d", step, count, status, response.Counts[status])
+			}
+		}
+		if len(response.Counts) != len(want) {
+			t.Errorf("%s: expected only %v, got %v", step, want, response.Counts)
+		}
+		if total := going + maybe + notGoing + waitlisted; response.Total != total {
+			t.Errorf("%s: expected total %d, got %d", step, total, response.Total)
+		}
+	}
+
+	assertSummary("no RSVPs", 0, 0, 0, 0)
+
+	rsvpAs(t, rsvpHandlers, "did:plc:a1", "going")
+	rsvpAs(t, rsvpHandlers, "did:plc:a2", "maybe")
+	assertSummary("after create", 1, 1, 0, 0)
+
+	rsvpAs(t, rsvpHandlers, "did:plc:a2", "going")
+	rsvpAs(t, rsvpHandlers, "did:plc:a3", "going")
+	assertSummary("after update", 2, 0, 0, 1)
+
+	rsvpAs(t, rsvpHandlers, "did:plc:a4", "not_going")
+	assertSummary("after declining", 2, 0, 1, 1)
+
+	deleteRSVPAs(t, rsvpHandlers, "did:plc:a1")
+	assertSummary("after delete", 2, 0, 1, 0)
+}
+
+func TestGetEventRSVPSummary_Visibility(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:owner")
+
+	proposer := "did:plc:member"
+	event := &scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Proposed Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+		Status:        scene.EventStatusPendingApproval,
+		ProposedByDID: &proposer,
+	}
+	if err := eventRepo.Insert(event); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		userDID  string
+		wantCode int
+	}{
+		{"anonymous", "", http.StatusNotFound},
+		{"other user", "did:plc:stranger", http.StatusNotFound},
+		{"proposer", proposer, http.StatusOK},
+		{"scene owner", "did:plc:owner", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getRSVPSummary(handlers, tt.userDID)
+			if tt.wantCode == http.StatusNotFound {
+				assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+				return
+			}
+			if w.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetEventRSVPSummary_PrivateScene(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	handlers.SetMembershipRepo(membershipRepo)
+	membersScene := createTestScene(t, sceneRepo, "scene-1", "did:plc:owner")
+	membersScene.Visibility = scene.VisibilityMembersOnly
+	if err := sceneRepo.Update(membersScene); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+	if _, err := membershipRepo.Upsert(&membership.Membership{SceneID: "scene-1", UserDID: "did:plc:member", Role: "member", Status: "active", TrustWeight: 0.5}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+	if err := eventRepo.Insert(&scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Members Night",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	// Published events in a members-only scene are hidden from non-members
+	assertErrorResponse(t, getRSVPSummary(handlers, ""), http.StatusNotFound, ErrCodeNotFound)
+	assertErrorResponse(t, getRSVPSummary(handlers, "did:plc:stranger"), http.StatusNotFound, ErrCodeNotFound)
+	if w := getRSVPSummary(handlers, "did:plc:member"); w.Code != http.StatusOK {
+		t.Errorf("expected 200 for a member, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetEventRSVPSummary_EventNotFound(t *testing.T) {
+	handlers := NewEventHandlers(scene.NewInMemoryEventRepository(), scene.NewInMemorySceneRepository(), audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+
+	w := getRSVPSummary(handlers, "did:plc:user")
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+}
@@ -0,0 +1,283 @@
+// Package api provides HTTP handlers for the Subcults API.
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/notification"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/validate"
+)
+
+// CancelSeriesResponse represents the response for POST /events/series/{seriesId}/cancel.
+type CancelSeriesResponse struct {
+	SeriesID          string   `json:"series_id"`
+	CancelledEventIDs []string `json:"cancelled_event_ids"`
+	NotifiedCount     int      `json:"notified_count"`
+}
+
+// NextOccurrenceResponse represents the response for GET /events/series/{seriesId}/next.
+// Next is null when the series has no upcoming, non-cancelled occurrence.
+type NextOccurrenceResponse struct {
+	SeriesID string         `json:"series_id"`
+	Next     *EventResponse `json:"next"`
+}
+
+// EventSeriesHandlers holds dependencies for recurring event series HTTP handlers.
+type EventSeriesHandlers struct {
+	eventRepo scene.EventRepository
+	sceneRepo scene.SceneRepository
+	rsvpRepo  scene.RSVPRepository
+	auditRepo audit.Repository
+	outbox    notification.Outbox
+	timeNow   func() time.Time // For testability
+
+	membershipRepo membership.MembershipRepository // Optional: grants members access to members-only series
+}
+
+// NewEventSeriesHandlers creates a new EventSeriesHandlers instance.
+func NewEventSeriesHandlers(eventRepo scene.EventRepository, sceneRepo scene.SceneRepository, rsvpRepo scene.RSVPRepository, auditRepo audit.Repository, outbox notification.Outbox) *EventSeriesHandlers {
+	return &EventSeriesHandlers{
+		eventRepo: eventRepo,
+		sceneRepo: sceneRepo,
+		rsvpRepo:  rsvpRepo,
+		auditRepo: auditRepo,
+		outbox:    outbox,
+		timeNow:   time.Now,
+	}
+}
+
+// SetMembershipRepo installs the membership repository consulted when a
+// members-only scene's series is requested. Without it, only the scene owner
+// can see such series.
+func (h *EventSeriesHandlers) SetMembershipRepo(repo membership.MembershipRepository) {
+	h.membershipRepo = repo
+}
+
+// CancelSeries handles POST /events/series/{seriesId}/cancel - cancels all future
+// occurrences of a recurring series. Past occurrences are left untouched.
+// Idempotent: re-cancelling a series cancels nothing and sends no notifications.
+func (h *EventSeriesHandlers) CancelSeries(w http.ResponseWriter, r *http.Request) {
+	// Expected path: /events/series/{seriesId}/cancel
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/series/"), "/")
+	if len(pathParts) == 0 || pathParts[0] == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Series ID is required")
+		return
+	}
+	seriesID := pathParts[0]
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Parse request body (optional reason)
+	var req CancelEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+		return
+	}
+
+	// Sanitize reason if provided to prevent HTML injection
+	if req.Reason != nil {
+		sanitized := validate.SanitizeHTML(*req.Reason)
+		req.Reason = &sanitized
+	}
+
+	occurrences, err := h.eventRepo.ListBySeries(seriesID)
+	if err != nil {
+		if err == scene.ErrSeriesNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Series not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to list series occurrences", "error", err, "series_id", seriesID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve series")
+		return
+	}
+
+	// All occurrences of a series share a scene; only its owner may cancel
+	sceneID := occurrences[0].SceneID
+	foundScene, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to check scene ownership", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify scene ownership")
+		return
+	}
+	if !foundScene.IsOwner(userDID) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to cancel this series")
+		return
+	}
+
+	cancelled, err := h.eventRepo.CancelSeriesFrom(seriesID, h.timeNow(), req.Reason)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to cancel series", "error", err, "series_id", seriesID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to cancel series")
+		return
+	}
+
+	// Collect RSVP holders across all newly cancelled occurrences so each
+	// attendee receives a single notification for the series
+	cancelledIDs := make([]string, 0, len(cancelled))
+	recipients := make(map[string]bool)
+	for _, event := range cancelled {
+		cancelledIDs = append(cancelledIDs, event.ID)
+
+		if err := audit.LogAccessFromRequest(r, h.auditRepo, "event", event.ID, "event_cancel", audit.OutcomeSuccess); err != nil {
+			slog.ErrorContext(r.Context(), "failed to log event cancellation", "error", err, "event_id", event.ID)
+		}
+
+		rsvps, err := h.rsvpRepo.ListByEvent(event.ID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to list rsvps for cancelled event", "error", err, "event_id", event.ID)
+			continue
+		}
+		for _, rsvp := range rsvps {
+			// Users who declined don't need to hear about the cancellation
+			if rsvp.Status == scene.RSVPStatusNotGoing {
+				continue
+			}
+			recipients[rsvp.UserID] = true
+		}
+	}
+
+	recipientDIDs := make([]string, 0, len(recipients))
+	for did := range recipients {
+		recipientDIDs = append(recipientDIDs, did)
+	}
+	sort.Strings(recipientDIDs)
+
+	notified := 0
+	for _, did := range recipientDIDs {
+		payload := map[string]string{
+			"series_id":       seriesID,
+			"scene_id":        sceneID,
+			"cancelled_count": strconv.Itoa(len(cancelled)),
+		}
+		if req.Reason != nil {
+			payload["reason"] = *req.Reason
+		}
+		if err := h.outbox.Enqueue(&notification.Notification{
+			RecipientDID: did,
+			Type:         notification.TypeEventSeriesCancelled,
+			SubjectID:    seriesID,
+			Payload:      payload,
+		}); err != nil {
+			slog.ErrorContext(r.Context(), "failed to enqueue series cancellation notification", "error", err, "series_id", seriesID)
+			continue
+		}
+		notified++
+	}
+
+	response := CancelSeriesResponse{
+		SeriesID:          seriesID,
+		CancelledEventIDs: cancelledIDs,
+		NotifiedCount:     notified,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		return
+	}
+}
+
+// GetNextOccurrence handles GET /events/series/{seriesId}/next - returns the
+// soonest occurrence of a recurring series that has not started yet, skipping
+// cancelled and unpublished occurrences. A series whose occurrences are all past
+// or cancelled responds 200 OK with a null next. Series in scenes the caller
+// cannot see respond 404, as if they did not exist.
+func (h *EventSeriesHandlers) GetNextOccurrence(w http.ResponseWriter, r *http.Request) {
+	// Expected path: /events/series/{seriesId}/next
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/series/"), "/")
+	if len(pathParts) == 0 || pathParts[0] == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Series ID is required")
+		return
+	}
+	seriesID := pathParts[0]
+
+	occurrences, err := h.eventRepo.ListBySeries(seriesID)
+	if err != nil {
+		if err == scene.ErrSeriesNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Series not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to list series occurrences", "error", err, "series_id", seriesID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve series")
+		return
+	}
+
+	// A series lives in one scene; apply its visibility rules
+	if len(occurrences) > 0 {
+		seriesScene, err := h.sceneRepo.GetByID(occurrences[0].SceneID)
+		if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+			slog.ErrorContext(r.Context(), "failed to get scene", "error", err, "scene_id", occurrences[0].SceneID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve series")
+			return
+		}
+		canAccess := false
+		if err == nil {
+			canAccess, err = sceneVisibleTo(h.membershipRepo, seriesScene, middleware.GetUserDID(r.Context()))
+			if err != nil {
+				slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", seriesScene.ID)
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+				WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check access permissions")
+				return
+			}
+		}
+		// Use uniform error message - same as "not found" to prevent enumeration
+		if !canAccess {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Series not found")
+			return
+		}
+	}
+
+	response := NextOccurrenceResponse{SeriesID: seriesID}
+
+	// Occurrences are sorted by start time, so the first upcoming one is the next
+	now := h.timeNow()
+	for _, event := range occurrences {
+		if !event.StartsAt.After(now) || event.IsCancelled() || !event.IsPublished() {
+			continue
+		}
+		next := newEventResponse(event)
+		response.Next = &next
+		break
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		return
+	}
+}
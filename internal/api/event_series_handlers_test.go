@@ -0,0 +1,317 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/notification"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// seriesTestFixture holds repositories seeded with a series of four weekly occurrences:
+// one in the past and three in the future.
+type seriesTestFixture struct {
+	handlers  *EventSeriesHandlers
+	eventRepo *scene.InMemoryEventRepository
+	outbox    *notification.InMemoryOutbox
+}
+
+func setupSeriesTest(t *testing.T) *seriesTestFixture {
+	t.Helper()
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	outbox := notification.NewInMemoryOutbox()
+	handlers := NewEventSeriesHandlers(eventRepo, sceneRepo, rsvpRepo, audit.NewInMemoryRepository(), outbox)
+
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:host")
+
+	seriesID := "series-1"
+	now := time.Now()
+	starts := []time.Time{
+		now.Add(-7 * 24 * time.Hour),
+		now.Add(7 * 24 * time.Hour),
+		now.Add(14 * 24 * time.Hour),
+		now.Add(21 * 24 * time.Hour),
+	}
+	ids := []string{"past", "future-1", "future-2", "future-3"}
+	for i, startsAt := range starts {
+		if err := eventRepo.Insert(&scene.Event{
+			ID:            ids[i],
+			SceneID:       "scene-1",
+			Title:         "Weekly Session",
+			CoarseGeohash: "dr5regw",
+			Status:        "scheduled",
+			StartsAt:      startsAt,
+			SeriesID:      &seriesID,
+		}); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+
+	// did:plc:alice RSVPs to two future occurrences and must be notified once;
+	// did:plc:bob only RSVPed to the past occurrence and must not be notified.
+	rsvps := []scene.RSVP{
+		{EventID: "future-1", UserID: "did:plc:alice", Status: "going"},
+		{EventID: "future-2", UserID: "did:plc:alice", Status: "maybe"},
+		{EventID: "future-3", UserID: "did:plc:carol", Status: "going"},
+		{EventID: "past", UserID: "did:plc:bob", Status: "going"},
+	}
+	for i := range rsvps {
+		if _, err := rsvpRepo.Upsert(&rsvps[i]); err != nil {
+			t.Fatalf("failed to upsert rsvp: %v", err)
+		}
+	}
+
+	return &seriesTestFixture{handlers: handlers, eventRepo: eventRepo, outbox: outbox}
+}
+
+func doCancelSeries(t *testing.T, h *EventSeriesHandlers, userDID string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, _ := json.Marshal(CancelEventRequest{Reason: strPtr("Venue closed")})
+	req := httptest.NewRequest(http.MethodPost, "/events/series/series-1/cancel", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	h.CancelSeries(w, req)
+	return w
+}
+
+func countNotifications(t *testing.T, outbox *notification.InMemoryOutbox, did string) int {
+	t.Helper()
+	notifications, err := outbox.ListByRecipient(did)
+	if err != nil {
+		t.Fatalf("ListByRecipient() error = %v", err)
+	}
+	return len(notifications)
+}
+
+func TestCancelSeries_CancelsFutureOccurrencesOnly(t *testing.T) {
+	f := setupSeriesTest(t)
+
+	w := doCancelSeries(t, f.handlers, "did:plc:host")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp CancelSeriesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.CancelledEventIDs) != 3 {
+		t.Errorf("expected 3 cancelled occurrences, got %v", resp.CancelledEventIDs)
+	}
+	if resp.NotifiedCount != 2 {
+		t.Errorf("expected 2 notifications, got %d", resp.NotifiedCount)
+	}
+
+	past, err := f.eventRepo.GetByID("past")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if past.Status == "cancelled" {
+		t.Error("expected past occurrence to remain untouched")
+	}
+	for _, id := range []string{"future-1", "future-2", "future-3"} {
+		event, err := f.eventRepo.GetByID(id)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if event.Status != "cancelled" || event.CancellationReason == nil || *event.CancellationReason != "Venue closed" {
+			t.Errorf("expected %s to be cancelled with reason, got status=%s", id, event.Status)
+		}
+	}
+
+	if got := countNotifications(t, f.outbox, "did:plc:alice"); got != 1 {
+		t.Errorf("expected alice to receive 1 deduped notification, got %d", got)
+	}
+	if got := countNotifications(t, f.outbox, "did:plc:carol"); got != 1 {
+		t.Errorf("expected carol to receive 1 notification, got %d", got)
+	}
+	if got := countNotifications(t, f.outbox, "did:plc:bob"); got != 0 {
+		t.Errorf("expected bob (past RSVP only) to receive no notification, got %d", got)
+	}
+}
+
+func TestCancelSeries_NonHostForbidden(t *testing.T) {
+	f := setupSeriesTest(t)
+
+	w := doCancelSeries(t, f.handlers, "did:plc:alice")
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", w.Code)
+	}
+
+	event, err := f.eventRepo.GetByID("future-1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if event.Status == "cancelled" {
+		t.Error("expected occurrence to remain scheduled after rejected cancel")
+	}
+}
+
+func TestCancelSeries_Idempotent(t *testing.T) {
+	f := setupSeriesTest(t)
+
+	if w := doCancelSeries(t, f.handlers, "did:plc:host"); w.Code != http.StatusOK {
+		t.Fatalf("first cancel: expected status 200, got %d", w.Code)
+	}
+
+	w := doCancelSeries(t, f.handlers, "did:plc:host")
+	if w.Code != http.StatusOK {
+		t.Fatalf("second cancel: expected status 200, got %d", w.Code)
+	}
+
+	var resp CancelSeriesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.CancelledEventIDs) != 0 || resp.NotifiedCount != 0 {
+		t.Errorf("expected re-cancel to be a no-op, got %+v", resp)
+	}
+	if got := countNotifications(t, f.outbox, "did:plc:alice"); got != 1 {
+		t.Errorf("expected alice to still have 1 notification, got %d", got)
+	}
+}
+
+func TestCancelSeries_NotFound(t *testing.T) {
+	f := setupSeriesTest(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/events/series/missing/cancel", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:host"))
+	w := httptest.NewRecorder()
+	f.handlers.CancelSeries(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+func doGetNextOccurrence(t *testing.T, h *EventSeriesHandlers, seriesID string) (*httptest.ResponseRecorder, NextOccurrenceResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/events/series/"+seriesID+"/next", nil)
+	w := httptest.NewRecorder()
+	h.GetNextOccurrence(w, req)
+
+	var resp NextOccurrenceResponse
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+func TestGetNextOccurrence_ReturnsSoonestUpcoming(t *testing.T) {
+	f := setupSeriesTest(t)
+
+	w, resp := doGetNextOccurrence(t, f.handlers, "series-1")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.SeriesID != "series-1" {
+		t.Errorf("expected series_id series-1, got %q", resp.SeriesID)
+	}
+	if resp.Next == nil || resp.Next.ID != "future-1" {
+		t.Fatalf("expected next occurrence future-1, got %+v", resp.Next)
+	}
+	stored, err := f.eventRepo.GetByID("future-1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !resp.Next.StartsAt.Time.Equal(stored.StartsAt.Truncate(time.Second)) {
+		t.Errorf("expected starts_at %v, got %v", stored.StartsAt, resp.Next.StartsAt.Time)
+	}
+}
+
+func TestGetNextOccurrence_SkipsCancelledOccurrence(t *testing.T) {
+	f := setupSeriesTest(t)
+	if err := f.eventRepo.Cancel("future-1", nil); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	w, resp := doGetNextOccurrence(t, f.handlers, "series-1")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Next == nil || resp.Next.ID != "future-2" {
+		t.Errorf("expected next occurrence future-2, got %+v", resp.Next)
+	}
+}
+
+func TestGetNextOccurrence_ExhaustedSeries(t *testing.T) {
+	f := setupSeriesTest(t)
+
+	// Every occurrence has passed
+	f.handlers.timeNow = func() time.Time { return time.Now().Add(30 * 24 * time.Hour) }
+	w, resp := doGetNextOccurrence(t, f.handlers, "series-1")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Next != nil {
+		t.Errorf("expected no next occurrence for a past series, got %+v", resp.Next)
+	}
+	if !bytes.Contains(w.Body.Bytes(), []byte(`"next":null`)) {
+		t.Errorf("expected an explicit null next, got %s", w.Body.String())
+	}
+
+	// Every upcoming occurrence was cancelled
+	f.handlers.timeNow = time.Now
+	if w := doCancelSeries(t, f.handlers, "did:plc:host"); w.Code != http.StatusOK {
+		t.Fatalf("CancelSeries: expected status 200, got %d", w.Code)
+	}
+	if _, resp := doGetNextOccurrence(t, f.handlers, "series-1"); resp.Next != nil {
+		t.Errorf("expected no next occurrence for a cancelled series, got %+v", resp.Next)
+	}
+
+	if w, _ := doGetNextOccurrence(t, f.handlers, "missing"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for an unknown series, got %d", w.Code)
+	}
+}
+
+func TestGetNextOccurrence_HiddenFromNonMembers(t *testing.T) {
+	f := setupSeriesTest(t)
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	f.handlers.SetMembershipRepo(membershipRepo)
+
+	seriesScene, err := f.handlers.sceneRepo.GetByID("scene-1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	seriesScene.Visibility = scene.VisibilityMembersOnly
+	if err := f.handlers.sceneRepo.Update(seriesScene); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err := membershipRepo.Upsert(&membership.Membership{SceneID: "scene-1", UserDID: "did:plc:member", Role: "member", Status: "active"}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	getAs := func(userDID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/events/series/series-1/next", nil)
+		if userDID != "" {
+			req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+		}
+		w := httptest.NewRecorder()
+		f.handlers.GetNextOccurrence(w, req)
+		return w
+	}
+
+	for _, userDID := range []string{"", "did:plc:stranger"} {
+		w := getAs(userDID)
+		assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+		if bytes.Contains(w.Body.Bytes(), []byte("future-1")) {
+			t.Errorf("response leaked the occurrence to %q: %s", userDID, w.Body.String())
+		}
+	}
+	for _, userDID := range []string{"did:plc:member", "did:plc:host"} {
+		if w := getAs(userDID); w.Code != http.StatusOK {
+			t.Errorf("expected status 200 for %q, got %d: %s", userDID, w.Code, w.Body.String())
+		}
+	}
+}
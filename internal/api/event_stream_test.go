@@ -28,7 +28,7 @@ func TestGetEvent_WithActiveStream(t *testing.T) {
 	startsAt := time.Now().Add(1 * time.Hour)
 	testEvent := &scene.Event{
 		ID:            eventID,
-		SceneID:       uuid.New().String(),
+		SceneID:       createTestScene(t, sceneRepo, uuid.New().String(), "did:plc:owner").ID,
 		Title:         "Test Event with Stream",
 		CoarseGeohash: "dr5regw",
 		Status:        "scheduled",
@@ -91,7 +91,7 @@ func TestGetEvent_WithEndedStream(t *testing.T) {
 	startsAt := time.Now().Add(1 * time.Hour)
 	testEvent := &scene.Event{
 		ID:            eventID,
-		SceneID:       uuid.New().String(),
+		SceneID:       createTestScene(t, sceneRepo, uuid.New().String(), "did:plc:owner").ID,
 		Title:         "Test Event with Ended Stream",
 		CoarseGeohash: "dr5regw",
 		Status:        "scheduled",
@@ -148,7 +148,7 @@ func TestGetEvent_WithNoStream(t *testing.T) {
 	startsAt := time.Now().Add(1 * time.Hour)
 	testEvent := &scene.Event{
 		ID:            eventID,
-		SceneID:       uuid.New().String(),
+		SceneID:       createTestScene(t, sceneRepo, uuid.New().String(), "did:plc:owner").ID,
 		Title:         "Test Event without Stream",
 		CoarseGeohash: "dr5regw",
 		Status:        "scheduled",
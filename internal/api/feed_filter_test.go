@@ -0,0 +1,48 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+func TestGetSceneFeed_Filters(t *testing.T) {
+	handlers, older, newer := newFeedSortTest(t, scene.FeedSortRanked)
+
+	sceneID := "scene-sorted"
+	other := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:other", Text: "other", Labels: []string{post.LabelNSFW}}
+	if err := handlers.repo.Create(other); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantIDs []string
+	}{
+		{name: "author", query: "?author=did:plc:author", wantIDs: []string{newer, older}},
+		{name: "exclude labels", query: "?exclude_labels=nsfw,spam", wantIDs: []string{newer, older}},
+		{name: "include labels", query: "?labels=nsfw", wantIDs: []string{other.ID}},
+		{name: "author and labels", query: "?author=did:plc:author&labels=nsfw", wantIDs: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := getSortedFeed(t, handlers, tt.query)
+			// Filtered feeds list newest first even in ranked scenes
+			if response.FeedSort != scene.FeedSortChronological {
+				t.Errorf("expected feed_sort %q, got %q", scene.FeedSortChronological, response.FeedSort)
+			}
+			got := feedPostIDs(response)
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("expected posts %v, got %v", tt.wantIDs, got)
+			}
+			for i := range got {
+				if got[i] != tt.wantIDs[i] {
+					t.Errorf("expected posts %v, got %v", tt.wantIDs, got)
+					break
+				}
+			}
+		})
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/post"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/slo"
 )
 
 // createTestSceneForFeed creates a public scene for feed testing.
@@ -91,6 +94,28 @@ func TestGetSceneFeed_Success(t *testing.T) {
 	}
 }
 
+// TestGetSceneFeed_RecordsLatencySLO tests that feed requests are recorded
+// against the feed latency SLO.
+func TestGetSceneFeed_RecordsLatencySLO(t *testing.T) {
+	handlers := newTestPostHandlers()
+	// A negative target makes every request count against the budget
+	tracker := slo.NewTracker("feed", -1, slo.DefaultWindow)
+	handlers.SetFeedLatencySLO(tracker)
+
+	createTestSceneForFeed(handlers.sceneRepo, "scene123", "did:example:owner")
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene123/feed", nil)
+	w := httptest.NewRecorder()
+	handlers.GetSceneFeed(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := tracker.BurnFraction(); got != 1 {
+		t.Errorf("expected the feed request to be recorded, burn fraction = %v", got)
+	}
+}
+
 // TestGetSceneFeed_Pagination tests cursor-based pagination.
 func TestGetSceneFeed_Pagination(t *testing.T) {
 	handlers := newTestPostHandlers()
@@ -430,3 +455,259 @@ func encodeCursorString(cursor *post.FeedCursor) string {
 	// Encode as "created_at_unix_nano:id"
 	return fmt.Sprintf("%d:%s", cursor.CreatedAt.UnixNano(), cursor.ID)
 }
+
+// TestGetSceneFeed_NotModified tests that an up-to-date client receives 304.
+func TestGetSceneFeed_NotModified(t *testing.T) {
+	handlers := newTestPostHandlers()
+
+	sceneID := "scene123"
+	createTestSceneForFeed(handlers.sceneRepo, sceneID, "did:example:owner")
+	seedTestPosts(handlers.repo, sceneID, "event123", 3)
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/"+sceneID+"/feed", nil)
+	w := httptest.NewRecorder()
+	handlers.GetSceneFeed(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	lastModified := w.Header().Get("Last-Modified")
+	if lastModified == "" {
+		t.Fatal("expected Last-Modified header to be set")
+	}
+
+	// Replay with the returned Last-Modified value
+	req2 := httptest.NewRequest(http.MethodGet, "/scenes/"+sceneID+"/feed", nil)
+	req2.Header.Set("If-Modified-Since", lastModified)
+	w2 := httptest.NewRecorder()
+	handlers.GetSceneFeed(w2, req2)
+
+	if w2.Code != http.StatusNotModified {
+		t.Fatalf("expected status 304, got %d: %s", w2.Code, w2.Body.String())
+	}
+	if w2.Body.Len() != 0 {
+		t.Errorf("expected empty body for 304, got %q", w2.Body.String())
+	}
+}
+
+// TestGetSceneFeed_ModifiedSinceStale tests that a stale client receives data.
+func TestGetSceneFeed_ModifiedSinceStale(t *testing.T) {
+	handlers := newTestPostHandlers()
+
+	sceneID := "scene123"
+	createTestSceneForFeed(handlers.sceneRepo, sceneID, "did:example:owner")
+	seedTestPosts(handlers.repo, sceneID, "event123", 3)
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/"+sceneID+"/feed", nil)
+	req.Header.Set("If-Modified-Since", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	w := httptest.NewRecorder()
+	handlers.GetSceneFeed(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response FeedResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Posts) != 3 {
+		t.Errorf("expected 3 posts, got %d", len(response.Posts))
+	}
+}
+
+// TestGetEventFeed_NewPostUpdatesLastModified tests that a new post advances Last-Modified
+// and invalidates a previously up-to-date client.
+func TestGetEventFeed_NewPostUpdatesLastModified(t *testing.T) {
+	handlers := newTestPostHandlers()
+
+	eventID := "event123"
+	seedTestPosts(handlers.repo, "scene123", eventID, 1)
+
+	req := httptest.NewRequest(http.MethodGet, "/events/"+eventID+"/feed", nil)
+	w := httptest.NewRecorder()
+	handlers.GetEventFeed(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	firstModified, err := http.ParseTime(w.Header().Get("Last-Modified"))
+	if err != nil {
+		t.Fatalf("failed to parse Last-Modified: %v", err)
+	}
+
+	// HTTP dates have second precision; wait for the next second boundary
+	time.Sleep(time.Until(firstModified.Add(time.Second)))
+	seedTestPosts(handlers.repo, "scene123", eventID, 1)
+
+	req2 := httptest.NewRequest(http.MethodGet, "/events/"+eventID+"/feed", nil)
+	req2.Header.Set("If-Modified-Since", firstModified.Format(http.TimeFormat))
+	w2 := httptest.NewRecorder()
+	handlers.GetEventFeed(w2, req2)
+
+	if w2.Code != http.StatusOK {
+		t.Fatalf("expected status 200 after new post, got %d", w2.Code)
+	}
+	secondModified, err := http.ParseTime(w2.Header().Get("Last-Modified"))
+	if err != nil {
+		t.Fatalf("failed to parse Last-Modified: %v", err)
+	}
+	if !secondModified.After(firstModified) {
+		t.Errorf("expected Last-Modified to advance, got %v then %v", firstModified, secondModified)
+	}
+
+	var response FeedResponse
+	if err := json.NewDecoder(w2.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Posts) != 2 {
+		t.Errorf("expected 2 posts, got %d", len(response.Posts))
+	}
+}
+
+// windowRecordingRepo records the time window of each feed listing.
+type windowRecordingRepo struct {
+	*post.InMemoryPostRepository
+	windows []post.FeedWindow
+}
+
+func (r *windowRecordingRepo) ListBySceneFiltered(sceneID string, opts post.FeedOptions, limit int, cursor *post.FeedCursor) ([]*post.Post, *post.FeedCursor, error) {
+	r.windows = append(r.windows, opts.Window)
+	return r.InMemoryPostRepository.ListBySceneFiltered(sceneID, opts, limit, cursor)
+}
+
+func (r *windowRecordingRepo) ListByEvent(eventID string, window post.FeedWindow, limit int, cursor *post.FeedCursor) ([]*post.Post, *post.FeedCursor, error) {
+	r.windows = append(r.windows, window)
+	return r.InMemoryPostRepository.ListByEvent(eventID, window, limit, cursor)
+}
+
+// newWindowTestHandlers creates post handlers over a public scene123 with
+// three posts, also in event123, whose repository records feed windows.
+func newWindowTestHandlers(t *testing.T) (*PostHandlers, *windowRecordingRepo) {
+	t.Helper()
+	repo := &windowRecordingRepo{InMemoryPostRepository: post.NewInMemoryPostRepository()}
+	sceneRepo := scene.NewInMemorySceneRepository()
+	createTestSceneForFeed(sceneRepo, "scene123", "did:example:owner")
+	seedTestPosts(repo, "scene123", "event123", 3)
+	return NewPostHandlers(repo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil), repo
+}
+
+// serveFeed serves GET target with the scene or event feed handler.
+func serveFeed(handlers *PostHandlers, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if strings.HasPrefix(target, "/events/") {
+		handlers.GetEventFeed(w, req)
+	} else {
+		handlers.GetSceneFeed(w, req)
+	}
+	return w
+}
+
+// TestGetFeeds_TimeWindow tests that since and until are passed to the
+// repository as the feed window.
+func TestGetFeeds_TimeWindow(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 8, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		want  post.FeedWindow
+	}{
+		{"since and until", "?since=2026-03-01T00:00:00Z&until=2026-03-08T12:30:00Z", post.FeedWindow{Since: since, Until: until}},
+		{"since only", "?since=2026-03-01T00:00:00Z", post.FeedWindow{Since: since}},
+		{"until only", "?until=2026-03-08T12:30:00Z", post.FeedWindow{Until: until}},
+		{"no window", "", post.FeedWindow{}},
+	}
+	for _, tt := range tests {
+		for _, target := range []string{"/scenes/scene123/feed", "/events/event123/feed"} {
+			t.Run(tt.name+" "+target, func(t *testing.T) {
+				handlers, repo := newWindowTestHandlers(t)
+				if w := serveFeed(handlers, target+tt.query); w.Code != http.StatusOK {
+					t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+				}
+				if len(repo.windows) != 1 {
+					t.Fatalf("expected one feed listing, got %d", len(repo.windows))
+				}
+				if got := repo.windows[0]; !got.Since.Equal(tt.want.Since) || !got.Until.Equal(tt.want.Until) {
+					t.Errorf("window = %+v, want %+v", got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+// TestGetFeeds_DefaultTimeWindow tests that the default window applies only
+// when a request sets neither since nor until.
+func TestGetFeeds_DefaultTimeWindow(t *testing.T) {
+	for _, target := range []string{"/scenes/scene123/feed", "/events/event123/feed"} {
+		t.Run(target, func(t *testing.T) {
+			handlers, repo := newWindowTestHandlers(t)
+			handlers.SetDefaultFeedWindow(7 * 24 * time.Hour)
+
+			before := time.Now()
+			if w := serveFeed(handlers, target); w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			after := time.Now()
+
+			window := repo.windows[0]
+			if window.Since.Before(before.Add(-7*24*time.Hour)) || window.Since.After(after.Add(-7*24*time.Hour)) || !window.Until.IsZero() {
+				t.Errorf("window = %+v, want since 7 days ago and no until", window)
+			}
+			// Posts from the last week are still listed
+			var response FeedResponse
+			w := serveFeed(handlers, target)
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(response.Posts) != 3 {
+				t.Errorf("expected 3 recent posts, got %d", len(response.Posts))
+			}
+
+			// An explicit bound overrides the default
+			if w := serveFeed(handlers, target+"?until=2026-03-08T12:30:00Z"); w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if window := repo.windows[len(repo.windows)-1]; !window.Since.IsZero() {
+				t.Errorf("window = %+v, want no since when until is given", window)
+			}
+		})
+	}
+}
+
+// TestGetFeeds_InvalidTimeWindow tests validation of since and until.
+func TestGetFeeds_InvalidTimeWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantCode string
+	}{
+		{"malformed since", "?since=yesterday", ErrCodeValidation},
+		{"malformed until", "?until=2026-13-01T00:00:00Z", ErrCodeValidation},
+		{"since after until", "?since=2026-03-02T00:00:00Z&until=2026-03-01T00:00:00Z", ErrCodeInvalidTimeRange},
+		{"since equals until", "?since=2026-03-01T00:00:00Z&until=2026-03-01T00:00:00Z", ErrCodeInvalidTimeRange},
+	}
+	for _, tt := range tests {
+		for _, target := range []string{"/scenes/scene123/feed", "/events/event123/feed"} {
+			t.Run(tt.name+" "+target, func(t *testing.T) {
+				handlers, repo := newWindowTestHandlers(t)
+				w := serveFeed(handlers, target+tt.query)
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+				}
+				var errResp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+					t.Fatalf("failed to decode error response: %v", err)
+				}
+				if errResp.Error.Code != tt.wantCode {
+					t.Errorf("error code = %q, want %q", errResp.Error.Code, tt.wantCode)
+				}
+				if len(repo.windows) != 0 {
+					t.Errorf("expected no feed listing for an invalid window, got %d", len(repo.windows))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// newFeedSortTest creates post handlers over a public scene with the given feed
+// sort, holding an older post with ten quote reposts and a newer post with none.
+// Returns the handlers and the (older, newer) post IDs.
+func newFeedSortTest(t *testing.T, feedSort string) (*PostHandlers, string, string) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	if err := sceneRepo.Insert(&scene.Scene{
+		ID:            "scene-sorted",
+		Name:          "Sorted Scene",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		Visibility:    scene.VisibilityPublic,
+		FeedSort:      feedSort,
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	postRepo := post.NewInMemoryPostRepository()
+	create := func(p *post.Post) string {
+		t.Helper()
+		if err := postRepo.Create(p); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+		return p.ID
+	}
+	sceneID, elsewhere := "scene-sorted", "scene-elsewhere"
+	older := create(&post.Post{SceneID: &sceneID, AuthorDID: "did:plc:author", Text: "older"})
+	for i := 0; i < 10; i++ {
+		create(&post.Post{SceneID: &elsewhere, AuthorDID: "did:plc:quoter", Text: "quoting", QuotedPostID: &older})
+	}
+	newer := create(&post.Post{SceneID: &sceneID, AuthorDID: "did:plc:author", Text: "newer"})
+
+	return NewPostHandlers(postRepo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil), older, newer
+}
+
+func getSortedFeed(t *testing.T, handlers *PostHandlers, query string) FeedResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handlers.GetSceneFeed(w, httptest.NewRequest(http.MethodGet, "/scenes/scene-sorted/feed"+query, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response FeedResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+func feedPostIDs(response FeedResponse) []string {
+	ids := make([]string, len(response.Posts))
+	for i, p := range response.Posts {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestGetSceneFeed_FeedSort(t *testing.T) {
+	tests := []struct {
+		name       string
+		sceneSort  string
+		query      string
+		wantSort   string
+		wantRanked bool
+	}{
+		{name: "default is chronological", wantSort: scene.FeedSortChronological},
+		{name: "explicit chronological", sceneSort: scene.FeedSortChronological, wantSort: scene.FeedSortChronological},
+		{name: "ranked scene", sceneSort: scene.FeedSortRanked, wantSort: scene.FeedSortRanked, wantRanked: true},
+		{name: "request overrides to ranked", query: "?sort=ranked", wantSort: scene.FeedSortRanked, wantRanked: true},
+		{name: "request overrides to chronological", sceneSort: scene.FeedSortRanked, query: "?sort=chronological", wantSort: scene.FeedSortChronological},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers, older, newer := newFeedSortTest(t, tt.sceneSort)
+
+			response := getSortedFeed(t, handlers, tt.query)
+			if response.FeedSort != tt.wantSort {
+				t.Errorf("feed_sort = %q, want %q", response.FeedSort, tt.wantSort)
+			}
+			want := []string{newer, older}
+			if tt.wantRanked {
+				want = []string{older, newer}
+			}
+			if got := feedPostIDs(response); !reflect.DeepEqual(got, want) {
+				t.Errorf("feed order = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGetSceneFeed_RankedPagination(t *testing.T) {
+	handlers, older, newer := newFeedSortTest(t, scene.FeedSortRanked)
+
+	first := getSortedFeed(t, handlers, "?limit=1")
+	if first.NextRankCursor == "" || first.NextCursor != nil {
+		t.Fatalf("expected only a rank cursor on the first page, got %+v", first)
+	}
+	second := getSortedFeed(t, handlers, "?limit=1&cursor="+first.NextRankCursor)
+	if second.NextRankCursor != "" {
+		t.Errorf("expected last page, got cursor %q", second.NextRankCursor)
+	}
+
+	if got, want := append(feedPostIDs(first), feedPostIDs(second)...), []string{older, newer}; !reflect.DeepEqual(got, want) {
+		t.Errorf("paged order = %v, want %v", got, want)
+	}
+}
+
+func TestGetSceneFeed_InvalidSortOrCursor(t *testing.T) {
+	handlers, _, _ := newFeedSortTest(t, scene.FeedSortRanked)
+
+	for _, query := range []string{"?sort=popular", "?cursor=not-a-rank-cursor"} {
+		w := httptest.NewRecorder()
+		handlers.GetSceneFeed(w, httptest.NewRequest(http.MethodGet, "/scenes/scene-sorted/feed"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d: %s", query, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/onnwee/subcults/internal/identity"
+	"github.com/onnwee/subcults/internal/middleware"
+)
+
+// HandleResolveRateLimit is the per-user (or per-IP when anonymous) rate limit
+// applied to GET /identity/resolve. Lookups are cheap to issue and each one may
+// reach DNS and the PLC directory, so the cap keeps handle enumeration slow.
+var HandleResolveRateLimit = middleware.RateLimitConfig{
+	RequestsPerWindow: 30,
+	WindowDuration:    time.Minute,
+}
+
+// IdentityHandlers holds dependencies for identity HTTP handlers.
+type IdentityHandlers struct {
+	resolver identity.HandleResolver
+}
+
+// NewIdentityHandlers creates a new IdentityHandlers instance.
+func NewIdentityHandlers(resolver identity.HandleResolver) *IdentityHandlers {
+	return &IdentityHandlers{resolver: resolver}
+}
+
+// ResolveHandleResponse is the DID and PDS a handle resolves to.
+type ResolveHandleResponse struct {
+	Handle string `json:"handle"`
+	DID    string `json:"did"`
+	PDS    string `json:"pds,omitempty"`
+}
+
+// ResolveHandle handles GET /identity/resolve?handle= - resolves an AT Protocol
+// handle to its DID for mention linking. A leading "@" is accepted.
+func (h *IdentityHandlers) ResolveHandle(w http.ResponseWriter, r *http.Request) {
+	raw := r.URL.Query().Get("handle")
+	if raw == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "handle parameter is required")
+		return
+	}
+	handle, err := identity.NormalizeHandle(raw)
+	if err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid handle")
+		return
+	}
+
+	resolution, err := h.resolver.ResolveHandle(r.Context(), handle)
+	if err != nil {
+		if errors.Is(err, identity.ErrHandleNotFound) || errors.Is(err, identity.ErrUnsupportedMethod) {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Handle not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to resolve handle", "error", err, "handle", handle)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to resolve handle")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(ResolveHandleResponse{
+		Handle: resolution.Handle,
+		DID:    resolution.DID,
+		PDS:    resolution.PDS,
+	}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
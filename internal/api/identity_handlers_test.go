@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/identity"
+	"github.com/onnwee/subcults/internal/middleware"
+)
+
+func newIdentityHandlersTest() *IdentityHandlers {
+	resolver := identity.NewInMemoryHandleResolver()
+	resolver.Add(identity.HandleResolution{
+		Handle: "alice.example.com",
+		DID:    "did:plc:alice",
+		PDS:    "https://pds.example.com",
+	})
+	return NewIdentityHandlers(resolver)
+}
+
+func TestResolveHandle(t *testing.T) {
+	handlers := newIdentityHandlersTest()
+
+	tests := []struct {
+		name       string
+		handle     string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "resolvable", handle: "alice.example.com", wantStatus: http.StatusOK},
+		{name: "mention form", handle: "@Alice.Example.com", wantStatus: http.StatusOK},
+		{name: "unresolvable", handle: "nobody.example.com", wantStatus: http.StatusNotFound, wantCode: ErrCodeNotFound},
+		{name: "missing", handle: "", wantStatus: http.StatusBadRequest, wantCode: ErrCodeValidation},
+		{name: "invalid", handle: "not a handle", wantStatus: http.StatusBadRequest, wantCode: ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/identity/resolve", nil)
+			q := req.URL.Query()
+			q.Set("handle", tt.handle)
+			req.URL.RawQuery = q.Encode()
+			w := httptest.NewRecorder()
+
+			handlers.ResolveHandle(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantCode != "" {
+				var errResp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+					t.Fatalf("failed to decode error response: %v", err)
+				}
+				if errResp.Error.Code != tt.wantCode {
+					t.Errorf("expected error code %s, got %s", tt.wantCode, errResp.Error.Code)
+				}
+				return
+			}
+
+			var response ResolveHandleResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			want := ResolveHandleResponse{Handle: "alice.example.com", DID: "did:plc:alice", PDS: "https://pds.example.com"}
+			if response != want {
+				t.Errorf("response = %+v, want %+v", response, want)
+			}
+		})
+	}
+}
+
+func TestResolveHandle_RateLimited(t *testing.T) {
+	handlers := newIdentityHandlersTest()
+	limited := middleware.RateLimiter(middleware.NewInMemoryRateLimitStore(), HandleResolveRateLimit, middleware.UserKeyFunc(), nil)(
+		http.HandlerFunc(handlers.ResolveHandle),
+	)
+
+	resolve := func(handle string) int {
+		req := httptest.NewRequest(http.MethodGet, "/identity/resolve?handle="+handle, nil)
+		req = req.WithContext(middleware.SetUserDID(context.Background(), "did:plc:prober"))
+		w := httptest.NewRecorder()
+		limited.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Misses count toward the limit, so probing for handles is throttled
+	for i := 0; i < HandleResolveRateLimit.RequestsPerWindow; i++ {
+		if code := resolve("nobody.example.com"); code != http.StatusNotFound {
+			t.Fatalf("attempt %d: expected status 404, got %d", i+1, code)
+		}
+	}
+	if code := resolve("alice.example.com"); code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 after %d attempts, got %d", HandleResolveRateLimit.RequestsPerWindow, code)
+	}
+}
@@ -0,0 +1,10 @@
+package api
+
+// emptyIfNil returns s, or an empty slice when s is nil, so array fields in
+// responses always serialize as [] and never as null.
+func emptyIfNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// marshalFields marshals v and returns its top-level fields as raw JSON.
+func marshalFields(t *testing.T, v interface{}) map[string]json.RawMessage {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	return fields
+}
+
+func TestResponses_NilSlicesSerializeAsEmptyArrays(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		fields []string
+	}{
+		{"scene", newSceneResponse(&scene.Scene{ID: "scene-1"}), []string{"tags"}},
+		{"event", newEventResponse(&scene.Event{ID: "event-1", StartsAt: time.Now()}), []string{"tags"}},
+		{"post", newPostResponse(&post.Post{ID: "post-1"}), []string{"attachments", "labels"}},
+		{"feed", newFeedResponse(nil, nil), []string{"posts"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := marshalFields(t, tt.value)
+			for _, field := range tt.fields {
+				if got := string(fields[field]); got != "[]" {
+					t.Errorf("%s = %s, want []", field, got)
+				}
+			}
+		})
+	}
+}
+
+func TestResponses_PopulatedSlicesUnchanged(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  map[string]string
+	}{
+		{"scene", newSceneResponse(&scene.Scene{ID: "scene-1", Tags: []string{"techno", "warehouse"}}), map[string]string{"tags": `["techno","warehouse"]`}},
+		{"event", newEventResponse(&scene.Event{ID: "event-1", StartsAt: time.Now(), Tags: []string{"live"}}), map[string]string{"tags": `["live"]`}},
+		{"post", newPostResponse(&post.Post{ID: "post-1", Labels: []string{"nsfw"}, Attachments: []post.Attachment{{Key: "posts/1/a.jpg"}}}), map[string]string{
+			"labels":      `["nsfw"]`,
+			"attachments": `[{"key":"posts/1/a.jpg"}]`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := marshalFields(t, tt.value)
+			for field, want := range tt.want {
+				if got := string(fields[field]); got != want {
+					t.Errorf("%s = %s, want %s", field, got, want)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"regexp"
@@ -11,6 +12,7 @@ import (
 	"github.com/onnwee/subcults/internal/audit"
 	"github.com/onnwee/subcults/internal/livekit"
 	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
 	"github.com/onnwee/subcults/internal/stream"
 )
 
@@ -31,6 +33,9 @@ type LiveKitTokenResponse struct {
 type LiveKitHandlers struct {
 	tokenService *livekit.TokenService
 	auditRepo    audit.Repository
+	blockRepo    scene.BlockRepository    // Optional: refuses tokens to DIDs blocked in the room's scene
+	streamRepo   stream.SessionRepository // Maps rooms to their stream session, for the block check
+	eventRepo    scene.EventRepository    // Resolves event streams to their scene, for the block check
 }
 
 // NewLiveKitHandlers creates a new LiveKitHandlers instance.
@@ -41,6 +46,15 @@ func NewLiveKitHandlers(tokenService *livekit.TokenService, auditRepo audit.Repo
 	}
 }
 
+// SetBlockRepo installs the scene block list checked before issuing a token.
+// A room's scene is that of the stream session it is named after; event
+// streams use the event's scene.
+func (h *LiveKitHandlers) SetBlockRepo(repo scene.BlockRepository, streamRepo stream.SessionRepository, eventRepo scene.EventRepository) {
+	h.blockRepo = repo
+	h.streamRepo = streamRepo
+	h.eventRepo = eventRepo
+}
+
 // Room ID validation: alphanumeric, hyphens, underscores, colons (max 128 chars)
 // This prevents injection attacks and restricts to safe characters.
 var roomIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_:-]{1,128}$`)
@@ -86,8 +100,22 @@ func (h *LiveKitHandlers) IssueToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// DIDs blocked in the room's scene may not join it
+	if h.blockRepo != nil {
+		sceneID, err := h.roomSceneID(req.RoomID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to resolve room scene", "error", err, "room_id", req.RoomID)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to generate token")
+			return
+		}
+		if !checkSceneBlock(ctx, w, h.blockRepo, sceneID, userDID) {
+			return
+		}
+	}
+
 	// TODO: Future enhancement - verify membership if room is restricted
-	// For now, any authenticated user can join any room
+	// For now, any authenticated, unblocked user can join any room
 
 	// Generate participant identity using shared utility function
 	participantID := stream.GenerateParticipantID(userDID)
@@ -126,6 +154,7 @@ func (h *LiveKitHandlers) IssueToken(w http.ResponseWriter, r *http.Request) {
 	// Use "livekit_room" as entity type and room_id as entity ID
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
 		EntityType: "livekit_room",
 		EntityID:   req.RoomID,
 		Action:     "token_issued",
@@ -153,3 +182,34 @@ func (h *LiveKitHandlers) IssueToken(w http.ResponseWriter, r *http.Request) {
 		slog.ErrorContext(ctx, "failed to encode token response", "error", err)
 	}
 }
+
+// roomSceneID returns the scene of the stream session roomID is named after.
+// Returns "" for rooms not named after a session, or whose session or event no
+// longer exists.
+func (h *LiveKitHandlers) roomSceneID(roomID string) (string, error) {
+	sessionID, ok := stream.SessionIDFromRoomName(roomID)
+	if !ok || h.streamRepo == nil {
+		return "", nil
+	}
+	session, err := h.streamRepo.GetByID(sessionID)
+	if err != nil {
+		if errors.Is(err, stream.ErrStreamNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	if session.SceneID != nil && *session.SceneID != "" {
+		return *session.SceneID, nil
+	}
+	if session.EventID != nil && *session.EventID != "" && h.eventRepo != nil {
+		event, err := h.eventRepo.GetByID(*session.EventID)
+		if err != nil {
+			if errors.Is(err, scene.ErrEventNotFound) {
+				return "", nil
+			}
+			return "", err
+		}
+		return event.SceneID, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,329 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/replay"
+	"github.com/onnwee/subcults/internal/stream"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxLiveKitWebhookBytes caps the size of a LiveKit webhook payload.
+const maxLiveKitWebhookBytes = 64 << 10
+
+// SetWebhookKeyProvider installs the LiveKit API key/secret pairs used to verify
+// webhook signatures. Without one, every webhook is rejected.
+func (h *StreamHandlers) SetWebhookKeyProvider(provider auth.KeyProvider) {
+	h.webhookKeys = provider
+}
+
+// SetReplayGuard enables anti-replay checks on LiveKit webhooks: deliveries
+// created outside the guard's window are rejected, and an event ID that was
+// already processed is acknowledged without being processed again.
+func (h *StreamHandlers) SetReplayGuard(guard *replay.Guard) {
+	h.replayGuard = guard
+}
+
+// HandleLiveKitWebhook handles POST /livekit/webhook - reconciles participant
+// presence with what LiveKit reports, so a client that crashes without calling
+// /streams/{id}/leave does not stay counted as active.
+//
+// Deliveries must carry a valid LiveKit signature; unsigned or mis-signed
+// payloads are rejected with 401. With a replay guard, events created outside
+// its window are rejected with 400 and repeated event IDs are acknowledged
+// without being processed again. participant_joined and participant_left (or
+// participant_connection_aborted) record the participant's join or leave and
+// resync the session's active participant count. room_finished marks any
+// remaining participants as left and ends the session if it is still active.
+// egress_ended stores the location of a completed recording on the session.
+// Events for rooms that don't belong to a stream session are acknowledged and
+// ignored. Failures respond 500 so LiveKit redelivers the event.
+func (h *StreamHandlers) HandleLiveKitWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.webhookKeys == nil {
+		slog.WarnContext(ctx, "LiveKit webhook rejected: no signing keys configured")
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "invalid signature")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxLiveKitWebhookBytes)
+	body, err := webhook.Receive(r, h.webhookKeys)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "request body too large")
+			return
+		}
+		slog.WarnContext(ctx, "LiveKit webhook signature verification failed", "error", err)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "invalid signature")
+		return
+	}
+
+	var event livekit.WebhookEvent
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, &event); err != nil {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "invalid webhook payload")
+		return
+	}
+
+	// Log minimal event info (type and ID only, not full payload)
+	slog.InfoContext(ctx, "LiveKit webhook event received", "event_type", event.Event, "event_id", event.Id)
+
+	// LiveKit delivers events at least once, so a repeated event ID is
+	// acknowledged without reprocessing; the signed body carries created_at
+	replayID := "livekit:" + event.Id
+	if h.replayGuard != nil {
+		switch err := h.replayGuard.Check(replayID, time.Unix(event.CreatedAt, 0)); {
+		case errors.Is(err, replay.ErrReplayed):
+			slog.InfoContext(ctx, "duplicate LiveKit webhook ignored", "event_id", event.Id)
+			w.WriteHeader(http.StatusOK)
+			return
+		case err != nil:
+			slog.WarnContext(ctx, "LiveKit webhook rejected by replay guard", "event_id", event.Id, "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "stale or replayed webhook")
+			return
+		}
+	}
+
+	session, ok := h.webhookSession(ctx, &event)
+	if !ok {
+		// Not a stream session room; acknowledge so LiveKit doesn't retry
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	switch event.Event {
+	case webhook.EventParticipantJoined:
+		err = h.reconcileJoin(session.ID, event.Participant)
+	case webhook.EventParticipantLeft, webhook.EventParticipantConnectionAborted:
+		err = h.reconcileLeave(session.ID, event.Participant)
+	case webhook.EventRoomFinished:
+		err = h.reconcileRoomFinished(ctx, session)
+	case webhook.EventEgressEnded:
+		err = h.recordEgressResult(session.ID, event.EgressInfo)
+	default:
+		slog.DebugContext(ctx, "ignoring unhandled LiveKit webhook event type", "event_type", event.Event)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to reconcile LiveKit webhook event",
+			"error", err,
+			"event_type", event.Event,
+			"event_id", event.Id,
+			"stream_id", session.ID,
+		)
+		// Let LiveKit's redelivery through
+		if h.replayGuard != nil {
+			h.replayGuard.Forget(replayID)
+		}
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to process webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// webhookSession resolves the stream session a webhook event's room belongs to.
+// Returns false for rooms that aren't named after a session or whose session
+// no longer exists. Egress events name their room in the egress info.
+func (h *StreamHandlers) webhookSession(ctx context.Context, event *livekit.WebhookEvent) (*stream.Session, bool) {
+	var roomName string
+	switch {
+	case event.Room != nil:
+		roomName = event.Room.Name
+	case event.EgressInfo != nil:
+		roomName = event.EgressInfo.RoomName
+	default:
+		return nil, false
+	}
+	sessionID, ok := stream.SessionIDFromRoomName(roomName)
+	if !ok {
+		return nil, false
+	}
+	session, err := h.streamRepo.GetByID(sessionID)
+	if err != nil {
+		if !errors.Is(err, stream.ErrStreamNotFound) {
+			slog.ErrorContext(ctx, "failed to get stream session for LiveKit webhook", "error", err, "stream_id", sessionID)
+		}
+		return nil, false
+	}
+	return session, true
+}
+
+// recordEgressResult stores where a completed recording of the session was
+// written. Failed or aborted egresses, and those without a file or playlist,
+// leave the session without a recording.
+func (h *StreamHandlers) recordEgressResult(streamID string, info *livekit.EgressInfo) error {
+	if info == nil || info.Status != livekit.EgressStatus_EGRESS_COMPLETE {
+		return nil
+	}
+	location := egressLocation(info)
+	if location == "" {
+		return nil
+	}
+	return h.streamRepo.SetRecordingURL(streamID, location)
+}
+
+// egressLocation returns the location of an egress's recording: its file,
+// or its HLS playlist for segmented output.
+func egressLocation(info *livekit.EgressInfo) string {
+	for _, file := range info.GetFileResults() {
+		if file.GetLocation() != "" {
+			return file.GetLocation()
+		}
+	}
+	for _, segments := range info.GetSegmentResults() {
+		if segments.GetPlaylistLocation() != "" {
+			return segments.GetPlaylistLocation()
+		}
+	}
+	if file := info.GetFile(); file.GetLocation() != "" {
+		return file.GetLocation()
+	}
+	return info.GetSegments().GetPlaylistLocation()
+}
+
+// reconcileJoin records a participant LiveKit reports as joined. A participant
+// whose join the client already reported is left as is.
+func (h *StreamHandlers) reconcileJoin(streamID string, info *livekit.ParticipantInfo) error {
+	if h.participantRepo == nil || info == nil || info.Identity == "" {
+		return nil
+	}
+
+	// A join within the debounce window resumes the pending leave's record,
+	// as it would for a client re-join
+	if h.joinDebouncer != nil && h.joinDebouncer.Rejoin(streamID, info.Identity) {
+		if _, err := h.participantRepo.ResumeParticipant(streamID, info.Identity); err == nil {
+			return h.syncActiveCount(streamID)
+		}
+	}
+
+	participant, isReconnection, err := h.participantRepo.RecordJoin(streamID, info.Identity, participantDID(info))
+	if err != nil && !errors.Is(err, stream.ErrParticipantAlreadyActive) {
+		return err
+	}
+	if err == nil && h.eventBroadcaster != nil {
+		activeCount, _ := h.participantRepo.GetActiveCount(streamID)
+		h.eventBroadcaster.Broadcast(streamID, &stream.ParticipantStateEvent{
+			Type:            "participant_joined",
+			StreamSessionID: streamID,
+			ParticipantID:   participant.ParticipantID,
+			UserDID:         participant.UserDID,
+			Timestamp:       participant.JoinedAt,
+			IsReconnection:  isReconnection,
+			ActiveCount:     activeCount,
+		})
+	}
+	return h.syncActiveCount(streamID)
+}
+
+// reconcileLeave records a participant LiveKit reports as gone. A participant
+// whose leave the client already reported is left as is.
+func (h *StreamHandlers) reconcileLeave(streamID string, info *livekit.ParticipantInfo) error {
+	if h.participantRepo == nil || info == nil || info.Identity == "" {
+		return nil
+	}
+	if err := h.recordWebhookLeave(streamID, info.Identity, participantDID(info), true); err != nil {
+		return err
+	}
+	return h.syncActiveCount(streamID)
+}
+
+// reconcileRoomFinished marks every remaining participant as left and ends the
+// session if it is still active.
+func (h *StreamHandlers) reconcileRoomFinished(ctx context.Context, session *stream.Session) error {
+	if h.participantRepo != nil {
+		active, err := h.participantRepo.GetActiveParticipants(session.ID)
+		if err != nil {
+			return err
+		}
+		for _, p := range active {
+			if err := h.recordWebhookLeave(session.ID, p.ParticipantID, p.UserDID, false); err != nil {
+				return err
+			}
+		}
+		if err := h.syncActiveCount(session.ID); err != nil {
+			return err
+		}
+	}
+
+	if session.EndedAt != nil {
+		return nil
+	}
+	if _, err := h.endSession(ctx, session, ""); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "ended stream session after LiveKit room finished", "stream_id", session.ID)
+	return nil
+}
+
+// recordWebhookLeave marks a participant as left and broadcasts the leave.
+// With debounce set, the broadcast is held back by the join debouncer so a
+// participant LiveKit reports rejoining within the window never appears to
+// leave. A participant who isn't active is ignored.
+func (h *StreamHandlers) recordWebhookLeave(streamID, participantID, userDID string, debounce bool) error {
+	if err := h.participantRepo.RecordLeave(streamID, participantID); err != nil {
+		if errors.Is(err, stream.ErrParticipantNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	leftAt := time.Now()
+	broadcastLeave := func() {
+		if h.eventBroadcaster == nil {
+			return
+		}
+		activeCount, _ := h.participantRepo.GetActiveCount(streamID)
+		h.eventBroadcaster.Broadcast(streamID, &stream.ParticipantStateEvent{
+			Type:            "participant_left",
+			StreamSessionID: streamID,
+			ParticipantID:   participantID,
+			UserDID:         userDID,
+			Timestamp:       leftAt,
+			ActiveCount:     activeCount,
+		})
+	}
+	if debounce && h.joinDebouncer != nil {
+		h.joinDebouncer.Leave(streamID, participantID, broadcastLeave)
+	} else {
+		broadcastLeave()
+	}
+	return nil
+}
+
+// syncActiveCount rewrites the session's denormalized active participant count
+// from the participant records, correcting any drift.
+func (h *StreamHandlers) syncActiveCount(streamID string) error {
+	count, err := h.participantRepo.GetActiveCount(streamID)
+	if err != nil {
+		return err
+	}
+	return h.participantRepo.UpdateSessionParticipantCount(streamID, count)
+}
+
+// participantDID returns the user DID recorded in a participant's token
+// metadata by the /livekit/token endpoint, or "" if there is none.
+func participantDID(info *livekit.ParticipantInfo) string {
+	var metadata struct {
+		DID string `json:"did"`
+	}
+	if err := json.Unmarshal([]byte(info.Metadata), &metadata); err != nil {
+		return ""
+	}
+	return metadata.DID
+}
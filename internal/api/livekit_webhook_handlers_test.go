@@ -0,0 +1,401 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/replay"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	webhookAPIKey    = "APIwebhooktest"
+	webhookAPISecret = "webhook-test-secret-at-least-32-chars"
+)
+
+// livekitWebhookTest is a scene stream with webhook verification configured.
+type livekitWebhookTest struct {
+	handlers        *StreamHandlers
+	streamRepo      *stream.InMemorySessionRepository
+	participantRepo *stream.InMemoryParticipantRepository
+	auditRepo       audit.Repository
+	streamID        string
+	roomName        string
+}
+
+func newLiveKitWebhookTest(t *testing.T) *livekitWebhookTest {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:host")
+	streamRepo := stream.NewInMemorySessionRepository()
+	participantRepo := stream.NewInMemoryParticipantRepository(streamRepo)
+	auditRepo := audit.NewInMemoryRepository()
+
+	streamID, roomName, err := streamRepo.CreateStreamSession(ptrString("scene-1"), nil, "did:plc:host")
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	handlers := NewStreamHandlers(streamRepo, participantRepo, nil, sceneRepo, scene.NewInMemoryEventRepository(), auditRepo, nil, nil, nil)
+	handlers.SetWebhookKeyProvider(auth.NewSimpleKeyProvider(webhookAPIKey, webhookAPISecret))
+
+	return &livekitWebhookTest{
+		handlers:        handlers,
+		streamRepo:      streamRepo,
+		participantRepo: participantRepo,
+		auditRepo:       auditRepo,
+		streamID:        streamID,
+		roomName:        roomName,
+	}
+}
+
+// signedWebhookRequest builds a webhook delivery signed the way LiveKit signs
+// them: a JWT whose sha256 claim is the base64 SHA-256 of the body.
+func signedWebhookRequest(t *testing.T, event *livekit.WebhookEvent, secret string) *http.Request {
+	t.Helper()
+
+	body, err := protojson.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal webhook event: %v", err)
+	}
+	sum := sha256.Sum256(body)
+	token, err := auth.NewAccessToken(webhookAPIKey, secret).
+		SetValidFor(time.Minute).
+		SetSha256(base64.StdEncoding.EncodeToString(sum[:])).
+		ToJWT()
+	if err != nil {
+		t.Fatalf("failed to sign webhook: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/livekit/webhook", bytes.NewReader(body))
+	req.Header.Set("Authorization", token)
+	return req
+}
+
+func (e *livekitWebhookTest) deliver(t *testing.T, eventType, userDID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	event := &livekit.WebhookEvent{
+		Id:    "EV_" + eventType,
+		Event: eventType,
+		Room:  &livekit.Room{Name: e.roomName},
+	}
+	if userDID != "" {
+		event.Participant = &livekit.ParticipantInfo{
+			Identity: stream.GenerateParticipantID(userDID),
+			Metadata: `{"did":"` + userDID + `"}`,
+		}
+	}
+	w := httptest.NewRecorder()
+	e.handlers.HandleLiveKitWebhook(w, signedWebhookRequest(t, event, webhookAPISecret))
+	if w.Code != http.StatusOK {
+		t.Fatalf("%s: expected status 200, got %d: %s", eventType, w.Code, w.Body.String())
+	}
+	return w
+}
+
+func (e *livekitWebhookTest) assertActiveCount(t *testing.T, want int) {
+	t.Helper()
+
+	if got, _ := e.participantRepo.GetActiveCount(e.streamID); got != want {
+		t.Errorf("expected %d active participants, got %d", want, got)
+	}
+	session, err := e.streamRepo.GetByID(e.streamID)
+	if err != nil {
+		t.Fatalf("failed to get stream: %v", err)
+	}
+	if session.ActiveParticipantCount != want {
+		t.Errorf("expected active_participant_count %d, got %d", want, session.ActiveParticipantCount)
+	}
+}
+
+func TestLiveKitWebhook_RejectsUnverifiedPayloads(t *testing.T) {
+	env := newLiveKitWebhookTest(t)
+	event := &livekit.WebhookEvent{
+		Event:       webhook.EventParticipantJoined,
+		Room:        &livekit.Room{Name: env.roomName},
+		Participant: &livekit.ParticipantInfo{Identity: stream.GenerateParticipantID("did:plc:viewer")},
+	}
+
+	unsigned := signedWebhookRequest(t, event, webhookAPISecret)
+	unsigned.Header.Del("Authorization")
+
+	tampered := signedWebhookRequest(t, event, webhookAPISecret)
+	tamperedBody, _ := protojson.Marshal(&livekit.WebhookEvent{Event: webhook.EventRoomFinished, Room: event.Room})
+	tampered.Body = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tamperedBody)).Body
+
+	tests := []struct {
+		name     string
+		handlers *StreamHandlers
+		req      *http.Request
+	}{
+		{name: "unsigned", handlers: env.handlers, req: unsigned},
+		{name: "wrong secret", handlers: env.handlers, req: signedWebhookRequest(t, event, "some-other-secret-at-least-32-chars")},
+		{name: "body does not match signature", handlers: env.handlers, req: tampered},
+		{
+			name:     "no signing keys configured",
+			handlers: NewStreamHandlers(env.streamRepo, env.participantRepo, nil, nil, nil, env.auditRepo, nil, nil, nil),
+			req:      signedWebhookRequest(t, event, webhookAPISecret),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handlers.HandleLiveKitWebhook(w, tt.req)
+			assertErrorResponse(t, w, http.StatusUnauthorized, ErrCodeAuthFailed)
+		})
+	}
+
+	env.assertActiveCount(t, 0)
+	if session, _ := env.streamRepo.GetByID(env.streamID); session.EndedAt != nil {
+		t.Error("expected the stream to stay active")
+	}
+}
+
+func TestLiveKitWebhook_ParticipantJoinedAndLeft(t *testing.T) {
+	env := newLiveKitWebhookTest(t)
+
+	env.deliver(t, webhook.EventParticipantJoined, "did:plc:viewer")
+	env.assertActiveCount(t, 1)
+
+	participants, _ := env.participantRepo.GetActiveParticipants(env.streamID)
+	if len(participants) != 1 || participants[0].UserDID != "did:plc:viewer" {
+		t.Fatalf("expected did:plc:viewer to be active, got %+v", participants)
+	}
+
+	// A redelivered join doesn't count the participant twice
+	env.deliver(t, webhook.EventParticipantJoined, "did:plc:viewer")
+	env.assertActiveCount(t, 1)
+
+	env.deliver(t, webhook.EventParticipantLeft, "did:plc:viewer")
+	env.assertActiveCount(t, 0)
+}
+
+func TestLiveKitWebhook_ReconcilesCrashedClient(t *testing.T) {
+	env := newLiveKitWebhookTest(t)
+
+	// The client reports its join, then crashes without calling leave
+	if w := joinStreamAs(env.handlers, env.streamID, "did:plc:viewer"); w.Code != http.StatusOK {
+		t.Fatalf("join: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	env.deliver(t, webhook.EventParticipantJoined, "did:plc:viewer")
+	env.assertActiveCount(t, 1)
+
+	env.deliver(t, webhook.EventParticipantConnectionAborted, "did:plc:viewer")
+	env.assertActiveCount(t, 0)
+
+	// The client's late leave call is still accepted as a best-effort metric
+	req := httptest.NewRequest(http.MethodPost, "/streams/"+env.streamID+"/leave", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:viewer"))
+	w := httptest.NewRecorder()
+	env.handlers.LeaveStream(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("leave: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	env.assertActiveCount(t, 0)
+}
+
+func TestLiveKitWebhook_RoomFinishedEndsSession(t *testing.T) {
+	env := newLiveKitWebhookTest(t)
+	env.deliver(t, webhook.EventParticipantJoined, "did:plc:host")
+	env.deliver(t, webhook.EventParticipantJoined, "did:plc:viewer")
+	env.assertActiveCount(t, 2)
+
+	env.deliver(t, webhook.EventRoomFinished, "")
+	env.assertActiveCount(t, 0)
+
+	session, err := env.streamRepo.GetByID(env.streamID)
+	if err != nil {
+		t.Fatalf("failed to get stream: %v", err)
+	}
+	if session.EndedAt == nil {
+		t.Fatal("expected the stream session to be ended")
+	}
+
+	logs, err := env.auditRepo.QueryByEntity("stream_session", env.streamID, 0)
+	if err != nil {
+		t.Fatalf("QueryByEntity() error = %v", err)
+	}
+	var ended *audit.AuditLog
+	for _, log := range logs {
+		if log.Action == "ended" {
+			ended = log
+		}
+	}
+	if ended == nil || ended.ActorType != audit.ActorSystem || ended.ActorID != audit.SystemActorReconciler {
+		t.Errorf("expected a reconciler audit entry for the ended stream, got %+v", ended)
+	}
+
+	// A redelivered room_finished is a no-op
+	endedAt := *session.EndedAt
+	env.deliver(t, webhook.EventRoomFinished, "")
+	if session, _ := env.streamRepo.GetByID(env.streamID); !session.EndedAt.Equal(endedAt) {
+		t.Errorf("expected ended_at to stay %v, got %v", endedAt, session.EndedAt)
+	}
+}
+
+func TestLiveKitWebhook_EgressEndedRecordsRecording(t *testing.T) {
+	env := newLiveKitWebhookTest(t)
+
+	deliverEgress := func(status livekit.EgressStatus, location string) {
+		t.Helper()
+		event := &livekit.WebhookEvent{
+			Id:    "EV_egress_" + status.String(),
+			Event: webhook.EventEgressEnded,
+			EgressInfo: &livekit.EgressInfo{
+				EgressId:    "EG_1",
+				RoomName:    env.roomName,
+				Status:      status,
+				FileResults: []*livekit.FileInfo{{Location: location}},
+			},
+		}
+		w := httptest.NewRecorder()
+		env.handlers.HandleLiveKitWebhook(w, signedWebhookRequest(t, event, webhookAPISecret))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	// A failed egress leaves the session without a recording
+	deliverEgress(livekit.EgressStatus_EGRESS_FAILED, "s3://recordings/partial.ogg")
+	if session, _ := env.streamRepo.GetByID(env.streamID); session.RecordingURL != nil {
+		t.Fatalf("expected no recording after a failed egress, got %q", *session.RecordingURL)
+	}
+
+	deliverEgress(livekit.EgressStatus_EGRESS_COMPLETE, "s3://recordings/stream.ogg")
+	session, err := env.streamRepo.GetByID(env.streamID)
+	if err != nil {
+		t.Fatalf("failed to get stream: %v", err)
+	}
+	if session.RecordingURL == nil || *session.RecordingURL != "s3://recordings/stream.ogg" {
+		t.Errorf("expected the recording URL to be stored, got %v", session.RecordingURL)
+	}
+}
+
+func TestLiveKitWebhook_IgnoresUnknownRooms(t *testing.T) {
+	env := newLiveKitWebhookTest(t)
+
+	for _, roomName := range []string{"scene-legacy-1700000000", stream.GenerateRoomName("missing-session")} {
+		event := &livekit.WebhookEvent{
+			Event:       webhook.EventParticipantJoined,
+			Room:        &livekit.Room{Name: roomName},
+			Participant: &livekit.ParticipantInfo{Identity: stream.GenerateParticipantID("did:plc:viewer")},
+		}
+		w := httptest.NewRecorder()
+		env.handlers.HandleLiveKitWebhook(w, signedWebhookRequest(t, event, webhookAPISecret))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d: %s", roomName, w.Code, w.Body.String())
+		}
+	}
+	env.assertActiveCount(t, 0)
+}
+
+func TestLiveKitWebhook_ReplayGuard(t *testing.T) {
+	env := newLiveKitWebhookTest(t)
+	env.handlers.SetReplayGuard(replay.NewGuard(replay.DefaultWindow))
+
+	send := func(id, eventType string, createdAt time.Time) *httptest.ResponseRecorder {
+		event := &livekit.WebhookEvent{
+			Id:        id,
+			Event:     eventType,
+			CreatedAt: createdAt.Unix(),
+			Room:      &livekit.Room{Name: env.roomName},
+			Participant: &livekit.ParticipantInfo{
+				Identity: stream.GenerateParticipantID("did:plc:viewer"),
+				Metadata: `{"did":"did:plc:viewer"}`,
+			},
+		}
+		w := httptest.NewRecorder()
+		env.handlers.HandleLiveKitWebhook(w, signedWebhookRequest(t, event, webhookAPISecret))
+		return w
+	}
+
+	now := time.Now()
+	if w := send("EV_join", webhook.EventParticipantJoined, now); w.Code != http.StatusOK {
+		t.Fatalf("join: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send("EV_leave", webhook.EventParticipantLeft, now); w.Code != http.StatusOK {
+		t.Fatalf("leave: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// A duplicate of the earlier join is acknowledged but not reprocessed
+	if w := send("EV_join", webhook.EventParticipantJoined, now); w.Code != http.StatusOK {
+		t.Fatalf("duplicate join: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	env.assertActiveCount(t, 0)
+
+	// Events created outside the window are rejected
+	w := send("EV_stale", webhook.EventParticipantJoined, now.Add(-time.Hour))
+	assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeBadRequest)
+	env.assertActiveCount(t, 0)
+}
+
+func TestLiveKitWebhook_RejoinWithinDebounceWindowReopensRecord(t *testing.T) {
+	env := newLiveKitWebhookTest(t)
+	env.handlers.SetJoinDebouncer(stream.NewJoinDebouncer(time.Hour))
+
+	// The client reports its join, LiveKit sees the connection drop and come
+	// back, and the client re-joins
+	if w := joinStreamAs(env.handlers, env.streamID, "did:plc:viewer"); w.Code != http.StatusOK {
+		t.Fatalf("join: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	env.deliver(t, webhook.EventParticipantLeft, "did:plc:viewer")
+	env.assertActiveCount(t, 0)
+	env.deliver(t, webhook.EventParticipantJoined, "did:plc:viewer")
+	env.assertActiveCount(t, 1)
+
+	env.deliver(t, webhook.EventParticipantLeft, "did:plc:viewer")
+	if w := joinStreamAs(env.handlers, env.streamID, "did:plc:viewer"); w.Code != http.StatusOK {
+		t.Fatalf("rejoin: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	env.assertActiveCount(t, 1)
+
+	// Neither reconnection counts as a join or starts a new record
+	session, err := env.streamRepo.GetByID(env.streamID)
+	if err != nil {
+		t.Fatalf("failed to get stream: %v", err)
+	}
+	if session.JoinCount != 1 {
+		t.Errorf("expected join_count 1, got %d", session.JoinCount)
+	}
+	history, err := env.participantRepo.GetParticipantHistory(env.streamID)
+	if err != nil {
+		t.Fatalf("failed to get participant history: %v", err)
+	}
+	if len(history) != 1 || history[0].ReconnectionCount != 2 {
+		t.Errorf("expected one record with reconnection_count 2, got %+v", history)
+	}
+}
+
+func TestLiveKitWebhook_RejoinAfterDebounceWindowCreatesRecord(t *testing.T) {
+	window := 10 * time.Millisecond
+	env := newLiveKitWebhookTest(t)
+	env.handlers.SetJoinDebouncer(stream.NewJoinDebouncer(window))
+
+	env.deliver(t, webhook.EventParticipantJoined, "did:plc:viewer")
+	env.deliver(t, webhook.EventParticipantLeft, "did:plc:viewer")
+	time.Sleep(2 * window)
+	env.deliver(t, webhook.EventParticipantJoined, "did:plc:viewer")
+	env.assertActiveCount(t, 1)
+
+	history, err := env.participantRepo.GetParticipantHistory(env.streamID)
+	if err != nil {
+		t.Fatalf("failed to get participant history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Errorf("expected separate participant records per session, got %d", len(history))
+	}
+}
@@ -2,6 +2,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/onnwee/subcults/internal/audit"
 	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/notification"
 	"github.com/onnwee/subcults/internal/scene"
 )
 
@@ -20,6 +22,7 @@ type MembershipHandlers struct {
 	membershipRepo membership.MembershipRepository
 	sceneRepo      scene.SceneRepository
 	auditRepo      audit.Repository
+	outbox         notification.Outbox // Optional; delivers scene welcome messages
 }
 
 // NewMembershipHandlers creates a new MembershipHandlers instance.
@@ -35,8 +38,15 @@ func NewMembershipHandlers(
 	}
 }
 
+// SetNotificationOutbox enables welcome notifications for scenes with a
+// welcome template. Without an outbox no welcomes are sent.
+func (h *MembershipHandlers) SetNotificationOutbox(outbox notification.Outbox) {
+	h.outbox = outbox
+}
+
 // RequestMembership handles POST /scenes/{id}/membership/request
-// Creates a pending membership request for the authenticated user.
+// Creates a pending membership request for the authenticated user. Responds
+// 201 Created for a new membership and 200 OK when an existing one is reused.
 func (h *MembershipHandlers) RequestMembership(w http.ResponseWriter, r *http.Request) {
 	// Extract scene ID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
@@ -139,9 +149,14 @@ func (h *MembershipHandlers) RequestMembership(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Return created membership
+	// Return created membership; re-requesting after a rejection may reuse the
+	// existing membership, which is reported as an update
+	code := http.StatusOK
+	if result.Inserted {
+		code = http.StatusCreated
+	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(code)
 	if err := json.NewEncoder(w).Encode(createdMembership); err != nil {
 		return
 	}
@@ -237,6 +252,8 @@ func (h *MembershipHandlers) ApproveMembership(w http.ResponseWriter, r *http.Re
 		}
 	}
 
+	h.sendWelcome(r.Context(), existingScene, existingMembership)
+
 	// Get updated membership for response
 	updatedMembership, err := h.membershipRepo.GetByID(existingMembership.ID)
 	if err != nil {
@@ -359,3 +376,39 @@ func (h *MembershipHandlers) RejectMembership(w http.ResponseWriter, r *http.Req
 		return
 	}
 }
+
+// sendWelcome enqueues the scene's welcome notification for a newly active
+// member. Each member is welcomed at most once per scene, and scenes without a
+// welcome template send nothing. Failures are logged and never fail the request.
+func (h *MembershipHandlers) sendWelcome(ctx context.Context, s *scene.Scene, m *membership.Membership) {
+	if h.outbox == nil {
+		return
+	}
+	text := scene.RenderWelcome(s, m.UserDID)
+	if text == "" {
+		return
+	}
+
+	first, err := h.membershipRepo.MarkWelcomed(m.ID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to mark membership welcomed", "error", err, "membership_id", m.ID)
+		return
+	}
+	if !first {
+		return
+	}
+
+	// Sent on behalf of the scene rather than its owner
+	if err := h.outbox.Enqueue(&notification.Notification{
+		RecipientDID: m.UserDID,
+		Type:         notification.TypeSceneWelcome,
+		SubjectID:    s.ID,
+		Payload: map[string]string{
+			"scene_id":   s.ID,
+			"scene_name": s.Name,
+			"text":       text,
+		},
+	}); err != nil {
+		slog.ErrorContext(ctx, "failed to enqueue scene welcome notification", "error", err, "membership_id", m.ID, "scene_id", s.ID)
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/notification"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// newWelcomeTest sets up a scene with the given welcome template and a pending
+// membership for did:plc:requester, returning the handlers, repository,
+// outbox and membership ID.
+func newWelcomeTest(t *testing.T, template string) (*MembershipHandlers, *membership.InMemoryMembershipRepository, *notification.InMemoryOutbox, string) {
+	t.Helper()
+
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	outbox := notification.NewInMemoryOutbox()
+	handlers := NewMembershipHandlers(membershipRepo, sceneRepo, audit.NewInMemoryRepository())
+	handlers.SetNotificationOutbox(outbox)
+
+	if err := sceneRepo.Insert(&scene.Scene{
+		ID:                  "scene-123",
+		Name:                "Test Scene",
+		OwnerDID:            "did:plc:owner",
+		CoarseGeohash:       "u4pruydqqvj",
+		WelcomePostTemplate: template,
+	}); err != nil {
+		t.Fatalf("Failed to insert test scene: %v", err)
+	}
+
+	result, err := membershipRepo.Upsert(&membership.Membership{
+		SceneID:     "scene-123",
+		UserDID:     "did:plc:requester",
+		Role:        "member",
+		Status:      "pending",
+		TrustWeight: 0.5,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pending membership: %v", err)
+	}
+
+	return handlers, membershipRepo, outbox, result.ID
+}
+
+func approveRequester(t *testing.T, handlers *MembershipHandlers) {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", "/scenes/scene-123/membership/did:plc:requester/approve", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+	handlers.ApproveMembership(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestApproveMembership_SendsWelcome(t *testing.T) {
+	handlers, membershipRepo, outbox, membershipID := newWelcomeTest(t, "Welcome to {scene}, {member}!")
+
+	approveRequester(t, handlers)
+
+	notifications, _ := outbox.ListByRecipient("did:plc:requester")
+	if len(notifications) != 1 {
+		t.Fatalf("Expected 1 welcome notification, got %d", len(notifications))
+	}
+	n := notifications[0]
+	if n.Type != notification.TypeSceneWelcome {
+		t.Errorf("Expected type %q, got %q", notification.TypeSceneWelcome, n.Type)
+	}
+	if n.SubjectID != "scene-123" || n.Payload["scene_id"] != "scene-123" {
+		t.Errorf("Expected the welcome to reference scene-123, got subject %q payload %v", n.SubjectID, n.Payload)
+	}
+	if want := "Welcome to Test Scene, did:plc:requester!"; n.Payload["text"] != want {
+		t.Errorf("Expected text %q, got %q", want, n.Payload["text"])
+	}
+
+	if m, _ := membershipRepo.GetByID(membershipID); m.WelcomedAt == nil {
+		t.Error("Expected welcomed_at to be set")
+	}
+	if owner, _ := outbox.ListByRecipient("did:plc:owner"); len(owner) != 0 {
+		t.Errorf("Expected no notifications for the owner, got %d", len(owner))
+	}
+}
+
+func TestApproveMembership_WelcomesOnlyOnce(t *testing.T) {
+	handlers, membershipRepo, outbox, membershipID := newWelcomeTest(t, "Welcome, {member}!")
+
+	approveRequester(t, handlers)
+
+	// The member drops back to pending and is approved again
+	if err := membershipRepo.UpdateStatus(membershipID, "pending", nil); err != nil {
+		t.Fatalf("Failed to reset membership: %v", err)
+	}
+	approveRequester(t, handlers)
+
+	notifications, _ := outbox.ListByRecipient("did:plc:requester")
+	if len(notifications) != 1 {
+		t.Errorf("Expected 1 welcome notification after re-approval, got %d", len(notifications))
+	}
+}
+
+func TestApproveMembership_EmptyWelcomeTemplate(t *testing.T) {
+	for _, template := range []string{"", "   "} {
+		handlers, membershipRepo, outbox, membershipID := newWelcomeTest(t, template)
+
+		approveRequester(t, handlers)
+
+		if notifications, _ := outbox.ListByRecipient("did:plc:requester"); len(notifications) != 0 {
+			t.Errorf("template %q: expected no welcome notification, got %d", template, len(notifications))
+		}
+		if m, _ := membershipRepo.GetByID(membershipID); m.WelcomedAt != nil {
+			t.Errorf("template %q: expected welcomed_at to stay unset", template)
+		}
+	}
+}
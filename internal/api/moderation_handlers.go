@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/onnwee/subcults/internal/audit"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
 )
@@ -21,6 +22,7 @@ const (
 type ModerationHandlers struct {
 	sceneRepo scene.SceneRepository
 	adminDIDs []string // List of authorized admin DIDs
+	auditRepo audit.Repository // Optional: records mutes in the scene's moderation log
 }
 
 // NewModerationHandlers creates a new ModerationHandlers instance.
@@ -34,6 +36,11 @@ func NewModerationHandlers(
 	}
 }
 
+// SetAuditRepo installs the audit repository that mutes and unmutes are recorded to.
+func (h *ModerationHandlers) SetAuditRepo(repo audit.Repository) {
+	h.auditRepo = repo
+}
+
 // isAdminDID checks if the given DID is an authorized admin.
 func (h *ModerationHandlers) isAdminDID(did string) bool {
 	for _, adminDID := range h.adminDIDs {
@@ -140,6 +147,12 @@ func (h *ModerationHandlers) MuteScene(w http.ResponseWriter, r *http.Request) {
 		"scene_id", sceneID,
 		"admin_did", userDID,
 		"reason", req.Reason)
+	if h.auditRepo != nil {
+		if err := audit.LogModerationAction(ctx, h.auditRepo, "scene", sceneID, sceneID, audit.ActionModerationMute, req.Reason); err != nil {
+			slog.WarnContext(ctx, "failed to log scene mute audit", "error", err, "scene_id", sceneID)
+			// Continue - audit failure should not block the operation
+		}
+	}
 
 	// Build response
 	response := MuteSceneResponse{
@@ -216,6 +229,12 @@ func (h *ModerationHandlers) UnmuteScene(w http.ResponseWriter, r *http.Request)
 	slog.InfoContext(ctx, "scene unmuted",
 		"scene_id", sceneID,
 		"admin_did", userDID)
+	if h.auditRepo != nil {
+		if err := audit.LogModerationAction(ctx, h.auditRepo, "scene", sceneID, sceneID, audit.ActionModerationUnmute, ""); err != nil {
+			slog.WarnContext(ctx, "failed to log scene unmute audit", "error", err, "scene_id", sceneID)
+			// Continue - audit failure should not block the operation
+		}
+	}
 
 	// Build response
 	response := UnmuteSceneResponse{
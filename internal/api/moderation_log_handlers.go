@@ -0,0 +1,155 @@
+// Package api provides HTTP handlers for the Subcults API.
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// Moderation log pagination defaults
+const (
+	DefaultModerationLogLimit = 20
+	MaxModerationLogLimit     = 100
+)
+
+// ModerationLogEntry represents a single moderation action in a scene's moderation log.
+type ModerationLogEntry struct {
+	ID           string    `json:"id"`
+	Action       string    `json:"action"`
+	EntityType   string    `json:"entity_type"`
+	EntityID     string    `json:"entity_id"`
+	ModeratorDID string    `json:"moderator_did"`
+	Reason       string    `json:"reason,omitempty"`
+	Outcome      string    `json:"outcome"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ModerationLogResponse represents the response for GET /scenes/{id}/moderation_log.
+type ModerationLogResponse struct {
+	Entries    []ModerationLogEntry `json:"entries"`
+	NextOffset *int                 `json:"next_offset,omitempty"`
+}
+
+// ModerationLogHandlers holds dependencies for scene moderation log handlers.
+type ModerationLogHandlers struct {
+	sceneRepo scene.SceneRepository
+	auditRepo audit.Repository
+}
+
+// NewModerationLogHandlers creates a new ModerationLogHandlers instance.
+func NewModerationLogHandlers(sceneRepo scene.SceneRepository, auditRepo audit.Repository) *ModerationLogHandlers {
+	return &ModerationLogHandlers{
+		sceneRepo: sceneRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// GetSceneModerationLog handles GET /scenes/{id}/moderation_log - lists moderation actions
+// taken within a scene, newest first. Only the scene owner may view the log.
+func (h *ModerationLogHandlers) GetSceneModerationLog(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) == 0 || pathParts[0] == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+		return
+	}
+	sceneID := pathParts[0]
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Parse pagination parameters
+	query := r.URL.Query()
+	limit := DefaultModerationLogLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "limit must be a positive integer")
+			return
+		}
+		if parsedLimit > MaxModerationLogLimit {
+			parsedLimit = MaxModerationLogLimit
+		}
+		limit = parsedLimit
+	}
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err != nil || parsedOffset < 0 {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "offset must be a non-negative integer")
+			return
+		}
+		offset = parsedOffset
+	}
+
+	existingScene, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
+
+	// Verify ownership
+	if !existingScene.IsOwner(userDID) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to view this scene's moderation log")
+		return
+	}
+
+	// Fetch one extra entry to determine whether another page exists
+	logs, err := h.auditRepo.QueryBySceneActions(sceneID, audit.ModerationActions, limit+1, offset)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to query moderation log", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve moderation log")
+		return
+	}
+
+	response := ModerationLogResponse{
+		Entries: make([]ModerationLogEntry, 0, len(logs)),
+	}
+	if len(logs) > limit {
+		logs = logs[:limit]
+		nextOffset := offset + limit
+		response.NextOffset = &nextOffset
+	}
+	for _, log := range logs {
+		response.Entries = append(response.Entries, ModerationLogEntry{
+			ID:           log.ID,
+			Action:       log.Action,
+			EntityType:   log.EntityType,
+			EntityID:     log.EntityID,
+			ModeratorDID: log.UserDID,
+			Reason:       log.Reason,
+			Outcome:      log.Outcome,
+			CreatedAt:    log.CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		return
+	}
+}
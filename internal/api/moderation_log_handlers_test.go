@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+func setupModerationLogTest(t *testing.T) (*ModerationLogHandlers, audit.Repository) {
+	t.Helper()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:owner")
+	createTestScene(t, sceneRepo, "scene-2", "did:plc:other-owner")
+	return NewModerationLogHandlers(sceneRepo, auditRepo), auditRepo
+}
+
+func TestGetSceneModerationLog_ListsSceneActions(t *testing.T) {
+	handlers, auditRepo := setupModerationLogTest(t)
+
+	entries := []audit.LogEntry{
+		{UserDID: "did:plc:mod1", EntityType: "post", EntityID: "post-1", Action: audit.ActionModerationLabelApply, SceneID: "scene-1", Reason: "spam"},
+		{UserDID: "did:plc:mod2", EntityType: "post", EntityID: "post-2", Action: audit.ActionModerationRemove, SceneID: "scene-1", Reason: "harassment"},
+		// Unrelated entries: other scene, and a non-moderation action in this scene
+		{UserDID: "did:plc:mod1", EntityType: "post", EntityID: "post-3", Action: audit.ActionModerationPurge, SceneID: "scene-2", Reason: "raid"},
+		{UserDID: "did:plc:owner", EntityType: "scene", EntityID: "scene-1", Action: "scene_update", SceneID: "scene-1"},
+	}
+	for _, entry := range entries {
+		if _, err := auditRepo.LogAccess(entry); err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene-1/moderation_log", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+
+	handlers.GetSceneModerationLog(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp ModerationLogResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(resp.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(resp.Entries))
+	}
+	if resp.Entries[0].EntityID != "post-2" || resp.Entries[0].ModeratorDID != "did:plc:mod2" || resp.Entries[0].Reason != "harassment" {
+		t.Errorf("unexpected first entry: %+v", resp.Entries[0])
+	}
+	if resp.Entries[1].Action != audit.ActionModerationLabelApply {
+		t.Errorf("expected second entry action %s, got %s", audit.ActionModerationLabelApply, resp.Entries[1].Action)
+	}
+	if resp.NextOffset != nil {
+		t.Errorf("expected no next_offset, got %d", *resp.NextOffset)
+	}
+}
+
+func TestGetSceneModerationLog_Pagination(t *testing.T) {
+	handlers, auditRepo := setupModerationLogTest(t)
+
+	for i := 0; i < 3; i++ {
+		if _, err := auditRepo.LogAccess(audit.LogEntry{
+			UserDID: "did:plc:mod1", EntityType: "post", EntityID: "post-1",
+			Action: audit.ActionModerationLabelApply, SceneID: "scene-1",
+		}); err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene-1/moderation_log?limit=2", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+
+	handlers.GetSceneModerationLog(w, req)
+
+	var resp ModerationLogResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(resp.Entries))
+	}
+	if resp.NextOffset == nil || *resp.NextOffset != 2 {
+		t.Fatalf("expected next_offset 2, got %v", resp.NextOffset)
+	}
+}
+
+func TestGetSceneModerationLog_NonOwnerForbidden(t *testing.T) {
+	handlers, _ := setupModerationLogTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene-1/moderation_log", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:mod1"))
+	w := httptest.NewRecorder()
+
+	handlers.GetSceneModerationLog(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestGetSceneModerationLog_Unauthenticated(t *testing.T) {
+	handlers, _ := setupModerationLogTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene-1/moderation_log", nil)
+	w := httptest.NewRecorder()
+
+	handlers.GetSceneModerationLog(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestGetSceneModerationLog_DeletedScene(t *testing.T) {
+	sceneRepo := scene.NewInMemorySceneRepository()
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:owner")
+	if err := sceneRepo.Delete("scene-1"); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+	handlers := NewModerationLogHandlers(sceneRepo, audit.NewInMemoryRepository())
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene-1/moderation_log", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+
+	handlers.GetSceneModerationLog(w, req)
+
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// ownerScopeFixture seeds did:plc:owner with a live scene, a soft-deleted scene and
+// a soft-deleted event, plus one live scene for did:plc:other.
+type ownerScopeFixture struct {
+	sceneHandlers *SceneHandlers
+	eventHandlers *EventHandlers
+}
+
+func setupOwnerScopeTest(t *testing.T) *ownerScopeFixture {
+	t.Helper()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+
+	createTestScene(t, sceneRepo, "scene-live", "did:plc:owner")
+	createTestScene(t, sceneRepo, "scene-deleted", "did:plc:owner")
+	createTestScene(t, sceneRepo, "scene-other", "did:plc:other")
+	if err := sceneRepo.Delete("scene-deleted"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	now := time.Now()
+	deletedAt := now.Add(-time.Hour)
+	for _, e := range []*scene.Event{
+		{ID: "event-live", SceneID: "scene-live", Title: "Live", CoarseGeohash: "dr5regw", StartsAt: now.Add(time.Hour)},
+		{ID: "event-deleted", SceneID: "scene-live", Title: "Deleted", CoarseGeohash: "dr5regw", StartsAt: now.Add(2 * time.Hour), DeletedAt: &deletedAt},
+		{ID: "event-other", SceneID: "scene-other", Title: "Other", CoarseGeohash: "dr5regw", StartsAt: now.Add(time.Hour)},
+	} {
+		if err := eventRepo.Insert(e); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+
+	return &ownerScopeFixture{
+		sceneHandlers: NewSceneHandlers(sceneRepo, membership.NewInMemoryMembershipRepository(), streamRepo),
+		eventHandlers: NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), streamRepo, nil),
+	}
+}
+
+func ownerScopeRequest(target, userDID string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	return req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+}
+
+func listMyScenes(t *testing.T, f *ownerScopeFixture, target, userDID string) []string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	f.sceneHandlers.ListOwnedScenes(w, ownerScopeRequest(target, userDID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var summaries []OwnedSceneSummary
+	if err := json.NewDecoder(w.Body).Decode(&summaries); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	ids := make([]string, len(summaries))
+	for i, s := range summaries {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+func listMyEvents(t *testing.T, f *ownerScopeFixture, target, userDID string) []string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	f.eventHandlers.ListMyEvents(w, ownerScopeRequest(target, userDID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var events []scene.Event
+	if err := json.NewDecoder(w.Body).Decode(&events); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+func containsID(ids []string, id string) bool {
+	for _, got := range ids {
+		if got == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestListMyScenes_OwnerScope(t *testing.T) {
+	f := setupOwnerScopeTest(t)
+
+	if ids := listMyScenes(t, f, "/me/scenes", "did:plc:owner"); len(ids) != 1 || ids[0] != "scene-live" {
+		t.Errorf("expected only scene-live without include_deleted, got %v", ids)
+	}
+	if ids := listMyScenes(t, f, "/me/scenes?include_deleted=true", "did:plc:owner"); len(ids) != 2 || !containsID(ids, "scene-deleted") {
+		t.Errorf("expected owner to see their deleted scene, got %v", ids)
+	}
+
+	ids := listMyScenes(t, f, "/me/scenes?include_deleted=true", "did:plc:other")
+	if len(ids) != 1 || ids[0] != "scene-other" {
+		t.Errorf("expected other user to see only their own scene, got %v", ids)
+	}
+}
+
+func TestListMyEvents_OwnerScope(t *testing.T) {
+	f := setupOwnerScopeTest(t)
+
+	if ids := listMyEvents(t, f, "/me/events", "did:plc:owner"); len(ids) != 1 || ids[0] != "event-live" {
+		t.Errorf("expected only event-live without include_deleted, got %v", ids)
+	}
+	if ids := listMyEvents(t, f, "/me/events?include_deleted=true", "did:plc:owner"); len(ids) != 2 || !containsID(ids, "event-deleted") {
+		t.Errorf("expected owner to see their deleted event, got %v", ids)
+	}
+
+	ids := listMyEvents(t, f, "/me/events?include_deleted=true", "did:plc:other")
+	if len(ids) != 1 || ids[0] != "event-other" {
+		t.Errorf("expected other user to see only their own event, got %v", ids)
+	}
+}
+
+func TestListMyEvents_Unauthenticated(t *testing.T) {
+	f := setupOwnerScopeTest(t)
+
+	w := httptest.NewRecorder()
+	f.eventHandlers.ListMyEvents(w, httptest.NewRequest(http.MethodGet, "/me/events", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestListMyScenes_InvalidIncludeDeleted(t *testing.T) {
+	f := setupOwnerScopeTest(t)
+
+	w := httptest.NewRecorder()
+	f.sceneHandlers.ListOwnedScenes(w, ownerScopeRequest("/me/scenes?include_deleted=maybe", "did:plc:owner"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
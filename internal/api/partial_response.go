@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+)
+
+// PartialResponse marks a response that composes optional data sources.
+// Embed it in the response type and attach each optional enrichment through
+// Enrich: when a non-critical dependency fails, the enrichment is omitted and
+// the response is flagged partial with a warning instead of failing with a 500.
+type PartialResponse struct {
+	Partial  bool     `json:"partial,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Enrich runs the named enricher. An error is logged and recorded as a warning;
+// enrichers should return nil when their data simply doesn't exist.
+func (p *PartialResponse) Enrich(ctx context.Context, name string, enricher func() error) {
+	if err := enricher(); err != nil {
+		slog.WarnContext(ctx, "optional enrichment failed", "enrichment", name, "error", err)
+		p.Partial = true
+		p.Warnings = append(p.Warnings, name+" unavailable")
+	}
+}
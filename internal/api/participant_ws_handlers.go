@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/onnwee/subcults/internal/middleware"
@@ -57,7 +58,7 @@ func (h *ParticipantWebSocketHandlers) SubscribeToParticipantEvents(w http.Respo
 	streamID := pathParts[0]
 
 	// Verify stream exists
-	_, err := h.streamRepo.GetByID(streamID)
+	session, err := h.streamRepo.GetByID(streamID)
 	if err != nil {
 		if err == stream.ErrStreamNotFound {
 			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Stream session not found")
@@ -68,6 +69,13 @@ func (h *ParticipantWebSocketHandlers) SubscribeToParticipantEvents(w http.Respo
 		return
 	}
 
+	// Ended streams have no further events; subscribing would only recreate
+	// the registry the broadcaster released when the stream ended
+	if session.EndedAt != nil {
+		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Stream has ended")
+		return
+	}
+
 	// Check if event broadcaster is available
 	if h.eventBroadcaster == nil {
 		slog.ErrorContext(ctx, "event broadcaster not configured", "stream_id", streamID)
@@ -75,6 +83,13 @@ func (h *ParticipantWebSocketHandlers) SubscribeToParticipantEvents(w http.Respo
 		return
 	}
 
+	// Reject before upgrading so the client gets a readable HTTP error
+	if !h.eventBroadcaster.HasCapacity(streamID) {
+		slog.WarnContext(ctx, "stream subscriber limit reached", "stream_id", streamID)
+		WriteError(w, ctx, http.StatusServiceUnavailable, ErrCodeStreamSubscribersFull, "Stream has reached its subscriber limit, try again later")
+		return
+	}
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -85,8 +100,14 @@ func (h *ParticipantWebSocketHandlers) SubscribeToParticipantEvents(w http.Respo
 		return
 	}
 
-	// Subscribe to events
-	h.eventBroadcaster.Subscribe(streamID, conn)
+	// Subscribe to events; the stream may have filled up since the capacity check
+	if err := h.eventBroadcaster.Subscribe(streamID, conn); err != nil {
+		slog.WarnContext(ctx, "stream subscriber limit reached", "stream_id", streamID)
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "stream subscriber limit reached")
+		_ = conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+		conn.Close()
+		return
+	}
 
 	// Log subscription
 	requestID := middleware.GetRequestID(ctx)
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newParticipantWSServer serves SubscribeToParticipantEvents for an
+// authenticated test user on a new stream session, returning the session ID
+// and the URL of its socket.
+func newParticipantWSServer(t *testing.T, broadcaster *stream.EventBroadcaster) (string, string) {
+	t.Helper()
+	streamRepo := stream.NewInMemorySessionRepository()
+	streamID, _, err := streamRepo.CreateStreamSession(strPtr("scene-1"), nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("failed to create stream session: %v", err)
+	}
+
+	handlers := NewParticipantWebSocketHandlers(streamRepo, broadcaster)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlers.SubscribeToParticipantEvents(w, r.WithContext(middleware.SetUserDID(r.Context(), testUserDID)))
+	}))
+	t.Cleanup(server.Close)
+
+	return streamID, "ws" + strings.TrimPrefix(server.URL, "http") + "/streams/" + streamID + "/participants/ws"
+}
+
+// waitForConnectionCount polls until the broadcaster reports want subscribers.
+func waitForConnectionCount(t *testing.T, b *stream.EventBroadcaster, streamID string, want int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for b.ConnectionCount(streamID) != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("connection count = %d, want %d", b.ConnectionCount(streamID), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSubscribeToParticipantEvents_SubscriberLimit(t *testing.T) {
+	broadcaster := stream.NewEventBroadcaster()
+	broadcaster.SetMaxSubscribersPerStream(2)
+	streamID, url := newParticipantWSServer(t, broadcaster)
+
+	// Up to the limit connects
+	var conns []*websocket.Conn
+	for i := 0; i < 2; i++ {
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			t.Fatalf("subscriber %d: dial failed: %v", i+1, err)
+		}
+		defer conn.Close()
+		conns = append(conns, conn)
+	}
+	waitForConnectionCount(t, broadcaster, streamID, 2)
+
+	// Beyond it is refused with a clear error before the upgrade
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err == nil {
+		t.Fatal("expected dial beyond the limit to fail")
+	}
+	if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503 beyond the limit, got %+v", resp)
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	resp.Body.Close()
+	if errResp.Error.Code != ErrCodeStreamSubscribersFull {
+		t.Errorf("error code = %q, want %q", errResp.Error.Code, ErrCodeStreamSubscribersFull)
+	}
+
+	// Disconnecting frees a slot
+	conns[0].Close()
+	waitForConnectionCount(t, broadcaster, streamID, 1)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial after a disconnect failed: %v", err)
+	}
+	defer conn.Close()
+	waitForConnectionCount(t, broadcaster, streamID, 2)
+}
+
+func TestSubscribeToParticipantEvents_EndedStream(t *testing.T) {
+	streamRepo := stream.NewInMemorySessionRepository()
+	streamID, _, err := streamRepo.CreateStreamSession(strPtr("scene-1"), nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("failed to create stream session: %v", err)
+	}
+	if err := streamRepo.EndStreamSession(streamID); err != nil {
+		t.Fatalf("failed to end stream session: %v", err)
+	}
+
+	broadcaster := stream.NewEventBroadcaster()
+	handlers := NewParticipantWebSocketHandlers(streamRepo, broadcaster)
+	req := httptest.NewRequest(http.MethodGet, "/streams/"+streamID+"/participants/ws", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), testUserDID))
+	w := httptest.NewRecorder()
+	handlers.SubscribeToParticipantEvents(w, req)
+
+	assertErrorResponse(t, w, http.StatusConflict, ErrCodeConflict)
+	if n := broadcaster.TrackedStreams(); n != 0 {
+		t.Errorf("expected no tracked streams, got %d", n)
+	}
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/payment"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/stripe/stripe-go/v81"
+)
+
+// newCancelCheckoutTest creates payment handlers with a pending payment
+// cs_pending paid by did:plc:payer in scene-1.
+func newCancelCheckoutTest(t *testing.T) (*PaymentHandlers, *payment.InMemoryPaymentRepository, *[]string) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	if err := sceneRepo.Insert(&scene.Scene{ID: "scene-1", Name: "Scene One", OwnerDID: "did:plc:owner123", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("failed to create test scene: %v", err)
+	}
+	paymentRepo := payment.NewInMemoryPaymentRepository()
+	if err := paymentRepo.CreatePending(&payment.PaymentRecord{
+		SessionID: "cs_pending",
+		Amount:    1000,
+		Fee:       50,
+		Currency:  "usd",
+		UserDID:   "did:plc:payer",
+		SceneID:   "scene-1",
+	}); err != nil {
+		t.Fatalf("failed to create payment record: %v", err)
+	}
+
+	var expired []string
+	client := &mockStripeClient{
+		expireCheckoutSessionFunc: func(sessionID string) (*stripe.CheckoutSession, error) {
+			expired = append(expired, sessionID)
+			return &stripe.CheckoutSession{ID: sessionID, Status: stripe.CheckoutSessionStatusExpired}, nil
+		},
+	}
+	handlers := NewPaymentHandlers(
+		sceneRepo,
+		paymentRepo,
+		client,
+		"https://example.com/return",
+		"https://example.com/refresh",
+		5.0,
+	)
+	return handlers, paymentRepo, &expired
+}
+
+func cancelCheckoutAs(handlers *PaymentHandlers, sessionID, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/payments/"+sessionID+"/cancel", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.CancelCheckoutSession(w, req)
+	return w
+}
+
+func TestCancelCheckoutSession_PayerCancelsPending(t *testing.T) {
+	handlers, paymentRepo, expired := newCancelCheckoutTest(t)
+
+	w := cancelCheckoutAs(handlers, "cs_pending", "did:plc:payer")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp PaymentStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != payment.StatusCanceled {
+		t.Errorf("expected status %q, got %q", payment.StatusCanceled, resp.Status)
+	}
+	if len(*expired) != 1 || (*expired)[0] != "cs_pending" {
+		t.Errorf("expected the Stripe session to be expired once, got %v", *expired)
+	}
+
+	record, _ := paymentRepo.GetBySessionID("cs_pending")
+	if record.Status != payment.StatusCanceled {
+		t.Errorf("expected stored status %q, got %q", payment.StatusCanceled, record.Status)
+	}
+
+	// Canceling again is a no-op that doesn't call Stripe
+	if w := cancelCheckoutAs(handlers, "cs_pending", "did:plc:payer"); w.Code != http.StatusOK {
+		t.Errorf("repeat cancel: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(*expired) != 1 {
+		t.Errorf("expected no second Stripe call, got %v", *expired)
+	}
+}
+
+func TestCancelCheckoutSession_NonPayerRejected(t *testing.T) {
+	handlers, paymentRepo, expired := newCancelCheckoutTest(t)
+
+	// Even the scene owner can't cancel someone else's checkout
+	for _, did := range []string{"did:plc:stranger", "did:plc:owner123"} {
+		w := cancelCheckoutAs(handlers, "cs_pending", did)
+		assertErrorResponse(t, w, http.StatusForbidden, ErrCodeForbidden)
+	}
+
+	if len(*expired) != 0 {
+		t.Errorf("expected no Stripe call, got %v", *expired)
+	}
+	if record, _ := paymentRepo.GetBySessionID("cs_pending"); record.Status != payment.StatusPending {
+		t.Errorf("expected payment to stay pending, got %q", record.Status)
+	}
+}
+
+func TestCancelCheckoutSession_SucceededPaymentRefused(t *testing.T) {
+	handlers, paymentRepo, expired := newCancelCheckoutTest(t)
+	if err := paymentRepo.MarkCompleted("cs_pending", "pi_test123"); err != nil {
+		t.Fatalf("MarkCompleted() error = %v", err)
+	}
+
+	w := cancelCheckoutAs(handlers, "cs_pending", "did:plc:payer")
+	assertErrorResponse(t, w, http.StatusConflict, ErrCodeConflict)
+
+	if len(*expired) != 0 {
+		t.Errorf("expected no Stripe call, got %v", *expired)
+	}
+	if record, _ := paymentRepo.GetBySessionID("cs_pending"); record.Status != payment.StatusSucceeded {
+		t.Errorf("expected payment to stay succeeded, got %q", record.Status)
+	}
+}
+
+func TestCancelCheckoutSession_StripeFailureLeavesPending(t *testing.T) {
+	handlers, paymentRepo, _ := newCancelCheckoutTest(t)
+	handlers.stripeClient = &mockStripeClient{
+		expireCheckoutSessionFunc: func(string) (*stripe.CheckoutSession, error) {
+			return nil, errors.New("stripe unavailable")
+		},
+	}
+
+	w := cancelCheckoutAs(handlers, "cs_pending", "did:plc:payer")
+	assertErrorResponse(t, w, http.StatusInternalServerError, ErrCodeInternal)
+
+	if record, _ := paymentRepo.GetBySessionID("cs_pending"); record.Status != payment.StatusPending {
+		t.Errorf("expected payment to stay pending, got %q", record.Status)
+	}
+}
+
+func TestCancelCheckoutSession_StripeSessionAlreadyClosed(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     stripe.CheckoutSessionStatus
+		wantCode   int
+		wantStatus string
+	}{
+		{name: "already expired cancels", status: stripe.CheckoutSessionStatusExpired, wantCode: http.StatusOK, wantStatus: payment.StatusCanceled},
+		{name: "completed is left for the webhook", status: stripe.CheckoutSessionStatusComplete, wantCode: http.StatusConflict, wantStatus: payment.StatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers, paymentRepo, _ := newCancelCheckoutTest(t)
+			handlers.stripeClient = &mockStripeClient{
+				expireCheckoutSessionFunc: func(string) (*stripe.CheckoutSession, error) {
+					return nil, errors.New("only open checkout sessions can be expired")
+				},
+				getCheckoutSessionFunc: func(sessionID string) (*stripe.CheckoutSession, error) {
+					return &stripe.CheckoutSession{ID: sessionID, Status: tt.status}, nil
+				},
+			}
+
+			w := cancelCheckoutAs(handlers, "cs_pending", "did:plc:payer")
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if record, _ := paymentRepo.GetBySessionID("cs_pending"); record.Status != tt.wantStatus {
+				t.Errorf("expected payment status %q, got %q", tt.wantStatus, record.Status)
+			}
+		})
+	}
+}
+
+func TestCancelCheckoutSession_NotFound(t *testing.T) {
+	handlers, _, _ := newCancelCheckoutTest(t)
+
+	w := cancelCheckoutAs(handlers, "cs_missing", "did:plc:payer")
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodePaymentNotFound)
+}
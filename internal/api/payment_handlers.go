@@ -3,9 +3,12 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/onnwee/subcults/internal/middleware"
@@ -19,6 +22,63 @@ const (
 	ErrCodeNotOnboarded     = "not_onboarded"
 )
 
+// OnboardingRateLimit is the per-DID rate limit applied to POST /payments/onboard.
+// Each onboarding attempt may create a Stripe Connect account, so scripted
+// retries are capped well below other write endpoints.
+var OnboardingRateLimit = middleware.RateLimitConfig{
+	RequestsPerWindow: 5,
+	WindowDuration:    time.Hour,
+}
+
+// onboardingLinkTTL is how long a Stripe account link stays usable.
+const onboardingLinkTTL = 30 * time.Minute
+
+// onboardingLink is an onboarding link issued for a scene's Connect account.
+type onboardingLink struct {
+	accountID string
+	url       string
+	expiresAt time.Time
+}
+
+// keyedMutex hands out one mutex per key, so work on different keys runs
+// concurrently. Entries are dropped once no caller holds or waits on them.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+// keyedLock is a key's mutex and the number of callers holding or awaiting it.
+type keyedLock struct {
+	sync.Mutex
+	refs int
+}
+
+// Lock locks key and returns the function that unlocks it.
+func (k *keyedMutex) Lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*keyedLock)
+	}
+	lock, ok := k.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		k.locks[key] = lock
+	}
+	lock.refs++
+	k.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		k.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
 // PaymentHandlers holds dependencies for payment-related HTTP handlers.
 type PaymentHandlers struct {
 	sceneRepo             scene.SceneRepository
@@ -27,6 +87,15 @@ type PaymentHandlers struct {
 	returnURL             string
 	refreshURL            string
 	applicationFeePercent float64
+	metrics               *payment.Metrics // Optional, can be nil
+
+	// onboardLocks serializes onboarding per scene so concurrent retries cannot
+	// each create a Connect account; links holds in-progress links keyed by
+	// scene ID and is guarded by linksMu.
+	onboardLocks keyedMutex
+	linksMu      sync.Mutex
+	links        map[string]onboardingLink
+	timeNow      func() time.Time // For testability
 }
 
 // NewPaymentHandlers creates a new PaymentHandlers instance.
@@ -45,9 +114,55 @@ func NewPaymentHandlers(
 		returnURL:             returnURL,
 		refreshURL:            refreshURL,
 		applicationFeePercent: applicationFeePercent,
+		links:                 make(map[string]onboardingLink),
+		timeNow:               time.Now,
+	}
+}
+
+// SetMetrics installs onboarding metrics. Passing nil disables metric collection.
+func (h *PaymentHandlers) SetMetrics(m *payment.Metrics) {
+	h.metrics = m
+}
+
+// recordOnboardingFailure increments the onboarding failure counter if metrics are installed.
+func (h *PaymentHandlers) recordOnboardingFailure(reason string) {
+	if h.metrics != nil {
+		h.metrics.IncOnboardingFailure(reason)
 	}
 }
 
+// inProgressLink returns the scene's unexpired onboarding link for accountID,
+// dropping any other link the scene holds.
+func (h *PaymentHandlers) inProgressLink(sceneID, accountID string) (onboardingLink, bool) {
+	h.linksMu.Lock()
+	defer h.linksMu.Unlock()
+
+	link, ok := h.links[sceneID]
+	if !ok {
+		return onboardingLink{}, false
+	}
+	if link.accountID != accountID || !h.timeNow().Before(link.expiresAt) {
+		delete(h.links, sceneID)
+		return onboardingLink{}, false
+	}
+	return link, true
+}
+
+// storeLink records a scene's onboarding link, pruning expired links so
+// scenes that never retry do not accumulate.
+func (h *PaymentHandlers) storeLink(sceneID string, link onboardingLink) {
+	h.linksMu.Lock()
+	defer h.linksMu.Unlock()
+
+	now := h.timeNow()
+	for id, existing := range h.links {
+		if !now.Before(existing.expiresAt) {
+			delete(h.links, id)
+		}
+	}
+	h.links[sceneID] = link
+}
+
 // OnboardSceneRequest represents the request body for creating a Stripe onboarding link.
 type OnboardSceneRequest struct {
 	SceneID string `json:"scene_id"`
@@ -87,6 +202,11 @@ func (h *PaymentHandlers) OnboardScene(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Hold the scene's onboarding lock across the read and the Connect account
+	// write, so a concurrent retry sees this request's account
+	unlock := h.onboardLocks.Lock(req.SceneID)
+	defer unlock()
+
 	// Get scene from repository
 	existingScene, err := h.sceneRepo.GetByID(req.SceneID)
 	if err != nil {
@@ -103,8 +223,22 @@ func (h *PaymentHandlers) OnboardScene(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.metrics != nil {
+		h.metrics.IncOnboardingAttempt()
+	}
+
 	// Check if scene already has a connected account
 	if existingScene.ConnectedAccountID != nil && *existingScene.ConnectedAccountID != "" {
+		// A rapid retry while onboarding is still in progress gets the same link
+		// back rather than an error or a second Connect account
+		if link, ok := h.inProgressLink(req.SceneID, *existingScene.ConnectedAccountID); ok && existingScene.AccountOnboardedAt == nil {
+			if h.metrics != nil {
+				h.metrics.IncOnboardingReused()
+			}
+			slog.InfoContext(ctx, "reusing in-progress onboarding link", "scene_id", req.SceneID, "account_id", link.accountID)
+			writeOnboardSceneResponse(w, link)
+			return
+		}
 		ctx = middleware.SetErrorCode(ctx, ErrCodeAlreadyOnboarded)
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeAlreadyOnboarded, "scene is already onboarded for payments")
 		return
@@ -113,6 +247,7 @@ func (h *PaymentHandlers) OnboardScene(w http.ResponseWriter, r *http.Request) {
 	// Create Stripe Connect account
 	account, err := h.stripeClient.CreateConnectAccount()
 	if err != nil {
+		h.recordOnboardingFailure(payment.OnboardingFailureConnectAccount)
 		slog.ErrorContext(ctx, "failed to create Stripe Connect account", "scene_id", req.SceneID, "error", err)
 		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
 		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to create payment account")
@@ -120,8 +255,9 @@ func (h *PaymentHandlers) OnboardScene(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create onboarding link
-	link, err := h.stripeClient.CreateAccountLink(account.ID, h.returnURL, h.refreshURL)
+	accountLink, err := h.stripeClient.CreateAccountLink(account.ID, h.returnURL, h.refreshURL)
 	if err != nil {
+		h.recordOnboardingFailure(payment.OnboardingFailureAccountLink)
 		slog.ErrorContext(ctx, "failed to create account link", "account_id", account.ID, "error", err)
 		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
 		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to create onboarding link")
@@ -133,19 +269,29 @@ func (h *PaymentHandlers) OnboardScene(w http.ResponseWriter, r *http.Request) {
 	// will be tracked via webhook (separate task).
 	existingScene.ConnectedAccountID = &account.ID
 	if err := h.sceneRepo.Update(existingScene); err != nil {
+		h.recordOnboardingFailure(payment.OnboardingFailureSceneUpdate)
 		slog.ErrorContext(ctx, "failed to update scene with connected account", "scene_id", req.SceneID, "error", err)
 		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
 		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to save payment account")
 		return
 	}
 
-	// Return onboarding URL and expiry
 	// Stripe account links typically expire in 30 minutes
-	expiresAt := time.Now().Add(30 * time.Minute).Format(time.RFC3339)
+	link := onboardingLink{
+		accountID: account.ID,
+		url:       accountLink.URL,
+		expiresAt: h.timeNow().Add(onboardingLinkTTL),
+	}
+	h.storeLink(req.SceneID, link)
 
+	writeOnboardSceneResponse(w, link)
+}
+
+// writeOnboardSceneResponse writes the onboarding URL and its expiry.
+func writeOnboardSceneResponse(w http.ResponseWriter, link onboardingLink) {
 	response := OnboardSceneResponse{
-		URL:       link.URL,
-		ExpiresAt: expiresAt,
+		URL:       link.url,
+		ExpiresAt: link.expiresAt.Format(time.RFC3339),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -362,11 +508,20 @@ func isValidRedirectURL(rawURL string) bool {
 
 // PaymentStatusResponse represents the response for payment status query.
 type PaymentStatusResponse struct {
-	Status      string     `json:"status"`
-	AmountCents int64      `json:"amount_cents"`
-	FeeCents    int64      `json:"fee_cents"`
-	Currency    string     `json:"currency"`
-	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	Status    string        `json:"status"`
+	Amount    payment.Money `json:"amount"`
+	Fee       payment.Money `json:"fee"`
+	UpdatedAt *time.Time    `json:"updated_at,omitempty"`
+}
+
+// newPaymentStatusResponse builds the status response for a payment record.
+func newPaymentStatusResponse(record *payment.PaymentRecord) PaymentStatusResponse {
+	return PaymentStatusResponse{
+		Status:    record.Status,
+		Amount:    payment.NewMoney(record.Amount, record.Currency),
+		Fee:       payment.NewMoney(record.Fee, record.Currency),
+		UpdatedAt: record.UpdatedAt,
+	}
 }
 
 // GetPaymentStatus retrieves the current status of a payment by checkout session ID.
@@ -412,7 +567,7 @@ func (h *PaymentHandlers) GetPaymentStatus(w http.ResponseWriter, r *http.Reques
 	// If not the payment owner, check if they are the scene owner
 	isSceneOwner := false
 	if !isPaymentOwner {
-		scn, err := h.sceneRepo.GetByID(paymentRecord.SceneID)
+		isSceneOwner, err = scene.CheckOwnership(h.sceneRepo, paymentRecord.SceneID, userDID)
 		if err != nil {
 			if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
 				// Scene not found or deleted - deny access
@@ -425,7 +580,6 @@ func (h *PaymentHandlers) GetPaymentStatus(w http.ResponseWriter, r *http.Reques
 			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to verify authorization")
 			return
 		}
-		isSceneOwner = scn.IsOwner(userDID)
 	}
 
 	// If neither payment owner nor scene owner, deny access
@@ -436,13 +590,7 @@ func (h *PaymentHandlers) GetPaymentStatus(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Return payment status
-	response := PaymentStatusResponse{
-		Status:      paymentRecord.Status,
-		AmountCents: paymentRecord.Amount,
-		FeeCents:    paymentRecord.Fee,
-		Currency:    paymentRecord.Currency,
-		UpdatedAt:   paymentRecord.UpdatedAt,
-	}
+	response := newPaymentStatusResponse(paymentRecord)
 
 	// For terminal states, allow a short-lived client-side cache to reduce
 	// repeated repository/DB reads during polling while keeping data private.
@@ -457,6 +605,222 @@ func (h *PaymentHandlers) GetPaymentStatus(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// CancelCheckoutSession cancels a pending checkout the caller abandoned.
+// POST /payments/{sessionId}/cancel
+// Only the payer may cancel. The Stripe session is expired first so it can no
+// longer be paid, then the payment is marked canceled. Canceling an already
+// canceled payment succeeds; any other non-pending payment is refused with 409.
+func (h *PaymentHandlers) CancelCheckoutSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get authenticated user DID from context
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeUnauthorized)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeUnauthorized, "authentication required")
+		return
+	}
+
+	// Extract session ID from /payments/{sessionId}/cancel
+	sessionID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/payments/"), "/cancel")
+	if sessionID == "" || strings.Contains(sessionID, "/") {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "session ID is required")
+		return
+	}
+
+	paymentRecord, err := h.paymentRepo.GetBySessionID(sessionID)
+	if err != nil {
+		if err == payment.ErrPaymentRecordNotFound {
+			ctx = middleware.SetErrorCode(ctx, ErrCodePaymentNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodePaymentNotFound, "payment not found")
+			return
+		}
+		slog.ErrorContext(ctx, "failed to get payment record", "session_id", sessionID, "error", err)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to cancel payment")
+		return
+	}
+
+	// Authorization: only the user who created the payment can cancel it
+	if paymentRecord.UserDID != userDID {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "only the payment creator can cancel a payment")
+		return
+	}
+
+	switch paymentRecord.Status {
+	case payment.StatusCanceled:
+		// Idempotent: already canceled, nothing to expire
+		writePaymentStatusResponse(w, paymentRecord)
+		return
+	case payment.StatusPending:
+	default:
+		ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "only pending payments can be canceled")
+		return
+	}
+
+	// Expire the Stripe session first; if it has already been paid, the local
+	// record is left for the webhook to complete
+	if err := payment.ExpireCheckout(h.stripeClient, sessionID); err != nil {
+		if err == payment.ErrCheckoutCompleted {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "checkout has already been completed")
+			return
+		}
+		slog.ErrorContext(ctx, "failed to expire checkout session", "session_id", sessionID, "error", err)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to cancel checkout session")
+		return
+	}
+
+	if err := h.paymentRepo.MarkCanceled(sessionID); err != nil {
+		if err == payment.ErrInvalidStatusTransition {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "only pending payments can be canceled")
+			return
+		}
+		slog.ErrorContext(ctx, "failed to mark payment canceled", "session_id", sessionID, "error", err)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to cancel payment")
+		return
+	}
+
+	paymentRecord, err = h.paymentRepo.GetBySessionID(sessionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get payment record", "session_id", sessionID, "error", err)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to cancel payment")
+		return
+	}
+
+	slog.InfoContext(ctx, "checkout canceled by payer", "session_id", sessionID)
+	writePaymentStatusResponse(w, paymentRecord)
+}
+
+// writePaymentStatusResponse writes the status of a payment record.
+func writePaymentStatusResponse(w http.ResponseWriter, record *payment.PaymentRecord) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(newPaymentStatusResponse(record)); err != nil {
+		slog.Error("failed to encode response", "error", err)
+	}
+}
+
+// MaxPaymentStatusBatchSize caps the session IDs accepted by POST /payments/status/batch.
+const MaxPaymentStatusBatchSize = 50
+
+// BatchPaymentStatusRequest represents the request body for a bulk payment status query.
+type BatchPaymentStatusRequest struct {
+	SessionIDs []string `json:"session_ids"`
+}
+
+// BatchPaymentStatusResponse represents the response for a bulk payment status query.
+// Sessions that don't exist and sessions the caller may not view are both listed
+// in Denied, so the response doesn't reveal which session IDs exist.
+type BatchPaymentStatusResponse struct {
+	Statuses map[string]PaymentStatusResponse `json:"statuses"`
+	Denied   []string                         `json:"denied"`
+}
+
+// GetPaymentStatusBatch retrieves the status of several payments by checkout session ID.
+// POST /payments/status/batch
+// Only payments the caller created, or that belong to a scene the caller owns, are returned.
+func (h *PaymentHandlers) GetPaymentStatusBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get authenticated user DID from context
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeUnauthorized)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeUnauthorized, "authentication required")
+		return
+	}
+
+	var req BatchPaymentStatusRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, ctx, err)
+		return
+	}
+
+	if len(req.SessionIDs) == 0 {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "session_ids must contain at least one session ID")
+		return
+	}
+	if len(req.SessionIDs) > MaxPaymentStatusBatchSize {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation,
+			fmt.Sprintf("session_ids cannot contain more than %d session IDs", MaxPaymentStatusBatchSize))
+		return
+	}
+
+	response := BatchPaymentStatusResponse{
+		Statuses: make(map[string]PaymentStatusResponse),
+		Denied:   []string{},
+	}
+
+	// Cache scene ownership so a batch for one scene checks it once
+	ownsScene := make(map[string]bool)
+	seen := make(map[string]bool, len(req.SessionIDs))
+
+	for _, sessionID := range req.SessionIDs {
+		if sessionID == "" {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "session_ids cannot contain empty values")
+			return
+		}
+		if seen[sessionID] {
+			continue
+		}
+		seen[sessionID] = true
+
+		paymentRecord, err := h.paymentRepo.GetBySessionID(sessionID)
+		if err != nil {
+			if err == payment.ErrPaymentRecordNotFound {
+				response.Denied = append(response.Denied, sessionID)
+				continue
+			}
+			slog.ErrorContext(ctx, "failed to get payment record", "session_id", sessionID, "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to retrieve payment status")
+			return
+		}
+
+		// Authorization: only the user who created the payment or scene owner can access
+		allowed := paymentRecord.UserDID == userDID
+		if !allowed {
+			isSceneOwner, checked := ownsScene[paymentRecord.SceneID]
+			if !checked {
+				isSceneOwner, err = scene.CheckOwnership(h.sceneRepo, paymentRecord.SceneID, userDID)
+				if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+					slog.ErrorContext(ctx, "failed to get scene", "scene_id", paymentRecord.SceneID, "error", err)
+					ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+					WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "failed to verify authorization")
+					return
+				}
+				// Scene not found or deleted - deny access
+				ownsScene[paymentRecord.SceneID] = isSceneOwner
+			}
+			allowed = isSceneOwner
+		}
+
+		if !allowed {
+			response.Denied = append(response.Denied, sessionID)
+			continue
+		}
+
+		response.Statuses[sessionID] = newPaymentStatusResponse(paymentRecord)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to encode response", "error", err)
+	}
+}
+
 // OnboardingStatusResponse represents the current onboarding status for a scene.
 type OnboardingStatusResponse struct {
 	SceneID                 string `json:"scene_id"`
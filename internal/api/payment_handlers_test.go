@@ -19,6 +19,8 @@ type mockStripeClient struct {
 	createAccountFunc         func() (*stripe.Account, error)
 	createAccountLinkFunc     func(accountID, returnURL, refreshURL string) (*stripe.AccountLink, error)
 	createCheckoutSessionFunc func(params *payment.CheckoutSessionParams) (*stripe.CheckoutSession, error)
+	expireCheckoutSessionFunc func(sessionID string) (*stripe.CheckoutSession, error)
+	getCheckoutSessionFunc    func(sessionID string) (*stripe.CheckoutSession, error)
 }
 
 func (m *mockStripeClient) CreateConnectAccount() (*stripe.Account, error) {
@@ -47,6 +49,26 @@ func (m *mockStripeClient) CreateCheckoutSession(params *payment.CheckoutSession
 	}, nil
 }
 
+func (m *mockStripeClient) ExpireCheckoutSession(sessionID string) (*stripe.CheckoutSession, error) {
+	if m.expireCheckoutSessionFunc != nil {
+		return m.expireCheckoutSessionFunc(sessionID)
+	}
+	return &stripe.CheckoutSession{
+		ID:     sessionID,
+		Status: stripe.CheckoutSessionStatusExpired,
+	}, nil
+}
+
+func (m *mockStripeClient) GetCheckoutSession(sessionID string) (*stripe.CheckoutSession, error) {
+	if m.getCheckoutSessionFunc != nil {
+		return m.getCheckoutSessionFunc(sessionID)
+	}
+	return &stripe.CheckoutSession{
+		ID:     sessionID,
+		Status: stripe.CheckoutSessionStatusOpen,
+	}, nil
+}
+
 // TestOnboardScene_Success tests successful scene onboarding.
 func TestOnboardScene_Success(t *testing.T) {
 	sceneRepo := scene.NewInMemorySceneRepository()
@@ -867,14 +889,11 @@ func TestGetPaymentStatus_Success(t *testing.T) {
 	if response.Status != payment.StatusPending {
 		t.Errorf("expected status pending, got %s", response.Status)
 	}
-	if response.AmountCents != 10000 {
-		t.Errorf("expected amount 10000, got %d", response.AmountCents)
-	}
-	if response.FeeCents != 500 {
-		t.Errorf("expected fee 500, got %d", response.FeeCents)
+	if response.Amount != payment.NewMoney(10000, "usd") {
+		t.Errorf("expected amount 10000 usd, got %+v", response.Amount)
 	}
-	if response.Currency != "usd" {
-		t.Errorf("expected currency usd, got %s", response.Currency)
+	if response.Fee != payment.NewMoney(500, "usd") {
+		t.Errorf("expected fee 500 usd, got %+v", response.Fee)
 	}
 }
 
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/payment"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stripe/stripe-go/v81"
+)
+
+// setupOnboardingTest creates payment handlers with registered metrics and a
+// single scene that has not been onboarded.
+func setupOnboardingTest(t *testing.T, client *mockStripeClient) (*PaymentHandlers, *prometheus.Registry) {
+	t.Helper()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	if err := sceneRepo.Insert(&scene.Scene{
+		ID:            "scene-1",
+		Name:          "Test Scene",
+		OwnerDID:      "did:plc:owner123",
+		CoarseGeohash: "dr5regw",
+	}); err != nil {
+		t.Fatalf("failed to create test scene: %v", err)
+	}
+
+	handlers := NewPaymentHandlers(
+		sceneRepo,
+		payment.NewInMemoryPaymentRepository(),
+		client,
+		"https://example.com/return",
+		"https://example.com/refresh",
+		5.0,
+	)
+	metrics := payment.NewMetrics()
+	reg := prometheus.NewRegistry()
+	if err := metrics.Register(reg); err != nil {
+		t.Fatalf("failed to register metrics: %v", err)
+	}
+	handlers.SetMetrics(metrics)
+	return handlers, reg
+}
+
+func newOnboardRequest(t *testing.T) *http.Request {
+	t.Helper()
+	body, _ := json.Marshal(OnboardSceneRequest{SceneID: "scene-1"})
+	req := httptest.NewRequest(http.MethodPost, "/payments/onboard", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner123"))
+}
+
+// countingStripeClient returns a mock client that issues a new account ID per call.
+func countingStripeClient(accountsCreated *int) *mockStripeClient {
+	return &mockStripeClient{
+		createAccountFunc: func() (*stripe.Account, error) {
+			*accountsCreated++
+			return &stripe.Account{ID: fmt.Sprintf("acct_%d", *accountsCreated)}, nil
+		},
+	}
+}
+
+func TestOnboardScene_RapidRetryReusesLink(t *testing.T) {
+	accountsCreated := 0
+	handlers, reg := setupOnboardingTest(t, countingStripeClient(&accountsCreated))
+
+	var responses []OnboardSceneResponse
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		handlers.OnboardScene(w, newOnboardRequest(t))
+		if w.Code != http.StatusOK {
+			t.Fatalf("attempt %d: expected status 200, got %d: %s", i+1, w.Code, w.Body.String())
+		}
+		var resp OnboardSceneResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+
+	if accountsCreated != 1 {
+		t.Errorf("expected 1 Connect account to be created, got %d", accountsCreated)
+	}
+	if responses[0] != responses[1] {
+		t.Errorf("expected retry to reuse link %+v, got %+v", responses[0], responses[1])
+	}
+	expected := `
+# HELP payment_onboarding_attempts_total Total number of payment onboarding requests that passed authorization checks
+# TYPE payment_onboarding_attempts_total counter
+payment_onboarding_attempts_total 2
+# HELP payment_onboarding_reused_total Total number of onboarding retries served an in-progress link instead of a new Connect account
+# TYPE payment_onboarding_reused_total counter
+payment_onboarding_reused_total 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), payment.MetricOnboardingAttempts, payment.MetricOnboardingReused); err != nil {
+		t.Errorf("unexpected metric values: %v", err)
+	}
+}
+
+func TestOnboardScene_ExpiredLinkNotReused(t *testing.T) {
+	accountsCreated := 0
+	handlers, _ := setupOnboardingTest(t, countingStripeClient(&accountsCreated))
+	now := time.Now()
+	handlers.timeNow = func() time.Time { return now }
+
+	w := httptest.NewRecorder()
+	handlers.OnboardScene(w, newOnboardRequest(t))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Once the link has expired the scene is treated as onboarded, as before
+	handlers.timeNow = func() time.Time { return now.Add(onboardingLinkTTL + time.Second) }
+	w = httptest.NewRecorder()
+	handlers.OnboardScene(w, newOnboardRequest(t))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if accountsCreated != 1 {
+		t.Errorf("expected 1 Connect account to be created, got %d", accountsCreated)
+	}
+}
+
+func TestOnboardScene_RateLimited(t *testing.T) {
+	accountsCreated := 0
+	handlers, _ := setupOnboardingTest(t, countingStripeClient(&accountsCreated))
+	limited := middleware.RateLimiter(middleware.NewInMemoryRateLimitStore(), OnboardingRateLimit, middleware.UserKeyFunc(), nil)(
+		http.HandlerFunc(handlers.OnboardScene),
+	)
+
+	for i := 0; i < OnboardingRateLimit.RequestsPerWindow; i++ {
+		w := httptest.NewRecorder()
+		limited.ServeHTTP(w, newOnboardRequest(t))
+		if w.Code != http.StatusOK {
+			t.Fatalf("attempt %d: expected status 200, got %d: %s", i+1, w.Code, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	limited.ServeHTTP(w, newOnboardRequest(t))
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 after %d attempts, got %d", OnboardingRateLimit.RequestsPerWindow, w.Code)
+	}
+	if accountsCreated != 1 {
+		t.Errorf("expected 1 Connect account to be created, got %d", accountsCreated)
+	}
+}
+
+func TestOnboardScene_FailureMetrics(t *testing.T) {
+	client := &mockStripeClient{
+		createAccountLinkFunc: func(accountID, returnURL, refreshURL string) (*stripe.AccountLink, error) {
+			return nil, errors.New("stripe unavailable")
+		},
+	}
+	handlers, reg := setupOnboardingTest(t, client)
+
+	w := httptest.NewRecorder()
+	handlers.OnboardScene(w, newOnboardRequest(t))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", w.Code)
+	}
+
+	expected := `
+# HELP payment_onboarding_attempts_total Total number of payment onboarding requests that passed authorization checks
+# TYPE payment_onboarding_attempts_total counter
+payment_onboarding_attempts_total 1
+# HELP payment_onboarding_failures_total Total number of payment onboarding failures, by reason
+# TYPE payment_onboarding_failures_total counter
+payment_onboarding_failures_total{reason="account_link"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), payment.MetricOnboardingAttempts, payment.MetricOnboardingFailures); err != nil {
+		t.Errorf("unexpected metric values: %v", err)
+	}
+}
+
+func TestOnboardScene_ConcurrentRetriesCreateOneAccount(t *testing.T) {
+	var mu sync.Mutex
+	accountsCreated := 0
+	handlers, _ := setupOnboardingTest(t, &mockStripeClient{
+		createAccountFunc: func() (*stripe.Account, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			accountsCreated++
+			return &stripe.Account{ID: fmt.Sprintf("acct_%d", accountsCreated)}, nil
+		},
+	})
+
+	const retries = 8
+	var wg sync.WaitGroup
+	codes := make([]int, retries)
+	for i := 0; i < retries; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			handlers.OnboardScene(w, newOnboardRequest(t))
+			codes[i] = w.Code
+		}(i)
+	}
+	wg.Wait()
+
+	for i, code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("attempt %d: expected status 200, got %d", i+1, code)
+		}
+	}
+	if accountsCreated != 1 {
+		t.Errorf("expected 1 Connect account to be created, got %d", accountsCreated)
+	}
+	if len(handlers.onboardLocks.locks) != 0 {
+		t.Errorf("expected onboarding locks to be released, %d remain", len(handlers.onboardLocks.locks))
+	}
+}
+
+func TestOnboardScene_ScenesOnboardConcurrently(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls int32
+	handlers, _ := setupOnboardingTest(t, &mockStripeClient{
+		createAccountFunc: func() (*stripe.Account, error) {
+			// The first scene's Stripe call stalls until the second scene is done
+			if atomic.AddInt32(&calls, 1) == 1 {
+				close(started)
+				<-release
+				return &stripe.Account{ID: "acct_slow"}, nil
+			}
+			return &stripe.Account{ID: "acct_fast"}, nil
+		},
+	})
+	if err := handlers.sceneRepo.Insert(&scene.Scene{
+		ID:            "scene-2",
+		Name:          "Other Scene",
+		OwnerDID:      "did:plc:owner123",
+		CoarseGeohash: "dr5regw",
+	}); err != nil {
+		t.Fatalf("failed to create test scene: %v", err)
+	}
+
+	slow := make(chan int)
+	go func() {
+		w := httptest.NewRecorder()
+		handlers.OnboardScene(w, newOnboardRequest(t))
+		slow <- w.Code
+	}()
+	<-started
+
+	fast := make(chan int)
+	go func() {
+		body, _ := json.Marshal(OnboardSceneRequest{SceneID: "scene-2"})
+		req := httptest.NewRequest(http.MethodPost, "/payments/onboard", bytes.NewReader(body))
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner123"))
+		w := httptest.NewRecorder()
+		handlers.OnboardScene(w, req)
+		fast <- w.Code
+	}()
+
+	select {
+	case code := <-fast:
+		if code != http.StatusOK {
+			t.Errorf("expected status 200 for the second scene, got %d", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("onboarding one scene blocked on another scene's Stripe call")
+	}
+	close(release)
+	if code := <-slow; code != http.StatusOK {
+		t.Errorf("expected status 200 for the first scene, got %d", code)
+	}
+}
+
+func TestOnboardScene_ExpiredLinksPruned(t *testing.T) {
+	accountsCreated := 0
+	handlers, _ := setupOnboardingTest(t, countingStripeClient(&accountsCreated))
+	if err := handlers.sceneRepo.Insert(&scene.Scene{
+		ID:            "scene-2",
+		Name:          "Other Scene",
+		OwnerDID:      "did:plc:owner123",
+		CoarseGeohash: "dr5regw",
+	}); err != nil {
+		t.Fatalf("failed to create test scene: %v", err)
+	}
+	now := time.Now()
+	handlers.timeNow = func() time.Time { return now }
+
+	w := httptest.NewRecorder()
+	handlers.OnboardScene(w, newOnboardRequest(t))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// scene-1 never retries; onboarding another scene later drops its stale link
+	handlers.timeNow = func() time.Time { return now.Add(onboardingLinkTTL + time.Second) }
+	body, _ := json.Marshal(OnboardSceneRequest{SceneID: "scene-2"})
+	req := httptest.NewRequest(http.MethodPost, "/payments/onboard", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner123"))
+	w = httptest.NewRecorder()
+	handlers.OnboardScene(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if _, ok := handlers.links["scene-1"]; ok {
+		t.Error("expected scene-1's expired link to be pruned")
+	}
+	if len(handlers.links) != 1 {
+		t.Errorf("expected 1 stored link, got %d", len(handlers.links))
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/payment"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// newBatchStatusTest creates payment handlers with two scenes and three payments:
+// cs_own (paid by did:plc:user123 in scene-1), cs_scene (paid by someone else in
+// scene-1, owned by did:plc:owner123) and cs_other (paid by someone else in scene-2).
+func newBatchStatusTest(t *testing.T) *PaymentHandlers {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	paymentRepo := payment.NewInMemoryPaymentRepository()
+	handlers := NewPaymentHandlers(
+		sceneRepo,
+		paymentRepo,
+		&mockStripeClient{},
+		"https://example.com/return",
+		"https://example.com/refresh",
+		5.0,
+	)
+
+	for _, sc := range []*scene.Scene{
+		{ID: "scene-1", Name: "Scene One", OwnerDID: "did:plc:owner123", CoarseGeohash: "dr5regw"},
+		{ID: "scene-2", Name: "Scene Two", OwnerDID: "did:plc:owner456", CoarseGeohash: "dr5regw"},
+	} {
+		if err := sceneRepo.Insert(sc); err != nil {
+			t.Fatalf("failed to create test scene: %v", err)
+		}
+	}
+
+	for _, rec := range []*payment.PaymentRecord{
+		{SessionID: "cs_own", Amount: 1000, Fee: 50, Currency: "usd", UserDID: "did:plc:user123", SceneID: "scene-1"},
+		{SessionID: "cs_scene", Amount: 2000, Fee: 100, Currency: "usd", UserDID: "did:plc:buyer", SceneID: "scene-1"},
+		{SessionID: "cs_other", Amount: 3000, Fee: 150, Currency: "usd", UserDID: "did:plc:buyer", SceneID: "scene-2"},
+	} {
+		if err := paymentRepo.CreatePending(rec); err != nil {
+			t.Fatalf("failed to create payment record: %v", err)
+		}
+	}
+
+	return handlers
+}
+
+func postBatchStatus(handlers *PaymentHandlers, userDID string, sessionIDs []string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(BatchPaymentStatusRequest{SessionIDs: sessionIDs})
+	req := httptest.NewRequest(http.MethodPost, "/payments/status/batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.GetPaymentStatusBatch(w, req)
+	return w
+}
+
+func TestGetPaymentStatusBatch_MixedAuthorization(t *testing.T) {
+	handlers := newBatchStatusTest(t)
+
+	// did:plc:owner123 owns scene-1 but didn't pay for anything
+	w := postBatchStatus(handlers, "did:plc:owner123", []string{"cs_own", "cs_scene", "cs_other", "cs_missing", "cs_scene"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response BatchPaymentStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(response.Statuses) != 2 {
+		t.Errorf("expected 2 permitted statuses, got %d: %+v", len(response.Statuses), response.Statuses)
+	}
+	if got := response.Statuses["cs_scene"]; got.Amount.Cents != 2000 || got.Status != payment.StatusPending {
+		t.Errorf("unexpected status for cs_scene: %+v", got)
+	}
+	if _, ok := response.Statuses["cs_own"]; !ok {
+		t.Error("expected scene owner to see cs_own")
+	}
+	if _, ok := response.Statuses["cs_other"]; ok {
+		t.Error("expected cs_other from another scene to be withheld")
+	}
+
+	denied := map[string]bool{}
+	for _, id := range response.Denied {
+		denied[id] = true
+	}
+	if len(response.Denied) != 2 || !denied["cs_other"] || !denied["cs_missing"] {
+		t.Errorf("expected cs_other and cs_missing to be denied, got %v", response.Denied)
+	}
+
+	// The payer sees only their own payment
+	w = postBatchStatus(handlers, "did:plc:user123", []string{"cs_own", "cs_scene"})
+	response = BatchPaymentStatusResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := response.Statuses["cs_own"]; !ok || len(response.Statuses) != 1 {
+		t.Errorf("expected payer to see only cs_own, got %+v", response.Statuses)
+	}
+}
+
+func TestGetPaymentStatusBatch_ExceedsCap(t *testing.T) {
+	handlers := newBatchStatusTest(t)
+
+	sessionIDs := make([]string, MaxPaymentStatusBatchSize+1)
+	for i := range sessionIDs {
+		sessionIDs[i] = fmt.Sprintf("cs_%d", i)
+	}
+
+	w := postBatchStatus(handlers, "did:plc:user123", sessionIDs)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation {
+		t.Errorf("expected error code %s, got %s", ErrCodeValidation, errResp.Error.Code)
+	}
+
+	// An empty batch is also rejected
+	if w := postBatchStatus(handlers, "did:plc:user123", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for empty batch, got %d", w.Code)
+	}
+}
+
+func TestGetPaymentStatusBatch_AllUnauthorized(t *testing.T) {
+	handlers := newBatchStatusTest(t)
+
+	w := postBatchStatus(handlers, "did:plc:stranger", []string{"cs_own", "cs_scene", "cs_other"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response BatchPaymentStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Statuses) != 0 {
+		t.Errorf("expected no permitted statuses, got %+v", response.Statuses)
+	}
+	if len(response.Denied) != 3 {
+		t.Errorf("expected all 3 sessions denied, got %v", response.Denied)
+	}
+}
+
+func TestGetPaymentStatusBatch_Unauthenticated(t *testing.T) {
+	handlers := newBatchStatusTest(t)
+
+	w := postBatchStatus(handlers, "", []string{"cs_own"})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
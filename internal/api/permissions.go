@@ -0,0 +1,92 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/trust"
+)
+
+// Capabilities reported by GET /scenes/{id}/permissions and
+// GET /events/{id}/permissions. Each one mirrors the check the handler for that
+// action enforces, so clients can render controls without guessing.
+const (
+	CapabilityView         = "view"          // Read the resource
+	CapabilityEdit         = "edit"          // PATCH the resource
+	CapabilityDelete       = "delete"        // DELETE /scenes/{id}
+	CapabilityModerate     = "moderate"      // Blocks, moderation log, membership review, pinned event
+	CapabilityStream       = "stream"        // Start a stream for the resource
+	CapabilityCreateEvent  = "create_event"  // Publish events in the scene directly
+	CapabilityProposeEvent = "propose_event" // Propose events for the owner's approval
+	CapabilityPost         = "post"          // Post to the scene or event feed
+	CapabilityAnnounce     = "announce"      // Post scene announcements
+	CapabilityCancel       = "cancel"        // POST /events/{id}/cancel
+	CapabilityReview       = "review"        // Approve or reject a proposed event
+	CapabilityRSVP         = "rsvp"          // RSVP to the event
+)
+
+// PermissionsResponse represents the response for the permissions endpoints.
+type PermissionsResponse struct {
+	// Role is "owner" for the scene owner, the caller's active membership role
+	// otherwise, and omitted for anonymous callers and non-members.
+	Role         string   `json:"role,omitempty"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// sceneRole returns "owner" if userDID owns the scene, its active membership
+// role if it has one, and "" otherwise. A nil membershipRepo only recognises
+// the owner.
+func sceneRole(membershipRepo membership.MembershipRepository, s *scene.Scene, userDID string) (string, error) {
+	if userDID == "" {
+		return "", nil
+	}
+	if s.IsOwner(userDID) {
+		return trust.RoleOwner, nil
+	}
+	if membershipRepo == nil {
+		return "", nil
+	}
+	m, err := membershipRepo.GetBySceneAndUser(s.ID, userDID)
+	if err != nil {
+		if errors.Is(err, membership.ErrMembershipNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	if m.Status != "active" {
+		return "", nil
+	}
+	return m.Role, nil
+}
+
+// sceneVisibleTo reports whether userDID may see the scene: its owner always,
+// anyone for public scenes, and active members for members-only scenes. Hidden
+// scenes and unknown visibility modes are owner-only. A nil membershipRepo
+// recognises no members.
+func sceneVisibleTo(membershipRepo membership.MembershipRepository, s *scene.Scene, userDID string) (bool, error) {
+	if s.IsOwner(userDID) {
+		return true, nil
+	}
+	switch s.Visibility {
+	case scene.VisibilityPublic:
+		return true, nil
+	case scene.VisibilityMembersOnly:
+		role, err := sceneRole(membershipRepo, s, userDID)
+		return role != "", err
+	default:
+		return false, nil
+	}
+}
+
+// roleCanAnnounce reports whether userDID, holding role from sceneRole, may post
+// announcements in the scene: its owner, or an active curator.
+func roleCanAnnounce(s *scene.Scene, userDID, role string) bool {
+	return s.IsOwner(userDID) || role == trust.RoleCurator
+}
+
+// roleCanModerate reports whether userDID, holding role from sceneRole, may
+// label and remove posts in the scene: its owner, or an active curator.
+func roleCanModerate(s *scene.Scene, userDID, role string) bool {
+	return s.IsOwner(userDID) || role == trust.RoleCurator
+}
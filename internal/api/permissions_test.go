@@ -0,0 +1,270 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+const (
+	permOwnerDID   = "did:plc:owner"
+	permCuratorDID = "did:plc:curator"
+	permMemberDID  = "did:plc:member"
+	permBlockedDID = "did:plc:blocked"
+)
+
+// newPermissionsTest creates scene and event handlers over a public scene that
+// requires event approval, with an active curator and member, a blocked DID,
+// and an upcoming event.
+func newPermissionsTest(t *testing.T) (*SceneHandlers, *EventHandlers) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	testScene := createTestScene(t, sceneRepo, "scene-1", permOwnerDID)
+	testScene.EventApprovalRequired = true
+	if err := sceneRepo.Update(testScene); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	for did, role := range map[string]string{permCuratorDID: "curator", permMemberDID: "member"} {
+		if _, err := membershipRepo.Upsert(&membership.Membership{
+			SceneID:     "scene-1",
+			UserDID:     did,
+			Role:        role,
+			Status:      "active",
+			TrustWeight: 0.5,
+		}); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	blockRepo := scene.NewInMemoryBlockRepository()
+	if _, err := blockRepo.Add(&scene.Block{SceneID: "scene-1", BlockedDID: permBlockedDID, BlockedBy: permOwnerDID}); err != nil {
+		t.Fatalf("failed to block: %v", err)
+	}
+
+	eventRepo := scene.NewInMemoryEventRepository()
+	if err := eventRepo.Insert(&scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Warehouse Night",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	sceneHandlers := NewSceneHandlers(sceneRepo, membershipRepo, stream.NewInMemorySessionRepository())
+	sceneHandlers.SetBlockRepo(blockRepo)
+	eventHandlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	eventHandlers.SetMembershipRepo(membershipRepo)
+	eventHandlers.SetBlockRepo(blockRepo)
+	return sceneHandlers, eventHandlers
+}
+
+func doGetPermissions(t *testing.T, handler http.HandlerFunc, target, userDID string) PermissionsResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response PermissionsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+func TestGetScenePermissions(t *testing.T) {
+	sceneHandlers, _ := newPermissionsTest(t)
+
+	tests := []struct {
+		name     string
+		userDID  string
+		wantRole string
+		want     []string
+	}{
+		{
+			name:     "owner",
+			userDID:  permOwnerDID,
+			wantRole: "owner",
+			want: []string{CapabilityView, CapabilityEdit, CapabilityDelete, CapabilityModerate, CapabilityStream,
+				CapabilityCreateEvent, CapabilityPost, CapabilityAnnounce},
+		},
+		{
+			name:     "curator",
+			userDID:  permCuratorDID,
+			wantRole: "curator",
+			want:     []string{CapabilityView, CapabilityProposeEvent, CapabilityPost, CapabilityAnnounce},
+		},
+		{
+			name:     "member",
+			userDID:  permMemberDID,
+			wantRole: "member",
+			want:     []string{CapabilityView, CapabilityProposeEvent, CapabilityPost},
+		},
+		{
+			name:    "non-member",
+			userDID: "did:plc:stranger",
+			want:    []string{CapabilityView, CapabilityPost},
+		},
+		{
+			name:    "blocked",
+			userDID: permBlockedDID,
+			want:    []string{CapabilityView},
+		},
+		{
+			name: "anonymous",
+			want: []string{CapabilityView},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := doGetPermissions(t, sceneHandlers.GetScenePermissions, "/scenes/scene-1/permissions", tt.userDID)
+			if got.Role != tt.wantRole {
+				t.Errorf("role = %q, want %q", got.Role, tt.wantRole)
+			}
+			if !reflect.DeepEqual(got.Capabilities, tt.want) {
+				t.Errorf("capabilities = %v, want %v", got.Capabilities, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetScenePermissions_HiddenScene(t *testing.T) {
+	sceneHandlers, _ := newPermissionsTest(t)
+	hidden := &scene.Scene{ID: "scene-hidden", Name: "Hidden Scene", OwnerDID: permOwnerDID, CoarseGeohash: "dr5regw", Visibility: scene.VisibilityHidden}
+	if err := sceneHandlers.repo.Insert(hidden); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	for _, userDID := range []string{"", permMemberDID} {
+		req := httptest.NewRequest(http.MethodGet, "/scenes/scene-hidden/permissions", nil)
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+		w := httptest.NewRecorder()
+		sceneHandlers.GetScenePermissions(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%q: expected status 404, got %d: %s", userDID, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestGetEventPermissions(t *testing.T) {
+	_, eventHandlers := newPermissionsTest(t)
+
+	tests := []struct {
+		name    string
+		userDID string
+		want    []string
+	}{
+		{
+			name:    "owner",
+			userDID: permOwnerDID,
+			want:    []string{CapabilityView, CapabilityEdit, CapabilityCancel, CapabilityStream, CapabilityPost, CapabilityRSVP},
+		},
+		{
+			name:    "curator",
+			userDID: permCuratorDID,
+			want:    []string{CapabilityView, CapabilityPost, CapabilityRSVP},
+		},
+		{
+			name:    "member",
+			userDID: permMemberDID,
+			want:    []string{CapabilityView, CapabilityPost, CapabilityRSVP},
+		},
+		{
+			name:    "blocked",
+			userDID: permBlockedDID,
+			want:    []string{CapabilityView},
+		},
+		{
+			name: "anonymous",
+			want: []string{CapabilityView},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := doGetPermissions(t, eventHandlers.GetEventPermissions, "/events/event-1/permissions", tt.userDID)
+			if !reflect.DeepEqual(got.Capabilities, tt.want) {
+				t.Errorf("capabilities = %v, want %v", got.Capabilities, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetEventPermissions_StartedEvent(t *testing.T) {
+	_, eventHandlers := newPermissionsTest(t)
+	// Past the edit grace period and closed to RSVPs
+	eventHandlers.timeNow = func() time.Time { return time.Now().Add(48 * time.Hour) }
+
+	got := doGetPermissions(t, eventHandlers.GetEventPermissions, "/events/event-1/permissions", permOwnerDID)
+	want := []string{CapabilityView, CapabilityCancel, CapabilityStream, CapabilityPost}
+	if !reflect.DeepEqual(got.Capabilities, want) {
+		t.Errorf("capabilities = %v, want %v", got.Capabilities, want)
+	}
+}
+
+func TestSceneVisibleTo(t *testing.T) {
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	for did, status := range map[string]string{permMemberDID: "active", "did:plc:pending": "pending"} {
+		if _, err := membershipRepo.Upsert(&membership.Membership{
+			SceneID:     "scene-1",
+			UserDID:     did,
+			Role:        "member",
+			Status:      status,
+			TrustWeight: 0.5,
+		}); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		visibility string
+		userDID    string
+		nilRepo    bool
+		want       bool
+	}{
+		{name: "public to anyone", visibility: scene.VisibilityPublic, userDID: "", want: true},
+		{name: "members-only to owner", visibility: scene.VisibilityMembersOnly, userDID: permOwnerDID, want: true},
+		{name: "members-only to active member", visibility: scene.VisibilityMembersOnly, userDID: permMemberDID, want: true},
+		{name: "members-only to pending member", visibility: scene.VisibilityMembersOnly, userDID: "did:plc:pending", want: false},
+		{name: "members-only to stranger", visibility: scene.VisibilityMembersOnly, userDID: "did:plc:stranger", want: false},
+		{name: "members-only to anonymous", visibility: scene.VisibilityMembersOnly, userDID: "", want: false},
+		{name: "members-only without membership repository", visibility: scene.VisibilityMembersOnly, userDID: permMemberDID, nilRepo: true, want: false},
+		{name: "hidden to owner", visibility: scene.VisibilityHidden, userDID: permOwnerDID, want: true},
+		{name: "hidden to active member", visibility: scene.VisibilityHidden, userDID: permMemberDID, want: false},
+		{name: "unknown visibility", visibility: "bogus", userDID: permMemberDID, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &scene.Scene{ID: "scene-1", OwnerDID: permOwnerDID, Visibility: tt.visibility}
+			var repo membership.MembershipRepository = membershipRepo
+			if tt.nilRepo {
+				repo = nil
+			}
+			got, err := sceneVisibleTo(repo, s, tt.userDID)
+			if err != nil {
+				t.Fatalf("sceneVisibleTo() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("sceneVisibleTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newPinnedEventTest creates event handlers over a scene owned by did:plc:owner
+// with three events a week apart: "event-first", "event-second" and the
+// scene's weekly flagship "event-weekly", which starts last.
+func newPinnedEventTest(t *testing.T) (*EventHandlers, scene.EventRepository) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	for _, sc := range []*scene.Scene{
+		{ID: "scene-pinned", Name: "Pinned Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+		{ID: "scene-other", Name: "Other Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+	} {
+		if err := sceneRepo.Insert(sc); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	eventRepo := scene.NewInMemoryEventRepository()
+	start := time.Now().Add(24 * time.Hour)
+	for i, ev := range []struct{ id, sceneID string }{
+		{"event-first", "scene-pinned"},
+		{"event-second", "scene-pinned"},
+		{"event-weekly", "scene-pinned"},
+		{"event-elsewhere", "scene-other"},
+	} {
+		if err := eventRepo.Insert(&scene.Event{
+			ID:            ev.id,
+			SceneID:       ev.sceneID,
+			Title:         "Test Event",
+			CoarseGeohash: "dr5regw",
+			StartsAt:      start.Add(time.Duration(i) * 7 * 24 * time.Hour),
+		}); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+
+	handlers := NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), scene.NewInMemoryRSVPRepository(), stream.NewInMemorySessionRepository(), nil)
+	return handlers, eventRepo
+}
+
+func pinEvent(handlers *EventHandlers, userDID, eventID string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(SetPinnedEventRequest{EventID: eventID})
+	req := httptest.NewRequest(http.MethodPut, "/scenes/scene-pinned/pinned_event", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.SetPinnedEvent(w, req)
+	return w
+}
+
+func listSceneEvents(t *testing.T, handlers *EventHandlers) SceneEventsResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handlers.ListSceneEvents(w, httptest.NewRequest(http.MethodGet, "/scenes/scene-pinned/events", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response SceneEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+func sceneEventIDs(response SceneEventsResponse) []string {
+	ids := make([]string, len(response.Events))
+	for i, event := range response.Events {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+func TestListSceneEvents_PinnedEventFirst(t *testing.T) {
+	handlers, _ := newPinnedEventTest(t)
+
+	unpinned := listSceneEvents(t, handlers)
+	if want := []string{"event-first", "event-second", "event-weekly"}; !reflect.DeepEqual(sceneEventIDs(unpinned), want) {
+		t.Errorf("unpinned order = %v, want %v", sceneEventIDs(unpinned), want)
+	}
+	if unpinned.PinnedEventID != nil {
+		t.Errorf("expected no pinned event, got %s", *unpinned.PinnedEventID)
+	}
+
+	if w := pinEvent(handlers, "did:plc:owner", "event-weekly"); w.Code != http.StatusOK {
+		t.Fatalf("pin: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	pinned := listSceneEvents(t, handlers)
+	if want := []string{"event-weekly", "event-first", "event-second"}; !reflect.DeepEqual(sceneEventIDs(pinned), want) {
+		t.Errorf("pinned order = %v, want %v", sceneEventIDs(pinned), want)
+	}
+	if pinned.PinnedEventID == nil || *pinned.PinnedEventID != "event-weekly" {
+		t.Errorf("pinned_event_id = %v, want event-weekly", pinned.PinnedEventID)
+	}
+}
+
+func TestClearPinnedEvent(t *testing.T) {
+	handlers, _ := newPinnedEventTest(t)
+	if w := pinEvent(handlers, "did:plc:owner", "event-weekly"); w.Code != http.StatusOK {
+		t.Fatalf("pin: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/scenes/scene-pinned/pinned_event", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+	handlers.ClearPinnedEvent(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("clear: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	response := listSceneEvents(t, handlers)
+	if response.PinnedEventID != nil {
+		t.Errorf("expected no pinned event after clearing, got %s", *response.PinnedEventID)
+	}
+	if want := []string{"event-first", "event-second", "event-weekly"}; !reflect.DeepEqual(sceneEventIDs(response), want) {
+		t.Errorf("order after clearing = %v, want %v", sceneEventIDs(response), want)
+	}
+}
+
+func TestListSceneEvents_CancelledPinnedEventDemoted(t *testing.T) {
+	handlers, eventRepo := newPinnedEventTest(t)
+	if w := pinEvent(handlers, "did:plc:owner", "event-weekly"); w.Code != http.StatusOK {
+		t.Fatalf("pin: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if err := eventRepo.Cancel("event-weekly", nil); err != nil {
+		t.Fatalf("failed to cancel event: %v", err)
+	}
+
+	response := listSceneEvents(t, handlers)
+	if response.PinnedEventID != nil {
+		t.Errorf("expected cancelled event to lose the pinned slot, got %s", *response.PinnedEventID)
+	}
+	if want := []string{"event-first", "event-second", "event-weekly"}; !reflect.DeepEqual(sceneEventIDs(response), want) {
+		t.Errorf("order with cancelled pin = %v, want %v", sceneEventIDs(response), want)
+	}
+}
+
+func TestSetPinnedEvent_Rejected(t *testing.T) {
+	handlers, eventRepo := newPinnedEventTest(t)
+	if err := eventRepo.Cancel("event-second", nil); err != nil {
+		t.Fatalf("failed to cancel event: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		userDID    string
+		eventID    string
+		wantStatus int
+	}{
+		{name: "not the owner", userDID: "did:plc:stranger", eventID: "event-weekly", wantStatus: http.StatusForbidden},
+		{name: "event in another scene", userDID: "did:plc:owner", eventID: "event-elsewhere", wantStatus: http.StatusBadRequest},
+		{name: "unknown event", userDID: "did:plc:owner", eventID: "event-missing", wantStatus: http.StatusBadRequest},
+		{name: "cancelled event", userDID: "did:plc:owner", eventID: "event-second", wantStatus: http.StatusBadRequest},
+		{name: "missing event ID", userDID: "did:plc:owner", eventID: "", wantStatus: http.StatusBadRequest},
+		{name: "unauthenticated", userDID: "", eventID: "event-weekly", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := pinEvent(handlers, tt.userDID, tt.eventID); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestListSceneEvents_PrivateSceneNotFound(t *testing.T) {
+	handlers, _ := newPinnedEventTest(t)
+	s, err := handlers.sceneRepo.GetByID("scene-pinned")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	s.Visibility = scene.VisibilityMembersOnly
+	if err := handlers.sceneRepo.Update(s); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	for _, userDID := range []string{"", "did:plc:stranger"} {
+		req := httptest.NewRequest(http.MethodGet, "/scenes/scene-pinned/events", nil)
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+		w := httptest.NewRecorder()
+		handlers.ListSceneEvents(w, req)
+		assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene-pinned/events", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w := httptest.NewRecorder()
+	handlers.ListSceneEvents(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected the owner to list events, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestListSceneEvents_MatureSceneRedacted(t *testing.T) {
+	handlers, _ := newPinnedEventTest(t)
+	s, err := handlers.sceneRepo.GetByID("scene-pinned")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	s.ContentRating = scene.ContentRatingMature
+	if err := handlers.sceneRepo.Update(s); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	handlers.ListSceneEvents(w, httptest.NewRequest(http.MethodGet, "/scenes/scene-pinned/events", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Vary"); got != MatureContentHeader {
+		t.Errorf("Vary = %q, want %s", got, MatureContentHeader)
+	}
+	var redacted SceneEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&redacted); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !redacted.ContentRedacted || len(redacted.Events) != 0 {
+		t.Errorf("expected redacted response without events, got %+v", redacted)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene-pinned/events", nil)
+	req.Header.Set(MatureContentHeader, "true")
+	w = httptest.NewRecorder()
+	handlers.ListSceneEvents(w, req)
+	var optedIn SceneEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&optedIn); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if optedIn.ContentRedacted || len(optedIn.Events) != 3 {
+		t.Errorf("expected 3 events after opting in, got %+v", optedIn)
+	}
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/trust"
+)
+
+const (
+	announceSceneID  = "scene-announce"
+	announceOwnerDID = "did:plc:owner"
+	announceCurator  = "did:plc:curator"
+	announceMember   = "did:plc:member"
+)
+
+// newAnnouncementTest creates post handlers with a public scene owned by
+// announceOwnerDID, an active curator and an active regular member.
+func newAnnouncementTest(t *testing.T) *PostHandlers {
+	t.Helper()
+
+	postRepo := post.NewInMemoryPostRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	handlers := NewPostHandlers(postRepo, sceneRepo, membershipRepo, nil)
+
+	if err := sceneRepo.Insert(&scene.Scene{
+		ID:            announceSceneID,
+		Name:          "Announcement Scene",
+		OwnerDID:      announceOwnerDID,
+		Visibility:    scene.VisibilityPublic,
+		CoarseGeohash: "9q8yy",
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	for did, role := range map[string]string{announceCurator: trust.RoleCurator, announceMember: trust.RoleMember} {
+		if _, err := membershipRepo.Upsert(&membership.Membership{
+			SceneID:     announceSceneID,
+			UserDID:     did,
+			Role:        role,
+			Status:      "active",
+			TrustWeight: 0.5,
+		}); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	return handlers
+}
+
+func postAnnouncement(t *testing.T, handlers *PostHandlers, authorDID string, req CreatePostRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r = r.WithContext(middleware.SetUserDID(r.Context(), authorDID))
+	w := httptest.NewRecorder()
+	handlers.CreatePost(w, r)
+	return w
+}
+
+func getSceneFeedPosts(t *testing.T, handler http.HandlerFunc, target string) []PostResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, target, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s: expected status 200, got %d: %s", target, w.Code, w.Body.String())
+	}
+	var response FeedResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response.Posts
+}
+
+func TestCreatePost_AnnouncementByOwnerOrCurator(t *testing.T) {
+	handlers := newAnnouncementTest(t)
+
+	for _, did := range []string{announceOwnerDID, announceCurator} {
+		w := postAnnouncement(t, handlers, did, CreatePostRequest{
+			SceneID:        strPtr(announceSceneID),
+			Text:           "Venue change for Friday",
+			IsAnnouncement: true,
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("%s: expected status 201, got %d: %s", did, w.Code, w.Body.String())
+		}
+
+		var created post.Post
+		if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !created.IsAnnouncement {
+			t.Errorf("%s: expected is_announcement to be true", did)
+		}
+	}
+}
+
+func TestCreatePost_AnnouncementForbiddenForMember(t *testing.T) {
+	handlers := newAnnouncementTest(t)
+
+	for _, did := range []string{announceMember, "did:plc:stranger"} {
+		w := postAnnouncement(t, handlers, did, CreatePostRequest{
+			SceneID:        strPtr(announceSceneID),
+			Text:           "Listen to me",
+			IsAnnouncement: true,
+		})
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected status 403, got %d: %s", did, w.Code, w.Body.String())
+		}
+	}
+
+	// The same member can still post to the regular feed
+	w := postAnnouncement(t, handlers, announceMember, CreatePostRequest{
+		SceneID: strPtr(announceSceneID),
+		Text:    "Regular post",
+	})
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected regular post to be created, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreatePost_AnnouncementValidation(t *testing.T) {
+	handlers := newAnnouncementTest(t)
+
+	tests := []struct {
+		name       string
+		req        CreatePostRequest
+		wantStatus int
+	}{
+		{
+			name:       "event-only announcement",
+			req:        CreatePostRequest{EventID: strPtr("event-1"), Text: "Doors at 8", IsAnnouncement: true},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown scene",
+			req:        CreatePostRequest{SceneID: strPtr("scene-missing"), Text: "Doors at 8", IsAnnouncement: true},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postAnnouncement(t, handlers, announceOwnerDID, tt.req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetSceneAnnouncements_ListedSeparately(t *testing.T) {
+	handlers := newAnnouncementTest(t)
+
+	for _, req := range []struct {
+		author string
+		body   CreatePostRequest
+	}{
+		{announceOwnerDID, CreatePostRequest{SceneID: strPtr(announceSceneID), Text: "Announcement one", IsAnnouncement: true}},
+		{announceMember, CreatePostRequest{SceneID: strPtr(announceSceneID), Text: "Regular one"}},
+		{announceCurator, CreatePostRequest{SceneID: strPtr(announceSceneID), Text: "Announcement two", IsAnnouncement: true}},
+		{announceMember, CreatePostRequest{SceneID: strPtr(announceSceneID), Text: "Regular two"}},
+	} {
+		if w := postAnnouncement(t, handlers, req.author, req.body); w.Code != http.StatusCreated {
+			t.Fatalf("failed to create post: %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	announcements := getSceneFeedPosts(t, handlers.GetSceneAnnouncements, "/scenes/"+announceSceneID+"/announcements")
+	if len(announcements) != 2 {
+		t.Errorf("expected 2 announcements, got %d", len(announcements))
+	}
+	for _, p := range announcements {
+		if !p.IsAnnouncement {
+			t.Errorf("regular post %q listed as an announcement", p.Text)
+		}
+	}
+
+	feed := getSceneFeedPosts(t, handlers.GetSceneFeed, "/scenes/"+announceSceneID+"/feed")
+	if len(feed) != 2 {
+		t.Errorf("expected 2 regular posts in the feed, got %d", len(feed))
+	}
+	for _, p := range feed {
+		if p.IsAnnouncement {
+			t.Errorf("announcement %q listed in the regular feed", p.Text)
+		}
+	}
+
+	inline := getSceneFeedPosts(t, handlers.GetSceneFeed, "/scenes/"+announceSceneID+"/feed?include_announcements=true")
+	if len(inline) != 4 {
+		t.Errorf("expected all 4 posts with announcements inline, got %d", len(inline))
+	}
+
+	w := httptest.NewRecorder()
+	handlers.GetSceneFeed(w, httptest.NewRequest(http.MethodGet, "/scenes/"+announceSceneID+"/feed?include_announcements=maybe", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid include_announcements, got %d", w.Code)
+	}
+}
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/onnwee/subcults/internal/attachment"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/storage"
+)
+
+// attachmentsOfSize returns n key-based attachments of sizeBytes each.
+func attachmentsOfSize(n int, sizeBytes int64) []post.Attachment {
+	attachments := make([]post.Attachment, n)
+	for i := range attachments {
+		attachments[i] = post.Attachment{Key: "attachments/did:plc:testuser123/photo.jpg", Type: "image/jpeg", SizeBytes: sizeBytes}
+	}
+	return attachments
+}
+
+// storedS3Client reports uploaded audio objects of the given sizes by key,
+// standing in for R2.
+type storedS3Client map[string]int64
+
+func (c storedS3Client) HeadObject(_ context.Context, in *s3.HeadObjectInput, _ ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	size, ok := c[aws.ToString(in.Key)]
+	if !ok {
+		return nil, errors.New("no such key")
+	}
+	return &s3.HeadObjectOutput{ContentType: aws.String("audio/mpeg"), ContentLength: aws.Int64(size)}, nil
+}
+
+func (storedS3Client) GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (storedS3Client) PutObject(context.Context, *s3.PutObjectInput, ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	return nil, errors.New("not implemented")
+}
+
+// newStoredAttachmentHandlers creates post handlers whose attachment sizes are
+// read from objects the test uploads with storedAttachments.
+func newStoredAttachmentHandlers(t *testing.T) (*PostHandlers, storedS3Client) {
+	t.Helper()
+	store := storedS3Client{}
+	metadataService, err := attachment.NewMetadataService(attachment.MetadataServiceConfig{S3Client: store, BucketName: "test-bucket"})
+	if err != nil {
+		t.Fatalf("failed to create metadata service: %v", err)
+	}
+	handlers := newTestPostHandlers()
+	handlers.metadataService = metadataService
+	return handlers, store
+}
+
+// storedAttachments uploads n distinct objects of sizeBytes each to store and
+// returns attachments referencing them.
+func storedAttachments(store storedS3Client, n int, sizeBytes int64) []post.Attachment {
+	attachments := make([]post.Attachment, n)
+	for i := range attachments {
+		key := fmt.Sprintf("attachments/did:plc:testuser123/track-%d-%d.mp3", len(store), i)
+		store[key] = sizeBytes
+		attachments[i] = post.Attachment{Key: key, Type: "audio/mpeg", SizeBytes: sizeBytes}
+	}
+	return attachments
+}
+
+func doUpdatePostAttachments(t *testing.T, h *PostHandlers, postID string, attachments []post.Attachment) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(UpdatePostRequest{Attachments: &attachments})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := withAuthContext(httptest.NewRequest(http.MethodPatch, "/posts/"+postID, bytes.NewReader(body)))
+	w := httptest.NewRecorder()
+	h.UpdatePost(w, req)
+	return w
+}
+
+// createdPostID returns the ID of the post in a 201 response.
+func createdPostID(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created PostResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return created.ID
+}
+
+func doCreatePostWithAttachments(t *testing.T, h *PostHandlers, sceneID string, attachments []post.Attachment) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(CreatePostRequest{SceneID: &sceneID, Text: "Gallery", Attachments: attachments})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := withAuthContext(httptest.NewRequest(http.MethodPost, "/posts", bytes.NewReader(body)))
+	w := httptest.NewRecorder()
+	h.CreatePost(w, req)
+	return w
+}
+
+func TestCreatePost_AttachmentTotalSizeLimit(t *testing.T) {
+	handlers := newTestPostHandlers()
+
+	// Six 10MB images each pass individually but total 60MB
+	w := doCreatePostWithAttachments(t, handlers, "scene-1", attachmentsOfSize(6, 10<<20))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation || !strings.Contains(errResp.Error.Message, "per-post total size") {
+		t.Errorf("expected per-post limit validation error, got %+v", errResp.Error)
+	}
+}
+
+func TestCreatePost_SceneDailyUploadQuota(t *testing.T) {
+	handlers, store := newStoredAttachmentHandlers(t)
+	tracker := storage.NewInMemoryQuotaTracker()
+	handlers.SetUploadQuota(tracker, 25<<20)
+
+	if w := doCreatePostWithAttachments(t, handlers, "scene-1", storedAttachments(store, 2, 10<<20)); w.Code != http.StatusCreated {
+		t.Fatalf("expected first post to pass, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := doCreatePostWithAttachments(t, handlers, "scene-1", storedAttachments(store, 1, 10<<20))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 once the quota is exceeded, got %d", w.Code)
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation || !strings.Contains(errResp.Error.Message, "daily upload quota") {
+		t.Errorf("expected daily quota validation error, got %+v", errResp.Error)
+	}
+	if got := tracker.Usage("scene-1"); got != 20<<20 {
+		t.Errorf("expected rejected post not to be charged, usage = %d", got)
+	}
+
+	// Another scene is unaffected
+	if w := doCreatePostWithAttachments(t, handlers, "scene-2", storedAttachments(store, 1, 10<<20)); w.Code != http.StatusCreated {
+		t.Errorf("expected other scene's post to pass, got %d", w.Code)
+	}
+}
+
+func TestCreatePost_AttachmentsWithinLimits(t *testing.T) {
+	handlers, store := newStoredAttachmentHandlers(t)
+
+	w := doCreatePostWithAttachments(t, handlers, "scene-1", storedAttachments(store, MaxAttachments, 5<<20))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreatePost_RejectsNonPositiveAttachmentSizes(t *testing.T) {
+	handlers := newTestPostHandlers()
+
+	for _, size := range []int64{0, -(10 << 20)} {
+		attachments := append(attachmentsOfSize(1, 10<<20), post.Attachment{Key: "attachments/did:plc:testuser123/b.jpg", Type: "image/jpeg", SizeBytes: size})
+		w := doCreatePostWithAttachments(t, handlers, "scene-1", attachments)
+		assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+	}
+}
+
+func TestCreatePost_UnverifiedAttachmentsChargedAtUploadCap(t *testing.T) {
+	handlers := newTestPostHandlers()
+	tracker := storage.NewInMemoryQuotaTracker()
+	handlers.SetUploadQuota(tracker, 100<<20)
+	handlers.SetMaxAttachmentBytes(15 << 20)
+
+	// Without storage to read sizes from, a 1-byte declaration counts as the cap
+	createdPostID(t, doCreatePostWithAttachments(t, handlers, "scene-1", attachmentsOfSize(1, 1)))
+	if got := tracker.Usage("scene-1"); got != 15<<20 {
+		t.Errorf("usage = %d, want the %d byte upload cap", got, 15<<20)
+	}
+
+	// Understated sizes can't slip past the per-post total either
+	w := doCreatePostWithAttachments(t, handlers, "scene-1", attachmentsOfSize(4, 1))
+	assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+}
+
+func TestCreatePost_EventOnlyPostChargesEventScene(t *testing.T) {
+	handlers, store := newStoredAttachmentHandlers(t)
+	tracker := storage.NewInMemoryQuotaTracker()
+	handlers.SetUploadQuota(tracker, 25<<20)
+	eventRepo := scene.NewInMemoryEventRepository()
+	if err := eventRepo.Insert(&scene.Event{ID: "event-1", SceneID: "scene-1", Title: "Night", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	handlers.SetBlockRepo(nil, eventRepo)
+
+	eventID := "event-1"
+	body, err := json.Marshal(CreatePostRequest{EventID: &eventID, Text: "Set recording", Attachments: storedAttachments(store, 2, 10<<20)})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	handlers.CreatePost(w, withAuthContext(httptest.NewRequest(http.MethodPost, "/posts", bytes.NewReader(body))))
+	createdPostID(t, w)
+
+	if got := tracker.Usage("scene-1"); got != 20<<20 {
+		t.Errorf("expected the event's scene to be charged, usage = %d", got)
+	}
+}
+
+func TestUpdatePost_ChargesAddedAttachments(t *testing.T) {
+	handlers, store := newStoredAttachmentHandlers(t)
+	tracker := storage.NewInMemoryQuotaTracker()
+	handlers.SetUploadQuota(tracker, 25<<20)
+
+	original := storedAttachments(store, 1, 10<<20)
+	postID := createdPostID(t, doCreatePostWithAttachments(t, handlers, "scene-1", original))
+
+	// Keeping the existing attachment and adding one charges only the new one
+	added := append(original, storedAttachments(store, 1, 10<<20)...)
+	if w := doUpdatePostAttachments(t, handlers, postID, added); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := tracker.Usage("scene-1"); got != 20<<20 {
+		t.Errorf("usage = %d, want %d", got, 20<<20)
+	}
+
+	// Swapping attachments in by editing can't get around the quota
+	w := doUpdatePostAttachments(t, handlers, postID, storedAttachments(store, 1, 10<<20))
+	assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+	if got := tracker.Usage("scene-1"); got != 20<<20 {
+		t.Errorf("expected the rejected update not to be charged, usage = %d", got)
+	}
+}
+
+func TestUpdatePost_AttachmentTotalSizeLimit(t *testing.T) {
+	handlers, store := newStoredAttachmentHandlers(t)
+	postID := createdPostID(t, doCreatePostWithAttachments(t, handlers, "scene-1", nil))
+
+	// Declared sizes are replaced by the stored ones before the limit is checked
+	attachments := storedAttachments(store, 6, 10<<20)
+	for i := range attachments {
+		attachments[i].SizeBytes = 1
+	}
+	w := doUpdatePostAttachments(t, handlers, postID, attachments)
+	assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+}
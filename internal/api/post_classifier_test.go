@@ -0,0 +1,149 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/post"
+)
+
+// fakeClassifier flags attachments whose key is in mature and fails with err
+// when set.
+type fakeClassifier struct {
+	mature map[string]bool
+	err    error
+	calls  int
+}
+
+func (f *fakeClassifier) IsMature(_ context.Context, att post.Attachment) (bool, error) {
+	f.calls++
+	if f.err != nil {
+		return false, f.err
+	}
+	return f.mature[att.Key], nil
+}
+
+func createdPostLabels(t *testing.T, w *httptest.ResponseRecorder) []string {
+	t.Helper()
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created PostResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return created.Labels
+}
+
+func TestCreatePost_ContentClassifier(t *testing.T) {
+	attachments := []post.Attachment{
+		{Key: "attachments/did:plc:testuser123/track.mp3", Type: "audio/mpeg", SizeBytes: 1 << 20},
+		{Key: "attachments/did:plc:testuser123/photo.jpg", Type: "image/jpeg", SizeBytes: 1 << 20},
+	}
+
+	tests := []struct {
+		name       string
+		classifier *fakeClassifier
+		wantNSFW   bool
+	}{
+		{
+			name:       "flags mature image",
+			classifier: &fakeClassifier{mature: map[string]bool{"attachments/did:plc:testuser123/photo.jpg": true}},
+			wantNSFW:   true,
+		},
+		{
+			name:       "passes clean image",
+			classifier: &fakeClassifier{},
+			wantNSFW:   false,
+		},
+		{
+			name:       "classifier error fails open",
+			classifier: &fakeClassifier{err: errors.New("classifier unavailable")},
+			wantNSFW:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := newTestPostHandlers()
+			handlers.SetContentClassifier(tt.classifier)
+
+			labels := createdPostLabels(t, doCreatePostWithAttachments(t, handlers, "scene-1", attachments))
+			if got := slices.Contains(labels, post.LabelNSFW); got != tt.wantNSFW {
+				t.Errorf("nsfw label = %v, want %v (labels %v)", got, tt.wantNSFW, labels)
+			}
+			// Only the image is sent for classification
+			if tt.classifier.calls != 1 {
+				t.Errorf("classifier called %d times, want 1", tt.classifier.calls)
+			}
+		})
+	}
+}
+
+func TestCreatePost_DefaultClassifierDoesNotLabel(t *testing.T) {
+	handlers := newTestPostHandlers()
+
+	labels := createdPostLabels(t, doCreatePostWithAttachments(t, handlers, "scene-1", attachmentsOfSize(1, 1<<20)))
+	if slices.Contains(labels, post.LabelNSFW) {
+		t.Errorf("expected no automatic labels without a classifier, got %v", labels)
+	}
+}
+
+func TestUpdatePost_ClassifiesAddedImages(t *testing.T) {
+	handlers := newTestPostHandlers()
+	classifier := &fakeClassifier{mature: map[string]bool{"attachments/did:plc:testuser123/photo.jpg": true}}
+	handlers.SetContentClassifier(classifier)
+
+	postID := createdPostID(t, doCreatePostWithAttachments(t, handlers, "scene-1", []post.Attachment{
+		{Key: "attachments/did:plc:testuser123/track.mp3", Type: "audio/mpeg", SizeBytes: 1 << 20},
+	}))
+
+	w := doUpdatePostAttachments(t, handlers, postID, []post.Attachment{
+		{Key: "attachments/did:plc:testuser123/photo.jpg", Type: "image/jpeg", SizeBytes: 1 << 20},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	updated, err := handlers.repo.GetByID(postID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !updated.HasLabel(post.LabelNSFW) {
+		t.Errorf("expected a mature image added on update to be labeled nsfw, got %v", updated.Labels)
+	}
+}
+
+func TestUpdatePost_AuthorCannotRemoveNSFW(t *testing.T) {
+	handlers := newTestPostHandlers()
+	handlers.SetContentClassifier(&fakeClassifier{mature: map[string]bool{"attachments/did:plc:testuser123/photo.jpg": true}})
+
+	postID := createdPostID(t, doCreatePostWithAttachments(t, handlers, "scene-1", []post.Attachment{
+		{Key: "attachments/did:plc:testuser123/photo.jpg", Type: "image/jpeg", SizeBytes: 1 << 20},
+	}))
+
+	labels := []string{}
+	body, err := json.Marshal(UpdatePostRequest{Labels: &labels})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := withAuthContext(httptest.NewRequest(http.MethodPatch, "/posts/"+postID, bytes.NewReader(body)))
+	w := httptest.NewRecorder()
+	handlers.UpdatePost(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	updated, err := handlers.repo.GetByID(postID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !updated.HasLabel(post.LabelNSFW) {
+		t.Errorf("expected the nsfw label to survive the author's update, got %v", updated.Labels)
+	}
+}
@@ -2,19 +2,25 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/onnwee/subcults/internal/attachment"
+	"github.com/onnwee/subcults/internal/audit"
 	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/post"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/slo"
+	"github.com/onnwee/subcults/internal/storage"
 	"github.com/onnwee/subcults/internal/validate"
 )
 
@@ -24,12 +30,33 @@ const (
 	MaxAttachments    = 6
 )
 
+// Aggregate attachment size limits
+const (
+	MaxPostAttachmentBytes       = 50 << 20  // 50MB total across all attachments on one post
+	DefaultSceneDailyUploadBytes = 500 << 20 // 500MB of new attachments per scene per UTC day
+	DefaultMaxAttachmentBytes    = 15 << 20  // Upload size cap; matches the upload service default
+)
+
 // PostHandlers holds dependencies for post HTTP handlers.
 type PostHandlers struct {
 	repo            post.PostRepository
 	sceneRepo       scene.SceneRepository
 	membershipRepo  membership.MembershipRepository
 	metadataService *attachment.MetadataService // Optional: for enriching attachment metadata
+	feedLatencySLO  *slo.Tracker                // Optional: records feed latency against its SLO
+	classifier      post.ContentClassifier      // Auto-labels mature image attachments at creation
+	auditRepo       audit.Repository            // Optional: records updates with their field changes
+	blockRepo       scene.BlockRepository       // Optional: rejects posts from DIDs blocked in the target scene
+	eventRepo       scene.EventRepository       // Resolves the scene of event-only posts for block checks and upload quotas
+	reactionRepo    post.ReactionRepository     // Emoji reactions, aggregated into post responses
+
+	uploadQuota        storage.QuotaTracker
+	sceneDailyUpload   int64 // Per-scene daily attachment byte quota
+	maxAttachmentBytes int64 // Upload size cap, charged for attachments whose size wasn't read from storage
+
+	defaultFeedWindow time.Duration // How far back feeds reach when the request sets no window; 0 is unbounded
+
+	metadataDisabledOnce sync.Once // Logs the first post created without enrichment
 }
 
 // NewPostHandlers creates a new PostHandlers instance.
@@ -38,15 +65,154 @@ type PostHandlers struct {
 // Note: This constructor was updated to include metadataService as a new parameter.
 // All existing callers have been updated. The service is optional (can be nil) to
 // maintain graceful degradation when R2 is not configured.
+//
+// Attachment enrichment on CreatePost is the only feature that uses the service.
+// Without it, attachments are stored as the client sent them: their type and size
+// are not verified against storage, image EXIF is not stripped, and dimensions
+// are not filled in. Maturity classification then runs on the client-declared
+// type, and the per-post size limit and scene upload quota count each uploaded
+// attachment as at least the upload size cap, since its declared size can't be
+// trusted.
 func NewPostHandlers(repo post.PostRepository, sceneRepo scene.SceneRepository, membershipRepo membership.MembershipRepository, metadataService *attachment.MetadataService) *PostHandlers {
 	return &PostHandlers{
 		repo:            repo,
 		sceneRepo:       sceneRepo,
 		membershipRepo:  membershipRepo,
 		metadataService: metadataService,
+		classifier:      post.NoopClassifier{},
+		reactionRepo:    post.NewInMemoryReactionRepository(),
+
+		uploadQuota:        storage.NewInMemoryQuotaTracker(),
+		sceneDailyUpload:   DefaultSceneDailyUploadBytes,
+		maxAttachmentBytes: DefaultMaxAttachmentBytes,
+	}
+}
+
+// SetUploadQuota replaces the per-scene daily upload quota tracker and limit.
+// Non-positive limits are ignored.
+func (h *PostHandlers) SetUploadQuota(tracker storage.QuotaTracker, dailyLimitBytes int64) {
+	if tracker != nil {
+		h.uploadQuota = tracker
+	}
+	if dailyLimitBytes > 0 {
+		h.sceneDailyUpload = dailyLimitBytes
 	}
 }
 
+// SetMaxAttachmentBytes sets the upload size cap. Attachments whose size could
+// not be read from storage count as at least this large against the per-post
+// size limit and the scene upload quota. Non-positive values are ignored.
+func (h *PostHandlers) SetMaxAttachmentBytes(maxBytes int64) {
+	if maxBytes > 0 {
+		h.maxAttachmentBytes = maxBytes
+	}
+}
+
+// SetFeedLatencySLO installs the tracker that records feed latencies for
+// error budget burn alerting.
+func (h *PostHandlers) SetFeedLatencySLO(tracker *slo.Tracker) {
+	h.feedLatencySLO = tracker
+}
+
+// SetContentClassifier installs the classifier that automatically labels posts
+// with mature image attachments as NSFW. A nil classifier disables it.
+func (h *PostHandlers) SetContentClassifier(classifier post.ContentClassifier) {
+	if classifier == nil {
+		classifier = post.NoopClassifier{}
+	}
+	h.classifier = classifier
+}
+
+// SetAuditRepo installs the audit repository that post updates are recorded to.
+func (h *PostHandlers) SetAuditRepo(repo audit.Repository) {
+	h.auditRepo = repo
+}
+
+// SetBlockRepo installs the scene block list consulted before creating a post.
+// eventRepo resolves the scene of posts that only name an event; without it,
+// event-only posts are not checked.
+func (h *PostHandlers) SetBlockRepo(repo scene.BlockRepository, eventRepo scene.EventRepository) {
+	h.blockRepo = repo
+	h.eventRepo = eventRepo
+}
+
+// SetDefaultFeedWindow bounds scene and event feeds to posts from the last d
+// when a request sets neither since nor until. Non-positive d leaves feeds
+// unbounded, the default.
+func (h *PostHandlers) SetDefaultFeedWindow(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	h.defaultFeedWindow = d
+}
+
+// parseFeedWindow reads a feed request's since and until parameters (RFC3339).
+// If neither is set, the default feed window applies. Writes a 400 and returns
+// false if either is malformed or since is not before until.
+func (h *PostHandlers) parseFeedWindow(w http.ResponseWriter, r *http.Request) (post.FeedWindow, bool) {
+	query := r.URL.Query()
+	sinceStr := strings.TrimSpace(query.Get("since"))
+	untilStr := strings.TrimSpace(query.Get("until"))
+
+	var window post.FeedWindow
+	if sinceStr == "" && untilStr == "" {
+		if h.defaultFeedWindow > 0 {
+			window.Since = time.Now().Add(-h.defaultFeedWindow)
+		}
+		return window, true
+	}
+
+	if sinceStr != "" {
+		since, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "invalid 'since' timestamp, must be RFC3339 format")
+			return window, false
+		}
+		window.Since = since
+	}
+	if untilStr != "" {
+		until, err := time.Parse(time.RFC3339, untilStr)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "invalid 'until' timestamp, must be RFC3339 format")
+			return window, false
+		}
+		window.Until = until
+	}
+	if !window.Since.IsZero() && !window.Until.IsZero() && !window.Since.Before(window.Until) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidTimeRange)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidTimeRange, "'since' must be before 'until'")
+		return window, false
+	}
+	return window, true
+}
+
+// parseFeedFilters reads a scene feed request's author, labels and
+// exclude_labels parameters; the label lists are comma-separated. Reports
+// whether any filter is set.
+func parseFeedFilters(r *http.Request) (post.FeedOptions, bool) {
+	query := r.URL.Query()
+	var opts post.FeedOptions
+	if author := strings.TrimSpace(query.Get("author")); author != "" {
+		opts.AuthorDID = &author
+	}
+	opts.IncludeLabels = splitLabelsParam(query.Get("labels"))
+	opts.ExcludeLabels = splitLabelsParam(query.Get("exclude_labels"))
+	return opts, opts.AuthorDID != nil || len(opts.IncludeLabels) > 0 || len(opts.ExcludeLabels) > 0
+}
+
+// splitLabelsParam splits a comma-separated label list, dropping empty entries.
+func splitLabelsParam(raw string) []string {
+	var labels []string
+	for _, label := range strings.Split(raw, ",") {
+		if l := strings.TrimSpace(label); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
 // CreatePostRequest represents the request body for creating a post.
 type CreatePostRequest struct {
 	SceneID     *string           `json:"scene_id,omitempty"`
@@ -54,6 +220,18 @@ type CreatePostRequest struct {
 	Text        string            `json:"text"`
 	Attachments []post.Attachment `json:"attachments,omitempty"`
 	Labels      []string          `json:"labels,omitempty"`
+
+	// IsAnnouncement posts to the scene's announcements channel; only the scene
+	// owner or a curator may set it
+	IsAnnouncement bool `json:"is_announcement,omitempty"`
+
+	// ParentID makes the post a reply; the parent must be live and in the same
+	// scene and event
+	ParentID *string `json:"parent_id,omitempty"`
+
+	// QuotedPostID makes the post a quote repost of another live post, counted
+	// in the quoted post's repost_count
+	QuotedPostID *string `json:"quoted_post_id,omitempty"`
 }
 
 // UpdatePostRequest represents the request body for updating a post.
@@ -76,6 +254,57 @@ func validatePostAttachments(attachments []post.Attachment) error {
 	return nil
 }
 
+// totalAttachmentBytes returns the combined declared size of the attachments.
+// Uploaded (key-based) attachments must declare a positive size and legacy URL
+// attachments a non-negative one, so no attachment can lower the total.
+func totalAttachmentBytes(attachments []post.Attachment) (int64, error) {
+	var total int64
+	for i, att := range attachments {
+		if att.SizeBytes < 0 || (att.Key != "" && att.SizeBytes == 0) {
+			return 0, fmt.Errorf("attachment %d: size_bytes must be positive", i)
+		}
+		total += att.SizeBytes
+	}
+	return total, nil
+}
+
+// attachmentCharges returns the bytes each attachment counts against the
+// per-post size limit and the scene upload quota. Sizes read from storage are
+// exact. Other uploaded attachments count as at least the upload size cap,
+// since uploads can't exceed it but a client could understate their size.
+func (h *PostHandlers) attachmentCharges(attachments []post.Attachment, verified []bool) []int64 {
+	charges := make([]int64, len(attachments))
+	for i, att := range attachments {
+		charges[i] = att.SizeBytes
+		if att.Key != "" && !verified[i] {
+			charges[i] = max(att.SizeBytes, h.maxAttachmentBytes)
+		}
+	}
+	return charges
+}
+
+// validateAttachmentTotal enforces the per-post aggregate size limit on the
+// attachments' charged sizes.
+func validateAttachmentTotal(charges []int64) error {
+	var total int64
+	for _, charge := range charges {
+		total += charge
+	}
+	if total > MaxPostAttachmentBytes {
+		return fmt.Errorf("attachments exceed the per-post total size limit of %dMB", MaxPostAttachmentBytes>>20)
+	}
+	return nil
+}
+
+// sameAttachment reports whether two attachments refer to the same stored
+// object, or for legacy attachments, the same URL.
+func sameAttachment(a, b post.Attachment) bool {
+	if a.Key != "" || b.Key != "" {
+		return a.Key == b.Key
+	}
+	return a.URL == b.URL
+}
+
 // extractPostID extracts the post ID from the URL path.
 // Returns the post ID and an error if the ID is missing or invalid.
 func extractPostID(r *http.Request) (string, error) {
@@ -89,9 +318,8 @@ func extractPostID(r *http.Request) (string, error) {
 // CreatePost handles POST /posts - creates a new post.
 func (h *PostHandlers) CreatePost(w http.ResponseWriter, r *http.Request) {
 	var req CreatePostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r.Context(), err)
 		return
 	}
 
@@ -124,6 +352,11 @@ func (h *PostHandlers) CreatePost(w http.ResponseWriter, r *http.Request) {
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
 		return
 	}
+	if _, err := totalAttachmentBytes(req.Attachments); err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
 
 	// Sanitize and validate labels
 	sanitizedLabels := make([]string, len(req.Labels))
@@ -146,35 +379,120 @@ func (h *PostHandlers) CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Enrich attachments with metadata if service is configured
-	// This fetches metadata from R2 and strips EXIF data for images
-	enrichedAttachments := make([]post.Attachment, 0, len(req.Attachments))
-	if h.metadataService != nil {
-		for _, att := range req.Attachments {
-			// Skip empty keys or attachments that already have all metadata
-			if att.Key == "" {
-				// Legacy URL-based attachment without key, keep as-is
-				enrichedAttachments = append(enrichedAttachments, att)
-				continue
+	// Resolve the scene the post lands in, directly or through its event
+	targetSceneID, err := h.postSceneID(req.SceneID, req.EventID)
+	if err != nil {
+		if err == scene.ErrEventNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve event", "error", err, "event_id", *req.EventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+
+	// DIDs blocked in the target scene may not post there
+	if h.blockRepo != nil && !checkSceneBlock(r.Context(), w, h.blockRepo, targetSceneID, authorDID) {
+		return
+	}
+
+	// Replies stay in their parent's scene and event; deleted parents take no replies
+	if req.ParentID != nil {
+		parent, err := h.repo.GetByID(*req.ParentID)
+		if err != nil {
+			if err == post.ErrPostNotFound {
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+				WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Parent post not found")
+				return
 			}
+			slog.ErrorContext(r.Context(), "failed to retrieve parent post", "error", err, "post_id", *req.ParentID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve parent post")
+			return
+		}
+		if !sameOptionalID(parent.SceneID, req.SceneID) || !sameOptionalID(parent.EventID, req.EventID) {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "A reply must have the same scene_id and event_id as its parent")
+			return
+		}
+	}
 
-			// Enrich the attachment with metadata from R2
-			enriched, err := h.metadataService.EnrichAttachment(r.Context(), att.Key)
-			if err != nil {
-				// Log the error but don't fail the request
-				// Use the attachment as provided by the client
-				slog.WarnContext(r.Context(), "failed to enrich attachment",
-					"key", att.Key,
-					"error", err)
-				enrichedAttachments = append(enrichedAttachments, att)
-				continue
+	// Deleted posts can't be quoted
+	if req.QuotedPostID != nil {
+		if _, err := h.repo.GetByID(*req.QuotedPostID); err != nil {
+			if err == post.ErrPostNotFound {
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+				WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Quoted post not found")
+				return
 			}
+			slog.ErrorContext(r.Context(), "failed to retrieve quoted post", "error", err, "post_id", *req.QuotedPostID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve quoted post")
+			return
+		}
+	}
 
-			enrichedAttachments = append(enrichedAttachments, *enriched)
+	// Announcements belong to a scene and are reserved for its owner and curators
+	if req.IsAnnouncement {
+		if req.SceneID == nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Announcements require a scene_id")
+			return
+		}
+		announceScene, err := h.sceneRepo.GetByID(*req.SceneID)
+		if err != nil {
+			if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+				WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+				return
+			}
+			slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", *req.SceneID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+			return
+		}
+		allowed, err := h.canAnnounce(announceScene, authorDID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check announcement permission", "error", err, "scene_id", *req.SceneID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check permissions")
+			return
+		}
+		if !allowed {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+			WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the scene owner or a curator can post announcements")
+			return
 		}
-	} else {
-		// No metadata service configured, use attachments as-is
-		enrichedAttachments = req.Attachments
+	}
+
+	// Enrich attachments with metadata if service is configured
+	// This fetches metadata from R2 and strips EXIF data for images
+	enrichedAttachments, verified := h.enrichAttachments(r.Context(), req.Attachments)
+
+	// Enforce aggregate size limits on the sizes read from storage, or the
+	// upload size cap where storage couldn't be read
+	charges := h.attachmentCharges(enrichedAttachments, verified)
+	if err := validateAttachmentTotal(charges); err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	// Auto-label mature images; a classifier failure must not block posting
+	labels, err := post.ApplyMaturityLabel(r.Context(), h.classifier, sanitizedLabels, enrichedAttachments)
+	if err != nil {
+		slog.WarnContext(r.Context(), "content classification failed", "error", err)
+	}
+
+	// Charge the daily upload quota of the scene the post lands in
+	attachmentBytes, quotaSceneID, err := h.reserveUploadQuota(targetSceneID, charges)
+	if err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation,
+			fmt.Sprintf("Scene daily upload quota of %dMB exceeded", h.sceneDailyUpload>>20))
+		return
 	}
 
 	// Create post
@@ -184,10 +502,17 @@ func (h *PostHandlers) CreatePost(w http.ResponseWriter, r *http.Request) {
 		AuthorDID:   authorDID,
 		Text:        req.Text,
 		Attachments: enrichedAttachments,
-		Labels:      sanitizedLabels,
+		Labels:      labels,
+		ParentID:    req.ParentID,
+
+		QuotedPostID:   req.QuotedPostID,
+		IsAnnouncement: req.IsAnnouncement,
 	}
 
 	if err := h.repo.Create(newPost); err != nil {
+		if quotaSceneID != "" {
+			h.uploadQuota.Release(quotaSceneID, attachmentBytes)
+		}
 		slog.ErrorContext(r.Context(), "failed to create post", "error", err)
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
 		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to create post")
@@ -197,12 +522,107 @@ func (h *PostHandlers) CreatePost(w http.ResponseWriter, r *http.Request) {
 	// Return created post
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(newPost); err != nil {
+	if err := json.NewEncoder(w).Encode(newPostResponse(newPost)); err != nil {
 		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
 		return
 	}
 }
 
+// sameOptionalID reports whether two optional IDs are both unset or equal.
+func sameOptionalID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// reserveUploadQuota charges the combined charges against sceneID's daily upload
+// quota. Returns the bytes reserved and the scene they were reserved for, which
+// the caller releases if the post fails to persist; the scene is "" when
+// nothing was reserved. Posts whose scene can't be resolved are not charged.
+func (h *PostHandlers) reserveUploadQuota(sceneID string, charges []int64) (int64, string, error) {
+	var total int64
+	for _, charge := range charges {
+		total += charge
+	}
+	if sceneID == "" || total == 0 {
+		return 0, "", nil
+	}
+	if err := h.uploadQuota.Reserve(sceneID, total, h.sceneDailyUpload); err != nil {
+		return 0, "", err
+	}
+	return total, sceneID, nil
+}
+
+// postSceneID returns the scene a new post targets: its scene_id, or the scene
+// of its event_id when an event repository is configured. Returns "" otherwise.
+func (h *PostHandlers) postSceneID(sceneID, eventID *string) (string, error) {
+	if sceneID != nil && *sceneID != "" {
+		return *sceneID, nil
+	}
+	if eventID == nil || *eventID == "" || h.eventRepo == nil {
+		return "", nil
+	}
+	event, err := h.eventRepo.GetByID(*eventID)
+	if err != nil {
+		return "", err
+	}
+	return event.SceneID, nil
+}
+
+// postAuditFields snapshots the author-editable fields of a post for diffing
+// in the audit log.
+func postAuditFields(p *post.Post) map[string]any {
+	return map[string]any{
+		"text":        p.Text,
+		"attachments": p.Attachments,
+		"labels":      p.Labels,
+	}
+}
+
+// enrichAttachments replaces key-based attachments with the metadata service's
+// view of the stored object, and reports which attachments were read from
+// storage. Without a metadata service the attachments are returned as-is, and
+// this is logged the first time so a missing R2 setup is visible without
+// flooding the log on every post. Enrichment failures keep the client's
+// attachment rather than failing the request.
+func (h *PostHandlers) enrichAttachments(ctx context.Context, attachments []post.Attachment) ([]post.Attachment, []bool) {
+	verified := make([]bool, len(attachments))
+	if h.metadataService == nil {
+		if len(attachments) > 0 {
+			h.metadataDisabledOnce.Do(func() {
+				slog.WarnContext(ctx, "attachment metadata service not configured, storing client-provided attachment metadata")
+			})
+		}
+		return attachments, verified
+	}
+
+	enriched := make([]post.Attachment, 0, len(attachments))
+	for i, att := range attachments {
+		if att.Key == "" {
+			// Legacy URL-based attachment without key, keep as-is
+			enriched = append(enriched, att)
+			continue
+		}
+
+		// Enrich the attachment with metadata from R2
+		result, err := h.metadataService.EnrichAttachment(ctx, att.Key)
+		if err != nil || result == nil {
+			// Log the error but don't fail the request
+			// Use the attachment as provided by the client
+			slog.WarnContext(ctx, "failed to enrich attachment",
+				"key", att.Key,
+				"error", err)
+			enriched = append(enriched, att)
+			continue
+		}
+
+		enriched = append(enriched, *result)
+		verified[i] = true
+	}
+	return enriched, verified
+}
+
 // UpdatePost handles PATCH /posts/{id} - updates an existing post.
 func (h *PostHandlers) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	// Extract post ID from URL path
@@ -223,9 +643,8 @@ func (h *PostHandlers) UpdatePost(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var req UpdatePostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r.Context(), err)
 		return
 	}
 
@@ -250,6 +669,27 @@ func (h *PostHandlers) UpdatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Resolve the post's scene, through its event for event-only posts
+	postScene, err := h.postSceneID(existingPost.SceneID, existingPost.EventID)
+	if err != nil && err != scene.ErrEventNotFound {
+		slog.ErrorContext(r.Context(), "failed to retrieve event", "error", err, "post_id", postID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+
+	// DIDs blocked in the post's scene may not edit their posts there
+	if h.blockRepo != nil && !checkSceneBlock(r.Context(), w, h.blockRepo, postScene, userDID) {
+		return
+	}
+
+	before := postAuditFields(existingPost)
+	wasNSFW := existingPost.HasLabel(post.LabelNSFW)
+
+	// Charged sizes of attachments the update adds
+	var addedCharges []int64
+	var addedAttachments []post.Attachment
+
 	// Apply updates
 	if req.Text != nil {
 		validatedText, err := validate.PostContent(*req.Text)
@@ -273,7 +713,30 @@ func (h *PostHandlers) UpdatePost(w http.ResponseWriter, r *http.Request) {
 			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
 			return
 		}
-		existingPost.Attachments = *req.Attachments
+		if _, err := totalAttachmentBytes(*req.Attachments); err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+
+		enrichedAttachments, verified := h.enrichAttachments(r.Context(), *req.Attachments)
+		charges := h.attachmentCharges(enrichedAttachments, verified)
+		if err := validateAttachmentTotal(charges); err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+
+		// Attachments the post already had were charged when they were added
+		for i, att := range enrichedAttachments {
+			if slices.ContainsFunc(existingPost.Attachments, func(prev post.Attachment) bool { return sameAttachment(prev, att) }) {
+				charges[i] = 0
+				continue
+			}
+			addedAttachments = append(addedAttachments, att)
+		}
+		addedCharges = charges
+		existingPost.Attachments = enrichedAttachments
 	}
 
 	if req.Labels != nil {
@@ -289,11 +752,42 @@ func (h *PostHandlers) UpdatePost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// The nsfw label may have come from the classifier, so the author
+		// can't remove it
+		if wasNSFW && !slices.Contains(sanitizedLabels, post.LabelNSFW) {
+			sanitizedLabels = append(sanitizedLabels, post.LabelNSFW)
+		}
 		existingPost.Labels = sanitizedLabels
 	}
 
+	// Auto-label newly added mature images, failing open as at creation
+	if len(addedAttachments) > 0 {
+		labels, err := post.ApplyMaturityLabel(r.Context(), h.classifier, existingPost.Labels, addedAttachments)
+		if err != nil {
+			slog.WarnContext(r.Context(), "content classification failed", "post_id", existingPost.ID, "error", err)
+		}
+		existingPost.Labels = labels
+	}
+
+	// Charge newly added attachments to the daily upload quota of the post's
+	// scene, resolved through its event for event-only posts
+	var reservedBytes int64
+	var reservedSceneID string
+	if len(addedCharges) > 0 {
+		reservedBytes, reservedSceneID, err = h.reserveUploadQuota(postScene, addedCharges)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation,
+				fmt.Sprintf("Scene daily upload quota of %dMB exceeded", h.sceneDailyUpload>>20))
+			return
+		}
+	}
+
 	// Update in repository
 	if err := h.repo.Update(existingPost); err != nil {
+		if reservedSceneID != "" {
+			h.uploadQuota.Release(reservedSceneID, reservedBytes)
+		}
 		if err == post.ErrPostDeleted {
 			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
 			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Post not found")
@@ -305,10 +799,20 @@ func (h *PostHandlers) UpdatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.auditRepo != nil {
+		changes := audit.Diff(before, postAuditFields(existingPost))
+		if err := audit.LogUpdateFromRequest(r, h.auditRepo, "post", postID, "post_update", changes); err != nil {
+			slog.ErrorContext(r.Context(), "failed to log post update", "error", err, "post_id", postID)
+			// Don't fail the request, but log the error
+		}
+	}
+
 	// Return updated post (existingPost has been modified in-place)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(existingPost); err != nil {
+	response := newPostResponse(existingPost)
+	h.attachReactions(r.Context(), response)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
 		return
 	}
@@ -370,10 +874,46 @@ func (h *PostHandlers) DeletePost(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// PostResponse is the JSON representation of a post. Attachments and Labels
+// shadow the embedded post's so they are always arrays. Reactions holds the
+// post's reaction counts by emoji and is always an object.
+type PostResponse struct {
+	*post.Post
+	Attachments []post.Attachment `json:"attachments"`
+	Labels      []string          `json:"labels"`
+	Reactions   map[string]int    `json:"reactions"`
+}
+
+// newPostResponse builds the JSON representation of p.
+func newPostResponse(p *post.Post) PostResponse {
+	return PostResponse{
+		Post:        p,
+		Attachments: emptyIfNil(p.Attachments),
+		Labels:      emptyIfNil(p.Labels),
+		Reactions:   map[string]int{},
+	}
+}
+
 // FeedResponse represents the JSON response for feed endpoints.
 type FeedResponse struct {
-	Posts      []*post.Post     `json:"posts"`
-	NextCursor *post.FeedCursor `json:"next_cursor,omitempty"`
+	Posts           []PostResponse   `json:"posts"`
+	NextCursor      *post.FeedCursor `json:"next_cursor,omitempty"`
+	FeedSort        string           `json:"feed_sort,omitempty"`        // Order the page was listed in: chronological or ranked
+	NextRankCursor  string           `json:"next_rank_cursor,omitempty"` // Opaque cursor for the next ranked page
+	ContentRating   string           `json:"content_rating,omitempty"`   // Set when posts were withheld from a mature scene
+	ContentRedacted bool             `json:"content_redacted,omitempty"` // True when the viewer has not opted in to mature content
+}
+
+// newFeedResponse builds a feed page from posts.
+func newFeedResponse(posts []*post.Post, nextCursor *post.FeedCursor) FeedResponse {
+	response := FeedResponse{
+		Posts:      make([]PostResponse, len(posts)),
+		NextCursor: nextCursor,
+	}
+	for i, p := range posts {
+		response.Posts[i] = newPostResponse(p)
+	}
+	return response
 }
 
 // parseCursor parses cursor from query parameter.
@@ -402,52 +942,83 @@ func parseCursor(cursorStr string) *post.FeedCursor {
 	}
 }
 
-// canAccessScene checks if a user can access a scene based on visibility rules.
-// Returns true if access is allowed, false otherwise.
-func (h *PostHandlers) canAccessScene(s *scene.Scene, requesterDID string) (bool, error) {
-	// Owner always has access
-	if s.IsOwner(requesterDID) {
-		return true, nil
+// visibleFeedScene loads the scene a feed or thread belongs to and checks the
+// requester may see it, writing a 404 with notFound when the scene is missing
+// or hidden from them, so its existence isn't revealed. Reports false once an
+// error response has been written.
+func (h *PostHandlers) visibleFeedScene(w http.ResponseWriter, r *http.Request, sceneID, notFound string) (*scene.Scene, bool) {
+	foundScene, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		return nil, false
+	}
+	canAccess := false
+	if err == nil {
+		canAccess, err = sceneVisibleTo(h.membershipRepo, foundScene, middleware.GetUserDID(r.Context()))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check access permissions")
+			return nil, false
+		}
+	}
+	if !canAccess {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, notFound)
+		return nil, false
 	}
+	return foundScene, true
+}
 
-	// Check visibility rules
-	switch s.Visibility {
-	case scene.VisibilityPublic:
-		// Public scenes are accessible to everyone
-		return true, nil
+// canAnnounce reports whether a user may post announcements in a scene: its
+// owner, or an active member with the curator role.
+func (h *PostHandlers) canAnnounce(s *scene.Scene, userDID string) (bool, error) {
+	role, err := sceneRole(h.membershipRepo, s, userDID)
+	if err != nil {
+		return false, err
+	}
+	return roleCanAnnounce(s, userDID, role), nil
+}
 
-	case scene.VisibilityMembersOnly:
-		// Members-only scenes require active membership
-		if requesterDID == "" {
-			return false, nil
-		}
+// GetSceneFeed handles GET /scenes/{id}/feed - retrieves posts for a scene with pagination.
+// Announcements are left to GET /scenes/{id}/announcements unless
+// include_announcements=true is passed. since and until bound the feed by post time.
+func (h *PostHandlers) GetSceneFeed(w http.ResponseWriter, r *http.Request) {
+	if h.feedLatencySLO != nil {
+		defer h.feedLatencySLO.ObserveSince(time.Now())
+	}
 
-		// Check if requester is an active member
-		m, err := h.membershipRepo.GetBySceneAndUser(s.ID, requesterDID)
+	announcements := post.AnnouncementsExclude
+	if raw := r.URL.Query().Get("include_announcements"); raw != "" {
+		include, err := strconv.ParseBool(raw)
 		if err != nil {
-			// Not a member or error retrieving membership
-			if err == membership.ErrMembershipNotFound {
-				return false, nil
-			}
-			return false, err
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid include_announcements parameter")
+			return
 		}
+		if include {
+			announcements = post.AnnouncementsInclude
+		}
+	}
 
-		// Only active members can access
-		return m.Status == "active", nil
-
-	case scene.VisibilityHidden:
-		// Hidden scenes only accessible to owner (already checked above)
-		return false, nil
+	h.serveSceneFeed(w, r, announcements)
+}
 
-	default:
-		// Unknown visibility mode - deny access for safety
-		slog.Warn("unknown visibility mode", "visibility", s.Visibility, "scene_id", s.ID)
-		return false, nil
+// GetSceneAnnouncements handles GET /scenes/{id}/announcements - retrieves a
+// scene's announcements with pagination.
+func (h *PostHandlers) GetSceneAnnouncements(w http.ResponseWriter, r *http.Request) {
+	if h.feedLatencySLO != nil {
+		defer h.feedLatencySLO.ObserveSince(time.Now())
 	}
+
+	h.serveSceneFeed(w, r, post.AnnouncementsOnly)
 }
 
-// GetSceneFeed handles GET /scenes/{id}/feed - retrieves posts for a scene with pagination.
-func (h *PostHandlers) GetSceneFeed(w http.ResponseWriter, r *http.Request) {
+// serveSceneFeed writes a page of a scene's posts, filtered by announcement
+// status, after checking the requester can see the scene.
+func (h *PostHandlers) serveSceneFeed(w http.ResponseWriter, r *http.Request, announcements post.AnnouncementFilter) {
 	// Extract scene ID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
 	if len(pathParts) < 2 || pathParts[0] == "" {
@@ -477,7 +1048,7 @@ func (h *PostHandlers) GetSceneFeed(w http.ResponseWriter, r *http.Request) {
 	requesterDID := middleware.GetUserDID(r.Context())
 
 	// Check visibility permissions
-	canAccess, err := h.canAccessScene(foundScene, requesterDID)
+	canAccess, err := sceneVisibleTo(h.membershipRepo, foundScene, requesterDID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
@@ -497,6 +1068,46 @@ func (h *PostHandlers) GetSceneFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Mature scenes withhold their posts until the viewer opts in
+	if foundScene.IsMature() {
+		w.Header().Add("Vary", MatureContentHeader)
+	}
+	if redactMatureScene(r, foundScene, requesterDID) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(FeedResponse{
+			Posts:           []PostResponse{},
+			ContentRating:   foundScene.ContentRating,
+			ContentRedacted: true,
+		}); err != nil {
+			slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		}
+		return
+	}
+
+	// Resolve the feed order: the scene's default unless the request overrides it.
+	// The announcements channel always lists newest first.
+	feedSort := scene.FeedSortChronological
+	if announcements != post.AnnouncementsOnly {
+		if foundScene.RanksFeed() {
+			feedSort = scene.FeedSortRanked
+		}
+		if raw := r.URL.Query().Get("sort"); raw != "" {
+			if errMsg := validateFeedSort(raw); errMsg != "" {
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+				WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid sort parameter")
+				return
+			}
+			feedSort = raw
+		}
+	}
+
+	// Filtered feeds are listed newest first
+	filters, filtered := parseFeedFilters(r)
+	if filtered {
+		feedSort = scene.FeedSortChronological
+	}
+
 	// Parse query parameters
 	limitStr := r.URL.Query().Get("limit")
 	cursorStr := r.URL.Query().Get("cursor")
@@ -516,23 +1127,59 @@ func (h *PostHandlers) GetSceneFeed(w http.ResponseWriter, r *http.Request) {
 		limit = parsedLimit
 	}
 
-	// Parse cursor
-	cursor := parseCursor(cursorStr)
+	window, ok := h.parseFeedWindow(w, r)
+	if !ok {
+		return
+	}
 
-	// Fetch posts from repository
-	posts, nextCursor, err := h.repo.ListByScene(sceneID, limit, cursor)
+	// Short-circuit with 304 if nothing changed since the client's copy
+	lastActivity, err := h.repo.SceneLastActivityAt(sceneID)
 	if err != nil {
-		slog.ErrorContext(r.Context(), "failed to list scene posts", "error", err, "scene_id", sceneID)
+		slog.ErrorContext(r.Context(), "failed to get scene last activity", "error", err, "scene_id", sceneID)
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
 		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve posts")
 		return
 	}
+	if checkNotModified(w, r, lastActivity) {
+		return
+	}
 
-	// Build response
-	response := FeedResponse{
-		Posts:      posts,
-		NextCursor: nextCursor,
+	// Fetch posts from repository
+	var response FeedResponse
+	if feedSort == scene.FeedSortRanked {
+		// Ranked cursors are opaque; reject malformed ones rather than restart from the top
+		rankCursor, err := post.DecodeRankedFeedCursor(cursorStr)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid cursor")
+			return
+		}
+		posts, nextRankCursor, err := h.repo.ListRankedSceneFeed(sceneID, announcements, window, limit, rankCursor)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to list ranked scene posts", "error", err, "scene_id", sceneID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve posts")
+			return
+		}
+		response = newFeedResponse(posts, nil)
+		h.attachReactions(r.Context(), response.Posts...)
+		if nextRankCursor != nil {
+			response.NextRankCursor = post.EncodeRankedFeedCursor(nextRankCursor)
+		}
+	} else {
+		filters.Window = window
+		filters.Announcements = announcements
+		posts, nextCursor, err := h.repo.ListBySceneFiltered(sceneID, filters, limit, parseCursor(cursorStr))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to list scene posts", "error", err, "scene_id", sceneID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve posts")
+			return
+		}
+		response = newFeedResponse(posts, nextCursor)
+		h.attachReactions(r.Context(), response.Posts...)
 	}
+	response.FeedSort = feedSort
 
 	// Return feed
 	w.Header().Set("Content-Type", "application/json")
@@ -544,7 +1191,13 @@ func (h *PostHandlers) GetSceneFeed(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetEventFeed handles GET /events/{id}/feed - retrieves posts for an event with pagination.
+// since and until bound the feed by post time. The event's scene visibility and
+// mature-content rules apply as for the scene feed.
 func (h *PostHandlers) GetEventFeed(w http.ResponseWriter, r *http.Request) {
+	if h.feedLatencySLO != nil {
+		defer h.feedLatencySLO.ObserveSince(time.Now())
+	}
+
 	// Extract event ID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
 	if len(pathParts) < 2 || pathParts[0] == "" {
@@ -576,8 +1229,62 @@ func (h *PostHandlers) GetEventFeed(w http.ResponseWriter, r *http.Request) {
 	// Parse cursor
 	cursor := parseCursor(cursorStr)
 
+	window, ok := h.parseFeedWindow(w, r)
+	if !ok {
+		return
+	}
+
+	// Check the event's scene, when events can be resolved
+	if h.eventRepo != nil {
+		event, err := h.eventRepo.GetByID(eventID)
+		if err != nil {
+			if err == scene.ErrEventNotFound {
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+				WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+				return
+			}
+			slog.ErrorContext(r.Context(), "failed to retrieve event", "error", err, "event_id", eventID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+			return
+		}
+		eventScene, ok := h.visibleFeedScene(w, r, event.SceneID, "Event not found")
+		if !ok {
+			return
+		}
+
+		// Mature scenes withhold their posts until the viewer opts in
+		if eventScene.IsMature() {
+			w.Header().Add("Vary", MatureContentHeader)
+		}
+		if redactMatureScene(r, eventScene, middleware.GetUserDID(r.Context())) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(FeedResponse{
+				Posts:           []PostResponse{},
+				ContentRating:   eventScene.ContentRating,
+				ContentRedacted: true,
+			}); err != nil {
+				slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+			}
+			return
+		}
+	}
+
+	// Short-circuit with 304 if nothing changed since the client's copy
+	lastActivity, err := h.repo.EventLastActivityAt(eventID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to get event last activity", "error", err, "event_id", eventID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve posts")
+		return
+	}
+	if checkNotModified(w, r, lastActivity) {
+		return
+	}
+
 	// Fetch posts from repository
-	posts, nextCursor, err := h.repo.ListByEvent(eventID, limit, cursor)
+	posts, nextCursor, err := h.repo.ListByEvent(eventID, window, limit, cursor)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to list event posts", "error", err, "event_id", eventID)
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
@@ -586,10 +1293,8 @@ func (h *PostHandlers) GetEventFeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Build response
-	response := FeedResponse{
-		Posts:      posts,
-		NextCursor: nextCursor,
-	}
+	response := newFeedResponse(posts, nextCursor)
+	h.attachReactions(r.Context(), response.Posts...)
 
 	// Return feed
 	w.Header().Set("Content-Type", "application/json")
@@ -599,3 +1304,102 @@ func (h *PostHandlers) GetEventFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// GetPostReplies handles GET /posts/{id}/replies - lists the replies to a post,
+// oldest first, with cursor-based pagination. Replies to a deleted post are
+// still listed, marked orphaned. Replies share their parent's scene, or its
+// event's scene, so that scene's visibility and mature-content rules apply as
+// for its feed, even when the thread is empty.
+func (h *PostHandlers) GetPostReplies(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/posts/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "replies" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Post ID is required")
+		return
+	}
+	postID := pathParts[0]
+
+	// Default limit is 20, max is 100
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid limit parameter")
+			return
+		}
+		if parsedLimit > 100 {
+			parsedLimit = 100
+		}
+		limit = parsedLimit
+	}
+	cursor := parseCursor(r.URL.Query().Get("cursor"))
+
+	// The thread's scene is the parent's, or its event's, whether or not the
+	// parent was deleted; access is checked before any reply is read
+	parent, err := h.repo.GetByIDIncludingDeleted(postID)
+	if err != nil {
+		if err == post.ErrPostNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Post not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve post", "error", err, "post_id", postID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve replies")
+		return
+	}
+	threadSceneID, err := h.postSceneID(parent.SceneID, parent.EventID)
+	if err != nil && err != scene.ErrEventNotFound {
+		slog.ErrorContext(r.Context(), "failed to retrieve event", "error", err, "post_id", postID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		return
+	}
+
+	var threadScene *scene.Scene
+	if err == nil && threadSceneID != "" {
+		var ok bool
+		if threadScene, ok = h.visibleFeedScene(w, r, threadSceneID, "Post not found"); !ok {
+			return
+		}
+	} else if err != nil {
+		// The parent's event is gone, so its scene's rules can't be checked
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Post not found")
+		return
+	}
+
+	var response FeedResponse
+	if threadScene != nil && threadScene.IsMature() {
+		w.Header().Add("Vary", MatureContentHeader)
+	}
+	if threadScene != nil && redactMatureScene(r, threadScene, middleware.GetUserDID(r.Context())) {
+		response = FeedResponse{
+			Posts:           []PostResponse{},
+			ContentRating:   threadScene.ContentRating,
+			ContentRedacted: true,
+		}
+	} else {
+		replies, nextCursor, err := h.repo.ListReplies(postID, limit, cursor)
+		if err != nil {
+			if err == post.ErrPostNotFound {
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+				WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Post not found")
+				return
+			}
+			slog.ErrorContext(r.Context(), "failed to list post replies", "error", err, "post_id", postID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve replies")
+			return
+		}
+		response = newFeedResponse(replies, nextCursor)
+		h.attachReactions(r.Context(), response.Posts...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
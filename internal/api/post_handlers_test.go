@@ -148,6 +148,50 @@ func TestCreatePost_MissingTarget(t *testing.T) {
 	}
 }
 
+// TestCreatePost_RequestBody tests that malformed JSON and empty bodies are
+// reported with distinct error codes.
+func TestCreatePost_RequestBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+		wantErr  string
+	}{
+		{name: "malformed JSON", body: `{"scene_id":`, wantCode: http.StatusBadRequest, wantErr: ErrCodeBadRequest},
+		{name: "empty object", body: `{}`, wantCode: http.StatusBadRequest, wantErr: ErrCodeValidation},
+		{name: "null body", body: `null`, wantCode: http.StatusBadRequest, wantErr: ErrCodeValidation},
+		{name: "valid body", body: `{"scene_id":"scene123","text":"hello"}`, wantCode: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := newTestPostHandlers()
+
+			req := httptest.NewRequest(http.MethodPost, "/posts", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			req = withAuthContext(req)
+			handlers.CreatePost(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantErr == "" {
+				return
+			}
+
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantErr {
+				t.Errorf("expected error code '%s', got '%s'", tt.wantErr, errResp.Error.Code)
+			}
+		})
+	}
+}
+
 // TestCreatePost_EmptyText tests that empty text returns validation error.
 func TestCreatePost_EmptyText(t *testing.T) {
 	handlers := newTestPostHandlers()
@@ -368,7 +412,7 @@ func TestUpdatePost_Labels(t *testing.T) {
 		SceneID:   &sceneID,
 		AuthorDID: testUserDID,
 		Text:      "Test post",
-		Labels:    []string{post.LabelNSFW}, // Use valid moderation label
+		Labels:    []string{post.LabelSpam}, // Use valid moderation label
 	}
 	if err := handlers.repo.Create(originalPost); err != nil {
 		t.Fatalf("failed to create post: %v", err)
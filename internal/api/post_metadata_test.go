@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/onnwee/subcults/internal/attachment"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const metadataDisabledLog = "attachment metadata service not configured"
+
+// failingS3Client fails every call, standing in for an unreachable R2.
+type failingS3Client struct{}
+
+func (failingS3Client) HeadObject(context.Context, *s3.HeadObjectInput, ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	return nil, errors.New("r2 unreachable")
+}
+
+func (failingS3Client) GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	return nil, errors.New("r2 unreachable")
+}
+
+func (failingS3Client) PutObject(context.Context, *s3.PutObjectInput, ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	return nil, errors.New("r2 unreachable")
+}
+
+// captureLogs routes the default logger to a buffer for the rest of the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func createdPostAttachments(t *testing.T, w *httptest.ResponseRecorder) []post.Attachment {
+	t.Helper()
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created PostResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return created.Attachments
+}
+
+func TestCreatePost_NilMetadataService(t *testing.T) {
+	logs := captureLogs(t)
+	handlers := newTestPostHandlers()
+
+	attachments := []post.Attachment{
+		{Key: "attachments/did:plc:testuser123/photo.jpg", Type: "image/jpeg", SizeBytes: 2 << 20},
+		{Key: "attachments/did:plc:testuser123/track.mp3", Type: "audio/mpeg", SizeBytes: 4 << 20},
+	}
+	got := createdPostAttachments(t, doCreatePostWithAttachments(t, handlers, "scene-1", attachments))
+
+	// Attachments are stored exactly as the client sent them
+	if !reflect.DeepEqual(got, attachments) {
+		t.Errorf("attachments = %+v, want client-provided %+v", got, attachments)
+	}
+
+	// A second post does not log again
+	createdPostAttachments(t, doCreatePostWithAttachments(t, handlers, "scene-1", attachments[:1]))
+	if n := strings.Count(logs.String(), metadataDisabledLog); n != 1 {
+		t.Errorf("expected the missing metadata service to be logged once, got %d times:\n%s", n, logs.String())
+	}
+}
+
+func TestCreatePost_NilMetadataService_NoAttachments(t *testing.T) {
+	logs := captureLogs(t)
+	handlers := newTestPostHandlers()
+
+	if got := createdPostAttachments(t, doCreatePostWithAttachments(t, handlers, "scene-1", nil)); len(got) != 0 {
+		t.Errorf("expected no attachments, got %+v", got)
+	}
+	if strings.Contains(logs.String(), metadataDisabledLog) {
+		t.Errorf("expected no metadata warning for a post without attachments:\n%s", logs.String())
+	}
+}
+
+func TestCreatePost_NilMetadataService_LimitsUseClientSizes(t *testing.T) {
+	handlers := newTestPostHandlers()
+
+	// Without enrichment the declared sizes are all there is to check
+	w := doCreatePostWithAttachments(t, handlers, "scene-1", attachmentsOfSize(2, MaxPostAttachmentBytes/2+1))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for oversize declared attachments, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreatePost_NilMetadataService_ClassifierUsesClientType(t *testing.T) {
+	handlers := newTestPostHandlers()
+	classifier := &fakeClassifier{mature: map[string]bool{"attachments/did:plc:testuser123/photo.jpg": true}}
+	handlers.SetContentClassifier(classifier)
+
+	labels := createdPostLabels(t, doCreatePostWithAttachments(t, handlers, "scene-1", attachmentsOfSize(1, 1<<20)))
+	if classifier.calls != 1 {
+		t.Errorf("expected classifier to run on the client-declared image, got %d calls", classifier.calls)
+	}
+	if !slices.Contains(labels, post.LabelNSFW) {
+		t.Errorf("expected %q label, got %v", post.LabelNSFW, labels)
+	}
+}
+
+func TestCreatePost_MetadataServiceFailureKeepsClientAttachment(t *testing.T) {
+	metadataService, err := attachment.NewMetadataService(attachment.MetadataServiceConfig{
+		S3Client:   failingS3Client{},
+		BucketName: "subcults-media",
+	})
+	if err != nil {
+		t.Fatalf("failed to create metadata service: %v", err)
+	}
+	handlers := NewPostHandlers(post.NewInMemoryPostRepository(), scene.NewInMemorySceneRepository(),
+		membership.NewInMemoryMembershipRepository(), metadataService)
+
+	attachments := attachmentsOfSize(1, 1<<20)
+	got := createdPostAttachments(t, doCreatePostWithAttachments(t, handlers, "scene-1", attachments))
+	if !reflect.DeepEqual(got, attachments) {
+		t.Errorf("attachments = %+v, want client-provided %+v", got, attachments)
+	}
+}
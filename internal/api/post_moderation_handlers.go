@@ -0,0 +1,293 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// purgePageSize is how many of an author's posts a purge removes per feed page.
+const purgePageSize = 100
+
+// ModerationActionRequest is the body of the scene moderator post actions.
+// Label is only used when applying a label.
+type ModerationActionRequest struct {
+	Label  string `json:"label,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// PurgeRequest is the body of POST /scenes/{id}/moderation/purge.
+type PurgeRequest struct {
+	AuthorDID string `json:"author_did"`
+	Reason    string `json:"reason"`
+}
+
+// PurgeResponse reports how many posts a purge removed.
+type PurgeResponse struct {
+	SceneID   string `json:"scene_id"`
+	AuthorDID string `json:"author_did"`
+	Removed   int    `json:"removed"`
+}
+
+// ApplyModerationLabel handles POST /posts/{id}/moderation - a scene moderator
+// (the owner or a curator) applies a moderation label to a post in the scene.
+// Applying a label the post already has is a no-op and is not logged again.
+func (h *PostHandlers) ApplyModerationLabel(w http.ResponseWriter, r *http.Request) {
+	target, sceneID, req, ok := h.moderatedPost(w, r)
+	if !ok {
+		return
+	}
+	if err := post.ValidateLabels([]string{req.Label}); err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid moderation label")
+		return
+	}
+
+	if !slices.Contains(target.Labels, req.Label) {
+		target.Labels = append(target.Labels, req.Label)
+		if err := h.repo.Update(target); err != nil {
+			if err == post.ErrPostDeleted || err == post.ErrPostNotFound {
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+				WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Post not found")
+				return
+			}
+			slog.ErrorContext(r.Context(), "failed to label post", "error", err, "post_id", target.ID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update post")
+			return
+		}
+		h.logModeration(r, "post", target.ID, sceneID, audit.ActionModerationLabelApply, req.Reason)
+	}
+
+	response := newPostResponse(target)
+	h.attachReactions(r.Context(), response)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// RemovePostAsModerator handles DELETE /posts/{id}/moderation - a scene
+// moderator soft-deletes a post in the scene.
+func (h *PostHandlers) RemovePostAsModerator(w http.ResponseWriter, r *http.Request) {
+	target, sceneID, req, ok := h.moderatedPost(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(target.ID); err != nil {
+		if err == post.ErrPostNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Post not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to remove post", "error", err, "post_id", target.ID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to delete post")
+		return
+	}
+	h.logModeration(r, "post", target.ID, sceneID, audit.ActionModerationRemove, req.Reason)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PurgeAuthorPosts handles POST /scenes/{id}/moderation/purge - a scene
+// moderator soft-deletes every post an author has in the scene's feed. The
+// purge is logged once, against the author.
+func (h *PostHandlers) PurgeAuthorPosts(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) != 3 || pathParts[0] == "" || pathParts[1] != "moderation" || pathParts[2] != "purge" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+		return
+	}
+	sceneID := pathParts[0]
+
+	if middleware.GetUserDID(r.Context()) == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	var req PurgeRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r.Context(), err)
+		return
+	}
+	authorDID := strings.TrimSpace(req.AuthorDID)
+	if !strings.HasPrefix(authorDID, "did:") {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "author_did must be a valid DID")
+		return
+	}
+	reason, ok := validateModerationReason(w, r, req.Reason)
+	if !ok {
+		return
+	}
+
+	if !h.requireSceneModerator(w, r, sceneID) {
+		return
+	}
+
+	// Deleted posts drop out of the feed, so each pass restarts from the top
+	removed := 0
+	opts := post.FeedOptions{AuthorDID: &authorDID}
+	for {
+		posts, _, err := h.repo.ListBySceneFiltered(sceneID, opts, purgePageSize, nil)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to list posts to purge", "error", err, "scene_id", sceneID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to purge posts")
+			return
+		}
+		if len(posts) == 0 {
+			break
+		}
+		for _, p := range posts {
+			if err := h.repo.Delete(p.ID); err != nil && err != post.ErrPostNotFound {
+				slog.ErrorContext(r.Context(), "failed to purge post", "error", err, "post_id", p.ID)
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+				WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to purge posts")
+				return
+			}
+			removed++
+		}
+	}
+	h.logModeration(r, "user", authorDID, sceneID, audit.ActionModerationPurge, reason)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(PurgeResponse{SceneID: sceneID, AuthorDID: authorDID, Removed: removed}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// moderatedPost parses a /posts/{id}/moderation request and loads the post,
+// requiring the caller to moderate the post's scene. Returns the post, its
+// scene ID and the request body. Writes the error response and returns false
+// otherwise.
+func (h *PostHandlers) moderatedPost(w http.ResponseWriter, r *http.Request) (*post.Post, string, ModerationActionRequest, bool) {
+	var req ModerationActionRequest
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/posts/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "moderation" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Post ID is required")
+		return nil, "", req, false
+	}
+	postID := pathParts[0]
+
+	if middleware.GetUserDID(r.Context()) == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return nil, "", req, false
+	}
+
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r.Context(), err)
+		return nil, "", req, false
+	}
+	reason, ok := validateModerationReason(w, r, req.Reason)
+	if !ok {
+		return nil, "", req, false
+	}
+	req.Reason = reason
+	req.Label = strings.TrimSpace(req.Label)
+
+	target, err := h.repo.GetByID(postID)
+	if err != nil {
+		if err == post.ErrPostNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Post not found")
+			return nil, "", req, false
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve post", "error", err, "post_id", postID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve post")
+		return nil, "", req, false
+	}
+
+	sceneID, err := h.postSceneID(target.SceneID, target.EventID)
+	if err != nil && err != scene.ErrEventNotFound {
+		slog.ErrorContext(r.Context(), "failed to retrieve event", "error", err, "post_id", postID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+		return nil, "", req, false
+	}
+	// Posts outside any known scene have no moderators
+	if sceneID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to moderate this post")
+		return nil, "", req, false
+	}
+	if !h.requireSceneModerator(w, r, sceneID) {
+		return nil, "", req, false
+	}
+	return target, sceneID, req, true
+}
+
+// requireSceneModerator checks the caller moderates sceneID: its owner or an
+// active curator. Writes the error response and returns false otherwise.
+func (h *PostHandlers) requireSceneModerator(w http.ResponseWriter, r *http.Request, sceneID string) bool {
+	userDID := middleware.GetUserDID(r.Context())
+	moderated, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound || err == scene.ErrSceneDeleted {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			return false
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		return false
+	}
+	role, err := sceneRole(h.membershipRepo, moderated, userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene role", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check permissions")
+		return false
+	}
+	if !roleCanModerate(moderated, userDID, role) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to moderate this scene")
+		return false
+	}
+	return true
+}
+
+// validateModerationReason requires a reason of at most MaxBlockReasonLength
+// characters and returns it trimmed. Writes a 400 and returns false otherwise.
+func validateModerationReason(w http.ResponseWriter, r *http.Request, reason string) (string, bool) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "reason is required")
+		return "", false
+	}
+	if len(reason) > MaxBlockReasonLength {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "reason must be at most 500 characters")
+		return "", false
+	}
+	return reason, true
+}
+
+// logModeration records a moderation action in the scene's moderation log.
+// Audit failures are logged and do not fail the request.
+func (h *PostHandlers) logModeration(r *http.Request, entityType, entityID, sceneID, action, reason string) {
+	if h.auditRepo == nil {
+		return
+	}
+	if err := audit.LogModerationAction(r.Context(), h.auditRepo, entityType, entityID, sceneID, action, reason); err != nil {
+		slog.WarnContext(r.Context(), "failed to log moderation action", "error", err, "action", action, "scene_id", sceneID)
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+const (
+	modOwnerDID   = "did:plc:owner"
+	modCuratorDID = "did:plc:curator"
+	modAuthorDID  = "did:plc:author"
+)
+
+// setupPostModerationTest creates post handlers and a moderation log over
+// scene-1, owned by modOwnerDID with modCuratorDID as an active curator.
+func setupPostModerationTest(t *testing.T) (*PostHandlers, *ModerationLogHandlers) {
+	t.Helper()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	createTestScene(t, sceneRepo, "scene-1", modOwnerDID)
+	if _, err := membershipRepo.Upsert(&membership.Membership{
+		SceneID: "scene-1",
+		UserDID: modCuratorDID,
+		Role:    "curator",
+		Status:  "active",
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	handlers := NewPostHandlers(post.NewInMemoryPostRepository(), sceneRepo, membershipRepo, nil)
+	handlers.SetAuditRepo(auditRepo)
+	return handlers, NewModerationLogHandlers(sceneRepo, auditRepo)
+}
+
+// createModerationTestPost creates a post by modAuthorDID in scene-1.
+func createModerationTestPost(t *testing.T, handlers *PostHandlers) *post.Post {
+	t.Helper()
+	sceneID := "scene-1"
+	p := &post.Post{SceneID: &sceneID, AuthorDID: modAuthorDID, Text: "Hello"}
+	if err := handlers.repo.Create(p); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	return p
+}
+
+// getModerationLog returns scene-1's moderation log as its owner sees it.
+func getModerationLog(t *testing.T, handlers *ModerationLogHandlers) []ModerationLogEntry {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene-1/moderation_log", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), modOwnerDID))
+	w := httptest.NewRecorder()
+	handlers.GetSceneModerationLog(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ModerationLogResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp.Entries
+}
+
+func TestPostModeration_ActionsAppearInModerationLog(t *testing.T) {
+	handlers, logHandlers := setupPostModerationTest(t)
+	labeled := createModerationTestPost(t, handlers)
+	removed := createModerationTestPost(t, handlers)
+
+	w := doEventRequest(t, handlers.ApplyModerationLabel, http.MethodPost, "/posts/"+labeled.ID+"/moderation", modCuratorDID,
+		ModerationActionRequest{Label: post.LabelSpam, Reason: "link spam"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	stored, err := handlers.repo.GetByID(labeled.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if !stored.HasLabel(post.LabelSpam) {
+		t.Errorf("expected post to be labeled spam, got %v", stored.Labels)
+	}
+
+	w = doEventRequest(t, handlers.RemovePostAsModerator, http.MethodDelete, "/posts/"+removed.ID+"/moderation", modOwnerDID,
+		ModerationActionRequest{Reason: "harassment"})
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := handlers.repo.GetByID(removed.ID); err != post.ErrPostNotFound {
+		t.Errorf("expected removed post to be gone, got %v", err)
+	}
+
+	w = doEventRequest(t, handlers.PurgeAuthorPosts, http.MethodPost, "/scenes/scene-1/moderation/purge", modOwnerDID,
+		PurgeRequest{AuthorDID: modAuthorDID, Reason: "raid"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var purge PurgeResponse
+	if err := json.NewDecoder(w.Body).Decode(&purge); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if purge.Removed != 1 {
+		t.Errorf("expected the remaining post to be purged, got %d removed", purge.Removed)
+	}
+
+	entries := getModerationLog(t, logHandlers)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 moderation log entries, got %d: %+v", len(entries), entries)
+	}
+	want := []struct{ action, entityID, moderator, reason string }{
+		{audit.ActionModerationPurge, modAuthorDID, modOwnerDID, "raid"},
+		{audit.ActionModerationRemove, removed.ID, modOwnerDID, "harassment"},
+		{audit.ActionModerationLabelApply, labeled.ID, modCuratorDID, "link spam"},
+	}
+	for i, w := range want {
+		got := entries[i]
+		if got.Action != w.action || got.EntityID != w.entityID || got.ModeratorDID != w.moderator || got.Reason != w.reason {
+			t.Errorf("entry %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestPostModeration_Validation(t *testing.T) {
+	handlers, logHandlers := setupPostModerationTest(t)
+	p := createModerationTestPost(t, handlers)
+	path := "/posts/" + p.ID + "/moderation"
+
+	tests := []struct {
+		name     string
+		userDID  string
+		body     ModerationActionRequest
+		wantCode int
+		wantErr  string
+	}{
+		{"unauthenticated", "", ModerationActionRequest{Label: post.LabelSpam, Reason: "spam"}, http.StatusUnauthorized, ErrCodeAuthFailed},
+		{"not a moderator", modAuthorDID, ModerationActionRequest{Label: post.LabelSpam, Reason: "spam"}, http.StatusForbidden, ErrCodeForbidden},
+		{"missing reason", modOwnerDID, ModerationActionRequest{Label: post.LabelSpam}, http.StatusBadRequest, ErrCodeValidation},
+		{"unknown label", modOwnerDID, ModerationActionRequest{Label: "boring", Reason: "meh"}, http.StatusBadRequest, ErrCodeValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doEventRequest(t, handlers.ApplyModerationLabel, http.MethodPost, path, tt.userDID, tt.body)
+			assertErrorResponse(t, w, tt.wantCode, tt.wantErr)
+		})
+	}
+
+	if entries := getModerationLog(t, logHandlers); len(entries) != 0 {
+		t.Errorf("expected rejected actions to leave no log entries, got %+v", entries)
+	}
+}
+
+func TestModerationHandlers_MuteLogsToModerationLog(t *testing.T) {
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	createTestScene(t, sceneRepo, "scene-1", modOwnerDID)
+	handlers := NewModerationHandlers(sceneRepo, []string{"did:plc:admin"})
+	handlers.SetAuditRepo(auditRepo)
+
+	for _, action := range []string{"mute", "unmute"} {
+		w := doEventRequest(t, func(w http.ResponseWriter, r *http.Request) {
+			r.SetPathValue("sceneID", "scene-1")
+			if action == "mute" {
+				handlers.MuteScene(w, r)
+			} else {
+				handlers.UnmuteScene(w, r)
+			}
+		}, http.MethodPost, "/internal/moderation/scenes/scene-1/"+action, "did:plc:admin", MuteSceneRequest{Reason: "policy violation"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", action, w.Code, w.Body.String())
+		}
+	}
+
+	entries := getModerationLog(t, NewModerationLogHandlers(sceneRepo, auditRepo))
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 moderation log entries, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Action != audit.ActionModerationUnmute || entries[1].Action != audit.ActionModerationMute {
+		t.Errorf("unexpected actions: %+v", entries)
+	}
+	if entries[1].ModeratorDID != "did:plc:admin" || entries[1].Reason != "policy violation" {
+		t.Errorf("unexpected mute entry: %+v", entries[1])
+	}
+}
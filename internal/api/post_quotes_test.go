@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+func TestCreatePost_Quote(t *testing.T) {
+	postRepo := post.NewInMemoryPostRepository()
+	handlers := NewPostHandlers(postRepo, scene.NewInMemorySceneRepository(), membership.NewInMemoryMembershipRepository(), nil)
+
+	sceneID := "scene123"
+	original := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:other", Text: "Original"}
+	if err := postRepo.Create(original); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	w := createReplyRequest(t, handlers, CreatePostRequest{SceneID: &sceneID, Text: "Quote", QuotedPostID: &original.ID})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created post.Post
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.QuotedPostID == nil || *created.QuotedPostID != original.ID {
+		t.Errorf("expected quoted_post_id %s, got %v", original.ID, created.QuotedPostID)
+	}
+
+	stored, err := postRepo.GetByID(original.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.RepostCount != 1 {
+		t.Errorf("expected repost count 1, got %d", stored.RepostCount)
+	}
+
+	trending, err := postRepo.ListTrending(sceneID, time.Hour, 10)
+	if err != nil {
+		t.Fatalf("ListTrending failed: %v", err)
+	}
+	if len(trending) != 1 || trending[0].ID != original.ID {
+		t.Errorf("expected the quoted post to be trending, got %d posts", len(trending))
+	}
+}
+
+func TestCreatePost_QuoteValidation(t *testing.T) {
+	postRepo := post.NewInMemoryPostRepository()
+	handlers := NewPostHandlers(postRepo, scene.NewInMemorySceneRepository(), membership.NewInMemoryMembershipRepository(), nil)
+
+	sceneID := "scene123"
+	deleted := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:other", Text: "Deleted"}
+	if err := postRepo.Create(deleted); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	if err := postRepo.Delete(deleted.ID); err != nil {
+		t.Fatalf("failed to delete post: %v", err)
+	}
+
+	for name, quotedID := range map[string]string{
+		"missing quoted post": "does-not-exist",
+		"deleted quoted post": deleted.ID,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := createReplyRequest(t, handlers, CreatePostRequest{SceneID: &sceneID, Text: "Quote", QuotedPostID: &quotedID})
+			assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+		})
+	}
+
+	stored, err := postRepo.GetByIDIncludingDeleted(deleted.ID)
+	if err != nil {
+		t.Fatalf("GetByIDIncludingDeleted failed: %v", err)
+	}
+	if stored.RepostCount != 0 {
+		t.Errorf("expected repost count 0 on the deleted post, got %d", stored.RepostCount)
+	}
+}
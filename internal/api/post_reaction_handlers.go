@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// ReactionRequest is the body of POST and DELETE /posts/{id}/reactions.
+type ReactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+// ReactionsResponse is the JSON response after adding a reaction: the post's
+// reaction counts by emoji, including the new one.
+type ReactionsResponse struct {
+	PostID    string         `json:"post_id"`
+	Emoji     string         `json:"emoji"`
+	Reactions map[string]int `json:"reactions"`
+}
+
+// SetReactionRepo replaces the repository post reactions are stored in.
+// A nil repository is ignored.
+func (h *PostHandlers) SetReactionRepo(repo post.ReactionRepository) {
+	if repo != nil {
+		h.reactionRepo = repo
+	}
+}
+
+// AddReaction handles POST /posts/{id}/reactions - reacts to a post with an
+// allowlisted emoji. Idempotent: returns 201 when the reaction is new and 200
+// when the caller had already reacted with that emoji.
+func (h *PostHandlers) AddReaction(w http.ResponseWriter, r *http.Request) {
+	postID, emoji, ok := h.parseReactionRequest(w, r)
+	if !ok {
+		return
+	}
+	if h.getReactablePost(w, r, postID) == nil {
+		return
+	}
+
+	added, err := h.reactionRepo.Add(postID, middleware.GetUserDID(r.Context()), emoji)
+	if err != nil {
+		if err == post.ErrTooManyReactionKinds {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeConflict)
+			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Post has reached the maximum number of distinct reactions")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to add reaction", "error", err, "post_id", postID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to add reaction")
+		return
+	}
+	if added {
+		h.adjustReactionCount(r.Context(), postID, 1)
+	}
+
+	counts, err := h.reactionRepo.CountsForPosts([]string{postID})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to load post reactions", "error", err, "post_id", postID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve reactions")
+		return
+	}
+	response := ReactionsResponse{PostID: postID, Emoji: emoji, Reactions: counts[postID]}
+	if response.Reactions == nil {
+		response.Reactions = map[string]int{}
+	}
+
+	status := http.StatusOK
+	if added {
+		status = http.StatusCreated
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
+
+// RemoveReaction handles DELETE /posts/{id}/reactions - removes the caller's
+// reaction with the emoji in the request body.
+func (h *PostHandlers) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	postID, emoji, ok := h.parseReactionRequest(w, r)
+	if !ok {
+		return
+	}
+	if h.getReactablePost(w, r, postID) == nil {
+		return
+	}
+
+	if err := h.reactionRepo.Remove(postID, middleware.GetUserDID(r.Context()), emoji); err != nil {
+		if err == post.ErrReactionNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Reaction not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to remove reaction", "error", err, "post_id", postID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to remove reaction")
+		return
+	}
+	h.adjustReactionCount(r.Context(), postID, -1)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseReactionRequest extracts the post ID from a /posts/{id}/reactions path
+// and the emoji from the body, requiring an authenticated caller and an
+// allowlisted emoji. Writes the error response and returns false otherwise.
+func (h *PostHandlers) parseReactionRequest(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/posts/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "reactions" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Post ID is required")
+		return "", "", false
+	}
+
+	if middleware.GetUserDID(r.Context()) == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return "", "", false
+	}
+
+	var req ReactionRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r.Context(), err)
+		return "", "", false
+	}
+	if err := post.ValidateReaction(req.Emoji); err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Reaction emoji is not allowed")
+		return "", "", false
+	}
+	return pathParts[0], req.Emoji, true
+}
+
+// getReactablePost loads a post the caller may react to: it must not be
+// deleted, its scene must be visible to the caller, and the caller must not be
+// blocked there. Writes the error response and returns nil otherwise.
+func (h *PostHandlers) getReactablePost(w http.ResponseWriter, r *http.Request, postID string) *post.Post {
+	userDID := middleware.GetUserDID(r.Context())
+
+	target, err := h.repo.GetByID(postID)
+	if err != nil {
+		if err == post.ErrPostNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Post not found")
+			return nil
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve post", "error", err, "post_id", postID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve post")
+		return nil
+	}
+
+	if target.SceneID != nil {
+		postScene, err := h.sceneRepo.GetByID(*target.SceneID)
+		if err != nil && err != scene.ErrSceneNotFound && err != scene.ErrSceneDeleted {
+			slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", *target.SceneID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+			return nil
+		}
+		canAccess := false
+		if err == nil {
+			canAccess, err = sceneVisibleTo(h.membershipRepo, postScene, userDID)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", postScene.ID)
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+				WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check access permissions")
+				return nil
+			}
+		}
+		// Use uniform error message - same as "not found" to prevent enumeration
+		if !canAccess {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Post not found")
+			return nil
+		}
+	}
+
+	// DIDs blocked in the post's scene may not react there
+	if h.blockRepo != nil {
+		blockSceneID, err := h.postSceneID(target.SceneID, target.EventID)
+		if err != nil && err != scene.ErrEventNotFound {
+			slog.ErrorContext(r.Context(), "failed to retrieve event", "error", err, "post_id", postID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
+			return nil
+		}
+		if !checkSceneBlock(r.Context(), w, h.blockRepo, blockSceneID, userDID) {
+			return nil
+		}
+	}
+	return target
+}
+
+// adjustReactionCount mirrors a reaction change onto the post, which feeds the
+// ranked feed and advances its scene's and event's Last-Modified. The reaction
+// itself is already stored, so a failure is logged rather than returned; a post
+// deleted in the meantime has no feed to refresh.
+func (h *PostHandlers) adjustReactionCount(ctx context.Context, postID string, delta int) {
+	if err := h.repo.AdjustReactionCount(postID, delta); err != nil && err != post.ErrPostNotFound {
+		slog.ErrorContext(ctx, "failed to update post reaction count", "error", err, "post_id", postID)
+	}
+}
+
+// attachReactions fills in the reaction counts of responses with one batch
+// lookup. Reactions are decoration, so a failed lookup is logged and the
+// posts are returned without them.
+func (h *PostHandlers) attachReactions(ctx context.Context, responses ...PostResponse) {
+	if len(responses) == 0 {
+		return
+	}
+	postIDs := make([]string, len(responses))
+	for i, resp := range responses {
+		postIDs[i] = resp.ID
+	}
+	counts, err := h.reactionRepo.CountsForPosts(postIDs)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load post reactions", "error", err)
+		return
+	}
+	for _, resp := range responses {
+		for emoji, count := range counts[resp.ID] {
+			resp.Reactions[emoji] = count
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// reactAs sends a reaction request for postID as userDID and returns the recorder.
+func reactAs(t *testing.T, handlers *PostHandlers, method, postID, userDID, emoji string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(ReactionRequest{Emoji: emoji})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest(method, "/posts/"+postID+"/reactions", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	if method == http.MethodDelete {
+		handlers.RemoveReaction(w, req)
+	} else {
+		handlers.AddReaction(w, req)
+	}
+	return w
+}
+
+// newReactionTestPost creates a post in a public scene.
+func newReactionTestPost(t *testing.T, handlers *PostHandlers) *post.Post {
+	t.Helper()
+	createTestScene(t, handlers.sceneRepo, "scene123", "did:plc:owner")
+	sceneID := "scene123"
+	p := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:author", Text: "Hello"}
+	if err := handlers.repo.Create(p); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	return p
+}
+
+func TestAddReaction_Idempotent(t *testing.T) {
+	handlers := newTestPostHandlers()
+	p := newReactionTestPost(t, handlers)
+
+	w := reactAs(t, handlers, http.MethodPost, p.ID, testUserDID, "🔥")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = reactAs(t, handlers, http.MethodPost, p.ID, testUserDID, "🔥")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for a repeated reaction, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ReactionsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Reactions["🔥"] != 1 {
+		t.Errorf("expected a single 🔥 reaction, got %v", resp.Reactions)
+	}
+
+	if w := reactAs(t, handlers, http.MethodPost, p.ID, "did:plc:other", "🔥"); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The feed aggregates reactions per post
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene123/feed", nil)
+	w = httptest.NewRecorder()
+	handlers.GetSceneFeed(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var feed FeedResponse
+	if err := json.NewDecoder(w.Body).Decode(&feed); err != nil {
+		t.Fatalf("failed to decode feed: %v", err)
+	}
+	if len(feed.Posts) != 1 || feed.Posts[0].Reactions["🔥"] != 2 {
+		t.Errorf("expected the feed post to have 2 🔥 reactions, got %+v", feed.Posts)
+	}
+}
+
+func TestRemoveReaction(t *testing.T) {
+	handlers := newTestPostHandlers()
+	p := newReactionTestPost(t, handlers)
+
+	if w := reactAs(t, handlers, http.MethodPost, p.ID, testUserDID, "👍"); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := reactAs(t, handlers, http.MethodDelete, p.ID, testUserDID, "👍")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = reactAs(t, handlers, http.MethodDelete, p.ID, testUserDID, "👍")
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+
+	counts, err := handlers.reactionRepo.CountsForPosts([]string{p.ID})
+	if err != nil {
+		t.Fatalf("CountsForPosts failed: %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("expected no reactions after removal, got %v", counts)
+	}
+}
+
+func TestAddReaction_DeletedPost(t *testing.T) {
+	handlers := newTestPostHandlers()
+	p := newReactionTestPost(t, handlers)
+	if err := handlers.repo.Delete(p.ID); err != nil {
+		t.Fatalf("failed to delete post: %v", err)
+	}
+
+	w := reactAs(t, handlers, http.MethodPost, p.ID, testUserDID, "🔥")
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+
+	w = reactAs(t, handlers, http.MethodDelete, p.ID, testUserDID, "🔥")
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+}
+
+func TestAddReaction_Validation(t *testing.T) {
+	handlers := newTestPostHandlers()
+	p := newReactionTestPost(t, handlers)
+
+	t.Run("emoji not allowlisted", func(t *testing.T) {
+		w := reactAs(t, handlers, http.MethodPost, p.ID, testUserDID, "🍆")
+		assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/posts/"+p.ID+"/reactions", bytes.NewReader([]byte(`{"emoji":"🔥"}`)))
+		w := httptest.NewRecorder()
+		handlers.AddReaction(w, req)
+		assertErrorResponse(t, w, http.StatusUnauthorized, ErrCodeAuthFailed)
+	})
+
+	t.Run("blocked in scene", func(t *testing.T) {
+		blockRepo := scene.NewInMemoryBlockRepository()
+		handlers.SetBlockRepo(blockRepo, nil)
+		defer handlers.SetBlockRepo(nil, nil)
+		if _, err := blockRepo.Add(&scene.Block{SceneID: "scene123", BlockedDID: "did:plc:blocked", BlockedBy: "did:plc:owner"}); err != nil {
+			t.Fatalf("failed to block: %v", err)
+		}
+		w := reactAs(t, handlers, http.MethodPost, p.ID, "did:plc:blocked", "🔥")
+		assertErrorResponse(t, w, http.StatusForbidden, ErrCodeForbidden)
+	})
+
+	t.Run("distinct emoji cap", func(t *testing.T) {
+		added := 0
+		for emoji := range post.AllowedReactions {
+			w := reactAs(t, handlers, http.MethodPost, p.ID, testUserDID, emoji)
+			if added < post.MaxReactionKindsPerPost {
+				if w.Code != http.StatusCreated {
+					t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+				}
+				added++
+				continue
+			}
+			assertErrorResponse(t, w, http.StatusConflict, ErrCodeConflict)
+			break
+		}
+	})
+}
+
+// TestAddReaction_AdvancesFeedLastModified tests that reacting to a post
+// invalidates a previously up-to-date conditional feed request, since the
+// feed carries the post's reaction counts.
+func TestAddReaction_AdvancesFeedLastModified(t *testing.T) {
+	handlers := newTestPostHandlers()
+	p := newReactionTestPost(t, handlers)
+
+	getFeed := func(ifModifiedSince string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/scenes/scene123/feed", nil)
+		if ifModifiedSince != "" {
+			req.Header.Set("If-Modified-Since", ifModifiedSince)
+		}
+		w := httptest.NewRecorder()
+		handlers.GetSceneFeed(w, req)
+		return w
+	}
+
+	w := getFeed("")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	firstModified, err := http.ParseTime(w.Header().Get("Last-Modified"))
+	if err != nil {
+		t.Fatalf("failed to parse Last-Modified: %v", err)
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		// HTTP dates have second precision; wait for the next second boundary
+		time.Sleep(time.Until(firstModified.Add(time.Second)))
+		if w := reactAs(t, handlers, method, p.ID, testUserDID, "🔥"); w.Code >= 300 {
+			t.Fatalf("%s reaction failed with status %d: %s", method, w.Code, w.Body.String())
+		}
+
+		w = getFeed(firstModified.Format(http.TimeFormat))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 after %s reaction, got %d", method, w.Code)
+		}
+		if firstModified, err = http.ParseTime(w.Header().Get("Last-Modified")); err != nil {
+			t.Fatalf("failed to parse Last-Modified: %v", err)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// createReplyRequest posts a reply as the test user and returns the recorder.
+func createReplyRequest(t *testing.T, handlers *PostHandlers, reqBody CreatePostRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = withAuthContext(req)
+	w := httptest.NewRecorder()
+	handlers.CreatePost(w, req)
+	return w
+}
+
+func TestCreatePost_Reply(t *testing.T) {
+	postRepo := post.NewInMemoryPostRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewPostHandlers(postRepo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil)
+
+	sceneID := "scene123"
+	eventID := "event123"
+	parent := &post.Post{SceneID: &sceneID, EventID: &eventID, AuthorDID: "did:plc:other", Text: "Parent"}
+	if err := postRepo.Create(parent); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+
+	w := createReplyRequest(t, handlers, CreatePostRequest{SceneID: &sceneID, EventID: &eventID, Text: "Reply", ParentID: &parent.ID})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created post.Post
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ParentID == nil || *created.ParentID != parent.ID {
+		t.Errorf("expected parent_id %s, got %v", parent.ID, created.ParentID)
+	}
+
+	stored, err := postRepo.GetByID(parent.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if stored.ReplyCount != 1 {
+		t.Errorf("expected parent reply count 1, got %d", stored.ReplyCount)
+	}
+}
+
+func TestCreatePost_ReplyValidation(t *testing.T) {
+	postRepo := post.NewInMemoryPostRepository()
+	handlers := NewPostHandlers(postRepo, scene.NewInMemorySceneRepository(), membership.NewInMemoryMembershipRepository(), nil)
+
+	sceneID := "scene123"
+	eventID := "event123"
+	parent := &post.Post{SceneID: &sceneID, EventID: &eventID, AuthorDID: "did:plc:other", Text: "Parent"}
+	if err := postRepo.Create(parent); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+	deleted := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:other", Text: "Deleted"}
+	if err := postRepo.Create(deleted); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	if err := postRepo.Delete(deleted.ID); err != nil {
+		t.Fatalf("failed to delete post: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		req      CreatePostRequest
+		wantCode int
+		wantErr  string
+	}{
+		{
+			name:     "missing parent",
+			req:      CreatePostRequest{SceneID: &sceneID, Text: "Reply", ParentID: strPtr("does-not-exist")},
+			wantCode: http.StatusNotFound,
+			wantErr:  ErrCodeNotFound,
+		},
+		{
+			name:     "deleted parent",
+			req:      CreatePostRequest{SceneID: &sceneID, Text: "Reply", ParentID: &deleted.ID},
+			wantCode: http.StatusNotFound,
+			wantErr:  ErrCodeNotFound,
+		},
+		{
+			name:     "other scene",
+			req:      CreatePostRequest{SceneID: strPtr("other-scene"), EventID: &eventID, Text: "Reply", ParentID: &parent.ID},
+			wantCode: http.StatusBadRequest,
+			wantErr:  ErrCodeValidation,
+		},
+		{
+			name:     "missing event",
+			req:      CreatePostRequest{SceneID: &sceneID, Text: "Reply", ParentID: &parent.ID},
+			wantCode: http.StatusBadRequest,
+			wantErr:  ErrCodeValidation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := createReplyRequest(t, handlers, tt.req)
+			assertErrorResponse(t, w, tt.wantCode, tt.wantErr)
+		})
+	}
+}
+
+func TestGetPostReplies(t *testing.T) {
+	postRepo := post.NewInMemoryPostRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewPostHandlers(postRepo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil)
+	createTestScene(t, sceneRepo, "scene123", "did:plc:owner")
+
+	sceneID := "scene123"
+	parent := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:owner", Text: "Parent"}
+	if err := postRepo.Create(parent); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+	for _, text := range []string{"First", "Second", "Third"} {
+		if err := postRepo.Create(&post.Post{SceneID: &sceneID, AuthorDID: testUserDID, Text: text, ParentID: &parent.ID}); err != nil {
+			t.Fatalf("failed to create reply: %v", err)
+		}
+	}
+
+	getReplies := func(path string) FeedResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		handlers.GetPostReplies(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response FeedResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	response := getReplies("/posts/" + parent.ID + "/replies?limit=2")
+	if len(response.Posts) != 2 || response.NextCursor == nil {
+		t.Fatalf("expected a first page of 2 replies with a cursor, got %d", len(response.Posts))
+	}
+
+	// Deleting the parent keeps its replies, marked orphaned
+	if err := postRepo.Delete(parent.ID); err != nil {
+		t.Fatalf("failed to delete parent: %v", err)
+	}
+	response = getReplies("/posts/" + parent.ID + "/replies")
+	if len(response.Posts) != 3 {
+		t.Fatalf("expected 3 replies after deleting the parent, got %d", len(response.Posts))
+	}
+	for _, reply := range response.Posts {
+		if !reply.Orphaned {
+			t.Errorf("expected reply %q to be marked orphaned", reply.Text)
+		}
+	}
+}
+
+func TestGetPostReplies_NotFound(t *testing.T) {
+	handlers := newTestPostHandlers()
+
+	req := httptest.NewRequest(http.MethodGet, "/posts/does-not-exist/replies", nil)
+	w := httptest.NewRecorder()
+	handlers.GetPostReplies(w, req)
+
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+}
+
+func TestGetPostReplies_PrivateScene(t *testing.T) {
+	postRepo := post.NewInMemoryPostRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewPostHandlers(postRepo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil)
+	privateScene := createTestScene(t, sceneRepo, "scene123", "did:plc:owner")
+	privateScene.Visibility = scene.VisibilityMembersOnly
+	if err := sceneRepo.Update(privateScene); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+
+	sceneID := "scene123"
+	parent := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:owner", Text: "Parent"}
+	if err := postRepo.Create(parent); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+	if err := postRepo.Create(&post.Post{SceneID: &sceneID, AuthorDID: "did:plc:owner", Text: "Reply", ParentID: &parent.ID}); err != nil {
+		t.Fatalf("failed to create reply: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/posts/"+parent.ID+"/replies", nil)
+	req = withAuthContext(req)
+	w := httptest.NewRecorder()
+	handlers.GetPostReplies(w, req)
+
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+}
+
+func TestGetPostReplies_PrivateSceneEmptyThread(t *testing.T) {
+	postRepo := post.NewInMemoryPostRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewPostHandlers(postRepo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil)
+	privateScene := createTestScene(t, sceneRepo, "scene123", "did:plc:owner")
+	privateScene.Visibility = scene.VisibilityHidden
+	if err := sceneRepo.Update(privateScene); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+
+	sceneID := "scene123"
+	parent := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:owner", Text: "Parent"}
+	if err := postRepo.Create(parent); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+
+	// A thread without replies, or whose parent was deleted, doesn't confirm the post exists
+	for _, deleteParent := range []bool{false, true} {
+		if deleteParent {
+			if err := postRepo.Delete(parent.ID); err != nil {
+				t.Fatalf("failed to delete parent: %v", err)
+			}
+		}
+		req := withAuthContext(httptest.NewRequest(http.MethodGet, "/posts/"+parent.ID+"/replies", nil))
+		w := httptest.NewRecorder()
+		handlers.GetPostReplies(w, req)
+		assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+	}
+}
+
+func TestGetPostReplies_EventThreadChecksEventScene(t *testing.T) {
+	postRepo := post.NewInMemoryPostRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	handlers := NewPostHandlers(postRepo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil)
+	handlers.SetBlockRepo(nil, eventRepo)
+	privateScene := createTestScene(t, sceneRepo, "scene123", "did:plc:owner")
+	privateScene.Visibility = scene.VisibilityMembersOnly
+	if err := sceneRepo.Update(privateScene); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+	if err := eventRepo.Insert(&scene.Event{ID: "event-1", SceneID: "scene123", Title: "Night", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	eventID := "event-1"
+	parent := &post.Post{EventID: &eventID, AuthorDID: "did:plc:owner", Text: "Parent"}
+	if err := postRepo.Create(parent); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+	if err := postRepo.Create(&post.Post{EventID: &eventID, AuthorDID: "did:plc:owner", Text: "Reply", ParentID: &parent.ID}); err != nil {
+		t.Fatalf("failed to create reply: %v", err)
+	}
+
+	req := withAuthContext(httptest.NewRequest(http.MethodGet, "/posts/"+parent.ID+"/replies", nil))
+	w := httptest.NewRecorder()
+	handlers.GetPostReplies(w, req)
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+
+	// The owner reads the thread
+	req = httptest.NewRequest(http.MethodGet, "/posts/"+parent.ID+"/replies", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+	w = httptest.NewRecorder()
+	handlers.GetPostReplies(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for the owner, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp FeedResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Posts) != 1 {
+		t.Errorf("expected 1 reply, got %d", len(resp.Posts))
+	}
+}
+
+func TestGetPostReplies_MatureSceneEmptyThread(t *testing.T) {
+	postRepo := post.NewInMemoryPostRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewPostHandlers(postRepo, sceneRepo, membership.NewInMemoryMembershipRepository(), nil)
+	matureScene := createTestScene(t, sceneRepo, "scene123", "did:plc:owner")
+	matureScene.ContentRating = scene.ContentRatingMature
+	if err := sceneRepo.Update(matureScene); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+
+	sceneID := "scene123"
+	parent := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:owner", Text: "Parent"}
+	if err := postRepo.Create(parent); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+
+	req := withAuthContext(httptest.NewRequest(http.MethodGet, "/posts/"+parent.ID+"/replies", nil))
+	w := httptest.NewRecorder()
+	handlers.GetPostReplies(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Values("Vary"); len(got) != 1 || got[0] != MatureContentHeader {
+		t.Errorf("Vary = %v, want %s", got, MatureContentHeader)
+	}
+	var resp FeedResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.ContentRedacted {
+		t.Error("expected the mature thread to be redacted without opt-in")
+	}
+}
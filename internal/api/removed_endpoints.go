@@ -0,0 +1,99 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/onnwee/subcults/internal/middleware"
+)
+
+// RemovedEndpoint describes an API path that has been retired.
+type RemovedEndpoint struct {
+	// Path is matched exactly, or as a prefix when it ends with "/".
+	Path string
+
+	// Replacement is the path clients should migrate to; empty if there is none.
+	Replacement string
+
+	// Message overrides the default human-readable explanation.
+	Message string
+}
+
+// RemovedEndpointRegistry answers requests for retired paths with 410 Gone so
+// clients can tell "removed" apart from "never existed" (404).
+type RemovedEndpointRegistry struct {
+	exact    map[string]RemovedEndpoint
+	prefixes []RemovedEndpoint
+}
+
+// NewRemovedEndpointRegistry creates a registry containing the given endpoints.
+func NewRemovedEndpointRegistry(endpoints ...RemovedEndpoint) *RemovedEndpointRegistry {
+	reg := &RemovedEndpointRegistry{exact: make(map[string]RemovedEndpoint)}
+	for _, ep := range endpoints {
+		reg.Register(ep)
+	}
+	return reg
+}
+
+// Register adds a removed endpoint. Registering the same path again replaces it.
+func (reg *RemovedEndpointRegistry) Register(ep RemovedEndpoint) {
+	if !strings.HasSuffix(ep.Path, "/") {
+		reg.exact[ep.Path] = ep
+		return
+	}
+	for i, existing := range reg.prefixes {
+		if existing.Path == ep.Path {
+			reg.prefixes[i] = ep
+			return
+		}
+	}
+	reg.prefixes = append(reg.prefixes, ep)
+}
+
+// Lookup returns the removed endpoint matching path, preferring an exact match
+// and then the longest matching prefix.
+func (reg *RemovedEndpointRegistry) Lookup(path string) (RemovedEndpoint, bool) {
+	if ep, ok := reg.exact[path]; ok {
+		return ep, true
+	}
+
+	var match RemovedEndpoint
+	found := false
+	for _, ep := range reg.prefixes {
+		if strings.HasPrefix(path, ep.Path) && len(ep.Path) > len(match.Path) {
+			match = ep
+			found = true
+		}
+	}
+	return match, found
+}
+
+// Middleware short-circuits requests for removed paths with 410 Gone and passes
+// everything else to next, so unknown paths keep returning 404.
+func (reg *RemovedEndpointRegistry) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ep, ok := reg.Lookup(r.URL.Path); ok {
+			writeGone(w, r, ep)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeGone writes the 410 response for a removed endpoint.
+func writeGone(w http.ResponseWriter, r *http.Request, ep RemovedEndpoint) {
+	message := ep.Message
+	if message == "" {
+		message = "This endpoint has been removed"
+		if ep.Replacement != "" {
+			message += "; use " + ep.Replacement + " instead"
+		}
+	}
+
+	ctx := middleware.SetErrorCode(r.Context(), ErrCodeGone)
+	writeErrorDetail(w, ctx, http.StatusGone, ErrorDetail{
+		Code:        ErrCodeGone,
+		Message:     message,
+		Replacement: ep.Replacement,
+	})
+}
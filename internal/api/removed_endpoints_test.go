@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// notFoundHandler mimics the catch-all route in cmd/api.
+var notFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, r.Context(), http.StatusNotFound, ErrCodeNotFound, "The requested resource was not found")
+})
+
+func serveWithRemovedEndpoints(reg *RemovedEndpointRegistry, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	reg.Middleware(notFoundHandler).ServeHTTP(w, req)
+	return w
+}
+
+func TestRemovedEndpoints_ReturnsGoneWithReplacement(t *testing.T) {
+	reg := NewRemovedEndpointRegistry(
+		RemovedEndpoint{Path: "/scenes/mine", Replacement: "/me/scenes"},
+	)
+
+	w := serveWithRemovedEndpoints(reg, "/scenes/mine")
+	if w.Code != http.StatusGone {
+		t.Fatalf("expected status 410, got %d", w.Code)
+	}
+
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error.Code != ErrCodeGone {
+		t.Errorf("expected code %s, got %s", ErrCodeGone, resp.Error.Code)
+	}
+	if resp.Error.Replacement != "/me/scenes" {
+		t.Errorf("expected replacement /me/scenes, got %q", resp.Error.Replacement)
+	}
+	if resp.Error.Message != "This endpoint has been removed; use /me/scenes instead" {
+		t.Errorf("unexpected message: %s", resp.Error.Message)
+	}
+}
+
+func TestRemovedEndpoints_PrefixMatch(t *testing.T) {
+	reg := NewRemovedEndpointRegistry(
+		RemovedEndpoint{Path: "/v0/", Message: "The v0 API has been retired"},
+		RemovedEndpoint{Path: "/v0/scenes/", Replacement: "/scenes/"},
+	)
+
+	w := serveWithRemovedEndpoints(reg, "/v0/scenes/abc")
+	if w.Code != http.StatusGone {
+		t.Fatalf("expected status 410, got %d", w.Code)
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error.Replacement != "/scenes/" {
+		t.Errorf("expected longest prefix to win, got replacement %q", resp.Error.Replacement)
+	}
+
+	w = serveWithRemovedEndpoints(reg, "/v0/events")
+	resp = ErrorResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if w.Code != http.StatusGone || resp.Error.Message != "The v0 API has been retired" || resp.Error.Replacement != "" {
+		t.Errorf("expected custom message without replacement, got %d %+v", w.Code, resp.Error)
+	}
+}
+
+func TestRemovedEndpoints_UnknownPathStillNotFound(t *testing.T) {
+	reg := NewRemovedEndpointRegistry(
+		RemovedEndpoint{Path: "/scenes/mine", Replacement: "/me/scenes"},
+	)
+
+	w := serveWithRemovedEndpoints(reg, "/never-existed")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+
+	var raw map[string]map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if raw["error"]["code"] != ErrCodeNotFound {
+		t.Errorf("expected code %s, got %v", ErrCodeNotFound, raw["error"]["code"])
+	}
+	if _, ok := raw["error"]["replacement"]; ok {
+		t.Error("expected 404 envelope to omit replacement")
+	}
+}
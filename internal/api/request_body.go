@@ -0,0 +1,61 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/onnwee/subcults/internal/middleware"
+)
+
+// ErrEmptyBody indicates a required JSON request body was absent, a literal null,
+// or an empty object.
+var ErrEmptyBody = errors.New("request body required")
+
+// decodeJSONBody decodes a required JSON request body into v.
+// Returns ErrEmptyBody when there is nothing to decode, so callers can tell a
+// missing body apart from malformed JSON; any other error means the body is malformed.
+func decodeJSONBody(r *http.Request, v any) error {
+	if r.Body == nil {
+		return ErrEmptyBody
+	}
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrEmptyBody
+		}
+		return err
+	}
+
+	trimmed := bytes.TrimSpace(raw)
+	if bytes.Equal(trimmed, []byte("null")) {
+		return ErrEmptyBody
+	}
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &fields); err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return ErrEmptyBody
+		}
+	}
+
+	return json.Unmarshal(trimmed, v)
+}
+
+// writeDecodeError writes the error response for a decodeJSONBody failure:
+// ErrCodeValidation for an empty body and ErrCodeBadRequest for malformed JSON.
+func writeDecodeError(w http.ResponseWriter, ctx context.Context, err error) {
+	if errors.Is(err, ErrEmptyBody) {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Request body required")
+		return
+	}
+	ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+	WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+}
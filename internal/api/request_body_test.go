@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSONBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantEmpty bool
+		wantErr   bool
+	}{
+		{name: "valid body", body: `{"text": "hello"}`},
+		{name: "empty object", body: `{}`, wantEmpty: true},
+		{name: "empty object with whitespace", body: " { } \n", wantEmpty: true},
+		{name: "null body", body: `null`, wantEmpty: true},
+		{name: "absent body", body: ``, wantEmpty: true},
+		{name: "malformed JSON", body: `{"text": `, wantErr: true},
+		{name: "wrong type", body: `{"text": 42}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/posts", strings.NewReader(tt.body))
+
+			var dst CreatePostRequest
+			err := decodeJSONBody(req, &dst)
+
+			switch {
+			case tt.wantEmpty:
+				if !errors.Is(err, ErrEmptyBody) {
+					t.Errorf("decodeJSONBody() error = %v, want ErrEmptyBody", err)
+				}
+			case tt.wantErr:
+				if err == nil || errors.Is(err, ErrEmptyBody) {
+					t.Errorf("decodeJSONBody() error = %v, want malformed JSON error", err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("decodeJSONBody() unexpected error = %v", err)
+				}
+				if dst.Text != "hello" {
+					t.Errorf("expected text 'hello', got %q", dst.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeJSONBody_NilBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/posts", nil)
+	req.Body = nil
+
+	var dst CreatePostRequest
+	if err := decodeJSONBody(req, &dst); !errors.Is(err, ErrEmptyBody) {
+		t.Errorf("decodeJSONBody() error = %v, want ErrEmptyBody", err)
+	}
+}
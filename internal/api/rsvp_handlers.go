@@ -2,34 +2,62 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/onnwee/subcults/internal/audit"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
 )
 
 // RSVPRequest represents the request body for creating/updating an RSVP.
 type RSVPRequest struct {
-	Status string `json:"status"` // "going" or "maybe"
+	Status string `json:"status"` // "going", "maybe" or "not_going"; defaults to "going"
 }
 
 // RSVPResponse represents the response body for RSVP operations.
 // Note: UserID is intentionally omitted to protect user privacy.
 type RSVPResponse struct {
-	EventID   string     `json:"event_id"`
-	Status    string     `json:"status"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	EventID          string     `json:"event_id"`
+	Status           string     `json:"status"`                      // "waitlisted" when a going RSVP was made to an event at capacity
+	Created          bool       `json:"created"`                     // True if this request created the RSVP, false if it updated one
+	WaitlistPosition int        `json:"waitlist_position,omitempty"` // 1-based; set only while waitlisted
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// My RSVPs pagination defaults
+const (
+	DefaultMyRSVPsLimit = 50
+	MaxMyRSVPsLimit     = 100
+)
+
+// MyRSVP is one of the authenticated user's RSVPs with the event's start time.
+type MyRSVP struct {
+	EventID        string    `json:"event_id"`
+	Status         string    `json:"status"`
+	EventStartsAt  time.Time `json:"event_starts_at"`
+	EventCancelled bool      `json:"event_cancelled,omitempty"`
+}
+
+// MyRSVPsResponse represents the response for GET /me/rsvps.
+type MyRSVPsResponse struct {
+	RSVPs      []MyRSVP `json:"rsvps"`
+	NextOffset *int     `json:"next_offset,omitempty"`
 }
 
 // RSVPHandlers holds dependencies for RSVP HTTP handlers.
 type RSVPHandlers struct {
 	rsvpRepo  scene.RSVPRepository
 	eventRepo scene.EventRepository
+	blockRepo scene.BlockRepository // Optional: rejects RSVPs from DIDs blocked in the event's scene
+	auditRepo audit.Repository      // Optional: records waitlist promotions
 }
 
 // NewRSVPHandlers creates a new RSVPHandlers instance.
@@ -40,7 +68,21 @@ func NewRSVPHandlers(rsvpRepo scene.RSVPRepository, eventRepo scene.EventReposit
 	}
 }
 
+// SetBlockRepo installs the scene block list consulted before accepting an RSVP.
+func (h *RSVPHandlers) SetBlockRepo(repo scene.BlockRepository) {
+	h.blockRepo = repo
+}
+
+// SetAuditRepo installs the audit log that records waitlist promotions.
+func (h *RSVPHandlers) SetAuditRepo(repo audit.Repository) {
+	h.auditRepo = repo
+}
+
 // CreateOrUpdateRSVP handles POST /events/{id}/rsvp - creates or updates an RSVP.
+// Responds 201 Created when a new RSVP is created and 200 OK when an existing
+// one is updated; the response's created field mirrors the status code.
+// Once an event's going RSVPs reach its capacity, further going RSVPs are
+// recorded as waitlisted and the response carries the waitlist position.
 func (h *RSVPHandlers) CreateOrUpdateRSVP(w http.ResponseWriter, r *http.Request) {
 	// Extract event ID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
@@ -59,11 +101,14 @@ func (h *RSVPHandlers) CreateOrUpdateRSVP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Validate status
+	// Validate status; clients that predate maybe and not_going send none
 	status := strings.TrimSpace(req.Status)
-	if status != "going" && status != "maybe" {
+	if status == "" {
+		status = scene.RSVPStatusGoing
+	}
+	if status != scene.RSVPStatusGoing && status != scene.RSVPStatusMaybe && status != scene.RSVPStatusNotGoing {
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "status must be 'going' or 'maybe'")
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "status must be 'going', 'maybe' or 'not_going'")
 		return
 	}
 
@@ -88,6 +133,17 @@ func (h *RSVPHandlers) CreateOrUpdateRSVP(w http.ResponseWriter, r *http.Request
 		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve event")
 		return
 	}
+	// Events awaiting approval aren't public yet
+	if !existingEvent.IsPublished() {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Event not found")
+		return
+	}
+
+	// Blocked DIDs may not RSVP to the scene's events
+	if !checkSceneBlock(r.Context(), w, h.blockRepo, existingEvent.SceneID, userDID) {
+		return
+	}
 
 	// Validate event is strictly upcoming (starts_at > now)
 	// Business rule: RSVPs are only allowed for events that haven't started yet
@@ -105,7 +161,8 @@ func (h *RSVPHandlers) CreateOrUpdateRSVP(w http.ResponseWriter, r *http.Request
 		Status:  status,
 	}
 
-	if err := h.rsvpRepo.Upsert(rsvp); err != nil {
+	created, err := h.rsvpRepo.UpsertWithCapacity(rsvp, existingEvent.Capacity)
+	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to upsert RSVP", "error", err, "event_id", eventID)
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
 		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to save RSVP")
@@ -121,24 +178,45 @@ func (h *RSVPHandlers) CreateOrUpdateRSVP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Switching away from going may have freed a place
+	if status != scene.RSVPStatusGoing {
+		promoteWaitlist(r.Context(), h.rsvpRepo, h.auditRepo, existingEvent)
+	}
+
 	// Create response without exposing user_id (privacy requirement)
 	response := RSVPResponse{
 		EventID:   stored.EventID,
 		Status:    stored.Status,
+		Created:   created,
 		CreatedAt: stored.CreatedAt,
 		UpdatedAt: stored.UpdatedAt,
 	}
+	if stored.Status == scene.RSVPStatusWaitlisted {
+		position, err := h.rsvpRepo.WaitlistPosition(eventID, userDID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to get waitlist position", "error", err, "event_id", eventID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVP")
+			return
+		}
+		response.WaitlistPosition = position
+	}
 
 	// Return created/updated RSVP
+	code := http.StatusOK
+	if created {
+		code = http.StatusCreated
+	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(code)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		// Log error but response already started
 		slog.ErrorContext(r.Context(), "failed to encode RSVP response", "error", err)
 	}
 }
 
-// DeleteRSVP handles DELETE /events/{id}/rsvp - removes an RSVP.
+// DeleteRSVP handles DELETE /events/{id}/rsvp - removes an RSVP. A freed place
+// at an event with a waitlist goes to the oldest waitlisted RSVP.
 func (h *RSVPHandlers) DeleteRSVP(w http.ResponseWriter, r *http.Request) {
 	// Extract event ID from URL path
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/events/"), "/")
@@ -193,6 +271,149 @@ func (h *RSVPHandlers) DeleteRSVP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	promoteWaitlist(r.Context(), h.rsvpRepo, h.auditRepo, existingEvent)
+
 	// Return success with no content
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// ListMyRSVPs handles GET /me/rsvps - lists the authenticated user's RSVPs,
+// ordered by event start time. Filter with status=going|maybe|not_going|waitlisted. RSVPs to
+// cancelled or deleted events are omitted unless include_cancelled=true.
+func (h *RSVPHandlers) ListMyRSVPs(w http.ResponseWriter, r *http.Request) {
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	query := r.URL.Query()
+	status := strings.TrimSpace(query.Get("status"))
+	if status != "" && status != "going" && status != "maybe" && status != scene.RSVPStatusNotGoing && status != scene.RSVPStatusWaitlisted {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "status must be 'going', 'maybe', 'not_going' or 'waitlisted'")
+		return
+	}
+	includeCancelled := false
+	if raw := query.Get("include_cancelled"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "include_cancelled must be a boolean")
+			return
+		}
+		includeCancelled = parsed
+	}
+	limit := DefaultMyRSVPsLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "limit must be a positive integer")
+			return
+		}
+		if parsedLimit > MaxMyRSVPsLimit {
+			parsedLimit = MaxMyRSVPsLimit
+		}
+		limit = parsedLimit
+	}
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err != nil || parsedOffset < 0 {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "offset must be a non-negative integer")
+			return
+		}
+		offset = parsedOffset
+	}
+
+	rsvps, err := h.rsvpRepo.ListByUser(userDID, status)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list RSVPs", "error", err, "user_did", userDID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVPs")
+		return
+	}
+
+	results := make([]MyRSVP, 0, len(rsvps))
+	for _, rsvp := range rsvps {
+		event, err := h.eventRepo.GetByID(rsvp.EventID)
+		if err != nil {
+			if err == scene.ErrEventNotFound {
+				continue
+			}
+			slog.ErrorContext(r.Context(), "failed to get event", "error", err, "event_id", rsvp.EventID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve RSVPs")
+			return
+		}
+		cancelled := event.DeletedAt != nil || event.IsCancelled()
+		if cancelled && !includeCancelled {
+			continue
+		}
+		results = append(results, MyRSVP{
+			EventID:        rsvp.EventID,
+			Status:         rsvp.Status,
+			EventStartsAt:  event.StartsAt,
+			EventCancelled: cancelled,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if !results[i].EventStartsAt.Equal(results[j].EventStartsAt) {
+			return results[i].EventStartsAt.Before(results[j].EventStartsAt)
+		}
+		return results[i].EventID < results[j].EventID
+	})
+
+	response := MyRSVPsResponse{RSVPs: []MyRSVP{}}
+	if offset < len(results) {
+		page := results[offset:]
+		if len(page) > limit {
+			page = page[:limit]
+			nextOffset := offset + limit
+			response.NextOffset = &nextOffset
+		}
+		response.RSVPs = page
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode RSVPs response", "error", err)
+	}
+}
+
+// eventCapacity validates a requested event capacity, mapping 0 to no limit.
+// The second return value is false for a negative capacity.
+func eventCapacity(capacity *int) (*int, bool) {
+	if capacity == nil || *capacity == 0 {
+		return nil, true
+	}
+	if *capacity < 0 {
+		return nil, false
+	}
+	return capacity, true
+}
+
+// promoteWaitlist fills any free places at event from its waitlist, oldest
+// first, recording an rsvp_promoted audit entry for each promotion. Failures
+// are logged rather than returned, since the change that freed the place has
+// already been saved; the next change retries the promotion.
+func promoteWaitlist(ctx context.Context, rsvpRepo scene.RSVPRepository, auditRepo audit.Repository, event *scene.Event) {
+	promoted, err := rsvpRepo.PromoteWaitlisted(event.ID, event.Capacity)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to promote waitlisted RSVPs", "error", err, "event_id", event.ID)
+		return
+	}
+	for _, rsvp := range promoted {
+		slog.InfoContext(ctx, "promoted waitlisted RSVP", "event_id", event.ID)
+		if auditRepo == nil {
+			continue
+		}
+		if err := audit.LogSystemAction(ctx, auditRepo, audit.SystemActorWaitlist, "rsvp", rsvp.EventID+":"+rsvp.UserID, "rsvp_promoted"); err != nil {
+			slog.ErrorContext(ctx, "failed to log RSVP promotion", "error", err, "event_id", event.ID)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
@@ -45,9 +46,9 @@ func TestCreateOrUpdateRSVP_Success(t *testing.T) {
 	// Call handler
 	handlers.CreateOrUpdateRSVP(w, req)
 
-	// Verify response
-	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	// Verify response reports a newly created RSVP
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d: %s", w.Code, w.Body.String())
 	}
 
 	// Verify response doesn't contain user_id (privacy requirement)
@@ -55,6 +56,9 @@ func TestCreateOrUpdateRSVP_Success(t *testing.T) {
 	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
 		t.Fatalf("Failed to unmarshal response: %v", err)
 	}
+	if !response.Created {
+		t.Error("Expected response created to be true for a first RSVP")
+	}
 	if response.Status != "going" {
 		t.Errorf("Expected response status 'going', got %s", response.Status)
 	}
@@ -100,7 +104,7 @@ func TestCreateOrUpdateRSVP_UpdateStatus(t *testing.T) {
 		UserID:  "did:plc:user1",
 		Status:  "maybe",
 	}
-	if err := rsvpRepo.Upsert(initialRSVP); err != nil {
+	if _, err := rsvpRepo.Upsert(initialRSVP); err != nil {
 		t.Fatalf("Failed to create initial RSVP: %v", err)
 	}
 
@@ -116,10 +120,17 @@ func TestCreateOrUpdateRSVP_UpdateStatus(t *testing.T) {
 	w := httptest.NewRecorder()
 	handlers.CreateOrUpdateRSVP(w, req)
 
-	// Verify response
+	// Verify response reports an update
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status 200, got %d: %s", w.Code, w.Body.String())
 	}
+	var response RSVPResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Created {
+		t.Error("Expected response created to be false for a status change")
+	}
 
 	// Verify status was updated
 	stored, err := rsvpRepo.GetByEventAndUser("event-1", "did:plc:user1")
@@ -163,8 +174,8 @@ func TestCreateOrUpdateRSVP_Idempotent(t *testing.T) {
 	handlers.CreateOrUpdateRSVP(w1, req1)
 
 	// Verify first response
-	if w1.Code != http.StatusOK {
-		t.Errorf("Expected status 200 on first RSVP, got %d: %s", w1.Code, w1.Body.String())
+	if w1.Code != http.StatusCreated {
+		t.Errorf("Expected status 201 on first RSVP, got %d: %s", w1.Code, w1.Body.String())
 	}
 
 	// Second request with same status (idempotent test)
@@ -177,7 +188,7 @@ func TestCreateOrUpdateRSVP_Idempotent(t *testing.T) {
 	w2 := httptest.NewRecorder()
 	handlers.CreateOrUpdateRSVP(w2, req2)
 
-	// Verify second response is also 200 (idempotent)
+	// Verify second response is 200 (idempotent update, not a second create)
 	if w2.Code != http.StatusOK {
 		t.Errorf("Expected status 200 on duplicate RSVP (idempotent), got %d: %s", w2.Code, w2.Body.String())
 	}
@@ -283,6 +294,35 @@ func TestCreateOrUpdateRSVP_EventNotFound(t *testing.T) {
 	}
 }
 
+func TestCreateOrUpdateRSVP_UnpublishedEvent(t *testing.T) {
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+
+	event := &scene.Event{
+		ID:            "event-pending",
+		SceneID:       "scene-1",
+		Title:         "Proposed Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+		Status:        scene.EventStatusPendingApproval,
+	}
+	if err := eventRepo.Insert(event); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	body, _ := json.Marshal(RSVPRequest{Status: "going"})
+	req := httptest.NewRequest("POST", "/events/event-pending/rsvp", bytes.NewReader(body))
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:user1"))
+	w := httptest.NewRecorder()
+	handlers.CreateOrUpdateRSVP(w, req)
+
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeNotFound)
+	if _, err := rsvpRepo.GetByEventAndUser("event-pending", "did:plc:user1"); err == nil {
+		t.Error("expected no RSVP to be recorded on an unpublished event")
+	}
+}
+
 func TestCreateOrUpdateRSVP_Unauthenticated(t *testing.T) {
 	rsvpRepo := scene.NewInMemoryRSVPRepository()
 	eventRepo := scene.NewInMemoryEventRepository()
@@ -327,7 +367,7 @@ func TestDeleteRSVP_Success(t *testing.T) {
 		UserID:  "did:plc:user1",
 		Status:  "going",
 	}
-	if err := rsvpRepo.Upsert(rsvp); err != nil {
+	if _, err := rsvpRepo.Upsert(rsvp); err != nil {
 		t.Fatalf("Failed to create RSVP: %v", err)
 	}
 
@@ -407,7 +447,7 @@ func TestDeleteRSVP_PastEvent(t *testing.T) {
 		UserID:  "did:plc:user1",
 		Status:  "going",
 	}
-	if err := rsvpRepo.Upsert(rsvp); err != nil {
+	if _, err := rsvpRepo.Upsert(rsvp); err != nil {
 		t.Fatalf("Failed to create RSVP: %v", err)
 	}
 
@@ -441,3 +481,218 @@ func TestDeleteRSVP_Unauthenticated(t *testing.T) {
 		t.Errorf("Expected status 401, got %d", w.Code)
 	}
 }
+
+// newMyRSVPsTest creates RSVP handlers where did:plc:user1 has RSVPed to three
+// upcoming events (one cancelled) and did:plc:user2 to one of them.
+func newMyRSVPsTest(t *testing.T) *RSVPHandlers {
+	t.Helper()
+
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	now := time.Now()
+	for i, id := range []string{"event-soon", "event-later", "event-cancelled"} {
+		if err := eventRepo.Insert(&scene.Event{
+			ID:            id,
+			SceneID:       "scene-1",
+			Title:         "Test Event",
+			CoarseGeohash: "dr5regw",
+			StartsAt:      now.Add(time.Duration(i+1) * 24 * time.Hour),
+		}); err != nil {
+			t.Fatalf("Failed to insert event: %v", err)
+		}
+	}
+	if err := eventRepo.Cancel("event-cancelled", nil); err != nil {
+		t.Fatalf("Failed to cancel event: %v", err)
+	}
+
+	rsvps := []*scene.RSVP{
+		{EventID: "event-later", UserID: "did:plc:user1", Status: "maybe"},
+		{EventID: "event-soon", UserID: "did:plc:user1", Status: "going"},
+		{EventID: "event-cancelled", UserID: "did:plc:user1", Status: "going"},
+		{EventID: "event-soon", UserID: "did:plc:user2", Status: "going"},
+	}
+	for _, rsvp := range rsvps {
+		if _, err := rsvpRepo.Upsert(rsvp); err != nil {
+			t.Fatalf("Failed to upsert RSVP: %v", err)
+		}
+	}
+	return NewRSVPHandlers(rsvpRepo, eventRepo)
+}
+
+func listMyRSVPs(t *testing.T, handlers *RSVPHandlers, query string) MyRSVPsResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/me/rsvps"+query, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:user1"))
+	w := httptest.NewRecorder()
+
+	handlers.ListMyRSVPs(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response MyRSVPsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	return response
+}
+
+func myRSVPEventIDs(response MyRSVPsResponse) []string {
+	ids := make([]string, len(response.RSVPs))
+	for i, rsvp := range response.RSVPs {
+		ids[i] = rsvp.EventID
+	}
+	return ids
+}
+
+func TestListMyRSVPs(t *testing.T) {
+	handlers := newMyRSVPsTest(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "excludes cancelled events by default", want: []string{"event-soon", "event-later"}},
+		{name: "filter by going", query: "?status=going", want: []string{"event-soon"}},
+		{name: "filter by maybe", query: "?status=maybe", want: []string{"event-later"}},
+		{name: "include cancelled", query: "?include_cancelled=true", want: []string{"event-soon", "event-later", "event-cancelled"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := listMyRSVPs(t, handlers, tt.query)
+			if got := myRSVPEventIDs(response); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("event IDs = %v, want %v", got, tt.want)
+			}
+			for _, rsvp := range response.RSVPs {
+				if rsvp.EventStartsAt.IsZero() {
+					t.Errorf("expected event_starts_at for %s", rsvp.EventID)
+				}
+				if rsvp.EventCancelled != (rsvp.EventID == "event-cancelled") {
+					t.Errorf("event_cancelled = %v for %s", rsvp.EventCancelled, rsvp.EventID)
+				}
+			}
+		})
+	}
+}
+
+func TestListMyRSVPs_Pagination(t *testing.T) {
+	handlers := newMyRSVPsTest(t)
+
+	first := listMyRSVPs(t, handlers, "?limit=1")
+	if first.NextOffset == nil || *first.NextOffset != 1 {
+		t.Fatalf("Expected next_offset 1, got %v", first.NextOffset)
+	}
+	second := listMyRSVPs(t, handlers, "?limit=1&offset=1")
+	if second.NextOffset != nil {
+		t.Errorf("Expected last page, got next_offset %d", *second.NextOffset)
+	}
+
+	if got, want := append(myRSVPEventIDs(first), myRSVPEventIDs(second)...), []string{"event-soon", "event-later"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("paged event IDs = %v, want %v", got, want)
+	}
+}
+
+func TestListMyRSVPs_InvalidParams(t *testing.T) {
+	handlers := newMyRSVPsTest(t)
+
+	for _, query := range []string{"?status=interested", "?include_cancelled=maybe", "?limit=0", "?offset=-1"} {
+		req := httptest.NewRequest(http.MethodGet, "/me/rsvps"+query, nil)
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:user1"))
+		w := httptest.NewRecorder()
+
+		handlers.ListMyRSVPs(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestListMyRSVPs_Unauthenticated(t *testing.T) {
+	handlers := newMyRSVPsTest(t)
+	w := httptest.NewRecorder()
+
+	handlers.ListMyRSVPs(w, httptest.NewRequest(http.MethodGet, "/me/rsvps", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+}
+
+func TestCreateOrUpdateRSVP_StatusTransitions(t *testing.T) {
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+
+	event := &scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Test Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}
+	if err := eventRepo.Insert(event); err != nil {
+		t.Fatalf("Failed to insert event: %v", err)
+	}
+
+	sendRSVP := func(body string) (int, RSVPResponse) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/events/event-1/rsvp", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:user1"))
+		w := httptest.NewRecorder()
+		handlers.CreateOrUpdateRSVP(w, req)
+
+		var response RSVPResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return w.Code, response
+	}
+
+	// A missing status defaults to going for older clients
+	code, response := sendRSVP(`{}`)
+	if code != http.StatusCreated || response.Status != "going" {
+		t.Fatalf("Expected 201 going for a missing status, got %d %q", code, response.Status)
+	}
+
+	transitions := []string{"maybe", "not_going", "going", "not_going", "maybe", "going", "going"}
+	for _, status := range transitions {
+		code, response := sendRSVP(`{"status":"` + status + `"}`)
+		if code != http.StatusOK {
+			t.Fatalf("Expected 200 when changing to %s, got %d", status, code)
+		}
+		if response.Status != status || response.Created {
+			t.Errorf("Expected an in-place update to %s, got %+v", status, response)
+		}
+
+		counts, err := rsvpRepo.CountByStatus("event-1")
+		if err != nil {
+			t.Fatalf("CountByStatus failed: %v", err)
+		}
+		if !reflect.DeepEqual(counts, map[string]int{status: 1}) {
+			t.Errorf("Expected a single %s RSVP, got %v", status, counts)
+		}
+	}
+}
+
+func TestCreateOrUpdateRSVP_RejectsUnknownStatus(t *testing.T) {
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+
+	// waitlisted is assigned by the server, never chosen
+	for _, status := range []string{"invalid", "GOING", "not going", "waitlisted"} {
+		body, _ := json.Marshal(RSVPRequest{Status: status})
+		req := httptest.NewRequest("POST", "/events/event-1/rsvp", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:user1"))
+		w := httptest.NewRecorder()
+
+		handlers.CreateOrUpdateRSVP(w, req)
+
+		assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+	}
+}
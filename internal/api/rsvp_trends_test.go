@@ -0,0 +1,197 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newRSVPTrendsTest creates event handlers over scene-trends, owned by
+// did:plc:owner, with events in the weeks of 2 and 9 March 2026, an older
+// event in February, and a cancelled event, each with RSVPs.
+func newRSVPTrendsTest(t *testing.T) *EventHandlers {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	if err := sceneRepo.Insert(&scene.Scene{ID: "scene-trends", Name: "Trends Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	eventRepo := scene.NewInMemoryEventRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	for _, ev := range []struct {
+		id       string
+		startsAt time.Time
+		status   string
+		going    int
+		maybe    int
+	}{
+		{"event-tuesday", time.Date(2026, 3, 3, 20, 0, 0, 0, time.UTC), "", 2, 1},
+		{"event-friday", time.Date(2026, 3, 6, 21, 0, 0, 0, time.UTC), "", 1, 1},
+		{"event-next-week", time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC), "", 1, 0},
+		{"event-february", time.Date(2026, 2, 10, 20, 0, 0, 0, time.UTC), "", 4, 3},
+		{"event-cancelled", time.Date(2026, 3, 4, 20, 0, 0, 0, time.UTC), "cancelled", 5, 5},
+	} {
+		if err := eventRepo.Insert(&scene.Event{
+			ID:            ev.id,
+			SceneID:       "scene-trends",
+			Title:         "Test Event",
+			CoarseGeohash: "dr5regw",
+			StartsAt:      ev.startsAt,
+			Status:        ev.status,
+		}); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+		for i := 0; i < ev.going+ev.maybe; i++ {
+			status := "going"
+			if i >= ev.going {
+				status = "maybe"
+			}
+			userID := "did:plc:fan" + string(rune('a'+i))
+			if _, err := rsvpRepo.Upsert(&scene.RSVP{EventID: ev.id, UserID: userID, Status: status}); err != nil {
+				t.Fatalf("failed to insert rsvp: %v", err)
+			}
+		}
+	}
+
+	return NewEventHandlers(eventRepo, sceneRepo, audit.NewInMemoryRepository(), rsvpRepo, stream.NewInMemorySessionRepository(), nil)
+}
+
+func getRSVPTrends(handlers *EventHandlers, userDID, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/scenes/scene-trends/rsvp_trends?"+query, nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	handlers.GetSceneRSVPTrends(w, req)
+	return w
+}
+
+func decodeRSVPTrends(t *testing.T, w *httptest.ResponseRecorder) RSVPTrendsResponse {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response RSVPTrendsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+func TestGetSceneRSVPTrends_BucketsAcrossEvents(t *testing.T) {
+	handlers := newRSVPTrendsTest(t)
+
+	response := decodeRSVPTrends(t, getRSVPTrends(handlers, "did:plc:owner",
+		"from=2026-03-02T00:00:00Z&to=2026-03-23T00:00:00Z"))
+
+	if response.SceneID != "scene-trends" || response.Bucket != RSVPTrendBucketWeek {
+		t.Errorf("scene_id = %q, bucket = %q; want scene-trends, week", response.SceneID, response.Bucket)
+	}
+	// Tuesday and Friday share a week; the cancelled event is not counted; the
+	// last week has no events but is still reported
+	want := []RSVPTrendBucket{
+		{Start: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Events: 2, Going: 3, Maybe: 2},
+		{Start: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), Events: 1, Going: 1, Maybe: 0},
+		{Start: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), Events: 0, Going: 0, Maybe: 0},
+	}
+	if !reflect.DeepEqual(response.Buckets, want) {
+		t.Errorf("buckets = %+v, want %+v", response.Buckets, want)
+	}
+}
+
+func TestGetSceneRSVPTrends_DayAndMonthBuckets(t *testing.T) {
+	handlers := newRSVPTrendsTest(t)
+
+	days := decodeRSVPTrends(t, getRSVPTrends(handlers, "did:plc:owner",
+		"from=2026-03-03T00:00:00Z&to=2026-03-05T00:00:00Z&bucket=day"))
+	wantDays := []RSVPTrendBucket{
+		{Start: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), Events: 1, Going: 2, Maybe: 1},
+		{Start: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(days.Buckets, wantDays) {
+		t.Errorf("day buckets = %+v, want %+v", days.Buckets, wantDays)
+	}
+
+	months := decodeRSVPTrends(t, getRSVPTrends(handlers, "did:plc:owner",
+		"from=2026-02-01T00:00:00Z&to=2026-04-01T00:00:00Z&bucket=month"))
+	wantMonths := []RSVPTrendBucket{
+		{Start: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Events: 1, Going: 4, Maybe: 3},
+		{Start: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Events: 3, Going: 4, Maybe: 2},
+	}
+	if !reflect.DeepEqual(months.Buckets, wantMonths) {
+		t.Errorf("month buckets = %+v, want %+v", months.Buckets, wantMonths)
+	}
+}
+
+func TestGetSceneRSVPTrends_WindowExcludesOlderEvents(t *testing.T) {
+	handlers := newRSVPTrendsTest(t)
+
+	// The February event's RSVPs would dominate if it leaked into the window
+	response := decodeRSVPTrends(t, getRSVPTrends(handlers, "did:plc:owner",
+		"from=2026-03-01T00:00:00Z&to=2026-04-01T00:00:00Z&bucket=month"))
+	want := []RSVPTrendBucket{
+		{Start: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Events: 3, Going: 4, Maybe: 2},
+	}
+	if !reflect.DeepEqual(response.Buckets, want) {
+		t.Errorf("buckets = %+v, want %+v", response.Buckets, want)
+	}
+}
+
+func TestGetSceneRSVPTrends_NonOwnerRejected(t *testing.T) {
+	handlers := newRSVPTrendsTest(t)
+
+	w := getRSVPTrends(handlers, "did:plc:stranger", "from=2026-03-02T00:00:00Z&to=2026-03-23T00:00:00Z")
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeForbidden {
+		t.Errorf("error code = %q, want %q", errResp.Error.Code, ErrCodeForbidden)
+	}
+}
+
+func TestGetSceneRSVPTrends_InvalidRequests(t *testing.T) {
+	handlers := newRSVPTrendsTest(t)
+
+	tests := []struct {
+		name       string
+		userDID    string
+		query      string
+		wantStatus int
+		wantCode   string
+	}{
+		{"unauthenticated", "", "from=2026-03-02T00:00:00Z&to=2026-03-23T00:00:00Z", http.StatusUnauthorized, ErrCodeAuthFailed},
+		{"missing to", "did:plc:owner", "from=2026-03-02T00:00:00Z", http.StatusBadRequest, ErrCodeValidation},
+		{"malformed from", "did:plc:owner", "from=yesterday&to=2026-03-23T00:00:00Z", http.StatusBadRequest, ErrCodeValidation},
+		{"from after to", "did:plc:owner", "from=2026-03-23T00:00:00Z&to=2026-03-02T00:00:00Z", http.StatusBadRequest, ErrCodeInvalidTimeRange},
+		{"window too long", "did:plc:owner", "from=2025-01-01T00:00:00Z&to=2026-03-23T00:00:00Z", http.StatusBadRequest, ErrCodeValidation},
+		{"unknown bucket", "did:plc:owner", "from=2026-03-02T00:00:00Z&to=2026-03-23T00:00:00Z&bucket=hour", http.StatusBadRequest, ErrCodeValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getRSVPTrends(handlers, tt.userDID, tt.query)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", errResp.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// insertCappedEvent stores a future event with the given capacity.
+func insertCappedEvent(t *testing.T, eventRepo scene.EventRepository, sceneID string, capacity int) *scene.Event {
+	t.Helper()
+	event := &scene.Event{
+		ID:            "event-1",
+		SceneID:       sceneID,
+		Title:         "Capped Event",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+		Capacity:      &capacity,
+	}
+	if err := eventRepo.Insert(event); err != nil {
+		t.Fatalf("Failed to insert event: %v", err)
+	}
+	return event
+}
+
+// rsvpAs sends an RSVP for event-1 as userDID and decodes the response.
+func rsvpAs(t *testing.T, handlers *RSVPHandlers, userDID, status string) RSVPResponse {
+	t.Helper()
+	body, _ := json.Marshal(RSVPRequest{Status: status})
+	req := httptest.NewRequest("POST", "/events/event-1/rsvp", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+
+	handlers.CreateOrUpdateRSVP(w, req)
+
+	if w.Code != http.StatusCreated && w.Code != http.StatusOK {
+		t.Fatalf("RSVP as %s: expected 200 or 201, got %d: %s", userDID, w.Code, w.Body.String())
+	}
+	var response RSVPResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	return response
+}
+
+// deleteRSVPAs removes userDID's RSVP for event-1.
+func deleteRSVPAs(t *testing.T, handlers *RSVPHandlers, userDID string) {
+	t.Helper()
+	req := httptest.NewRequest("DELETE", "/events/event-1/rsvp", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+
+	handlers.DeleteRSVP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Delete RSVP as %s: expected 204, got %d: %s", userDID, w.Code, w.Body.String())
+	}
+}
+
+func TestCreateOrUpdateRSVP_WaitlistsAtCapacity(t *testing.T) {
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	insertCappedEvent(t, eventRepo, "scene-1", 2)
+
+	for _, userDID := range []string{"did:plc:a1", "did:plc:a2"} {
+		response := rsvpAs(t, handlers, userDID, "going")
+		if response.Status != "going" || response.WaitlistPosition != 0 {
+			t.Errorf("expected %s to be going, got %+v", userDID, response)
+		}
+	}
+
+	for i, userDID := range []string{"did:plc:a3", "did:plc:a4"} {
+		response := rsvpAs(t, handlers, userDID, "going")
+		if response.Status != "waitlisted" {
+			t.Errorf("expected %s to be waitlisted, got %q", userDID, response.Status)
+		}
+		if response.WaitlistPosition != i+1 {
+			t.Errorf("expected %s at waitlist position %d, got %d", userDID, i+1, response.WaitlistPosition)
+		}
+	}
+
+	// Maybe RSVPs don't count towards capacity
+	if response := rsvpAs(t, handlers, "did:plc:m1", "maybe"); response.Status != "maybe" {
+		t.Errorf("expected maybe RSVP to be accepted, got %q", response.Status)
+	}
+
+	counts, _ := rsvpRepo.GetCountsByEvent("event-1")
+	if counts.Going != 2 || counts.Maybe != 1 || counts.Waitlisted != 2 {
+		t.Errorf("expected 2 going, 1 maybe, 2 waitlisted, got %+v", counts)
+	}
+}
+
+func TestDeleteRSVP_PromotesOldestWaitlisted(t *testing.T) {
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	handlers.SetAuditRepo(auditRepo)
+	insertCappedEvent(t, eventRepo, "scene-1", 1)
+
+	for _, userDID := range []string{"did:plc:a1", "did:plc:a2", "did:plc:a3"} {
+		rsvpAs(t, handlers, userDID, "going")
+	}
+
+	deleteRSVPAs(t, handlers, "did:plc:a1")
+
+	promoted, err := rsvpRepo.GetByEventAndUser("event-1", "did:plc:a2")
+	if err != nil {
+		t.Fatalf("GetByEventAndUser failed: %v", err)
+	}
+	if promoted.Status != "going" {
+		t.Errorf("expected oldest waitlisted RSVP to be promoted, got %q", promoted.Status)
+	}
+	position, _ := rsvpRepo.WaitlistPosition("event-1", "did:plc:a3")
+	if position != 1 {
+		t.Errorf("expected did:plc:a3 to move up to position 1, got %d", position)
+	}
+
+	logs, err := auditRepo.QueryByEntity("rsvp", "event-1:did:plc:a2", 0)
+	if err != nil {
+		t.Fatalf("QueryByEntity failed: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Action != "rsvp_promoted" {
+		t.Fatalf("expected one rsvp_promoted audit entry, got %+v", logs)
+	}
+	if logs[0].ActorType != audit.ActorSystem || logs[0].ActorID != audit.SystemActorWaitlist {
+		t.Errorf("expected the waitlist system actor, got %s/%s", logs[0].ActorType, logs[0].ActorID)
+	}
+
+	// Switching from going to maybe frees the place as well
+	rsvpAs(t, handlers, "did:plc:a2", "maybe")
+	promoted, _ = rsvpRepo.GetByEventAndUser("event-1", "did:plc:a3")
+	if promoted.Status != "going" {
+		t.Errorf("expected did:plc:a3 to be promoted after a going RSVP became maybe, got %q", promoted.Status)
+	}
+}
+
+func TestUpdateEvent_Capacity(t *testing.T) {
+	eventRepo := scene.NewInMemoryEventRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	handlers := NewEventHandlers(eventRepo, sceneRepo, auditRepo, rsvpRepo, stream.NewInMemorySessionRepository(), nil)
+	rsvpHandlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:owner")
+	insertCappedEvent(t, eventRepo, "scene-1", 2)
+
+	for _, userDID := range []string{"did:plc:a1", "did:plc:a2", "did:plc:a3", "did:plc:a4"} {
+		rsvpAs(t, rsvpHandlers, userDID, "going")
+	}
+
+	updateCapacity := func(capacity int) *httptest.ResponseRecorder {
+		t.Helper()
+		body, _ := json.Marshal(UpdateEventRequest{Capacity: &capacity})
+		req := httptest.NewRequest("PATCH", "/events/event-1", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:owner"))
+		w := httptest.NewRecorder()
+		handlers.UpdateEvent(w, req)
+		return w
+	}
+
+	t.Run("lowering keeps confirmed attendees", func(t *testing.T) {
+		if w := updateCapacity(1); w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		counts, _ := rsvpRepo.GetCountsByEvent("event-1")
+		if counts.Going != 2 || counts.Waitlisted != 2 {
+			t.Errorf("expected 2 going and 2 waitlisted, got %+v", counts)
+		}
+
+		// A freed place isn't refilled while still over the lowered capacity
+		deleteRSVPAs(t, rsvpHandlers, "did:plc:a1")
+		counts, _ = rsvpRepo.GetCountsByEvent("event-1")
+		if counts.Going != 1 || counts.Waitlisted != 2 {
+			t.Errorf("expected 1 going and 2 waitlisted, got %+v", counts)
+		}
+	})
+
+	t.Run("raising promotes from the waitlist", func(t *testing.T) {
+		if w := updateCapacity(2); w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		promoted, _ := rsvpRepo.GetByEventAndUser("event-1", "did:plc:a3")
+		if promoted.Status != "going" {
+			t.Errorf("expected did:plc:a3 to be promoted, got %q", promoted.Status)
+		}
+		counts, _ := rsvpRepo.GetCountsByEvent("event-1")
+		if counts.Going != 2 || counts.Waitlisted != 1 {
+			t.Errorf("expected 2 going and 1 waitlisted, got %+v", counts)
+		}
+	})
+
+	t.Run("zero removes the cap", func(t *testing.T) {
+		if w := updateCapacity(0); w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		stored, _ := eventRepo.GetByID("event-1")
+		if stored.Capacity != nil {
+			t.Errorf("expected capacity to be cleared, got %d", *stored.Capacity)
+		}
+		counts, _ := rsvpRepo.GetCountsByEvent("event-1")
+		if counts.Going != 3 || counts.Waitlisted != 0 {
+			t.Errorf("expected the whole waitlist to be promoted, got %+v", counts)
+		}
+	})
+
+	t.Run("negative is rejected", func(t *testing.T) {
+		w := updateCapacity(-1)
+		assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+	})
+}
+
+func TestCreateOrUpdateRSVP_NotGoingFreesPlace(t *testing.T) {
+	rsvpRepo := scene.NewInMemoryRSVPRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	handlers := NewRSVPHandlers(rsvpRepo, eventRepo)
+	insertCappedEvent(t, eventRepo, "scene-1", 1)
+
+	rsvpAs(t, handlers, "did:plc:a1", "going")
+	rsvpAs(t, handlers, "did:plc:a2", "going")
+
+	if response := rsvpAs(t, handlers, "did:plc:a1", "not_going"); response.Status != "not_going" {
+		t.Fatalf("expected not_going, got %q", response.Status)
+	}
+	promoted, _ := rsvpRepo.GetByEventAndUser("event-1", "did:plc:a2")
+	if promoted.Status != "going" {
+		t.Errorf("expected did:plc:a2 to be promoted after did:plc:a1 declined, got %q", promoted.Status)
+	}
+}
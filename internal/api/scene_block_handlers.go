@@ -0,0 +1,246 @@
+// Package api provides HTTP handlers for the Subcults API.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// MaxBlockReasonLength caps the optional reason recorded with a scene block.
+const MaxBlockReasonLength = 500
+
+// BlockRequest represents the request body for POST /scenes/{id}/blocks.
+type BlockRequest struct {
+	DID    string `json:"did"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// SceneBlocksResponse represents the response for GET /scenes/{id}/blocks.
+type SceneBlocksResponse struct {
+	Blocks []*scene.Block `json:"blocks"`
+}
+
+// SceneBlockHandlers holds dependencies for scene block list handlers.
+type SceneBlockHandlers struct {
+	blockRepo scene.BlockRepository
+	sceneRepo scene.SceneRepository
+	auditRepo audit.Repository
+}
+
+// NewSceneBlockHandlers creates a new SceneBlockHandlers instance.
+func NewSceneBlockHandlers(blockRepo scene.BlockRepository, sceneRepo scene.SceneRepository, auditRepo audit.Repository) *SceneBlockHandlers {
+	return &SceneBlockHandlers{
+		blockRepo: blockRepo,
+		sceneRepo: sceneRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// ownedScene resolves the scene ID from the request path and verifies the
+// authenticated user owns it. Writes an error response and returns false otherwise.
+func (h *SceneBlockHandlers) ownedScene(w http.ResponseWriter, r *http.Request) (string, bool) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) < 2 || pathParts[0] == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+		return "", false
+	}
+	sceneID := pathParts[0]
+
+	userDID := middleware.GetUserDID(r.Context())
+	if userDID == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return "", false
+	}
+
+	existingScene, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil {
+		if err == scene.ErrSceneNotFound {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			return "", false
+		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		return "", false
+	}
+
+	if !existingScene.IsOwner(userDID) {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the scene owner can manage blocks")
+		return "", false
+	}
+	return sceneID, true
+}
+
+// ListBlocks handles GET /scenes/{id}/blocks - lists the DIDs blocked in a
+// scene, oldest first. Only the scene owner may view the list.
+func (h *SceneBlockHandlers) ListBlocks(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := h.ownedScene(w, r)
+	if !ok {
+		return
+	}
+
+	blocks, err := h.blockRepo.ListByScene(sceneID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list scene blocks", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to list blocks")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(SceneBlocksResponse{Blocks: blocks}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode scene blocks response", "error", err)
+	}
+}
+
+// BlockUser handles POST /scenes/{id}/blocks - blocks a DID from posting,
+// joining streams, or RSVPing in the scene (scene owner only). Responds 201
+// Created for a new block and 200 OK with the existing block if the DID is
+// already blocked.
+func (h *SceneBlockHandlers) BlockUser(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := h.ownedScene(w, r)
+	if !ok {
+		return
+	}
+
+	var req BlockRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r.Context(), err)
+		return
+	}
+
+	blockedDID := strings.TrimSpace(req.DID)
+	if !strings.HasPrefix(blockedDID, "did:") {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "did must be a valid DID")
+		return
+	}
+	ownerDID := middleware.GetUserDID(r.Context())
+	if blockedDID == ownerDID {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Scene owner cannot be blocked")
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > MaxBlockReasonLength {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "reason must be at most 500 characters")
+		return
+	}
+
+	block := &scene.Block{
+		SceneID:    sceneID,
+		BlockedDID: blockedDID,
+		BlockedBy:  ownerDID,
+		Reason:     reason,
+	}
+	added, err := h.blockRepo.Add(block)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to block user", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to block user")
+		return
+	}
+
+	// Audit log the block so it appears in the scene's moderation log
+	if added && h.auditRepo != nil {
+		if err := audit.LogModerationAction(r.Context(), h.auditRepo, "user", blockedDID, sceneID, audit.ActionModerationBlock, reason); err != nil {
+			slog.WarnContext(r.Context(), "failed to log scene block audit", "error", err, "scene_id", sceneID)
+			// Continue - audit failure should not block the operation
+		}
+	}
+
+	code := http.StatusOK
+	if added {
+		code = http.StatusCreated
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(block); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode scene block response", "error", err)
+	}
+}
+
+// UnblockUser handles DELETE /scenes/{id}/blocks/{did} - removes a DID from
+// the scene's block list (scene owner only).
+func (h *SceneBlockHandlers) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	sceneID, ok := h.ownedScene(w, r)
+	if !ok {
+		return
+	}
+
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) != 3 || pathParts[2] == "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "DID is required")
+		return
+	}
+	blockedDID, err := url.PathUnescape(pathParts[2])
+	if err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid DID in URL")
+		return
+	}
+
+	if err := h.blockRepo.Remove(sceneID, blockedDID); err != nil {
+		if errors.Is(err, scene.ErrBlockNotFound) {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Block not found")
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to unblock user", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to unblock user")
+		return
+	}
+
+	if h.auditRepo != nil {
+		if err := audit.LogModerationAction(r.Context(), h.auditRepo, "user", blockedDID, sceneID, audit.ActionModerationUnblock, ""); err != nil {
+			slog.WarnContext(r.Context(), "failed to log scene unblock audit", "error", err, "scene_id", sceneID)
+			// Continue - audit failure should not block the operation
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkSceneBlock writes a 403 and returns false if userDID is blocked in
+// sceneID. A nil blockRepo or empty sceneID allows the request.
+func checkSceneBlock(ctx context.Context, w http.ResponseWriter, blockRepo scene.BlockRepository, sceneID, userDID string) bool {
+	blocked, err := isSceneBlocked(blockRepo, sceneID, userDID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check scene block", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check permissions")
+		return false
+	}
+	if blocked {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You are blocked from this scene")
+		return false
+	}
+	return true
+}
+
+// isSceneBlocked reports whether userDID is blocked in sceneID. A nil blockRepo
+// or empty sceneID blocks no one.
+func isSceneBlocked(blockRepo scene.BlockRepository, sceneID, userDID string) (bool, error) {
+	if blockRepo == nil || sceneID == "" {
+		return false, nil
+	}
+	return blockRepo.IsBlocked(sceneID, userDID)
+}
@@ -0,0 +1,316 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/livekit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+const (
+	blockOwnerDID   = "did:plc:owner"
+	blockedDID      = "did:plc:troll"
+	unblockedMember = "did:plc:friend"
+)
+
+// blockTestEnv wires the block list into every handler that consults it, with
+// one scene owned by blockOwnerDID, an upcoming event, and a scene stream.
+type blockTestEnv struct {
+	blocks   *SceneBlockHandlers
+	posts    *PostHandlers
+	rsvps    *RSVPHandlers
+	streams  *StreamHandlers
+	livekit  *LiveKitHandlers
+	audit    audit.Repository
+	streamID string
+	roomName string
+}
+
+func newBlockTestEnv(t *testing.T) *blockTestEnv {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	streamRepo := stream.NewInMemorySessionRepository()
+	blockRepo := scene.NewInMemoryBlockRepository()
+	auditRepo := audit.NewInMemoryRepository()
+
+	createTestScene(t, sceneRepo, "scene-1", blockOwnerDID)
+	if err := eventRepo.Insert(&scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-1",
+		Title:         "Warehouse Night",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+	streamID, roomName, err := streamRepo.CreateStreamSession(ptrString("scene-1"), nil, blockOwnerDID)
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	posts := NewPostHandlers(post.NewInMemoryPostRepository(), sceneRepo, membership.NewInMemoryMembershipRepository(), nil)
+	posts.SetBlockRepo(blockRepo, eventRepo)
+	rsvps := NewRSVPHandlers(scene.NewInMemoryRSVPRepository(), eventRepo)
+	rsvps.SetBlockRepo(blockRepo)
+	streams := NewStreamHandlers(streamRepo, nil, nil, sceneRepo, eventRepo, auditRepo, nil, nil, nil)
+	streams.SetBlockRepo(blockRepo)
+	tokenService, err := livekit.NewTokenService("test-api-key", "test-api-secret")
+	if err != nil {
+		t.Fatalf("failed to create token service: %v", err)
+	}
+	livekitHandlers := NewLiveKitHandlers(tokenService, auditRepo)
+	livekitHandlers.SetBlockRepo(blockRepo, streamRepo, eventRepo)
+
+	return &blockTestEnv{
+		blocks:   NewSceneBlockHandlers(blockRepo, sceneRepo, auditRepo),
+		posts:    posts,
+		rsvps:    rsvps,
+		streams:  streams,
+		livekit:  livekitHandlers,
+		audit:    auditRepo,
+		streamID: streamID,
+		roomName: roomName,
+	}
+}
+
+func (e *blockTestEnv) block(userDID string, req BlockRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(req)
+	r := httptest.NewRequest(http.MethodPost, "/scenes/scene-1/blocks", bytes.NewReader(body))
+	r = r.WithContext(middleware.SetUserDID(r.Context(), userDID))
+	w := httptest.NewRecorder()
+	e.blocks.BlockUser(w, r)
+	return w
+}
+
+func (e *blockTestEnv) unblock(userDID, did string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodDelete, "/scenes/scene-1/blocks/"+url.PathEscape(did), nil)
+	r = r.WithContext(middleware.SetUserDID(r.Context(), userDID))
+	w := httptest.NewRecorder()
+	e.blocks.UnblockUser(w, r)
+	return w
+}
+
+func (e *blockTestEnv) list(userDID string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/scenes/scene-1/blocks", nil)
+	r = r.WithContext(middleware.SetUserDID(r.Context(), userDID))
+	w := httptest.NewRecorder()
+	e.blocks.ListBlocks(w, r)
+	return w
+}
+
+func (e *blockTestEnv) createPost(userDID string, req CreatePostRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(req)
+	r := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewReader(body))
+	r = r.WithContext(middleware.SetUserDID(r.Context(), userDID))
+	w := httptest.NewRecorder()
+	e.posts.CreatePost(w, r)
+	return w
+}
+
+func (e *blockTestEnv) rsvp(userDID string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(RSVPRequest{Status: "going"})
+	r := httptest.NewRequest(http.MethodPost, "/events/event-1/rsvp", bytes.NewReader(body))
+	r = r.WithContext(middleware.SetUserDID(r.Context(), userDID))
+	w := httptest.NewRecorder()
+	e.rsvps.CreateOrUpdateRSVP(w, r)
+	return w
+}
+
+func (e *blockTestEnv) cancelRSVP(userDID string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodDelete, "/events/event-1/rsvp", nil)
+	r = r.WithContext(middleware.SetUserDID(r.Context(), userDID))
+	w := httptest.NewRecorder()
+	e.rsvps.DeleteRSVP(w, r)
+	return w
+}
+
+func (e *blockTestEnv) updatePost(userDID, postID, text string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(UpdatePostRequest{Text: &text})
+	r := httptest.NewRequest(http.MethodPatch, "/posts/"+postID, bytes.NewReader(body))
+	r = r.WithContext(middleware.SetUserDID(r.Context(), userDID))
+	w := httptest.NewRecorder()
+	e.posts.UpdatePost(w, r)
+	return w
+}
+
+func (e *blockTestEnv) issueToken(userDID string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(LiveKitTokenRequest{RoomID: e.roomName})
+	r := httptest.NewRequest(http.MethodPost, "/livekit/token", bytes.NewReader(body))
+	r = r.WithContext(middleware.SetUserDID(r.Context(), userDID))
+	w := httptest.NewRecorder()
+	e.livekit.IssueToken(w, r)
+	return w
+}
+
+func (e *blockTestEnv) mustBlock(t *testing.T, did string) {
+	t.Helper()
+	if w := e.block(blockOwnerDID, BlockRequest{DID: did, Reason: "harassment"}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201 blocking %s, got %d: %s", did, w.Code, w.Body.String())
+	}
+}
+
+func assertForbidden(t *testing.T, action string, w *httptest.ResponseRecorder) {
+	t.Helper()
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("%s: expected status 403, got %d: %s", action, w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("%s: failed to decode error response: %v", action, err)
+	}
+	if errResp.Error.Code != ErrCodeForbidden {
+		t.Errorf("%s: expected error code %s, got %s", action, ErrCodeForbidden, errResp.Error.Code)
+	}
+}
+
+func TestSceneBlock_BlockedDIDRejected(t *testing.T) {
+	env := newBlockTestEnv(t)
+	env.mustBlock(t, blockedDID)
+
+	assertForbidden(t, "scene post", env.createPost(blockedDID, CreatePostRequest{SceneID: strPtr("scene-1"), Text: "hello"}))
+	assertForbidden(t, "event post", env.createPost(blockedDID, CreatePostRequest{EventID: strPtr("event-1"), Text: "hello"}))
+	assertForbidden(t, "stream join", joinStreamAs(env.streams, env.streamID, blockedDID))
+	assertForbidden(t, "livekit token", env.issueToken(blockedDID))
+	assertForbidden(t, "rsvp", env.rsvp(blockedDID))
+}
+
+func TestSceneBlock_BlockedDIDCanWithdrawButNotEdit(t *testing.T) {
+	env := newBlockTestEnv(t)
+
+	postID := createdPostID(t, env.createPost(blockedDID, CreatePostRequest{SceneID: strPtr("scene-1"), Text: "hello"}))
+	if w := env.rsvp(blockedDID); w.Code != http.StatusCreated {
+		t.Fatalf("rsvp: expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	env.mustBlock(t, blockedDID)
+
+	assertForbidden(t, "post edit", env.updatePost(blockedDID, postID, "edited"))
+
+	// Withdrawing an RSVP only takes the DID out of the scene
+	if w := env.cancelRSVP(blockedDID); w.Code != http.StatusNoContent {
+		t.Errorf("rsvp withdrawal: expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSceneBlock_UnblockedDIDProceeds(t *testing.T) {
+	env := newBlockTestEnv(t)
+	env.mustBlock(t, blockedDID)
+
+	if w := env.createPost(unblockedMember, CreatePostRequest{SceneID: strPtr("scene-1"), Text: "hello"}); w.Code != http.StatusCreated {
+		t.Errorf("post: expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := joinStreamAs(env.streams, env.streamID, unblockedMember); w.Code != http.StatusOK {
+		t.Errorf("stream join: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := env.issueToken(unblockedMember); w.Code != http.StatusOK {
+		t.Errorf("livekit token: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := env.rsvp(unblockedMember); w.Code != http.StatusCreated {
+		t.Errorf("rsvp: expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Lifting the block lets the DID back in
+	if w := env.unblock(blockOwnerDID, blockedDID); w.Code != http.StatusNoContent {
+		t.Fatalf("unblock: expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := env.createPost(blockedDID, CreatePostRequest{SceneID: strPtr("scene-1"), Text: "sorry"}); w.Code != http.StatusCreated {
+		t.Errorf("post after unblock: expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := joinStreamAs(env.streams, env.streamID, blockedDID); w.Code != http.StatusOK {
+		t.Errorf("stream join after unblock: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSceneBlock_OwnerOnlyManagement(t *testing.T) {
+	env := newBlockTestEnv(t)
+
+	assertForbidden(t, "non-owner block", env.block(unblockedMember, BlockRequest{DID: blockedDID}))
+	env.mustBlock(t, blockedDID)
+	assertForbidden(t, "non-owner list", env.list(unblockedMember))
+	assertForbidden(t, "non-owner unblock", env.unblock(unblockedMember, blockedDID))
+	assertForbidden(t, "blocked user unblocks self", env.unblock(blockedDID, blockedDID))
+
+	// The owner sees the block, which survived the attempts above
+	w := env.list(blockOwnerDID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("list: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SceneBlocksResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Blocks) != 1 || resp.Blocks[0].BlockedDID != blockedDID || resp.Blocks[0].BlockedBy != blockOwnerDID || resp.Blocks[0].Reason != "harassment" {
+		t.Errorf("unexpected blocks: %+v", resp.Blocks)
+	}
+
+	// Blocking again is idempotent
+	if w := env.block(blockOwnerDID, BlockRequest{DID: blockedDID}); w.Code != http.StatusOK {
+		t.Errorf("repeat block: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := env.unblock(blockOwnerDID, blockedDID); w.Code != http.StatusNoContent {
+		t.Fatalf("unblock: expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := env.unblock(blockOwnerDID, blockedDID); w.Code != http.StatusNotFound {
+		t.Errorf("repeat unblock: expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSceneBlock_AuditLogged(t *testing.T) {
+	env := newBlockTestEnv(t)
+	env.mustBlock(t, blockedDID)
+	env.block(blockOwnerDID, BlockRequest{DID: blockedDID}) // Already blocked: not logged again
+	env.unblock(blockOwnerDID, blockedDID)
+
+	logs, err := env.audit.QueryBySceneActions("scene-1", audit.ModerationActions, 0, 0)
+	if err != nil {
+		t.Fatalf("QueryBySceneActions() error = %v", err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(logs))
+	}
+	actions := map[string]*audit.AuditLog{}
+	for _, log := range logs {
+		actions[log.Action] = log
+	}
+	blockLog := actions[audit.ActionModerationBlock]
+	if blockLog == nil || blockLog.EntityID != blockedDID || blockLog.UserDID != blockOwnerDID || blockLog.Reason != "harassment" {
+		t.Errorf("unexpected block audit entry: %+v", blockLog)
+	}
+	if actions[audit.ActionModerationUnblock] == nil {
+		t.Error("expected an unblock audit entry")
+	}
+}
+
+func TestSceneBlock_Validation(t *testing.T) {
+	env := newBlockTestEnv(t)
+
+	tests := []struct {
+		name string
+		req  BlockRequest
+	}{
+		{name: "missing DID", req: BlockRequest{}},
+		{name: "not a DID", req: BlockRequest{DID: "troll.example.com"}},
+		{name: "scene owner", req: BlockRequest{DID: blockOwnerDID}},
+		{name: "reason too long", req: BlockRequest{DID: blockedDID, Reason: string(bytes.Repeat([]byte("x"), MaxBlockReasonLength+1))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := env.block(blockOwnerDID, tt.req); w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
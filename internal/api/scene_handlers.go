@@ -2,16 +2,25 @@
 package api
 
 import (
-	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/audit"
 	"github.com/onnwee/subcults/internal/color"
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/identity"
+	"github.com/onnwee/subcults/internal/jsontime"
 	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
@@ -23,27 +32,35 @@ import (
 
 // CreateSceneRequest represents the request body for creating a scene.
 type CreateSceneRequest struct {
-	Name          string         `json:"name"`
-	Description   string         `json:"description,omitempty"`
-	OwnerDID      string         `json:"owner_did"`
-	AllowPrecise  bool           `json:"allow_precise"`
-	PrecisePoint  *scene.Point   `json:"precise_point,omitempty"`
-	CoarseGeohash string         `json:"coarse_geohash"`
-	Tags          []string       `json:"tags,omitempty"`
-	Visibility    string         `json:"visibility,omitempty"`
-	Palette       *scene.Palette `json:"palette,omitempty"`
+	Name                  string         `json:"name"`
+	Description           string         `json:"description,omitempty"`
+	OwnerDID              string         `json:"owner_did"`
+	AllowPrecise          bool           `json:"allow_precise"`
+	PrecisePoint          *scene.Point   `json:"precise_point,omitempty"`
+	CoarseGeohash         string         `json:"coarse_geohash"`
+	Tags                  []string       `json:"tags,omitempty"`
+	Visibility            string         `json:"visibility,omitempty"`
+	ContentRating         string         `json:"content_rating,omitempty"`
+	FeedSort              string         `json:"feed_sort,omitempty"`
+	Palette               *scene.Palette `json:"palette,omitempty"`
+	EventApprovalRequired bool           `json:"event_approval_required,omitempty"`
+	WelcomePostTemplate   string         `json:"welcome_post_template,omitempty"`
 }
 
 // UpdateSceneRequest represents the request body for updating a scene.
 // Only includes mutable fields (owner is immutable).
 type UpdateSceneRequest struct {
-	Name         *string        `json:"name,omitempty"`
-	Description  *string        `json:"description,omitempty"`
-	Tags         []string       `json:"tags,omitempty"`
-	Visibility   *string        `json:"visibility,omitempty"`
-	Palette      *scene.Palette `json:"palette,omitempty"`
-	AllowPrecise *bool          `json:"allow_precise,omitempty"`
-	PrecisePoint *scene.Point   `json:"precise_point,omitempty"`
+	Name                  *string        `json:"name,omitempty"`
+	Description           *string        `json:"description,omitempty"`
+	Tags                  []string       `json:"tags,omitempty"`
+	Visibility            *string        `json:"visibility,omitempty"`
+	ContentRating         *string        `json:"content_rating,omitempty"`
+	FeedSort              *string        `json:"feed_sort,omitempty"`
+	Palette               *scene.Palette `json:"palette,omitempty"`
+	AllowPrecise          *bool          `json:"allow_precise,omitempty"`
+	PrecisePoint          *scene.Point   `json:"precise_point,omitempty"`
+	EventApprovalRequired *bool          `json:"event_approval_required,omitempty"`
+	WelcomePostTemplate   *string        `json:"welcome_post_template,omitempty"`
 }
 
 // UpdateScenePaletteRequest represents the request body for updating scene palette.
@@ -56,6 +73,11 @@ type SceneHandlers struct {
 	repo           scene.SceneRepository
 	membershipRepo membership.MembershipRepository
 	streamRepo     stream.SessionRepository
+	creationGate   *identity.Gate        // Optional account criteria for creating scenes
+	auditRepo      audit.Repository      // Optional: records updates with their field changes
+	blockRepo      scene.BlockRepository // Optional: blocked DIDs lose posting permissions
+	maxDescLength  int                   // Longest description accepted, in characters
+	timeNow        func() time.Time      // For testability
 }
 
 // NewSceneHandlers creates a new SceneHandlers instance.
@@ -64,6 +86,33 @@ func NewSceneHandlers(repo scene.SceneRepository, membershipRepo membership.Memb
 		repo:           repo,
 		membershipRepo: membershipRepo,
 		streamRepo:     streamRepo,
+		maxDescLength:  validate.MaxDescriptionLength,
+		timeNow:        time.Now,
+	}
+}
+
+// SetCreationGate installs the account gate checked before a scene is created.
+// Without one, any authenticated or anonymous caller may create scenes.
+func (h *SceneHandlers) SetCreationGate(gate *identity.Gate) {
+	h.creationGate = gate
+}
+
+// SetAuditRepo installs the audit repository that scene updates are recorded to.
+func (h *SceneHandlers) SetAuditRepo(repo audit.Repository) {
+	h.auditRepo = repo
+}
+
+// SetBlockRepo installs the scene block list consulted when reporting a
+// caller's permissions.
+func (h *SceneHandlers) SetBlockRepo(repo scene.BlockRepository) {
+	h.blockRepo = repo
+}
+
+// SetMaxDescriptionLength overrides the longest scene description accepted, in
+// characters. Non-positive values are ignored.
+func (h *SceneHandlers) SetMaxDescriptionLength(n int) {
+	if n > 0 {
+		h.maxDescLength = n
 	}
 }
 
@@ -78,6 +127,38 @@ func validateVisibility(visibility string) string {
 	return ""
 }
 
+// validateGeohash validates a geohash field, returning an error message naming
+// the field, or "" if it is valid.
+func validateGeohash(field, geohash string) string {
+	if _, err := geo.DecodeGeohash(geohash); err != nil {
+		return field + " is not a valid geohash: " + err.Error()
+	}
+	return ""
+}
+
+// validatePrecisePoint validates an optional precise_point, returning an error
+// message or "" if it is absent or within coordinate bounds.
+func validatePrecisePoint(point *scene.Point) string {
+	if point == nil {
+		return ""
+	}
+	if err := geo.ValidatePoint(point.Lat, point.Lng); err != nil {
+		return "precise_point " + err.Error()
+	}
+	return ""
+}
+
+// validateFeedSort validates the default feed sort order.
+func validateFeedSort(feedSort string) string {
+	if feedSort == "" {
+		return "" // Empty is OK, will default to "chronological"
+	}
+	if feedSort != scene.FeedSortChronological && feedSort != scene.FeedSortRanked {
+		return "feed_sort must be 'chronological' or 'ranked'"
+	}
+	return ""
+}
+
 // CreateScene handles POST /scenes - creates a new scene.
 func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 	var req CreateSceneRequest
@@ -97,7 +178,7 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 	req.Name = validatedName
 
 	// Validate and sanitize description
-	validatedDesc, err := validate.Description(req.Description)
+	validatedDesc, err := validate.DescriptionWithMax(req.Description, h.maxDescLength)
 	if err != nil {
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid description: %v", err))
@@ -105,6 +186,15 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 	}
 	req.Description = validatedDesc
 
+	// Validate and sanitize welcome template
+	validatedWelcome, err := validate.DescriptionWithMax(req.WelcomePostTemplate, scene.MaxWelcomeTemplateLength)
+	if err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid welcome_post_template: %v", err))
+		return
+	}
+	req.WelcomePostTemplate = validatedWelcome
+
 	// Validate owner_did
 	if strings.TrimSpace(req.OwnerDID) == "" {
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
@@ -118,6 +208,18 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "coarse_geohash is required")
 		return
 	}
+	if errMsg := validateGeohash("coarse_geohash", req.CoarseGeohash); errMsg != "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInvalidGeohash)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeInvalidGeohash, errMsg)
+		return
+	}
+
+	// Validate precise_point
+	if errMsg := validatePrecisePoint(req.PrecisePoint); errMsg != "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+		return
+	}
 
 	// Validate visibility
 	if errMsg := validateVisibility(req.Visibility); errMsg != "" {
@@ -131,6 +233,48 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 		req.Visibility = "public"
 	}
 
+	// Validate content rating
+	if errMsg := validateContentRating(req.ContentRating); errMsg != "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+		return
+	}
+	if req.ContentRating == "" {
+		req.ContentRating = scene.ContentRatingGeneral
+	}
+
+	// Validate feed sort
+	if errMsg := validateFeedSort(req.FeedSort); errMsg != "" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+		return
+	}
+	if req.FeedSort == "" {
+		req.FeedSort = scene.FeedSortChronological
+	}
+
+	// Enforce account criteria for the authenticated creator
+	if h.creationGate != nil {
+		userDID := middleware.GetUserDID(r.Context())
+		if userDID == "" {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeAuthFailed)
+			WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+			return
+		}
+		if err := h.creationGate.Check(r.Context(), userDID); err != nil {
+			var gateErr *identity.GateError
+			if errors.As(err, &gateErr) {
+				ctx := middleware.SetErrorCode(r.Context(), ErrCodeForbidden)
+				WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Not eligible to create scenes: "+gateErr.Reason)
+				return
+			}
+			slog.ErrorContext(r.Context(), "failed to check scene creation eligibility", "error", err, "user_did", userDID)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to verify account eligibility")
+			return
+		}
+	}
+
 	// Check for duplicate name
 	ctx, endCheckSpan := tracing.StartSpan(r.Context(), "check_duplicate_scene_name")
 	tracing.SetAttributes(ctx,
@@ -162,18 +306,22 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 	// Create scene
 	now := time.Now()
 	newScene := &scene.Scene{
-		ID:            uuid.New().String(),
-		Name:          req.Name,
-		Description:   req.Description,
-		OwnerDID:      req.OwnerDID,
-		AllowPrecise:  req.AllowPrecise,
-		PrecisePoint:  req.PrecisePoint,
-		CoarseGeohash: req.CoarseGeohash,
-		Tags:          sanitizedTags,
-		Visibility:    req.Visibility,
-		Palette:       req.Palette,
-		CreatedAt:     &now,
-		UpdatedAt:     &now,
+		ID:                    uuid.New().String(),
+		Name:                  req.Name,
+		Description:           req.Description,
+		OwnerDID:              req.OwnerDID,
+		AllowPrecise:          req.AllowPrecise,
+		PrecisePoint:          req.PrecisePoint,
+		CoarseGeohash:         req.CoarseGeohash,
+		Tags:                  sanitizedTags,
+		Visibility:            req.Visibility,
+		ContentRating:         req.ContentRating,
+		FeedSort:              req.FeedSort,
+		Palette:               req.Palette,
+		CreatedAt:             &now,
+		UpdatedAt:             &now,
+		EventApprovalRequired: req.EventApprovalRequired,
+		WelcomePostTemplate:   req.WelcomePostTemplate,
 	}
 
 	// Insert into repository (will automatically enforce location consent).
@@ -209,10 +357,12 @@ func (h *SceneHandlers) CreateScene(w http.ResponseWriter, r *http.Request) {
 	tracing.AddEvent(ctx, "scene_created",
 		attribute.String("scene_id", stored.ID))
 
-	// Return created scene
+	// Return created scene with any soft validation warnings
+	response := newSceneResponse(stored)
+	response.Warnings = sceneWarnings(stored)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(stored); err != nil {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		// Log error but response already started
 		return
 	}
@@ -257,7 +407,7 @@ func (h *SceneHandlers) GetScene(w http.ResponseWriter, r *http.Request) {
 	requesterDID := middleware.GetUserDID(r.Context())
 
 	// Check visibility permissions
-	canAccess, err := h.canAccessScene(r.Context(), foundScene, requesterDID)
+	canAccess, err := sceneVisibleTo(h.membershipRepo, foundScene, requesterDID)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
@@ -284,55 +434,131 @@ func (h *SceneHandlers) GetScene(w http.ResponseWriter, r *http.Request) {
 		"visibility", foundScene.Visibility,
 		"requester_did", requesterDID)
 
+	// Mature scenes return only a stub until the viewer opts in
+	if foundScene.IsMature() {
+		w.Header().Add("Vary", MatureContentHeader)
+	}
+	if redactMatureScene(r, foundScene, requesterDID) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(newSceneStubResponse(foundScene)); err != nil {
+			return
+		}
+		return
+	}
+
 	// Return scene (privacy already enforced by repository)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(foundScene); err != nil {
+	if err := json.NewEncoder(w).Encode(newSceneResponse(foundScene)); err != nil {
 		return
 	}
 }
 
-// canAccessScene checks if a user can access a scene based on visibility rules.
-// Returns true if access is allowed, false otherwise.
-func (h *SceneHandlers) canAccessScene(ctx context.Context, s *scene.Scene, requesterDID string) (bool, error) {
-	// Owner always has access
-	if s.IsOwner(requesterDID) {
-		return true, nil
+// GetScenePermissions handles GET /scenes/{id}/permissions - reports what the
+// caller may do in a scene, computed with the same checks the scene, event,
+// post and stream handlers enforce. Callers who cannot see the scene get the
+// same 404 as GET /scenes/{id}.
+func (h *SceneHandlers) GetScenePermissions(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "permissions" {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Scene ID is required")
+		return
 	}
+	sceneID := pathParts[0]
 
-	// Check visibility rules
-	switch s.Visibility {
-	case scene.VisibilityPublic:
-		// Public scenes are accessible to everyone
-		return true, nil
-
-	case scene.VisibilityMembersOnly:
-		// Members-only scenes require active membership
-		if requesterDID == "" {
-			return false, nil
+	foundScene, err := h.repo.GetByID(sceneID)
+	if err != nil {
+		if errors.Is(err, scene.ErrSceneNotFound) || errors.Is(err, scene.ErrSceneDeleted) {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+			return
 		}
+		slog.ErrorContext(r.Context(), "failed to retrieve scene", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scene")
+		return
+	}
 
-		// Check if requester is an active member
-		m, err := h.membershipRepo.GetBySceneAndUser(s.ID, requesterDID)
-		if err != nil {
-			// Not a member or error retrieving membership
-			if err == membership.ErrMembershipNotFound {
-				return false, nil
-			}
-			return false, err
-		}
+	userDID := middleware.GetUserDID(r.Context())
+	canAccess, err := sceneVisibleTo(h.membershipRepo, foundScene, userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to check scene access", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check access permissions")
+		return
+	}
+	if !canAccess {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Scene not found")
+		return
+	}
 
-		// Only active members can access
-		return m.Status == "active", nil
+	response, err := h.scenePermissions(foundScene, userDID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to compute scene permissions", "error", err, "scene_id", sceneID)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check permissions")
+		return
+	}
 
-	case scene.VisibilityHidden:
-		// Hidden scenes only accessible to owner (already checked above)
-		return false, nil
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode scene permissions response", "error", err)
+	}
+}
 
-	default:
-		// Unknown visibility mode - deny access for safety
-		slog.WarnContext(ctx, "unknown visibility mode", "visibility", s.Visibility, "scene_id", s.ID)
-		return false, nil
+// scenePermissions lists the capabilities userDID holds in a scene it can see.
+func (h *SceneHandlers) scenePermissions(s *scene.Scene, userDID string) (PermissionsResponse, error) {
+	response := PermissionsResponse{Capabilities: []string{CapabilityView}}
+	if userDID == "" {
+		return response, nil
+	}
+
+	role, err := sceneRole(h.membershipRepo, s, userDID)
+	if err != nil {
+		return response, err
+	}
+	response.Role = role
+
+	isOwner := s.IsOwner(userDID)
+	if isOwner {
+		response.Capabilities = append(response.Capabilities,
+			CapabilityEdit, CapabilityDelete, CapabilityModerate, CapabilityStream, CapabilityCreateEvent)
+	} else if role != "" && s.EventApprovalRequired {
+		response.Capabilities = append(response.Capabilities, CapabilityProposeEvent)
+	}
+
+	blocked, err := isSceneBlocked(h.blockRepo, s.ID, userDID)
+	if err != nil {
+		return response, err
+	}
+	if !blocked {
+		response.Capabilities = append(response.Capabilities, CapabilityPost)
+		if roleCanAnnounce(s, userDID, role) {
+			response.Capabilities = append(response.Capabilities, CapabilityAnnounce)
+		}
+	}
+	return response, nil
+}
+
+// sceneAuditFields snapshots the owner-editable fields of a scene for diffing
+// in the audit log.
+func sceneAuditFields(s *scene.Scene) map[string]any {
+	return map[string]any{
+		"name":                    s.Name,
+		"description":             s.Description,
+		"tags":                    s.Tags,
+		"visibility":              s.Visibility,
+		"content_rating":          s.ContentRating,
+		"feed_sort":               s.FeedSort,
+		"palette":                 s.Palette,
+		"allow_precise":           s.AllowPrecise,
+		"precise_point":           s.PrecisePoint,
+		"event_approval_required": s.EventApprovalRequired,
+		"welcome_post_template":   s.WelcomePostTemplate,
 	}
 }
 
@@ -384,6 +610,7 @@ func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
 		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You do not have permission to update this scene")
 		return
 	}
+	before := sceneAuditFields(existingScene)
 
 	// Validate and apply updates
 	if req.Name != nil {
@@ -412,7 +639,7 @@ func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.Description != nil {
-		validatedDesc, err := validate.Description(*req.Description)
+		validatedDesc, err := validate.DescriptionWithMax(*req.Description, h.maxDescLength)
 		if err != nil {
 			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid description: %v", err))
@@ -438,6 +665,30 @@ func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
 		existingScene.Visibility = *req.Visibility
 	}
 
+	if req.ContentRating != nil {
+		if errMsg := validateContentRating(*req.ContentRating); errMsg != "" {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+			return
+		}
+		existingScene.ContentRating = *req.ContentRating
+		if existingScene.ContentRating == "" {
+			existingScene.ContentRating = scene.ContentRatingGeneral
+		}
+	}
+
+	if req.FeedSort != nil {
+		if errMsg := validateFeedSort(*req.FeedSort); errMsg != "" {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+			return
+		}
+		existingScene.FeedSort = *req.FeedSort
+		if existingScene.FeedSort == "" {
+			existingScene.FeedSort = scene.FeedSortChronological
+		}
+	}
+
 	if req.Palette != nil {
 		existingScene.Palette = req.Palette
 	}
@@ -446,7 +697,26 @@ func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
 		existingScene.AllowPrecise = *req.AllowPrecise
 	}
 
+	if req.EventApprovalRequired != nil {
+		existingScene.EventApprovalRequired = *req.EventApprovalRequired
+	}
+
+	if req.WelcomePostTemplate != nil {
+		validatedWelcome, err := validate.DescriptionWithMax(*req.WelcomePostTemplate, scene.MaxWelcomeTemplateLength)
+		if err != nil {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("Invalid welcome_post_template: %v", err))
+			return
+		}
+		existingScene.WelcomePostTemplate = validatedWelcome
+	}
+
 	if req.PrecisePoint != nil {
+		if errMsg := validatePrecisePoint(req.PrecisePoint); errMsg != "" {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, errMsg)
+			return
+		}
 		existingScene.PrecisePoint = req.PrecisePoint
 	}
 
@@ -475,10 +745,20 @@ func (h *SceneHandlers) UpdateScene(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Return updated scene
+	if h.auditRepo != nil {
+		changes := audit.Diff(before, sceneAuditFields(updated))
+		if err := audit.LogUpdateFromRequest(r, h.auditRepo, "scene", sceneID, "scene_update", changes); err != nil {
+			slog.ErrorContext(r.Context(), "failed to log scene update", "error", err, "scene_id", sceneID)
+			// Don't fail the request, but log the error
+		}
+	}
+
+	// Return updated scene with any soft validation warnings
+	response := newSceneResponse(updated)
+	response.Warnings = sceneWarnings(updated)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(updated); err != nil {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		return
 	}
 }
@@ -662,7 +942,7 @@ func (h *SceneHandlers) UpdateScenePalette(w http.ResponseWriter, r *http.Reques
 	// Return updated scene
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(existingScene); err != nil {
+	if err := json.NewEncoder(w).Encode(newSceneResponse(existingScene)); err != nil {
 		return
 	}
 }
@@ -678,22 +958,66 @@ func formatRatio(ratio float64) string {
 	return formatted
 }
 
+// SceneResponse is the JSON representation of a scene. Its timestamps shadow
+// the embedded scene's so they serialize as RFC3339 UTC with zero values omitted,
+// and Tags shadows the scene's so it is always an array.
+type SceneResponse struct {
+	*scene.Scene
+	Tags                []string      `json:"tags"`
+	AccountOnboardedAt  jsontime.Time `json:"account_onboarded_at,omitzero"`
+	ModerationTimestamp jsontime.Time `json:"moderation_timestamp,omitzero"`
+	CreatedAt           jsontime.Time `json:"created_at,omitzero"`
+	UpdatedAt           jsontime.Time `json:"updated_at,omitzero"`
+	DeletedAt           jsontime.Time `json:"deleted_at,omitzero"`
+	Warnings            []Warning     `json:"warnings,omitempty"` // Soft validation findings on create/update
+}
+
+// newSceneResponse builds the JSON representation of s.
+func newSceneResponse(s *scene.Scene) SceneResponse {
+	return SceneResponse{
+		Scene:               s,
+		Tags:                emptyIfNil(s.Tags),
+		AccountOnboardedAt:  jsontime.FromPtr(s.AccountOnboardedAt),
+		ModerationTimestamp: jsontime.FromPtr(s.ModerationTimestamp),
+		CreatedAt:           jsontime.FromPtr(s.CreatedAt),
+		UpdatedAt:           jsontime.FromPtr(s.UpdatedAt),
+		DeletedAt:           jsontime.FromPtr(s.DeletedAt),
+	}
+}
+
 // OwnedSceneSummary represents a summary of a scene owned by the user.
 // Used for the dashboard endpoint to provide key metrics without heavy fields.
 type OwnedSceneSummary struct {
-	ID              string     `json:"id"`
-	Name            string     `json:"name"`
-	Description     string     `json:"description,omitempty"`
-	CoarseGeohash   string     `json:"coarse_geohash"`
-	Tags            []string   `json:"tags,omitempty"`
-	Visibility      string     `json:"visibility"`
-	CreatedAt       *time.Time `json:"created_at,omitempty"`
-	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
-	MembersCount    int        `json:"members_count"`
-	HasActiveStream bool       `json:"has_active_stream"`
+	ID              string        `json:"id"`
+	Name            string        `json:"name"`
+	Description     string        `json:"description,omitempty"`
+	CoarseGeohash   string        `json:"coarse_geohash"`
+	Tags            []string      `json:"tags"`
+	Visibility      string        `json:"visibility"`
+	CreatedAt       jsontime.Time `json:"created_at,omitzero"`
+	UpdatedAt       jsontime.Time `json:"updated_at,omitzero"`
+	DeletedAt       jsontime.Time `json:"deleted_at,omitzero"`
+	MembersCount    int           `json:"members_count"`
+	HasActiveStream bool          `json:"has_active_stream"`
 }
 
-// ListOwnedScenes handles GET /scenes/owned - lists all scenes owned by the authenticated user.
+// ownerScopeFromRequest builds the owner scope for a "list mine" request. The
+// scope is always the authenticated user, so include_deleted=true can only ever
+// reveal the caller's own soft-deleted entities.
+func ownerScopeFromRequest(r *http.Request, userDID string) (scene.OwnerScope, error) {
+	scope := scene.OwnerScope{DID: userDID}
+	if raw := r.URL.Query().Get("include_deleted"); raw != "" {
+		includeDeleted, err := strconv.ParseBool(raw)
+		if err != nil {
+			return scope, fmt.Errorf("include_deleted must be a boolean")
+		}
+		scope.IncludeDeleted = includeDeleted
+	}
+	return scope, nil
+}
+
+// ListOwnedScenes handles GET /scenes/owned and GET /me/scenes - lists all scenes owned
+// by the authenticated user. Pass include_deleted=true to include the user's soft-deleted scenes.
 func (h *SceneHandlers) ListOwnedScenes(w http.ResponseWriter, r *http.Request) {
 	// Get authenticated user DID
 	userDID := middleware.GetUserDID(r.Context())
@@ -703,8 +1027,15 @@ func (h *SceneHandlers) ListOwnedScenes(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	scope, err := ownerScopeFromRequest(r, userDID)
+	if err != nil {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
 	// Get all scenes owned by user
-	scenes, err := h.repo.ListByOwner(userDID)
+	scenes, err := h.repo.ListByOwnerScope(scope)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to list owned scenes", "error", err, "user_did", userDID)
 		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
@@ -754,10 +1085,11 @@ func (h *SceneHandlers) ListOwnedScenes(w http.ResponseWriter, r *http.Request)
 			Name:            sc.Name,
 			Description:     sc.Description,
 			CoarseGeohash:   sc.CoarseGeohash,
-			Tags:            sc.Tags,
+			Tags:            emptyIfNil(sc.Tags),
 			Visibility:      sc.Visibility,
-			CreatedAt:       sc.CreatedAt,
-			UpdatedAt:       sc.UpdatedAt,
+			CreatedAt:       jsontime.FromPtr(sc.CreatedAt),
+			UpdatedAt:       jsontime.FromPtr(sc.UpdatedAt),
+			DeletedAt:       jsontime.FromPtr(sc.DeletedAt),
 			MembersCount:    membershipCounts[sc.ID], // Defaults to 0 if not in map
 			HasActiveStream: activeStreams[sc.ID],    // Defaults to false if not in map
 		}
@@ -772,3 +1104,174 @@ func (h *SceneHandlers) ListOwnedScenes(w http.ResponseWriter, r *http.Request)
 		return
 	}
 }
+
+// Spotlight rotation parameters.
+const (
+	// SpotlightBucket is how long a spotlight selection stays fixed before rotating.
+	SpotlightBucket = time.Hour
+
+	// DefaultSpotlightLimit is the number of scenes returned when no limit is given.
+	DefaultSpotlightLimit = 6
+
+	// MaxSpotlightLimit caps the limit query parameter.
+	MaxSpotlightLimit = 20
+
+	// spotlightStreamBonus is the extra weight a scene with a live stream gets.
+	spotlightStreamBonus = 10
+)
+
+// SpotlightScene is a public scene featured in the discovery spotlight.
+type SpotlightScene struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description,omitempty"`
+	CoarseGeohash   string   `json:"coarse_geohash"`
+	Tags            []string `json:"tags"`
+	MembersCount    int      `json:"members_count"`
+	HasActiveStream bool     `json:"has_active_stream"`
+	ContentRating   string   `json:"content_rating,omitempty"`
+	ContentRedacted bool     `json:"content_redacted,omitempty"` // Mature scene without opt-in; only id and rating are returned
+}
+
+// SpotlightResponse is the response for GET /scenes/spotlight.
+type SpotlightResponse struct {
+	Scenes    []SpotlightScene `json:"scenes"`
+	RotatesAt jsontime.Time    `json:"rotates_at"`
+}
+
+// GetSpotlight handles GET /scenes/spotlight - returns a rotating sample of
+// public scenes for discovery. The sample is seeded by the current time bucket,
+// so every caller sees the same scenes until the bucket rotates, and scenes with
+// more active members or a live stream are more likely to be picked. Mature
+// scenes are reduced to stubs until the viewer opts in.
+func (h *SceneHandlers) GetSpotlight(w http.ResponseWriter, r *http.Request) {
+	limit := DefaultSpotlightLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > MaxSpotlightLimit {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("limit must be between 1 and %d", MaxSpotlightLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	bucketStart := h.timeNow().UTC().Truncate(SpotlightBucket)
+
+	scenes, err := h.repo.ListPublic()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to list public scenes", "error", err)
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve scenes")
+		return
+	}
+
+	response := SpotlightResponse{
+		Scenes:    []SpotlightScene{},
+		RotatesAt: jsontime.New(bucketStart.Add(SpotlightBucket)),
+	}
+
+	if len(scenes) > 0 {
+		sceneIDs := make([]string, len(scenes))
+		for i, sc := range scenes {
+			sceneIDs[i] = sc.ID
+		}
+
+		// Batch query for membership counts (avoids N+1 query problem)
+		membershipCounts, err := h.membershipRepo.CountByScenes(sceneIDs, "active")
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to count memberships", "error", err)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve membership counts")
+			return
+		}
+
+		// Batch query for active streams (avoids N+1 query problem)
+		activeStreams, err := h.streamRepo.HasActiveStreamsForScenes(sceneIDs)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check active streams", "error", err)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to check active streams")
+			return
+		}
+
+		requesterDID := middleware.GetUserDID(r.Context())
+		hasMature := false
+		for _, sc := range selectSpotlight(scenes, membershipCounts, activeStreams, bucketStart, limit) {
+			if sc.IsMature() {
+				hasMature = true
+			}
+			if redactMatureScene(r, sc, requesterDID) {
+				response.Scenes = append(response.Scenes, SpotlightScene{
+					ID:              sc.ID,
+					Tags:            []string{},
+					ContentRating:   sc.ContentRating,
+					ContentRedacted: true,
+				})
+				continue
+			}
+			response.Scenes = append(response.Scenes, SpotlightScene{
+				ID:              sc.ID,
+				Name:            sc.Name,
+				Description:     sc.Description,
+				CoarseGeohash:   sc.CoarseGeohash,
+				Tags:            emptyIfNil(sc.Tags),
+				MembersCount:    membershipCounts[sc.ID],
+				HasActiveStream: activeStreams[sc.ID],
+				ContentRating:   sc.ContentRating,
+			})
+		}
+		if hasMature {
+			w.Header().Add("Vary", MatureContentHeader)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		return
+	}
+}
+
+// selectSpotlight picks up to limit scenes by weighted sampling without
+// replacement (Efraimidis-Spirakis). Each scene's random draw is a hash of the
+// bucket and scene ID, so the result is stable within a bucket and needs no
+// shared state between API instances.
+func selectSpotlight(scenes []*scene.Scene, membershipCounts map[string]int, activeStreams map[string]bool, bucketStart time.Time, limit int) []*scene.Scene {
+	type candidate struct {
+		scene *scene.Scene
+		key   float64
+	}
+
+	seed := strconv.FormatInt(bucketStart.Unix(), 10)
+	candidates := make([]candidate, 0, len(scenes))
+	for _, sc := range scenes {
+		weight := float64(1 + membershipCounts[sc.ID])
+		if activeStreams[sc.ID] {
+			weight += spotlightStreamBonus
+		}
+
+		// Map the hash to a uniform draw in (0, 1); ln(u)/w is largest for the
+		// scenes that should be picked first.
+		sum := sha256.Sum256([]byte(seed + ":" + sc.ID))
+		u := (float64(binary.BigEndian.Uint64(sum[:8])>>11) + 0.5) / (1 << 53)
+		candidates = append(candidates, candidate{scene: sc, key: math.Log(u) / weight})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].key != candidates[j].key {
+			return candidates[i].key > candidates[j].key
+		}
+		return candidates[i].scene.ID < candidates[j].scene.ID
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	selected := make([]*scene.Scene, len(candidates))
+	for i, c := range candidates {
+		selected[i] = c.scene
+	}
+	return selected
+}
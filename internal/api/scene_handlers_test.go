@@ -3,12 +3,14 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/onnwee/subcults/internal/identity"
 	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/scene"
@@ -385,6 +387,101 @@ func TestCreateScene_MissingRequiredFields(t *testing.T) {
 	}
 }
 
+// TestCreateScene_InvalidGeohash tests rejection of malformed coarse geohashes.
+func TestCreateScene_InvalidGeohash(t *testing.T) {
+	for _, geohash := range []string{"dr5rai", "dr5regw3pg6ss"} {
+		t.Run(geohash, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			membershipRepo := membership.NewInMemoryMembershipRepository()
+			streamRepo := stream.NewInMemorySessionRepository()
+			handlers := NewSceneHandlers(repo, membershipRepo, streamRepo)
+
+			body, _ := json.Marshal(CreateSceneRequest{
+				Name:          "Test Scene",
+				OwnerDID:      "did:plc:test123",
+				CoarseGeohash: geohash,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handlers.CreateScene(w, req)
+
+			assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeInvalidGeohash)
+		})
+	}
+}
+
+// TestCreateScene_InvalidPrecisePoint tests that precise points off the globe
+// are rejected.
+func TestCreateScene_InvalidPrecisePoint(t *testing.T) {
+	for _, point := range []scene.Point{{Lat: 91, Lng: 0}, {Lat: 0, Lng: -180.5}, {Lat: 1e308, Lng: 1e308}} {
+		t.Run(fmt.Sprintf("%v,%v", point.Lat, point.Lng), func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+			body, _ := json.Marshal(CreateSceneRequest{
+				Name:          "Test Scene",
+				OwnerDID:      "did:plc:test123",
+				CoarseGeohash: "dr5regw",
+				AllowPrecise:  true,
+				PrecisePoint:  &point,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handlers.CreateScene(w, req)
+
+			assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+		})
+	}
+}
+
+func TestCreateScene_WelcomePostTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantCode int
+		want     string
+	}{
+		{name: "trimmed", template: "  Welcome to {scene}, {member}!  ", wantCode: http.StatusCreated, want: "Welcome to {scene}, {member}!"},
+		{name: "html escaped", template: "<b>hi</b> {member}", wantCode: http.StatusCreated, want: "&lt;b&gt;hi&lt;/b&gt; {member}"},
+		{name: "too long", template: strings.Repeat("a", scene.MaxWelcomeTemplateLength+1), wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := scene.NewInMemorySceneRepository()
+			handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+			body, _ := json.Marshal(CreateSceneRequest{
+				Name:                "Test Scene",
+				OwnerDID:            "did:plc:test123",
+				CoarseGeohash:       "dr5regw",
+				WelcomePostTemplate: tt.template,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handlers.CreateScene(w, req)
+
+			if tt.wantCode != http.StatusCreated {
+				assertErrorResponse(t, w, tt.wantCode, ErrCodeValidation)
+				return
+			}
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+			var created scene.Scene
+			if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if created.WelcomePostTemplate != tt.want {
+				t.Errorf("welcome_post_template = %q, want %q", created.WelcomePostTemplate, tt.want)
+			}
+		})
+	}
+}
+
 // TestUpdateScene_Success tests successful scene update.
 func TestUpdateScene_Success(t *testing.T) {
 	repo := scene.NewInMemorySceneRepository()
@@ -453,6 +550,93 @@ func TestUpdateScene_Success(t *testing.T) {
 	}
 }
 
+// TestScene_DescriptionValidation tests description escaping and the configured
+// length cap on create and update.
+func TestScene_DescriptionValidation(t *testing.T) {
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	handlers.SetMaxDescriptionLength(20)
+
+	now := time.Now()
+	if err := repo.Insert(&scene.Scene{
+		ID:            "described-scene",
+		Name:          "Described Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		Visibility:    "public",
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		wantStatus  int
+		wantDesc    string
+	}{
+		{name: "normal description preserved", description: "Basement techno", wantStatus: http.StatusOK, wantDesc: "Basement techno"},
+		{name: "HTML escaped", description: "<i>dub</i> & bass", wantStatus: http.StatusOK, wantDesc: "&lt;i&gt;dub&lt;/i&gt; &amp; bass"},
+		{name: "over-length rejected", description: strings.Repeat("a", 21), wantStatus: http.StatusBadRequest},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantCreate := tt.wantStatus
+			if wantCreate == http.StatusOK {
+				wantCreate = http.StatusCreated
+			}
+
+			body, _ := json.Marshal(CreateSceneRequest{
+				Name:          fmt.Sprintf("New Scene %d", i),
+				Description:   tt.description,
+				OwnerDID:      "did:plc:test123",
+				CoarseGeohash: "dr5regw",
+			})
+			create := httptest.NewRecorder()
+			handlers.CreateScene(create, httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body)))
+
+			description := tt.description
+			body, _ = json.Marshal(UpdateSceneRequest{Description: &description})
+			req := httptest.NewRequest(http.MethodPatch, "/scenes/described-scene", bytes.NewReader(body))
+			req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:test123"))
+			update := httptest.NewRecorder()
+			handlers.UpdateScene(update, req)
+
+			for _, got := range []struct {
+				op         string
+				w          *httptest.ResponseRecorder
+				wantStatus int
+			}{
+				{"create", create, wantCreate},
+				{"update", update, tt.wantStatus},
+			} {
+				if got.w.Code != got.wantStatus {
+					t.Fatalf("%s: expected status %d, got %d: %s", got.op, got.wantStatus, got.w.Code, got.w.Body.String())
+				}
+				if tt.wantStatus != http.StatusOK {
+					var errResp ErrorResponse
+					if err := json.NewDecoder(got.w.Body).Decode(&errResp); err != nil {
+						t.Fatalf("%s: failed to decode error response: %v", got.op, err)
+					}
+					if errResp.Error.Code != ErrCodeValidation {
+						t.Errorf("%s: error code = %q, want %q", got.op, errResp.Error.Code, ErrCodeValidation)
+					}
+					continue
+				}
+				var resp scene.Scene
+				if err := json.NewDecoder(got.w.Body).Decode(&resp); err != nil {
+					t.Fatalf("%s: failed to decode response: %v", got.op, err)
+				}
+				if resp.Description != tt.wantDesc {
+					t.Errorf("%s: description = %q, want %q", got.op, resp.Description, tt.wantDesc)
+				}
+			}
+		})
+	}
+}
+
 // TestUpdateScene_NotFound tests updating a non-existent scene.
 func TestUpdateScene_NotFound(t *testing.T) {
 	repo := scene.NewInMemorySceneRepository()
@@ -2209,3 +2393,245 @@ func TestListOwnedScenes_EmptyList(t *testing.T) {
 		t.Errorf("expected empty list, got %d scenes", len(summaries))
 	}
 }
+
+// TestSceneResponse_Timestamps tests that scene timestamps serialize as RFC3339 UTC
+// and that zero values are omitted rather than emitted as 0001-01-01.
+func TestSceneResponse_Timestamps(t *testing.T) {
+	created := time.Date(2026, 3, 14, 9, 30, 15, 500, time.FixedZone("UTC-5", -5*60*60))
+	sc := &scene.Scene{
+		ID:            "scene-times",
+		Name:          "Times",
+		OwnerDID:      "did:plc:owner",
+		CoarseGeohash: "dr5regw",
+		CreatedAt:     &created,
+		UpdatedAt:     &time.Time{},
+	}
+
+	data, err := json.Marshal(newSceneResponse(sc))
+	if err != nil {
+		t.Fatalf("failed to marshal scene response: %v", err)
+	}
+
+	body := string(data)
+	if strings.Contains(body, "0001-01-01") {
+		t.Errorf("zero timestamp serialized as year 1: %s", body)
+	}
+	if strings.Contains(body, "updated_at") {
+		t.Errorf("expected zero updated_at to be omitted: %s", body)
+	}
+	if !strings.Contains(body, `"created_at":"2026-03-14T14:30:15Z"`) {
+		t.Errorf("expected created_at as RFC3339 UTC: %s", body)
+	}
+	if strings.Count(body, "created_at") != 1 {
+		t.Errorf("expected a single created_at key: %s", body)
+	}
+}
+
+// newSpotlightTest creates scene handlers backed by n public scenes (spot-00 ..)
+// with the clock fixed at the returned pointer's value.
+func newSpotlightTest(t *testing.T, n int) (*SceneHandlers, *scene.InMemorySceneRepository, *time.Time) {
+	t.Helper()
+	repo := scene.NewInMemorySceneRepository()
+	handlers := NewSceneHandlers(repo, membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	for i := 0; i < n; i++ {
+		if err := repo.Insert(&scene.Scene{
+			ID:            fmt.Sprintf("spot-%02d", i),
+			Name:          fmt.Sprintf("Spotlight Scene %d", i),
+			OwnerDID:      "did:plc:owner",
+			CoarseGeohash: "dr5regw",
+			Visibility:    scene.VisibilityPublic,
+		}); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	now := time.Date(2026, 3, 14, 10, 5, 0, 0, time.UTC)
+	handlers.timeNow = func() time.Time { return now }
+	return handlers, repo, &now
+}
+
+// getSpotlightIDs calls GetSpotlight and returns the featured scene IDs in order.
+func getSpotlightIDs(t *testing.T, handlers *SceneHandlers, query string) []string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/scenes/spotlight"+query, nil)
+	w := httptest.NewRecorder()
+	handlers.GetSpotlight(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response SpotlightResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	ids := make([]string, len(response.Scenes))
+	for i, sc := range response.Scenes {
+		ids[i] = sc.ID
+	}
+	return ids
+}
+
+func TestGetSpotlight_DeterministicWithinBucket(t *testing.T) {
+	handlers, _, now := newSpotlightTest(t, 30)
+
+	first := getSpotlightIDs(t, handlers, "")
+	if len(first) != DefaultSpotlightLimit {
+		t.Fatalf("expected %d scenes, got %d", DefaultSpotlightLimit, len(first))
+	}
+
+	// Later in the same hour the selection is unchanged
+	*now = now.Add(50 * time.Minute)
+	second := getSpotlightIDs(t, handlers, "")
+	if strings.Join(first, ",") != strings.Join(second, ",") {
+		t.Errorf("expected same spotlight within a bucket, got %v then %v", first, second)
+	}
+}
+
+func TestGetSpotlight_RotatesAcrossBuckets(t *testing.T) {
+	handlers, _, now := newSpotlightTest(t, 30)
+
+	first := strings.Join(getSpotlightIDs(t, handlers, ""), ",")
+	rotated := 0
+	for i := 0; i < 24; i++ {
+		*now = now.Add(SpotlightBucket)
+		if strings.Join(getSpotlightIDs(t, handlers, ""), ",") != first {
+			rotated++
+		}
+	}
+	if rotated == 0 {
+		t.Error("expected spotlight to rotate across buckets")
+	}
+}
+
+func TestGetSpotlight_ExcludesIneligibleScenes(t *testing.T) {
+	handlers, repo, _ := newSpotlightTest(t, 3)
+
+	for _, sc := range []*scene.Scene{
+		{ID: "private", Name: "Private", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityMembersOnly},
+		{ID: "unlisted", Name: "Unlisted", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityHidden},
+		{ID: "deleted", Name: "Deleted", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+		{ID: "moderated", Name: "Moderated", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic, ModerationStatus: "hidden"},
+	} {
+		if err := repo.Insert(sc); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+	if err := repo.Delete("deleted"); err != nil {
+		t.Fatalf("failed to delete scene: %v", err)
+	}
+
+	ids := getSpotlightIDs(t, handlers, fmt.Sprintf("?limit=%d", MaxSpotlightLimit))
+	if len(ids) != 3 {
+		t.Errorf("expected only the 3 public scenes, got %v", ids)
+	}
+	for _, id := range ids {
+		if !strings.HasPrefix(id, "spot-") {
+			t.Errorf("ineligible scene %q in spotlight", id)
+		}
+	}
+}
+
+func TestGetSpotlight_InvalidLimit(t *testing.T) {
+	handlers, _, _ := newSpotlightTest(t, 1)
+
+	for _, limit := range []string{"0", "abc", fmt.Sprintf("%d", MaxSpotlightLimit+1)} {
+		req := httptest.NewRequest(http.MethodGet, "/scenes/spotlight?limit="+limit, nil)
+		w := httptest.NewRecorder()
+		handlers.GetSpotlight(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("limit=%s: expected status 400, got %d", limit, w.Code)
+		}
+	}
+}
+
+func TestSelectSpotlight_FavorsActiveScenes(t *testing.T) {
+	scenes := make([]*scene.Scene, 10)
+	for i := range scenes {
+		scenes[i] = &scene.Scene{ID: fmt.Sprintf("spot-%02d", i)}
+	}
+	counts := map[string]int{"spot-07": 100}
+
+	picked := 0
+	bucket := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		selected := selectSpotlight(scenes, counts, nil, bucket.Add(time.Duration(i)*SpotlightBucket), 1)
+		if selected[0].ID == "spot-07" {
+			picked++
+		}
+	}
+	if picked < 50 {
+		t.Errorf("expected heavily weighted scene to lead most buckets, led %d of 100", picked)
+	}
+}
+
+// postCreateScene creates a scene owned by userDID, authenticated as userDID.
+func postCreateScene(t *testing.T, handlers *SceneHandlers, userDID string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(CreateSceneRequest{
+		Name:          "Gated Scene",
+		OwnerDID:      userDID,
+		CoarseGeohash: "dr5regw",
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.CreateScene(w, req)
+	return w
+}
+
+// newGatedSceneHandlers creates scene handlers whose creation gate requires a
+// day-old account; did:plc:established is 30 days old and did:plc:fresh an hour.
+func newGatedSceneHandlers(enabled bool) *SceneHandlers {
+	resolver := identity.NewInMemoryResolver()
+	resolver.Add(identity.Account{DID: "did:plc:established", CreatedAt: time.Now().Add(-30 * 24 * time.Hour)})
+	resolver.Add(identity.Account{DID: "did:plc:fresh", CreatedAt: time.Now().Add(-time.Hour)})
+
+	handlers := NewSceneHandlers(scene.NewInMemorySceneRepository(), membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	handlers.SetCreationGate(identity.NewGate(identity.GateConfig{Enabled: enabled, MinAccountAge: 24 * time.Hour}, resolver))
+	return handlers
+}
+
+func TestCreateScene_GateAllowsQualifyingAccount(t *testing.T) {
+	handlers := newGatedSceneHandlers(true)
+
+	if w := postCreateScene(t, handlers, "did:plc:established"); w.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateScene_GateRejectsNewAccount(t *testing.T) {
+	handlers := newGatedSceneHandlers(true)
+
+	for _, did := range []string{"did:plc:fresh", "did:plc:unknown"} {
+		w := postCreateScene(t, handlers, did)
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("%s: expected status 403, got %d: %s", did, w.Code, w.Body.String())
+		}
+
+		var errResp ErrorResponse
+		if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+			t.Fatalf("failed to decode error response: %v", err)
+		}
+		if errResp.Error.Code != ErrCodeForbidden {
+			t.Errorf("%s: expected error code %s, got %s", did, ErrCodeForbidden, errResp.Error.Code)
+		}
+		if !strings.Contains(errResp.Error.Message, "account") {
+			t.Errorf("%s: expected reason in message, got %q", did, errResp.Error.Message)
+		}
+	}
+}
+
+func TestCreateScene_GateDisabledAllowsAll(t *testing.T) {
+	handlers := newGatedSceneHandlers(false)
+
+	for _, did := range []string{"did:plc:fresh", "did:plc:unknown"} {
+		if w := postCreateScene(t, handlers, did); w.Code != http.StatusCreated {
+			t.Errorf("%s: expected status 201, got %d: %s", did, w.Code, w.Body.String())
+		}
+	}
+}
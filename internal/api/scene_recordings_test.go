@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newSceneRecordingsTest creates stream handlers over a public and a
+// members-only scene, both owned by did:plc:owner. The public scene has, newest
+// first: an active scene stream, a recorded event stream, an ended scene
+// stream without a recording, and a recorded scene stream. The members-only
+// scene has one recorded stream. It returns the handlers, the analytics
+// repository, and the public scene's recorded session IDs, newest first.
+func newSceneRecordingsTest(t *testing.T) (*StreamHandlers, *stream.InMemoryAnalyticsRepository, []string) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	for _, sc := range []*scene.Scene{
+		{ID: "scene-public", Name: "Public Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+		{ID: "scene-members", Name: "Members Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityMembersOnly},
+	} {
+		if err := sceneRepo.Insert(sc); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	eventRepo := scene.NewInMemoryEventRepository()
+	if err := eventRepo.Insert(&scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-public",
+		Title:         "Warehouse Night",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(-24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	base := time.Now().Add(-72 * time.Hour)
+	endedAt := base.Add(4 * time.Hour)
+	sessions := []*stream.Session{
+		{SceneID: ptrString("scene-public"), HostDID: "did:plc:host", StartedAt: base, EndedAt: &endedAt, RecordingURL: ptrString("s3://recordings/first.ogg")},
+		{SceneID: ptrString("scene-public"), HostDID: "did:plc:host", StartedAt: base.Add(time.Hour), EndedAt: &endedAt},
+		{EventID: ptrString("event-1"), HostDID: "did:plc:host", StartedAt: base.Add(2 * time.Hour), EndedAt: &endedAt, RecordingURL: ptrString("s3://recordings/event.ogg")},
+		{SceneID: ptrString("scene-public"), HostDID: "did:plc:host", StartedAt: base.Add(3 * time.Hour)},
+		{SceneID: ptrString("scene-members"), HostDID: "did:plc:host", StartedAt: base, EndedAt: &endedAt, RecordingURL: ptrString("s3://recordings/members.ogg")},
+	}
+	for _, s := range sessions {
+		if _, err := streamRepo.Upsert(s); err != nil {
+			t.Fatalf("failed to insert stream session: %v", err)
+		}
+	}
+
+	analyticsRepo := stream.NewInMemoryAnalyticsRepository(streamRepo)
+	handlers := NewStreamHandlers(streamRepo, nil, analyticsRepo, sceneRepo, eventRepo, audit.NewInMemoryRepository(), nil, nil, nil)
+	return handlers, analyticsRepo, []string{sessions[2].ID, sessions[0].ID}
+}
+
+func doListSceneRecordings(h *StreamHandlers, sceneID, query, userDID string) *httptest.ResponseRecorder {
+	target := "/scenes/" + sceneID + "/recordings"
+	if query != "" {
+		target += "?" + query
+	}
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	h.ListSceneRecordings(w, req)
+	return w
+}
+
+func decodeRecordings(t *testing.T, w *httptest.ResponseRecorder) SceneRecordingsResponse {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SceneRecordingsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func recordingIDs(resp SceneRecordingsResponse) []string {
+	ids := make([]string, len(resp.Recordings))
+	for i, rec := range resp.Recordings {
+		ids[i] = rec.StreamSessionID
+	}
+	return ids
+}
+
+func TestListSceneRecordings_ListsRecordedStreams(t *testing.T) {
+	handlers, _, want := newSceneRecordingsTest(t)
+
+	resp := decodeRecordings(t, doListSceneRecordings(handlers, "scene-public", "", "did:plc:viewer"))
+	if got := recordingIDs(resp); !reflect.DeepEqual(got, want) {
+		t.Fatalf("recordings = %v, want %v", got, want)
+	}
+	if resp.NextCursor != "" {
+		t.Errorf("expected no next cursor, got %q", resp.NextCursor)
+	}
+
+	event := resp.Recordings[0]
+	if event.RecordingURL != "s3://recordings/event.ogg" || event.Title != "Warehouse Night" {
+		t.Errorf("unexpected event recording: %+v", event)
+	}
+	if event.DurationSeconds != int((2 * time.Hour).Seconds()) {
+		t.Errorf("expected duration %d, got %d", int((2 * time.Hour).Seconds()), event.DurationSeconds)
+	}
+	if event.PeakConcurrentListeners != nil {
+		t.Errorf("expected no peak before analytics are computed, got %d", *event.PeakConcurrentListeners)
+	}
+}
+
+func TestListSceneRecordings_ReportsAnalytics(t *testing.T) {
+	handlers, analyticsRepo, ids := newSceneRecordingsTest(t)
+	for _, did := range []string{"did:plc:a", "did:plc:b"} {
+		if err := analyticsRepo.RecordParticipantEvent(ids[0], did, "join", nil); err != nil {
+			t.Fatalf("RecordParticipantEvent() error = %v", err)
+		}
+	}
+	computed, err := analyticsRepo.ComputeAnalytics(ids[0])
+	if err != nil {
+		t.Fatalf("ComputeAnalytics() error = %v", err)
+	}
+
+	resp := decodeRecordings(t, doListSceneRecordings(handlers, "scene-public", "", "did:plc:viewer"))
+	rec := resp.Recordings[0]
+	if rec.PeakConcurrentListeners == nil || *rec.PeakConcurrentListeners != 2 {
+		t.Errorf("expected peak of 2 listeners, got %v", rec.PeakConcurrentListeners)
+	}
+	if rec.DurationSeconds != computed.StreamDurationSeconds {
+		t.Errorf("expected duration %d from analytics, got %d", computed.StreamDurationSeconds, rec.DurationSeconds)
+	}
+}
+
+func TestListSceneRecordings_PaginatesPastUnrecordedStreams(t *testing.T) {
+	handlers, _, want := newSceneRecordingsTest(t)
+
+	first := decodeRecordings(t, doListSceneRecordings(handlers, "scene-public", "limit=1", "did:plc:viewer"))
+	if got := recordingIDs(first); !reflect.DeepEqual(got, want[:1]) {
+		t.Fatalf("first page = %v, want %v", got, want[:1])
+	}
+	if first.NextCursor == "" {
+		t.Fatal("expected a next cursor")
+	}
+
+	// The unrecorded stream between the two recordings doesn't leave a short page
+	second := decodeRecordings(t, doListSceneRecordings(handlers, "scene-public", "limit=1&cursor="+url.QueryEscape(first.NextCursor), "did:plc:viewer"))
+	if got := recordingIDs(second); !reflect.DeepEqual(got, want[1:]) {
+		t.Fatalf("second page = %v, want %v", got, want[1:])
+	}
+	if second.NextCursor != "" {
+		t.Errorf("expected no next cursor on the last page, got %q", second.NextCursor)
+	}
+}
+
+func TestListSceneRecordings_PrivateSceneOwnerOnly(t *testing.T) {
+	handlers, _, _ := newSceneRecordingsTest(t)
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	if _, err := membershipRepo.Upsert(&membership.Membership{
+		SceneID:     "scene-members",
+		UserDID:     "did:plc:member",
+		Role:        "member",
+		Status:      "active",
+		TrustWeight: 0.5,
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+	handlers.SetMembershipRepo(membershipRepo)
+
+	owner := decodeRecordings(t, doListSceneRecordings(handlers, "scene-members", "", "did:plc:owner"))
+	if len(owner.Recordings) != 1 || owner.Recordings[0].RecordingURL != "s3://recordings/members.ogg" {
+		t.Errorf("expected the owner to see the members-only recording, got %+v", owner.Recordings)
+	}
+
+	if member := decodeRecordings(t, doListSceneRecordings(handlers, "scene-members", "", "did:plc:member")); len(member.Recordings) != 0 {
+		t.Errorf("member: expected no recordings, got %d", len(member.Recordings))
+	}
+
+	// Non-members can't see the scene at all
+	w := doListSceneRecordings(handlers, "scene-members", "", "did:plc:stranger")
+	assertErrorResponse(t, w, http.StatusNotFound, ErrCodeSceneNotFound)
+}
+
+func TestListSceneRecordings_Validation(t *testing.T) {
+	handlers, _, _ := newSceneRecordingsTest(t)
+
+	assertErrorResponse(t, doListSceneRecordings(handlers, "scene-public", "", ""), http.StatusUnauthorized, ErrCodeAuthFailed)
+	assertErrorResponse(t, doListSceneRecordings(handlers, "scene-public", "limit=0", "did:plc:viewer"), http.StatusBadRequest, ErrCodeValidation)
+	assertErrorResponse(t, doListSceneRecordings(handlers, "scene-public", "cursor=bogus", "did:plc:viewer"), http.StatusBadRequest, ErrCodeValidation)
+	assertErrorResponse(t, doListSceneRecordings(handlers, "missing", "", "did:plc:viewer"), http.StatusNotFound, ErrCodeSceneNotFound)
+}
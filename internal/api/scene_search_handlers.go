@@ -0,0 +1,200 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// Per-type caps for scene-scoped search. A single scene has far less content
+// than the whole platform, so each type gets a larger share than in global search.
+const (
+	maxSceneSearchEvents = 15
+	maxSceneSearchPosts  = 15
+)
+
+// SetMembershipRepo installs the membership repository used to let active
+// members search members-only scenes. Without one, only the owner can search
+// a non-public scene.
+func (h *SearchHandlers) SetMembershipRepo(repo membership.MembershipRepository) {
+	h.membershipRepo = repo
+}
+
+// SearchScene handles GET /scenes/{id}/search?q= - text search restricted to
+// one scene's events and posts. Each type is ranked by its own composite score
+// and the two are merged as in global search. Scenes the requester cannot see
+// are reported as not found.
+func (h *SearchHandlers) SearchScene(w http.ResponseWriter, r *http.Request) {
+	if h.latencySLO != nil {
+		defer h.latencySLO.ObserveSince(time.Now())
+	}
+	ctx := r.Context()
+
+	// Expected: /scenes/{id}/search
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "search" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		return
+	}
+	sceneID := pathParts[0]
+
+	query := r.URL.Query()
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "q parameter is required")
+		return
+	}
+
+	cursorState, err := decodeGlobalSearchCursor(query.Get("cursor"))
+	if err != nil {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "invalid cursor")
+		return
+	}
+
+	s, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil {
+		if errors.Is(err, scene.ErrSceneNotFound) || errors.Is(err, scene.ErrSceneDeleted) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeSceneNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(ctx, "failed to get scene", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to search")
+		return
+	}
+	visible, err := sceneVisibleTo(h.membershipRepo, s, middleware.GetUserDID(ctx))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check scene visibility", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to search")
+		return
+	}
+	if !visible {
+		// Don't reveal that a hidden or members-only scene exists
+		ctx = middleware.SetErrorCode(ctx, ErrCodeSceneNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneNotFound, "Scene not found")
+		return
+	}
+
+	searchNow := time.Now()
+	eventResults, eventNextCursor, err := h.eventRepo.SearchEvents(scene.EventSearchOptions{
+		MinLng:           -180,
+		MinLat:           -90,
+		MaxLng:           180,
+		MaxLat:           90,
+		From:             searchNow.AddDate(-defaultEventPastYearsForGlobalSearch, 0, 0),
+		To:               searchNow.AddDate(defaultEventFutureYearsForGlobalSearch, 0, 0),
+		Query:            q,
+		SceneID:          s.ID,
+		Limit:            maxSceneSearchEvents,
+		Cursor:           cursorState.EventCursor,
+		DisableProximity: true,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to search events for scene search", "error", err, "scene_id", s.ID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to search")
+		return
+	}
+
+	postResults := make([]*post.Post, 0)
+	postNextCursor := ""
+	if h.postRepo != nil {
+		postResults, postNextCursor, err = h.postRepo.SearchPosts(q, &s.ID, maxSceneSearchPosts, cursorState.PostCursor, nil)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to search posts for scene search", "error", err, "scene_id", s.ID)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to search")
+			return
+		}
+	}
+
+	type scoredSceneResult struct {
+		result *GlobalSearchResult
+		score  float64
+		key    string
+	}
+	scored := make([]scoredSceneResult, 0, len(eventResults)+len(postResults))
+	for i, e := range eventResults {
+		createdAt := ""
+		if e.CreatedAt != nil {
+			createdAt = e.CreatedAt.Format(time.RFC3339)
+		}
+		scored = append(scored, scoredSceneResult{
+			result: &GlobalSearchResult{
+				Type: "event",
+				Event: &GlobalEventSearchResult{
+					ID:        e.ID,
+					SceneID:   e.SceneID,
+					Title:     e.Title,
+					StartsAt:  e.StartsAt.Format(time.RFC3339),
+					CreatedAt: createdAt,
+				},
+			},
+			score: globalNormalizedScore(i, len(eventResults)),
+			key:   "event:" + e.ID,
+		})
+	}
+	redactPost := h.newMaturePostRedactor(r)
+	for i, p := range postResults {
+		postResult := &PostSearchResult{
+			ID:        p.ID,
+			Excerpt:   makeExcerpt(p.Text, 160),
+			SceneID:   p.SceneID,
+			CreatedAt: p.CreatedAt.Format(time.RFC3339),
+		}
+		if redactPost(p) {
+			postResult.Excerpt = ""
+			postResult.ContentRedacted = true
+		}
+		scored = append(scored, scoredSceneResult{
+			result: &GlobalSearchResult{Type: "post", Post: postResult},
+			score:  globalNormalizedScore(i, len(postResults)),
+			key:    "post:" + p.ID,
+		})
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].score == scored[j].score {
+			return scored[i].key < scored[j].key
+		}
+		return scored[i].score > scored[j].score
+	})
+
+	results := make([]*GlobalSearchResult, 0, len(scored))
+	for _, item := range scored {
+		results = append(results, item.result)
+	}
+
+	nextCursor := ""
+	if eventNextCursor != "" || postNextCursor != "" {
+		nextCursor = encodeGlobalSearchCursor(globalSearchCursor{
+			EventCursor: eventNextCursor,
+			PostCursor:  postNextCursor,
+		})
+	}
+
+	response := GlobalSearchResponse{
+		Results:    results,
+		NextCursor: nextCursor,
+		Count:      len(results),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode scene search response", "error", err)
+	}
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/post"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// newSceneSearchTest creates two public scenes, each with a matching event and
+// posts, and returns handlers searching them along with the created post IDs
+// keyed by name.
+func newSceneSearchTest(t *testing.T) (*SearchHandlers, *scene.InMemorySceneRepository, *membership.InMemoryMembershipRepository, map[string]string) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	postRepo := post.NewInMemoryPostRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	handlers := NewSearchHandlers(sceneRepo, postRepo, nil, eventRepo)
+	handlers.SetMembershipRepo(membershipRepo)
+
+	for _, s := range []*scene.Scene{
+		{ID: "scene-1", Name: "Warehouse Collective", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+		{ID: "scene-2", Name: "Other Collective", OwnerDID: "did:plc:other", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+	} {
+		if err := sceneRepo.Insert(s); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	startsAt := time.Now().Add(24 * time.Hour)
+	for _, e := range []*scene.Event{
+		{ID: "event-1", SceneID: "scene-1", Title: "Warehouse Rave", StartsAt: startsAt},
+		{ID: "event-2", SceneID: "scene-2", Title: "Warehouse Rave", StartsAt: startsAt},
+	} {
+		e.AllowPrecise = true
+		e.PrecisePoint = &scene.Point{Lat: 40.7, Lng: -74.0}
+		e.CoarseGeohash = "dr5regw"
+		if err := eventRepo.Insert(e); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+
+	postIDs := make(map[string]string)
+	for _, p := range []struct {
+		name, sceneID, text string
+	}{
+		{"post-partial", "scene-1", "rave flyers are up"},
+		{"post-exact", "scene-1", "warehouse rave lineup announced"},
+		{"post-other", "scene-2", "warehouse rave lineup announced"},
+		{"post-unrelated", "scene-1", "gear swap"},
+	} {
+		sceneID := p.sceneID
+		created := &post.Post{SceneID: &sceneID, AuthorDID: "did:plc:author", Text: p.text}
+		if err := postRepo.Create(created); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+		postIDs[p.name] = created.ID
+	}
+
+	return handlers, sceneRepo, membershipRepo, postIDs
+}
+
+func doSearchScene(h *SearchHandlers, path, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if userDID != "" {
+		req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	}
+	w := httptest.NewRecorder()
+	h.SearchScene(w, req)
+	return w
+}
+
+func TestSearchScene_ConfinedToScene(t *testing.T) {
+	handlers, _, _, postIDs := newSceneSearchTest(t)
+
+	w := doSearchScene(handlers, "/scenes/scene-1/search?q=warehouse+rave", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response GlobalSearchResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	var gotEvents, gotPosts []string
+	for _, result := range response.Results {
+		switch result.Type {
+		case "event":
+			if result.Event.SceneID != "scene-1" {
+				t.Errorf("event %s from scene %s leaked into scene-1 search", result.Event.ID, result.Event.SceneID)
+			}
+			gotEvents = append(gotEvents, result.Event.ID)
+		case "post":
+			if result.Post.SceneID == nil || *result.Post.SceneID != "scene-1" {
+				t.Errorf("post %s from another scene leaked into scene-1 search", result.Post.ID)
+			}
+			gotPosts = append(gotPosts, result.Post.ID)
+		default:
+			t.Errorf("unexpected result type %q", result.Type)
+		}
+	}
+
+	if len(gotEvents) != 1 || gotEvents[0] != "event-1" {
+		t.Errorf("events = %v, want [event-1]", gotEvents)
+	}
+	// The exact phrase match outranks the partial match; the unrelated post is excluded
+	if len(gotPosts) != 2 || gotPosts[0] != postIDs["post-exact"] || gotPosts[1] != postIDs["post-partial"] {
+		t.Errorf("posts = %v, want [%s %s]", gotPosts, postIDs["post-exact"], postIDs["post-partial"])
+	}
+	if response.Count != len(response.Results) {
+		t.Errorf("count = %d, want %d", response.Count, len(response.Results))
+	}
+}
+
+func TestSearchScene_Visibility(t *testing.T) {
+	handlers, sceneRepo, membershipRepo, _ := newSceneSearchTest(t)
+
+	private, err := sceneRepo.GetByID("scene-1")
+	if err != nil {
+		t.Fatalf("failed to get scene: %v", err)
+	}
+	private.Visibility = scene.VisibilityMembersOnly
+	if err := sceneRepo.Update(private); err != nil {
+		t.Fatalf("failed to update scene: %v", err)
+	}
+	if _, err := membershipRepo.Upsert(&membership.Membership{
+		SceneID:     "scene-1",
+		UserDID:     "did:plc:member",
+		Role:        "member",
+		Status:      "active",
+		TrustWeight: 0.5,
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		userDID    string
+		wantStatus int
+	}{
+		{name: "anonymous", wantStatus: http.StatusNotFound},
+		{name: "non-member", userDID: "did:plc:stranger", wantStatus: http.StatusNotFound},
+		{name: "active member", userDID: "did:plc:member", wantStatus: http.StatusOK},
+		{name: "owner", userDID: "did:plc:owner", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doSearchScene(handlers, "/scenes/scene-1/search?q=rave", tt.userDID); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSearchScene_Errors(t *testing.T) {
+	handlers, _, _, _ := newSceneSearchTest(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "missing query", path: "/scenes/scene-1/search", wantStatus: http.StatusBadRequest},
+		{name: "invalid cursor", path: "/scenes/scene-1/search?q=rave&cursor=!!!", wantStatus: http.StatusBadRequest},
+		{name: "unknown scene", path: "/scenes/missing/search?q=rave", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doSearchScene(handlers, tt.path, ""); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
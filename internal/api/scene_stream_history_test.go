@@ -0,0 +1,183 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newSceneStreamHistoryTest creates stream handlers over a public and a
+// members-only scene, both owned by did:plc:owner. The public scene has, newest
+// first: an active scene stream, an ended event stream, and an ended scene
+// stream. The members-only scene has one ended stream. It returns the handlers
+// and the public scene's session IDs, newest first.
+func newSceneStreamHistoryTest(t *testing.T) (*StreamHandlers, []string) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	for _, sc := range []*scene.Scene{
+		{ID: "scene-public", Name: "Public Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+		{ID: "scene-members", Name: "Members Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityMembersOnly},
+	} {
+		if err := sceneRepo.Insert(sc); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	eventRepo := scene.NewInMemoryEventRepository()
+	if err := eventRepo.Insert(&scene.Event{
+		ID:            "event-1",
+		SceneID:       "scene-public",
+		Title:         "Warehouse Night",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      time.Now().Add(-24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	base := time.Now().Add(-72 * time.Hour)
+	endedAt := base.Add(4 * time.Hour)
+	sessions := []*stream.Session{
+		{SceneID: ptrString("scene-public"), HostDID: "did:plc:host", StartedAt: base, EndedAt: &endedAt},
+		{EventID: ptrString("event-1"), HostDID: "did:plc:host", StartedAt: base.Add(time.Hour), EndedAt: &endedAt},
+		{SceneID: ptrString("scene-public"), HostDID: "did:plc:host", StartedAt: base.Add(2 * time.Hour)},
+		{SceneID: ptrString("scene-members"), HostDID: "did:plc:host", StartedAt: base, EndedAt: &endedAt},
+	}
+	for _, s := range sessions {
+		if _, err := streamRepo.Upsert(s); err != nil {
+			t.Fatalf("failed to insert stream session: %v", err)
+		}
+	}
+
+	handlers := NewStreamHandlers(streamRepo, nil, nil, sceneRepo, eventRepo, audit.NewInMemoryRepository(), nil, nil, nil)
+	return handlers, []string{sessions[2].ID, sessions[1].ID, sessions[0].ID}
+}
+
+func doListSceneStreamHistory(h *StreamHandlers, sceneID, query, userDID string) *httptest.ResponseRecorder {
+	target := "/scenes/" + sceneID + "/streams/history"
+	if query != "" {
+		target += "?" + query
+	}
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	h.ListSceneStreamHistory(w, req)
+	return w
+}
+
+func TestListSceneStreamHistory_OwnerPaginates(t *testing.T) {
+	handlers, want := newSceneStreamHistoryTest(t)
+
+	var got []string
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		query := "limit=2"
+		if cursor != "" {
+			query += "&cursor=" + url.QueryEscape(cursor)
+		}
+		response := decodeStreamList(t, doListSceneStreamHistory(handlers, "scene-public", query, "did:plc:owner"))
+		got = append(got, streamListIDs(response)...)
+		if response.NextCursor == "" {
+			break
+		}
+		cursor = response.NextCursor
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected streams %v newest first, got %v", want, got)
+	}
+}
+
+func TestListSceneStreamHistory_NonOwnerSeesEndedPublicStreams(t *testing.T) {
+	handlers, ids := newSceneStreamHistoryTest(t)
+
+	response := decodeStreamList(t, doListSceneStreamHistory(handlers, "scene-public", "", "did:plc:viewer"))
+	if got, want := streamListIDs(response), ids[1:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected ended streams %v, got %v", want, got)
+	}
+	for _, item := range response.Streams {
+		if item.Status != stream.StatusEnded {
+			t.Errorf("expected status %q, got %q", stream.StatusEnded, item.Status)
+		}
+	}
+}
+
+func TestListSceneStreamHistory_NonOwnerPagesAreFull(t *testing.T) {
+	handlers, ids := newSceneStreamHistoryTest(t)
+
+	// The active stream at the head of the history doesn't leave a short page
+	first := decodeStreamList(t, doListSceneStreamHistory(handlers, "scene-public", "limit=1", "did:plc:viewer"))
+	if got, want := streamListIDs(first), ids[1:2]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("first page = %v, want %v", got, want)
+	}
+	if first.NextCursor == "" {
+		t.Fatal("expected a next cursor")
+	}
+
+	second := decodeStreamList(t, doListSceneStreamHistory(handlers, "scene-public", "limit=1&cursor="+url.QueryEscape(first.NextCursor), "did:plc:viewer"))
+	if got, want := streamListIDs(second), ids[2:]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("second page = %v, want %v", got, want)
+	}
+	if second.NextCursor != "" {
+		t.Errorf("expected no next cursor on the last page, got %q", second.NextCursor)
+	}
+}
+
+func TestListSceneStreamHistory_NonPublicScene(t *testing.T) {
+	handlers, _ := newSceneStreamHistoryTest(t)
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	if _, err := membershipRepo.Upsert(&membership.Membership{
+		SceneID:     "scene-members",
+		UserDID:     "did:plc:member",
+		Role:        "member",
+		Status:      "active",
+		TrustWeight: 0.5,
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+	handlers.SetMembershipRepo(membershipRepo)
+
+	if response := decodeStreamList(t, doListSceneStreamHistory(handlers, "scene-members", "", "did:plc:owner")); len(response.Streams) != 1 {
+		t.Errorf("owner: expected 1 stream, got %d", len(response.Streams))
+	}
+	if response := decodeStreamList(t, doListSceneStreamHistory(handlers, "scene-members", "", "did:plc:member")); len(response.Streams) != 0 {
+		t.Errorf("member: expected no streams, got %d", len(response.Streams))
+	}
+	if w := doListSceneStreamHistory(handlers, "scene-members", "", "did:plc:stranger"); w.Code != http.StatusNotFound {
+		t.Errorf("stranger: expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestListSceneStreamHistory_Validation(t *testing.T) {
+	handlers, _ := newSceneStreamHistoryTest(t)
+
+	tests := []struct {
+		name       string
+		sceneID    string
+		query      string
+		userDID    string
+		wantStatus int
+	}{
+		{name: "unauthenticated", sceneID: "scene-public", wantStatus: http.StatusUnauthorized},
+		{name: "limit too large", sceneID: "scene-public", query: "limit=101", userDID: "did:plc:owner", wantStatus: http.StatusBadRequest},
+		{name: "malformed cursor", sceneID: "scene-public", query: "cursor=%25%25%25", userDID: "did:plc:owner", wantStatus: http.StatusBadRequest},
+		{name: "unknown scene", sceneID: "scene-missing", userDID: "did:plc:owner", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doListSceneStreamHistory(handlers, tt.sceneID, tt.query, tt.userDID); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
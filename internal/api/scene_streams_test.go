@@ -0,0 +1,211 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newSceneStreamsTest creates stream handlers over a public scene with:
+//   - a live scene-wide stream and a live stream for "event-live"
+//   - upcoming events "event-soon" and "event-later" with no stream yet
+//   - a cancelled upcoming event, an upcoming event awaiting approval and a past
+//     event, none of which is scheduled
+//
+// It returns the handlers and the live session IDs, oldest first.
+func newSceneStreamsTest(t *testing.T) (*StreamHandlers, []string) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	for _, sc := range []*scene.Scene{
+		{ID: "scene-streams", Name: "Streams Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+		{ID: "scene-private", Name: "Private Scene", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityMembersOnly},
+	} {
+		if err := sceneRepo.Insert(sc); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	eventRepo := scene.NewInMemoryEventRepository()
+	now := time.Now()
+	for _, ev := range []struct {
+		id       string
+		startsAt time.Time
+	}{
+		{"event-live", now.Add(-time.Hour)},
+		{"event-later", now.Add(48 * time.Hour)},
+		{"event-soon", now.Add(24 * time.Hour)},
+		{"event-cancelled", now.Add(36 * time.Hour)},
+		{"event-past", now.Add(-48 * time.Hour)},
+	} {
+		if err := eventRepo.Insert(&scene.Event{
+			ID:            ev.id,
+			SceneID:       "scene-streams",
+			Title:         "Title " + ev.id,
+			CoarseGeohash: "dr5regw",
+			StartsAt:      ev.startsAt,
+		}); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+	if err := eventRepo.Cancel("event-cancelled", nil); err != nil {
+		t.Fatalf("failed to cancel event: %v", err)
+	}
+	if err := eventRepo.Insert(&scene.Event{
+		ID:            "event-pending",
+		SceneID:       "scene-streams",
+		Title:         "Title event-pending",
+		CoarseGeohash: "dr5regw",
+		StartsAt:      now.Add(12 * time.Hour),
+		Status:        scene.EventStatusPendingApproval,
+	}); err != nil {
+		t.Fatalf("failed to insert event: %v", err)
+	}
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	sceneID := "scene-streams"
+	sceneStreamID, _, err := streamRepo.CreateStreamSession(&sceneID, nil, "did:plc:host1")
+	if err != nil {
+		t.Fatalf("failed to create stream session: %v", err)
+	}
+	if err := streamRepo.UpdateActiveParticipantCount(sceneStreamID, 12); err != nil {
+		t.Fatalf("failed to update participant count: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	eventID := "event-live"
+	eventStreamID, _, err := streamRepo.CreateStreamSession(nil, &eventID, "did:plc:host2")
+	if err != nil {
+		t.Fatalf("failed to create stream session: %v", err)
+	}
+
+	handlers := NewStreamHandlers(streamRepo, nil, nil, sceneRepo, eventRepo, audit.NewInMemoryRepository(), nil, nil, nil)
+	return handlers, []string{sceneStreamID, eventStreamID}
+}
+
+func doListSceneStreams(h *StreamHandlers, sceneID, state, userDID string) *httptest.ResponseRecorder {
+	url := "/scenes/" + sceneID + "/streams"
+	if state != "" {
+		url += "?state=" + state
+	}
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	h.ListSceneStreams(w, req)
+	return w
+}
+
+func decodeSceneStreams(t *testing.T, w *httptest.ResponseRecorder) []SceneStreamItem {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response SceneStreamsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response.Streams
+}
+
+// sceneStreamKeys identifies each item by its state and stream or event ID.
+func sceneStreamKeys(items []SceneStreamItem) []string {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		if item.State == SceneStreamStateLive {
+			keys[i] = "live:" + item.StreamSessionID
+		} else {
+			keys[i] = "scheduled:" + *item.EventID
+		}
+	}
+	return keys
+}
+
+func TestListSceneStreams_Live(t *testing.T) {
+	handlers, liveIDs := newSceneStreamsTest(t)
+
+	items := decodeSceneStreams(t, doListSceneStreams(handlers, "scene-streams", "live", "did:plc:viewer"))
+	if want := []string{"live:" + liveIDs[0], "live:" + liveIDs[1]}; !reflect.DeepEqual(sceneStreamKeys(items), want) {
+		t.Fatalf("live streams = %v, want %v", sceneStreamKeys(items), want)
+	}
+	if items[0].ParticipantCount != 12 {
+		t.Errorf("participant_count = %d, want 12", items[0].ParticipantCount)
+	}
+	if items[0].StartedAt.IsZero() {
+		t.Error("expected started_at on a live stream")
+	}
+	if items[1].Title != "Title event-live" {
+		t.Errorf("event stream title = %q, want the event's title", items[1].Title)
+	}
+}
+
+func TestListSceneStreams_Scheduled(t *testing.T) {
+	handlers, _ := newSceneStreamsTest(t)
+
+	items := decodeSceneStreams(t, doListSceneStreams(handlers, "scene-streams", "scheduled", "did:plc:viewer"))
+	if want := []string{"scheduled:event-soon", "scheduled:event-later"}; !reflect.DeepEqual(sceneStreamKeys(items), want) {
+		t.Fatalf("scheduled streams = %v, want %v", sceneStreamKeys(items), want)
+	}
+	if items[0].ScheduledStartAt.IsZero() || !items[0].StartedAt.IsZero() {
+		t.Errorf("expected only scheduled_start_at on a scheduled stream, got %+v", items[0])
+	}
+}
+
+func TestListSceneStreams_AllLiveFirst(t *testing.T) {
+	handlers, liveIDs := newSceneStreamsTest(t)
+
+	for _, state := range []string{"all", ""} {
+		items := decodeSceneStreams(t, doListSceneStreams(handlers, "scene-streams", state, "did:plc:viewer"))
+		want := []string{"live:" + liveIDs[0], "live:" + liveIDs[1], "scheduled:event-soon", "scheduled:event-later"}
+		if !reflect.DeepEqual(sceneStreamKeys(items), want) {
+			t.Errorf("state=%q: streams = %v, want %v", state, sceneStreamKeys(items), want)
+		}
+	}
+}
+
+func TestListSceneStreams_PrivateScene(t *testing.T) {
+	handlers, _ := newSceneStreamsTest(t)
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+	if _, err := membershipRepo.Upsert(&membership.Membership{
+		SceneID:     "scene-private",
+		UserDID:     "did:plc:member",
+		Role:        "member",
+		Status:      "active",
+		TrustWeight: 0.5,
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+	handlers.SetMembershipRepo(membershipRepo)
+
+	tests := []struct {
+		name       string
+		userDID    string
+		wantStatus int
+	}{
+		{name: "owner", userDID: "did:plc:owner", wantStatus: http.StatusOK},
+		{name: "active member", userDID: "did:plc:member", wantStatus: http.StatusOK},
+		{name: "stranger", userDID: "did:plc:stranger", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doListSceneStreams(handlers, "scene-private", "", tt.userDID); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestListSceneStreams_InvalidState(t *testing.T) {
+	handlers, _ := newSceneStreamsTest(t)
+
+	if w := doListSceneStreams(handlers, "scene-streams", "ended", "did:plc:viewer"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -4,6 +4,7 @@ package api
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -12,30 +13,43 @@ import (
 	"strings"
 	"time"
 
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/post"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/slo"
 	"github.com/onnwee/subcults/internal/trust"
 )
 
 // SearchHandlers holds dependencies for search HTTP handlers.
 type SearchHandlers struct {
-	sceneRepo  scene.SceneRepository
-	eventRepo  scene.EventRepository
-	postRepo   post.PostRepository
-	trustStore TrustScoreStore
+	sceneRepo      scene.SceneRepository
+	eventRepo      scene.EventRepository
+	postRepo       post.PostRepository
+	trustStore     TrustScoreStore
+	membershipRepo membership.MembershipRepository // Optional; lets members search members-only scenes
+	latencySLO     *slo.Tracker                    // Optional; records search latency against its SLO
+	suggestions    *suggestCache                   // Prefix matches for GET /search/suggest
 }
 
 // NewSearchHandlers creates a new SearchHandlers instance.
 func NewSearchHandlers(sceneRepo scene.SceneRepository, postRepo post.PostRepository, trustStore TrustScoreStore, eventRepo scene.EventRepository) *SearchHandlers {
 	return &SearchHandlers{
-		sceneRepo:  sceneRepo,
-		eventRepo:  eventRepo,
-		postRepo:   postRepo,
-		trustStore: trustStore,
+		sceneRepo:   sceneRepo,
+		eventRepo:   eventRepo,
+		postRepo:    postRepo,
+		trustStore:  trustStore,
+		suggestions: newSuggestCache(),
 	}
 }
 
+// SetLatencySLO installs the tracker that records search latencies for
+// error budget burn alerting.
+func (h *SearchHandlers) SetLatencySLO(tracker *slo.Tracker) {
+	h.latencySLO = tracker
+}
+
 // SceneSearchResponse represents the response for scene search.
 type SceneSearchResponse struct {
 	Results    []*SceneSearchResult `json:"results"`
@@ -50,9 +64,12 @@ type SceneSearchResult struct {
 	Description   string       `json:"description,omitempty"`
 	JitteredPoint *scene.Point `json:"jittered_centroid,omitempty"` // Always jittered for privacy
 	CoarseGeohash string       `json:"coarse_geohash"`
-	Tags          []string     `json:"tags,omitempty"`
+	Tags          []string     `json:"tags"`
 	Visibility    string       `json:"visibility"`
 	TrustScore    *float64     `json:"trust_score,omitempty"` // Only if trust ranking enabled
+
+	ContentRating   string `json:"content_rating,omitempty"`
+	ContentRedacted bool   `json:"content_redacted,omitempty"` // Mature scene content withheld; see MatureContentHeader
 }
 
 // Constants for bbox validation
@@ -71,6 +88,10 @@ const (
 
 // SearchScenes handles GET /search/scenes - searches for scenes with ranking and pagination.
 func (h *SearchHandlers) SearchScenes(w http.ResponseWriter, r *http.Request) {
+	if h.latencySLO != nil {
+		defer h.latencySLO.ObserveSince(time.Now())
+	}
+
 	// Parse query parameters
 	query := r.URL.Query()
 
@@ -81,7 +102,7 @@ func (h *SearchHandlers) SearchScenes(w http.ResponseWriter, r *http.Request) {
 	var lat, lng *float64
 	if latStr := strings.TrimSpace(query.Get("lat")); latStr != "" {
 		parsedLat, err := strconv.ParseFloat(latStr, 64)
-		if err != nil || parsedLat < -90 || parsedLat > 90 {
+		if err != nil || geo.ValidateLatitude(parsedLat) != nil {
 			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "lat must be a valid latitude between -90 and 90")
 			return
@@ -90,7 +111,7 @@ func (h *SearchHandlers) SearchScenes(w http.ResponseWriter, r *http.Request) {
 	}
 	if lngStr := strings.TrimSpace(query.Get("lon")); lngStr != "" {
 		parsedLng, err := strconv.ParseFloat(lngStr, 64)
-		if err != nil || parsedLng < -180 || parsedLng > 180 {
+		if err != nil || geo.ValidateLongitude(parsedLng) != nil {
 			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "lon must be a valid longitude between -180 and 180")
 			return
@@ -152,13 +173,13 @@ func (h *SearchHandlers) SearchScenes(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Validate bbox coordinates
-			if minLng < -180 || minLng > 180 || maxLng < -180 || maxLng > 180 {
+			if geo.ValidateLongitude(minLng) != nil || geo.ValidateLongitude(maxLng) != nil {
 				ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 				WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Longitude must be between -180 and 180")
 				return
 			}
 
-			if minLat < -90 || minLat > 90 || maxLat < -90 || maxLat > 90 {
+			if geo.ValidateLatitude(minLat) != nil || geo.ValidateLatitude(maxLat) != nil {
 				ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 				WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Latitude must be between -90 and 90")
 				return
@@ -253,18 +274,19 @@ func (h *SearchHandlers) SearchScenes(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if trustEnabled && len(results) > 0 {
-		for _, s := range results {
-			score, scoreErr := h.trustStore.GetScore(s.ID)
-			if scoreErr != nil {
-				slog.WarnContext(r.Context(), "failed to get trust score", "scene_id", s.ID, "error", scoreErr)
-				continue
-			}
-			if score != nil {
-				searchOpts.TrustScores[s.ID] = score.Score
-			}
+		sceneIDs := make([]string, len(results))
+		for i, s := range results {
+			sceneIDs[i] = s.ID
 		}
 
-		if len(searchOpts.TrustScores) > 0 {
+		scores, ok := lookupTrustScores(r.Context(), h.trustStore, sceneIDs, "scenes")
+		if !ok {
+			// Trust graph unavailable: serve the trust-free ranking from the first pass
+			trustEnabled = false
+			searchOpts.TrustScores = nil
+		} else if len(scores) > 0 {
+			searchOpts.TrustScores = scores
+
 			results, nextCursor, err = h.sceneRepo.SearchScenes(searchOpts)
 			if err != nil {
 				slog.ErrorContext(r.Context(), "failed to search scenes with trust scores", "error", err, "query", q, "bbox", bboxStr)
@@ -276,15 +298,22 @@ func (h *SearchHandlers) SearchScenes(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert to search results with jittered coordinates
+	requesterDID := middleware.GetUserDID(r.Context())
 	searchResults := make([]*SceneSearchResult, 0, len(results))
 	for _, s := range results {
+		if redactMatureScene(r, s, requesterDID) {
+			searchResults = append(searchResults, newRedactedSceneSearchResult(s))
+			continue
+		}
+
 		result := &SceneSearchResult{
 			ID:            s.ID,
 			Name:          s.Name,
 			Description:   s.Description,
 			CoarseGeohash: s.CoarseGeohash,
-			Tags:          s.Tags,
+			Tags:          emptyIfNil(s.Tags),
 			Visibility:    s.Visibility,
+			ContentRating: s.ContentRating,
 		}
 
 		// Apply jitter to coordinates for privacy
@@ -353,9 +382,11 @@ func applyJitter(point *scene.Point) *scene.Point {
 		lngSign = -1.0
 	}
 
+	// Keep the offset point on the globe for points near a pole or the
+	// antimeridian
 	return &scene.Point{
-		Lat: point.Lat + latOffset*latSign,
-		Lng: point.Lng + lngOffset*lngSign,
+		Lat: geo.ClampLatitude(point.Lat + latOffset*latSign),
+		Lng: geo.NormalizeLongitude(point.Lng + lngOffset*lngSign),
 	}
 }
 
@@ -421,6 +452,8 @@ type PostSearchResult struct {
 	SceneID    *string  `json:"scene_id,omitempty"`
 	TrustScore *float64 `json:"trust_score,omitempty"` // Only if trust ranking enabled
 	CreatedAt  string   `json:"created_at"`            // ISO 8601 format
+
+	ContentRedacted bool `json:"content_redacted,omitempty"` // Excerpt withheld because the post's scene is mature
 }
 
 // GlobalSearchResult represents one item in mixed global search results.
@@ -455,6 +488,10 @@ type globalSearchCursor struct {
 
 // SearchGlobal handles GET /search/global - unified search across scenes, events, and posts.
 func (h *SearchHandlers) SearchGlobal(w http.ResponseWriter, r *http.Request) {
+	if h.latencySLO != nil {
+		defer h.latencySLO.ObserveSince(time.Now())
+	}
+
 	query := r.URL.Query()
 	q := strings.TrimSpace(query.Get("q"))
 	if q == "" {
@@ -479,7 +516,7 @@ func (h *SearchHandlers) SearchGlobal(w http.ResponseWriter, r *http.Request) {
 	var lat, lng *float64
 	if latStr := strings.TrimSpace(query.Get("lat")); latStr != "" {
 		parsedLat, parseErr := strconv.ParseFloat(latStr, 64)
-		if parseErr != nil || parsedLat < -90 || parsedLat > 90 {
+		if parseErr != nil || geo.ValidateLatitude(parsedLat) != nil {
 			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "lat must be a valid latitude between -90 and 90")
 			return
@@ -488,7 +525,7 @@ func (h *SearchHandlers) SearchGlobal(w http.ResponseWriter, r *http.Request) {
 	}
 	if lngStr := strings.TrimSpace(query.Get("lon")); lngStr != "" {
 		parsedLng, parseErr := strconv.ParseFloat(lngStr, 64)
-		if parseErr != nil || parsedLng < -180 || parsedLng > 180 {
+		if parseErr != nil || geo.ValidateLongitude(parsedLng) != nil {
 			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
 			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "lon must be a valid longitude between -180 and 180")
 			return
@@ -594,18 +631,23 @@ func (h *SearchHandlers) SearchGlobal(w http.ResponseWriter, r *http.Request) {
 		key    string
 	}
 	scored := make([]scoredGlobalResult, 0, len(sceneResults)+len(eventResults)+len(postResults))
+	requesterDID := middleware.GetUserDID(r.Context())
 	for i, s := range sceneResults {
 		sceneResult := &SceneSearchResult{
 			ID:            s.ID,
 			Name:          s.Name,
 			Description:   s.Description,
 			CoarseGeohash: s.CoarseGeohash,
-			Tags:          s.Tags,
+			Tags:          emptyIfNil(s.Tags),
 			Visibility:    s.Visibility,
+			ContentRating: s.ContentRating,
 		}
 		if s.PrecisePoint != nil {
 			sceneResult.JitteredPoint = applyJitter(s.PrecisePoint)
 		}
+		if redactMatureScene(r, s, requesterDID) {
+			sceneResult = newRedactedSceneSearchResult(s)
+		}
 		scored = append(scored, scoredGlobalResult{
 			result: &GlobalSearchResult{Type: "scene", Scene: sceneResult},
 			score:  globalNormalizedScore(i, len(sceneResults)),
@@ -632,16 +674,22 @@ func (h *SearchHandlers) SearchGlobal(w http.ResponseWriter, r *http.Request) {
 			key:   "event:" + e.ID,
 		})
 	}
+	redactPost := h.newMaturePostRedactor(r)
 	for i, p := range postResults {
+		postResult := &PostSearchResult{
+			ID:        p.ID,
+			Excerpt:   makeExcerpt(p.Text, 160),
+			SceneID:   p.SceneID,
+			CreatedAt: p.CreatedAt.Format(time.RFC3339),
+		}
+		if redactPost(p) {
+			postResult.Excerpt = ""
+			postResult.ContentRedacted = true
+		}
 		scored = append(scored, scoredGlobalResult{
 			result: &GlobalSearchResult{
 				Type: "post",
-				Post: &PostSearchResult{
-					ID:        p.ID,
-					Excerpt:   makeExcerpt(p.Text, 160),
-					SceneID:   p.SceneID,
-					CreatedAt: p.CreatedAt.Format(time.RFC3339),
-				},
+				Post: postResult,
 			},
 			score: globalNormalizedScore(i, len(postResults)),
 			key:   "post:" + p.ID,
@@ -717,6 +765,10 @@ func encodeGlobalSearchCursor(state globalSearchCursor) string {
 
 // SearchPosts handles GET /search/posts - searches for posts with text relevance and scene filter.
 func (h *SearchHandlers) SearchPosts(w http.ResponseWriter, r *http.Request) {
+	if h.latencySLO != nil {
+		defer h.latencySLO.ObserveSince(time.Now())
+	}
+
 	// Parse query parameters
 	query := r.URL.Query()
 
@@ -815,6 +867,7 @@ func (h *SearchHandlers) SearchPosts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert to search results with excerpts
+	redactPost := h.newMaturePostRedactor(r)
 	searchResults := make([]*PostSearchResult, 0, len(filtered))
 	for _, p := range filtered {
 		result := &PostSearchResult{
@@ -823,6 +876,10 @@ func (h *SearchHandlers) SearchPosts(w http.ResponseWriter, r *http.Request) {
 			SceneID:   p.SceneID,
 			CreatedAt: p.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), // ISO 8601
 		}
+		if redactPost(p) {
+			result.Excerpt = ""
+			result.ContentRedacted = true
+		}
 
 		// Note: Trust score integration is not yet implemented for post search
 		// trust_score field will always be nil and is omitted from JSON response
@@ -846,6 +903,52 @@ func (h *SearchHandlers) SearchPosts(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// newRedactedSceneSearchResult builds the search result for a mature scene the
+// viewer has not opted in to: only its ID, visibility and rating are returned.
+func newRedactedSceneSearchResult(s *scene.Scene) *SceneSearchResult {
+	return &SceneSearchResult{
+		ID:              s.ID,
+		Tags:            []string{},
+		Visibility:      s.Visibility,
+		ContentRating:   s.ContentRating,
+		ContentRedacted: true,
+	}
+}
+
+// newMaturePostRedactor returns a function reporting whether a post's content
+// must be withheld because it belongs to a mature scene the requester has not
+// opted in to. Scene lookups are cached for the request; lookup failures other
+// than a missing scene redact, failing closed.
+func (h *SearchHandlers) newMaturePostRedactor(r *http.Request) func(p *post.Post) bool {
+	if matureContentOptIn(r) {
+		return func(*post.Post) bool { return false }
+	}
+
+	requesterDID := middleware.GetUserDID(r.Context())
+	redacted := make(map[string]bool)
+	return func(p *post.Post) bool {
+		if p.SceneID == nil || *p.SceneID == "" {
+			return false
+		}
+		sceneID := *p.SceneID
+		if v, ok := redacted[sceneID]; ok {
+			return v
+		}
+
+		s, err := h.sceneRepo.GetByID(sceneID)
+		switch {
+		case err == nil:
+			redacted[sceneID] = redactMatureScene(r, s, requesterDID)
+		case errors.Is(err, scene.ErrSceneNotFound) || errors.Is(err, scene.ErrSceneDeleted):
+			redacted[sceneID] = false
+		default:
+			slog.WarnContext(r.Context(), "failed to check post scene content rating", "error", err, "scene_id", sceneID)
+			redacted[sceneID] = true
+		}
+		return redacted[sceneID]
+	}
+}
+
 // makeExcerpt creates a text excerpt of the specified length.
 // Truncates at word boundary if possible.
 func makeExcerpt(text string, maxLen int) string {
@@ -125,7 +125,7 @@ func TestSearchEvents_Success(t *testing.T) {
 
 	// Verify sorting: event1 should come before event2 (earlier start time)
 	if len(response.Events) >= 2 {
-		if !response.Events[0].StartsAt.Before(response.Events[1].StartsAt) {
+		if !response.Events[0].StartsAt.Before(response.Events[1].StartsAt.Time) {
 			t.Error("events should be sorted by starts_at ascending")
 		}
 	}
@@ -186,6 +186,12 @@ func TestSearchEvents_BboxValidation(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantCode:   ErrCodeValidation,
 		},
+		{
+			name:       "NaN coordinate",
+			bbox:       "-74.1,NaN,-73.9,40.8",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeValidation,
+		},
 		{
 			name:       "non-numeric coordinate",
 			bbox:       "invalid,40.6,-73.9,40.8",
@@ -10,6 +10,7 @@ import (
 
 	"github.com/onnwee/subcults/internal/post"
 	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/slo"
 )
 
 // TestSearchPosts_Success tests successful post search with text query.
@@ -93,6 +94,25 @@ func TestSearchPosts_Success(t *testing.T) {
 	}
 }
 
+// TestSearchPosts_RecordsLatencySLO tests that searches, including rejected
+// ones, are recorded against the search latency SLO.
+func TestSearchPosts_RecordsLatencySLO(t *testing.T) {
+	handlers := NewSearchHandlers(scene.NewInMemorySceneRepository(), post.NewInMemoryPostRepository(), nil, scene.NewInMemoryEventRepository())
+	// A negative target makes every request count against the budget
+	tracker := slo.NewTracker("search", -1, slo.DefaultWindow)
+	handlers.SetLatencySLO(tracker)
+
+	for _, target := range []string{"/search/posts?q=electronic", "/search/posts"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		handlers.SearchPosts(w, req)
+	}
+
+	if got := tracker.BurnFraction(); got != 1 {
+		t.Errorf("expected searches to be recorded, burn fraction = %v", got)
+	}
+}
+
 // TestSearchPosts_WithSceneFilter tests post search with scene filter.
 func TestSearchPosts_WithSceneFilter(t *testing.T) {
 	postRepo := post.NewInMemoryPostRepository()
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/ranking"
 	"github.com/onnwee/subcults/internal/scene"
 	"github.com/onnwee/subcults/internal/trust"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 // TestSearchScenes_Success tests successful scene search.
@@ -190,6 +194,11 @@ func TestSearchScenes_BboxValidation(t *testing.T) {
 			bbox:       "-200,40.6,-73.9,40.8",
 			expectCode: http.StatusBadRequest,
 		},
+		{
+			name:       "NaN coordinate",
+			bbox:       "NaN,40.6,-73.9,40.8",
+			expectCode: http.StatusBadRequest,
+		},
 		{
 			name:       "min >= max (longitude)",
 			bbox:       "-73.9,40.6,-74.1,40.8",
@@ -221,6 +230,30 @@ func TestSearchScenes_BboxValidation(t *testing.T) {
 	}
 }
 
+// TestSearchScenes_InvalidCoordinates tests that out-of-range and non-finite
+// lat/lon values are rejected rather than reaching proximity scoring.
+func TestSearchScenes_InvalidCoordinates(t *testing.T) {
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewSearchHandlers(sceneRepo, nil, nil, scene.NewInMemoryEventRepository())
+
+	for _, query := range []string{
+		"lat=91&lon=0",
+		"lat=-1e308&lon=0",
+		"lat=NaN&lon=0",
+		"lat=0&lon=181",
+		"lat=0&lon=Inf",
+	} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/search/scenes?q=test&"+query, nil)
+			w := httptest.NewRecorder()
+
+			handlers.SearchScenes(w, req)
+
+			assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+		})
+	}
+}
+
 // TestSearchScenes_RequiresBbox tests that bbox parameter is required.
 func TestSearchScenes_RequiresBbox(t *testing.T) {
 	sceneRepo := scene.NewInMemorySceneRepository()
@@ -495,3 +528,95 @@ func TestSearchScenes_LatLonAndGenresFilter(t *testing.T) {
 		t.Fatalf("expected only techno scene, got %d results", response.Count)
 	}
 }
+
+// partialTrustStore returns scores for some scenes and errors for the rest,
+// simulating a trust graph that becomes unavailable mid-request.
+type partialTrustStore struct {
+	scores map[string]float64
+}
+
+func (p *partialTrustStore) GetScore(sceneID string) (*TrustScore, error) {
+	score, ok := p.scores[sceneID]
+	if !ok {
+		return nil, fmt.Errorf("simulated trust store error")
+	}
+	return &TrustScore{SceneID: sceneID, Score: score}, nil
+}
+
+// TestSearchScenes_TrustStoreErrorDegrades verifies that a trust lookup failure
+// yields a trust-free ranking instead of a 500, and is counted in metrics.
+func TestSearchScenes_TrustStoreErrorDegrades(t *testing.T) {
+	tests := []struct {
+		name  string
+		store TrustScoreStore
+	}{
+		{name: "store fully down", store: &failingTrustStore{}},
+		{name: "store fails partway", store: &partialTrustStore{scores: map[string]float64{"scene1": 0.9}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sceneRepo := scene.NewInMemorySceneRepository()
+			handlers := NewSearchHandlers(sceneRepo, nil, tt.store, scene.NewInMemoryEventRepository())
+
+			now := time.Now()
+			for _, id := range []string{"scene1", "scene2"} {
+				if err := sceneRepo.Insert(&scene.Scene{
+					ID:            id,
+					Name:          "Music Scene",
+					OwnerDID:      "did:plc:" + id,
+					AllowPrecise:  true,
+					PrecisePoint:  &scene.Point{Lat: 40.7128, Lng: -74.0060},
+					CoarseGeohash: "dr5regw",
+					Visibility:    scene.VisibilityPublic,
+					CreatedAt:     &now,
+					UpdatedAt:     &now,
+				}); err != nil {
+					t.Fatalf("failed to insert scene: %v", err)
+				}
+			}
+
+			metrics := ranking.NewMetrics()
+			reg := prometheus.NewRegistry()
+			if err := metrics.Register(reg); err != nil {
+				t.Fatalf("failed to register metrics: %v", err)
+			}
+			ranking.SetMetrics(metrics)
+			defer ranking.SetMetrics(nil)
+
+			trust.SetRankingEnabled(true)
+			defer trust.SetRankingEnabled(false)
+
+			req := httptest.NewRequest(http.MethodGet, "/search/scenes?q=music&bbox=-74.1,40.6,-73.9,40.8", nil)
+			w := httptest.NewRecorder()
+
+			handlers.SearchScenes(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var response SceneSearchResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+			if len(response.Results) != 2 {
+				t.Fatalf("expected 2 results, got %d", len(response.Results))
+			}
+			for _, result := range response.Results {
+				if result.TrustScore != nil {
+					t.Errorf("expected trust-free ranking, got trust score for %s", result.ID)
+				}
+			}
+
+			expected := `
+# HELP ranking_trust_degraded_total Total number of search requests ranked without trust because trust lookup failed, by search type
+# TYPE ranking_trust_degraded_total counter
+ranking_trust_degraded_total{search="scenes"} 1
+`
+			if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), ranking.MetricRankingTrustDegraded); err != nil {
+				t.Errorf("unexpected metric value: %v", err)
+			}
+		})
+	}
+}
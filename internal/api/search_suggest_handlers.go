@@ -0,0 +1,251 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// Suggest endpoint limits. Autocomplete is cheap to call once per keystroke,
+// which makes it an easy way to scrape scene names, so each request returns
+// only a handful of names for a prefix of meaningful length.
+const (
+	MinSuggestQueryLength = 2  // Shorter prefixes return no suggestions
+	MaxSuggestQueryLength = 64 // Longer queries are rejected
+	DefaultSuggestLimit   = 5
+	MaxSuggestLimit       = 10
+
+	// suggestCandidateCap bounds the matches cached per prefix; visibility is
+	// re-checked per request, so a few spare candidates cover dropped scenes.
+	suggestCandidateCap = 50
+	suggestCacheTTL     = 30 * time.Second
+
+	// suggestCacheSweepThreshold is the cache size at which expired prefixes
+	// are pruned; while still full afterwards, new prefixes are not cached.
+	suggestCacheSweepThreshold = 10000
+)
+
+// SuggestRateLimit is the per-user (or per-IP when anonymous) rate limit
+// applied to GET /search/suggest. It is tighter than search's limit, since a
+// scraper walking prefixes issues far more requests than a person typing.
+var SuggestRateLimit = middleware.RateLimitConfig{
+	RequestsPerWindow: 30,
+	WindowDuration:    time.Minute,
+}
+
+// SceneSuggestion is a scene name offered while the user types.
+type SceneSuggestion struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SuggestResponse is the JSON response for GET /search/suggest.
+type SuggestResponse struct {
+	Suggestions []SceneSuggestion `json:"suggestions"`
+}
+
+// suggestCacheEntry holds the IDs of the public scenes matching a prefix, in
+// suggestion order.
+type suggestCacheEntry struct {
+	sceneIDs  []string
+	expiresAt time.Time
+}
+
+// suggestCache is a short-TTL cache of prefix matches, so common prefixes
+// don't scan every public scene on each keystroke. Thread-safe.
+type suggestCache struct {
+	mu      sync.Mutex
+	entries map[string]suggestCacheEntry
+	timeNow func() time.Time // For testability
+}
+
+// newSuggestCache creates an empty suggestion cache.
+func newSuggestCache() *suggestCache {
+	return &suggestCache{
+		entries: make(map[string]suggestCacheEntry),
+		timeNow: time.Now,
+	}
+}
+
+// get returns the cached scene IDs for prefix, if unexpired.
+func (c *suggestCache) get(prefix string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[prefix]
+	if !ok || !c.timeNow().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.sceneIDs, true
+}
+
+// put caches the scene IDs matching prefix.
+func (c *suggestCache) put(prefix string, sceneIDs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.timeNow()
+	if len(c.entries) >= suggestCacheSweepThreshold {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		if len(c.entries) >= suggestCacheSweepThreshold {
+			return
+		}
+	}
+	c.entries[prefix] = suggestCacheEntry{sceneIDs: sceneIDs, expiresAt: now.Add(suggestCacheTTL)}
+}
+
+// SuggestScenes handles GET /search/suggest - suggests public scene names
+// starting with the query, or with one of its words starting with it.
+// Queries shorter than MinSuggestQueryLength return no suggestions. Members-only,
+// hidden and moderated scenes are never suggested, nor are mature scenes unless
+// the request opts in.
+func (h *SearchHandlers) SuggestScenes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	prefix := strings.ToLower(strings.TrimSpace(query.Get("q")))
+	if utf8.RuneCountInString(prefix) > MaxSuggestQueryLength {
+		ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "q must be at most 64 characters")
+		return
+	}
+
+	limit := DefaultSuggestLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "limit must be a positive integer")
+			return
+		}
+		if limit > MaxSuggestLimit {
+			limit = MaxSuggestLimit
+		}
+	}
+
+	response := SuggestResponse{Suggestions: []SceneSuggestion{}}
+	if utf8.RuneCountInString(prefix) >= MinSuggestQueryLength {
+		suggestions, err := h.suggestScenes(r, prefix, limit)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to suggest scenes", "error", err)
+			ctx := middleware.SetErrorCode(r.Context(), ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to suggest scenes")
+			return
+		}
+		response.Suggestions = suggestions
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode suggest response", "error", err)
+	}
+}
+
+// suggestScenes returns up to limit suggestions for prefix. Cached matches
+// are re-read, so a scene that was renamed, made private or moderated since it
+// was cached drops out immediately.
+func (h *SearchHandlers) suggestScenes(r *http.Request, prefix string, limit int) ([]SceneSuggestion, error) {
+	sceneIDs, ok := h.suggestions.get(prefix)
+	if !ok {
+		scenes, err := h.sceneRepo.ListPublic()
+		if err != nil {
+			return nil, err
+		}
+		sceneIDs = matchSuggestionPrefix(scenes, prefix)
+		h.suggestions.put(prefix, sceneIDs)
+	}
+
+	requesterDID := middleware.GetUserDID(r.Context())
+	suggestions := make([]SceneSuggestion, 0, limit)
+	for _, id := range sceneIDs {
+		if len(suggestions) == limit {
+			break
+		}
+		s, err := h.sceneRepo.GetByID(id)
+		if err != nil {
+			if errors.Is(err, scene.ErrSceneNotFound) || errors.Is(err, scene.ErrSceneDeleted) {
+				continue
+			}
+			return nil, err
+		}
+		if !isSuggestible(s) || !suggestionMatches(s.Name, prefix) || redactMatureScene(r, s, requesterDID) {
+			continue
+		}
+		suggestions = append(suggestions, SceneSuggestion{ID: s.ID, Name: s.Name})
+	}
+	return suggestions, nil
+}
+
+// matchSuggestionPrefix returns the IDs of up to suggestCandidateCap scenes
+// matching prefix: names starting with it first, then names with a later word
+// starting with it, each alphabetically.
+func matchSuggestionPrefix(scenes []*scene.Scene, prefix string) []string {
+	var matches []*scene.Scene
+	for _, s := range scenes {
+		if isSuggestible(s) && suggestionMatches(s.Name, prefix) {
+			matches = append(matches, s)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		iLeading := strings.HasPrefix(strings.ToLower(matches[i].Name), prefix)
+		jLeading := strings.HasPrefix(strings.ToLower(matches[j].Name), prefix)
+		if iLeading != jLeading {
+			return iLeading
+		}
+		if matches[i].Name != matches[j].Name {
+			return matches[i].Name < matches[j].Name
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	if len(matches) > suggestCandidateCap {
+		matches = matches[:suggestCandidateCap]
+	}
+	sceneIDs := make([]string, len(matches))
+	for i, s := range matches {
+		sceneIDs[i] = s.ID
+	}
+	return sceneIDs
+}
+
+// suggestionMatches reports whether name, or one of its words, starts with
+// the lowercase prefix.
+func suggestionMatches(name, prefix string) bool {
+	name = strings.ToLower(name)
+	if strings.HasPrefix(name, prefix) {
+		return true
+	}
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSuggestible reports whether s may be offered as a suggestion: only public
+// scenes that moderation has not hidden or suspended, as in ListPublic.
+func isSuggestible(s *scene.Scene) bool {
+	if s.DeletedAt != nil || s.Visibility != scene.VisibilityPublic {
+		return false
+	}
+	return s.ModerationStatus != "hidden" && s.ModerationStatus != "suspended"
+}
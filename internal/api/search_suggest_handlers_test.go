@@ -0,0 +1,194 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// newSuggestTest creates search handlers over scenes with the given names
+// and visibilities, keyed by scene ID.
+func newSuggestTest(t *testing.T, scenes map[string]*scene.Scene) (*SearchHandlers, *scene.InMemorySceneRepository) {
+	t.Helper()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	for id, s := range scenes {
+		s.ID = id
+		s.OwnerDID = "did:plc:owner"
+		s.CoarseGeohash = "dr5regw"
+		if err := sceneRepo.Insert(s); err != nil {
+			t.Fatalf("failed to insert scene %s: %v", id, err)
+		}
+	}
+	return NewSearchHandlers(sceneRepo, nil, nil, scene.NewInMemoryEventRepository()), sceneRepo
+}
+
+// suggest calls GET /search/suggest with q and returns the suggested scene IDs.
+func suggest(t *testing.T, handler http.Handler, q string) []string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/search/suggest?q="+url.QueryEscape(q), nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SuggestResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	ids := make([]string, len(resp.Suggestions))
+	for i, s := range resp.Suggestions {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+func TestSuggestScenes_PrefixMatches(t *testing.T) {
+	handlers, _ := newSuggestTest(t, map[string]*scene.Scene{
+		"techno":  {Name: "Techno Bunker", Visibility: scene.VisibilityPublic},
+		"detroit": {Name: "Detroit Techno Collective", Visibility: scene.VisibilityPublic},
+		"jazz":    {Name: "Jazz Cellar", Visibility: scene.VisibilityPublic},
+	})
+
+	// Names starting with the prefix come before names with a later word matching it
+	got := suggest(t, http.HandlerFunc(handlers.SuggestScenes), "tech")
+	if want := []string{"techno", "detroit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("suggestions = %v, want %v", got, want)
+	}
+}
+
+func TestSuggestScenes_ShortQueryReturnsNothing(t *testing.T) {
+	handlers, _ := newSuggestTest(t, map[string]*scene.Scene{
+		"techno": {Name: "Techno Bunker", Visibility: scene.VisibilityPublic},
+	})
+
+	for _, q := range []string{"", "t", " t "} {
+		if got := suggest(t, http.HandlerFunc(handlers.SuggestScenes), q); len(got) != 0 {
+			t.Errorf("q=%q: expected no suggestions, got %v", q, got)
+		}
+	}
+}
+
+func TestSuggestScenes_ExcludesPrivateScenes(t *testing.T) {
+	handlers, sceneRepo := newSuggestTest(t, map[string]*scene.Scene{
+		"public":  {Name: "Noise Public", Visibility: scene.VisibilityPublic},
+		"members": {Name: "Noise Members", Visibility: scene.VisibilityMembersOnly},
+		"hidden":  {Name: "Noise Hidden", Visibility: scene.VisibilityHidden},
+		"mature":  {Name: "Noise Mature", Visibility: scene.VisibilityPublic, ContentRating: scene.ContentRatingMature},
+		"removed": {Name: "Noise Removed", Visibility: scene.VisibilityPublic, ModerationStatus: "hidden"},
+	})
+	handler := http.HandlerFunc(handlers.SuggestScenes)
+
+	if got, want := suggest(t, handler, "noise"), []string{"public"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("suggestions = %v, want %v", got, want)
+	}
+
+	// A scene made private while its prefix is cached stops being suggested at once
+	s, err := sceneRepo.GetByID("public")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	s.Visibility = scene.VisibilityHidden
+	if err := sceneRepo.Update(s); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := suggest(t, handler, "noise"); len(got) != 0 {
+		t.Errorf("expected no suggestions after the scene was hidden, got %v", got)
+	}
+}
+
+func TestSuggestScenes_CapsResults(t *testing.T) {
+	scenes := make(map[string]*scene.Scene)
+	for i := 0; i < MaxSuggestLimit+5; i++ {
+		scenes[fmt.Sprintf("scene-%02d", i)] = &scene.Scene{Name: fmt.Sprintf("Drone %02d", i), Visibility: scene.VisibilityPublic}
+	}
+	handlers, _ := newSuggestTest(t, scenes)
+	handler := http.HandlerFunc(handlers.SuggestScenes)
+
+	if got := suggest(t, handler, "drone"); len(got) != DefaultSuggestLimit {
+		t.Errorf("expected %d suggestions by default, got %d", DefaultSuggestLimit, len(got))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/search/suggest?q=drone&limit=100", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	var resp SuggestResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Suggestions) != MaxSuggestLimit {
+		t.Errorf("expected limit to be capped at %d, got %d", MaxSuggestLimit, len(resp.Suggestions))
+	}
+}
+
+func TestSuggestScenes_Validation(t *testing.T) {
+	handlers, _ := newSuggestTest(t, nil)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "query too long", query: "q=" + strings.Repeat("a", MaxSuggestQueryLength+1)},
+		{name: "invalid limit", query: "q=drone&limit=abc"},
+		{name: "zero limit", query: "q=drone&limit=0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/search/suggest?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			handlers.SuggestScenes(w, req)
+			assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+		})
+	}
+}
+
+func TestSuggestScenes_RateLimited(t *testing.T) {
+	handlers, _ := newSuggestTest(t, map[string]*scene.Scene{
+		"techno": {Name: "Techno Bunker", Visibility: scene.VisibilityPublic},
+	})
+	limited := middleware.RateLimiter(middleware.NewInMemoryRateLimitStore(), SuggestRateLimit, middleware.UserKeyFunc(), nil)(
+		http.HandlerFunc(handlers.SuggestScenes),
+	)
+
+	for i := 0; i < SuggestRateLimit.RequestsPerWindow; i++ {
+		suggest(t, limited, "te")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/search/suggest?q=te", nil)
+	w := httptest.NewRecorder()
+	limited.ServeHTTP(w, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 after %d requests, got %d", SuggestRateLimit.RequestsPerWindow, w.Code)
+	}
+}
+
+func TestSuggestScenes_CachesPrefixMatches(t *testing.T) {
+	handlers, sceneRepo := newSuggestTest(t, map[string]*scene.Scene{
+		"techno": {Name: "Techno Bunker", Visibility: scene.VisibilityPublic},
+	})
+	handler := http.HandlerFunc(handlers.SuggestScenes)
+	now := time.Now()
+	handlers.suggestions.timeNow = func() time.Time { return now }
+
+	suggest(t, handler, "tech")
+	if err := sceneRepo.Insert(&scene.Scene{ID: "techhouse", Name: "Tech House", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	// The cached prefix is served until it expires
+	if got, want := suggest(t, handler, "tech"), []string{"techno"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cached suggestions = %v, want %v", got, want)
+	}
+	handlers.suggestions.timeNow = func() time.Time { return now.Add(suggestCacheTTL) }
+	if got, want := suggest(t, handler, "tech"), []string{"techhouse", "techno"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("suggestions after expiry = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// RecomputeAnalyticsResponse reports the progress of one analytics recompute batch.
+type RecomputeAnalyticsResponse struct {
+	Examined   int                       `json:"examined"`   // Ended streams examined in this batch
+	Recomputed int                       `json:"recomputed"` // Streams in the window whose analytics changed
+	Unchanged  int                       `json:"unchanged"`  // Streams in the window that were already correct
+	Failed     []stream.RecomputeFailure `json:"failed"`
+	NextCursor string                    `json:"next_cursor,omitempty"` // Pass back as cursor to continue
+	Done       bool                      `json:"done"`
+}
+
+// SetAdminDIDs configures the DIDs allowed to call admin stream endpoints.
+func (h *StreamHandlers) SetAdminDIDs(dids []string) {
+	h.adminDIDs = dids
+}
+
+// RecomputeAnalytics handles POST /admin/streams/recompute_analytics?from=&to=
+// - recomputes analytics for one batch of streams that ended within the window.
+// The response carries a cursor; repeat the request with it until done is true.
+// Recomputation is idempotent, so a batch that was interrupted or failed can
+// simply be retried with the same cursor.
+func (h *StreamHandlers) RecomputeAnalytics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+	if !slices.Contains(h.adminDIDs, userDID) {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Admin privileges required")
+		return
+	}
+
+	query := r.URL.Query()
+	from, err := time.Parse(time.RFC3339, query.Get("from"))
+	if err != nil {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "'from' is required and must be RFC3339 format")
+		return
+	}
+	to, err := time.Parse(time.RFC3339, query.Get("to"))
+	if err != nil {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "'to' is required and must be RFC3339 format")
+		return
+	}
+	opts := stream.RecomputeOptions{
+		From:   from,
+		To:     to,
+		Cursor: query.Get("cursor"),
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := parseIntInRange(limitStr, "limit", 1, stream.MaxRecomputeBatchSize)
+		if err != nil {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+		opts.BatchSize = limit
+	}
+
+	progress, err := stream.RecomputeAnalyticsWindow(h.streamRepo, h.analyticsRepo, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, stream.ErrInvalidRecomputeWindow):
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "'from' must be before 'to'")
+		case errors.Is(err, stream.ErrInvalidCursor):
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid cursor")
+		default:
+			slog.ErrorContext(ctx, "failed to recompute stream analytics", "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		}
+		return
+	}
+
+	slog.InfoContext(ctx, "recomputed stream analytics batch",
+		"admin_did", userDID,
+		"from", from,
+		"to", to,
+		"examined", progress.Examined,
+		"recomputed", progress.Recomputed,
+		"unchanged", progress.Unchanged,
+		"failed", len(progress.Failed),
+	)
+
+	response := RecomputeAnalyticsResponse{
+		Examined:   progress.Examined,
+		Recomputed: progress.Recomputed,
+		Unchanged:  progress.Unchanged,
+		Failed:     progress.Failed,
+		NextCursor: progress.NextCursor,
+		Done:       progress.NextCursor == "",
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode recompute response", "error", err)
+	}
+}
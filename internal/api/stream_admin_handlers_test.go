@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+func newRecomputeAnalyticsTest(t *testing.T) *StreamHandlers {
+	t.Helper()
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	analyticsRepo := stream.NewInMemoryAnalyticsRepository(streamRepo)
+	endedAt := time.Date(2026, 3, 1, 13, 0, 0, 0, time.UTC)
+	if _, err := streamRepo.Upsert(&stream.Session{
+		RoomName:  "room",
+		HostDID:   "did:plc:host",
+		StartedAt: endedAt.Add(-time.Hour),
+		EndedAt:   &endedAt,
+	}); err != nil {
+		t.Fatalf("failed to insert session: %v", err)
+	}
+
+	handlers := NewStreamHandlers(streamRepo, nil, analyticsRepo, scene.NewInMemorySceneRepository(), scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+	handlers.SetAdminDIDs([]string{"did:plc:admin"})
+	return handlers
+}
+
+func doRecomputeAnalytics(h *StreamHandlers, query, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/admin/streams/recompute_analytics?"+query, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	h.RecomputeAnalytics(w, req)
+	return w
+}
+
+func TestRecomputeAnalytics_Admin(t *testing.T) {
+	handlers := newRecomputeAnalyticsTest(t)
+
+	w := doRecomputeAnalytics(handlers, "from=2026-03-01T00:00:00Z&to=2026-03-02T00:00:00Z", "did:plc:admin")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response RecomputeAnalyticsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Examined != 1 || response.Recomputed != 1 || !response.Done || response.NextCursor != "" {
+		t.Errorf("response = %+v, want one stream recomputed and done", response)
+	}
+}
+
+func TestRecomputeAnalytics_Errors(t *testing.T) {
+	handlers := newRecomputeAnalyticsTest(t)
+
+	tests := []struct {
+		name       string
+		query      string
+		userDID    string
+		wantStatus int
+	}{
+		{name: "unauthenticated", query: "from=2026-03-01T00:00:00Z&to=2026-03-02T00:00:00Z", wantStatus: http.StatusUnauthorized},
+		{name: "not an admin", query: "from=2026-03-01T00:00:00Z&to=2026-03-02T00:00:00Z", userDID: "did:plc:host", wantStatus: http.StatusForbidden},
+		{name: "missing from", query: "to=2026-03-02T00:00:00Z", userDID: "did:plc:admin", wantStatus: http.StatusBadRequest},
+		{name: "reversed window", query: "from=2026-03-02T00:00:00Z&to=2026-03-01T00:00:00Z", userDID: "did:plc:admin", wantStatus: http.StatusBadRequest},
+		{name: "invalid cursor", query: "from=2026-03-01T00:00:00Z&to=2026-03-02T00:00:00Z&cursor=bogus", userDID: "did:plc:admin", wantStatus: http.StatusBadRequest},
+		{name: "limit out of range", query: "from=2026-03-01T00:00:00Z&to=2026-03-02T00:00:00Z&limit=0", userDID: "did:plc:admin", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doRecomputeAnalytics(handlers, tt.query, tt.userDID); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// failingAnalyticsRepo is an analytics repository whose reads always fail.
+type failingAnalyticsRepo struct {
+	stream.AnalyticsRepository
+}
+
+func (failingAnalyticsRepo) GetAnalytics(string) (*stream.Analytics, error) {
+	return nil, errors.New("analytics database unavailable")
+}
+
+// newStreamDetailTest creates an ended stream hosted by did:plc:host in a public
+// scene, with analytics computed in the returned analytics repository.
+func newStreamDetailTest(t *testing.T) (*stream.InMemorySessionRepository, *stream.InMemoryAnalyticsRepository, *scene.InMemorySceneRepository, string) {
+	t.Helper()
+	streamRepo := stream.NewInMemorySessionRepository()
+	analyticsRepo := stream.NewInMemoryAnalyticsRepository(streamRepo)
+	sceneRepo := scene.NewInMemorySceneRepository()
+
+	if err := sceneRepo.Insert(&scene.Scene{
+		ID:            "detail-scene",
+		Name:          "Detail Scene",
+		OwnerDID:      "did:plc:host",
+		CoarseGeohash: "dr5regw",
+		Visibility:    scene.VisibilityPublic,
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	streamID, _, err := streamRepo.CreateStreamSession(ptrString("detail-scene"), nil, "did:plc:host")
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	if err := analyticsRepo.RecordParticipantEvent(streamID, "did:plc:listener", "join", nil); err != nil {
+		t.Fatalf("failed to record join: %v", err)
+	}
+	if err := streamRepo.EndStreamSession(streamID); err != nil {
+		t.Fatalf("failed to end stream: %v", err)
+	}
+	if _, err := analyticsRepo.ComputeAnalytics(streamID); err != nil {
+		t.Fatalf("failed to compute analytics: %v", err)
+	}
+	return streamRepo, analyticsRepo, sceneRepo, streamID
+}
+
+func getStreamDetail(t *testing.T, handlers *StreamHandlers, streamID, userDID string) StreamDetailResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/streams/"+streamID, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.GetStream(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response StreamDetailResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+func TestGetStream_FullResponse(t *testing.T) {
+	streamRepo, analyticsRepo, sceneRepo, streamID := newStreamDetailTest(t)
+	handlers := NewStreamHandlers(streamRepo, nil, analyticsRepo, sceneRepo, scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+
+	response := getStreamDetail(t, handlers, streamID, "did:plc:host")
+	if response.Partial || len(response.Warnings) != 0 {
+		t.Errorf("expected complete response, got partial=%v warnings=%v", response.Partial, response.Warnings)
+	}
+	if response.Scene == nil || response.Scene.Name != "Detail Scene" {
+		t.Errorf("expected scene preview, got %+v", response.Scene)
+	}
+	if _, ok := response.Analytics["total_unique_participants"]; !ok {
+		t.Errorf("expected analytics for host, got %v", response.Analytics)
+	}
+
+	// Other viewers get the scene preview but not the host-only analytics
+	response = getStreamDetail(t, handlers, streamID, "did:plc:listener")
+	if response.Analytics != nil {
+		t.Errorf("expected no analytics for non-host, got %v", response.Analytics)
+	}
+	if response.Scene == nil || response.Partial {
+		t.Errorf("expected complete response with scene preview, got %+v", response)
+	}
+}
+
+func TestGetStream_DegradesWhenEnricherFails(t *testing.T) {
+	streamRepo, _, sceneRepo, streamID := newStreamDetailTest(t)
+	handlers := NewStreamHandlers(streamRepo, nil, failingAnalyticsRepo{}, sceneRepo, scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+
+	response := getStreamDetail(t, handlers, streamID, "did:plc:host")
+	if !response.Partial {
+		t.Error("expected partial response")
+	}
+	if len(response.Warnings) != 1 || response.Warnings[0] != "analytics unavailable" {
+		t.Errorf("expected analytics warning, got %v", response.Warnings)
+	}
+	if response.Analytics != nil {
+		t.Errorf("expected analytics to be omitted, got %v", response.Analytics)
+	}
+
+	// The core stream data and other enrichments are still present
+	if response.ID != streamID || response.Status != "ended" {
+		t.Errorf("unexpected stream data: %+v", response.StreamSessionResponse)
+	}
+	if response.Scene == nil {
+		t.Error("expected scene preview despite analytics failure")
+	}
+}
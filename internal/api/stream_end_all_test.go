@@ -0,0 +1,172 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// failingEndSessionRepository fails EndStreamSession for a single stream ID.
+type failingEndSessionRepository struct {
+	*stream.InMemorySessionRepository
+	failID string
+}
+
+func (r *failingEndSessionRepository) EndStreamSession(id string) error {
+	if id == r.failID {
+		return errors.New("database unavailable")
+	}
+	return r.InMemorySessionRepository.EndStreamSession(id)
+}
+
+func doEndAllStreams(t *testing.T, h *StreamHandlers, userDID string) EndAllStreamsResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/me/streams/end_all", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+
+	h.EndAllStreams(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp EndAllStreamsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestEndAllStreams_EndsEveryActiveStream(t *testing.T) {
+	streamRepo := stream.NewInMemorySessionRepository()
+	analyticsRepo := stream.NewInMemoryAnalyticsRepository(streamRepo)
+	handlers := NewStreamHandlers(streamRepo, nil, analyticsRepo, scene.NewInMemorySceneRepository(), scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+
+	hostDID := "did:plc:host456"
+	sceneID := "scene-123"
+	eventID := "event-456"
+	sceneStreamID, _, err := streamRepo.CreateStreamSession(&sceneID, nil, hostDID)
+	if err != nil {
+		t.Fatalf("failed to create stream session: %v", err)
+	}
+	eventStreamID, _, err := streamRepo.CreateStreamSession(nil, &eventID, hostDID)
+	if err != nil {
+		t.Fatalf("failed to create stream session: %v", err)
+	}
+	otherHostStreamID, _, err := streamRepo.CreateStreamSession(&sceneID, nil, "did:plc:other")
+	if err != nil {
+		t.Fatalf("failed to create stream session: %v", err)
+	}
+
+	resp := doEndAllStreams(t, handlers, hostDID)
+
+	if resp.EndedCount != 2 || resp.FailedCount != 0 || len(resp.Results) != 2 {
+		t.Fatalf("expected 2 ended and 0 failed, got %+v", resp)
+	}
+	for _, result := range resp.Results {
+		if result.Status != "ended" {
+			t.Errorf("expected stream %s status 'ended', got %q", result.StreamID, result.Status)
+		}
+		if !result.AnalyticsComputed {
+			t.Errorf("expected analytics computed for stream %s", result.StreamID)
+		}
+	}
+
+	for _, id := range []string{sceneStreamID, eventStreamID} {
+		session, err := streamRepo.GetByID(id)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if session.EndedAt == nil {
+			t.Errorf("expected stream %s to be ended", id)
+		}
+	}
+
+	other, err := streamRepo.GetByID(otherHostStreamID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if other.EndedAt != nil {
+		t.Error("expected another host's stream to remain active")
+	}
+}
+
+func TestEndAllStreams_NoActiveStreams(t *testing.T) {
+	streamRepo := stream.NewInMemorySessionRepository()
+	handlers := NewStreamHandlers(streamRepo, nil, nil, scene.NewInMemorySceneRepository(), scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+
+	resp := doEndAllStreams(t, handlers, "did:plc:host456")
+
+	if resp.Results == nil || len(resp.Results) != 0 {
+		t.Errorf("expected empty results, got %v", resp.Results)
+	}
+	if resp.EndedCount != 0 || resp.FailedCount != 0 {
+		t.Errorf("expected zero counts, got %+v", resp)
+	}
+}
+
+func TestEndAllStreams_PartialFailure(t *testing.T) {
+	baseRepo := stream.NewInMemorySessionRepository()
+	hostDID := "did:plc:host456"
+	sceneID := "scene-123"
+
+	failID, _, err := baseRepo.CreateStreamSession(&sceneID, nil, hostDID)
+	if err != nil {
+		t.Fatalf("failed to create stream session: %v", err)
+	}
+	okID, _, err := baseRepo.CreateStreamSession(&sceneID, nil, hostDID)
+	if err != nil {
+		t.Fatalf("failed to create stream session: %v", err)
+	}
+
+	streamRepo := &failingEndSessionRepository{InMemorySessionRepository: baseRepo, failID: failID}
+	handlers := NewStreamHandlers(streamRepo, nil, nil, scene.NewInMemorySceneRepository(), scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+
+	resp := doEndAllStreams(t, handlers, hostDID)
+
+	if resp.EndedCount != 1 || resp.FailedCount != 1 {
+		t.Fatalf("expected 1 ended and 1 failed, got %+v", resp)
+	}
+	for _, result := range resp.Results {
+		switch result.StreamID {
+		case failID:
+			if result.Status != "failed" || result.Error == "" {
+				t.Errorf("expected failed result with error, got %+v", result)
+			}
+		case okID:
+			if result.Status != "ended" || result.Error != "" {
+				t.Errorf("expected ended result, got %+v", result)
+			}
+		default:
+			t.Errorf("unexpected stream in results: %s", result.StreamID)
+		}
+	}
+
+	session, err := baseRepo.GetByID(okID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if session.EndedAt == nil {
+		t.Error("expected the healthy stream to be ended despite the other failure")
+	}
+}
+
+func TestEndAllStreams_Unauthenticated(t *testing.T) {
+	handlers := NewStreamHandlers(stream.NewInMemorySessionRepository(), nil, nil, scene.NewInMemorySceneRepository(), scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/me/streams/end_all", nil)
+	w := httptest.NewRecorder()
+	handlers.EndAllStreams(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
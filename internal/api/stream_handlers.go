@@ -5,15 +5,24 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/livekit/protocol/auth"
 	"github.com/livekit/protocol/livekit"
+	"github.com/onnwee/subcults/internal/analytics"
 	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/jsontime"
 	livekitpkg "github.com/onnwee/subcults/internal/livekit"
+	"github.com/onnwee/subcults/internal/membership"
 	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/replay"
 	"github.com/onnwee/subcults/internal/scene"
 	"github.com/onnwee/subcults/internal/stream"
 )
@@ -22,6 +31,14 @@ import (
 type CreateStreamRequest struct {
 	SceneID *string `json:"scene_id,omitempty"`
 	EventID *string `json:"event_id,omitempty"`
+	Region  *string `json:"region,omitempty"` // Optional LiveKit region; defaults to the configured home region
+
+	// MaxParticipants optionally caps concurrent participants; omitted or 0 means unlimited
+	MaxParticipants *int `json:"max_participants,omitempty"`
+
+	// ScheduledStartAt announces the stream ahead of time; the room isn't
+	// created until the host calls POST /streams/{id}/start
+	ScheduledStartAt *time.Time `json:"scheduled_start_at,omitempty"`
 }
 
 // StreamSessionResponse represents the response for stream session operations.
@@ -30,9 +47,16 @@ type StreamSessionResponse struct {
 	RoomName string  `json:"room_name"`
 	SceneID  *string `json:"scene_id,omitempty"`
 	EventID  *string `json:"event_id,omitempty"`
-	Status   string  `json:"status"` // "active" or "ended"
+	Region   string  `json:"region,omitempty"`
+	Status   string  `json:"status"` // "scheduled", "active" or "ended"
+
+	MaxParticipants  *int          `json:"max_participants,omitempty"`
+	ScheduledStartAt jsontime.Time `json:"scheduled_start_at,omitzero"`
 }
 
+// maxStreamScheduleLead is how far ahead a stream may be scheduled.
+const maxStreamScheduleLead = 90 * 24 * time.Hour
+
 // StreamHandlers holds dependencies for stream session HTTP handlers.
 type StreamHandlers struct {
 	streamRepo       stream.SessionRepository
@@ -44,6 +68,16 @@ type StreamHandlers struct {
 	streamMetrics    *stream.Metrics
 	eventBroadcaster *stream.EventBroadcaster
 	roomService      *livekitpkg.RoomService
+	membershipRepo   membership.MembershipRepository // Enforces members/followers-only join policies
+	blockRepo        scene.BlockRepository           // Rejects joins from DIDs blocked in the stream's scene
+	joinDebouncer    *stream.JoinDebouncer           // Coalesces rapid leave/re-join; nil records every leave immediately
+	regions          []string                        // LiveKit regions a stream may request; empty disables region selection
+	homeRegion       string                          // Region used when a stream does not request one
+	maxActivePerHost int                             // Active streams a host may run at once; 0 disables the limit
+	hostLimitExempt  map[string]bool                 // Host DIDs that bypass maxActivePerHost
+	adminDIDs        []string                        // DIDs allowed to call admin stream endpoints
+	webhookKeys      auth.KeyProvider                // LiveKit API keys that sign webhooks; nil rejects every webhook
+	replayGuard      *replay.Guard                   // Drops stale and duplicate webhook deliveries; nil disables
 }
 
 // NewStreamHandlers creates a new StreamHandlers instance.
@@ -71,6 +105,45 @@ func NewStreamHandlers(
 	}
 }
 
+// SetMembershipRepo installs the membership repository used to enforce join policies.
+// Without one, streams with a members_only or followers_only policy admit only the host.
+func (h *StreamHandlers) SetMembershipRepo(repo membership.MembershipRepository) {
+	h.membershipRepo = repo
+}
+
+// SetBlockRepo installs the scene block list consulted before recording a join.
+func (h *StreamHandlers) SetBlockRepo(repo scene.BlockRepository) {
+	h.blockRepo = repo
+}
+
+// SetJoinDebouncer installs the debouncer that holds back a leave's side effects
+// (leave count, analytics, participant_left broadcast) so a participant who
+// re-joins within the window is treated as one continuous session.
+func (h *StreamHandlers) SetJoinDebouncer(d *stream.JoinDebouncer) {
+	h.joinDebouncer = d
+}
+
+// SetRegions configures the LiveKit regions a new stream may request.
+// homeRegion is used when a request does not name one and should be listed in
+// regions. The region is recorded on the session for clients to route by;
+// LiveKit's create-room API has no region setting, so placement is left to
+// LiveKit's own node selection.
+func (h *StreamHandlers) SetRegions(regions []string, homeRegion string) {
+	h.regions = regions
+	h.homeRegion = homeRegion
+}
+
+// SetHostStreamLimit caps how many streams a single host may have active at
+// once. A non-positive max disables the limit. Hosts in exemptDIDs (e.g.
+// trusted broadcasters running several rooms) are never limited.
+func (h *StreamHandlers) SetHostStreamLimit(max int, exemptDIDs []string) {
+	h.maxActivePerHost = max
+	h.hostLimitExempt = make(map[string]bool, len(exemptDIDs))
+	for _, did := range exemptDIDs {
+		h.hostLimitExempt[did] = true
+	}
+}
+
 // CreateStream handles POST /streams - creates a new stream session.
 func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -85,9 +158,8 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var req CreateStreamRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, ctx, err)
 		return
 	}
 
@@ -111,6 +183,36 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 		req.EventID = &trimmed
 	}
 
+	// Resolve the stream region: an explicit request must name a configured region
+	region := h.homeRegion
+	if req.Region != nil && strings.TrimSpace(*req.Region) != "" {
+		region = strings.TrimSpace(*req.Region)
+		if !slices.Contains(h.regions, region) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Unknown region")
+			return
+		}
+	}
+
+	maxParticipants, ok := participantCap(req.MaxParticipants)
+	if !ok {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "max_participants must be 0 or greater")
+		return
+	}
+
+	var scheduledStartAt *time.Time
+	if req.ScheduledStartAt != nil {
+		startAt := req.ScheduledStartAt.UTC()
+		now := time.Now()
+		if !startAt.After(now) || startAt.After(now.Add(maxStreamScheduleLead)) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "scheduled_start_at must be in the future and within 90 days")
+			return
+		}
+		scheduledStartAt = &startAt
+	}
+
 	// Validate ownership
 	if sceneIDProvided {
 		// Check if user is the scene owner
@@ -210,6 +312,26 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Enforce the per-host soft limit on concurrent streams
+	if h.maxActivePerHost > 0 && !h.hostLimitExempt[userDID] {
+		active, err := h.streamRepo.ListActiveByHost(userDID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to count active streams for host",
+				"error", err,
+				"user_did", userDID,
+			)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			return
+		}
+		if len(active) >= h.maxActivePerHost {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict,
+				fmt.Sprintf("You already have %d active streams, the maximum allowed; end one before starting another", len(active)))
+			return
+		}
+	}
+
 	// Create stream session in database first
 	// The database has unique partial indexes that prevent race conditions by ensuring
 	// only one active stream per scene/event. If a concurrent request slips through the
@@ -235,36 +357,50 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create LiveKit room with 2-hour timeout (7200 seconds)
-	// emptyTimeout: room closes 2 hours after last participant leaves
-	// maxParticipants: 0 = unlimited
-	//
-	// IMPORTANT: If room creation fails, we still proceed with the stream creation in the database.
-	// This design choice provides resilience against temporary LiveKit API failures. The room
-	// will be created on-demand when the first participant joins via the JoinStream handler.
-	// This ensures users can always create streams even if LiveKit is temporarily unavailable.
-	if h.roomService != nil {
-		_, err = h.roomService.CreateRoom(ctx, roomName, 7200, 0)
-		if err != nil {
-			// Log error but don't fail the request - room may already exist or LiveKit may be temporarily down
-			// The room will be created on-demand during JoinStream if it doesn't exist
-			slog.WarnContext(ctx, "failed to create LiveKit room (will create on-demand during join)",
+	if region != "" {
+		if err := h.streamRepo.SetRegion(id, region); err != nil {
+			// Log error but don't fail the request - the region is informational
+			slog.ErrorContext(ctx, "failed to record stream region",
 				"error", err,
-				"room_name", roomName,
 				"stream_id", id,
+				"region", region,
 			)
-		} else {
-			slog.InfoContext(ctx, "created LiveKit room",
-				"room_name", roomName,
+		}
+	}
+
+	if maxParticipants != nil {
+		if err := h.streamRepo.SetMaxParticipants(id, maxParticipants); err != nil {
+			slog.ErrorContext(ctx, "failed to record stream participant cap",
+				"error", err,
+				"stream_id", id,
+			)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to create stream session")
+			return
+		}
+	}
+
+	// A scheduled stream's room is created when the host starts it
+	status := stream.StatusActive
+	if scheduledStartAt != nil {
+		if err := h.streamRepo.SetScheduledStart(id, *scheduledStartAt); err != nil {
+			slog.ErrorContext(ctx, "failed to record stream schedule",
+				"error", err,
 				"stream_id", id,
-				"empty_timeout", 7200,
 			)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to create stream session")
+			return
 		}
+		status = stream.StatusScheduled
+	} else {
+		h.createRoom(ctx, id, roomName, maxParticipants)
 	}
 
 	// Log stream creation for audit
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
 		EntityType: "stream_session",
 		EntityID:   id,
 		Action:     "created",
@@ -286,7 +422,11 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 		RoomName: roomName,
 		SceneID:  req.SceneID,
 		EventID:  req.EventID,
-		Status:   "active",
+		Region:   region,
+		Status:   status,
+
+		MaxParticipants:  maxParticipants,
+		ScheduledStartAt: jsontime.FromPtr(scheduledStartAt),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -296,8 +436,46 @@ func (h *StreamHandlers) CreateStream(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// EndStream handles POST /streams/{id}/end - ends a stream session.
-func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
+// createRoom creates the LiveKit room for a stream session that is going live.
+//
+// The room is created with a 2-hour timeout (7200 seconds), so it closes 2 hours
+// after the last participant leaves, and with the stream's participant cap,
+// which LiveKit enforces as well (0 = unlimited).
+//
+// IMPORTANT: If room creation fails, we still proceed with the stream in the database.
+// This design choice provides resilience against temporary LiveKit API failures. The room
+// will be created on-demand when the first participant joins via the JoinStream handler.
+// This ensures users can always create streams even if LiveKit is temporarily unavailable.
+func (h *StreamHandlers) createRoom(ctx context.Context, streamID, roomName string, maxParticipants *int) {
+	if h.roomService == nil {
+		return
+	}
+	var roomCap uint32
+	if maxParticipants != nil {
+		roomCap = uint32(*maxParticipants)
+	}
+	if _, err := h.roomService.CreateRoom(ctx, roomName, 7200, roomCap); err != nil {
+		// Log error but don't fail the request - room may already exist or LiveKit may be temporarily down
+		// The room will be created on-demand during JoinStream if it doesn't exist
+		slog.WarnContext(ctx, "failed to create LiveKit room (will create on-demand during join)",
+			"error", err,
+			"room_name", roomName,
+			"stream_id", streamID,
+		)
+		return
+	}
+	slog.InfoContext(ctx, "created LiveKit room",
+		"room_name", roomName,
+		"stream_id", streamID,
+		"empty_timeout", 7200,
+	)
+}
+
+// StartStream handles POST /streams/{id}/start - takes a scheduled stream live.
+// Creates the LiveKit room and moves the session from scheduled to active.
+// Only the stream host can start it; streams that are already live or have
+// ended are rejected with 409.
+func (h *StreamHandlers) StartStream(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Extract user DID from context (set by auth middleware)
@@ -309,16 +487,15 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Extract stream ID from URL path
-	// Expected: /streams/{id}/end
+	// Expected: /streams/{id}/start
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
-	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "end" {
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "start" {
 		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
 		return
 	}
 	streamID := pathParts[0]
 
-	// Get the stream session to verify ownership
 	session, err := h.streamRepo.GetByID(streamID)
 	if err != nil {
 		if errors.Is(err, stream.ErrStreamNotFound) {
@@ -335,23 +512,96 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 	// Verify that the user is the stream host
 	if session.HostDID != userDID {
 		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You must be the stream host to end it")
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the stream host can start the stream")
 		return
 	}
 
-	// End the stream session in database
-	err = h.streamRepo.EndStreamSession(streamID)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to end stream session",
+	switch session.Status() {
+	case stream.StatusEnded:
+		ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Stream has ended")
+		return
+	case stream.StatusActive:
+		ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Stream is already live")
+		return
+	}
+
+	if err := h.streamRepo.StartScheduledSession(streamID); err != nil {
+		if errors.Is(err, stream.ErrStreamNotScheduled) {
+			// Started or ended concurrently
+			ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Stream is not scheduled")
+			return
+		}
+		slog.ErrorContext(ctx, "failed to start scheduled stream",
 			"error", err,
 			"stream_id", streamID,
-			"user_did", userDID,
 		)
 		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to end stream session")
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to start stream")
 		return
 	}
 
+	h.createRoom(ctx, streamID, session.RoomName, session.MaxParticipants)
+
+	// Log action for audit
+	auditEntry := audit.LogEntry{
+		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
+		EntityType: "stream_session",
+		EntityID:   streamID,
+		Action:     "started",
+		RequestID:  middleware.GetRequestID(ctx),
+	}
+
+	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
+		// Log error but don't fail the request
+		slog.ErrorContext(ctx, "failed to log stream start audit entry",
+			"error", err,
+			"stream_id", streamID,
+		)
+	}
+
+	response := StreamSessionResponse{
+		ID:       streamID,
+		RoomName: session.RoomName,
+		SceneID:  session.SceneID,
+		EventID:  session.EventID,
+		Region:   session.Region,
+		Status:   stream.StatusActive,
+
+		MaxParticipants: session.MaxParticipants,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode stream response", "error", err)
+	}
+}
+
+// endSessionOutcome reports which best-effort cleanup steps succeeded after a
+// stream session was ended.
+type endSessionOutcome struct {
+	roomDeleted       bool
+	analyticsComputed bool
+}
+
+// endSession ends a stream session, deletes its LiveKit room, computes analytics,
+// and records an audit entry. Only the database write is fatal; room deletion,
+// analytics, and audit failures are logged and reported in the outcome. An empty
+// userDID means the session was ended by reconciling LiveKit state, and the
+// audit entry is recorded as a system action.
+func (h *StreamHandlers) endSession(ctx context.Context, session *stream.Session, userDID string) (endSessionOutcome, error) {
+	var outcome endSessionOutcome
+	streamID := session.ID
+
+	// End the stream session in database
+	if err := h.streamRepo.EndStreamSession(streamID); err != nil {
+		return outcome, err
+	}
+
 	// Delete LiveKit room to disconnect all participants
 	// KNOWN LIMITATION: If room deletion fails with a non-retryable error after the database
 	// write succeeds, the stream will appear "ended" in the database but participants may remain
@@ -362,10 +612,11 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 	// operators can use LiveKit's admin API or dashboard to manually clean up orphaned rooms
 	// if needed (rooms where ended_at IS NOT NULL but LiveKit room still exists).
 	if h.roomService != nil {
-		err = h.roomService.DeleteRoom(ctx, session.RoomName)
+		err := h.roomService.DeleteRoom(ctx, session.RoomName)
 		if err != nil {
 			// Check if this is a "room not found" error (already deleted is acceptable)
 			if errors.Is(err, livekitpkg.ErrRoomNotFound) {
+				outcome.roomDeleted = true
 				slog.InfoContext(ctx, "LiveKit room already deleted",
 					"room_name", session.RoomName,
 					"stream_id", streamID,
@@ -381,6 +632,7 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 				)
 			}
 		} else {
+			outcome.roomDeleted = true
 			slog.InfoContext(ctx, "deleted LiveKit room",
 				"room_name", session.RoomName,
 				"stream_id", streamID,
@@ -390,7 +642,7 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 
 	// Compute analytics for the ended stream
 	if h.analyticsRepo != nil {
-		_, err = h.analyticsRepo.ComputeAnalytics(streamID)
+		_, err := h.analyticsRepo.ComputeAnalytics(streamID)
 		if err != nil {
 			// Log error but don't fail the request
 			slog.ErrorContext(ctx, "failed to compute stream analytics",
@@ -399,6 +651,7 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 				"user_did", userDID,
 			)
 		} else {
+			outcome.analyticsComputed = true
 			slog.InfoContext(ctx, "computed stream analytics",
 				"stream_id", streamID,
 				"user_did", userDID,
@@ -406,14 +659,25 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Release the stream's real-time subscriber registry; connected
+	// subscribers are disconnected after the broadcaster's ended retention
+	if h.eventBroadcaster != nil {
+		h.eventBroadcaster.StreamEnded(streamID)
+	}
+
 	// Log stream ending for audit
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
 		EntityType: "stream_session",
 		EntityID:   streamID,
 		Action:     "ended",
 		RequestID:  middleware.GetRequestID(ctx),
 	}
+	if userDID == "" {
+		auditEntry.ActorType = audit.ActorSystem
+		auditEntry.ActorID = audit.SystemActorReconciler
+	}
 
 	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
 		// Log error but don't fail the request
@@ -424,24 +688,11 @@ func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 		)
 	}
 
-	// Return response
-	response := StreamSessionResponse{
-		ID:       streamID,
-		RoomName: session.RoomName,
-		SceneID:  session.SceneID,
-		EventID:  session.EventID,
-		Status:   "ended",
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		slog.ErrorContext(ctx, "failed to encode stream response", "error", err)
-	}
+	return outcome, nil
 }
 
-// GetStream handles GET /streams/{id} - retrieves stream session details.
-func (h *StreamHandlers) GetStream(w http.ResponseWriter, r *http.Request) {
+// EndStream handles POST /streams/{id}/end - ends a stream session.
+func (h *StreamHandlers) EndStream(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Extract user DID from context (set by auth middleware)
@@ -453,15 +704,16 @@ func (h *StreamHandlers) GetStream(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Extract stream ID from URL path
-	// Expected: /streams/{id}
-	streamID := strings.TrimPrefix(r.URL.Path, "/streams/")
-	if streamID == "" || strings.Contains(streamID, "/") {
+	// Expected: /streams/{id}/end
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "end" {
 		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
 		return
 	}
+	streamID := pathParts[0]
 
-	// Get the stream session
+	// Get the stream session to verify ownership
 	session, err := h.streamRepo.GetByID(streamID)
 	if err != nil {
 		if errors.Is(err, stream.ErrStreamNotFound) {
@@ -475,19 +727,34 @@ func (h *StreamHandlers) GetStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Determine status
-	status := "active"
-	if session.EndedAt != nil {
-		status = "ended"
+	// Verify that the user is the stream host
+	if session.HostDID != userDID {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You must be the stream host to end it")
+		return
+	}
+
+	if _, err := h.endSession(ctx, session, userDID); err != nil {
+		slog.ErrorContext(ctx, "failed to end stream session",
+			"error", err,
+			"stream_id", streamID,
+			"user_did", userDID,
+		)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to end stream session")
+		return
 	}
 
 	// Return response
 	response := StreamSessionResponse{
-		ID:       session.ID,
+		ID:       streamID,
 		RoomName: session.RoomName,
 		SceneID:  session.SceneID,
 		EventID:  session.EventID,
-		Status:   status,
+		Region:   session.Region,
+		Status:   "ended",
+
+		MaxParticipants: session.MaxParticipants,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -497,13 +764,88 @@ func (h *StreamHandlers) GetStream(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// UpdateStreamRequest represents the request body for updating stream metadata.
-type UpdateStreamRequest struct {
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+// EndAllStreamResult reports the outcome of ending one stream in an end-all request.
+type EndAllStreamResult struct {
+	StreamID          string  `json:"stream_id"`
+	RoomName          string  `json:"room_name"`
+	SceneID           *string `json:"scene_id,omitempty"`
+	EventID           *string `json:"event_id,omitempty"`
+	Status            string  `json:"status"` // "ended" or "failed"
+	RoomDeleted       bool    `json:"room_deleted"`
+	AnalyticsComputed bool    `json:"analytics_computed"`
+	Error             string  `json:"error,omitempty"`
 }
 
-// UpdateStream handles PATCH /streams/{id} - updates stream metadata.
-func (h *StreamHandlers) UpdateStream(w http.ResponseWriter, r *http.Request) {
+// EndAllStreamsResponse represents the response for POST /me/streams/end_all.
+type EndAllStreamsResponse struct {
+	Results     []EndAllStreamResult `json:"results"`
+	EndedCount  int                  `json:"ended_count"`
+	FailedCount int                  `json:"failed_count"`
+}
+
+// EndAllStreams handles POST /me/streams/end_all - ends every active stream hosted
+// by the authenticated user. A failure to end one stream does not stop the others;
+// each stream's outcome is reported individually.
+func (h *StreamHandlers) EndAllStreams(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	sessions, err := h.streamRepo.ListActiveByHost(userDID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list active streams for host", "error", err, "user_did", userDID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to list active streams")
+		return
+	}
+
+	response := EndAllStreamsResponse{
+		Results: make([]EndAllStreamResult, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		result := EndAllStreamResult{
+			StreamID: session.ID,
+			RoomName: session.RoomName,
+			SceneID:  session.SceneID,
+			EventID:  session.EventID,
+			Status:   "ended",
+		}
+
+		outcome, err := h.endSession(ctx, session, userDID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to end stream session",
+				"error", err,
+				"stream_id", session.ID,
+				"user_did", userDID,
+			)
+			result.Status = "failed"
+			result.Error = "Failed to end stream session"
+			response.FailedCount++
+		} else {
+			result.RoomDeleted = outcome.roomDeleted
+			result.AnalyticsComputed = outcome.analyticsComputed
+			response.EndedCount++
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode end all streams response", "error", err)
+	}
+}
+
+// GetStream handles GET /streams/{id} - retrieves stream session details, enriched
+// with a scene preview and, for the host of an ended stream, its analytics.
+func (h *StreamHandlers) GetStream(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Extract user DID from context (set by auth middleware)
@@ -523,7 +865,7 @@ func (h *StreamHandlers) UpdateStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get the stream session to verify ownership
+	// Get the stream session
 	session, err := h.streamRepo.GetByID(streamID)
 	if err != nil {
 		if errors.Is(err, stream.ErrStreamNotFound) {
@@ -537,30 +879,815 @@ func (h *StreamHandlers) UpdateStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify that the user is the stream host
-	if session.HostDID != userDID {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
-		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You must be the stream host to update it")
-		return
-	}
+	// Determine status
+	status := session.Status()
+
+	// Build response; optional enrichments degrade to warnings on failure
+	response := StreamDetailResponse{
+		StreamSessionResponse: StreamSessionResponse{
+			ID:       session.ID,
+			RoomName: session.RoomName,
+			SceneID:  session.SceneID,
+			EventID:  session.EventID,
+			Region:   session.Region,
+			Status:   status,
+
+			MaxParticipants:  session.MaxParticipants,
+			ScheduledStartAt: jsontime.FromPtr(session.ScheduledStartAt),
+		},
+	}
+	response.Enrich(ctx, "scene", func() error {
+		return h.enrichStreamScene(&response, session, userDID)
+	})
+	response.Enrich(ctx, "analytics", func() error {
+		return h.enrichStreamAnalytics(ctx, &response, session, userDID)
+	})
 
-	// Parse request body
-	var req UpdateStreamRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
-		return
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode stream response", "error", err)
 	}
+}
 
-	// Update room metadata in LiveKit if metadata is provided
-	if req.Metadata != nil && h.roomService != nil {
-		// Convert metadata to JSON string
-		metadataJSON, err := json.Marshal(req.Metadata)
-		if err != nil {
-			slog.ErrorContext(ctx, "failed to marshal metadata", "error", err)
-			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to process metadata")
-			return
+// StreamScenePreview is a lightweight summary of a stream's scene.
+type StreamScenePreview struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	CoarseGeohash string `json:"coarse_geohash"`
+}
+
+// StreamDetailResponse is the response for GET /streams/{id}. The scene preview
+// and analytics are optional enrichments; see PartialResponse.
+type StreamDetailResponse struct {
+	StreamSessionResponse
+	Scene     *StreamScenePreview        `json:"scene,omitempty"`
+	Analytics map[string]json.RawMessage `json:"analytics,omitempty"` // Host only, after the stream ends
+	PartialResponse
+}
+
+// enrichStreamScene attaches a preview of the stream's scene when the requester
+// can see it. Missing or deleted scenes are omitted without a warning.
+func (h *StreamHandlers) enrichStreamScene(response *StreamDetailResponse, session *stream.Session, userDID string) error {
+	if session.SceneID == nil || h.sceneRepo == nil {
+		return nil
+	}
+	s, err := h.sceneRepo.GetByID(*session.SceneID)
+	if err != nil {
+		if errors.Is(err, scene.ErrSceneNotFound) || errors.Is(err, scene.ErrSceneDeleted) {
+			return nil
+		}
+		return err
+	}
+	if s.Visibility != scene.VisibilityPublic && !s.IsOwner(userDID) {
+		return nil
+	}
+	response.Scene = &StreamScenePreview{
+		ID:            s.ID,
+		Name:          s.Name,
+		CoarseGeohash: s.CoarseGeohash,
+	}
+	return nil
+}
+
+// enrichStreamAnalytics attaches redacted analytics for the host of an ended
+// stream. Analytics that haven't been computed yet are omitted without a warning.
+func (h *StreamHandlers) enrichStreamAnalytics(ctx context.Context, response *StreamDetailResponse, session *stream.Session, userDID string) error {
+	if h.analyticsRepo == nil || session.EndedAt == nil || session.HostDID != userDID {
+		return nil
+	}
+	result, err := h.analyticsRepo.GetAnalytics(session.ID)
+	if err != nil {
+		if errors.Is(err, stream.ErrAnalyticsNotFound) {
+			return nil
+		}
+		return err
+	}
+	redacted, stripped, err := analytics.Redact(result, analytics.StreamAnalyticsFields)
+	if err != nil {
+		return err
+	}
+	if len(stripped) > 0 {
+		slog.WarnContext(ctx, "stripped non-allowlisted analytics fields",
+			"stream_id", session.ID,
+			"fields", stripped,
+		)
+	}
+	response.Analytics = redacted
+	return nil
+}
+
+// Stream list pagination limits.
+const (
+	DefaultStreamListLimit = 20
+	MaxStreamListLimit     = 100
+)
+
+// StreamListItem is one stream in a GET /streams listing.
+type StreamListItem struct {
+	StreamSessionResponse
+	HostDID   string        `json:"host_did"`
+	StartedAt jsontime.Time `json:"started_at,omitzero"`
+	EndedAt   jsontime.Time `json:"ended_at,omitzero"`
+}
+
+// StreamListResponse is the response for GET /streams.
+type StreamListResponse struct {
+	Streams    []StreamListItem `json:"streams"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// ListStreams handles GET /streams - lists scheduled, active and historical streams,
+// newest first, filtered by status (scheduled/active/ended) and a from/to window on
+// start time.
+// Ended streams in non-public scenes are listed only for their host, so a page
+// may hold fewer than limit streams while next_cursor is still set.
+func (h *StreamHandlers) ListStreams(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	query := r.URL.Query()
+	opts := stream.ListOptions{
+		Status: query.Get("status"),
+		Limit:  DefaultStreamListLimit,
+		Cursor: query.Get("cursor"),
+	}
+	switch opts.Status {
+	case "", stream.StatusScheduled, stream.StatusActive, stream.StatusEnded:
+	default:
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "status must be one of: scheduled, active, ended")
+		return
+	}
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "invalid 'from' timestamp, must be RFC3339 format")
+			return
+		}
+		opts.From = from
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "invalid 'to' timestamp, must be RFC3339 format")
+			return
+		}
+		opts.To = to
+	}
+	if !opts.From.IsZero() && !opts.To.IsZero() && !opts.From.Before(opts.To) {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "'from' must be before 'to'")
+		return
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := parseIntInRange(limitStr, "limit", 1, MaxStreamListLimit)
+		if err != nil {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+		opts.Limit = limit
+	}
+
+	sessions, nextCursor, err := h.streamRepo.ListStreams(opts)
+	if err != nil {
+		if errors.Is(err, stream.ErrInvalidCursor) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid cursor")
+			return
+		}
+		slog.ErrorContext(ctx, "failed to list stream sessions", "error", err)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+
+	response := StreamListResponse{
+		Streams:    make([]StreamListItem, 0, len(sessions)),
+		NextCursor: nextCursor,
+	}
+	for _, session := range sessions {
+		if session.EndedAt != nil && session.HostDID != userDID {
+			private, err := h.isPrivateStream(session)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to check stream visibility", "error", err, "stream_id", session.ID)
+				ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+				WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+				return
+			}
+			if private {
+				continue
+			}
+		}
+
+		response.Streams = append(response.Streams, newStreamListItem(session))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode stream list response", "error", err)
+	}
+}
+
+// newStreamListItem converts a session into a stream listing entry.
+func newStreamListItem(session *stream.Session) StreamListItem {
+	status := session.Status()
+	return StreamListItem{
+		StreamSessionResponse: StreamSessionResponse{
+			ID:       session.ID,
+			RoomName: session.RoomName,
+			SceneID:  session.SceneID,
+			EventID:  session.EventID,
+			Region:   session.Region,
+			Status:   status,
+
+			MaxParticipants:  session.MaxParticipants,
+			ScheduledStartAt: jsontime.FromPtr(session.ScheduledStartAt),
+		},
+		HostDID:   session.HostDID,
+		StartedAt: jsontime.New(session.StartedAt),
+		EndedAt:   jsontime.FromPtr(session.EndedAt),
+	}
+}
+
+// isPrivateStream reports whether a stream belongs to a scene that isn't public,
+// either directly or through its event. Streams whose scene or event can no
+// longer be found are treated as private.
+func (h *StreamHandlers) isPrivateStream(session *stream.Session) (bool, error) {
+	sceneID := session.SceneID
+	if sceneID == nil && session.EventID != nil && h.eventRepo != nil {
+		event, err := h.eventRepo.GetByID(*session.EventID)
+		if err != nil {
+			if errors.Is(err, scene.ErrEventNotFound) {
+				return true, nil
+			}
+			return false, err
+		}
+		sceneID = &event.SceneID
+	}
+	if sceneID == nil || h.sceneRepo == nil {
+		return true, nil
+	}
+	s, err := h.sceneRepo.GetByID(*sceneID)
+	if err != nil {
+		if errors.Is(err, scene.ErrSceneNotFound) || errors.Is(err, scene.ErrSceneDeleted) {
+			return true, nil
+		}
+		return false, err
+	}
+	return s.Visibility != scene.VisibilityPublic, nil
+}
+
+// Scene stream states for GET /scenes/{id}/streams.
+const (
+	SceneStreamStateLive      = "live"
+	SceneStreamStateScheduled = "scheduled"
+	SceneStreamStateAll       = "all"
+)
+
+// SceneStreamItem is one live or scheduled stream in a scene's stream listing.
+// Live streams carry the session and its start time; scheduled streams are
+// upcoming events without an active stream and carry the event's start time.
+type SceneStreamItem struct {
+	State            string        `json:"state"` // live or scheduled
+	StreamSessionID  string        `json:"stream_session_id,omitempty"`
+	EventID          *string       `json:"event_id,omitempty"`
+	Title            string        `json:"title,omitempty"` // Event title, if the stream belongs to an event
+	HostDID          string        `json:"host_did,omitempty"`
+	StartedAt        jsontime.Time `json:"started_at,omitzero"`
+	ScheduledStartAt jsontime.Time `json:"scheduled_start_at,omitzero"`
+	ParticipantCount int           `json:"participant_count"`
+}
+
+// SceneStreamsResponse is the response for GET /scenes/{id}/streams.
+type SceneStreamsResponse struct {
+	Streams []SceneStreamItem `json:"streams"`
+}
+
+// ListSceneStreams handles GET /scenes/{id}/streams?state=live|scheduled|all -
+// lists a scene's live streams (oldest first) followed by its scheduled ones
+// (soonest first). Scenes that aren't public are reported as not found unless
+// the requester is the owner or an active member.
+func (h *StreamHandlers) ListSceneStreams(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Expected: /scenes/{id}/streams
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "streams" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		return
+	}
+	sceneID := pathParts[0]
+
+	state := r.URL.Query().Get("state")
+	if state == "" {
+		state = SceneStreamStateAll
+	}
+	if state != SceneStreamStateLive && state != SceneStreamStateScheduled && state != SceneStreamStateAll {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "state must be one of: live, scheduled, all")
+		return
+	}
+
+	s, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil {
+		if errors.Is(err, scene.ErrSceneNotFound) || errors.Is(err, scene.ErrSceneDeleted) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeSceneNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(ctx, "failed to get scene", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+	visible, err := sceneVisibleTo(h.membershipRepo, s, userDID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check scene visibility", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+	if !visible {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeSceneNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneNotFound, "Scene not found")
+		return
+	}
+
+	events, err := h.eventRepo.ListByScene(sceneID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list scene events", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+	eventIDs := make([]string, len(events))
+	titles := make(map[string]string, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.ID
+		titles[event.ID] = event.Title
+	}
+
+	sessions, err := h.streamRepo.ListActiveForScene(sceneID, eventIDs)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list scene streams", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+
+	response := SceneStreamsResponse{Streams: make([]SceneStreamItem, 0)}
+	liveEvents := make(map[string]bool)
+	for _, session := range sessions {
+		if session.EventID != nil {
+			liveEvents[*session.EventID] = true
+		}
+		if state == SceneStreamStateScheduled {
+			continue
+		}
+		item := SceneStreamItem{
+			State:            SceneStreamStateLive,
+			StreamSessionID:  session.ID,
+			EventID:          session.EventID,
+			HostDID:          session.HostDID,
+			StartedAt:        jsontime.New(session.StartedAt),
+			ParticipantCount: session.ActiveParticipantCount,
+		}
+		if session.EventID != nil {
+			item.Title = titles[*session.EventID]
+		}
+		response.Streams = append(response.Streams, item)
+	}
+
+	// Events come back sorted by start time, so scheduled streams are soonest first
+	if state != SceneStreamStateLive {
+		now := time.Now()
+		for _, event := range events {
+			if event.IsCancelled() || !event.IsPublished() || liveEvents[event.ID] || !event.StartsAt.After(now) {
+				continue
+			}
+			eventID := event.ID
+			response.Streams = append(response.Streams, SceneStreamItem{
+				State:            SceneStreamStateScheduled,
+				EventID:          &eventID,
+				Title:            event.Title,
+				ScheduledStartAt: jsontime.New(event.StartsAt),
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode scene streams response", "error", err)
+	}
+}
+
+// ListSceneStreamHistory handles GET /scenes/{id}/streams/history - lists a
+// scene's active and ended streams, including those of its events, newest first
+// with cursor pagination. The scene owner sees every stream; everyone else sees
+// only ended streams, and only when the scene is public. Scenes the requester
+// can't view are reported as not found.
+func (h *StreamHandlers) ListSceneStreamHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Expected: /scenes/{id}/streams/history
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) != 3 || pathParts[0] == "" || pathParts[1] != "streams" || pathParts[2] != "history" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		return
+	}
+	sceneID := pathParts[0]
+
+	query := r.URL.Query()
+	limit := DefaultStreamListLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := parseIntInRange(limitStr, "limit", 1, MaxStreamListLimit)
+		if err != nil {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+		limit = parsed
+	}
+	cursor, err := stream.DecodeListCursor(query.Get("cursor"))
+	if err != nil {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid cursor")
+		return
+	}
+
+	s, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil {
+		if errors.Is(err, scene.ErrSceneNotFound) || errors.Is(err, scene.ErrSceneDeleted) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeSceneNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(ctx, "failed to get scene", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+	visible, err := sceneVisibleTo(h.membershipRepo, s, userDID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check scene visibility", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+	if !visible {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeSceneNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneNotFound, "Scene not found")
+		return
+	}
+
+	response := StreamListResponse{Streams: make([]StreamListItem, 0)}
+	isOwner := s.IsOwner(userDID)
+	if isOwner || s.Visibility == scene.VisibilityPublic {
+		events, err := h.eventRepo.ListByScene(sceneID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to list scene events", "error", err, "scene_id", sceneID)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			return
+		}
+		eventIDs := make([]string, len(events))
+		for i, event := range events {
+			eventIDs[i] = event.ID
+		}
+
+		sessions, next, err := h.listSceneSessions(sceneID, eventIDs, limit, cursor, func(session *stream.Session) bool {
+			return isOwner || session.EndedAt != nil
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to list scene stream history", "error", err, "scene_id", sceneID)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			return
+		}
+		if next != nil {
+			response.NextCursor = stream.EncodeListCursor(next.StartedAt, next.ID)
+		}
+		for _, session := range sessions {
+			response.Streams = append(response.Streams, newStreamListItem(session))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode scene stream history response", "error", err)
+	}
+}
+
+// SceneRecordingItem is one recorded stream in a GET /scenes/{id}/recordings
+// listing.
+type SceneRecordingItem struct {
+	StreamSessionID string        `json:"stream_session_id"`
+	EventID         *string       `json:"event_id,omitempty"`
+	Title           string        `json:"title,omitempty"`
+	HostDID         string        `json:"host_did"`
+	RecordingURL    string        `json:"recording_url"`
+	StartedAt       jsontime.Time `json:"started_at,omitzero"`
+	EndedAt         jsontime.Time `json:"ended_at,omitzero"`
+	DurationSeconds int           `json:"duration_seconds"`
+
+	// PeakConcurrentListeners is omitted until the stream's analytics are computed.
+	PeakConcurrentListeners *int `json:"peak_concurrent_listeners,omitempty"`
+}
+
+// SceneRecordingsResponse is the JSON response for GET /scenes/{id}/recordings.
+type SceneRecordingsResponse struct {
+	Recordings []SceneRecordingItem `json:"recordings"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+// ListSceneRecordings handles GET /scenes/{id}/recordings - lists the scene's
+// ended streams that produced a recording, including those of its events,
+// newest first with cursor pagination. Recordings of a scene that isn't public
+// are listed only for its owner; scenes the requester can't view are reported
+// as not found.
+func (h *StreamHandlers) ListSceneRecordings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Expected: /scenes/{id}/recordings
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scenes/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "recordings" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		return
+	}
+	sceneID := pathParts[0]
+
+	query := r.URL.Query()
+	limit := DefaultStreamListLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := parseIntInRange(limitStr, "limit", 1, MaxStreamListLimit)
+		if err != nil {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+		limit = parsed
+	}
+	cursor, err := stream.DecodeListCursor(query.Get("cursor"))
+	if err != nil {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "Invalid cursor")
+		return
+	}
+
+	s, err := h.sceneRepo.GetByID(sceneID)
+	if err != nil {
+		if errors.Is(err, scene.ErrSceneNotFound) || errors.Is(err, scene.ErrSceneDeleted) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeSceneNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneNotFound, "Scene not found")
+			return
+		}
+		slog.ErrorContext(ctx, "failed to get scene", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+	visible, err := sceneVisibleTo(h.membershipRepo, s, userDID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check scene visibility", "error", err, "scene_id", sceneID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+	if !visible {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeSceneNotFound)
+		WriteError(w, ctx, http.StatusNotFound, ErrCodeSceneNotFound, "Scene not found")
+		return
+	}
+
+	response := SceneRecordingsResponse{Recordings: make([]SceneRecordingItem, 0)}
+	if s.IsOwner(userDID) || s.Visibility == scene.VisibilityPublic {
+		events, err := h.eventRepo.ListByScene(sceneID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to list scene events", "error", err, "scene_id", sceneID)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			return
+		}
+		eventIDs := make([]string, len(events))
+		titles := make(map[string]string, len(events))
+		for i, event := range events {
+			eventIDs[i] = event.ID
+			titles[event.ID] = event.Title
+		}
+
+		sessions, next, err := h.listSceneSessions(sceneID, eventIDs, limit, cursor, func(session *stream.Session) bool {
+			return session.EndedAt != nil && session.RecordingURL != nil
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to list scene recordings", "error", err, "scene_id", sceneID)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			return
+		}
+		if next != nil {
+			response.NextCursor = stream.EncodeListCursor(next.StartedAt, next.ID)
+		}
+		for _, session := range sessions {
+			item, err := h.newSceneRecordingItem(session)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to get stream analytics", "error", err, "stream_id", session.ID)
+				ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+				WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+				return
+			}
+			if session.EventID != nil {
+				item.Title = titles[*session.EventID]
+			}
+			response.Recordings = append(response.Recordings, item)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode scene recordings response", "error", err)
+	}
+}
+
+// newSceneRecordingItem converts an ended, recorded session into a recordings
+// listing entry. Duration and peak concurrency come from the stream's
+// analytics; until those are computed, the duration is the session's span.
+func (h *StreamHandlers) newSceneRecordingItem(session *stream.Session) (SceneRecordingItem, error) {
+	item := SceneRecordingItem{
+		StreamSessionID: session.ID,
+		EventID:         session.EventID,
+		HostDID:         session.HostDID,
+		RecordingURL:    *session.RecordingURL,
+		StartedAt:       jsontime.New(session.StartedAt),
+		EndedAt:         jsontime.FromPtr(session.EndedAt),
+		DurationSeconds: int(session.EndedAt.Sub(session.StartedAt).Seconds()),
+	}
+	if h.analyticsRepo == nil {
+		return item, nil
+	}
+	result, err := h.analyticsRepo.GetAnalytics(session.ID)
+	if err != nil {
+		if errors.Is(err, stream.ErrAnalyticsNotFound) {
+			return item, nil
+		}
+		return item, err
+	}
+	item.DurationSeconds = result.StreamDurationSeconds
+	peak := result.PeakConcurrentListeners
+	item.PeakConcurrentListeners = &peak
+	return item, nil
+}
+
+// listSceneSessions pages through a scene's sessions like ListByScene, keeping
+// only those keep accepts. It fetches further pages until limit sessions are
+// kept or the history runs out, so filtering never shortens a page; the
+// returned cursor is nil once there is nothing left to list.
+func (h *StreamHandlers) listSceneSessions(sceneID string, eventIDs []string, limit int, cursor *stream.ListCursor, keep func(*stream.Session) bool) ([]*stream.Session, *stream.ListCursor, error) {
+	kept := make([]*stream.Session, 0, limit)
+	for {
+		sessions, next, err := h.streamRepo.ListByScene(sceneID, eventIDs, limit, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, session := range sessions {
+			if !keep(session) {
+				continue
+			}
+			kept = append(kept, session)
+			if len(kept) == limit {
+				if next == nil && i == len(sessions)-1 {
+					return kept, nil, nil
+				}
+				return kept, &stream.ListCursor{StartedAt: session.StartedAt, ID: session.ID}, nil
+			}
+		}
+		if next == nil {
+			return kept, nil, nil
+		}
+		cursor = next
+	}
+}
+
+// UpdateStreamRequest represents the request body for updating stream metadata.
+type UpdateStreamRequest struct {
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// MaxParticipants sets the participant cap; 0 removes it. Participants
+	// already in the stream are not removed when the cap is lowered.
+	MaxParticipants *int `json:"max_participants,omitempty"`
+}
+
+// UpdateStream handles PATCH /streams/{id} - updates stream metadata and the
+// participant cap.
+func (h *StreamHandlers) UpdateStream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Extract stream ID from URL path
+	// Expected: /streams/{id}
+	streamID := strings.TrimPrefix(r.URL.Path, "/streams/")
+	if streamID == "" || strings.Contains(streamID, "/") {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		return
+	}
+
+	// Get the stream session to verify ownership
+	session, err := h.streamRepo.GetByID(streamID)
+	if err != nil {
+		if errors.Is(err, stream.ErrStreamNotFound) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Stream session not found")
+		} else {
+			slog.ErrorContext(ctx, "failed to get stream session", "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		}
+		return
+	}
+
+	// Verify that the user is the stream host
+	if session.HostDID != userDID {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "You must be the stream host to update it")
+		return
+	}
+
+	// Parse request body
+	var req UpdateStreamRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, ctx, err)
+		return
+	}
+
+	// Validate the cap before changing anything
+	var maxParticipants *int
+	if req.MaxParticipants != nil {
+		var ok bool
+		if maxParticipants, ok = participantCap(req.MaxParticipants); !ok {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "max_participants must be 0 or greater")
+			return
+		}
+	}
+
+	// Update room metadata in LiveKit if metadata is provided
+	if req.Metadata != nil && h.roomService != nil {
+		// Convert metadata to JSON string
+		metadataJSON, err := json.Marshal(req.Metadata)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to marshal metadata", "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to process metadata")
+			return
 		}
 
 		// Update room metadata in LiveKit
@@ -576,9 +1703,25 @@ func (h *StreamHandlers) UpdateStream(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// LiveKit only enforces the cap the room was created with, so later
+	// changes are enforced by JoinStream alone
+	if req.MaxParticipants != nil {
+		if err := h.streamRepo.SetMaxParticipants(streamID, maxParticipants); err != nil {
+			slog.ErrorContext(ctx, "failed to update stream participant cap",
+				"error", err,
+				"stream_id", streamID,
+			)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update participant cap")
+			return
+		}
+		session.MaxParticipants = maxParticipants
+	}
+
 	// Log metadata update for audit
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
 		EntityType: "stream_session",
 		EntityID:   streamID,
 		Action:     "metadata_updated",
@@ -595,10 +1738,7 @@ func (h *StreamHandlers) UpdateStream(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Determine status
-	status := "active"
-	if session.EndedAt != nil {
-		status = "ended"
-	}
+	status := session.Status()
 
 	// Return response
 	response := StreamSessionResponse{
@@ -606,7 +1746,11 @@ func (h *StreamHandlers) UpdateStream(w http.ResponseWriter, r *http.Request) {
 		RoomName: session.RoomName,
 		SceneID:  session.SceneID,
 		EventID:  session.EventID,
+		Region:   session.Region,
 		Status:   status,
+
+		MaxParticipants:  session.MaxParticipants,
+		ScheduledStartAt: jsontime.FromPtr(session.ScheduledStartAt),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -614,15 +1758,91 @@ func (h *StreamHandlers) UpdateStream(w http.ResponseWriter, r *http.Request) {
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		slog.ErrorContext(ctx, "failed to encode stream response", "error", err)
 	}
-}
+}
+
+// isSceneOwner checks if the given userDID owns the scene.
+func (h *StreamHandlers) isSceneOwner(ctx context.Context, sceneID, userDID string) (bool, error) {
+	return scene.CheckOwnership(h.sceneRepo, sceneID, userDID)
+}
+
+// sessionSceneID returns the scene a stream belongs to: its own scene, or its
+// event's scene for event streams. Returns "" if the stream has neither.
+func (h *StreamHandlers) sessionSceneID(session *stream.Session) (string, error) {
+	if session.SceneID != nil && *session.SceneID != "" {
+		return *session.SceneID, nil
+	}
+	if session.EventID != nil && *session.EventID != "" {
+		event, err := h.eventRepo.GetByID(*session.EventID)
+		if err != nil {
+			return "", err
+		}
+		return event.SceneID, nil
+	}
+	return "", nil
+}
+
+// joinPolicyAllows checks the user's membership in the stream's scene against
+// its join policy. Event streams use the event's scene.
+func (h *StreamHandlers) joinPolicyAllows(session *stream.Session, userDID string) (bool, error) {
+	if h.membershipRepo == nil {
+		return false, nil
+	}
+
+	sceneID, err := h.sessionSceneID(session)
+	if err != nil || sceneID == "" {
+		return false, err
+	}
+
+	m, err := h.membershipRepo.GetBySceneAndUser(sceneID, userDID)
+	if err != nil {
+		if errors.Is(err, membership.ErrMembershipNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return session.JoinPolicy.Allows(m.Role, m.Status), nil
+}
+
+// participantCap converts a requested participant cap to the form stored on a
+// session: nil or 0 means unlimited. Reports false for negative values or caps
+// too large for LiveKit.
+func participantCap(requested *int) (*int, bool) {
+	if requested == nil || *requested == 0 {
+		return nil, true
+	}
+	if *requested < 0 || int64(*requested) > math.MaxUint32 {
+		return nil, false
+	}
+	capCopy := *requested
+	return &capCopy, true
+}
+
+// streamFull reports whether a capped stream has no room for userDID. A
+// participant who is already active keeps their place, so a duplicate join
+// after a page reload isn't turned away.
+func (h *StreamHandlers) streamFull(session *stream.Session, userDID string) (bool, error) {
+	if h.participantRepo == nil {
+		return false, nil
+	}
+	active, err := h.participantRepo.GetActiveCount(session.ID)
+	if err != nil {
+		return false, err
+	}
+	if active < *session.MaxParticipants {
+		return false, nil
+	}
 
-// isSceneOwner checks if the given userDID owns the scene.
-func (h *StreamHandlers) isSceneOwner(ctx context.Context, sceneID, userDID string) (bool, error) {
-	foundScene, err := h.sceneRepo.GetByID(sceneID)
+	participantID := stream.GenerateParticipantID(userDID)
+	participants, err := h.participantRepo.GetActiveParticipants(session.ID)
 	if err != nil {
 		return false, err
 	}
-	return foundScene.IsOwner(userDID), nil
+	for _, p := range participants {
+		if p.ParticipantID == participantID {
+			return false, nil
+		}
+	}
+	return true, nil
 }
 
 // JoinStreamRequest represents the request body for recording a join event.
@@ -667,6 +1887,13 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Scheduled streams have no room to join until the host starts them
+	if session.Status() == stream.StatusScheduled {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Stream has not started yet")
+		return
+	}
+
 	// Check if stream is locked (only host can join locked streams)
 	if session.IsLocked && session.HostDID != userDID {
 		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
@@ -674,6 +1901,65 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Enforce the participant cap (the host always joins)
+	if session.MaxParticipants != nil && session.HostDID != userDID {
+		full, err := h.streamFull(session, userDID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to check stream capacity",
+				"error", err,
+				"stream_id", streamID,
+			)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			return
+		}
+		if full {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Stream is full")
+			return
+		}
+	}
+
+	// DIDs blocked in the stream's scene may not join
+	if h.blockRepo != nil {
+		sceneID, err := h.sessionSceneID(session)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to resolve stream scene",
+				"error", err,
+				"stream_id", streamID,
+			)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			return
+		}
+		if !checkSceneBlock(ctx, w, h.blockRepo, sceneID, userDID) {
+			return
+		}
+	}
+
+	// Enforce the join policy (the host always joins)
+	if session.JoinPolicy.RequiresMembership() && session.HostDID != userDID {
+		allowed, err := h.joinPolicyAllows(session, userDID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to check stream join policy",
+				"error", err,
+				"stream_id", streamID,
+			)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+			return
+		}
+		if !allowed {
+			message := "Only scene members can join this stream"
+			if session.JoinPolicy == stream.JoinPolicyFollowersOnly {
+				message = "Only scene followers can join this stream"
+			}
+			ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
+			WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, message)
+			return
+		}
+	}
+
 	// Parse optional request body for latency tracking
 	var req JoinStreamRequest
 	if r.Body != nil {
@@ -686,9 +1972,27 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 	// Generate participant ID from user DID
 	participantID := stream.GenerateParticipantID(userDID)
 
-	// Record participant join in participant repository
-	var isReconnection bool
-	if h.participantRepo != nil {
+	// A re-join within the debounce window resumes the previous session: the
+	// pending leave is cancelled and the join isn't counted again. A LiveKit
+	// webhook may already have resumed the record, which is the same session.
+	var coalesced bool
+	if h.participantRepo != nil && h.joinDebouncer != nil && h.joinDebouncer.Rejoin(streamID, participantID) {
+		if _, err := h.participantRepo.ResumeParticipant(streamID, participantID); err != nil && !errors.Is(err, stream.ErrParticipantAlreadyActive) {
+			slog.WarnContext(ctx, "failed to resume debounced participant, recording a new join",
+				"error", err,
+				"stream_id", streamID,
+				"participant_id", participantID,
+			)
+		} else {
+			coalesced = true
+		}
+	}
+	isReconnection := coalesced // A coalesced re-join continues the session as a reconnection
+
+	// Record participant join in participant repository. Any earlier leave's
+	// side effects have already been recorded, so the join is counted even if
+	// the repository's reconnection grace window reopens the previous record
+	if h.participantRepo != nil && !coalesced {
 		participant, reconnection, err := h.participantRepo.RecordJoin(streamID, participantID, userDID)
 		if err != nil {
 			if errors.Is(err, stream.ErrParticipantAlreadyActive) {
@@ -730,25 +2034,30 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Record join in repository
-	if err := h.streamRepo.RecordJoin(streamID); err != nil {
-		slog.ErrorContext(ctx, "failed to record join",
-			"error", err,
-			"stream_id", streamID,
-			"user_did", userDID,
-		)
-		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
-		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to record join event")
-		return
+	if !coalesced {
+		if err := h.streamRepo.RecordJoin(streamID); err != nil {
+			slog.ErrorContext(ctx, "failed to record join",
+				"error", err,
+				"stream_id", streamID,
+				"user_did", userDID,
+			)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to record join event")
+			return
+		}
 	}
 
 	// Record participant event for analytics
-	if h.analyticsRepo != nil {
-		// Validate and sanitize geohash prefix if provided
+	if h.analyticsRepo != nil && !coalesced {
+		// Validate and sanitize geohash prefix if provided; a malformed one is
+		// dropped rather than failing a join that has already been recorded
 		var geohashPrefix *string
 		if req.GeohashPrefix != nil && len(strings.TrimSpace(*req.GeohashPrefix)) >= 4 {
 			// Take only first 4 characters for privacy
-			prefix := strings.TrimSpace(*req.GeohashPrefix)[:4]
-			geohashPrefix = &prefix
+			prefix := strings.ToLower(strings.TrimSpace(*req.GeohashPrefix)[:4])
+			if _, err := geo.DecodeGeohash(prefix); err == nil {
+				geohashPrefix = &prefix
+			}
 		}
 
 		if err := h.analyticsRepo.RecordParticipantEvent(streamID, userDID, "join", geohashPrefix); err != nil {
@@ -762,7 +2071,7 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Increment Prometheus counter
-	if h.streamMetrics != nil {
+	if h.streamMetrics != nil && !coalesced {
 		h.streamMetrics.IncStreamJoins()
 	}
 
@@ -798,6 +2107,7 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 	// Log join event for audit
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
 		EntityType: "stream_session",
 		EntityID:   streamID,
 		Action:     "joined",
@@ -828,10 +2138,11 @@ func (h *StreamHandlers) JoinStream(w http.ResponseWriter, r *http.Request) {
 
 	// Return success response with the persisted join count
 	response := map[string]interface{}{
-		"stream_id":  streamID,
-		"room_name":  session.RoomName,
-		"join_count": session.JoinCount,
-		"status":     "joined",
+		"stream_id":       streamID,
+		"room_name":       session.RoomName,
+		"join_count":      session.JoinCount,
+		"is_reconnection": isReconnection,
+		"status":          "joined",
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -881,6 +2192,7 @@ func (h *StreamHandlers) LeaveStream(w http.ResponseWriter, r *http.Request) {
 	participantID := stream.GenerateParticipantID(userDID)
 
 	// Record participant leave in participant repository
+	var participantLeft bool
 	if h.participantRepo != nil {
 		if err := h.participantRepo.RecordLeave(streamID, participantID); err != nil {
 			if errors.Is(err, stream.ErrParticipantNotFound) {
@@ -901,25 +2213,24 @@ func (h *StreamHandlers) LeaveStream(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		} else {
-			// Broadcast participant left event via WebSocket
-			if h.eventBroadcaster != nil {
-				activeCount, _ := h.participantRepo.GetActiveCount(streamID)
-				event := &stream.ParticipantStateEvent{
-					Type:            "participant_left",
-					StreamSessionID: streamID,
-					ParticipantID:   participantID,
-					UserDID:         userDID,
-					Timestamp:       time.Now(),
-					IsReconnection:  false,
-					ActiveCount:     activeCount,
-				}
-				h.eventBroadcaster.Broadcast(streamID, event)
-			}
+			participantLeft = true
 		}
 	}
 
-	// Record leave in repository
-	if err := h.streamRepo.RecordLeave(streamID); err != nil {
+	leftAt := time.Now()
+	if participantLeft && h.joinDebouncer != nil {
+		// Hold back the leave's side effects in case the participant re-joins
+		finalizeCtx := context.WithoutCancel(ctx)
+		h.joinDebouncer.Leave(streamID, participantID, func() {
+			if err := h.finalizeLeave(finalizeCtx, streamID, participantID, userDID, leftAt, true); err != nil {
+				slog.ErrorContext(finalizeCtx, "failed to record debounced leave",
+					"error", err,
+					"stream_id", streamID,
+					"user_did", userDID,
+				)
+			}
+		})
+	} else if err := h.finalizeLeave(ctx, streamID, participantID, userDID, leftAt, participantLeft); err != nil {
 		slog.ErrorContext(ctx, "failed to record leave",
 			"error", err,
 			"stream_id", streamID,
@@ -930,26 +2241,10 @@ func (h *StreamHandlers) LeaveStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Record participant event for analytics
-	if h.analyticsRepo != nil {
-		if err := h.analyticsRepo.RecordParticipantEvent(streamID, userDID, "leave", nil); err != nil {
-			// Log error but don't fail the request
-			slog.ErrorContext(ctx, "failed to record participant leave event",
-				"error", err,
-				"stream_id", streamID,
-				"user_did", userDID,
-			)
-		}
-	}
-
-	// Increment Prometheus counter
-	if h.streamMetrics != nil {
-		h.streamMetrics.IncStreamLeaves()
-	}
-
 	// Log leave event for audit
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
 		EntityType: "stream_session",
 		EntityID:   streamID,
 		Action:     "left",
@@ -993,6 +2288,179 @@ func (h *StreamHandlers) LeaveStream(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// finalizeLeave records the side effects of a participant leaving: the
+// participant_left broadcast (when broadcast is set), the session leave count,
+// the analytics leave event and the leave metric. The broadcast and analytics
+// event are stamped with leftAt, so a leave held back by the join debouncer is
+// recorded when it happened. Only a leave count failure is returned; the rest
+// are logged.
+func (h *StreamHandlers) finalizeLeave(ctx context.Context, streamID, participantID, userDID string, leftAt time.Time, broadcast bool) error {
+	// Broadcast participant left event via WebSocket
+	if broadcast && h.eventBroadcaster != nil {
+		activeCount, _ := h.participantRepo.GetActiveCount(streamID)
+		event := &stream.ParticipantStateEvent{
+			Type:            "participant_left",
+			StreamSessionID: streamID,
+			ParticipantID:   participantID,
+			UserDID:         userDID,
+			Timestamp:       leftAt,
+			IsReconnection:  false,
+			ActiveCount:     activeCount,
+		}
+		h.eventBroadcaster.Broadcast(streamID, event)
+	}
+
+	// Record leave in repository
+	if err := h.streamRepo.RecordLeave(streamID); err != nil {
+		return err
+	}
+
+	// Record participant event for analytics
+	if h.analyticsRepo != nil {
+		if err := h.analyticsRepo.RecordParticipantEventAt(streamID, userDID, "leave", nil, leftAt); err != nil {
+			// Log error but don't fail the request
+			slog.ErrorContext(ctx, "failed to record participant leave event",
+				"error", err,
+				"stream_id", streamID,
+				"user_did", userDID,
+			)
+		}
+	}
+
+	// Increment Prometheus counter
+	if h.streamMetrics != nil {
+		h.streamMetrics.IncStreamLeaves()
+	}
+	return nil
+}
+
+// RaiseHandResponse represents the response for raising or lowering a hand.
+type RaiseHandResponse struct {
+	StreamID      string `json:"stream_id"`
+	ParticipantID string `json:"participant_id"`
+	HandRaised    bool   `json:"hand_raised"`
+}
+
+// RaiseHand handles POST /streams/{id}/raise_hand - asks the host for a turn
+// to speak. The caller must be an active participant; the raised hand is
+// broadcast to the stream as a hand_raised event.
+func (h *StreamHandlers) RaiseHand(w http.ResponseWriter, r *http.Request) {
+	h.setHandRaised(w, r, true)
+}
+
+// LowerHand handles DELETE /streams/{id}/raise_hand - withdraws a raised hand
+// and broadcasts a hand_lowered event.
+func (h *StreamHandlers) LowerHand(w http.ResponseWriter, r *http.Request) {
+	h.setHandRaised(w, r, false)
+}
+
+// setHandRaised raises or lowers the authenticated participant's hand.
+func (h *StreamHandlers) setHandRaised(w http.ResponseWriter, r *http.Request, raised bool) {
+	ctx := r.Context()
+
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Extract stream ID from URL path
+	// Expected: /streams/{id}/raise_hand
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "raise_hand" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		return
+	}
+	streamID := pathParts[0]
+
+	// Verify stream exists and is still live
+	session, err := h.streamRepo.GetByID(streamID)
+	if err != nil {
+		if errors.Is(err, stream.ErrStreamNotFound) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Stream session not found")
+		} else {
+			slog.ErrorContext(ctx, "failed to get stream session", "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		}
+		return
+	}
+	if session.EndedAt != nil {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+		WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Stream has ended")
+		return
+	}
+
+	if h.participantRepo == nil {
+		slog.ErrorContext(ctx, "participant repository not configured")
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Participant tracking not available")
+		return
+	}
+
+	participantID := stream.GenerateParticipantID(userDID)
+	eventType := "hand_raised"
+	if raised {
+		_, err = h.participantRepo.RaiseHand(streamID, participantID)
+	} else {
+		eventType = "hand_lowered"
+		_, err = h.participantRepo.LowerHand(streamID, participantID)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, stream.ErrParticipantNotFound):
+			ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Participant not active in stream")
+		case errors.Is(err, stream.ErrHandAlreadyRaised):
+			ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Hand is already raised")
+		case errors.Is(err, stream.ErrHandNotRaised):
+			ctx = middleware.SetErrorCode(ctx, ErrCodeConflict)
+			WriteError(w, ctx, http.StatusConflict, ErrCodeConflict, "Hand is not raised")
+		default:
+			slog.ErrorContext(ctx, "failed to update raised hand",
+				"error", err,
+				"stream_id", streamID,
+				"user_did", userDID,
+			)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update raised hand")
+		}
+		return
+	}
+
+	// Broadcast the raised hand so the host sees the speaking queue update
+	if h.eventBroadcaster != nil {
+		activeCount, _ := h.participantRepo.GetActiveCount(streamID)
+		event := &stream.ParticipantStateEvent{
+			Type:            eventType,
+			StreamSessionID: streamID,
+			ParticipantID:   participantID,
+			UserDID:         userDID,
+			Timestamp:       time.Now(),
+			IsReconnection:  false,
+			ActiveCount:     activeCount,
+		}
+		h.eventBroadcaster.Broadcast(streamID, event)
+	}
+
+	response := RaiseHandResponse{
+		StreamID:      streamID,
+		ParticipantID: participantID,
+		HandRaised:    raised,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode raise hand response", "error", err)
+	}
+}
+
 // GetStreamAnalytics handles GET /streams/{id}/analytics - retrieves analytics for a stream session.
 // Only accessible by the stream host (scene/event owner).
 func (h *StreamHandlers) GetStreamAnalytics(w http.ResponseWriter, r *http.Request) {
@@ -1045,7 +2513,7 @@ func (h *StreamHandlers) GetStreamAnalytics(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Get analytics
-	analytics, err := h.analyticsRepo.GetAnalytics(streamID)
+	result, err := h.analyticsRepo.GetAnalytics(streamID)
 	if err != nil {
 		if errors.Is(err, stream.ErrAnalyticsNotFound) {
 			// Analytics not computed yet - check if stream has ended
@@ -1067,6 +2535,7 @@ func (h *StreamHandlers) GetStreamAnalytics(w http.ResponseWriter, r *http.Reque
 	// Log analytics access for audit
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
 		EntityType: "stream_analytics",
 		EntityID:   streamID,
 		Action:     "viewed",
@@ -1082,30 +2551,117 @@ func (h *StreamHandlers) GetStreamAnalytics(w http.ResponseWriter, r *http.Reque
 		)
 	}
 
-	// Return analytics (no PII exposed - only aggregate data)
+	// Enforce aggregate-only output before encoding (no PII exposed)
+	redacted, stripped, err := analytics.Redact(result, analytics.StreamAnalyticsFields)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to redact analytics", "error", err, "stream_id", streamID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		return
+	}
+	if len(stripped) > 0 {
+		slog.WarnContext(ctx, "stripped non-allowlisted analytics fields",
+			"stream_id", streamID,
+			"fields", stripped,
+		)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(redacted); err != nil {
+		slog.ErrorContext(ctx, "failed to encode analytics response", "error", err)
+	}
+}
+
+// GetActiveParticipants handles GET /streams/{id}/participants - retrieves active participants.
+// Returns minimal participant info (no PII) for UI display.
+func (h *StreamHandlers) GetActiveParticipants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract stream ID from URL path
+	// Expected: /streams/{id}/participants
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "participants" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		return
+	}
+	streamID := pathParts[0]
+
+	// Verify stream exists
+	session, err := h.streamRepo.GetByID(streamID)
+	if err != nil {
+		if errors.Is(err, stream.ErrStreamNotFound) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Stream session not found")
+		} else {
+			slog.ErrorContext(ctx, "failed to get stream session", "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		}
+		return
+	}
+
+	// Get active count (efficient, uses denormalized field)
+	activeCount := session.ActiveParticipantCount
+
+	// Return participant count only (no PII)
+	// Individual participant identities are not exposed to preserve privacy
+	response := map[string]interface{}{
+		"stream_id":    streamID,
+		"active_count": activeCount,
+		"room_name":    session.RoomName,
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(analytics); err != nil {
-		slog.ErrorContext(ctx, "failed to encode analytics response", "error", err)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode participants response", "error", err)
 	}
 }
 
-// GetActiveParticipants handles GET /streams/{id}/participants - retrieves active participants.
-// Returns minimal participant info (no PII) for UI display.
-func (h *StreamHandlers) GetActiveParticipants(w http.ResponseWriter, r *http.Request) {
+// RosterEntry describes one active participant in the host's stream roster.
+// Participants are identified by their participant ID, as in the other host
+// controls, rather than their DID.
+type RosterEntry struct {
+	ParticipantID string     `json:"participant_id"`
+	JoinedAt      time.Time  `json:"joined_at"`
+	Muted         bool       `json:"muted"`
+	HandRaisedAt  *time.Time `json:"hand_raised_at,omitempty"`
+}
+
+// RosterResponse represents the response for GET /streams/{id}/roster.
+type RosterResponse struct {
+	StreamID     string        `json:"stream_id"`
+	ActiveCount  int           `json:"active_count"`
+	Participants []RosterEntry `json:"participants"`
+}
+
+// GetRoster handles GET /streams/{id}/roster - lists who is present in a stream,
+// earliest joiner first, with their mute and raised-hand state.
+// Only the stream host can view the roster; everyone else gets the count-only
+// GET /streams/{id}/participants. Each view is audit logged as roster_viewed.
+func (h *StreamHandlers) GetRoster(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
 	// Extract stream ID from URL path
-	// Expected: /streams/{id}/participants
+	// Expected: /streams/{id}/roster
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
-	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "participants" {
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "roster" {
 		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
 		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
 		return
 	}
 	streamID := pathParts[0]
 
-	// Verify stream exists
 	session, err := h.streamRepo.GetByID(streamID)
 	if err != nil {
 		if errors.Is(err, stream.ErrStreamNotFound) {
@@ -1119,21 +2675,69 @@ func (h *StreamHandlers) GetActiveParticipants(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Get active count (efficient, uses denormalized field)
-	activeCount := session.ActiveParticipantCount
+	// Verify that the user is the stream host (organizer)
+	if session.HostDID != userDID {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the stream host can view the roster")
+		return
+	}
 
-	// Return participant count only (no PII)
-	// Individual participant identities are not exposed to preserve privacy
-	response := map[string]interface{}{
-		"stream_id":    streamID,
-		"active_count": activeCount,
-		"room_name":    session.RoomName,
+	if h.participantRepo == nil {
+		slog.ErrorContext(ctx, "participant repository not configured")
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Participant tracking not available")
+		return
+	}
+
+	participants, err := h.participantRepo.GetActiveParticipants(streamID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get active participants", "error", err, "stream_id", streamID)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to retrieve roster")
+		return
+	}
+	slices.SortFunc(participants, func(a, b *stream.Participant) int {
+		if c := a.JoinedAt.Compare(b.JoinedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ParticipantID, b.ParticipantID)
+	})
+
+	response := RosterResponse{
+		StreamID:     streamID,
+		ActiveCount:  len(participants),
+		Participants: make([]RosterEntry, 0, len(participants)),
+	}
+	for _, p := range participants {
+		response.Participants = append(response.Participants, RosterEntry{
+			ParticipantID: p.ParticipantID,
+			JoinedAt:      p.JoinedAt,
+			Muted:         p.Muted,
+			HandRaisedAt:  p.HandRaisedAt,
+		})
+	}
+
+	// Roster views expose who attended, so record each one
+	auditEntry := audit.LogEntry{
+		UserDID:    userDID,
+		ActorType:  audit.ActorModerator,
+		EntityType: "stream",
+		EntityID:   streamID,
+		Action:     "roster_viewed",
+		RequestID:  middleware.GetRequestID(ctx),
+	}
+	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
+		// Log error but don't fail the request
+		slog.ErrorContext(ctx, "failed to log roster audit entry",
+			"error", err,
+			"stream_id", streamID,
+		)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		slog.ErrorContext(ctx, "failed to encode participants response", "error", err)
+		slog.ErrorContext(ctx, "failed to encode roster response", "error", err)
 	}
 }
 
@@ -1190,9 +2794,8 @@ func (h *StreamHandlers) MuteParticipant(w http.ResponseWriter, r *http.Request)
 
 	// Parse request body
 	var req MuteParticipantRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, ctx, err)
 		return
 	}
 
@@ -1217,22 +2820,13 @@ func (h *StreamHandlers) MuteParticipant(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Mute all audio tracks for the participant
-	var mutedTracks []string
-	for _, track := range participant.Tracks {
-		if track.Type == livekit.TrackType_AUDIO {
-			err = h.roomService.MuteParticipantTrack(ctx, session.RoomName, participantID, track.Sid, req.Muted)
-			if err != nil {
-				slog.ErrorContext(ctx, "failed to mute track",
-					"error", err,
-					"stream_id", streamID,
-					"participant_id", participantID,
-					"track_sid", track.Sid,
-				)
-				// Continue trying other tracks
-			} else {
-				mutedTracks = append(mutedTracks, track.Sid)
-			}
-		}
+	mutedTracks, failedTracks := h.muteAudioTracks(ctx, session, participantID, participant.Tracks, req.Muted)
+
+	// Every mute attempt failed; reporting 200 would claim a change that never happened
+	if len(failedTracks) > 0 && len(mutedTracks) == 0 {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to mute participant tracks")
+		return
 	}
 
 	if len(mutedTracks) == 0 {
@@ -1249,6 +2843,7 @@ func (h *StreamHandlers) MuteParticipant(w http.ResponseWriter, r *http.Request)
 	}
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorModerator,
 		EntityType: "stream_participant",
 		EntityID:   streamID + ":" + participantID,
 		Action:     action,
@@ -1270,6 +2865,8 @@ func (h *StreamHandlers) MuteParticipant(w http.ResponseWriter, r *http.Request)
 		"participant_id": participantID,
 		"muted":          req.Muted,
 		"tracks_muted":   len(mutedTracks),
+		"muted_tracks":   mutedTracks,
+		"failed_tracks":  failedTracks,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -1279,6 +2876,183 @@ func (h *StreamHandlers) MuteParticipant(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// muteAudioTracks mutes or unmutes each audio track in tracks, continuing past
+// failures, and records the participant's mute state for the host roster once
+// any track changed. Returns the SIDs of the tracks that were and weren't updated.
+func (h *StreamHandlers) muteAudioTracks(ctx context.Context, session *stream.Session, participantID string, tracks []*livekit.TrackInfo, muted bool) (mutedTracks, failedTracks []string) {
+	mutedTracks = []string{}
+	failedTracks = []string{}
+	for _, track := range tracks {
+		if track.Type != livekit.TrackType_AUDIO {
+			continue
+		}
+		if err := h.roomService.MuteParticipantTrack(ctx, session.RoomName, participantID, track.Sid, muted); err != nil {
+			slog.ErrorContext(ctx, "failed to mute track",
+				"error", err,
+				"stream_id", session.ID,
+				"participant_id", participantID,
+				"track_sid", track.Sid,
+			)
+			// Continue trying other tracks
+			failedTracks = append(failedTracks, track.Sid)
+		} else {
+			mutedTracks = append(mutedTracks, track.Sid)
+		}
+	}
+
+	if len(mutedTracks) > 0 && h.participantRepo != nil {
+		if err := h.participantRepo.SetMuted(session.ID, participantID, muted); err != nil && !errors.Is(err, stream.ErrParticipantNotFound) {
+			// Log error but don't fail the request; LiveKit already applied the mute
+			slog.ErrorContext(ctx, "failed to record participant mute state",
+				"error", err,
+				"stream_id", session.ID,
+				"participant_id", participantID,
+			)
+		}
+	}
+	return mutedTracks, failedTracks
+}
+
+// MuteAllRequest represents the optional request body for muting every participant.
+type MuteAllRequest struct {
+	Except []string `json:"except,omitempty"` // Participant IDs to leave unmuted, e.g. featured speakers; the host is never muted
+}
+
+// MuteAllResponse reports the outcome of POST /streams/{stream_id}/mute_all.
+type MuteAllResponse struct {
+	StreamID           string   `json:"stream_id"`
+	ParticipantsMuted  int      `json:"participants_muted"`
+	TracksMuted        int      `json:"tracks_muted"`
+	FailedParticipants []string `json:"failed_participants"`
+}
+
+// MuteAll handles POST /streams/{stream_id}/mute_all
+// Mutes the audio of every participant in the stream except those listed in
+// the optional except field. Failures on individual participants don't stop
+// the rest; participants with a track that couldn't be muted are reported in
+// failed_participants. Only the stream host (organizer) can perform this action.
+func (h *StreamHandlers) MuteAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Expected: /streams/{stream_id}/mute_all
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "mute_all" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		return
+	}
+	streamID := pathParts[0]
+
+	// Get the stream session to verify ownership
+	session, err := h.streamRepo.GetByID(streamID)
+	if err != nil {
+		if errors.Is(err, stream.ErrStreamNotFound) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Stream session not found")
+		} else {
+			slog.ErrorContext(ctx, "failed to get stream session", "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		}
+		return
+	}
+
+	// Verify that the user is the stream host (organizer)
+	if session.HostDID != userDID {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the stream host can mute participants")
+		return
+	}
+
+	// The body is optional; without one, everyone is muted
+	var req MuteAllRequest
+	if err := decodeJSONBody(r, &req); err != nil && !errors.Is(err, ErrEmptyBody) {
+		writeDecodeError(w, ctx, err)
+		return
+	}
+	except := make(map[string]bool, len(req.Except)+2)
+	for _, participantID := range req.Except {
+		except[participantID] = true
+	}
+	// The host and the caller keep their own audio
+	except[stream.GenerateParticipantID(session.HostDID)] = true
+	except[stream.GenerateParticipantID(userDID)] = true
+
+	// Check if room service is available
+	if h.roomService == nil {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Stream control operations are not available")
+		return
+	}
+
+	participants, err := h.roomService.ListParticipants(ctx, session.RoomName)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list participants from LiveKit",
+			"error", err,
+			"stream_id", streamID,
+		)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to list stream participants")
+		return
+	}
+
+	response := MuteAllResponse{
+		StreamID:           streamID,
+		FailedParticipants: []string{},
+	}
+	for _, participant := range participants {
+		if except[participant.Identity] {
+			continue
+		}
+		mutedTracks, failedTracks := h.muteAudioTracks(ctx, session, participant.Identity, participant.Tracks, true)
+		if len(mutedTracks) > 0 {
+			response.ParticipantsMuted++
+			response.TracksMuted += len(mutedTracks)
+		}
+		if len(failedTracks) > 0 {
+			response.FailedParticipants = append(response.FailedParticipants, participant.Identity)
+		}
+	}
+
+	// Every mute attempt failed; reporting 200 would claim a change that never happened
+	if len(response.FailedParticipants) > 0 && response.TracksMuted == 0 {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to mute participant tracks")
+		return
+	}
+
+	// One audit entry for the whole operation rather than one per participant
+	auditEntry := audit.LogEntry{
+		UserDID:    userDID,
+		ActorType:  audit.ActorModerator,
+		EntityType: "stream",
+		EntityID:   streamID,
+		Action:     "muted_all",
+		RequestID:  middleware.GetRequestID(ctx),
+	}
+	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
+		// Log error but don't fail the request
+		slog.ErrorContext(ctx, "failed to log mute all audit entry",
+			"error", err,
+			"stream_id", streamID,
+		)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode mute all response", "error", err)
+	}
+}
+
 // KickParticipant handles POST /streams/{stream_id}/participants/{participant_id}/kick
 // Removes a participant from the stream.
 // Only the stream host (organizer) can perform this action.
@@ -1348,6 +3122,7 @@ func (h *StreamHandlers) KickParticipant(w http.ResponseWriter, r *http.Request)
 	// Log action for audit
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorModerator,
 		EntityType: "stream_participant",
 		EntityID:   streamID + ":" + participantID,
 		Action:     "kicked",
@@ -1429,9 +3204,8 @@ func (h *StreamHandlers) SetFeaturedParticipant(w http.ResponseWriter, r *http.R
 
 	// Parse request body
 	var req SetFeaturedParticipantRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, ctx, err)
 		return
 	}
 
@@ -1468,6 +3242,7 @@ func (h *StreamHandlers) SetFeaturedParticipant(w http.ResponseWriter, r *http.R
 	}
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
 		EntityType: "stream_session",
 		EntityID:   streamID,
 		Action:     action,
@@ -1547,9 +3322,8 @@ func (h *StreamHandlers) LockStream(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var req LockStreamRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
-		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid JSON in request body")
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, ctx, err)
 		return
 	}
 
@@ -1572,6 +3346,7 @@ func (h *StreamHandlers) LockStream(w http.ResponseWriter, r *http.Request) {
 	}
 	auditEntry := audit.LogEntry{
 		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
 		EntityType: "stream_session",
 		EntityID:   streamID,
 		Action:     action,
@@ -1598,3 +3373,108 @@ func (h *StreamHandlers) LockStream(w http.ResponseWriter, r *http.Request) {
 		slog.ErrorContext(ctx, "failed to encode lock response", "error", err)
 	}
 }
+
+// SetJoinPolicyRequest represents the request body for changing a stream's join policy.
+type SetJoinPolicyRequest struct {
+	JoinPolicy stream.JoinPolicy `json:"join_policy"` // "public", "members_only", or "followers_only"
+}
+
+// SetJoinPolicy handles PATCH /streams/{stream_id}/join_policy
+// Restricts who may join the stream to scene members or followers, or opens it to everyone.
+// Only the stream host (organizer) can perform this action.
+func (h *StreamHandlers) SetJoinPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract user DID from context (set by auth middleware)
+	userDID := middleware.GetUserDID(ctx)
+	if userDID == "" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeAuthFailed)
+		WriteError(w, ctx, http.StatusUnauthorized, ErrCodeAuthFailed, "Authentication required")
+		return
+	}
+
+	// Extract stream ID from URL path
+	// Expected: /streams/{stream_id}/join_policy
+	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/streams/"), "/")
+	if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "join_policy" {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "Invalid URL path")
+		return
+	}
+	streamID := pathParts[0]
+
+	// Get the stream session to verify ownership
+	session, err := h.streamRepo.GetByID(streamID)
+	if err != nil {
+		if errors.Is(err, stream.ErrStreamNotFound) {
+			ctx = middleware.SetErrorCode(ctx, ErrCodeNotFound)
+			WriteError(w, ctx, http.StatusNotFound, ErrCodeNotFound, "Stream session not found")
+		} else {
+			slog.ErrorContext(ctx, "failed to get stream session", "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+			WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Internal server error")
+		}
+		return
+	}
+
+	// Verify that the user is the stream host (organizer)
+	if session.HostDID != userDID {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeForbidden)
+		WriteError(w, ctx, http.StatusForbidden, ErrCodeForbidden, "Only the stream host can change the join policy")
+		return
+	}
+
+	// Parse request body
+	var req SetJoinPolicyRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, ctx, err)
+		return
+	}
+
+	if !req.JoinPolicy.Valid() {
+		ctx = middleware.SetErrorCode(ctx, ErrCodeValidation)
+		WriteError(w, ctx, http.StatusBadRequest, ErrCodeValidation, "join_policy must be one of public, members_only, followers_only")
+		return
+	}
+
+	// Update join policy in database
+	if err := h.streamRepo.SetJoinPolicy(streamID, req.JoinPolicy); err != nil {
+		slog.ErrorContext(ctx, "failed to set join policy",
+			"error", err,
+			"stream_id", streamID,
+		)
+		ctx = middleware.SetErrorCode(ctx, ErrCodeInternal)
+		WriteError(w, ctx, http.StatusInternalServerError, ErrCodeInternal, "Failed to update stream join policy")
+		return
+	}
+
+	// Log action for audit
+	auditEntry := audit.LogEntry{
+		UserDID:    userDID,
+		ActorType:  audit.ActorUser,
+		EntityType: "stream_session",
+		EntityID:   streamID,
+		Action:     "join_policy_" + string(req.JoinPolicy),
+		RequestID:  middleware.GetRequestID(ctx),
+	}
+
+	if _, err := h.auditRepo.LogAccess(auditEntry); err != nil {
+		// Log error but don't fail the request
+		slog.ErrorContext(ctx, "failed to log join policy audit entry",
+			"error", err,
+			"stream_id", streamID,
+		)
+	}
+
+	// Return success response
+	response := map[string]interface{}{
+		"stream_id":   streamID,
+		"join_policy": req.JoinPolicy,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode join policy response", "error", err)
+	}
+}
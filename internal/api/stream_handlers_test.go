@@ -176,6 +176,35 @@ func TestCreateStream_NoSceneOrEvent(t *testing.T) {
 	}
 }
 
+// TestCreateStream_NullBody tests that a null body is reported as a missing body, not malformed JSON.
+func TestCreateStream_NullBody(t *testing.T) {
+	streamRepo := stream.NewInMemorySessionRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	eventRepo := scene.NewInMemoryEventRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	handlers := NewStreamHandlers(streamRepo, nil, nil, sceneRepo, eventRepo, auditRepo, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/streams", bytes.NewReader([]byte("null")))
+	req.Header.Set("Content-Type", "application/json")
+	ctx := middleware.SetUserDID(req.Context(), "did:plc:test123")
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	handlers.CreateStream(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeValidation {
+		t.Errorf("expected error code '%s', got '%s'", ErrCodeValidation, errResp.Error.Code)
+	}
+}
+
 // TestCreateStream_BothSceneAndEvent tests rejection when both scene_id and event_id are provided.
 func TestCreateStream_BothSceneAndEvent(t *testing.T) {
 	streamRepo := stream.NewInMemorySessionRepository()
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newHostLimitTest creates stream handlers over three scenes owned by hostDID,
+// with the host already streaming in the first `active` of them.
+func newHostLimitTest(t *testing.T, hostDID string, active int) *StreamHandlers {
+	t.Helper()
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	for i, id := range []string{"scene-a", "scene-b", "scene-c"} {
+		if err := sceneRepo.Insert(&scene.Scene{
+			ID:            id,
+			Name:          "Scene " + id,
+			OwnerDID:      hostDID,
+			CoarseGeohash: "dr5regw",
+		}); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+		if i < active {
+			sceneID := id
+			if _, _, err := streamRepo.CreateStreamSession(&sceneID, nil, hostDID); err != nil {
+				t.Fatalf("failed to create stream session: %v", err)
+			}
+		}
+	}
+
+	return NewStreamHandlers(streamRepo, nil, nil, sceneRepo, scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+}
+
+func doCreateSceneStream(t *testing.T, h *StreamHandlers, sceneID, userDID string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(CreateStreamRequest{SceneID: ptrString(sceneID)})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/streams", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	h.CreateStream(w, req)
+	return w
+}
+
+func TestCreateStream_HostAtLimitRejected(t *testing.T) {
+	handlers := newHostLimitTest(t, "did:plc:host", 2)
+	handlers.SetHostStreamLimit(2, nil)
+
+	w := doCreateSceneStream(t, handlers, "scene-c", "did:plc:host")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Error.Code != ErrCodeConflict {
+		t.Errorf("error code = %q, want %q", response.Error.Code, ErrCodeConflict)
+	}
+	if !strings.Contains(response.Error.Message, "2 active streams") {
+		t.Errorf("error message = %q, want it to mention the active stream count", response.Error.Message)
+	}
+}
+
+func TestCreateStream_HostUnderLimitSucceeds(t *testing.T) {
+	handlers := newHostLimitTest(t, "did:plc:host", 1)
+	handlers.SetHostStreamLimit(2, nil)
+
+	if w := doCreateSceneStream(t, handlers, "scene-c", "did:plc:host"); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateStream_ExemptHostBypassesLimit(t *testing.T) {
+	handlers := newHostLimitTest(t, "did:plc:festival", 2)
+	handlers.SetHostStreamLimit(2, []string{"did:plc:festival"})
+
+	if w := doCreateSceneStream(t, handlers, "scene-c", "did:plc:festival"); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateStream_HostLimitDisabled(t *testing.T) {
+	handlers := newHostLimitTest(t, "did:plc:host", 2)
+	handlers.SetHostStreamLimit(0, nil)
+
+	if w := doCreateSceneStream(t, handlers, "scene-c", "did:plc:host"); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
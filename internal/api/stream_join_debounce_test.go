@@ -0,0 +1,179 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newJoinDebounceTest creates stream handlers with participant and analytics
+// tracking, a debouncer with the given window, and one active stream. As in
+// production, the participant repository's reconnection grace matches the
+// debounce window.
+func newJoinDebounceTest(t *testing.T, window time.Duration) (*StreamHandlers, *stream.InMemorySessionRepository, stream.ParticipantRepository, *stream.InMemoryAnalyticsRepository, string) {
+	t.Helper()
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	participantRepo := stream.NewInMemoryParticipantRepository(streamRepo, stream.WithReconnectionGrace(window))
+	analyticsRepo := stream.NewInMemoryAnalyticsRepository(streamRepo)
+	sceneRepo := scene.NewInMemorySceneRepository()
+	handlers := NewStreamHandlers(streamRepo, participantRepo, analyticsRepo, sceneRepo, scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), stream.NewMetrics(), nil, nil)
+	handlers.SetJoinDebouncer(stream.NewJoinDebouncer(window))
+
+	testScene := &scene.Scene{ID: "scene-debounce", Name: "Debounce Scene", OwnerDID: "did:plc:host", CoarseGeohash: "dr5regw"}
+	if err := sceneRepo.Insert(testScene); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+	streamID, _, err := streamRepo.CreateStreamSession(ptrString(testScene.ID), nil, testScene.OwnerDID)
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	return handlers, streamRepo, participantRepo, analyticsRepo, streamID
+}
+
+func postStreamAction(t *testing.T, handler http.HandlerFunc, streamID, action, userDID string) map[string]interface{} {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/streams/"+streamID+"/"+action, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("%s: expected status 200, got %d: %s", action, rr.Code, rr.Body.String())
+	}
+	var response map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("%s: failed to decode response: %v", action, err)
+	}
+	return response
+}
+
+// countEvents tallies a stream's analytics participant events by type.
+func countEvents(t *testing.T, analyticsRepo *stream.InMemoryAnalyticsRepository, streamID string) map[string]int {
+	t.Helper()
+	events, err := analyticsRepo.GetParticipantEvents(streamID)
+	if err != nil {
+		t.Fatalf("failed to get participant events: %v", err)
+	}
+	counts := make(map[string]int)
+	for _, e := range events {
+		counts[e.EventType]++
+	}
+	return counts
+}
+
+func TestJoinStream_RejoinWithinDebounceWindowIsCoalesced(t *testing.T) {
+	handlers, streamRepo, participantRepo, analyticsRepo, streamID := newJoinDebounceTest(t, time.Hour)
+	const userDID = "did:plc:flaky"
+
+	if joined := postStreamAction(t, handlers.JoinStream, streamID, "join", userDID); joined["is_reconnection"] != false {
+		t.Errorf("first join: expected is_reconnection false, got %v", joined["is_reconnection"])
+	}
+	postStreamAction(t, handlers.LeaveStream, streamID, "leave", userDID)
+	if rejoined := postStreamAction(t, handlers.JoinStream, streamID, "join", userDID); rejoined["is_reconnection"] != true {
+		t.Errorf("re-join: expected is_reconnection true, got %v", rejoined["is_reconnection"])
+	}
+
+	session, err := streamRepo.GetByID(streamID)
+	if err != nil {
+		t.Fatalf("failed to get stream: %v", err)
+	}
+	if session.JoinCount != 1 || session.LeaveCount != 0 {
+		t.Errorf("expected join_count 1 and leave_count 0, got %d and %d", session.JoinCount, session.LeaveCount)
+	}
+	if counts := countEvents(t, analyticsRepo, streamID); counts["join"] != 1 || counts["leave"] != 0 {
+		t.Errorf("expected a single join analytics event, got %v", counts)
+	}
+
+	history, err := participantRepo.GetParticipantHistory(streamID)
+	if err != nil {
+		t.Fatalf("failed to get participant history: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected one participant record, got %d", len(history))
+	}
+	if history[0].LeftAt != nil || history[0].ReconnectionCount != 1 {
+		t.Errorf("expected an active record with reconnection_count 1, got left_at=%v reconnection_count=%d", history[0].LeftAt, history[0].ReconnectionCount)
+	}
+}
+
+func TestJoinStream_RejoinAfterDebounceWindowIsCountedSeparately(t *testing.T) {
+	window := 10 * time.Millisecond
+	handlers, streamRepo, participantRepo, analyticsRepo, streamID := newJoinDebounceTest(t, window)
+	const userDID = "did:plc:flaky"
+
+	postStreamAction(t, handlers.JoinStream, streamID, "join", userDID)
+	postStreamAction(t, handlers.LeaveStream, streamID, "leave", userDID)
+	leftBy := time.Now()
+
+	// Wait for the held-back leave to be recorded
+	deadline := time.Now().Add(time.Second)
+	for {
+		session, err := streamRepo.GetByID(streamID)
+		if err != nil {
+			t.Fatalf("failed to get stream: %v", err)
+		}
+		if session.LeaveCount == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected leave to be recorded after the debounce window")
+		}
+		time.Sleep(window)
+	}
+
+	postStreamAction(t, handlers.JoinStream, streamID, "join", userDID)
+
+	session, err := streamRepo.GetByID(streamID)
+	if err != nil {
+		t.Fatalf("failed to get stream: %v", err)
+	}
+	if session.JoinCount != 2 || session.LeaveCount != 1 {
+		t.Errorf("expected join_count 2 and leave_count 1, got %d and %d", session.JoinCount, session.LeaveCount)
+	}
+	if counts := countEvents(t, analyticsRepo, streamID); counts["join"] != 2 || counts["leave"] != 1 {
+		t.Errorf("expected two joins and one leave in analytics, got %v", counts)
+	}
+
+	// The held-back leave is recorded at the time the participant left
+	events, err := analyticsRepo.GetParticipantEvents(streamID)
+	if err != nil {
+		t.Fatalf("failed to get participant events: %v", err)
+	}
+	for _, e := range events {
+		if e.EventType == "leave" && e.OccurredAt.After(leftBy) {
+			t.Errorf("expected leave recorded by %v, got %v", leftBy, e.OccurredAt)
+		}
+	}
+
+	history, err := participantRepo.GetParticipantHistory(streamID)
+	if err != nil {
+		t.Fatalf("failed to get participant history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Errorf("expected separate participant records per session, got %d", len(history))
+	}
+}
+
+func TestLeaveStream_WithoutDebouncerRecordsImmediately(t *testing.T) {
+	handlers, streamRepo, _, _, streamID := newJoinDebounceTest(t, time.Hour)
+	handlers.SetJoinDebouncer(nil)
+	const userDID = "did:plc:steady"
+
+	postStreamAction(t, handlers.JoinStream, streamID, "join", userDID)
+	postStreamAction(t, handlers.LeaveStream, streamID, "leave", userDID)
+
+	session, err := streamRepo.GetByID(streamID)
+	if err != nil {
+		t.Fatalf("failed to get stream: %v", err)
+	}
+	if session.LeaveCount != 1 {
+		t.Errorf("expected leave_count 1, got %d", session.LeaveCount)
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newJoinPolicyTest creates handlers with a scene stream under the given policy
+// and a membership repository, returning the handlers, stream ID and membership repo.
+func newJoinPolicyTest(t *testing.T, policy stream.JoinPolicy) (*StreamHandlers, string, *membership.InMemoryMembershipRepository) {
+	t.Helper()
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	membershipRepo := membership.NewInMemoryMembershipRepository()
+
+	handlers := NewStreamHandlers(streamRepo, nil, nil, sceneRepo, scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+	handlers.SetMembershipRepo(membershipRepo)
+
+	if err := sceneRepo.Insert(&scene.Scene{
+		ID:            "scene-join",
+		Name:          "Join Policy Scene",
+		OwnerDID:      "did:plc:host123",
+		CoarseGeohash: "dr5regw",
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	streamID, _, err := streamRepo.CreateStreamSession(ptrString("scene-join"), nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	if err := streamRepo.SetJoinPolicy(streamID, policy); err != nil {
+		t.Fatalf("failed to set join policy: %v", err)
+	}
+
+	return handlers, streamID, membershipRepo
+}
+
+func addSceneMembership(t *testing.T, repo *membership.InMemoryMembershipRepository, userDID, role, status string) {
+	t.Helper()
+	if _, err := repo.Upsert(&membership.Membership{
+		SceneID:     "scene-join",
+		UserDID:     userDID,
+		Role:        role,
+		Status:      status,
+		TrustWeight: 0.5,
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+}
+
+func joinStreamAs(handlers *StreamHandlers, streamID, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/streams/"+streamID+"/join", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.JoinStream(w, req)
+	return w
+}
+
+func TestJoinStream_MembersOnly_MemberJoins(t *testing.T) {
+	handlers, streamID, membershipRepo := newJoinPolicyTest(t, stream.JoinPolicyMembersOnly)
+	addSceneMembership(t, membershipRepo, "did:plc:member", "member", "active")
+
+	w := joinStreamAs(handlers, streamID, "did:plc:member")
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestJoinStream_MembersOnly_NonMemberRejected(t *testing.T) {
+	handlers, streamID, membershipRepo := newJoinPolicyTest(t, stream.JoinPolicyMembersOnly)
+	addSceneMembership(t, membershipRepo, "did:plc:pending", "member", "pending")
+	addSceneMembership(t, membershipRepo, "did:plc:guest", "guest", "active")
+
+	for _, userDID := range []string{"did:plc:stranger", "did:plc:pending", "did:plc:guest"} {
+		w := joinStreamAs(handlers, streamID, userDID)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected status 403, got %d", userDID, w.Code)
+			continue
+		}
+
+		var errResp ErrorResponse
+		if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+			t.Fatalf("failed to decode error response: %v", err)
+		}
+		if errResp.Error.Code != ErrCodeForbidden {
+			t.Errorf("%s: expected error code %s, got %s", userDID, ErrCodeForbidden, errResp.Error.Code)
+		}
+	}
+}
+
+func TestJoinStream_FollowersOnly_AdmitsGuests(t *testing.T) {
+	handlers, streamID, membershipRepo := newJoinPolicyTest(t, stream.JoinPolicyFollowersOnly)
+	addSceneMembership(t, membershipRepo, "did:plc:guest", "guest", "active")
+
+	if w := joinStreamAs(handlers, streamID, "did:plc:guest"); w.Code != http.StatusOK {
+		t.Errorf("expected follower to join, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := joinStreamAs(handlers, streamID, "did:plc:stranger"); w.Code != http.StatusForbidden {
+		t.Errorf("expected non-follower to be rejected, got %d", w.Code)
+	}
+}
+
+func TestJoinStream_JoinPolicy_HostBypasses(t *testing.T) {
+	for _, policy := range []stream.JoinPolicy{stream.JoinPolicyMembersOnly, stream.JoinPolicyFollowersOnly} {
+		handlers, streamID, _ := newJoinPolicyTest(t, policy)
+
+		w := joinStreamAs(handlers, streamID, "did:plc:host123")
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected host to join, got %d: %s", policy, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestSetJoinPolicy(t *testing.T) {
+	handlers, streamID, _ := newJoinPolicyTest(t, stream.JoinPolicyPublic)
+
+	tests := []struct {
+		name           string
+		userDID        string
+		body           string
+		expectedStatus int
+	}{
+		{"host sets members_only", "did:plc:host123", `{"join_policy":"members_only"}`, http.StatusOK},
+		{"non-host forbidden", "did:plc:otheruser", `{"join_policy":"public"}`, http.StatusForbidden},
+		{"unknown policy rejected", "did:plc:host123", `{"join_policy":"friends_only"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/streams/"+streamID+"/join_policy", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(middleware.SetUserDID(req.Context(), tt.userDID))
+			w := httptest.NewRecorder()
+
+			handlers.SetJoinPolicy(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	session, err := handlers.streamRepo.GetByID(streamID)
+	if err != nil {
+		t.Fatalf("failed to get stream session: %v", err)
+	}
+	if session.JoinPolicy != stream.JoinPolicyMembersOnly {
+		t.Errorf("expected join policy members_only, got %s", session.JoinPolicy)
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newStreamListTest creates handlers over four streams hosted by did:plc:host:
+// an active and an ended stream in a public scene, an ended stream in a private
+// scene, and an ended stream in the public scene that started ten days ago.
+func newStreamListTest(t *testing.T) (*StreamHandlers, map[string]string) {
+	t.Helper()
+	streamRepo := stream.NewInMemorySessionRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+
+	for _, sc := range []*scene.Scene{
+		{ID: "public-scene", Name: "Public", OwnerDID: "did:plc:host", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityPublic},
+		{ID: "private-scene", Name: "Private", OwnerDID: "did:plc:host", CoarseGeohash: "dr5regw", Visibility: scene.VisibilityMembersOnly},
+	} {
+		if err := sceneRepo.Insert(sc); err != nil {
+			t.Fatalf("failed to insert scene: %v", err)
+		}
+	}
+
+	now := time.Now()
+	ended := now.Add(-30 * time.Minute)
+	ids := make(map[string]string)
+	for name, session := range map[string]*stream.Session{
+		"active":  {SceneID: ptrString("public-scene"), StartedAt: now.Add(-10 * time.Minute)},
+		"ended":   {SceneID: ptrString("public-scene"), StartedAt: now.Add(-2 * time.Hour), EndedAt: &ended},
+		"private": {SceneID: ptrString("private-scene"), StartedAt: now.Add(-3 * time.Hour), EndedAt: &ended},
+		"old":     {SceneID: ptrString("public-scene"), StartedAt: now.Add(-10 * 24 * time.Hour), EndedAt: &ended},
+	} {
+		session.HostDID = "did:plc:host"
+		session.RoomName = "room-" + name
+		result, err := streamRepo.Upsert(session)
+		if err != nil {
+			t.Fatalf("failed to insert stream: %v", err)
+		}
+		ids[name] = result.ID
+	}
+
+	handlers := NewStreamHandlers(streamRepo, nil, nil, sceneRepo, scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+	return handlers, ids
+}
+
+func listStreams(t *testing.T, handlers *StreamHandlers, userDID, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/streams?"+query, nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.ListStreams(w, req)
+	return w
+}
+
+func decodeStreamList(t *testing.T, w *httptest.ResponseRecorder) StreamListResponse {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response StreamListResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+func streamListIDs(response StreamListResponse) []string {
+	ids := make([]string, len(response.Streams))
+	for i, item := range response.Streams {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestListStreams_StatusFilter(t *testing.T) {
+	handlers, ids := newStreamListTest(t)
+
+	active := decodeStreamList(t, listStreams(t, handlers, "did:plc:host", "status=active"))
+	if got := streamListIDs(active); len(got) != 1 || got[0] != ids["active"] {
+		t.Errorf("status=active: got %v, want [%s]", got, ids["active"])
+	}
+	if active.Streams[0].Status != stream.StatusActive || !active.Streams[0].EndedAt.IsZero() {
+		t.Errorf("unexpected active stream: %+v", active.Streams[0])
+	}
+
+	ended := decodeStreamList(t, listStreams(t, handlers, "did:plc:host", "status=ended"))
+	want := []string{ids["ended"], ids["private"], ids["old"]}
+	if got := streamListIDs(ended); len(got) != 3 || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("status=ended: got %v, want %v (newest first)", got, want)
+	}
+
+	if w := listStreams(t, handlers, "did:plc:host", "status=paused"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for unknown status, got %d", w.Code)
+	}
+}
+
+func TestListStreams_TimeWindowExcludesOlderStreams(t *testing.T) {
+	handlers, ids := newStreamListTest(t)
+
+	from := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	response := decodeStreamList(t, listStreams(t, handlers, "did:plc:host", "from="+from))
+	for _, id := range streamListIDs(response) {
+		if id == ids["old"] {
+			t.Errorf("expected stream started before 'from' to be excluded, got %v", streamListIDs(response))
+		}
+	}
+	if len(response.Streams) != 3 {
+		t.Errorf("expected 3 streams in window, got %d", len(response.Streams))
+	}
+
+	to := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	response = decodeStreamList(t, listStreams(t, handlers, "did:plc:host", "to="+to))
+	if got := streamListIDs(response); len(got) != 1 || got[0] != ids["old"] {
+		t.Errorf("to=%s: got %v, want [%s]", to, got, ids["old"])
+	}
+
+	if w := listStreams(t, handlers, "did:plc:host", "from="+from+"&to="+to); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 when from is after to, got %d", w.Code)
+	}
+}
+
+func TestListStreams_CursorContinuity(t *testing.T) {
+	handlers, ids := newStreamListTest(t)
+
+	var seen []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		query := "limit=1"
+		if cursor != "" {
+			query += "&cursor=" + cursor
+		}
+		response := decodeStreamList(t, listStreams(t, handlers, "did:plc:host", query))
+		seen = append(seen, streamListIDs(response)...)
+		cursor = response.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	want := []string{ids["active"], ids["ended"], ids["private"], ids["old"]}
+	if len(seen) != len(want) {
+		t.Fatalf("expected %d streams across pages, got %v", len(want), seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("page %d: got %s, want %s", i, seen[i], want[i])
+		}
+	}
+
+	if w := listStreams(t, handlers, "did:plc:host", "cursor=not-a-cursor"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid cursor, got %d", w.Code)
+	}
+}
+
+func TestListStreams_EndedPrivateStreamsHostOnly(t *testing.T) {
+	handlers, ids := newStreamListTest(t)
+
+	response := decodeStreamList(t, listStreams(t, handlers, "did:plc:listener", "status=ended"))
+	for _, id := range streamListIDs(response) {
+		if id == ids["private"] {
+			t.Error("expected ended private stream to be hidden from non-host")
+		}
+	}
+	if len(response.Streams) != 2 {
+		t.Errorf("expected 2 public ended streams, got %v", streamListIDs(response))
+	}
+
+	if w := listStreams(t, handlers, "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without authentication, got %d", w.Code)
+	}
+}
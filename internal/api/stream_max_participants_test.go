@@ -0,0 +1,174 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newCappedStreamTest creates a stream hosted by did:plc:host with the given
+// participant cap.
+func newCappedStreamTest(t *testing.T, maxParticipants int) (*StreamHandlers, *stream.InMemorySessionRepository, string) {
+	t.Helper()
+
+	sceneRepo := scene.NewInMemorySceneRepository()
+	createTestScene(t, sceneRepo, "scene-1", "did:plc:host")
+	streamRepo := stream.NewInMemorySessionRepository()
+	participantRepo := stream.NewInMemoryParticipantRepository(streamRepo)
+
+	streamID, _, err := streamRepo.CreateStreamSession(ptrString("scene-1"), nil, "did:plc:host")
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	if err := streamRepo.SetMaxParticipants(streamID, &maxParticipants); err != nil {
+		t.Fatalf("failed to set participant cap: %v", err)
+	}
+
+	handlers := NewStreamHandlers(streamRepo, participantRepo, nil, sceneRepo, scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, nil)
+	return handlers, streamRepo, streamID
+}
+
+func ptrInt(i int) *int {
+	return &i
+}
+
+func updateStreamAs(handlers *StreamHandlers, streamID, userDID string, reqBody UpdateStreamRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPatch, "/streams/"+streamID, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.UpdateStream(w, req)
+	return w
+}
+
+func TestJoinStream_MaxParticipants(t *testing.T) {
+	handlers, _, streamID := newCappedStreamTest(t, 2)
+
+	for _, did := range []string{"did:plc:viewer1", "did:plc:viewer2"} {
+		if w := joinStreamAs(handlers, streamID, did); w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", did, w.Code, w.Body.String())
+		}
+	}
+
+	w := joinStreamAs(handlers, streamID, "did:plc:viewer3")
+	assertErrorResponse(t, w, http.StatusConflict, ErrCodeConflict)
+
+	// An active participant joining again keeps their place
+	if w := joinStreamAs(handlers, streamID, "did:plc:viewer1"); w.Code == http.StatusConflict {
+		t.Errorf("expected an active participant's repeat join not to be rejected as full: %s", w.Body.String())
+	}
+
+	// The host always gets in
+	if w := joinStreamAs(handlers, streamID, "did:plc:host"); w.Code != http.StatusOK {
+		t.Errorf("host: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestJoinStream_MaxParticipants_FreedByLeave(t *testing.T) {
+	handlers, _, streamID := newCappedStreamTest(t, 1)
+
+	if w := joinStreamAs(handlers, streamID, "did:plc:viewer1"); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	assertErrorResponse(t, joinStreamAs(handlers, streamID, "did:plc:viewer2"), http.StatusConflict, ErrCodeConflict)
+
+	req := httptest.NewRequest(http.MethodPost, "/streams/"+streamID+"/leave", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:viewer1"))
+	handlers.LeaveStream(httptest.NewRecorder(), req)
+
+	if w := joinStreamAs(handlers, streamID, "did:plc:viewer2"); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 after a leave freed a place, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUpdateStream_MaxParticipants(t *testing.T) {
+	handlers, streamRepo, streamID := newCappedStreamTest(t, 1)
+
+	if w := joinStreamAs(handlers, streamID, "did:plc:viewer1"); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Raising the cap lets the next viewer in
+	w := updateStreamAs(handlers, streamID, "did:plc:host", UpdateStreamRequest{MaxParticipants: ptrInt(2)})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp StreamSessionResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.MaxParticipants == nil || *resp.MaxParticipants != 2 {
+		t.Errorf("expected max_participants 2 in response, got %v", resp.MaxParticipants)
+	}
+	if w := joinStreamAs(handlers, streamID, "did:plc:viewer2"); w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	assertErrorResponse(t, joinStreamAs(handlers, streamID, "did:plc:viewer3"), http.StatusConflict, ErrCodeConflict)
+
+	// 0 removes the cap
+	if w := updateStreamAs(handlers, streamID, "did:plc:host", UpdateStreamRequest{MaxParticipants: ptrInt(0)}); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if session, _ := streamRepo.GetByID(streamID); session.MaxParticipants != nil {
+		t.Errorf("expected the cap to be cleared, got %d", *session.MaxParticipants)
+	}
+	if w := joinStreamAs(handlers, streamID, "did:plc:viewer3"); w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertErrorResponse(t,
+		updateStreamAs(handlers, streamID, "did:plc:host", UpdateStreamRequest{MaxParticipants: ptrInt(-1)}),
+		http.StatusBadRequest, ErrCodeValidation)
+}
+
+func TestCreateStream_MaxParticipantsForwardedToRoomService(t *testing.T) {
+	handlers, streamRepo, rooms := newRegionTest(t)
+
+	body, _ := json.Marshal(CreateStreamRequest{SceneID: ptrString("scene-region"), MaxParticipants: ptrInt(25)})
+	req := httptest.NewRequest(http.MethodPost, "/streams", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:host123"))
+	w := httptest.NewRecorder()
+	handlers.CreateStream(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp StreamSessionResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.MaxParticipants == nil || *resp.MaxParticipants != 25 {
+		t.Errorf("expected max_participants 25 in response, got %v", resp.MaxParticipants)
+	}
+	if session, _ := streamRepo.GetByID(resp.ID); session.MaxParticipants == nil || *session.MaxParticipants != 25 {
+		t.Errorf("expected the cap to be stored on the session, got %v", session.MaxParticipants)
+	}
+	if len(rooms.created) != 1 || rooms.created[0].MaxParticipants != 25 {
+		t.Errorf("expected CreateRoom with max_participants 25, got %+v", rooms.created)
+	}
+}
+
+func TestCreateStream_InvalidMaxParticipants(t *testing.T) {
+	handlers, _, rooms := newRegionTest(t)
+
+	body, _ := json.Marshal(CreateStreamRequest{SceneID: ptrString("scene-region"), MaxParticipants: ptrInt(-5)})
+	req := httptest.NewRequest(http.MethodPost, "/streams", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:host123"))
+	w := httptest.NewRecorder()
+	handlers.CreateStream(w, req)
+
+	assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+	if len(rooms.created) != 0 {
+		t.Errorf("expected no room to be created, got %d", len(rooms.created))
+	}
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/onnwee/subcults/internal/audit"
+	livekitpkg "github.com/onnwee/subcults/internal/livekit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// fakeLiveKitParticipant is a LiveKit RoomService serving a single participant
+// whose mute requests fail for the track SIDs in failing.
+// Other methods panic via the nil embedded interface.
+type fakeLiveKitParticipant struct {
+	livekit.RoomService
+	tracks  []*livekit.TrackInfo
+	failing map[string]bool
+}
+
+func (f *fakeLiveKitParticipant) GetParticipant(_ context.Context, req *livekit.RoomParticipantIdentity) (*livekit.ParticipantInfo, error) {
+	return &livekit.ParticipantInfo{Identity: req.Identity, Tracks: f.tracks}, nil
+}
+
+func (f *fakeLiveKitParticipant) MutePublishedTrack(_ context.Context, req *livekit.MuteRoomTrackRequest) (*livekit.MuteRoomTrackResponse, error) {
+	if f.failing[req.TrackSid] {
+		return nil, errors.New("track unavailable")
+	}
+	return &livekit.MuteRoomTrackResponse{}, nil
+}
+
+// muteParticipantWithFailures mutes a participant with two audio tracks and one
+// video track, where mutes fail for the given audio track SIDs.
+func muteParticipantWithFailures(t *testing.T, failing ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	participant := &fakeLiveKitParticipant{
+		tracks: []*livekit.TrackInfo{
+			{Sid: "TR_audio1", Type: livekit.TrackType_AUDIO},
+			{Sid: "TR_audio2", Type: livekit.TrackType_AUDIO},
+			{Sid: "TR_video1", Type: livekit.TrackType_VIDEO},
+		},
+		failing: make(map[string]bool),
+	}
+	for _, sid := range failing {
+		participant.failing[sid] = true
+	}
+	server := httptest.NewServer(livekit.NewRoomServiceServer(participant))
+	t.Cleanup(server.Close)
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	roomService := livekitpkg.NewRoomService(server.URL, "APIkey123", "secret456")
+	handlers := NewStreamHandlers(streamRepo, nil, nil, scene.NewInMemorySceneRepository(), scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, roomService)
+
+	streamID, _, err := streamRepo.CreateStreamSession(ptrString("scene-mute"), nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	body, err := json.Marshal(MuteParticipantRequest{Muted: true})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/streams/"+streamID+"/participants/user-participant1/mute", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:host123"))
+	w := httptest.NewRecorder()
+	handlers.MuteParticipant(w, req)
+	return w
+}
+
+func TestMuteParticipant_TrackResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		failing    []string
+		wantMuted  []string
+		wantFailed []string
+	}{
+		{name: "full success", wantMuted: []string{"TR_audio1", "TR_audio2"}, wantFailed: []string{}},
+		{name: "partial failure", failing: []string{"TR_audio2"}, wantMuted: []string{"TR_audio1"}, wantFailed: []string{"TR_audio2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := muteParticipantWithFailures(t, tt.failing...)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var response struct {
+				TracksMuted  int      `json:"tracks_muted"`
+				MutedTracks  []string `json:"muted_tracks"`
+				FailedTracks []string `json:"failed_tracks"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if !reflect.DeepEqual(response.MutedTracks, tt.wantMuted) || response.TracksMuted != len(tt.wantMuted) {
+				t.Errorf("muted_tracks = %v (tracks_muted %d), want %v", response.MutedTracks, response.TracksMuted, tt.wantMuted)
+			}
+			if !reflect.DeepEqual(response.FailedTracks, tt.wantFailed) {
+				t.Errorf("failed_tracks = %v, want %v", response.FailedTracks, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestMuteParticipant_AllTracksFail(t *testing.T) {
+	w := muteParticipantWithFailures(t, "TR_audio1", "TR_audio2")
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var errResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != ErrCodeInternal {
+		t.Errorf("expected error code %s, got %s", ErrCodeInternal, errResp.Error.Code)
+	}
+}
+
+// fakeLiveKitRoom is a LiveKit RoomService serving a room's participants and
+// recording which participants had tracks muted. Mutes fail for participants
+// in failing. Other methods panic via the nil embedded interface.
+type fakeLiveKitRoom struct {
+	livekit.RoomService
+	participants []*livekit.ParticipantInfo
+	failing      map[string]bool
+	muted        map[string]int // participant identity -> tracks muted
+}
+
+func (f *fakeLiveKitRoom) ListParticipants(_ context.Context, _ *livekit.ListParticipantsRequest) (*livekit.ListParticipantsResponse, error) {
+	return &livekit.ListParticipantsResponse{Participants: f.participants}, nil
+}
+
+func (f *fakeLiveKitRoom) MutePublishedTrack(_ context.Context, req *livekit.MuteRoomTrackRequest) (*livekit.MuteRoomTrackResponse, error) {
+	if f.failing[req.Identity] {
+		return nil, errors.New("track unavailable")
+	}
+	f.muted[req.Identity]++
+	return &livekit.MuteRoomTrackResponse{}, nil
+}
+
+// muteAllTest creates a stream hosted by did:plc:host123 in a room with the
+// host and two speakers holding one audio track each, plus a listener with no
+// audio.
+func muteAllTest(t *testing.T, failing ...string) (*StreamHandlers, *fakeLiveKitRoom, audit.Repository, string) {
+	t.Helper()
+
+	room := &fakeLiveKitRoom{failing: make(map[string]bool), muted: make(map[string]int)}
+	for _, identity := range []string{stream.GenerateParticipantID("did:plc:host123"), "speaker1", "speaker2"} {
+		room.participants = append(room.participants, &livekit.ParticipantInfo{
+			Identity: identity,
+			Tracks:   []*livekit.TrackInfo{{Sid: "TR_" + identity, Type: livekit.TrackType_AUDIO}},
+		})
+	}
+	room.participants = append(room.participants, &livekit.ParticipantInfo{Identity: "listener1"})
+	for _, identity := range failing {
+		room.failing[identity] = true
+	}
+	server := httptest.NewServer(livekit.NewRoomServiceServer(room))
+	t.Cleanup(server.Close)
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	roomService := livekitpkg.NewRoomService(server.URL, "APIkey123", "secret456")
+	handlers := NewStreamHandlers(streamRepo, nil, nil, scene.NewInMemorySceneRepository(), scene.NewInMemoryEventRepository(), auditRepo, nil, nil, roomService)
+
+	streamID, _, err := streamRepo.CreateStreamSession(ptrString("scene-mute"), nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	return handlers, room, auditRepo, streamID
+}
+
+func doMuteAll(h *StreamHandlers, streamID, userDID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/streams/"+streamID+"/mute_all", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	h.MuteAll(w, req)
+	return w
+}
+
+func TestMuteAll_MutesEveryoneExceptListed(t *testing.T) {
+	handlers, room, auditRepo, streamID := muteAllTest(t)
+
+	w := doMuteAll(handlers, streamID, "did:plc:host123", `{"except":["speaker1"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response MuteAllResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.ParticipantsMuted != 1 || response.TracksMuted != 1 || len(response.FailedParticipants) != 0 {
+		t.Errorf("unexpected response: %+v", response)
+	}
+	if want := map[string]int{"speaker2": 1}; !reflect.DeepEqual(room.muted, want) {
+		t.Errorf("muted = %v, want %v", room.muted, want)
+	}
+
+	// A single audit entry covers the whole operation
+	logs, err := auditRepo.QueryByEntity("stream", streamID, 0)
+	if err != nil {
+		t.Fatalf("failed to query audit logs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Action != "muted_all" {
+		t.Errorf("expected one muted_all audit entry, got %+v", logs)
+	}
+}
+
+func TestMuteAll_EmptyBodyMutesEveryoneButHost(t *testing.T) {
+	handlers, room, _, streamID := muteAllTest(t)
+
+	if w := doMuteAll(handlers, streamID, "did:plc:host123", ""); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if want := map[string]int{"speaker1": 1, "speaker2": 1}; !reflect.DeepEqual(room.muted, want) {
+		t.Errorf("muted = %v, want %v", room.muted, want)
+	}
+}
+
+func TestMuteAll_AggregatesFailures(t *testing.T) {
+	handlers, _, _, streamID := muteAllTest(t, "speaker2")
+
+	w := doMuteAll(handlers, streamID, "did:plc:host123", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response MuteAllResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.ParticipantsMuted != 1 || !reflect.DeepEqual(response.FailedParticipants, []string{"speaker2"}) {
+		t.Errorf("unexpected response: %+v", response)
+	}
+
+	// Nothing muted at all is a server error
+	handlers, _, _, streamID = muteAllTest(t, "speaker1", "speaker2")
+	if w := doMuteAll(handlers, streamID, "did:plc:host123", ""); w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMuteAll_HostOnly(t *testing.T) {
+	handlers, room, _, streamID := muteAllTest(t)
+
+	if w := doMuteAll(handlers, streamID, "did:plc:guest", ""); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(room.muted) != 0 {
+		t.Errorf("expected no mutes, got %v", room.muted)
+	}
+}
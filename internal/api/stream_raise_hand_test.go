@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/onnwee/subcults/internal/audit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// newRaiseHandTest creates stream handlers with participant tracking and an
+// event broadcaster over a single live stream, returning the handlers, stream
+// session repository, broadcaster and stream ID.
+func newRaiseHandTest(t *testing.T) (*StreamHandlers, *stream.InMemorySessionRepository, *stream.EventBroadcaster, string) {
+	t.Helper()
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	participantRepo := stream.NewInMemoryParticipantRepository(streamRepo)
+	broadcaster := stream.NewEventBroadcaster()
+
+	streamID, _, err := streamRepo.CreateStreamSession(ptrString("scene-1"), nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	handlers := NewStreamHandlers(streamRepo, participantRepo, nil, scene.NewInMemorySceneRepository(), scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, broadcaster, nil)
+	return handlers, streamRepo, broadcaster, streamID
+}
+
+func doRaiseHand(h *StreamHandlers, method, streamID, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/streams/"+streamID+"/raise_hand", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	if method == http.MethodDelete {
+		h.LowerHand(w, req)
+	} else {
+		h.RaiseHand(w, req)
+	}
+	return w
+}
+
+// subscribeToStream connects a WebSocket subscriber to the broadcaster for
+// streamID and returns the client side of the connection.
+func subscribeToStream(t *testing.T, broadcaster *stream.EventBroadcaster, streamID string) *websocket.Conn {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		if err := broadcaster.Subscribe(streamID, conn); err != nil {
+			conn.Close()
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	waitForConnectionCount(t, broadcaster, streamID, 1)
+	return conn
+}
+
+func readParticipantEvent(t *testing.T, conn *websocket.Conn) stream.ParticipantStateEvent {
+	t.Helper()
+	var event stream.ParticipantStateEvent
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("failed to set read deadline: %v", err)
+	}
+	if err := conn.ReadJSON(&event); err != nil {
+		t.Fatalf("failed to read event: %v", err)
+	}
+	return event
+}
+
+func TestRaiseHand_RaiseAndLower(t *testing.T) {
+	handlers, _, broadcaster, streamID := newRaiseHandTest(t)
+	const viewer = "did:plc:viewer"
+
+	if w := joinStreamAs(handlers, streamID, viewer); w.Code != http.StatusOK {
+		t.Fatalf("join: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	conn := subscribeToStream(t, broadcaster, streamID)
+
+	w := doRaiseHand(handlers, http.MethodPost, streamID, viewer)
+	if w.Code != http.StatusOK {
+		t.Fatalf("raise: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp RaiseHandResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.HandRaised || resp.ParticipantID != stream.GenerateParticipantID(viewer) {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if event := readParticipantEvent(t, conn); event.Type != "hand_raised" || event.UserDID != viewer || event.ActiveCount != 1 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	// A second raise is rejected
+	if w := doRaiseHand(handlers, http.MethodPost, streamID, viewer); w.Code != http.StatusConflict {
+		t.Errorf("repeat raise: expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := doRaiseHand(handlers, http.MethodDelete, streamID, viewer); w.Code != http.StatusOK {
+		t.Fatalf("lower: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if event := readParticipantEvent(t, conn); event.Type != "hand_lowered" || event.UserDID != viewer {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if w := doRaiseHand(handlers, http.MethodDelete, streamID, viewer); w.Code != http.StatusConflict {
+		t.Errorf("repeat lower: expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRaiseHand_ClearedOnLeave(t *testing.T) {
+	handlers, _, _, streamID := newRaiseHandTest(t)
+	const viewer = "did:plc:viewer"
+
+	joinStreamAs(handlers, streamID, viewer)
+	if w := doRaiseHand(handlers, http.MethodPost, streamID, viewer); w.Code != http.StatusOK {
+		t.Fatalf("raise: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/streams/"+streamID+"/leave", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), viewer))
+	w := httptest.NewRecorder()
+	handlers.LeaveStream(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("leave: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Lowering after leaving fails, and rejoining starts with the hand down
+	if w := doRaiseHand(handlers, http.MethodDelete, streamID, viewer); w.Code != http.StatusNotFound {
+		t.Errorf("lower after leave: expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+	joinStreamAs(handlers, streamID, viewer)
+	if w := doRaiseHand(handlers, http.MethodDelete, streamID, viewer); w.Code != http.StatusConflict {
+		t.Errorf("lower after rejoin: expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doRaiseHand(handlers, http.MethodPost, streamID, viewer); w.Code != http.StatusOK {
+		t.Errorf("raise after rejoin: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRaiseHand_Validation(t *testing.T) {
+	handlers, streamRepo, _, streamID := newRaiseHandTest(t)
+	endedID, _, err := streamRepo.CreateStreamSession(ptrString("scene-1"), nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	joinStreamAs(handlers, endedID, "did:plc:viewer")
+	if err := streamRepo.EndStreamSession(endedID); err != nil {
+		t.Fatalf("failed to end stream: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		streamID   string
+		userDID    string
+		wantStatus int
+	}{
+		{name: "unauthenticated", streamID: streamID, wantStatus: http.StatusUnauthorized},
+		{name: "unknown stream", streamID: "stream-missing", userDID: "did:plc:viewer", wantStatus: http.StatusNotFound},
+		{name: "not a participant", streamID: streamID, userDID: "did:plc:viewer", wantStatus: http.StatusNotFound},
+		{name: "ended stream", streamID: endedID, userDID: "did:plc:viewer", wantStatus: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doRaiseHand(handlers, http.MethodPost, tt.streamID, tt.userDID); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/onnwee/subcults/internal/audit"
+	livekitpkg "github.com/onnwee/subcults/internal/livekit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// fakeLiveKitRooms is a LiveKit RoomService that records CreateRoom requests.
+// Only CreateRoom is implemented; other methods panic via the nil embedded interface.
+type fakeLiveKitRooms struct {
+	livekit.RoomService
+	mu      sync.Mutex
+	created []*livekit.CreateRoomRequest
+}
+
+func (f *fakeLiveKitRooms) CreateRoom(_ context.Context, req *livekit.CreateRoomRequest) (*livekit.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created = append(f.created, req)
+	return &livekit.Room{Name: req.Name}, nil
+}
+
+// newRegionTest creates stream handlers backed by a fake LiveKit server, with
+// regions us-east and eu-west configured and us-east as home.
+func newRegionTest(t *testing.T) (*StreamHandlers, *stream.InMemorySessionRepository, *fakeLiveKitRooms) {
+	t.Helper()
+
+	rooms := &fakeLiveKitRooms{}
+	server := httptest.NewServer(livekit.NewRoomServiceServer(rooms))
+	t.Cleanup(server.Close)
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	sceneRepo := scene.NewInMemorySceneRepository()
+	if err := sceneRepo.Insert(&scene.Scene{
+		ID:            "scene-region",
+		Name:          "Region Scene",
+		OwnerDID:      "did:plc:host123",
+		CoarseGeohash: "dr5regw",
+	}); err != nil {
+		t.Fatalf("failed to insert scene: %v", err)
+	}
+
+	roomService := livekitpkg.NewRoomService(server.URL, "APIkey123", "secret456")
+	handlers := NewStreamHandlers(streamRepo, nil, nil, sceneRepo, scene.NewInMemoryEventRepository(), audit.NewInMemoryRepository(), nil, nil, roomService)
+	handlers.SetRegions([]string{"us-east", "eu-west"}, "us-east")
+
+	return handlers, streamRepo, rooms
+}
+
+func createStreamInRegion(t *testing.T, handlers *StreamHandlers, region *string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(CreateStreamRequest{SceneID: ptrString("scene-region"), Region: region})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/streams", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:host123"))
+	w := httptest.NewRecorder()
+	handlers.CreateStream(w, req)
+	return w
+}
+
+func TestCreateStream_RegionRecorded(t *testing.T) {
+	tests := []struct {
+		name   string
+		region *string
+		want   string
+	}{
+		{name: "requested region", region: ptrString("eu-west"), want: "eu-west"},
+		{name: "defaults to home region", region: nil, want: "us-east"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers, streamRepo, rooms := newRegionTest(t)
+
+			w := createStreamInRegion(t, handlers, tt.region)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var response StreamSessionResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Region != tt.want {
+				t.Errorf("response region = %q, want %q", response.Region, tt.want)
+			}
+
+			if len(rooms.created) != 1 {
+				t.Fatalf("expected 1 CreateRoom call, got %d", len(rooms.created))
+			}
+			// LiveKit places the room itself; the region is never sent as a node ID
+			if got := rooms.created[0]; got.NodeId != "" || got.Name != response.RoomName {
+				t.Errorf("CreateRoom(name=%q, node=%q), want (%q, \"\")", got.Name, got.NodeId, response.RoomName)
+			}
+
+			session, err := streamRepo.GetByID(response.ID)
+			if err != nil {
+				t.Fatalf("failed to get session: %v", err)
+			}
+			if session.Region != tt.want {
+				t.Errorf("session region = %q, want %q", session.Region, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateStream_UnknownRegionRejected(t *testing.T) {
+	handlers, _, rooms := newRegionTest(t)
+
+	w := createStreamInRegion(t, handlers, ptrString("ap-south"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(rooms.created) != 0 {
+		t.Errorf("expected no room to be created, got %d", len(rooms.created))
+	}
+
+	// Without configured regions any requested region is unknown
+	handlers.SetRegions(nil, "")
+	w = createStreamInRegion(t, handlers, ptrString("us-east"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 with no regions configured, got %d: %s", w.Code, w.Body.String())
+	}
+}
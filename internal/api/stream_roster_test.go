@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/onnwee/subcults/internal/audit"
+	livekitpkg "github.com/onnwee/subcults/internal/livekit"
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// rosterTest creates a stream hosted by did:plc:host123 with participant
+// tracking, backed by a LiveKit room whose participants mirror whoever joins.
+func rosterTest(t *testing.T) (*StreamHandlers, *fakeLiveKitRoom, audit.Repository, string) {
+	t.Helper()
+
+	room := &fakeLiveKitRoom{failing: make(map[string]bool), muted: make(map[string]int)}
+	server := httptest.NewServer(livekit.NewRoomServiceServer(room))
+	t.Cleanup(server.Close)
+
+	streamRepo := stream.NewInMemorySessionRepository()
+	auditRepo := audit.NewInMemoryRepository()
+	roomService := livekitpkg.NewRoomService(server.URL, "APIkey123", "secret456")
+	handlers := NewStreamHandlers(streamRepo, stream.NewInMemoryParticipantRepository(streamRepo), nil, scene.NewInMemorySceneRepository(), scene.NewInMemoryEventRepository(), auditRepo, nil, nil, roomService)
+
+	streamID, _, err := streamRepo.CreateStreamSession(ptrString("scene-roster"), nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	return handlers, room, auditRepo, streamID
+}
+
+// joinRoomAs joins the stream as userDID and adds them to the LiveKit room with
+// one audio track.
+func joinRoomAs(t *testing.T, h *StreamHandlers, room *fakeLiveKitRoom, streamID, userDID string) string {
+	t.Helper()
+	if w := joinStreamAs(h, streamID, userDID); w.Code != http.StatusOK {
+		t.Fatalf("join as %s: expected status 200, got %d: %s", userDID, w.Code, w.Body.String())
+	}
+	identity := stream.GenerateParticipantID(userDID)
+	room.participants = append(room.participants, &livekit.ParticipantInfo{
+		Identity: identity,
+		Tracks:   []*livekit.TrackInfo{{Sid: "TR_" + identity, Type: livekit.TrackType_AUDIO}},
+	})
+	return identity
+}
+
+func doGetRoster(h *StreamHandlers, streamID, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/streams/"+streamID+"/roster", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	h.GetRoster(w, req)
+	return w
+}
+
+func TestGetRoster_HostSeesParticipantState(t *testing.T) {
+	handlers, room, auditRepo, streamID := rosterTest(t)
+
+	first := joinRoomAs(t, handlers, room, streamID, "did:plc:first")
+	time.Sleep(time.Millisecond) // Distinct join times
+	second := joinRoomAs(t, handlers, room, streamID, "did:plc:second")
+
+	if w := doRaiseHand(handlers, http.MethodPost, streamID, "did:plc:second"); w.Code != http.StatusOK {
+		t.Fatalf("raise: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doMuteAll(handlers, streamID, "did:plc:host123", `{"except":["`+second+`"]}`); w.Code != http.StatusOK {
+		t.Fatalf("mute all: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := doGetRoster(handlers, streamID, "did:plc:host123")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response RosterResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.ActiveCount != 2 || len(response.Participants) != 2 {
+		t.Fatalf("expected 2 participants, got %+v", response)
+	}
+	if p := response.Participants[0]; p.ParticipantID != first || !p.Muted || p.HandRaisedAt != nil || p.JoinedAt.IsZero() {
+		t.Errorf("unexpected first participant: %+v", p)
+	}
+	if p := response.Participants[1]; p.ParticipantID != second || p.Muted || p.HandRaisedAt == nil {
+		t.Errorf("unexpected second participant: %+v", p)
+	}
+
+	logs, err := auditRepo.QueryByEntity("stream", streamID, 0)
+	if err != nil {
+		t.Fatalf("failed to query audit logs: %v", err)
+	}
+	var viewed int
+	for _, log := range logs {
+		if log.Action == "roster_viewed" && log.UserDID == "did:plc:host123" {
+			viewed++
+		}
+	}
+	if viewed != 1 {
+		t.Errorf("expected one roster_viewed audit entry, got %+v", logs)
+	}
+}
+
+func TestGetRoster_HostOnly(t *testing.T) {
+	handlers, room, auditRepo, streamID := rosterTest(t)
+	joinRoomAs(t, handlers, room, streamID, "did:plc:viewer")
+
+	if w := doGetRoster(handlers, streamID, "did:plc:viewer"); w.Code != http.StatusForbidden {
+		t.Errorf("viewer: expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doGetRoster(handlers, streamID, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous: expected status 401, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doGetRoster(handlers, "stream-missing", "did:plc:host123"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown stream: expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+	if logs, _ := auditRepo.QueryByEntity("stream", streamID, 0); len(logs) != 0 {
+		t.Errorf("expected no audit entries for rejected views, got %+v", logs)
+	}
+
+	// The public participants endpoint stays count-only
+	req := httptest.NewRequest(http.MethodGet, "/streams/"+streamID+"/participants", nil)
+	w := httptest.NewRecorder()
+	handlers.GetActiveParticipants(w, req)
+	var public map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&public); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := public["participants"]; ok || public["active_count"] != float64(1) {
+		t.Errorf("expected a count-only response, got %v", public)
+	}
+}
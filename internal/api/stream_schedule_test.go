@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/middleware"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+func createScheduledStream(t *testing.T, handlers *StreamHandlers, startAt time.Time) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(CreateStreamRequest{SceneID: ptrString("scene-region"), ScheduledStartAt: &startAt})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/streams", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:plc:host123"))
+	w := httptest.NewRecorder()
+	handlers.CreateStream(w, req)
+	return w
+}
+
+func startStreamAs(handlers *StreamHandlers, streamID, userDID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/streams/"+streamID+"/start", nil)
+	req = req.WithContext(middleware.SetUserDID(req.Context(), userDID))
+	w := httptest.NewRecorder()
+	handlers.StartStream(w, req)
+	return w
+}
+
+func TestScheduledStream_Lifecycle(t *testing.T) {
+	handlers, streamRepo, rooms := newRegionTest(t)
+	startAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	w := createScheduledStream(t, handlers, startAt)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created StreamSessionResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.Status != stream.StatusScheduled {
+		t.Errorf("expected status %q, got %q", stream.StatusScheduled, created.Status)
+	}
+	if !created.ScheduledStartAt.Equal(startAt) {
+		t.Errorf("expected scheduled_start_at %v, got %v", startAt, created.ScheduledStartAt.Time)
+	}
+	if len(rooms.created) != 0 {
+		t.Fatalf("expected no LiveKit room before go-live, got %d", len(rooms.created))
+	}
+
+	// Nobody can join before go-live
+	assertErrorResponse(t, joinStreamAs(handlers, created.ID, "did:plc:viewer"), http.StatusConflict, ErrCodeConflict)
+
+	// Only the host can start it
+	assertErrorResponse(t, startStreamAs(handlers, created.ID, "did:plc:viewer"), http.StatusForbidden, ErrCodeForbidden)
+
+	w = startStreamAs(handlers, created.ID, "did:plc:host123")
+	if w.Code != http.StatusOK {
+		t.Fatalf("start: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var started StreamSessionResponse
+	if err := json.NewDecoder(w.Body).Decode(&started); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if started.Status != stream.StatusActive {
+		t.Errorf("expected status %q, got %q", stream.StatusActive, started.Status)
+	}
+	if len(rooms.created) != 1 || rooms.created[0].Name != created.RoomName {
+		t.Errorf("expected the room %q to be created on start, got %+v", created.RoomName, rooms.created)
+	}
+
+	session, err := streamRepo.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("failed to get stream: %v", err)
+	}
+	if session.Status() != stream.StatusActive || session.ScheduledStartAt != nil {
+		t.Errorf("expected the session to be live, got status %q, scheduled_start_at %v", session.Status(), session.ScheduledStartAt)
+	}
+
+	if w := joinStreamAs(handlers, created.ID, "did:plc:viewer"); w.Code != http.StatusOK {
+		t.Errorf("join after start: expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// A live stream can't be started again
+	assertErrorResponse(t, startStreamAs(handlers, created.ID, "did:plc:host123"), http.StatusConflict, ErrCodeConflict)
+}
+
+func TestCreateStream_InvalidScheduledStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		startAt time.Time
+	}{
+		{name: "in the past", startAt: time.Now().Add(-time.Minute)},
+		{name: "too far ahead", startAt: time.Now().Add(maxStreamScheduleLead + time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers, _, rooms := newRegionTest(t)
+
+			w := createScheduledStream(t, handlers, tt.startAt)
+			assertErrorResponse(t, w, http.StatusBadRequest, ErrCodeValidation)
+			if len(rooms.created) != 0 {
+				t.Errorf("expected no room to be created, got %d", len(rooms.created))
+			}
+		})
+	}
+}
+
+func TestStartStream_NotScheduled(t *testing.T) {
+	handlers, _, _ := newRegionTest(t)
+
+	w := createStreamInRegion(t, handlers, nil)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created StreamSessionResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	assertErrorResponse(t, startStreamAs(handlers, created.ID, "did:plc:host123"), http.StatusConflict, ErrCodeConflict)
+	assertErrorResponse(t, startStreamAs(handlers, "missing-stream", "did:plc:host123"), http.StatusNotFound, ErrCodeNotFound)
+}
@@ -2,6 +2,10 @@
 package api
 
 import (
+	"context"
+	"log/slog"
+
+	"github.com/onnwee/subcults/internal/ranking"
 	"github.com/onnwee/subcults/internal/trust"
 )
 
@@ -32,3 +36,26 @@ func NewTrustScoreStoreAdapter(store *trust.InMemoryScoreStore) TrustScoreStore
 	}
 	return &trustScoreStoreAdapter{store: store}
 }
+
+// lookupTrustScores fetches trust scores for the given scenes.
+// If any lookup fails, the trust graph is treated as unavailable for this request:
+// it returns ok=false so the caller ranks on the remaining components only, rather
+// than mixing scored and unscored scenes. The degradation is logged and counted.
+func lookupTrustScores(ctx context.Context, store TrustScoreStore, sceneIDs []string, search string) (scores map[string]float64, ok bool) {
+	scores = make(map[string]float64, len(sceneIDs))
+	for _, sceneID := range sceneIDs {
+		score, err := store.GetScore(sceneID)
+		if err != nil {
+			slog.WarnContext(ctx, "trust lookup failed, ranking without trust",
+				"search", search,
+				"scene_id", sceneID,
+				"error", err)
+			ranking.RecordTrustDegraded(search)
+			return nil, false
+		}
+		if score != nil {
+			scores[sceneID] = score.Score
+		}
+	}
+	return scores, true
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/onnwee/subcults/internal/color"
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/scene"
+)
+
+// Warning codes for soft validation. Codes are stable so clients can localize
+// them; the message is an English fallback.
+const (
+	WarnLowContrastPalette = "low_contrast_palette"
+	WarnPointNearCellEdge  = "precise_point_near_cell_edge"
+	WarnUnusuallyLongEvent = "unusually_long_event"
+)
+
+// Soft validation thresholds.
+const (
+	// minPaletteContrast is the WCAG AA contrast ratio for normal text.
+	minPaletteContrast = 4.5
+	// cellEdgeMargin is the fraction of a coarse geohash cell's width or height
+	// within which a precise point counts as near the edge.
+	cellEdgeMargin = 0.1
+	// longEventThreshold is the duration beyond which an event is unusually long.
+	longEventThreshold = 24 * time.Hour
+)
+
+// Warning is a non-fatal validation finding returned alongside a successful
+// create or update. It never blocks the write.
+type Warning struct {
+	Code    string `json:"code"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// sceneWarnings runs the soft validators for a stored scene.
+func sceneWarnings(s *scene.Scene) []Warning {
+	var warnings []Warning
+	if w, ok := paletteContrastWarning(s.Palette); ok {
+		warnings = append(warnings, w)
+	}
+	if w, ok := cellEdgeWarning(s.PrecisePoint, s.CoarseGeohash); ok {
+		warnings = append(warnings, w)
+	}
+	return warnings
+}
+
+// eventWarnings runs the soft validators for a stored event.
+func eventWarnings(event *scene.Event) []Warning {
+	var warnings []Warning
+	if w, ok := cellEdgeWarning(event.PrecisePoint, event.CoarseGeohash); ok {
+		warnings = append(warnings, w)
+	}
+	if event.EndsAt != nil && event.EndsAt.Sub(event.StartsAt) > longEventThreshold {
+		warnings = append(warnings, Warning{
+			Code:    WarnUnusuallyLongEvent,
+			Field:   "ends_at",
+			Message: fmt.Sprintf("Event lasts longer than %d hours", int(longEventThreshold.Hours())),
+		})
+	}
+	return warnings
+}
+
+// paletteContrastWarning warns when the palette's text and background colors
+// fall below WCAG AA contrast. Palettes with missing or malformed colors are
+// not checked.
+func paletteContrastWarning(palette *scene.Palette) (Warning, bool) {
+	if palette == nil {
+		return Warning{}, false
+	}
+	text, err := color.ParseHexColor(palette.Text)
+	if err != nil {
+		return Warning{}, false
+	}
+	background, err := color.ParseHexColor(palette.Background)
+	if err != nil {
+		return Warning{}, false
+	}
+	ratio := color.ContrastRatio(text, background)
+	if ratio >= minPaletteContrast {
+		return Warning{}, false
+	}
+	return Warning{
+		Code:    WarnLowContrastPalette,
+		Field:   "palette",
+		Message: fmt.Sprintf("Text and background contrast is %s:1, below the 4.5:1 WCAG AA minimum", formatRatio(ratio)),
+	}, true
+}
+
+// cellEdgeWarning warns when a precise point lies near the edge of, or outside,
+// its coarse geohash cell, where the public location may mislead.
+func cellEdgeWarning(point *scene.Point, coarseGeohash string) (Warning, bool) {
+	if point == nil {
+		return Warning{}, false
+	}
+	cell, ok := geo.DecodeBounds(coarseGeohash)
+	if !ok {
+		return Warning{}, false
+	}
+	latMargin := math.Min(point.Lat-cell.MinLat, cell.MaxLat-point.Lat) / (cell.MaxLat - cell.MinLat)
+	lngMargin := math.Min(point.Lng-cell.MinLng, cell.MaxLng-point.Lng) / (cell.MaxLng - cell.MinLng)
+	if math.Min(latMargin, lngMargin) >= cellEdgeMargin {
+		return Warning{}, false
+	}
+	return Warning{
+		Code:    WarnPointNearCellEdge,
+		Field:   "precise_point",
+		Message: "Precise point is near the edge of its coarse geohash cell",
+	}, true
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/geo"
+	"github.com/onnwee/subcults/internal/membership"
+	"github.com/onnwee/subcults/internal/scene"
+	"github.com/onnwee/subcults/internal/stream"
+)
+
+// cellCenter returns the center of a geohash cell.
+func cellCenter(t *testing.T, geohash string) *scene.Point {
+	t.Helper()
+	b, ok := geo.DecodeBounds(geohash)
+	if !ok {
+		t.Fatalf("failed to decode geohash %q", geohash)
+	}
+	return &scene.Point{Lat: (b.MinLat + b.MaxLat) / 2, Lng: (b.MinLng + b.MaxLng) / 2}
+}
+
+func createSceneWithWarnings(t *testing.T, req CreateSceneRequest) SceneResponse {
+	t.Helper()
+	handlers := NewSceneHandlers(scene.NewInMemorySceneRepository(), membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	httpReq := httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handlers.CreateScene(w, httpReq)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var response SceneResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response
+}
+
+func TestCreateScene_LowContrastPaletteWarns(t *testing.T) {
+	response := createSceneWithWarnings(t, CreateSceneRequest{
+		Name:          "Murky Scene",
+		OwnerDID:      "did:plc:test123",
+		CoarseGeohash: "dr5regw",
+		Palette:       &scene.Palette{Primary: "#ff0000", Secondary: "#00ff00", Accent: "#0000ff", Background: "#777777", Text: "#888888"},
+	})
+
+	if response.Scene == nil || response.ID == "" {
+		t.Fatal("expected the scene to be created")
+	}
+	if len(response.Warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %+v", response.Warnings)
+	}
+	if w := response.Warnings[0]; w.Code != WarnLowContrastPalette || w.Field != "palette" || w.Message == "" {
+		t.Errorf("unexpected warning: %+v", w)
+	}
+}
+
+func TestCreateScene_CleanSceneHasNoWarnings(t *testing.T) {
+	handlers := NewSceneHandlers(scene.NewInMemorySceneRepository(), membership.NewInMemoryMembershipRepository(), stream.NewInMemorySessionRepository())
+	body, err := json.Marshal(CreateSceneRequest{
+		Name:          "Clean Scene",
+		OwnerDID:      "did:plc:test123",
+		AllowPrecise:  true,
+		PrecisePoint:  cellCenter(t, "dr5regw"),
+		CoarseGeohash: "dr5regw",
+		Palette:       &scene.Palette{Primary: "#ff0000", Secondary: "#00ff00", Accent: "#0000ff", Background: "#ffffff", Text: "#000000"},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	handlers.CreateScene(w, httptest.NewRequest(http.MethodPost, "/scenes", bytes.NewReader(body)))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(w.Body).Decode(&raw); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if warnings, ok := raw["warnings"]; ok {
+		t.Errorf("expected no warnings field, got %s", warnings)
+	}
+}
+
+func TestCreateScene_PointNearCellEdgeWarns(t *testing.T) {
+	b, _ := geo.DecodeBounds("dr5regw")
+	response := createSceneWithWarnings(t, CreateSceneRequest{
+		Name:          "Edge Scene",
+		OwnerDID:      "did:plc:test123",
+		AllowPrecise:  true,
+		PrecisePoint:  &scene.Point{Lat: (b.MinLat + b.MaxLat) / 2, Lng: b.MaxLng - (b.MaxLng-b.MinLng)*0.01},
+		CoarseGeohash: "dr5regw",
+	})
+
+	if len(response.Warnings) != 1 || response.Warnings[0].Code != WarnPointNearCellEdge {
+		t.Errorf("expected a %s warning, got %+v", WarnPointNearCellEdge, response.Warnings)
+	}
+}
+
+func TestEventWarnings_UnusuallyLongEvent(t *testing.T) {
+	startsAt := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	short := startsAt.Add(4 * time.Hour)
+	long := startsAt.Add(3 * 24 * time.Hour)
+
+	if warnings := eventWarnings(&scene.Event{StartsAt: startsAt, EndsAt: &short}); len(warnings) != 0 {
+		t.Errorf("expected no warnings for a 4 hour event, got %+v", warnings)
+	}
+	warnings := eventWarnings(&scene.Event{StartsAt: startsAt, EndsAt: &long})
+	if len(warnings) != 1 || warnings[0].Code != WarnUnusuallyLongEvent || warnings[0].Field != "ends_at" {
+		t.Errorf("expected an %s warning, got %+v", WarnUnusuallyLongEvent, warnings)
+	}
+}
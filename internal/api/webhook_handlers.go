@@ -3,13 +3,17 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/onnwee/subcults/internal/middleware"
 	"github.com/onnwee/subcults/internal/payment"
+	"github.com/onnwee/subcults/internal/replay"
 	"github.com/onnwee/subcults/internal/scene"
 	"github.com/stripe/stripe-go/v81"
 	"github.com/stripe/stripe-go/v81/webhook"
@@ -29,6 +33,7 @@ type WebhookHandlers struct {
 	paymentRepo   payment.PaymentRepository
 	webhookRepo   payment.WebhookRepository
 	sceneRepo     scene.SceneRepository
+	replayGuard   *replay.Guard
 }
 
 // NewWebhookHandlers creates a new WebhookHandlers instance.
@@ -46,6 +51,14 @@ func NewWebhookHandlers(
 	}
 }
 
+// SetReplayGuard enables anti-replay checks: deliveries whose signed timestamp
+// is outside the guard's window, or that repeat an already-seen signed
+// delivery, are rejected. The guard's window replaces Stripe's default
+// signature tolerance. Without a guard, only that tolerance applies.
+func (h *WebhookHandlers) SetReplayGuard(guard *replay.Guard) {
+	h.replayGuard = guard
+}
+
 // HandleStripeWebhook processes Stripe webhook events with signature verification.
 // POST /internal/stripe
 func (h *WebhookHandlers) HandleStripeWebhook(w http.ResponseWriter, r *http.Request) {
@@ -67,15 +80,16 @@ func (h *WebhookHandlers) HandleStripeWebhook(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Verify the webhook signature
-	event, err := webhook.ConstructEvent(body, signature, h.webhookSecret)
+	// Verify the webhook signature. With a replay guard, the guard owns the
+	// timestamp check so its window is the only one applied.
+	opts := webhook.ConstructEventOptions{IgnoreTolerance: h.replayGuard != nil}
+	event, err := webhook.ConstructEventWithOptions(body, signature, h.webhookSecret, opts)
 	if err != nil {
 		// Stripe may deliver events using a newer API version than stripe-go expects.
 		// Keep cryptographic signature verification strict, but allow API-version mismatch
 		// to avoid unnecessary webhook downtime when endpoint version drift occurs.
-		event, err = webhook.ConstructEventWithOptions(body, signature, h.webhookSecret, webhook.ConstructEventOptions{
-			IgnoreAPIVersionMismatch: true,
-		})
+		opts.IgnoreAPIVersionMismatch = true
+		event, err = webhook.ConstructEventWithOptions(body, signature, h.webhookSecret, opts)
 		if err != nil {
 			slog.WarnContext(ctx, "webhook signature verification failed", "error", err)
 			ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
@@ -86,6 +100,22 @@ func (h *WebhookHandlers) HandleStripeWebhook(w http.ResponseWriter, r *http.Req
 		slog.WarnContext(ctx, "webhook accepted with API version mismatch", "error", err)
 	}
 
+	// Reject stale and replayed deliveries. Stripe re-signs each retry with a
+	// new timestamp, so keying on the signed timestamp as well as the event ID
+	// only rejects exact replays; retries fall through to the idempotency check.
+	if h.replayGuard != nil {
+		signedAt, err := stripeSignedAt(signature)
+		if err == nil {
+			err = h.replayGuard.Check("stripe:"+event.ID+":"+strconv.FormatInt(signedAt.Unix(), 10), signedAt)
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "webhook rejected by replay guard", "event_id", event.ID, "error", err)
+			ctx = middleware.SetErrorCode(ctx, ErrCodeBadRequest)
+			WriteError(w, ctx, http.StatusBadRequest, ErrCodeBadRequest, "stale or replayed webhook")
+			return
+		}
+	}
+
 	// Log minimal event info (type and ID only, not full payload)
 	slog.InfoContext(ctx, "webhook event received", "event_type", event.Type, "event_id", event.ID)
 
@@ -123,6 +153,21 @@ func (h *WebhookHandlers) HandleStripeWebhook(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusOK)
 }
 
+// stripeSignedAt returns the signing timestamp from a Stripe-Signature header
+// of the form "t=1495999758,v1=...".
+func stripeSignedAt(header string) (time.Time, error) {
+	for _, pair := range strings.Split(header, ",") {
+		if value, ok := strings.CutPrefix(pair, "t="); ok {
+			unix, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return time.Time{}, errors.New("invalid Stripe-Signature timestamp")
+			}
+			return time.Unix(unix, 0), nil
+		}
+	}
+	return time.Time{}, errors.New("missing Stripe-Signature timestamp")
+}
+
 // handleCheckoutSessionCompleted processes checkout.session.completed events.
 func (h *WebhookHandlers) handleCheckoutSessionCompleted(ctx context.Context, event stripe.Event) {
 	var session stripe.CheckoutSession
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/onnwee/subcults/internal/payment"
+	"github.com/onnwee/subcults/internal/replay"
 	"github.com/onnwee/subcults/internal/scene"
 )
 
@@ -466,6 +467,67 @@ func TestHandleStripeWebhook_UnknownEventType(t *testing.T) {
 	}
 }
 
+// TestHandleStripeWebhook_ReplayGuard tests the anti-replay window on top of signature checks.
+func TestHandleStripeWebhook_ReplayGuard(t *testing.T) {
+	webhookSecret := "whsec_test_secret"
+
+	tests := []struct {
+		name       string
+		window     time.Duration
+		age        time.Duration
+		deliveries int
+		wantStatus int
+	}{
+		{name: "fresh webhook accepted", window: time.Minute, age: 10 * time.Second, deliveries: 1, wantStatus: http.StatusOK},
+		{name: "too old timestamp rejected", window: time.Minute, age: 2 * time.Minute, deliveries: 1, wantStatus: http.StatusBadRequest},
+		{name: "window wider than Stripe default", window: 10 * time.Minute, age: 6 * time.Minute, deliveries: 1, wantStatus: http.StatusOK},
+		{name: "replay within window rejected", window: time.Minute, age: 10 * time.Second, deliveries: 2, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewWebhookHandlers(webhookSecret, payment.NewInMemoryPaymentRepository(), payment.NewInMemoryWebhookRepository(), scene.NewInMemorySceneRepository())
+			handlers.SetReplayGuard(replay.NewGuard(tt.window))
+
+			body := createStripeEventJSON("evt_replay", "some.unknown.event", map[string]interface{}{"id": "obj_test"})
+			signature := generateStripeSignature(body, webhookSecret, time.Now().Add(-tt.age).Unix())
+
+			var w *httptest.ResponseRecorder
+			for i := 0; i < tt.deliveries; i++ {
+				req := httptest.NewRequest(http.MethodPost, "/internal/stripe", bytes.NewReader(body))
+				req.Header.Set("Stripe-Signature", signature)
+				w = httptest.NewRecorder()
+				handlers.HandleStripeWebhook(w, req)
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestHandleStripeWebhook_ReplayGuardAllowsRetries tests that a re-signed retry
+// of an already-delivered event passes the guard and is deduplicated instead.
+func TestHandleStripeWebhook_ReplayGuardAllowsRetries(t *testing.T) {
+	webhookSecret := "whsec_test_secret"
+	webhookRepo := payment.NewInMemoryWebhookRepository()
+	handlers := NewWebhookHandlers(webhookSecret, payment.NewInMemoryPaymentRepository(), webhookRepo, scene.NewInMemorySceneRepository())
+	handlers.SetReplayGuard(replay.NewGuard(time.Minute))
+
+	body := createStripeEventJSON("evt_retry", "some.unknown.event", map[string]interface{}{"id": "obj_test"})
+	for _, age := range []time.Duration{30 * time.Second, 0} {
+		req := httptest.NewRequest(http.MethodPost, "/internal/stripe", bytes.NewReader(body))
+		req.Header.Set("Stripe-Signature", generateStripeSignature(body, webhookSecret, time.Now().Add(-age).Unix()))
+		w := httptest.NewRecorder()
+		handlers.HandleStripeWebhook(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("delivery signed %s ago: expected status 200, got %d", age, w.Code)
+		}
+	}
+}
+
 // Helper to create a properly formatted Stripe event JSON for testing
 func createStripeEventJSON(eventID, eventType string, dataObject map[string]interface{}) []byte {
 	event := map[string]interface{}{
@@ -467,6 +467,34 @@ func TestLogAccessFromRequest_WithXRealIPAndPort(t *testing.T) {
 	}
 }
 
+func TestLogAccessFromRequest_UsesRealIP(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	// The peer is not a trusted proxy, so RealIP ignores the spoofed header
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scenes/scene-892", nil)
+	req.Header.Set("X-Forwarded-For", "198.51.100.70")
+	req.RemoteAddr = "203.0.113.9:12345"
+	req = req.WithContext(middleware.SetUserDID(req.Context(), "did:web:test.com:user1001"))
+
+	handler := middleware.RealIP(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := LogAccessFromRequest(r, repo, "scene", "scene-892", "access_precise_location", ""); err != nil {
+			t.Fatalf("LogAccessFromRequest() error = %v", err)
+		}
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	results, err := repo.QueryByEntity("scene", "scene-892", 0)
+	if err != nil {
+		t.Fatalf("QueryByEntity() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", len(results))
+	}
+	if results[0].IPAddress != "203.0.113.9" {
+		t.Errorf("LogAccessFromRequest() IPAddress = %q, want 203.0.113.9 (resolved by RealIP)", results[0].IPAddress)
+	}
+}
+
 func TestLogAccessFromRequest_WithXForwardedForAndPort(t *testing.T) {
 	repo := NewInMemoryRepository()
 
@@ -661,3 +689,132 @@ func TestLogAccessFromRequest_ValidationErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestInMemoryRepository_QueryBySceneActions(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	entries := []LogEntry{
+		{UserDID: "mod1", EntityType: "post", EntityID: "post-1", Action: ActionModerationLabelApply, SceneID: "scene-1", Reason: "spam"},
+		{UserDID: "mod1", EntityType: "post", EntityID: "post-2", Action: ActionModerationRemove, SceneID: "scene-2", Reason: "off-topic"},
+		{UserDID: "owner", EntityType: "scene", EntityID: "scene-1", Action: "scene_update", SceneID: "scene-1"},
+		{UserDID: "mod2", EntityType: "post", EntityID: "post-3", Action: ActionModerationPurge, SceneID: "scene-1", Reason: "raid"},
+		{UserDID: "mod2", EntityType: "post", EntityID: "post-4", Action: ActionModerationRemove, SceneID: "scene-1", Reason: "harassment"},
+	}
+	for _, entry := range entries {
+		if _, err := repo.LogAccess(entry); err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+	}
+
+	results, err := repo.QueryBySceneActions("scene-1", ModerationActions, 0, 0)
+	if err != nil {
+		t.Fatalf("QueryBySceneActions() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("QueryBySceneActions() returned %d logs, want 3", len(results))
+	}
+	// Newest first
+	wantIDs := []string{"post-4", "post-3", "post-1"}
+	for i, log := range results {
+		if log.EntityID != wantIDs[i] {
+			t.Errorf("results[%d].EntityID = %q, want %q", i, log.EntityID, wantIDs[i])
+		}
+	}
+	if results[0].Reason != "harassment" {
+		t.Errorf("Reason = %q, want harassment", results[0].Reason)
+	}
+
+	// Pagination
+	page, err := repo.QueryBySceneActions("scene-1", ModerationActions, 1, 1)
+	if err != nil {
+		t.Fatalf("QueryBySceneActions() error = %v", err)
+	}
+	if len(page) != 1 || page[0].EntityID != "post-3" {
+		t.Errorf("QueryBySceneActions(limit=1, offset=1) = %v, want [post-3]", page)
+	}
+}
+
+func TestLogModerationAction(t *testing.T) {
+	repo := NewInMemoryRepository()
+	ctx := middleware.SetUserDID(context.Background(), "did:plc:mod")
+
+	if err := LogModerationAction(ctx, repo, "post", "post-1", "scene-1", ActionModerationLabelApply, "nsfw"); err != nil {
+		t.Fatalf("LogModerationAction() error = %v", err)
+	}
+
+	results, err := repo.QueryBySceneActions("scene-1", ModerationActions, 0, 0)
+	if err != nil {
+		t.Fatalf("QueryBySceneActions() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(results))
+	}
+	if results[0].UserDID != "did:plc:mod" || results[0].Reason != "nsfw" {
+		t.Errorf("unexpected entry: UserDID=%q Reason=%q", results[0].UserDID, results[0].Reason)
+	}
+	if results[0].ActorType != ActorModerator {
+		t.Errorf("ActorType = %q, want %q", results[0].ActorType, ActorModerator)
+	}
+
+	if err := LogModerationAction(ctx, nil, "post", "post-1", "scene-1", ActionModerationLabelApply, ""); err != ErrNilRepository {
+		t.Errorf("LogModerationAction(nil repo) error = %v, want ErrNilRepository", err)
+	}
+}
+
+func TestLogActorTypes(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	// A user-initiated action is attributed to the user
+	ctx := middleware.SetUserDID(context.Background(), "did:plc:alice")
+	if err := LogAccess(ctx, repo, "scene", "scene-1", "scene_update", ""); err != nil {
+		t.Fatalf("LogAccess() error = %v", err)
+	}
+
+	// A scheduler-driven transition has no user DID
+	if err := LogSystemAction(context.Background(), repo, SystemActorScheduler, "event", "event-1", "event_cancel"); err != nil {
+		t.Fatalf("LogSystemAction() error = %v", err)
+	}
+
+	users, err := repo.QueryByActorType(ActorUser, 0)
+	if err != nil {
+		t.Fatalf("QueryByActorType() error = %v", err)
+	}
+	if len(users) != 1 || users[0].UserDID != "did:plc:alice" || users[0].ActorID != "" {
+		t.Errorf("unexpected user entries: %+v", users)
+	}
+
+	system, err := repo.QueryByActorType(ActorSystem, 0)
+	if err != nil {
+		t.Fatalf("QueryByActorType() error = %v", err)
+	}
+	if len(system) != 1 {
+		t.Fatalf("expected 1 system entry, got %d", len(system))
+	}
+	if system[0].ActorID != SystemActorScheduler || system[0].UserDID != "" || system[0].Action != "event_cancel" {
+		t.Errorf("unexpected system entry: %+v", system[0])
+	}
+
+	if err := LogSystemAction(context.Background(), repo, "", "event", "event-1", "event_cancel"); err != ErrInvalidActorID {
+		t.Errorf("LogSystemAction(empty actor) error = %v, want ErrInvalidActorID", err)
+	}
+}
+
+func TestInMemoryRepository_LogAccess_DefaultActorType(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	withUser, err := repo.LogAccess(LogEntry{UserDID: "did:plc:bob", EntityType: "scene", EntityID: "scene-1", Action: "scene_create"})
+	if err != nil {
+		t.Fatalf("LogAccess() error = %v", err)
+	}
+	if withUser.ActorType != ActorUser {
+		t.Errorf("ActorType with user DID = %q, want %q", withUser.ActorType, ActorUser)
+	}
+
+	withoutUser, err := repo.LogAccess(LogEntry{EntityType: "scene", EntityID: "scene-1", Action: "scene_delete"})
+	if err != nil {
+		t.Fatalf("LogAccess() error = %v", err)
+	}
+	if withoutUser.ActorType != ActorSystem {
+		t.Errorf("ActorType without user DID = %q, want %q", withoutUser.ActorType, ActorSystem)
+	}
+}
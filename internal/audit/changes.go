@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/onnwee/subcults/internal/middleware"
+)
+
+// RedactedValue stands in for both values of a sensitive field's change.
+const RedactedValue = "[redacted]"
+
+// ChangePair holds a field's value before and after an update.
+type ChangePair struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// SensitiveChangeFields lists fields whose values are never written to the audit
+// log. A change to one is still recorded, so reviewers can see that it moved,
+// but both values are replaced with RedactedValue.
+var SensitiveChangeFields = map[string]bool{
+	"precise_point": true, // Exact coordinates are only ever shown with location consent
+}
+
+// Diff compares snapshots of an entity's fields taken before and after an
+// update, keyed by field name, and returns the fields whose values differ.
+// A field missing from one snapshot is treated as nil. Sensitive fields are
+// redacted. Returns nil when nothing changed.
+func Diff(before, after map[string]any) map[string]ChangePair {
+	var changes map[string]ChangePair
+	record := func(field string, old, new any) {
+		if reflect.DeepEqual(old, new) {
+			return
+		}
+		if SensitiveChangeFields[field] {
+			old, new = RedactedValue, RedactedValue
+		}
+		if changes == nil {
+			changes = make(map[string]ChangePair)
+		}
+		changes[field] = ChangePair{Old: old, New: new}
+	}
+
+	for field, old := range before {
+		record(field, old, after[field])
+	}
+	for field, new := range after {
+		if _, ok := before[field]; !ok {
+			record(field, nil, new)
+		}
+	}
+	return changes
+}
+
+// LogUpdateFromRequest records an update to an entity along with the fields it
+// changed, as computed by Diff. Request metadata is captured as in
+// LogAccessFromRequest.
+//
+// Error handling: fail-closed, as with LogAccess.
+func LogUpdateFromRequest(r *http.Request, repo Repository, entityType, entityID, action string, changes map[string]ChangePair) error {
+	if repo == nil {
+		return ErrNilRepository
+	}
+
+	if err := validateLogEntry(entityType, entityID, action, OutcomeSuccess); err != nil {
+		return err
+	}
+
+	entry := LogEntry{
+		UserDID:    middleware.GetUserDID(r.Context()),
+		ActorType:  ActorUser,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Outcome:    OutcomeSuccess,
+		RequestID:  middleware.GetRequestID(r.Context()),
+		IPAddress:  extractIPAddress(r),
+		UserAgent:  r.UserAgent(),
+		Changes:    changes,
+	}
+
+	_, err := repo.LogAccess(entry)
+	return err
+}
+
+// encodeChanges serializes a change set for hashing. Map keys are sorted by
+// encoding/json, so equal change sets always encode identically.
+func encodeChanges(changes map[string]ChangePair) string {
+	if len(changes) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		// Values come from snapshots of plain model fields, so this shouldn't
+		// happen; fmt also sorts map keys, keeping the fallback deterministic.
+		return fmt.Sprint(changes)
+	}
+	return string(data)
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before := map[string]any{
+		"title":         "Old",
+		"tags":          []string{"a", "b"},
+		"description":   "Same",
+		"precise_point": "40.7,-74.0",
+	}
+	after := map[string]any{
+		"title":         "New",
+		"tags":          []string{"a", "b"},
+		"description":   "Same",
+		"precise_point": "40.8,-73.9",
+		"ends_at":       "2026-01-01T00:00:00Z",
+	}
+
+	want := map[string]ChangePair{
+		"title":         {Old: "Old", New: "New"},
+		"precise_point": {Old: RedactedValue, New: RedactedValue},
+		"ends_at":       {Old: nil, New: "2026-01-01T00:00:00Z"},
+	}
+	if got := Diff(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+
+	if got := Diff(before, before); got != nil {
+		t.Errorf("Diff() of identical snapshots = %+v, want nil", got)
+	}
+}
+
+func TestLogUpdateFromRequest_HashCoversChanges(t *testing.T) {
+	repo := NewInMemoryRepository()
+	req := httptest.NewRequest("PATCH", "/events/event-1", nil)
+
+	changes := map[string]ChangePair{"title": {Old: "Old", New: "New"}}
+	if err := LogUpdateFromRequest(req, repo, "event", "event-1", "event_update", changes); err != nil {
+		t.Fatalf("LogUpdateFromRequest() error = %v", err)
+	}
+
+	logs, err := repo.QueryByEntity("event", "event-1", 0)
+	if err != nil {
+		t.Fatalf("QueryByEntity() error = %v", err)
+	}
+	if len(logs) != 1 || !reflect.DeepEqual(logs[0].Changes, changes) {
+		t.Fatalf("logged entries = %+v, want one with changes %+v", logs, changes)
+	}
+
+	if valid, err := repo.VerifyHashChain(); err != nil || !valid {
+		t.Fatalf("VerifyHashChain() = %v, %v; want true", valid, err)
+	}
+
+	// Tampering with a recorded change must break the hash chain
+	repo.logs[logs[0].ID].Changes = map[string]ChangePair{"title": {Old: "Old", New: "Forged"}}
+	if valid, err := repo.VerifyHashChain(); err != nil || valid {
+		t.Errorf("VerifyHashChain() after tampering = %v, %v; want false", valid, err)
+	}
+}
@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Entity history page sizes.
+const (
+	// DefaultEntityQueryLimit is the page size used when EntityQuery.Limit is not positive.
+	DefaultEntityQueryLimit = 50
+	// MaxEntityQueryLimit caps EntityQuery.Limit.
+	MaxEntityQueryLimit = 500
+)
+
+// ErrInvalidEntityCursor is returned when an entity history cursor is malformed
+// or does not point into the queried entity's history.
+var ErrInvalidEntityCursor = errors.New("invalid audit entity cursor")
+
+// EntityQuery selects one page of an entity's audit history.
+type EntityQuery struct {
+	EntityType string   // Type of the entity (required)
+	EntityID   string   // ID of the entity (required)
+	Actions    []string // Only return entries with one of these actions (optional; empty = all)
+	Limit      int      // Page size (<= 0 = DefaultEntityQueryLimit; capped at MaxEntityQueryLimit)
+	Cursor     string   // NextCursor from a previous page (optional)
+}
+
+// EntityHistoryPage is one page of an entity's audit history.
+type EntityHistoryPage struct {
+	Logs       []*AuditLog // Entries, newest first
+	NextCursor string      // Continuation token for the next page; empty on the last page
+}
+
+// entityCursor is the position of the last entry delivered by an entity history page.
+type entityCursor struct {
+	ID string `json:"id"`
+}
+
+// QueryEntityHistory retrieves one page of the audit history for an entity,
+// newest first. Entries are delivered in the order they were recorded, so
+// following NextCursor walks the whole history without duplicates or gaps even
+// while new entries are being logged; entries recorded after the first page
+// was fetched are not included in later pages.
+// Returns ErrInvalidEntityCursor if query.Cursor is malformed.
+func (r *InMemoryRepository) QueryEntityHistory(query EntityQuery) (*EntityHistoryPage, error) {
+	if query.EntityType == "" || query.EntityID == "" {
+		return nil, fmt.Errorf("entity type and ID are required")
+	}
+
+	cursor, err := decodeEntityCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	afterID := ""
+	if cursor != nil {
+		afterID = cursor.ID
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultEntityQueryLimit
+	}
+	if limit > MaxEntityQueryLimit {
+		limit = MaxEntityQueryLimit
+	}
+
+	logs, hasMore, err := r.queryEntity(query.EntityType, query.EntityID, query.Actions, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &EntityHistoryPage{Logs: logs}
+	if hasMore {
+		page.NextCursor = encodeEntityCursor(logs[len(logs)-1].ID)
+	}
+	return page, nil
+}
+
+// queryEntity returns up to limit entries for an entity, newest first, starting
+// after the entry with ID afterID (or from the newest entry when afterID is
+// empty). A non-positive limit returns every matching entry. hasMore reports
+// whether further matching entries exist beyond the returned ones.
+func (r *InMemoryRepository) queryEntity(entityType, entityID string, actions []string, afterID string, limit int) (results []*AuditLog, hasMore bool, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var wanted map[string]bool
+	if len(actions) > 0 {
+		wanted = make(map[string]bool, len(actions))
+		for _, action := range actions {
+			wanted[action] = true
+		}
+	}
+
+	start := len(r.order) - 1
+	if afterID != "" {
+		after, ok := r.logs[afterID]
+		if !ok || after.EntityType != entityType || after.EntityID != entityID {
+			return nil, false, ErrInvalidEntityCursor
+		}
+		for i := start; i >= 0; i-- {
+			if r.order[i] == afterID {
+				start = i - 1
+				break
+			}
+		}
+	}
+
+	// Iterate in reverse order (newest first)
+	for i := start; i >= 0; i-- {
+		log := r.logs[r.order[i]]
+
+		if log.EntityType != entityType || log.EntityID != entityID {
+			continue
+		}
+		if wanted != nil && !wanted[log.Action] {
+			continue
+		}
+		if limit > 0 && len(results) >= limit {
+			return results, true, nil
+		}
+
+		// Create a copy to prevent external modification
+		logCopy := *log
+		results = append(results, &logCopy)
+	}
+
+	return results, false, nil
+}
+
+// encodeEntityCursor encodes an entity history continuation token to a base64 string.
+func encodeEntityCursor(id string) string {
+	data, _ := json.Marshal(entityCursor{ID: id})
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// decodeEntityCursor decodes a base64 entity history continuation token.
+// Returns (nil, nil) for empty input, or ErrInvalidEntityCursor for malformed tokens.
+func decodeEntityCursor(encoded string) (*entityCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	data, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEntityCursor, err)
+	}
+	var cursor entityCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidEntityCursor
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,179 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// logEntityHistory records n entries for scene-1, interleaved with entries for
+// another scene, and returns the scene-1 entry IDs newest first. Every third
+// entry is a "modify_settings" action; the rest are "view_details".
+func logEntityHistory(t *testing.T, repo *InMemoryRepository, n int) []string {
+	t.Helper()
+
+	ids := make([]string, n)
+	for i := 0; i < n; i++ {
+		action := "view_details"
+		if i%3 == 0 {
+			action = "modify_settings"
+		}
+		log, err := repo.LogAccess(LogEntry{
+			UserDID:    fmt.Sprintf("user%d", i),
+			EntityType: "scene",
+			EntityID:   "scene-1",
+			Action:     action,
+		})
+		if err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+		ids[n-1-i] = log.ID
+
+		if _, err := repo.LogAccess(LogEntry{UserDID: "other", EntityType: "scene", EntityID: "scene-2", Action: action}); err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+	}
+	return ids
+}
+
+func TestInMemoryRepository_QueryEntityHistory_Paging(t *testing.T) {
+	repo := NewInMemoryRepository()
+	want := logEntityHistory(t, repo, 25)
+
+	var got []string
+	query := EntityQuery{EntityType: "scene", EntityID: "scene-1", Limit: 10}
+	for pages := 1; ; pages++ {
+		page, err := repo.QueryEntityHistory(query)
+		if err != nil {
+			t.Fatalf("QueryEntityHistory() page %d error = %v", pages, err)
+		}
+		for _, log := range page.Logs {
+			got = append(got, log.ID)
+		}
+		if page.NextCursor == "" {
+			if pages != 3 {
+				t.Errorf("history took %d pages, want 3", pages)
+			}
+			break
+		}
+		if len(page.Logs) != 10 {
+			t.Errorf("page %d returned %d logs, want 10", pages, len(page.Logs))
+		}
+		if pages > 3 {
+			t.Fatal("paging did not terminate")
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("paged %d logs, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("log %d = %s, want %s (history must be newest first without gaps)", i, got[i], want[i])
+		}
+	}
+}
+
+func TestInMemoryRepository_QueryEntityHistory_NewEntriesDuringPaging(t *testing.T) {
+	repo := NewInMemoryRepository()
+	want := logEntityHistory(t, repo, 4)
+
+	first, err := repo.QueryEntityHistory(EntityQuery{EntityType: "scene", EntityID: "scene-1", Limit: 2})
+	if err != nil {
+		t.Fatalf("QueryEntityHistory() error = %v", err)
+	}
+	if _, err := repo.LogAccess(LogEntry{UserDID: "late", EntityType: "scene", EntityID: "scene-1", Action: "view_details"}); err != nil {
+		t.Fatalf("LogAccess() error = %v", err)
+	}
+
+	second, err := repo.QueryEntityHistory(EntityQuery{EntityType: "scene", EntityID: "scene-1", Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("QueryEntityHistory() error = %v", err)
+	}
+	if len(second.Logs) != 2 || second.Logs[0].ID != want[2] || second.Logs[1].ID != want[3] {
+		t.Errorf("second page shifted after a new entry was logged")
+	}
+	if second.NextCursor != "" {
+		t.Errorf("expected no cursor on the last page, got %q", second.NextCursor)
+	}
+}
+
+func TestInMemoryRepository_QueryEntityHistory_DefaultLimit(t *testing.T) {
+	repo := NewInMemoryRepository()
+	logEntityHistory(t, repo, DefaultEntityQueryLimit+5)
+
+	for _, limit := range []int{0, -1} {
+		page, err := repo.QueryEntityHistory(EntityQuery{EntityType: "scene", EntityID: "scene-1", Limit: limit})
+		if err != nil {
+			t.Fatalf("QueryEntityHistory(limit=%d) error = %v", limit, err)
+		}
+		if len(page.Logs) != DefaultEntityQueryLimit {
+			t.Errorf("QueryEntityHistory(limit=%d) returned %d logs, want %d", limit, len(page.Logs), DefaultEntityQueryLimit)
+		}
+		if page.NextCursor == "" {
+			t.Errorf("QueryEntityHistory(limit=%d) should return a cursor for the remaining logs", limit)
+		}
+	}
+}
+
+func TestInMemoryRepository_QueryEntityHistory_ActionFilter(t *testing.T) {
+	repo := NewInMemoryRepository()
+	logEntityHistory(t, repo, 9) // modify_settings at 0, 3 and 6
+
+	page, err := repo.QueryEntityHistory(EntityQuery{
+		EntityType: "scene",
+		EntityID:   "scene-1",
+		Actions:    []string{"modify_settings"},
+		Limit:      2,
+	})
+	if err != nil {
+		t.Fatalf("QueryEntityHistory() error = %v", err)
+	}
+	if len(page.Logs) != 2 || page.Logs[0].UserDID != "user6" || page.Logs[1].UserDID != "user3" {
+		t.Fatalf("first filtered page = %v, want user6 and user3", userDIDs(page.Logs))
+	}
+
+	page, err = repo.QueryEntityHistory(EntityQuery{
+		EntityType: "scene",
+		EntityID:   "scene-1",
+		Actions:    []string{"modify_settings"},
+		Limit:      2,
+		Cursor:     page.NextCursor,
+	})
+	if err != nil {
+		t.Fatalf("QueryEntityHistory() error = %v", err)
+	}
+	if len(page.Logs) != 1 || page.Logs[0].UserDID != "user0" {
+		t.Errorf("second filtered page = %v, want user0", userDIDs(page.Logs))
+	}
+	if page.NextCursor != "" {
+		t.Errorf("expected no cursor on the last page, got %q", page.NextCursor)
+	}
+}
+
+func TestInMemoryRepository_QueryEntityHistory_InvalidCursor(t *testing.T) {
+	repo := NewInMemoryRepository()
+	logEntityHistory(t, repo, 3)
+
+	// A cursor from another entity's history does not point into this one
+	other, err := repo.QueryEntityHistory(EntityQuery{EntityType: "scene", EntityID: "scene-2", Limit: 1})
+	if err != nil {
+		t.Fatalf("QueryEntityHistory() error = %v", err)
+	}
+
+	for _, cursor := range []string{"not-base64!", encodeEntityCursor("missing"), other.NextCursor} {
+		_, err := repo.QueryEntityHistory(EntityQuery{EntityType: "scene", EntityID: "scene-1", Cursor: cursor})
+		if !errors.Is(err, ErrInvalidEntityCursor) {
+			t.Errorf("QueryEntityHistory(cursor=%q) error = %v, want ErrInvalidEntityCursor", cursor, err)
+		}
+	}
+}
+
+func userDIDs(logs []*AuditLog) []string {
+	dids := make([]string, len(logs))
+	for i, log := range logs {
+		dids[i] = log.UserDID
+	}
+	return dids
+}
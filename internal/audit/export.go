@@ -4,9 +4,12 @@ package audit
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -20,37 +23,80 @@ const (
 	ExportFormatJSON ExportFormat = "json"
 )
 
+// ErrInvalidExportCursor is returned when an export continuation token is malformed.
+var ErrInvalidExportCursor = errors.New("invalid audit export cursor")
+
 // ExportOptions configures audit log export parameters.
+// Exactly one of UserDID, EntityType/EntityID, or ActorType selects the entries.
 type ExportOptions struct {
-	Format    ExportFormat // Export format (csv or json)
-	From      time.Time    // Start of time range (inclusive)
-	To        time.Time    // End of time range (inclusive)
-	UserDID   string       // Filter by user DID (optional)
-	Limit     int          // Maximum number of entries to export (0 = no limit)
+	Format     ExportFormat // Export format (csv or json)
+	From       time.Time    // Start of time range (inclusive)
+	To         time.Time    // End of time range (inclusive)
+	UserDID    string       // Filter by user DID (optional)
+	EntityType string       // Filter by entity; requires EntityID (optional)
+	EntityID   string       // Filter by entity; requires EntityType (optional)
+	ActorType  string       // Filter by actor type (optional)
+	Limit      int          // Maximum number of entries to export (0 = no limit)
+	Cursor     string       // Continuation token from a previous ExportPage (optional)
+}
+
+// ExportPage is one page of a resumable audit export.
+type ExportPage struct {
+	Data       []byte // Entries in the requested format
+	Count      int    // Number of entries in Data
+	NextCursor string // Continuation token for the next page; empty when the export is complete
+}
+
+// exportCursor is the position of the last entry delivered by an export page.
+type exportCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
 }
 
 // ExportLogs exports audit logs matching the given options.
 // Returns the exported data as bytes in the specified format.
 func ExportLogs(repo Repository, opts ExportOptions) ([]byte, error) {
+	page, err := ExportLogsPage(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Data, nil
+}
+
+// ExportLogsPage exports one page of audit logs matching the given options,
+// newest first. Entries are ordered by creation time with ID as a tie-breaker,
+// and the returned NextCursor resumes after the last delivered entry, so an
+// interrupted download continues without duplicates or gaps. Entries written
+// after the export began sort ahead of the cursor and are never included in a
+// continuation; start a new export to pick them up.
+// Returns ErrInvalidExportCursor if opts.Cursor is malformed.
+func ExportLogsPage(repo Repository, opts ExportOptions) (*ExportPage, error) {
 	// Validate format
 	if opts.Format != ExportFormatCSV && opts.Format != ExportFormatJSON {
 		return nil, fmt.Errorf("unsupported export format: %s", opts.Format)
 	}
 
+	cursor, err := decodeExportCursor(opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
 	// Query logs based on filters
-	// Note: Query without limit first, then filter by time range, then apply limit
-	// This ensures we get the correct number of results after time filtering
+	// Note: Query without limit first, then filter by time range and cursor, then apply limit
+	// This ensures we get the correct number of results after filtering
 	var logs []*AuditLog
-	var err error
 
-	if opts.UserDID != "" {
-		// Export for specific user - query without limit first
+	switch {
+	case opts.UserDID != "":
 		logs, err = repo.QueryByUser(opts.UserDID, 0)
-	} else {
+	case opts.EntityType != "" && opts.EntityID != "":
+		logs, err = repo.QueryByEntity(opts.EntityType, opts.EntityID, 0)
+	case opts.ActorType != "":
+		logs, err = repo.QueryByActorType(opts.ActorType, 0)
+	default:
 		// Export all logs (would need a new repository method)
-		// For now, we'll use a high limit as a workaround
 		// In production, this should be a proper QueryAll with pagination
-		return nil, fmt.Errorf("export all logs not yet implemented - use UserDID filter")
+		return nil, fmt.Errorf("export all logs not yet implemented - use UserDID, entity, or ActorType filter")
 	}
 
 	if err != nil {
@@ -62,20 +108,82 @@ func ExportLogs(repo Repository, opts ExportOptions) ([]byte, error) {
 		logs = filterByTimeRange(logs, opts.From, opts.To)
 	}
 
-	// Apply limit after time filtering to get correct number of results
+	// Pin a total order so continuation tokens stay valid across requests
+	sort.SliceStable(logs, func(i, j int) bool {
+		return exportsBefore(logs[i], logs[j])
+	})
+
+	if cursor != nil {
+		logs = filterAfterCursor(logs, cursor)
+	}
+
+	// Apply limit after filtering to get correct number of results
+	page := &ExportPage{}
 	if opts.Limit > 0 && len(logs) > opts.Limit {
 		logs = logs[:opts.Limit]
+		last := logs[len(logs)-1]
+		page.NextCursor = encodeExportCursor(last.CreatedAt, last.ID)
 	}
+	page.Count = len(logs)
 
 	// Export in requested format
 	switch opts.Format {
 	case ExportFormatCSV:
-		return exportToCSV(logs)
+		page.Data, err = exportToCSV(logs)
 	case ExportFormatJSON:
-		return exportToJSON(logs)
+		page.Data, err = exportToJSON(logs)
 	default:
 		return nil, fmt.Errorf("unsupported export format: %s", opts.Format)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// exportsBefore reports whether a is delivered before b: newest first, with
+// higher IDs first among entries created at the same instant.
+func exportsBefore(a, b *AuditLog) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+// filterAfterCursor drops entries at or before the cursor position in export order.
+func filterAfterCursor(logs []*AuditLog, cursor *exportCursor) []*AuditLog {
+	position := &AuditLog{CreatedAt: cursor.CreatedAt, ID: cursor.ID}
+	var filtered []*AuditLog
+	for _, log := range logs {
+		if exportsBefore(position, log) {
+			filtered = append(filtered, log)
+		}
+	}
+	return filtered
+}
+
+// encodeExportCursor encodes an export continuation token to a base64 string.
+func encodeExportCursor(createdAt time.Time, id string) string {
+	data, _ := json.Marshal(exportCursor{CreatedAt: createdAt, ID: id})
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// decodeExportCursor decodes a base64 export continuation token.
+// Returns (nil, nil) for empty input, or ErrInvalidExportCursor for malformed tokens.
+func decodeExportCursor(encoded string) (*exportCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	data, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExportCursor, err)
+	}
+	var cursor exportCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidExportCursor
+	}
+	return &cursor, nil
 }
 
 // filterByTimeRange filters logs to only include entries within the time range.
@@ -160,6 +268,7 @@ func exportToJSON(logs []*AuditLog) ([]byte, error) {
 		IPAddress    string    `json:"ip_address,omitempty"`
 		UserAgent    string    `json:"user_agent,omitempty"`
 		PreviousHash string    `json:"previous_hash,omitempty"`
+		Changes      map[string]ChangePair `json:"changes,omitempty"`
 	}
 
 	exportLogs := make([]exportLog, len(logs))
@@ -176,6 +285,7 @@ func exportToJSON(logs []*AuditLog) ([]byte, error) {
 			IPAddress:    log.IPAddress,
 			UserAgent:    log.UserAgent,
 			PreviousHash: log.PreviousHash,
+			Changes:      log.Changes,
 		}
 	}
 
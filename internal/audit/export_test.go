@@ -3,6 +3,7 @@ package audit
 import (
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -317,3 +318,107 @@ func TestExportToCSV_SpecialCharacters(t *testing.T) {
 		t.Errorf("Expected 2 CSV rows, got %d", len(records))
 	}
 }
+
+// exportIDs pages through a JSON export with the given page size, writing
+// between pages via between, and returns the delivered IDs in order.
+func exportIDs(t *testing.T, repo Repository, opts ExportOptions, between func(page int)) []string {
+	t.Helper()
+
+	var ids []string
+	for page := 0; ; page++ {
+		result, err := ExportLogsPage(repo, opts)
+		if err != nil {
+			t.Fatalf("ExportLogsPage() page %d error = %v", page, err)
+		}
+
+		var logs []map[string]interface{}
+		if err := json.Unmarshal(result.Data, &logs); err != nil {
+			t.Fatalf("Failed to parse JSON: %v", err)
+		}
+		if len(logs) != result.Count {
+			t.Errorf("page %d Count = %d, want %d", page, result.Count, len(logs))
+		}
+		for _, log := range logs {
+			ids = append(ids, log["id"].(string))
+		}
+
+		if result.NextCursor == "" {
+			return ids
+		}
+		if page > 100 {
+			t.Fatal("export did not terminate")
+		}
+		if between != nil {
+			between(page)
+		}
+		opts.Cursor = result.NextCursor
+	}
+}
+
+func TestExportLogsPage_ResumeWithoutDuplicatesOrGaps(t *testing.T) {
+	repo := NewInMemoryRepository()
+	for i := 0; i < 10; i++ {
+		if _, err := repo.LogAccess(LogEntry{UserDID: "user1", EntityType: "scene", EntityID: "scene-1", Action: "scene_update"}); err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+	}
+
+	full, err := ExportLogsPage(repo, ExportOptions{Format: ExportFormatJSON, UserDID: "user1"})
+	if err != nil {
+		t.Fatalf("ExportLogsPage() error = %v", err)
+	}
+	if full.NextCursor != "" {
+		t.Errorf("unpaged export NextCursor = %q, want empty", full.NextCursor)
+	}
+	want := exportIDs(t, repo, ExportOptions{Format: ExportFormatJSON, UserDID: "user1"}, nil)
+	if len(want) != 10 {
+		t.Fatalf("unpaged export returned %d entries, want 10", len(want))
+	}
+
+	for _, opts := range []ExportOptions{
+		{Format: ExportFormatJSON, UserDID: "user1", Limit: 3},
+		{Format: ExportFormatJSON, EntityType: "scene", EntityID: "scene-1", Limit: 4},
+		{Format: ExportFormatJSON, ActorType: ActorUser, Limit: 5},
+	} {
+		got := exportIDs(t, repo, opts, nil)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("paged export (limit %d) = %v, want %v", opts.Limit, got, want)
+		}
+	}
+}
+
+func TestExportLogsPage_ConcurrentWritesDoNotCorruptContinuation(t *testing.T) {
+	repo := NewInMemoryRepository()
+	for i := 0; i < 6; i++ {
+		if _, err := repo.LogAccess(LogEntry{UserDID: "user1", EntityType: "scene", EntityID: "scene-1", Action: "scene_update"}); err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+	}
+	want := exportIDs(t, repo, ExportOptions{Format: ExportFormatJSON, UserDID: "user1"}, nil)
+
+	// New entries land ahead of the cursor and must not shift the remaining pages
+	got := exportIDs(t, repo, ExportOptions{Format: ExportFormatJSON, UserDID: "user1", Limit: 2}, func(int) {
+		if _, err := repo.LogAccess(LogEntry{UserDID: "user1", EntityType: "scene", EntityID: "scene-1", Action: "scene_update"}); err != nil {
+			t.Fatalf("LogAccess() error = %v", err)
+		}
+	})
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("export with concurrent writes = %v, want %v", got, want)
+	}
+
+	// A fresh export picks up the new entries
+	if all := exportIDs(t, repo, ExportOptions{Format: ExportFormatJSON, UserDID: "user1"}, nil); len(all) != len(want)+2 {
+		t.Errorf("fresh export returned %d entries, want %d", len(all), len(want)+2)
+	}
+}
+
+func TestExportLogsPage_InvalidCursor(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	for _, cursor := range []string{"not base64!", "e30="} {
+		_, err := ExportLogsPage(repo, ExportOptions{Format: ExportFormatJSON, UserDID: "user1", Cursor: cursor})
+		if !errors.Is(err, ErrInvalidExportCursor) {
+			t.Errorf("ExportLogsPage(cursor %q) error = %v, want %v", cursor, err, ErrInvalidExportCursor)
+		}
+	}
+}
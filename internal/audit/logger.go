@@ -16,6 +16,31 @@ const (
 	OutcomeFailure = "failure"
 )
 
+// Moderation action constants for audit logs.
+const (
+	ActionModerationLabelApply = "moderation_label_apply"
+	ActionModerationPurge      = "moderation_purge"
+	ActionModerationRemove     = "moderation_remove"
+	ActionModerationBlock      = "moderation_block"
+	ActionModerationUnblock    = "moderation_unblock"
+	ActionModerationMute       = "moderation_mute"
+	ActionModerationUnmute     = "moderation_unmute"
+)
+
+// ActionAccountDelete records the permanent deletion of an account after its grace period.
+const ActionAccountDelete = "account_delete"
+
+// ModerationActions lists the actions surfaced in scene moderation logs.
+var ModerationActions = []string{
+	ActionModerationLabelApply,
+	ActionModerationPurge,
+	ActionModerationRemove,
+	ActionModerationBlock,
+	ActionModerationUnblock,
+	ActionModerationMute,
+	ActionModerationUnmute,
+}
+
 var (
 	// ErrNilRepository is returned when a nil repository is passed to logging functions.
 	ErrNilRepository = errors.New("audit repository cannot be nil")
@@ -27,8 +52,19 @@ var (
 	ErrInvalidAction = errors.New("action cannot be empty")
 	// ErrInvalidOutcome is returned when an invalid outcome is provided.
 	ErrInvalidOutcome = errors.New("outcome must be 'success' or 'failure'")
+	// ErrInvalidActorID is returned when a system action is logged without an actor ID.
+	ErrInvalidActorID = errors.New("system actor ID cannot be empty")
 )
 
+// defaultActorType returns the actor type for an entry that doesn't set one:
+// entries with a user DID were taken by that user, anything else by the system.
+func defaultActorType(userDID string) string {
+	if userDID != "" {
+		return ActorUser
+	}
+	return ActorSystem
+}
+
 // ValidEntityTypes defines the allowed entity types for audit logging.
 var ValidEntityTypes = map[string]bool{
 	"scene":       true,
@@ -40,6 +76,7 @@ var ValidEntityTypes = map[string]bool{
 	"payment":     true,
 	"stream":      true,
 	"admin":       true,
+	"rsvp":        true,
 }
 
 // ValidActions defines the allowed actions for audit logging.
@@ -67,6 +104,14 @@ var ValidActions = map[string]bool{
 	"event_update":       true,
 	"event_delete":       true,
 	"event_cancel":       true,
+	"event_approve":      true,
+	"event_reject":       true,
+
+	// RSVP operations
+	"rsvp_promoted": true,
+
+	// Post operations
+	"post_update": true,
 
 	// Membership operations
 	"export_member_data": true,
@@ -89,6 +134,18 @@ var ValidActions = map[string]bool{
 	"participant_mute":  true,
 	"participant_kick":  true,
 	"participant_unmute": true,
+
+	// Account lifecycle
+	ActionAccountDelete: true,
+
+	// Moderation operations
+	ActionModerationLabelApply: true,
+	ActionModerationPurge:      true,
+	ActionModerationRemove:     true,
+	ActionModerationBlock:      true,
+	ActionModerationUnblock:    true,
+	ActionModerationMute:       true,
+	ActionModerationUnmute:     true,
 }
 
 // validateLogEntry validates the required fields of a log entry against whitelists.
@@ -120,9 +177,14 @@ func validateLogEntry(entityType, entityID, action, outcome string) error {
 }
 
 // extractIPAddress extracts the client IP address from an HTTP request.
-// It checks X-Forwarded-For, X-Real-IP, and RemoteAddr in that order.
+// It prefers the address resolved by middleware.RealIP; without it, it checks
+// X-Forwarded-For, X-Real-IP, and RemoteAddr in that order.
 // The port is stripped from the IP address to ensure compatibility with database storage.
 func extractIPAddress(r *http.Request) string {
+	if ip := middleware.GetRealIP(r.Context()); ip != "" {
+		return ip
+	}
+
 	// Check X-Forwarded-For header first (for proxied requests)
 	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
 		// Use the first IP in the chain, trimming whitespace per RFC 7239
@@ -190,6 +252,7 @@ func LogAccess(ctx context.Context, repo Repository, entityType, entityID, actio
 
 	entry := LogEntry{
 		UserDID:    middleware.GetUserDID(ctx),
+		ActorType:  ActorUser,
 		EntityType: entityType,
 		EntityID:   entityID,
 		Action:     action,
@@ -230,6 +293,7 @@ func LogAccessFromRequest(r *http.Request, repo Repository, entityType, entityID
 
 	entry := LogEntry{
 		UserDID:    middleware.GetUserDID(r.Context()),
+		ActorType:  ActorUser,
 		EntityType: entityType,
 		EntityID:   entityID,
 		Action:     action,
@@ -242,3 +306,64 @@ func LogAccessFromRequest(r *http.Request, repo Repository, entityType, entityID
 	_, err := repo.LogAccess(entry)
 	return err
 }
+
+// LogModerationAction records a moderation action taken within a scene.
+// The acting moderator is taken from the request context; sceneID and reason are
+// stored so the entry appears in the scene's moderation log.
+//
+// Error handling: fail-closed, as with LogAccess.
+func LogModerationAction(ctx context.Context, repo Repository, entityType, entityID, sceneID, action, reason string) error {
+	if repo == nil {
+		return ErrNilRepository
+	}
+
+	if err := validateLogEntry(entityType, entityID, action, OutcomeSuccess); err != nil {
+		return err
+	}
+
+	entry := LogEntry{
+		UserDID:    middleware.GetUserDID(ctx),
+		ActorType:  ActorModerator,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Outcome:    OutcomeSuccess,
+		RequestID:  middleware.GetRequestID(ctx),
+		SceneID:    sceneID,
+		Reason:     reason,
+	}
+
+	_, err := repo.LogAccess(entry)
+	return err
+}
+
+// LogSystemAction records an action taken by an automated process rather than a
+// user, such as a scheduled transition or a cascade delete. actorID names the
+// process (see the SystemActor* constants). No user DID is recorded.
+//
+// Error handling: fail-closed, as with LogAccess.
+func LogSystemAction(ctx context.Context, repo Repository, actorID, entityType, entityID, action string) error {
+	if repo == nil {
+		return ErrNilRepository
+	}
+	if actorID == "" {
+		return ErrInvalidActorID
+	}
+
+	if err := validateLogEntry(entityType, entityID, action, OutcomeSuccess); err != nil {
+		return err
+	}
+
+	entry := LogEntry{
+		ActorType:  ActorSystem,
+		ActorID:    actorID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Outcome:    OutcomeSuccess,
+		RequestID:  middleware.GetRequestID(ctx),
+	}
+
+	_, err := repo.LogAccess(entry)
+	return err
+}
@@ -6,10 +6,28 @@ import (
 	"time"
 )
 
+// Actor types distinguish who performed an audited action.
+const (
+	ActorUser      = "user"      // An authenticated user acting on their own behalf
+	ActorModerator = "moderator" // A scene moderator acting on another user's content
+	ActorAdmin     = "admin"     // A platform administrator
+	ActorSystem    = "system"    // An automated process with no human initiator
+)
+
+// System actor identifiers, recorded as ActorID on entries with ActorType ActorSystem.
+const (
+	SystemActorRetention  = "retention"  // Scheduled data retention enforcement
+	SystemActorScheduler  = "scheduler"  // Time-driven state transitions
+	SystemActorReconciler = "reconciler" // Background reconciliation of external state
+	SystemActorWaitlist   = "waitlist"   // Automatic promotion of waitlisted RSVPs
+)
+
 // AuditLog represents a single audit event in the system.
 type AuditLog struct {
 	ID         string
 	UserDID    string
+	ActorType  string // One of the Actor* constants
+	ActorID    string // System actor identifier; empty for human actors
 	EntityType string
 	EntityID   string
 	Action     string
@@ -21,6 +39,13 @@ type AuditLog struct {
 	IPAddress string
 	UserAgent string
 
+	// Moderation context
+	SceneID string // Scene the action was taken in, for scene-scoped moderation views
+	Reason  string // Moderator-supplied reason for the action
+
+	// Changes holds the fields modified by an update action; nil for other actions.
+	Changes map[string]ChangePair
+
 	// Tamper detection
 	PreviousHash string // SHA-256 hash of previous log entry for tamper detection
 }
@@ -28,6 +53,8 @@ type AuditLog struct {
 // LogEntry represents the input for creating an audit log entry.
 type LogEntry struct {
 	UserDID    string
+	ActorType  string // Defaults to ActorUser when UserDID is set, ActorSystem otherwise
+	ActorID    string
 	EntityType string
 	EntityID   string
 	Action     string
@@ -37,4 +64,11 @@ type LogEntry struct {
 	RequestID string
 	IPAddress string
 	UserAgent string
+
+	// Moderation context
+	SceneID string
+	Reason  string
+
+	// Fields modified by an update action, from Diff
+	Changes map[string]ChangePair
 }
@@ -18,12 +18,27 @@ type Repository interface {
 
 	// QueryByEntity retrieves audit logs for a specific entity, sorted by time (newest first).
 	// Limit specifies the maximum number of entries to return (0 = no limit).
+	// Kept for existing callers; prefer QueryEntityHistory, which pages long histories.
 	QueryByEntity(entityType, entityID string, limit int) ([]*AuditLog, error)
 
+	// QueryEntityHistory retrieves one page of an entity's audit history, newest first,
+	// optionally filtered by action. See EntityQuery for paging and limit semantics.
+	QueryEntityHistory(query EntityQuery) (*EntityHistoryPage, error)
+
 	// QueryByUser retrieves audit logs for a specific user, sorted by time (newest first).
 	// Limit specifies the maximum number of entries to return (0 = no limit).
 	QueryByUser(userDID string, limit int) ([]*AuditLog, error)
 
+	// QueryByActorType retrieves audit logs recorded by actors of the given type,
+	// sorted by time (newest first). Limit specifies the maximum number of entries
+	// to return (0 = no limit).
+	QueryByActorType(actorType string, limit int) ([]*AuditLog, error)
+
+	// QueryBySceneActions retrieves audit logs recorded against a scene whose action is one
+	// of actions, sorted by time (newest first). Offset skips that many matching entries;
+	// limit specifies the maximum number of entries to return (0 = no limit).
+	QueryBySceneActions(sceneID string, actions []string, limit, offset int) ([]*AuditLog, error)
+
 	// GetLastHash returns the hash of the most recent audit log entry.
 	// Returns empty string if no logs exist.
 	GetLastHash() (string, error)
@@ -56,9 +71,11 @@ func NewInMemoryRepository() *InMemoryRepository {
 // will invalidate all subsequent hashes.
 func computeHash(log *AuditLog) string {
 	// Concatenate all fields to create a string representation
-	data := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s|%s|%s|%s",
+	data := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s|%s|%s|%s|%s|%s|%s|%s",
 		log.ID,
 		log.UserDID,
+		log.ActorType,
+		log.ActorID,
 		log.EntityType,
 		log.EntityID,
 		log.Action,
@@ -67,9 +84,16 @@ func computeHash(log *AuditLog) string {
 		log.RequestID,
 		log.IPAddress,
 		log.UserAgent,
+		log.SceneID,
+		log.Reason,
 		log.PreviousHash,
 	)
 
+	// Appended only when present so hashes of entries without changes are unaffected
+	if changes := encodeChanges(log.Changes); changes != "" {
+		data += "|" + changes
+	}
+
 	hash := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(hash[:])
 }
@@ -85,9 +109,16 @@ func (r *InMemoryRepository) LogAccess(entry LogEntry) (*AuditLog, error) {
 		outcome = OutcomeSuccess
 	}
 
+	actorType := entry.ActorType
+	if actorType == "" {
+		actorType = defaultActorType(entry.UserDID)
+	}
+
 	log := &AuditLog{
 		ID:           uuid.New().String(),
 		UserDID:      entry.UserDID,
+		ActorType:    actorType,
+		ActorID:      entry.ActorID,
 		EntityType:   entry.EntityType,
 		EntityID:     entry.EntityID,
 		Action:       entry.Action,
@@ -96,6 +127,9 @@ func (r *InMemoryRepository) LogAccess(entry LogEntry) (*AuditLog, error) {
 		RequestID:    entry.RequestID,
 		IPAddress:    entry.IPAddress,
 		UserAgent:    entry.UserAgent,
+		SceneID:      entry.SceneID,
+		Reason:       entry.Reason,
+		Changes:      entry.Changes,
 		PreviousHash: r.lastHash, // Link to previous log entry
 	}
 
@@ -113,7 +147,14 @@ func (r *InMemoryRepository) LogAccess(entry LogEntry) (*AuditLog, error) {
 }
 
 // QueryByEntity retrieves audit logs for a specific entity, sorted by time (newest first).
+// A non-positive limit returns the entity's whole history.
 func (r *InMemoryRepository) QueryByEntity(entityType, entityID string, limit int) ([]*AuditLog, error) {
+	results, _, err := r.queryEntity(entityType, entityID, nil, "", limit)
+	return results, err
+}
+
+// QueryByUser retrieves audit logs for a specific user, sorted by time (newest first).
+func (r *InMemoryRepository) QueryByUser(userDID string, limit int) ([]*AuditLog, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -124,7 +165,7 @@ func (r *InMemoryRepository) QueryByEntity(entityType, entityID string, limit in
 		id := r.order[i]
 		log := r.logs[id]
 
-		if log.EntityType == entityType && log.EntityID == entityID {
+		if log.UserDID == userDID {
 			// Create a copy to prevent external modification
 			logCopy := *log
 			results = append(results, &logCopy)
@@ -138,8 +179,9 @@ func (r *InMemoryRepository) QueryByEntity(entityType, entityID string, limit in
 	return results, nil
 }
 
-// QueryByUser retrieves audit logs for a specific user, sorted by time (newest first).
-func (r *InMemoryRepository) QueryByUser(userDID string, limit int) ([]*AuditLog, error) {
+// QueryByActorType retrieves audit logs recorded by actors of the given type,
+// sorted by time (newest first).
+func (r *InMemoryRepository) QueryByActorType(actorType string, limit int) ([]*AuditLog, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -147,10 +189,9 @@ func (r *InMemoryRepository) QueryByUser(userDID string, limit int) ([]*AuditLog
 
 	// Iterate in reverse order (newest first)
 	for i := len(r.order) - 1; i >= 0; i-- {
-		id := r.order[i]
-		log := r.logs[id]
+		log := r.logs[r.order[i]]
 
-		if log.UserDID == userDID {
+		if log.ActorType == actorType {
 			// Create a copy to prevent external modification
 			logCopy := *log
 			results = append(results, &logCopy)
@@ -164,6 +205,45 @@ func (r *InMemoryRepository) QueryByUser(userDID string, limit int) ([]*AuditLog
 	return results, nil
 }
 
+// QueryBySceneActions retrieves audit logs for a scene matching any of the given actions,
+// sorted by time (newest first), skipping the first offset matches.
+func (r *InMemoryRepository) QueryBySceneActions(sceneID string, actions []string, limit, offset int) ([]*AuditLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(actions))
+	for _, action := range actions {
+		wanted[action] = true
+	}
+
+	var results []*AuditLog
+	skipped := 0
+
+	// Iterate in reverse order (newest first)
+	for i := len(r.order) - 1; i >= 0; i-- {
+		id := r.order[i]
+		log := r.logs[id]
+
+		if log.SceneID != sceneID || !wanted[log.Action] {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+
+		// Create a copy to prevent external modification
+		logCopy := *log
+		results = append(results, &logCopy)
+
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+	}
+
+	return results, nil
+}
+
 // GetLastHash returns the hash of the most recent audit log entry.
 // Returns empty string if no logs exist.
 func (r *InMemoryRepository) GetLastHash() (string, error) {
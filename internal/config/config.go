@@ -6,13 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/netip"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
+
+	"github.com/onnwee/subcults/internal/identity"
 )
 
 // Config holds all configuration values for the API server.
@@ -30,9 +34,11 @@ type Config struct {
 	JWTSecretPrevious string `koanf:"jwt_secret_previous"` // Previous key for rotation window
 
 	// LiveKit (WebRTC)
-	LiveKitURL       string `koanf:"livekit_url"`
-	LiveKitAPIKey    string `koanf:"livekit_api_key"`
-	LiveKitAPISecret string `koanf:"livekit_api_secret"`
+	LiveKitURL        string `koanf:"livekit_url"`
+	LiveKitAPIKey     string `koanf:"livekit_api_key"`
+	LiveKitAPISecret  string `koanf:"livekit_api_secret"`
+	LiveKitRegions    string `koanf:"livekit_regions"`     // Comma-separated regions a stream may request; empty disables region selection
+	LiveKitHomeRegion string `koanf:"livekit_home_region"` // Region used when a stream does not request one; must be listed in LiveKitRegions
 
 	// Stripe
 	StripeAPIKey                string  `koanf:"stripe_api_key"`
@@ -66,6 +72,36 @@ type Config struct {
 	// Feature Flags
 	RankTrustEnabled bool `koanf:"rank_trust_enabled"` // Enable trust-weighted ranking in search/feed
 
+	// Scheduling
+	MaxScheduleHorizonDays int `koanf:"max_schedule_horizon_days"` // How far ahead content may be scheduled. Default: 365 days
+	EventEditGraceMinutes  int `koanf:"event_edit_grace_minutes"`  // How long after start event details stay editable; 0 locks at start. Default: 30 minutes
+
+	// Content limits
+	EventMaxTags              int    `koanf:"event_max_tags"`                // Maximum tags per event after deduplication. Default: 10
+	DescriptionMaxLength      int    `koanf:"description_max_length"`        // Maximum scene and event description length in characters. Default: 5000
+	PostFeedDefaultWindowDays int    `koanf:"post_feed_default_window_days"` // How many days back feeds reach when a request sets no since/until; 0 is unbounded. Default: 0
+	ContentClassifierURL      string `koanf:"content_classifier_url"`        // Image moderation service that auto-labels mature post images as nsfw; empty disables
+
+	// Caching
+	OwnershipCacheTTLSeconds int `koanf:"ownership_cache_ttl_seconds"` // TTL for cached scene ownership checks; 0 disables. Default: 30 seconds
+
+	// Streaming
+	StreamJoinDebounceSeconds   int    `koanf:"stream_join_debounce_seconds"`   // Window in which a leave and re-join count as one session; 0 disables. Default: 5 seconds
+	StreamMaxActivePerHost      int    `koanf:"stream_max_active_per_host"`     // Streams a host may have active at once; 0 disables the limit. Default: 3
+	StreamHostLimitExemptDIDs   string `koanf:"stream_host_limit_exempt_dids"`  // Comma-separated DIDs exempt from StreamMaxActivePerHost
+	StreamMaxSubscribers        int    `koanf:"stream_max_subscribers"`         // Real-time event subscribers allowed per stream; 0 disables the limit. Default: 500
+	StreamEventSweepSeconds     int    `koanf:"stream_event_sweep_seconds"`     // How often the event subscriber registry is swept; 0 disables the sweep. Default: 60 seconds
+	StreamEndedRetentionSeconds int    `koanf:"stream_ended_retention_seconds"` // How long subscribers of an ended stream stay connected; 0 keeps them until they disconnect. Default: 300 seconds
+
+	// Webhooks
+	WebhookReplayWindowSeconds int `koanf:"webhook_replay_window_seconds"` // How far a webhook's signed timestamp may drift before it is rejected as stale. Default: 300 seconds
+
+	// Scene Creation Gate
+	SceneCreationGateEnabled        bool   `koanf:"scene_creation_gate_enabled"`          // Require a resolvable, sufficiently old DID to create scenes; disable for development
+	SceneCreationMinAccountAgeHours int    `koanf:"scene_creation_min_account_age_hours"` // Minimum DID age to create scenes. Default: 24 hours
+	SceneCreationAllowlist          string `koanf:"scene_creation_allowlist"`             // Comma-separated DIDs exempt from the gate
+	PLCDirectoryURL                 string `koanf:"plc_directory_url"`                    // did:plc directory used to resolve account age and handles
+
 	// Canary Deployment
 	CanaryEnabled          bool    `koanf:"canary_enabled"`           // Enable canary deployment
 	CanaryTrafficPercent   float64 `koanf:"canary_traffic_percent"`   // Percentage of traffic to route to canary (0-100)
@@ -91,6 +127,12 @@ type Config struct {
 	CORSAllowedHeaders   string `koanf:"cors_allowed_headers"`   // Comma-separated list of allowed headers
 	CORSAllowCredentials bool   `koanf:"cors_allow_credentials"` // Allow credentials (cookies, auth headers)
 	CORSMaxAge           int    `koanf:"cors_max_age"`           // Preflight cache duration in seconds
+
+	// Client IP resolution
+	TrustedProxies string `koanf:"trusted_proxies"` // Comma-separated CIDRs whose X-Forwarded-For/X-Real-IP headers are honored
+
+	// Administration
+	AdminDIDs string `koanf:"admin_dids"` // Comma-separated DIDs allowed to call /admin endpoints
 }
 
 // Configuration validation errors.
@@ -112,6 +154,8 @@ var (
 	ErrMissingR2Endpoint                 = errors.New("R2_ENDPOINT is required")
 	ErrInvalidPort                       = errors.New("PORT must be a valid integer")
 	ErrJWTSecretTooShort                 = errors.New("JWT secret must be at least 32 bytes")
+	ErrLiveKitHomeRegionNotListed        = errors.New("LIVEKIT_HOME_REGION must be one of LIVEKIT_REGIONS")
+	ErrInvalidTrustedProxies             = errors.New("TRUSTED_PROXIES must be a comma-separated list of CIDRs or IP addresses")
 )
 
 // Default values for non-secret configuration.
@@ -120,6 +164,20 @@ const (
 	DefaultEnv                         = "development"
 	DefaultR2MaxUploadSizeMB           = 15
 	DefaultRankTrustEnabled            = false
+	DefaultMaxScheduleHorizonDays      = 365  // Scheduled content may start at most one year out
+	DefaultEventEditGraceMinutes       = 30   // Long enough to fix a typo spotted once the event is underway
+	DefaultEventMaxTags                = 10   // Enough to describe genre and vibe without bloating storage and ranking
+	DefaultDescriptionMaxLength        = 5000 // Matches the post text limit
+	DefaultPostFeedDefaultWindowDays   = 0    // Feeds list all posts unless a deployment opts in
+	DefaultOwnershipCacheTTLSeconds    = 30   // Ownership changes propagate within 30s on other instances
+	DefaultStreamJoinDebounceSeconds   = 5    // Long enough to ride out a network blip or page reload
+	DefaultStreamMaxActivePerHost      = 3    // Room for a main stage plus side rooms without letting one host hog LiveKit
+	DefaultStreamMaxSubscribers        = 500  // Well above a typical room while bounding fan-out from one viral stream
+	DefaultStreamEventSweepSeconds     = 60   // Frequent enough to bound stale registry entries, cheap enough to ignore
+	DefaultStreamEndedRetentionSeconds = 300  // Lets clients show the final roster before they are disconnected
+	DefaultWebhookReplayWindowSeconds  = 300  // Matches Stripe's default signature tolerance
+	DefaultSceneCreationGateEnabled    = true
+	DefaultSceneCreationMinAgeHours    = 24  // New accounts wait a day before creating scenes
 	DefaultStripeApplicationFeePercent = 5.0 // 5% platform fee by default
 	DefaultCanaryEnabled               = false
 	DefaultCanaryTrafficPercent        = 5.0  // Start with 5% canary traffic
@@ -130,14 +188,15 @@ const (
 	DefaultCanaryVersion               = "canary"
 	DefaultTracingEnabled              = false
 	DefaultTracingExporterType         = "otlp-http"
-	DefaultTracingSampleRate           = 0.1   // 10% sampling in production
+	DefaultTracingSampleRate           = 0.1 // 10% sampling in production
 	DefaultTracingInsecure             = false
-	DefaultProfilingEnabled            = false // NEVER enable in production (security risk)
-	DefaultCORSAllowedOrigins          = ""    // Empty means CORS is disabled
-	DefaultCORSAllowedMethods          = "GET,POST,PUT,PATCH,DELETE,OPTIONS"                // Standard REST methods
-	DefaultCORSAllowedHeaders          = "Content-Type,Authorization,X-Request-ID"          // Essential headers
-	DefaultCORSAllowCredentials        = true                                                // Allow cookies/auth by default
-	DefaultCORSMaxAge                  = 3600                                                // 1 hour preflight cache
+	DefaultProfilingEnabled            = false                                                                  // NEVER enable in production (security risk)
+	DefaultCORSAllowedOrigins          = ""                                                                     // Empty means CORS is disabled
+	DefaultCORSAllowedMethods          = "GET,POST,PUT,PATCH,DELETE,OPTIONS"                                    // Standard REST methods
+	DefaultCORSAllowedHeaders          = "Content-Type,Authorization,X-Request-ID"                              // Essential headers
+	DefaultCORSAllowCredentials        = true                                                                   // Allow cookies/auth by default
+	DefaultCORSMaxAge                  = 3600                                                                   // 1 hour preflight cache
+	DefaultTrustedProxies              = "127.0.0.0/8,::1/128,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,fc00::/7" // Loopback and private networks
 )
 
 // Load reads configuration from environment variables and an optional config file.
@@ -168,6 +227,118 @@ func Load(configFilePath string) (*Config, []error) {
 		loadErrs = append(loadErrs, uploadSizeErr)
 	}
 
+	// Parse scheduling horizon from env with default
+	maxScheduleHorizonDays, horizonErr := getEnvIntOrDefault("MAX_SCHEDULE_HORIZON_DAYS", k.Int("max_schedule_horizon_days"), DefaultMaxScheduleHorizonDays)
+	if horizonErr != nil {
+		loadErrs = append(loadErrs, horizonErr)
+	} else if maxScheduleHorizonDays < 1 {
+		loadErrs = append(loadErrs, fmt.Errorf("MAX_SCHEDULE_HORIZON_DAYS must be at least 1, got %d", maxScheduleHorizonDays))
+	}
+
+	// Parse event edit grace period from env with default
+	eventEditGrace, eventEditGraceErr := getEnvIntOrDefault("EVENT_EDIT_GRACE_MINUTES", k.Int("event_edit_grace_minutes"), DefaultEventEditGraceMinutes)
+	if eventEditGraceErr != nil {
+		loadErrs = append(loadErrs, eventEditGraceErr)
+	} else if eventEditGrace < 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("EVENT_EDIT_GRACE_MINUTES must not be negative, got %d", eventEditGrace))
+	}
+
+	// Parse event tag cap from env with default
+	eventMaxTags, eventMaxTagsErr := getEnvIntOrDefault("EVENT_MAX_TAGS", k.Int("event_max_tags"), DefaultEventMaxTags)
+	if eventMaxTagsErr != nil {
+		loadErrs = append(loadErrs, eventMaxTagsErr)
+	} else if eventMaxTags < 1 {
+		loadErrs = append(loadErrs, fmt.Errorf("EVENT_MAX_TAGS must be at least 1, got %d", eventMaxTags))
+	}
+
+	// Parse description length cap from env with default
+	descriptionMaxLength, descriptionMaxLengthErr := getEnvIntOrDefault("DESCRIPTION_MAX_LENGTH", k.Int("description_max_length"), DefaultDescriptionMaxLength)
+	if descriptionMaxLengthErr != nil {
+		loadErrs = append(loadErrs, descriptionMaxLengthErr)
+	} else if descriptionMaxLength < 1 {
+		loadErrs = append(loadErrs, fmt.Errorf("DESCRIPTION_MAX_LENGTH must be at least 1, got %d", descriptionMaxLength))
+	}
+
+	// Parse default post feed window from env with default
+	postFeedWindowDays, postFeedWindowErr := getEnvIntOrDefault("POST_FEED_DEFAULT_WINDOW_DAYS", k.Int("post_feed_default_window_days"), DefaultPostFeedDefaultWindowDays)
+	if postFeedWindowErr != nil {
+		loadErrs = append(loadErrs, postFeedWindowErr)
+	} else if postFeedWindowDays < 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("POST_FEED_DEFAULT_WINDOW_DAYS must be 0 or greater, got %d", postFeedWindowDays))
+	}
+
+	// Parse ownership cache TTL from env with default
+	ownershipCacheTTL, ownershipTTLErr := getEnvIntOrDefault("OWNERSHIP_CACHE_TTL_SECONDS", k.Int("ownership_cache_ttl_seconds"), DefaultOwnershipCacheTTLSeconds)
+	if ownershipTTLErr != nil {
+		loadErrs = append(loadErrs, ownershipTTLErr)
+	} else if ownershipCacheTTL < 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("OWNERSHIP_CACHE_TTL_SECONDS must not be negative, got %d", ownershipCacheTTL))
+	}
+
+	streamJoinDebounce, streamJoinDebounceErr := getEnvIntOrDefault("STREAM_JOIN_DEBOUNCE_SECONDS", k.Int("stream_join_debounce_seconds"), DefaultStreamJoinDebounceSeconds)
+	if streamJoinDebounceErr != nil {
+		loadErrs = append(loadErrs, streamJoinDebounceErr)
+	} else if streamJoinDebounce < 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("STREAM_JOIN_DEBOUNCE_SECONDS must not be negative, got %d", streamJoinDebounce))
+	}
+
+	streamMaxActivePerHost, streamMaxActivePerHostErr := getEnvIntOrDefault("STREAM_MAX_ACTIVE_PER_HOST", k.Int("stream_max_active_per_host"), DefaultStreamMaxActivePerHost)
+	if streamMaxActivePerHostErr != nil {
+		loadErrs = append(loadErrs, streamMaxActivePerHostErr)
+	} else if streamMaxActivePerHost < 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("STREAM_MAX_ACTIVE_PER_HOST must not be negative, got %d", streamMaxActivePerHost))
+	}
+
+	streamMaxSubscribers, streamMaxSubscribersErr := getEnvIntOrDefault("STREAM_MAX_SUBSCRIBERS", k.Int("stream_max_subscribers"), DefaultStreamMaxSubscribers)
+	if streamMaxSubscribersErr != nil {
+		loadErrs = append(loadErrs, streamMaxSubscribersErr)
+	} else if streamMaxSubscribers < 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("STREAM_MAX_SUBSCRIBERS must not be negative, got %d", streamMaxSubscribers))
+	}
+
+	streamEventSweep, streamEventSweepErr := getEnvIntOrDefault("STREAM_EVENT_SWEEP_SECONDS", k.Int("stream_event_sweep_seconds"), DefaultStreamEventSweepSeconds)
+	if streamEventSweepErr != nil {
+		loadErrs = append(loadErrs, streamEventSweepErr)
+	} else if streamEventSweep < 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("STREAM_EVENT_SWEEP_SECONDS must not be negative, got %d", streamEventSweep))
+	}
+
+	streamEndedRetention, streamEndedRetentionErr := getEnvIntOrDefault("STREAM_ENDED_RETENTION_SECONDS", k.Int("stream_ended_retention_seconds"), DefaultStreamEndedRetentionSeconds)
+	if streamEndedRetentionErr != nil {
+		loadErrs = append(loadErrs, streamEndedRetentionErr)
+	} else if streamEndedRetention < 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("STREAM_ENDED_RETENTION_SECONDS must not be negative, got %d", streamEndedRetention))
+	}
+
+	// Parse webhook replay window from env with default
+	webhookReplayWindow, webhookReplayWindowErr := getEnvIntOrDefault("WEBHOOK_REPLAY_WINDOW_SECONDS", k.Int("webhook_replay_window_seconds"), DefaultWebhookReplayWindowSeconds)
+	if webhookReplayWindowErr != nil {
+		loadErrs = append(loadErrs, webhookReplayWindowErr)
+	} else if webhookReplayWindow <= 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("WEBHOOK_REPLAY_WINDOW_SECONDS must be positive, got %d", webhookReplayWindow))
+	}
+
+	// Parse scene creation gate settings from env with defaults
+	sceneCreationGateEnabled := DefaultSceneCreationGateEnabled
+	if k.Exists("scene_creation_gate_enabled") {
+		sceneCreationGateEnabled = k.Bool("scene_creation_gate_enabled")
+	}
+	if val := os.Getenv("SCENE_CREATION_GATE_ENABLED"); val != "" {
+		// Env var takes precedence over file config
+		switch strings.ToLower(val) {
+		case "true", "1", "yes", "on":
+			sceneCreationGateEnabled = true
+		case "false", "0", "no", "off":
+			sceneCreationGateEnabled = false
+		}
+	}
+	sceneCreationMinAge, sceneCreationMinAgeErr := getEnvIntOrDefault("SCENE_CREATION_MIN_ACCOUNT_AGE_HOURS", k.Int("scene_creation_min_account_age_hours"), DefaultSceneCreationMinAgeHours)
+	if sceneCreationMinAgeErr != nil {
+		loadErrs = append(loadErrs, sceneCreationMinAgeErr)
+	} else if sceneCreationMinAge < 0 {
+		loadErrs = append(loadErrs, fmt.Errorf("SCENE_CREATION_MIN_ACCOUNT_AGE_HOURS must not be negative, got %d", sceneCreationMinAge))
+	}
+
 	// Parse trust ranking feature flag from env with default
 	rankTrustEnabled := DefaultRankTrustEnabled
 	if k.Exists("rank_trust_enabled") {
@@ -367,48 +538,70 @@ func Load(configFilePath string) (*Config, []error) {
 
 	// Build config struct, with env vars taking precedence over file values
 	cfg := &Config{
-		Port:                        port,
-		Env:                         getEnvOrDefaultMulti([]string{"SUBCULT_ENV", "ENV", "GO_ENV"}, k.String("env"), DefaultEnv),
-		DatabaseURL:                 getEnvOrKoanf("DATABASE_URL", k, "database_url"),
-		JWTSecret:                   getEnvOrKoanf("JWT_SECRET", k, "jwt_secret"),
-		JWTSecretCurrent:            getEnvOrKoanf("JWT_SECRET_CURRENT", k, "jwt_secret_current"),
-		JWTSecretPrevious:           getEnvOrKoanf("JWT_SECRET_PREVIOUS", k, "jwt_secret_previous"),
-		LiveKitURL:                  getEnvOrKoanf("LIVEKIT_URL", k, "livekit_url"),
-		LiveKitAPIKey:               getEnvOrKoanf("LIVEKIT_API_KEY", k, "livekit_api_key"),
-		LiveKitAPISecret:            getEnvOrKoanf("LIVEKIT_API_SECRET", k, "livekit_api_secret"),
-		StripeAPIKey:                getEnvOrKoanf("STRIPE_API_KEY", k, "stripe_api_key"),
-		StripeWebhookSecret:         getEnvOrKoanf("STRIPE_WEBHOOK_SECRET", k, "stripe_webhook_secret"),
-		StripeOnboardingReturnURL:   getEnvOrKoanf("STRIPE_ONBOARDING_RETURN_URL", k, "stripe_onboarding_return_url"),
-		StripeOnboardingRefreshURL:  getEnvOrKoanf("STRIPE_ONBOARDING_REFRESH_URL", k, "stripe_onboarding_refresh_url"),
-		StripeApplicationFeePercent: stripeFeePercent,
-		MapTilerAPIKey:              getEnvOrKoanf("MAPTILER_API_KEY", k, "maptiler_api_key"),
-		JetstreamURL:                getEnvOrKoanf("JETSTREAM_URL", k, "jetstream_url"),
-		R2BucketName:                getEnvOrKoanf("R2_BUCKET_NAME", k, "r2_bucket_name"),
-		R2AccessKeyID:               getEnvOrKoanf("R2_ACCESS_KEY_ID", k, "r2_access_key_id"),
-		R2SecretAccessKey:           getEnvOrKoanf("R2_SECRET_ACCESS_KEY", k, "r2_secret_access_key"),
-		R2Endpoint:                  getEnvOrKoanf("R2_ENDPOINT", k, "r2_endpoint"),
-		R2MaxUploadSizeMB:           maxUploadSize,
-		RedisURL:                    getEnvOrKoanf("REDIS_URL", k, "redis_url"),
-		InternalServiceToken:        getEnvOrKoanf("INTERNAL_SERVICE_TOKEN", k, "internal_service_token"),
-		RankTrustEnabled:            rankTrustEnabled,
-		CanaryEnabled:               canaryEnabled,
-		CanaryTrafficPercent:        canaryTrafficPercent,
-		CanaryErrorThreshold:        canaryErrorThreshold,
-		CanaryLatencyThreshold:      canaryLatencyThreshold,
-		CanaryAutoRollback:          canaryAutoRollback,
-		CanaryMonitoringWindow:      canaryMonitoringWindow,
-		CanaryVersion:               canaryVersion,
-		TracingEnabled:              tracingEnabled,
-		TracingExporterType:         getEnvOrDefault("TRACING_EXPORTER_TYPE", k.String("tracing_exporter_type"), DefaultTracingExporterType),
-		TracingOTLPEndpoint:         getEnvOrKoanf("TRACING_OTLP_ENDPOINT", k, "tracing_otlp_endpoint"),
-		TracingSampleRate:           tracingSampleRate,
-		TracingInsecure:             tracingInsecure,
-		ProfilingEnabled:            profilingEnabled,
-		CORSAllowedOrigins:          corsAllowedOrigins,
-		CORSAllowedMethods:          corsAllowedMethods,
-		CORSAllowedHeaders:          corsAllowedHeaders,
-		CORSAllowCredentials:        corsAllowCredentials,
-		CORSMaxAge:                  corsMaxAge,
+		Port:                            port,
+		Env:                             getEnvOrDefaultMulti([]string{"SUBCULT_ENV", "ENV", "GO_ENV"}, k.String("env"), DefaultEnv),
+		DatabaseURL:                     getEnvOrKoanf("DATABASE_URL", k, "database_url"),
+		JWTSecret:                       getEnvOrKoanf("JWT_SECRET", k, "jwt_secret"),
+		JWTSecretCurrent:                getEnvOrKoanf("JWT_SECRET_CURRENT", k, "jwt_secret_current"),
+		JWTSecretPrevious:               getEnvOrKoanf("JWT_SECRET_PREVIOUS", k, "jwt_secret_previous"),
+		LiveKitURL:                      getEnvOrKoanf("LIVEKIT_URL", k, "livekit_url"),
+		LiveKitAPIKey:                   getEnvOrKoanf("LIVEKIT_API_KEY", k, "livekit_api_key"),
+		LiveKitAPISecret:                getEnvOrKoanf("LIVEKIT_API_SECRET", k, "livekit_api_secret"),
+		LiveKitRegions:                  getEnvOrKoanf("LIVEKIT_REGIONS", k, "livekit_regions"),
+		LiveKitHomeRegion:               getEnvOrKoanf("LIVEKIT_HOME_REGION", k, "livekit_home_region"),
+		StripeAPIKey:                    getEnvOrKoanf("STRIPE_API_KEY", k, "stripe_api_key"),
+		StripeWebhookSecret:             getEnvOrKoanf("STRIPE_WEBHOOK_SECRET", k, "stripe_webhook_secret"),
+		StripeOnboardingReturnURL:       getEnvOrKoanf("STRIPE_ONBOARDING_RETURN_URL", k, "stripe_onboarding_return_url"),
+		StripeOnboardingRefreshURL:      getEnvOrKoanf("STRIPE_ONBOARDING_REFRESH_URL", k, "stripe_onboarding_refresh_url"),
+		StripeApplicationFeePercent:     stripeFeePercent,
+		MapTilerAPIKey:                  getEnvOrKoanf("MAPTILER_API_KEY", k, "maptiler_api_key"),
+		JetstreamURL:                    getEnvOrKoanf("JETSTREAM_URL", k, "jetstream_url"),
+		R2BucketName:                    getEnvOrKoanf("R2_BUCKET_NAME", k, "r2_bucket_name"),
+		R2AccessKeyID:                   getEnvOrKoanf("R2_ACCESS_KEY_ID", k, "r2_access_key_id"),
+		R2SecretAccessKey:               getEnvOrKoanf("R2_SECRET_ACCESS_KEY", k, "r2_secret_access_key"),
+		R2Endpoint:                      getEnvOrKoanf("R2_ENDPOINT", k, "r2_endpoint"),
+		R2MaxUploadSizeMB:               maxUploadSize,
+		RedisURL:                        getEnvOrKoanf("REDIS_URL", k, "redis_url"),
+		InternalServiceToken:            getEnvOrKoanf("INTERNAL_SERVICE_TOKEN", k, "internal_service_token"),
+		RankTrustEnabled:                rankTrustEnabled,
+		MaxScheduleHorizonDays:          maxScheduleHorizonDays,
+		EventEditGraceMinutes:           eventEditGrace,
+		EventMaxTags:                    eventMaxTags,
+		DescriptionMaxLength:            descriptionMaxLength,
+		PostFeedDefaultWindowDays:       postFeedWindowDays,
+		ContentClassifierURL:            getEnvOrKoanf("CONTENT_CLASSIFIER_URL", k, "content_classifier_url"),
+		OwnershipCacheTTLSeconds:        ownershipCacheTTL,
+		StreamJoinDebounceSeconds:       streamJoinDebounce,
+		StreamMaxActivePerHost:          streamMaxActivePerHost,
+		StreamHostLimitExemptDIDs:       getEnvOrKoanf("STREAM_HOST_LIMIT_EXEMPT_DIDS", k, "stream_host_limit_exempt_dids"),
+		StreamMaxSubscribers:            streamMaxSubscribers,
+		StreamEventSweepSeconds:         streamEventSweep,
+		StreamEndedRetentionSeconds:     streamEndedRetention,
+		WebhookReplayWindowSeconds:      webhookReplayWindow,
+		SceneCreationGateEnabled:        sceneCreationGateEnabled,
+		SceneCreationMinAccountAgeHours: sceneCreationMinAge,
+		SceneCreationAllowlist:          getEnvOrKoanf("SCENE_CREATION_ALLOWLIST", k, "scene_creation_allowlist"),
+		PLCDirectoryURL:                 getEnvOrDefault("PLC_DIRECTORY_URL", k.String("plc_directory_url"), identity.DefaultPLCDirectoryURL),
+		CanaryEnabled:                   canaryEnabled,
+		CanaryTrafficPercent:            canaryTrafficPercent,
+		CanaryErrorThreshold:            canaryErrorThreshold,
+		CanaryLatencyThreshold:          canaryLatencyThreshold,
+		CanaryAutoRollback:              canaryAutoRollback,
+		CanaryMonitoringWindow:          canaryMonitoringWindow,
+		CanaryVersion:                   canaryVersion,
+		TracingEnabled:                  tracingEnabled,
+		TracingExporterType:             getEnvOrDefault("TRACING_EXPORTER_TYPE", k.String("tracing_exporter_type"), DefaultTracingExporterType),
+		TracingOTLPEndpoint:             getEnvOrKoanf("TRACING_OTLP_ENDPOINT", k, "tracing_otlp_endpoint"),
+		TracingSampleRate:               tracingSampleRate,
+		TracingInsecure:                 tracingInsecure,
+		ProfilingEnabled:                profilingEnabled,
+		CORSAllowedOrigins:              corsAllowedOrigins,
+		TrustedProxies:                  getEnvOrDefault("TRUSTED_PROXIES", k.String("trusted_proxies"), DefaultTrustedProxies),
+		AdminDIDs:                       getEnvOrKoanf("ADMIN_DIDS", k, "admin_dids"),
+		CORSAllowedMethods:              corsAllowedMethods,
+		CORSAllowedHeaders:              corsAllowedHeaders,
+		CORSAllowCredentials:            corsAllowCredentials,
+		CORSMaxAge:                      corsMaxAge,
 	}
 
 	// Validate and collect errors
@@ -517,6 +710,12 @@ func (c *Config) Validate() []error {
 	if c.LiveKitAPISecret == "" {
 		errs = append(errs, ErrMissingLiveKitAPISecret)
 	}
+	if c.LiveKitHomeRegion != "" && !slices.Contains(c.LiveKitRegionList(), c.LiveKitHomeRegion) {
+		errs = append(errs, ErrLiveKitHomeRegionNotListed)
+	}
+	if _, err := c.TrustedProxyList(); err != nil {
+		errs = append(errs, err)
+	}
 	if c.StripeAPIKey == "" {
 		errs = append(errs, ErrMissingStripeAPIKey)
 	}
@@ -555,51 +754,120 @@ func (c *Config) Validate() []error {
 	return errs
 }
 
+// LiveKitRegionList returns the configured LiveKit regions with surrounding
+// whitespace and empty entries removed.
+func (c *Config) LiveKitRegionList() []string {
+	var regions []string
+	for _, region := range strings.Split(c.LiveKitRegions, ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+// AdminDIDList returns the configured admin DIDs with surrounding whitespace
+// and empty entries removed.
+func (c *Config) AdminDIDList() []string {
+	var dids []string
+	for _, did := range strings.Split(c.AdminDIDs, ",") {
+		if did = strings.TrimSpace(did); did != "" {
+			dids = append(dids, did)
+		}
+	}
+	return dids
+}
+
+// TrustedProxyList parses TrustedProxies into network prefixes. Bare IP
+// addresses are treated as single-address prefixes; empty entries are skipped.
+// Returns an error wrapping ErrInvalidTrustedProxies for any other entry.
+func (c *Config) TrustedProxyList() ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(c.TrustedProxies, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTrustedProxies, entry)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
 // LogSummary returns a summary of the configuration suitable for logging.
 // All secrets are masked to prevent accidental exposure.
 func (c *Config) LogSummary() map[string]string {
 	return map[string]string{
-		"port":                          fmt.Sprintf("%d", c.Port),
-		"env":                           c.Env,
-		"database_url":                  maskDatabaseURL(c.DatabaseURL),
-		"jwt_secret":                    maskSecret(c.JWTSecret),
-		"jwt_secret_current":            maskSecret(c.JWTSecretCurrent),
-		"jwt_secret_previous":           maskSecret(c.JWTSecretPrevious),
-		"livekit_url":                   c.LiveKitURL,
-		"livekit_api_key":               maskSecret(c.LiveKitAPIKey),
-		"livekit_api_secret":            maskSecret(c.LiveKitAPISecret),
-		"stripe_api_key":                maskStripeKey(c.StripeAPIKey),
-		"stripe_webhook_secret":         maskSecret(c.StripeWebhookSecret),
-		"stripe_onboarding_return_url":  c.StripeOnboardingReturnURL,
-		"stripe_onboarding_refresh_url": c.StripeOnboardingRefreshURL,
-		"maptiler_api_key":              maskSecret(c.MapTilerAPIKey),
-		"jetstream_url":                 c.JetstreamURL,
-		"r2_bucket_name":                c.R2BucketName,
-		"r2_access_key_id":              maskSecret(c.R2AccessKeyID),
-		"r2_secret_access_key":          maskSecret(c.R2SecretAccessKey),
-		"r2_endpoint":                   c.R2Endpoint,
-		"r2_max_upload_size_mb":         fmt.Sprintf("%d", c.R2MaxUploadSizeMB),
-		"redis_url":                     maskDatabaseURL(c.RedisURL),
-		"internal_service_token":        maskSecret(c.InternalServiceToken),
-		"rank_trust_enabled":            fmt.Sprintf("%t", c.RankTrustEnabled),
-		"canary_enabled":                fmt.Sprintf("%t", c.CanaryEnabled),
-		"canary_traffic_percent":        fmt.Sprintf("%.2f", c.CanaryTrafficPercent),
-		"canary_error_threshold":        fmt.Sprintf("%.2f", c.CanaryErrorThreshold),
-		"canary_latency_threshold":      fmt.Sprintf("%.2f", c.CanaryLatencyThreshold),
-		"canary_auto_rollback":          fmt.Sprintf("%t", c.CanaryAutoRollback),
-		"canary_monitoring_window":      fmt.Sprintf("%d", c.CanaryMonitoringWindow),
-		"canary_version":                c.CanaryVersion,
-		"tracing_enabled":               fmt.Sprintf("%t", c.TracingEnabled),
-		"tracing_exporter_type":         c.TracingExporterType,
-		"tracing_otlp_endpoint":         c.TracingOTLPEndpoint,
-		"tracing_sample_rate":           fmt.Sprintf("%.2f", c.TracingSampleRate),
-		"tracing_insecure":              fmt.Sprintf("%t", c.TracingInsecure),
-		"profiling_enabled":             fmt.Sprintf("%t", c.ProfilingEnabled),
-		"cors_allowed_origins":          c.CORSAllowedOrigins,
-		"cors_allowed_methods":          c.CORSAllowedMethods,
-		"cors_allowed_headers":          c.CORSAllowedHeaders,
-		"cors_allow_credentials":        fmt.Sprintf("%t", c.CORSAllowCredentials),
-		"cors_max_age":                  fmt.Sprintf("%d", c.CORSMaxAge),
+		"port":                                 fmt.Sprintf("%d", c.Port),
+		"env":                                  c.Env,
+		"database_url":                         maskDatabaseURL(c.DatabaseURL),
+		"jwt_secret":                           maskSecret(c.JWTSecret),
+		"jwt_secret_current":                   maskSecret(c.JWTSecretCurrent),
+		"jwt_secret_previous":                  maskSecret(c.JWTSecretPrevious),
+		"livekit_url":                          c.LiveKitURL,
+		"livekit_api_key":                      maskSecret(c.LiveKitAPIKey),
+		"livekit_api_secret":                   maskSecret(c.LiveKitAPISecret),
+		"livekit_regions":                      c.LiveKitRegions,
+		"livekit_home_region":                  c.LiveKitHomeRegion,
+		"stripe_api_key":                       maskStripeKey(c.StripeAPIKey),
+		"stripe_webhook_secret":                maskSecret(c.StripeWebhookSecret),
+		"stripe_onboarding_return_url":         c.StripeOnboardingReturnURL,
+		"stripe_onboarding_refresh_url":        c.StripeOnboardingRefreshURL,
+		"maptiler_api_key":                     maskSecret(c.MapTilerAPIKey),
+		"jetstream_url":                        c.JetstreamURL,
+		"r2_bucket_name":                       c.R2BucketName,
+		"r2_access_key_id":                     maskSecret(c.R2AccessKeyID),
+		"r2_secret_access_key":                 maskSecret(c.R2SecretAccessKey),
+		"r2_endpoint":                          c.R2Endpoint,
+		"r2_max_upload_size_mb":                fmt.Sprintf("%d", c.R2MaxUploadSizeMB),
+		"redis_url":                            maskDatabaseURL(c.RedisURL),
+		"internal_service_token":               maskSecret(c.InternalServiceToken),
+		"rank_trust_enabled":                   fmt.Sprintf("%t", c.RankTrustEnabled),
+		"max_schedule_horizon_days":            fmt.Sprintf("%d", c.MaxScheduleHorizonDays),
+		"event_edit_grace_minutes":             fmt.Sprintf("%d", c.EventEditGraceMinutes),
+		"event_max_tags":                       fmt.Sprintf("%d", c.EventMaxTags),
+		"description_max_length":               fmt.Sprintf("%d", c.DescriptionMaxLength),
+		"post_feed_default_window_days":        fmt.Sprintf("%d", c.PostFeedDefaultWindowDays),
+		"content_classifier_url":               c.ContentClassifierURL,
+		"ownership_cache_ttl_seconds":          fmt.Sprintf("%d", c.OwnershipCacheTTLSeconds),
+		"stream_join_debounce_seconds":         fmt.Sprintf("%d", c.StreamJoinDebounceSeconds),
+		"stream_max_active_per_host":           fmt.Sprintf("%d", c.StreamMaxActivePerHost),
+		"stream_host_limit_exempt_dids":        c.StreamHostLimitExemptDIDs,
+		"stream_max_subscribers":               fmt.Sprintf("%d", c.StreamMaxSubscribers),
+		"stream_event_sweep_seconds":           fmt.Sprintf("%d", c.StreamEventSweepSeconds),
+		"stream_ended_retention_seconds":       fmt.Sprintf("%d", c.StreamEndedRetentionSeconds),
+		"webhook_replay_window_seconds":        fmt.Sprintf("%d", c.WebhookReplayWindowSeconds),
+		"scene_creation_gate_enabled":          fmt.Sprintf("%t", c.SceneCreationGateEnabled),
+		"scene_creation_min_account_age_hours": fmt.Sprintf("%d", c.SceneCreationMinAccountAgeHours),
+		"scene_creation_allowlist":             c.SceneCreationAllowlist,
+		"plc_directory_url":                    c.PLCDirectoryURL,
+		"canary_enabled":                       fmt.Sprintf("%t", c.CanaryEnabled),
+		"canary_traffic_percent":               fmt.Sprintf("%.2f", c.CanaryTrafficPercent),
+		"canary_error_threshold":               fmt.Sprintf("%.2f", c.CanaryErrorThreshold),
+		"canary_latency_threshold":             fmt.Sprintf("%.2f", c.CanaryLatencyThreshold),
+		"canary_auto_rollback":                 fmt.Sprintf("%t", c.CanaryAutoRollback),
+		"canary_monitoring_window":             fmt.Sprintf("%d", c.CanaryMonitoringWindow),
+		"canary_version":                       c.CanaryVersion,
+		"tracing_enabled":                      fmt.Sprintf("%t", c.TracingEnabled),
+		"tracing_exporter_type":                c.TracingExporterType,
+		"tracing_otlp_endpoint":                c.TracingOTLPEndpoint,
+		"tracing_sample_rate":                  fmt.Sprintf("%.2f", c.TracingSampleRate),
+		"tracing_insecure":                     fmt.Sprintf("%t", c.TracingInsecure),
+		"profiling_enabled":                    fmt.Sprintf("%t", c.ProfilingEnabled),
+		"cors_allowed_origins":                 c.CORSAllowedOrigins,
+		"cors_allowed_methods":                 c.CORSAllowedMethods,
+		"cors_allowed_headers":                 c.CORSAllowedHeaders,
+		"cors_allow_credentials":               fmt.Sprintf("%t", c.CORSAllowCredentials),
+		"cors_max_age":                         fmt.Sprintf("%d", c.CORSMaxAge),
+		"trusted_proxies":                      c.TrustedProxies,
+		"admin_dids":                           c.AdminDIDs,
 	}
 }
 
@@ -701,6 +969,8 @@ func (c *Config) LogValue() slog.Value {
 		slog.String("livekit_url", c.LiveKitURL),
 		slog.String("livekit_api_key", maskSecret(c.LiveKitAPIKey)),
 		slog.String("livekit_api_secret", maskSecret(c.LiveKitAPISecret)),
+		slog.String("livekit_regions", c.LiveKitRegions),
+		slog.String("livekit_home_region", c.LiveKitHomeRegion),
 
 		// Stripe (non-secret config visible, secrets masked)
 		slog.String("stripe_api_key", maskStripeKey(c.StripeAPIKey)),
@@ -725,6 +995,24 @@ func (c *Config) LogValue() slog.Value {
 
 		// Feature flags / behavior toggles
 		slog.Bool("rank_trust_enabled", c.RankTrustEnabled),
+		slog.Int("max_schedule_horizon_days", c.MaxScheduleHorizonDays),
+		slog.Int("event_edit_grace_minutes", c.EventEditGraceMinutes),
+		slog.Int("event_max_tags", c.EventMaxTags),
+		slog.Int("description_max_length", c.DescriptionMaxLength),
+		slog.Int("post_feed_default_window_days", c.PostFeedDefaultWindowDays),
+		slog.String("content_classifier_url", c.ContentClassifierURL),
+		slog.Int("ownership_cache_ttl_seconds", c.OwnershipCacheTTLSeconds),
+		slog.Int("stream_join_debounce_seconds", c.StreamJoinDebounceSeconds),
+		slog.Int("stream_max_active_per_host", c.StreamMaxActivePerHost),
+		slog.String("stream_host_limit_exempt_dids", c.StreamHostLimitExemptDIDs),
+		slog.Int("stream_max_subscribers", c.StreamMaxSubscribers),
+		slog.Int("stream_event_sweep_seconds", c.StreamEventSweepSeconds),
+		slog.Int("stream_ended_retention_seconds", c.StreamEndedRetentionSeconds),
+		slog.Int("webhook_replay_window_seconds", c.WebhookReplayWindowSeconds),
+		slog.Bool("scene_creation_gate_enabled", c.SceneCreationGateEnabled),
+		slog.Int("scene_creation_min_account_age_hours", c.SceneCreationMinAccountAgeHours),
+		slog.String("scene_creation_allowlist", c.SceneCreationAllowlist),
+		slog.String("plc_directory_url", c.PLCDirectoryURL),
 		slog.Bool("profiling_enabled", c.ProfilingEnabled),
 
 		// Canary configuration (non-secret, operational visibility)
@@ -749,5 +1037,7 @@ func (c *Config) LogValue() slog.Value {
 		slog.String("cors_allowed_headers", c.CORSAllowedHeaders),
 		slog.Bool("cors_allow_credentials", c.CORSAllowCredentials),
 		slog.Int("cors_max_age", c.CORSMaxAge),
+		slog.String("trusted_proxies", c.TrustedProxies),
+		slog.String("admin_dids", c.AdminDIDs),
 	)
 }
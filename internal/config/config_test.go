@@ -3,8 +3,11 @@ package config
 import (
 	"errors"
 	"os"
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/onnwee/subcults/internal/identity"
 )
 
 // clearEnv clears all environment variables that might affect config loading tests.
@@ -16,6 +19,10 @@ func clearEnv() {
 	os.Unsetenv("LIVEKIT_URL")
 	os.Unsetenv("LIVEKIT_API_KEY")
 	os.Unsetenv("LIVEKIT_API_SECRET")
+	os.Unsetenv("LIVEKIT_REGIONS")
+	os.Unsetenv("LIVEKIT_HOME_REGION")
+	os.Unsetenv("TRUSTED_PROXIES")
+	os.Unsetenv("ADMIN_DIDS")
 	os.Unsetenv("STRIPE_API_KEY")
 	os.Unsetenv("STRIPE_WEBHOOK_SECRET")
 	os.Unsetenv("STRIPE_ONBOARDING_RETURN_URL")
@@ -33,6 +40,21 @@ func clearEnv() {
 	os.Unsetenv("GO_ENV")
 	os.Unsetenv("SUBCULT_ENV")
 	os.Unsetenv("RANK_TRUST_ENABLED")
+	os.Unsetenv("MAX_SCHEDULE_HORIZON_DAYS")
+	os.Unsetenv("EVENT_EDIT_GRACE_MINUTES")
+	os.Unsetenv("EVENT_MAX_TAGS")
+	os.Unsetenv("DESCRIPTION_MAX_LENGTH")
+	os.Unsetenv("POST_FEED_DEFAULT_WINDOW_DAYS")
+	os.Unsetenv("OWNERSHIP_CACHE_TTL_SECONDS")
+	os.Unsetenv("STREAM_JOIN_DEBOUNCE_SECONDS")
+	os.Unsetenv("STREAM_MAX_ACTIVE_PER_HOST")
+	os.Unsetenv("STREAM_HOST_LIMIT_EXEMPT_DIDS")
+	os.Unsetenv("STREAM_MAX_SUBSCRIBERS")
+	os.Unsetenv("STREAM_EVENT_SWEEP_SECONDS")
+	os.Unsetenv("STREAM_ENDED_RETENTION_SECONDS")
+	os.Unsetenv("WEBHOOK_REPLAY_WINDOW_SECONDS")
+	os.Unsetenv("SCENE_CREATION_GATE_ENABLED")
+	os.Unsetenv("SCENE_CREATION_MIN_ACCOUNT_AGE_HOURS")
 	os.Unsetenv("PROFILING_ENABLED")
 }
 
@@ -1170,225 +1192,960 @@ rank_trust_enabled: false
 	}
 }
 
-// TestJWTSecretRotation tests the dual-key JWT rotation feature.
-func TestJWTSecretRotation(t *testing.T) {
-	clearEnv()
-	defer clearEnv()
-
-	t.Run("legacy JWT_SECRET still works", func(t *testing.T) {
-		clearEnv()
-		os.Setenv("DATABASE_URL", "postgres://localhost/test")
-		os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
-		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
-		os.Setenv("LIVEKIT_API_KEY", "api_key")
-		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
-		os.Setenv("STRIPE_API_KEY", "sk_test_123")
-		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
-		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
-		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
-		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
-		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
-
-		cfg, errs := Load("")
-		if len(errs) != 0 {
-			t.Errorf("Load() returned errors: %v", errs)
-		}
-		if cfg.JWTSecret != "supersecret32characterlongvalue!" {
-			t.Errorf("cfg.JWTSecret = %s, want supersecret32characterlongvalue!", cfg.JWTSecret)
-		}
-	})
-
-	t.Run("JWT_SECRET_CURRENT without previous secret", func(t *testing.T) {
-		clearEnv()
-		os.Setenv("DATABASE_URL", "postgres://localhost/test")
-		os.Setenv("JWT_SECRET_CURRENT", "current-secret-key-32-characters!")
-		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
-		os.Setenv("LIVEKIT_API_KEY", "api_key")
-		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
-		os.Setenv("STRIPE_API_KEY", "sk_test_123")
-		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
-		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
-		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
-		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
-		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
-
-		cfg, errs := Load("")
-		if len(errs) != 0 {
-			t.Errorf("Load() returned errors: %v", errs)
-		}
-		if cfg.JWTSecretCurrent != "current-secret-key-32-characters!" {
-			t.Errorf("cfg.JWTSecretCurrent = %s, want current-secret-key-32-characters!", cfg.JWTSecretCurrent)
-		}
-		if cfg.JWTSecretPrevious != "" {
-			t.Errorf("cfg.JWTSecretPrevious = %s, want empty", cfg.JWTSecretPrevious)
-		}
-	})
-
-	t.Run("both JWT_SECRET_CURRENT and JWT_SECRET_PREVIOUS", func(t *testing.T) {
-		clearEnv()
-		os.Setenv("DATABASE_URL", "postgres://localhost/test")
-		os.Setenv("JWT_SECRET_CURRENT", "current-secret-key-32-characters!")
-		os.Setenv("JWT_SECRET_PREVIOUS", "previous-secret-key-32-chars!!")
-		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
-		os.Setenv("LIVEKIT_API_KEY", "api_key")
-		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
-		os.Setenv("STRIPE_API_KEY", "sk_test_123")
-		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
-		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
-		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
-		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
-		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
-
-		cfg, errs := Load("")
-		if len(errs) != 0 {
-			t.Errorf("Load() returned errors: %v", errs)
-		}
-		if cfg.JWTSecretCurrent != "current-secret-key-32-characters!" {
-			t.Errorf("cfg.JWTSecretCurrent = %s, want current-secret-key-32-characters!", cfg.JWTSecretCurrent)
-		}
-		if cfg.JWTSecretPrevious != "previous-secret-key-32-chars!!" {
-			t.Errorf("cfg.JWTSecretPrevious = %s, want previous-secret-key-32-chars!!", cfg.JWTSecretPrevious)
-		}
-	})
+func TestLoad_MaxScheduleHorizonDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
+	}{
+		{name: "default when not set", envValue: "", want: DefaultMaxScheduleHorizonDays},
+		{name: "custom value", envValue: "90", want: 90},
+		{name: "zero rejected", envValue: "0", wantErr: true},
+		{name: "non-integer rejected", envValue: "forever", wantErr: true},
+	}
 
-	t.Run("missing both JWT_SECRET and JWT_SECRET_CURRENT fails", func(t *testing.T) {
-		clearEnv()
-		os.Setenv("DATABASE_URL", "postgres://localhost/test")
-		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
-		os.Setenv("LIVEKIT_API_KEY", "api_key")
-		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
-		os.Setenv("STRIPE_API_KEY", "sk_test_123")
-		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
-		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
-		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
-		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
-		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
 
-		_, errs := Load("")
-		if len(errs) == 0 {
-			t.Error("Load() expected errors, got none")
-		}
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
 
-		foundJWTError := false
-		for _, err := range errs {
-			if errors.Is(err, ErrMissingJWTSecret) {
-				foundJWTError = true
-				break
+			if tt.envValue != "" {
+				os.Setenv("MAX_SCHEDULE_HORIZON_DAYS", tt.envValue)
 			}
-		}
-		if !foundJWTError {
-			t.Errorf("Load() errors = %v, want ErrMissingJWTSecret", errs)
-		}
-	})
 
-	t.Run("JWT_SECRET takes precedence over legacy behavior", func(t *testing.T) {
-		clearEnv()
-		os.Setenv("DATABASE_URL", "postgres://localhost/test")
-		os.Setenv("JWT_SECRET", "legacy-secret-key-32-characters!")
-		os.Setenv("JWT_SECRET_CURRENT", "current-secret-key-32-characters!")
-		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
-		os.Setenv("LIVEKIT_API_KEY", "api_key")
-		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
-		os.Setenv("STRIPE_API_KEY", "sk_test_123")
-		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
-		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
-		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
-		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
-		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+			cfg, errs := Load("")
 
-		cfg, errs := Load("")
-		if len(errs) != 0 {
-			t.Errorf("Load() returned errors: %v", errs)
-		}
-		// Both should be populated
-		if cfg.JWTSecret != "legacy-secret-key-32-characters!" {
-			t.Errorf("cfg.JWTSecret = %s, want legacy-secret-key-32-characters!", cfg.JWTSecret)
-		}
-		if cfg.JWTSecretCurrent != "current-secret-key-32-characters!" {
-			t.Errorf("cfg.JWTSecretCurrent = %s, want current-secret-key-32-characters!", cfg.JWTSecretCurrent)
-		}
-	})
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want horizon error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.MaxScheduleHorizonDays != tt.want {
+				t.Errorf("cfg.MaxScheduleHorizonDays = %d, want %d", cfg.MaxScheduleHorizonDays, tt.want)
+			}
+		})
+	}
 }
 
-// TestGetJWTSecrets tests the helper method for retrieving JWT secrets.
-func TestGetJWTSecrets(t *testing.T) {
+func TestLoad_PostFeedDefaultWindowDays(t *testing.T) {
 	tests := []struct {
-		name             string
-		jwtSecret        string
-		jwtSecretCurrent string
-		jwtSecretPrev    string
-		wantCurrent      string
-		wantPrevious     string
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
 	}{
-		{
-			name:             "legacy JWT_SECRET only",
-			jwtSecret:        "legacy-secret",
-			jwtSecretCurrent: "",
-			jwtSecretPrev:    "",
-			wantCurrent:      "legacy-secret",
-			wantPrevious:     "",
-		},
-		{
-			name:             "JWT_SECRET_CURRENT only",
-			jwtSecret:        "",
-			jwtSecretCurrent: "current-secret",
-			jwtSecretPrev:    "",
-			wantCurrent:      "current-secret",
-			wantPrevious:     "",
-		},
-		{
-			name:             "JWT_SECRET_CURRENT with previous",
-			jwtSecret:        "",
-			jwtSecretCurrent: "current-secret",
-			jwtSecretPrev:    "previous-secret",
-			wantCurrent:      "current-secret",
-			wantPrevious:     "previous-secret",
-		},
-		{
-			name:             "JWT_SECRET_CURRENT takes precedence over JWT_SECRET",
-			jwtSecret:        "legacy-secret",
-			jwtSecretCurrent: "current-secret",
-			jwtSecretPrev:    "previous-secret",
-			wantCurrent:      "current-secret",
-			wantPrevious:     "previous-secret",
-		},
+		{name: "default when not set", envValue: "", want: DefaultPostFeedDefaultWindowDays},
+		{name: "custom value", envValue: "7", want: 7},
+		{name: "zero leaves feeds unbounded", envValue: "0", want: 0},
+		{name: "negative rejected", envValue: "-1", wantErr: true},
+		{name: "non-integer rejected", envValue: "week", wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cfg := &Config{
-				JWTSecret:         tt.jwtSecret,
-				JWTSecretCurrent:  tt.jwtSecretCurrent,
-				JWTSecretPrevious: tt.jwtSecretPrev,
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.envValue != "" {
+				os.Setenv("POST_FEED_DEFAULT_WINDOW_DAYS", tt.envValue)
 			}
 
-			current, previous := cfg.GetJWTSecrets()
-			if current != tt.wantCurrent {
-				t.Errorf("GetJWTSecrets() current = %v, want %v", current, tt.wantCurrent)
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want feed window error")
+				}
+				return
 			}
-			if previous != tt.wantPrevious {
-				t.Errorf("GetJWTSecrets() previous = %v, want %v", previous, tt.wantPrevious)
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.PostFeedDefaultWindowDays != tt.want {
+				t.Errorf("cfg.PostFeedDefaultWindowDays = %d, want %d", cfg.PostFeedDefaultWindowDays, tt.want)
 			}
 		})
 	}
 }
 
-// TestLoad_CORSConfiguration tests CORS environment variable parsing.
-func TestLoad_CORSConfiguration(t *testing.T) {
-clearEnv()
-defer clearEnv()
+func TestLoad_EventEditGraceMinutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
+	}{
+		{name: "default when not set", envValue: "", want: DefaultEventEditGraceMinutes},
+		{name: "custom value", envValue: "10", want: 10},
+		{name: "zero locks at start", envValue: "0", want: 0},
+		{name: "negative rejected", envValue: "-5", wantErr: true},
+		{name: "non-integer rejected", envValue: "soon", wantErr: true},
+	}
 
-// Set required env vars
-os.Setenv("DATABASE_URL", "postgres://localhost/test")
-os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
-os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
-os.Setenv("LIVEKIT_API_KEY", "api_key")
-os.Setenv("LIVEKIT_API_SECRET", "api_secret")
-os.Setenv("STRIPE_API_KEY", "sk_test_123")
-os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
-os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
-os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
-os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.envValue != "" {
+				os.Setenv("EVENT_EDIT_GRACE_MINUTES", tt.envValue)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want grace period error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.EventEditGraceMinutes != tt.want {
+				t.Errorf("cfg.EventEditGraceMinutes = %d, want %d", cfg.EventEditGraceMinutes, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_EventMaxTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
+	}{
+		{name: "default when not set", envValue: "", want: DefaultEventMaxTags},
+		{name: "custom value", envValue: "5", want: 5},
+		{name: "zero rejected", envValue: "0", wantErr: true},
+		{name: "non-integer rejected", envValue: "many", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.envValue != "" {
+				os.Setenv("EVENT_MAX_TAGS", tt.envValue)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want tag cap error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.EventMaxTags != tt.want {
+				t.Errorf("cfg.EventMaxTags = %d, want %d", cfg.EventMaxTags, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_DescriptionMaxLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
+	}{
+		{name: "default when not set", envValue: "", want: DefaultDescriptionMaxLength},
+		{name: "custom value", envValue: "2000", want: 2000},
+		{name: "zero rejected", envValue: "0", wantErr: true},
+		{name: "non-integer rejected", envValue: "long", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.envValue != "" {
+				os.Setenv("DESCRIPTION_MAX_LENGTH", tt.envValue)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want description length error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.DescriptionMaxLength != tt.want {
+				t.Errorf("cfg.DescriptionMaxLength = %d, want %d", cfg.DescriptionMaxLength, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_WebhookReplayWindowSeconds(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
+	}{
+		{name: "default when not set", envValue: "", want: DefaultWebhookReplayWindowSeconds},
+		{name: "custom value", envValue: "120", want: 120},
+		{name: "zero rejected", envValue: "0", wantErr: true},
+		{name: "negative rejected", envValue: "-5", wantErr: true},
+		{name: "non-integer rejected", envValue: "5m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.envValue != "" {
+				os.Setenv("WEBHOOK_REPLAY_WINDOW_SECONDS", tt.envValue)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want replay window error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.WebhookReplayWindowSeconds != tt.want {
+				t.Errorf("cfg.WebhookReplayWindowSeconds = %d, want %d", cfg.WebhookReplayWindowSeconds, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_OwnershipCacheTTLSeconds(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
+	}{
+		{name: "default when not set", envValue: "", want: DefaultOwnershipCacheTTLSeconds},
+		{name: "custom value", envValue: "5", want: 5},
+		{name: "zero disables", envValue: "0", want: 0},
+		{name: "negative rejected", envValue: "-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.envValue != "" {
+				os.Setenv("OWNERSHIP_CACHE_TTL_SECONDS", tt.envValue)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want TTL error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.OwnershipCacheTTLSeconds != tt.want {
+				t.Errorf("cfg.OwnershipCacheTTLSeconds = %d, want %d", cfg.OwnershipCacheTTLSeconds, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_StreamJoinDebounceSeconds(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
+	}{
+		{name: "default when not set", envValue: "", want: DefaultStreamJoinDebounceSeconds},
+		{name: "custom value", envValue: "10", want: 10},
+		{name: "zero disables", envValue: "0", want: 0},
+		{name: "negative rejected", envValue: "-1", wantErr: true},
+		{name: "non-integer rejected", envValue: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.envValue != "" {
+				os.Setenv("STREAM_JOIN_DEBOUNCE_SECONDS", tt.envValue)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want debounce error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.StreamJoinDebounceSeconds != tt.want {
+				t.Errorf("cfg.StreamJoinDebounceSeconds = %d, want %d", cfg.StreamJoinDebounceSeconds, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_StreamMaxActivePerHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
+	}{
+		{name: "default when not set", envValue: "", want: DefaultStreamMaxActivePerHost},
+		{name: "custom value", envValue: "5", want: 5},
+		{name: "zero disables", envValue: "0", want: 0},
+		{name: "negative rejected", envValue: "-1", wantErr: true},
+		{name: "non-integer rejected", envValue: "many", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+			os.Setenv("STREAM_HOST_LIMIT_EXEMPT_DIDS", "did:plc:radio,did:plc:festival")
+
+			if tt.envValue != "" {
+				os.Setenv("STREAM_MAX_ACTIVE_PER_HOST", tt.envValue)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want stream limit error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.StreamMaxActivePerHost != tt.want {
+				t.Errorf("cfg.StreamMaxActivePerHost = %d, want %d", cfg.StreamMaxActivePerHost, tt.want)
+			}
+			if cfg.StreamHostLimitExemptDIDs != "did:plc:radio,did:plc:festival" {
+				t.Errorf("cfg.StreamHostLimitExemptDIDs = %q, want the env value", cfg.StreamHostLimitExemptDIDs)
+			}
+		})
+	}
+}
+
+func TestLoad_StreamMaxSubscribers(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+		wantErr  bool
+	}{
+		{name: "default when not set", envValue: "", want: DefaultStreamMaxSubscribers},
+		{name: "custom value", envValue: "50", want: 50},
+		{name: "zero disables", envValue: "0", want: 0},
+		{name: "negative rejected", envValue: "-1", wantErr: true},
+		{name: "non-integer rejected", envValue: "lots", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.envValue != "" {
+				os.Setenv("STREAM_MAX_SUBSCRIBERS", tt.envValue)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want subscriber limit error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.StreamMaxSubscribers != tt.want {
+				t.Errorf("cfg.StreamMaxSubscribers = %d, want %d", cfg.StreamMaxSubscribers, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_StreamEventRegistryRetention(t *testing.T) {
+	tests := []struct {
+		name          string
+		sweep         string
+		retention     string
+		wantSweep     int
+		wantRetention int
+		wantErr       bool
+	}{
+		{name: "defaults when not set", wantSweep: DefaultStreamEventSweepSeconds, wantRetention: DefaultStreamEndedRetentionSeconds},
+		{name: "custom values", sweep: "15", retention: "30", wantSweep: 15, wantRetention: 30},
+		{name: "zero disables", sweep: "0", retention: "0", wantSweep: 0, wantRetention: 0},
+		{name: "negative sweep rejected", sweep: "-1", wantErr: true},
+		{name: "negative retention rejected", retention: "-5", wantErr: true},
+		{name: "non-integer rejected", sweep: "often", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.sweep != "" {
+				os.Setenv("STREAM_EVENT_SWEEP_SECONDS", tt.sweep)
+			}
+			if tt.retention != "" {
+				os.Setenv("STREAM_ENDED_RETENTION_SECONDS", tt.retention)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want registry retention error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.StreamEventSweepSeconds != tt.wantSweep {
+				t.Errorf("cfg.StreamEventSweepSeconds = %d, want %d", cfg.StreamEventSweepSeconds, tt.wantSweep)
+			}
+			if cfg.StreamEndedRetentionSeconds != tt.wantRetention {
+				t.Errorf("cfg.StreamEndedRetentionSeconds = %d, want %d", cfg.StreamEndedRetentionSeconds, tt.wantRetention)
+			}
+		})
+	}
+}
+
+func TestLoad_LiveKitRegions(t *testing.T) {
+	tests := []struct {
+		name        string
+		regions     string
+		homeRegion  string
+		wantRegions []string
+		wantErr     error
+	}{
+		{name: "not configured", wantRegions: nil},
+		{name: "regions trimmed", regions: " us-east, eu-west ,,", homeRegion: "us-east", wantRegions: []string{"us-east", "eu-west"}},
+		{name: "home region optional", regions: "us-east", wantRegions: []string{"us-east"}},
+		{name: "home region not listed", regions: "us-east,eu-west", homeRegion: "ap-south", wantErr: ErrLiveKitHomeRegionNotListed},
+		{name: "home region without regions", homeRegion: "us-east", wantErr: ErrLiveKitHomeRegionNotListed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+			os.Setenv("LIVEKIT_REGIONS", tt.regions)
+			os.Setenv("LIVEKIT_HOME_REGION", tt.homeRegion)
+
+			cfg, errs := Load("")
+
+			if tt.wantErr != nil {
+				found := false
+				for _, err := range errs {
+					if errors.Is(err, tt.wantErr) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Load() errors = %v, want %v", errs, tt.wantErr)
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if got := cfg.LiveKitRegionList(); !slices.Equal(got, tt.wantRegions) {
+				t.Errorf("cfg.LiveKitRegionList() = %v, want %v", got, tt.wantRegions)
+			}
+		})
+	}
+}
+
+func TestConfig_AdminDIDList(t *testing.T) {
+	tests := []struct {
+		name      string
+		adminDIDs string
+		want      []string
+	}{
+		{name: "not configured", want: nil},
+		{name: "trimmed", adminDIDs: " did:plc:ops , did:web:admin.example.com,,", want: []string{"did:plc:ops", "did:web:admin.example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{AdminDIDs: tt.adminDIDs}
+			if got := cfg.AdminDIDList(); !slices.Equal(got, tt.want) {
+				t.Errorf("cfg.AdminDIDList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+	tests := []struct {
+		name         string
+		proxies      string
+		wantPrefixes []string
+		wantErr      error
+	}{
+		{name: "default", wantPrefixes: []string{"127.0.0.0/8", "::1/128", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"}},
+		{name: "CIDRs trimmed and masked", proxies: " 10.1.2.3/16, 2001:db8::/32 ,,", wantPrefixes: []string{"10.1.0.0/16", "2001:db8::/32"}},
+		{name: "bare IP addresses", proxies: "203.0.113.7,2001:db8::1", wantPrefixes: []string{"203.0.113.7/32", "2001:db8::1/128"}},
+		{name: "invalid entry", proxies: "10.0.0.0/8,proxy.internal", wantErr: ErrInvalidTrustedProxies},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+			if tt.proxies != "" {
+				os.Setenv("TRUSTED_PROXIES", tt.proxies)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr != nil {
+				found := false
+				for _, err := range errs {
+					if errors.Is(err, tt.wantErr) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Load() errors = %v, want %v", errs, tt.wantErr)
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			prefixes, err := cfg.TrustedProxyList()
+			if err != nil {
+				t.Fatalf("cfg.TrustedProxyList() error = %v", err)
+			}
+			got := make([]string, len(prefixes))
+			for i, prefix := range prefixes {
+				got[i] = prefix.String()
+			}
+			if !slices.Equal(got, tt.wantPrefixes) {
+				t.Errorf("cfg.TrustedProxyList() = %v, want %v", got, tt.wantPrefixes)
+			}
+		})
+	}
+}
+
+// TestJWTSecretRotation tests the dual-key JWT rotation feature.
+func TestJWTSecretRotation(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+
+	t.Run("legacy JWT_SECRET still works", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("DATABASE_URL", "postgres://localhost/test")
+		os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+		os.Setenv("LIVEKIT_API_KEY", "api_key")
+		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+		os.Setenv("STRIPE_API_KEY", "sk_test_123")
+		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+		cfg, errs := Load("")
+		if len(errs) != 0 {
+			t.Errorf("Load() returned errors: %v", errs)
+		}
+		if cfg.JWTSecret != "supersecret32characterlongvalue!" {
+			t.Errorf("cfg.JWTSecret = %s, want supersecret32characterlongvalue!", cfg.JWTSecret)
+		}
+	})
+
+	t.Run("JWT_SECRET_CURRENT without previous secret", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("DATABASE_URL", "postgres://localhost/test")
+		os.Setenv("JWT_SECRET_CURRENT", "current-secret-key-32-characters!")
+		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+		os.Setenv("LIVEKIT_API_KEY", "api_key")
+		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+		os.Setenv("STRIPE_API_KEY", "sk_test_123")
+		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+		cfg, errs := Load("")
+		if len(errs) != 0 {
+			t.Errorf("Load() returned errors: %v", errs)
+		}
+		if cfg.JWTSecretCurrent != "current-secret-key-32-characters!" {
+			t.Errorf("cfg.JWTSecretCurrent = %s, want current-secret-key-32-characters!", cfg.JWTSecretCurrent)
+		}
+		if cfg.JWTSecretPrevious != "" {
+			t.Errorf("cfg.JWTSecretPrevious = %s, want empty", cfg.JWTSecretPrevious)
+		}
+	})
+
+	t.Run("both JWT_SECRET_CURRENT and JWT_SECRET_PREVIOUS", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("DATABASE_URL", "postgres://localhost/test")
+		os.Setenv("JWT_SECRET_CURRENT", "current-secret-key-32-characters!")
+		os.Setenv("JWT_SECRET_PREVIOUS", "previous-secret-key-32-chars!!")
+		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+		os.Setenv("LIVEKIT_API_KEY", "api_key")
+		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+		os.Setenv("STRIPE_API_KEY", "sk_test_123")
+		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+		cfg, errs := Load("")
+		if len(errs) != 0 {
+			t.Errorf("Load() returned errors: %v", errs)
+		}
+		if cfg.JWTSecretCurrent != "current-secret-key-32-characters!" {
+			t.Errorf("cfg.JWTSecretCurrent = %s, want current-secret-key-32-characters!", cfg.JWTSecretCurrent)
+		}
+		if cfg.JWTSecretPrevious != "previous-secret-key-32-chars!!" {
+			t.Errorf("cfg.JWTSecretPrevious = %s, want previous-secret-key-32-chars!!", cfg.JWTSecretPrevious)
+		}
+	})
+
+	t.Run("missing both JWT_SECRET and JWT_SECRET_CURRENT fails", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("DATABASE_URL", "postgres://localhost/test")
+		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+		os.Setenv("LIVEKIT_API_KEY", "api_key")
+		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+		os.Setenv("STRIPE_API_KEY", "sk_test_123")
+		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+		_, errs := Load("")
+		if len(errs) == 0 {
+			t.Error("Load() expected errors, got none")
+		}
+
+		foundJWTError := false
+		for _, err := range errs {
+			if errors.Is(err, ErrMissingJWTSecret) {
+				foundJWTError = true
+				break
+			}
+		}
+		if !foundJWTError {
+			t.Errorf("Load() errors = %v, want ErrMissingJWTSecret", errs)
+		}
+	})
+
+	t.Run("JWT_SECRET takes precedence over legacy behavior", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("DATABASE_URL", "postgres://localhost/test")
+		os.Setenv("JWT_SECRET", "legacy-secret-key-32-characters!")
+		os.Setenv("JWT_SECRET_CURRENT", "current-secret-key-32-characters!")
+		os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+		os.Setenv("LIVEKIT_API_KEY", "api_key")
+		os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+		os.Setenv("STRIPE_API_KEY", "sk_test_123")
+		os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+		os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+		os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+		os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+		os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+		cfg, errs := Load("")
+		if len(errs) != 0 {
+			t.Errorf("Load() returned errors: %v", errs)
+		}
+		// Both should be populated
+		if cfg.JWTSecret != "legacy-secret-key-32-characters!" {
+			t.Errorf("cfg.JWTSecret = %s, want legacy-secret-key-32-characters!", cfg.JWTSecret)
+		}
+		if cfg.JWTSecretCurrent != "current-secret-key-32-characters!" {
+			t.Errorf("cfg.JWTSecretCurrent = %s, want current-secret-key-32-characters!", cfg.JWTSecretCurrent)
+		}
+	})
+}
+
+// TestGetJWTSecrets tests the helper method for retrieving JWT secrets.
+func TestGetJWTSecrets(t *testing.T) {
+	tests := []struct {
+		name             string
+		jwtSecret        string
+		jwtSecretCurrent string
+		jwtSecretPrev    string
+		wantCurrent      string
+		wantPrevious     string
+	}{
+		{
+			name:             "legacy JWT_SECRET only",
+			jwtSecret:        "legacy-secret",
+			jwtSecretCurrent: "",
+			jwtSecretPrev:    "",
+			wantCurrent:      "legacy-secret",
+			wantPrevious:     "",
+		},
+		{
+			name:             "JWT_SECRET_CURRENT only",
+			jwtSecret:        "",
+			jwtSecretCurrent: "current-secret",
+			jwtSecretPrev:    "",
+			wantCurrent:      "current-secret",
+			wantPrevious:     "",
+		},
+		{
+			name:             "JWT_SECRET_CURRENT with previous",
+			jwtSecret:        "",
+			jwtSecretCurrent: "current-secret",
+			jwtSecretPrev:    "previous-secret",
+			wantCurrent:      "current-secret",
+			wantPrevious:     "previous-secret",
+		},
+		{
+			name:             "JWT_SECRET_CURRENT takes precedence over JWT_SECRET",
+			jwtSecret:        "legacy-secret",
+			jwtSecretCurrent: "current-secret",
+			jwtSecretPrev:    "previous-secret",
+			wantCurrent:      "current-secret",
+			wantPrevious:     "previous-secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				JWTSecret:         tt.jwtSecret,
+				JWTSecretCurrent:  tt.jwtSecretCurrent,
+				JWTSecretPrevious: tt.jwtSecretPrev,
+			}
+
+			current, previous := cfg.GetJWTSecrets()
+			if current != tt.wantCurrent {
+				t.Errorf("GetJWTSecrets() current = %v, want %v", current, tt.wantCurrent)
+			}
+			if previous != tt.wantPrevious {
+				t.Errorf("GetJWTSecrets() previous = %v, want %v", previous, tt.wantPrevious)
+			}
+		})
+	}
+}
+
+// TestLoad_CORSConfiguration tests CORS environment variable parsing.
+func TestLoad_CORSConfiguration(t *testing.T) {
+clearEnv()
+defer clearEnv()
+
+// Set required env vars
+os.Setenv("DATABASE_URL", "postgres://localhost/test")
+os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+os.Setenv("LIVEKIT_API_KEY", "api_key")
+os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+os.Setenv("STRIPE_API_KEY", "sk_test_123")
+os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+os.Setenv("MAPTILER_API_KEY", "maptiler_key")
 os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
 os.Setenv("R2_BUCKET_NAME", "test-bucket")
 os.Setenv("R2_ACCESS_KEY_ID", "test-key")
@@ -1666,3 +2423,66 @@ func TestConfig_LogValue_EmptySecrets(t *testing.T) {
 		t.Error("LogValue() should not contain <nil>")
 	}
 }
+
+func TestLoad_SceneCreationGate(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     string
+		minAge      string
+		wantEnabled bool
+		wantMinAge  int
+		wantErr     bool
+	}{
+		{name: "defaults", wantEnabled: DefaultSceneCreationGateEnabled, wantMinAge: DefaultSceneCreationMinAgeHours},
+		{name: "disabled for development", enabled: "false", wantEnabled: false, wantMinAge: DefaultSceneCreationMinAgeHours},
+		{name: "custom minimum age", minAge: "72", wantEnabled: true, wantMinAge: 72},
+		{name: "negative minimum age rejected", minAge: "-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			defer clearEnv()
+
+			os.Setenv("DATABASE_URL", "postgres://localhost/test")
+			os.Setenv("JWT_SECRET", "supersecret32characterlongvalue!")
+			os.Setenv("LIVEKIT_URL", "wss://livekit.example.com")
+			os.Setenv("LIVEKIT_API_KEY", "api_key")
+			os.Setenv("LIVEKIT_API_SECRET", "api_secret")
+			os.Setenv("STRIPE_API_KEY", "sk_test_123")
+			os.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_123")
+			os.Setenv("STRIPE_ONBOARDING_RETURN_URL", "https://example.com/return")
+			os.Setenv("STRIPE_ONBOARDING_REFRESH_URL", "https://example.com/refresh")
+			os.Setenv("MAPTILER_API_KEY", "maptiler_key")
+			os.Setenv("JETSTREAM_URL", "wss://jetstream.example.com")
+
+			if tt.enabled != "" {
+				os.Setenv("SCENE_CREATION_GATE_ENABLED", tt.enabled)
+			}
+			if tt.minAge != "" {
+				os.Setenv("SCENE_CREATION_MIN_ACCOUNT_AGE_HOURS", tt.minAge)
+			}
+
+			cfg, errs := Load("")
+
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("Load() returned no errors, want minimum age error")
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Load() returned errors: %v", errs)
+			}
+			if cfg.SceneCreationGateEnabled != tt.wantEnabled {
+				t.Errorf("SceneCreationGateEnabled = %v, want %v", cfg.SceneCreationGateEnabled, tt.wantEnabled)
+			}
+			if cfg.SceneCreationMinAccountAgeHours != tt.wantMinAge {
+				t.Errorf("SceneCreationMinAccountAgeHours = %d, want %d", cfg.SceneCreationMinAccountAgeHours, tt.wantMinAge)
+			}
+			if cfg.PLCDirectoryURL != identity.DefaultPLCDirectoryURL {
+				t.Errorf("PLCDirectoryURL = %q, want %q", cfg.PLCDirectoryURL, identity.DefaultPLCDirectoryURL)
+			}
+		})
+	}
+}
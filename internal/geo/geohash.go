@@ -1,7 +1,11 @@
 // Package geo provides geolocation utilities for privacy-preserving location handling.
 package geo
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // DefaultPrecision is the default geohash precision for public display.
 // A precision of 6 characters provides approximately ±0.61 km accuracy,
@@ -23,6 +27,18 @@ var validGeohashChars = map[rune]bool{
 // base32 is the geohash base32 alphabet.
 const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
 
+// MaxGeohashLength is the longest geohash DecodeGeohash accepts. Twelve
+// characters resolve to a few centimetres; anything longer carries no
+// additional meaning and is rejected as malformed.
+const MaxGeohashLength = 12
+
+// Geohash decoding errors.
+var (
+	ErrEmptyGeohash       = errors.New("geohash is empty")
+	ErrGeohashTooLong     = fmt.Errorf("geohash exceeds %d characters", MaxGeohashLength)
+	ErrInvalidGeohashChar = errors.New("geohash contains an invalid character")
+)
+
 // Encode encodes latitude and longitude into a geohash string with the specified precision.
 // Uses the standard geohash algorithm with base32 encoding.
 //
@@ -120,3 +136,57 @@ func RoundGeohash(input string, precision int) string {
 	// Truncate to precision
 	return lower[:precision]
 }
+
+// Bounds is the latitude/longitude box covered by a geohash cell.
+type Bounds struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+}
+
+// DecodeBounds returns the cell covered by geohash. The second return value is
+// false if geohash is not valid; see DecodeGeohash.
+func DecodeBounds(geohash string) (Bounds, bool) {
+	b, err := DecodeGeohash(geohash)
+	return b, err == nil
+}
+
+// DecodeGeohash returns the cell covered by geohash, which is matched
+// case-insensitively. It returns ErrEmptyGeohash, ErrGeohashTooLong, or an
+// error wrapping ErrInvalidGeohashChar that names the offending character.
+func DecodeGeohash(geohash string) (Bounds, error) {
+	if geohash == "" {
+		return Bounds{}, ErrEmptyGeohash
+	}
+	if len(geohash) > MaxGeohashLength {
+		return Bounds{}, ErrGeohashTooLong
+	}
+
+	b := Bounds{MinLat: -90.0, MaxLat: 90.0, MinLng: -180.0, MaxLng: 180.0}
+	even := true
+	for i, c := range strings.ToLower(geohash) {
+		idx := strings.IndexRune(base32, c)
+		if idx < 0 {
+			return Bounds{}, fmt.Errorf("%w: %q at position %d", ErrInvalidGeohashChar, c, i)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := idx&(1<<bit) != 0
+			if even {
+				mid := (b.MinLng + b.MaxLng) / 2
+				if set {
+					b.MinLng = mid
+				} else {
+					b.MaxLng = mid
+				}
+			} else {
+				mid := (b.MinLat + b.MaxLat) / 2
+				if set {
+					b.MinLat = mid
+				} else {
+					b.MaxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return b, nil
+}
@@ -1,6 +1,9 @@
 package geo
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestRoundGeohash(t *testing.T) {
 	tests := []struct {
@@ -247,3 +250,59 @@ func TestEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeBounds(t *testing.T) {
+	// Points round-trip into the cell their geohash decodes to
+	for _, p := range []struct{ lat, lng float64 }{{47.6062, -122.3321}, {52.5200, 13.4050}, {-33.8688, 151.2093}} {
+		hash := Encode(p.lat, p.lng, 6)
+		b, ok := DecodeBounds(hash)
+		if !ok {
+			t.Fatalf("DecodeBounds(%q) failed", hash)
+		}
+		if p.lat < b.MinLat || p.lat > b.MaxLat || p.lng < b.MinLng || p.lng > b.MaxLng {
+			t.Errorf("point (%v, %v) outside decoded cell %+v of %q", p.lat, p.lng, b, hash)
+		}
+	}
+
+	for _, hash := range []string{"", "abc", "u33d!"} {
+		if _, ok := DecodeBounds(hash); ok {
+			t.Errorf("DecodeBounds(%q) succeeded, want failure", hash)
+		}
+	}
+}
+
+func TestDecodeGeohash(t *testing.T) {
+	b, err := DecodeGeohash("dr5reg")
+	if err != nil {
+		t.Fatalf("DecodeGeohash(%q) error = %v", "dr5reg", err)
+	}
+	// dr5reg covers lower Manhattan
+	if lat, lng := 40.7128, -74.0060; lat < b.MinLat || lat > b.MaxLat || lng < b.MinLng || lng > b.MaxLng {
+		t.Errorf("DecodeGeohash(%q) = %+v, want a cell containing (%v, %v)", "dr5reg", b, lat, lng)
+	}
+	if upper, err := DecodeGeohash("DR5REG"); err != nil || upper != b {
+		t.Errorf("DecodeGeohash(%q) = %+v, %v; want %+v", "DR5REG", upper, err, b)
+	}
+
+	tests := []struct {
+		name    string
+		geohash string
+		wantErr error
+	}{
+		{name: "empty", geohash: "", wantErr: ErrEmptyGeohash},
+		{name: "invalid character", geohash: "dr5rai", wantErr: ErrInvalidGeohashChar},
+		{name: "punctuation", geohash: "u33d!", wantErr: ErrInvalidGeohashChar},
+		{name: "too long", geohash: "dr5regw3pg6ss", wantErr: ErrGeohashTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeGeohash(tt.geohash); !errors.Is(err, tt.wantErr) {
+				t.Errorf("DecodeGeohash(%q) error = %v, want %v", tt.geohash, err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := DecodeGeohash("dr5regw3pg6s"); err != nil {
+		t.Errorf("DecodeGeohash() of a %d-character geohash error = %v", MaxGeohashLength, err)
+	}
+}
@@ -0,0 +1,83 @@
+package geo
+
+import (
+	"errors"
+	"math"
+)
+
+// EarthRadiusMeters is the mean Earth radius used for great-circle distances.
+const EarthRadiusMeters = 6371008.8
+
+// MaxRadiusMeters is the great-circle distance to the antipode, the largest
+// distance between two points on the globe.
+const MaxRadiusMeters = math.Pi * EarthRadiusMeters
+
+// Coordinate validation errors.
+var (
+	ErrInvalidLatitude  = errors.New("latitude must be between -90 and 90")
+	ErrInvalidLongitude = errors.New("longitude must be between -180 and 180")
+)
+
+// ValidateLatitude returns ErrInvalidLatitude unless lat is a finite value in
+// [-90, 90]. NaN is rejected.
+func ValidateLatitude(lat float64) error {
+	if !(lat >= -90 && lat <= 90) {
+		return ErrInvalidLatitude
+	}
+	return nil
+}
+
+// ValidateLongitude returns ErrInvalidLongitude unless lng is a finite value in
+// [-180, 180]. NaN is rejected.
+func ValidateLongitude(lng float64) error {
+	if !(lng >= -180 && lng <= 180) {
+		return ErrInvalidLongitude
+	}
+	return nil
+}
+
+// ValidatePoint checks a client-supplied coordinate, returning
+// ErrInvalidLatitude or ErrInvalidLongitude.
+func ValidatePoint(lat, lng float64) error {
+	if err := ValidateLatitude(lat); err != nil {
+		return err
+	}
+	return ValidateLongitude(lng)
+}
+
+// ClampLatitude limits lat to [-90, 90], e.g. after offsetting a point near a
+// pole. NaN is returned unchanged.
+func ClampLatitude(lat float64) float64 {
+	return math.Max(-90, math.Min(90, lat))
+}
+
+// NormalizeLongitude wraps lng into [-180, 180), e.g. after offsetting a point
+// across the antimeridian. NaN and infinite values return NaN.
+func NormalizeLongitude(lng float64) float64 {
+	if lng >= -180 && lng < 180 {
+		return lng
+	}
+	wrapped := math.Mod(lng+180, 360)
+	if wrapped < 0 {
+		wrapped += 360
+	}
+	return wrapped - 180
+}
+
+// DistanceMeters returns the great-circle (haversine) distance between two
+// points. Latitudes are clamped and longitudes wrapped first, so points near
+// the poles or on either side of the antimeridian measure correctly. The
+// result is in [0, MaxRadiusMeters], or NaN if any input is NaN or infinite.
+func DistanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	phi1 := ClampLatitude(lat1) * math.Pi / 180
+	phi2 := ClampLatitude(lat2) * math.Pi / 180
+	dPhi := phi2 - phi1
+	dLambda := NormalizeLongitude(NormalizeLongitude(lng2)-NormalizeLongitude(lng1)) * math.Pi / 180
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	// Rounding can push a just past 1 for antipodal points, which would make
+	// Asin return NaN
+	a = math.Max(0, math.Min(1, a))
+	return 2 * EarthRadiusMeters * math.Asin(math.Sqrt(a))
+}
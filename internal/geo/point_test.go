@@ -0,0 +1,91 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidatePoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lng float64
+		wantErr  error
+	}{
+		{name: "valid", lat: 40.7128, lng: -74.0060},
+		{name: "north pole", lat: 90, lng: 0},
+		{name: "south pole", lat: -90, lng: 180},
+		{name: "antimeridian", lat: 0, lng: -180},
+		{name: "latitude above range", lat: 90.0001, lng: 0, wantErr: ErrInvalidLatitude},
+		{name: "latitude below range", lat: -1000, lng: 0, wantErr: ErrInvalidLatitude},
+		{name: "latitude NaN", lat: math.NaN(), lng: 0, wantErr: ErrInvalidLatitude},
+		{name: "latitude infinite", lat: math.Inf(1), lng: 0, wantErr: ErrInvalidLatitude},
+		{name: "longitude above range", lat: 0, lng: 180.5, wantErr: ErrInvalidLongitude},
+		{name: "longitude NaN", lat: 0, lng: math.NaN(), wantErr: ErrInvalidLongitude},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePoint(tt.lat, tt.lng); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidatePoint(%v, %v) = %v, want %v", tt.lat, tt.lng, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeLongitude(t *testing.T) {
+	tests := []struct {
+		lng, want float64
+	}{
+		{lng: 0, want: 0},
+		{lng: -180, want: -180},
+		{lng: 180, want: -180},
+		{lng: 190, want: -170},
+		{lng: -190, want: 170},
+		{lng: 540, want: -180},
+		{lng: -725, want: -5},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeLongitude(tt.lng); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("NormalizeLongitude(%v) = %v, want %v", tt.lng, got, tt.want)
+		}
+	}
+	if got := NormalizeLongitude(math.Inf(1)); !math.IsNaN(got) {
+		t.Errorf("NormalizeLongitude(+Inf) = %v, want NaN", got)
+	}
+}
+
+func TestDistanceMeters(t *testing.T) {
+	// One degree of arc along a great circle
+	oneDegree := EarthRadiusMeters * math.Pi / 180
+
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		want                   float64
+	}{
+		{name: "same point", lat1: 40.7128, lng1: -74.0060, lat2: 40.7128, lng2: -74.0060, want: 0},
+		{name: "one degree of latitude", lat1: 0, lng1: 0, lat2: 1, lng2: 0, want: oneDegree},
+		{name: "across the antimeridian", lat1: 0, lng1: 179.5, lat2: 0, lng2: -179.5, want: oneDegree},
+		{name: "antimeridian written as 180 and -180", lat1: 10, lng1: 180, lat2: 10, lng2: -180, want: 0},
+		{name: "longitude irrelevant at the pole", lat1: 90, lng1: 0, lat2: 90, lng2: 123, want: 0},
+		{name: "over the north pole", lat1: 89, lng1: 0, lat2: 89, lng2: 180, want: 2 * oneDegree},
+		{name: "pole to pole", lat1: 90, lng1: 0, lat2: -90, lng2: 0, want: MaxRadiusMeters},
+		{name: "antipodal points", lat1: 45, lng1: 30, lat2: -45, lng2: -150, want: MaxRadiusMeters},
+		{name: "out-of-range latitude clamped to the pole", lat1: 95, lng1: 0, lat2: 90, lng2: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceMeters(tt.lat1, tt.lng1, tt.lat2, tt.lng2)
+			if math.IsNaN(got) || math.Abs(got-tt.want) > 1e-3 {
+				t.Errorf("DistanceMeters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := DistanceMeters(math.NaN(), 0, 0, 0); !math.IsNaN(got) {
+		t.Errorf("DistanceMeters(NaN, ...) = %v, want NaN", got)
+	}
+}
@@ -0,0 +1,114 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var gateNow = time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+
+// failingResolver fails every resolution with a transport error.
+type failingResolver struct{}
+
+func (failingResolver) Resolve(context.Context, string) (*Account, error) {
+	return nil, errors.New("directory unreachable")
+}
+
+func newTestGate(config GateConfig) *Gate {
+	resolver := NewInMemoryResolver()
+	resolver.Add(Account{DID: "did:plc:established", CreatedAt: gateNow.Add(-30 * 24 * time.Hour)})
+	resolver.Add(Account{DID: "did:plc:fresh", CreatedAt: gateNow.Add(-time.Hour)})
+
+	gate := NewGate(config, resolver)
+	gate.timeNow = func() time.Time { return gateNow }
+	return gate
+}
+
+func TestGate_Check(t *testing.T) {
+	enabled := GateConfig{Enabled: true, MinAccountAge: 24 * time.Hour, Allowlist: []string{"did:plc:trusted"}}
+
+	tests := []struct {
+		name       string
+		config     GateConfig
+		did        string
+		wantReason string
+	}{
+		{name: "established account passes", config: enabled, did: "did:plc:established"},
+		{name: "too new rejected", config: enabled, did: "did:plc:fresh", wantReason: "account must be at least 1 day old"},
+		{name: "unresolvable rejected", config: enabled, did: "did:plc:unknown", wantReason: "account identity could not be resolved"},
+		{name: "allowlisted skips resolution", config: enabled, did: "did:plc:trusted"},
+		{name: "disabled passes everyone", config: GateConfig{MinAccountAge: 24 * time.Hour}, did: "did:plc:unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newTestGate(tt.config).Check(context.Background(), tt.did)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("Check() = %v, want nil", err)
+				}
+				return
+			}
+			var gateErr *GateError
+			if !errors.As(err, &gateErr) {
+				t.Fatalf("Check() = %v, want *GateError", err)
+			}
+			if gateErr.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", gateErr.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestGate_DIDWebNeedsAllowlist(t *testing.T) {
+	config := GateConfig{Enabled: true, MinAccountAge: 24 * time.Hour}
+	resolver := NewPLCResolver("http://plc.invalid", nil)
+
+	// A did:web account has no PLC history, so its age can't be verified
+	err := NewGate(config, resolver).Check(context.Background(), "did:web:example.com")
+	var gateErr *GateError
+	if !errors.As(err, &gateErr) {
+		t.Fatalf("Check() = %v, want *GateError", err)
+	}
+	if want := "account age can't be verified for this DID method"; gateErr.Reason != want {
+		t.Errorf("Reason = %q, want %q", gateErr.Reason, want)
+	}
+
+	config.Allowlist = []string{"did:web:example.com"}
+	if err := NewGate(config, resolver).Check(context.Background(), "did:web:example.com"); err != nil {
+		t.Errorf("allowlisted did:web Check() = %v, want nil", err)
+	}
+}
+
+func TestGate_ResolverFailure(t *testing.T) {
+	gate := NewGate(GateConfig{Enabled: true}, failingResolver{})
+
+	err := gate.Check(context.Background(), "did:plc:anyone")
+	var gateErr *GateError
+	if err == nil || errors.As(err, &gateErr) {
+		t.Errorf("expected a non-gate error when the resolver fails, got %v", err)
+	}
+
+	var nilGate *Gate
+	if err := nilGate.Check(context.Background(), "did:plc:anyone"); err != nil {
+		t.Errorf("nil gate Check() = %v, want nil", err)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := map[time.Duration]string{
+		time.Hour:        "1 hour",
+		12 * time.Hour:   "12 hours",
+		24 * time.Hour:   "1 day",
+		72 * time.Hour:   "3 days",
+		36 * time.Hour:   "36 hours",
+		90 * time.Minute: "2 hours",
+	}
+	for d, want := range tests {
+		if got := formatAge(d); got != want {
+			t.Errorf("formatAge(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onnwee/subcults/internal/validate"
+)
+
+// Handle resolution errors.
+var (
+	ErrInvalidHandle  = errors.New("invalid handle")
+	ErrHandleNotFound = errors.New("handle not found")
+)
+
+// handlePattern matches AT Protocol handle syntax: two or more dot-separated
+// DNS labels, the last of which does not start with a digit.
+var handlePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// maxHandleLength is the longest handle accepted, matching the DNS name limit.
+const maxHandleLength = 253
+
+// NormalizeHandle lowercases handle and strips a leading "@" as written in
+// mentions. Returns ErrInvalidHandle if the result is not a valid handle.
+func NormalizeHandle(handle string) (string, error) {
+	handle = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+	if len(handle) > maxHandleLength || !handlePattern.MatchString(handle) {
+		return "", ErrInvalidHandle
+	}
+	return handle, nil
+}
+
+// HandleResolution is a handle's verified DID and the PDS hosting its repository.
+type HandleResolution struct {
+	Handle string
+	DID    string
+	PDS    string // Personal data server endpoint URL
+}
+
+// HandleResolver resolves AT Protocol handles to DIDs.
+type HandleResolver interface {
+	// ResolveHandle returns the DID and PDS for a normalized handle.
+	// Returns ErrHandleNotFound if the handle does not resolve to a DID
+	// whose document claims it back.
+	ResolveHandle(ctx context.Context, handle string) (*HandleResolution, error)
+}
+
+// InMemoryHandleResolver is a HandleResolver backed by a fixed set of
+// resolutions, for development and tests.
+type InMemoryHandleResolver struct {
+	mu          sync.RWMutex
+	resolutions map[string]HandleResolution
+}
+
+// NewInMemoryHandleResolver creates an empty in-memory handle resolver.
+func NewInMemoryHandleResolver() *InMemoryHandleResolver {
+	return &InMemoryHandleResolver{resolutions: make(map[string]HandleResolution)}
+}
+
+// Add registers a resolution, replacing any existing one for the same handle.
+func (r *InMemoryHandleResolver) Add(resolution HandleResolution) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resolutions[resolution.Handle] = resolution
+}
+
+// ResolveHandle returns a copy of the registered resolution for handle.
+func (r *InMemoryHandleResolver) ResolveHandle(ctx context.Context, handle string) (*HandleResolution, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	resolution, ok := r.resolutions[handle]
+	if !ok {
+		return nil, ErrHandleNotFound
+	}
+	return &resolution, nil
+}
+
+// didDocument is the subset of a DID document needed to verify a handle and
+// locate its PDS.
+type didDocument struct {
+	AlsoKnownAs []string `json:"alsoKnownAs"`
+	Service     []struct {
+		ID              string `json:"id"`
+		ServiceEndpoint string `json:"serviceEndpoint"`
+	} `json:"service"`
+}
+
+// ATProtoHandleResolver resolves handles the way AT Protocol clients do: a DNS
+// TXT record at _atproto.<handle>, falling back to https://<handle>/.well-known/atproto-did.
+// The DID's document must list the handle in alsoKnownAs, and supplies the PDS.
+// did:plc documents are read from the PLC directory. did:web documents are read
+// from https://<host>/.well-known/did.json; as in AT Protocol, only hostname
+// did:web identities (no path) are supported.
+//
+// Handles and did:web hosts are chosen by users, so requests to them go through
+// a client that refuses to connect to private, loopback, and link-local
+// addresses.
+type ATProtoHandleResolver struct {
+	plcBaseURL string
+	client     *http.Client                                             // PLC directory requests
+	webClient  *http.Client                                             // Requests to user-controlled hosts
+	lookupTXT  func(ctx context.Context, name string) ([]string, error) // For testability
+}
+
+// NewATProtoHandleResolver creates a handle resolver that reads did:plc
+// documents from the PLC directory at plcBaseURL. If client is nil, a client
+// with a 5 second timeout is used for the directory.
+func NewATProtoHandleResolver(plcBaseURL string, client *http.Client) *ATProtoHandleResolver {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &ATProtoHandleResolver{
+		plcBaseURL: strings.TrimRight(plcBaseURL, "/"),
+		client:     client,
+		webClient: &http.Client{
+			Timeout: 5 * time.Second,
+			Transport: &http.Transport{
+				DialContext:         validate.SafeDialer(5 * time.Second).DialContext,
+				TLSHandshakeTimeout: 5 * time.Second,
+			},
+		},
+		lookupTXT: net.DefaultResolver.LookupTXT,
+	}
+}
+
+// ResolveHandle resolves handle to a DID, then verifies it against the DID's
+// document and reads the PDS endpoint.
+func (r *ATProtoHandleResolver) ResolveHandle(ctx context.Context, handle string) (*HandleResolution, error) {
+	did, err := r.lookupDID(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc *didDocument
+	switch {
+	case strings.HasPrefix(did, "did:plc:"):
+		doc, err = r.fetchPLCDocument(ctx, did)
+	case strings.HasPrefix(did, "did:web:"):
+		doc, err = r.fetchWebDocument(ctx, did)
+	default:
+		return nil, ErrUnsupportedMethod
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A handle is only trusted if the DID claims it back
+	verified := false
+	for _, aka := range doc.AlsoKnownAs {
+		if strings.EqualFold(aka, "at://"+handle) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrHandleNotFound
+	}
+
+	resolution := &HandleResolution{Handle: handle, DID: did}
+	for _, svc := range doc.Service {
+		if svc.ID == "#atproto_pds" || svc.ID == did+"#atproto_pds" {
+			resolution.PDS = svc.ServiceEndpoint
+			break
+		}
+	}
+	return resolution, nil
+}
+
+// lookupDID finds the DID a handle points at via DNS, then HTTPS.
+func (r *ATProtoHandleResolver) lookupDID(ctx context.Context, handle string) (string, error) {
+	if records, err := r.lookupTXT(ctx, "_atproto."+handle); err == nil {
+		for _, record := range records {
+			if did, ok := strings.CutPrefix(record, "did="); ok {
+				return strings.TrimSpace(did), nil
+			}
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+handle+"/.well-known/atproto-did", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build well-known request: %w", err)
+	}
+	resp, err := r.webClient.Do(req)
+	if err != nil {
+		// Unreachable hosts are how most made-up handles fail, and hosts
+		// resolving to private addresses are refused
+		return "", ErrHandleNotFound
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", ErrHandleNotFound
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 2048))
+	if err != nil {
+		return "", fmt.Errorf("failed to read well-known response: %w", err)
+	}
+	did := strings.TrimSpace(string(body))
+	if !strings.HasPrefix(did, "did:") {
+		return "", ErrHandleNotFound
+	}
+	return did, nil
+}
+
+// fetchPLCDocument fetches the DID document for did from the PLC directory.
+func (r *ATProtoHandleResolver) fetchPLCDocument(ctx context.Context, did string) (*didDocument, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.plcBaseURL+"/"+url.PathEscape(did), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build plc request: %w", err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query plc directory: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, ErrHandleNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("plc directory returned status %d", resp.StatusCode)
+	}
+
+	var doc didDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode plc document: %w", err)
+	}
+	return &doc, nil
+}
+
+// maxWebDocumentBytes caps the size of a did:web document.
+const maxWebDocumentBytes = 64 << 10
+
+// fetchWebDocument fetches the DID document for a hostname did:web from the
+// host's /.well-known/did.json. Path-based did:web identities (with further
+// colon-separated segments) and hosts with ports are not supported.
+func (r *ATProtoHandleResolver) fetchWebDocument(ctx context.Context, did string) (*didDocument, error) {
+	host, err := NormalizeHandle(strings.TrimPrefix(did, "did:web:"))
+	if err != nil {
+		return nil, ErrUnsupportedMethod
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+host+"/.well-known/did.json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build did:web request: %w", err)
+	}
+	resp, err := r.webClient.Do(req)
+	if err != nil {
+		return nil, ErrHandleNotFound
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrHandleNotFound
+	}
+
+	var doc didDocument
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxWebDocumentBytes)).Decode(&doc); err != nil {
+		// The host is user-controlled, so a malformed document is its problem
+		return nil, ErrHandleNotFound
+	}
+	return &doc, nil
+}
+
+// DefaultHandleCacheTTL is how long handle resolutions are cached by default.
+const DefaultHandleCacheTTL = 10 * time.Minute
+
+// handleCacheSweepThreshold is the cache size at which expired entries are pruned.
+const handleCacheSweepThreshold = 10000
+
+// handleCacheEntry is a cached resolution, or a cached ErrHandleNotFound when
+// resolution is nil, and when it stops being trusted.
+type handleCacheEntry struct {
+	resolution *HandleResolution
+	expiresAt  time.Time
+}
+
+// CachingHandleResolver wraps a HandleResolver with a TTL cache. Successful
+// resolutions and ErrHandleNotFound are cached, so repeated lookups of the same
+// unknown handle do not reach DNS or the PLC directory; other errors are not.
+// Thread-safe.
+type CachingHandleResolver struct {
+	resolver HandleResolver
+	ttl      time.Duration
+	mu       sync.Mutex
+	entries  map[string]handleCacheEntry
+	timeNow  func() time.Time // For testability
+}
+
+// NewCachingHandleResolver wraps resolver with a cache of the given ttl.
+// A non-positive ttl disables caching.
+func NewCachingHandleResolver(resolver HandleResolver, ttl time.Duration) *CachingHandleResolver {
+	return &CachingHandleResolver{
+		resolver: resolver,
+		ttl:      ttl,
+		entries:  make(map[string]handleCacheEntry),
+		timeNow:  time.Now,
+	}
+}
+
+// ResolveHandle serves unexpired resolutions from the cache, resolving and
+// caching them otherwise.
+func (c *CachingHandleResolver) ResolveHandle(ctx context.Context, handle string) (*HandleResolution, error) {
+	now := c.timeNow()
+
+	c.mu.Lock()
+	entry, ok := c.entries[handle]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		if entry.resolution == nil {
+			return nil, ErrHandleNotFound
+		}
+		resolution := *entry.resolution
+		return &resolution, nil
+	}
+
+	resolution, err := c.resolver.ResolveHandle(ctx, handle)
+	if err != nil && !errors.Is(err, ErrHandleNotFound) {
+		return nil, err
+	}
+	if c.ttl > 0 {
+		c.store(handle, resolution, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resolution, nil
+}
+
+// store caches resolution for handle, pruning expired entries once the cache is large.
+func (c *CachingHandleResolver) store(handle string, resolution *HandleResolution, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= handleCacheSweepThreshold {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+	}
+
+	entry := handleCacheEntry{expiresAt: now.Add(c.ttl)}
+	if resolution != nil {
+		resolutionCopy := *resolution
+		entry.resolution = &resolutionCopy
+	}
+	c.entries[handle] = entry
+}
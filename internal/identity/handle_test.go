@@ -0,0 +1,197 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onnwee/subcults/internal/validate"
+)
+
+// handlerTransport serves every request from handler, so a resolver's HTTPS
+// well-known lookups can be answered without real hosts.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	t.handler.ServeHTTP(w, req)
+	return w.Result(), nil
+}
+
+func TestNormalizeHandle(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "alice.bsky.social", want: "alice.bsky.social"},
+		{input: "@Alice.Bsky.Social", want: "alice.bsky.social"},
+		{input: "  bob.example.com ", want: "bob.example.com"},
+		{input: "localhost", wantErr: true},
+		{input: "alice.123", wantErr: true},
+		{input: "bad_chars.example.com", wantErr: true},
+		{input: "-leading.example.com", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeHandle(tt.input)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("NormalizeHandle(%q) error = %v, want ErrInvalidHandle", tt.input, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeHandle(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestATProtoHandleResolver_ResolveHandle(t *testing.T) {
+	plc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/did:plc:alice":
+			_, _ = w.Write([]byte(`{"alsoKnownAs":["at://alice.example.com"],"service":[{"id":"#atproto_pds","serviceEndpoint":"https://pds.example.com"}]}`))
+		case "/did:plc:bob":
+			_, _ = w.Write([]byte(`{"alsoKnownAs":["at://bob.example.com"],"service":[{"id":"#atproto_pds","serviceEndpoint":"https://bob-pds.example.com"}]}`))
+		case "/did:plc:someone":
+			_, _ = w.Write([]byte(`{"alsoKnownAs":["at://someone.example.com"]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer plc.Close()
+
+	// bob.example.com publishes its DID over HTTPS and carol.example.com hosts
+	// her did:web document; every other host is unreachable
+	wellKnown := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Host == "bob.example.com" && r.URL.Path == "/.well-known/atproto-did":
+			_, _ = w.Write([]byte("did:plc:bob\n"))
+		case r.Host == "carol.example.com" && r.URL.Path == "/.well-known/did.json":
+			_, _ = w.Write([]byte(`{"alsoKnownAs":["at://carol.example.com"],"service":[{"id":"did:web:carol.example.com#atproto_pds","serviceEndpoint":"https://carol-pds.example.com"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	resolver := NewATProtoHandleResolver(plc.URL, nil)
+	resolver.webClient = &http.Client{Transport: handlerTransport{handler: wellKnown}}
+	resolver.lookupTXT = func(_ context.Context, name string) ([]string, error) {
+		switch name {
+		case "_atproto.alice.example.com":
+			return []string{"did=did:plc:alice"}, nil
+		case "_atproto.impostor.example.com":
+			return []string{"did=did:plc:someone"}, nil
+		case "_atproto.carol.example.com":
+			return []string{"did=did:web:carol.example.com"}, nil
+		case "_atproto.dave.example.com":
+			return []string{"did=did:web:example.com:users:dave"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	tests := []struct {
+		handle  string
+		wantDID string
+		wantPDS string
+		wantErr error
+	}{
+		{handle: "alice.example.com", wantDID: "did:plc:alice", wantPDS: "https://pds.example.com"},
+		{handle: "bob.example.com", wantDID: "did:plc:bob", wantPDS: "https://bob-pds.example.com"},
+		{handle: "nobody.example.com", wantErr: ErrHandleNotFound},
+		{handle: "impostor.example.com", wantErr: ErrHandleNotFound}, // DID does not claim the handle back
+		{handle: "carol.example.com", wantDID: "did:web:carol.example.com", wantPDS: "https://carol-pds.example.com"},
+		{handle: "dave.example.com", wantErr: ErrUnsupportedMethod}, // Path-based did:web
+	}
+
+	for _, tt := range tests {
+		resolution, err := resolver.ResolveHandle(context.Background(), tt.handle)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ResolveHandle(%q) error = %v, want %v", tt.handle, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ResolveHandle(%q) error = %v", tt.handle, err)
+		}
+		if resolution.DID != tt.wantDID || resolution.PDS != tt.wantPDS {
+			t.Errorf("ResolveHandle(%q) = %+v, want DID %q PDS %q", tt.handle, resolution, tt.wantDID, tt.wantPDS)
+		}
+	}
+}
+
+func TestATProtoHandleResolver_RefusesPrivateHosts(t *testing.T) {
+	// A handle pointing at an internal service must not be fetched
+	internal := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("did:plc:alice"))
+	}))
+	defer internal.Close()
+
+	resolver := NewATProtoHandleResolver(DefaultPLCDirectoryURL, nil)
+	_, err := resolver.webClient.Get(internal.URL + "/.well-known/atproto-did")
+	if !errors.Is(err, validate.ErrSSRFRisk) {
+		t.Errorf("well-known fetch from %s error = %v, want ErrSSRFRisk", internal.URL, err)
+	}
+}
+
+// countingHandleResolver counts lookups and returns a fixed result.
+type countingHandleResolver struct {
+	calls int
+	err   error
+}
+
+func (r *countingHandleResolver) ResolveHandle(_ context.Context, handle string) (*HandleResolution, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &HandleResolution{Handle: handle, DID: "did:plc:cached"}, nil
+}
+
+func TestCachingHandleResolver(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCalls int // after three lookups, the last past the TTL
+	}{
+		{name: "resolutions are cached", wantCalls: 2},
+		{name: "not found is cached", err: ErrHandleNotFound, wantCalls: 2},
+		{name: "transient errors are not cached", err: errors.New("dns timeout"), wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &countingHandleResolver{err: tt.err}
+			cache := NewCachingHandleResolver(inner, time.Minute)
+			now := time.Now()
+			cache.timeNow = func() time.Time { return now }
+
+			for i := 0; i < 2; i++ {
+				resolution, err := cache.ResolveHandle(context.Background(), "alice.example.com")
+				if tt.err != nil {
+					if !errors.Is(err, tt.err) {
+						t.Fatalf("lookup %d error = %v, want %v", i+1, err, tt.err)
+					}
+				} else if err != nil || resolution.DID != "did:plc:cached" {
+					t.Fatalf("lookup %d = %+v, %v", i+1, resolution, err)
+				}
+			}
+
+			now = now.Add(2 * time.Minute)
+			_, _ = cache.ResolveHandle(context.Background(), "alice.example.com")
+
+			if inner.calls != tt.wantCalls {
+				t.Errorf("inner resolver called %d times, want %d", inner.calls, tt.wantCalls)
+			}
+		})
+	}
+}
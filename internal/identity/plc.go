@@ -0,0 +1,78 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultPLCDirectoryURL is the public did:plc directory.
+const DefaultPLCDirectoryURL = "https://plc.directory"
+
+// plcAuditEntry is one operation in a DID's PLC audit log.
+type plcAuditEntry struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Nullified bool      `json:"nullified"`
+}
+
+// PLCResolver resolves did:plc identities using a PLC directory's audit log.
+// The account creation time is the timestamp of the DID's first operation.
+// Other methods, including did:web, have no such history and return
+// ErrUnsupportedMethod.
+type PLCResolver struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewPLCResolver creates a resolver for the directory at baseURL.
+// If client is nil, a client with a 5 second timeout is used.
+func NewPLCResolver(baseURL string, client *http.Client) *PLCResolver {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &PLCResolver{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+	}
+}
+
+// Resolve fetches the audit log for did and returns its creation time.
+func (r *PLCResolver) Resolve(ctx context.Context, did string) (*Account, error) {
+	if !strings.HasPrefix(did, "did:plc:") {
+		return nil, ErrUnsupportedMethod
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+"/"+url.PathEscape(did)+"/log/audit", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build plc request: %w", err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query plc directory: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrDIDNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("plc directory returned status %d", resp.StatusCode)
+	}
+
+	var entries []plcAuditEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode plc audit log: %w", err)
+	}
+
+	// Entries are ordered oldest first; nullified operations never took effect
+	for _, entry := range entries {
+		if !entry.Nullified {
+			return &Account{DID: did, CreatedAt: entry.CreatedAt}, nil
+		}
+	}
+	return nil, ErrDIDNotFound
+}
@@ -0,0 +1,49 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPLCResolver_Resolve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/did:plc:abc123/log/audit":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[
+				{"did":"did:plc:abc123","createdAt":"2024-01-01T00:00:00Z","nullified":true},
+				{"did":"did:plc:abc123","createdAt":"2024-02-01T00:00:00Z","nullified":false},
+				{"did":"did:plc:abc123","createdAt":"2024-03-01T00:00:00Z","nullified":false}
+			]`))
+		case "/did:plc:broken/log/audit":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewPLCResolver(server.URL+"/", nil)
+
+	account, err := resolver.Resolve(context.Background(), "did:plc:abc123")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if want := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC); !account.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v, want first non-nullified operation %v", account.CreatedAt, want)
+	}
+
+	if _, err := resolver.Resolve(context.Background(), "did:plc:missing"); !errors.Is(err, ErrDIDNotFound) {
+		t.Errorf("missing DID error = %v, want ErrDIDNotFound", err)
+	}
+	if _, err := resolver.Resolve(context.Background(), "did:web:example.com"); !errors.Is(err, ErrUnsupportedMethod) {
+		t.Errorf("did:web error = %v, want ErrUnsupportedMethod", err)
+	}
+	if _, err := resolver.Resolve(context.Background(), "did:plc:broken"); err == nil || errors.Is(err, ErrDIDNotFound) {
+		t.Errorf("server error = %v, want a transport error", err)
+	}
+}
@@ -0,0 +1,61 @@
+// Package identity resolves AT Protocol DIDs to account metadata, resolves
+// handles to DIDs, and gates actions on account metadata.
+package identity
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Resolver errors.
+var (
+	ErrDIDNotFound       = errors.New("did not found")
+	ErrUnsupportedMethod = errors.New("unsupported did method")
+)
+
+// Account is the resolved metadata for a DID.
+type Account struct {
+	DID       string
+	CreatedAt time.Time // When the DID was first registered
+}
+
+// Resolver resolves DIDs to account metadata.
+type Resolver interface {
+	// Resolve returns the account for did.
+	// Returns ErrDIDNotFound if the DID is not registered, or
+	// ErrUnsupportedMethod if the resolver cannot handle its DID method.
+	Resolve(ctx context.Context, did string) (*Account, error)
+}
+
+// InMemoryResolver is a Resolver backed by a fixed set of accounts, for
+// development and tests.
+type InMemoryResolver struct {
+	mu       sync.RWMutex
+	accounts map[string]Account
+}
+
+// NewInMemoryResolver creates an empty in-memory resolver.
+func NewInMemoryResolver() *InMemoryResolver {
+	return &InMemoryResolver{accounts: make(map[string]Account)}
+}
+
+// Add registers an account, replacing any existing one with the same DID.
+func (r *InMemoryResolver) Add(account Account) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.accounts[account.DID] = account
+}
+
+// Resolve returns a copy of the registered account for did.
+func (r *InMemoryResolver) Resolve(ctx context.Context, did string) (*Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.accounts[did]
+	if !ok {
+		return nil, ErrDIDNotFound
+	}
+	return &account, nil
+}
@@ -0,0 +1,194 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dead-letter errors.
+var (
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	ErrReplayFailed       = errors.New("replay failed")
+)
+
+// DeadLetter is a Jetstream message that failed validation or persistence.
+// The raw payload is kept so the record can be replayed once the cause is fixed.
+type DeadLetter struct {
+	ID         string    `json:"id"`
+	Payload    []byte    `json:"payload"` // Raw CBOR message
+	DID        string    `json:"did"`
+	Collection string    `json:"collection"`
+	RKey       string    `json:"rkey"`
+	Rev        string    `json:"rev"`
+	Error      string    `json:"error"`    // Most recent failure
+	Attempts   int       `json:"attempts"` // Original attempt plus replays
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// DeadLetterStore persists dead-lettered messages.
+type DeadLetterStore interface {
+	// Add stores a new dead letter, assigning its ID and timestamps.
+	Add(ctx context.Context, letter *DeadLetter) (string, error)
+
+	// Get returns the dead letter with id, or ErrDeadLetterNotFound.
+	Get(ctx context.Context, id string) (*DeadLetter, error)
+
+	// RecordFailure replaces the dead letter's error and counts another attempt.
+	// Returns ErrDeadLetterNotFound if id doesn't exist.
+	RecordFailure(ctx context.Context, id, errMsg string) error
+
+	// Delete removes the dead letter with id, or returns ErrDeadLetterNotFound.
+	Delete(ctx context.Context, id string) error
+}
+
+// PostgresDeadLetterStore implements DeadLetterStore using the indexer_dead_letters table.
+type PostgresDeadLetterStore struct {
+	db *sql.DB
+}
+
+// NewPostgresDeadLetterStore creates a new PostgresDeadLetterStore.
+func NewPostgresDeadLetterStore(db *sql.DB) *PostgresDeadLetterStore {
+	return &PostgresDeadLetterStore{db: db}
+}
+
+// Add inserts a new dead letter.
+func (s *PostgresDeadLetterStore) Add(ctx context.Context, letter *DeadLetter) (string, error) {
+	var id string
+	query := `INSERT INTO indexer_dead_letters (payload, did, collection, rkey, rev, error)
+	          VALUES ($1, $2, $3, $4, $5, $6)
+	          RETURNING id`
+	err := s.db.QueryRowContext(ctx, query,
+		letter.Payload, letter.DID, letter.Collection, letter.RKey, letter.Rev, letter.Error,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("failed to insert dead letter: %w", err)
+	}
+	return id, nil
+}
+
+// Get retrieves a dead letter by ID.
+func (s *PostgresDeadLetterStore) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	var letter DeadLetter
+	query := `SELECT id, payload, did, collection, rkey, rev, error, attempts, created_at, updated_at
+	          FROM indexer_dead_letters WHERE id = $1`
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&letter.ID, &letter.Payload, &letter.DID, &letter.Collection, &letter.RKey, &letter.Rev,
+		&letter.Error, &letter.Attempts, &letter.CreatedAt, &letter.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDeadLetterNotFound
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	return &letter, nil
+}
+
+// RecordFailure updates a dead letter's error and attempt count.
+func (s *PostgresDeadLetterStore) RecordFailure(ctx context.Context, id, errMsg string) error {
+	query := `UPDATE indexer_dead_letters
+	          SET error = $2, attempts = attempts + 1, updated_at = NOW()
+	          WHERE id = $1`
+	return s.execOne(ctx, "update", query, id, errMsg)
+}
+
+// Delete removes a dead letter.
+func (s *PostgresDeadLetterStore) Delete(ctx context.Context, id string) error {
+	return s.execOne(ctx, "delete", `DELETE FROM indexer_dead_letters WHERE id = $1`, id)
+}
+
+// execOne runs a statement expected to affect exactly the row with the given ID.
+func (s *PostgresDeadLetterStore) execOne(ctx context.Context, op, query string, args ...any) error {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to %s dead letter: %w", op, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+// InMemoryDeadLetterStore is a DeadLetterStore for development and tests.
+// Thread-safe via RWMutex.
+type InMemoryDeadLetterStore struct {
+	mu      sync.RWMutex
+	letters map[string]*DeadLetter
+	timeNow func() time.Time // For testability
+}
+
+// NewInMemoryDeadLetterStore creates an empty in-memory dead-letter store.
+func NewInMemoryDeadLetterStore() *InMemoryDeadLetterStore {
+	return &InMemoryDeadLetterStore{
+		letters: make(map[string]*DeadLetter),
+		timeNow: time.Now,
+	}
+}
+
+// Add stores a copy of letter with a new ID.
+func (s *InMemoryDeadLetterStore) Add(ctx context.Context, letter *DeadLetter) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timeNow()
+	stored := *letter
+	stored.ID = uuid.New().String()
+	stored.Payload = append([]byte(nil), letter.Payload...)
+	stored.Attempts = 1
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	s.letters[stored.ID] = &stored
+	return stored.ID, nil
+}
+
+// Get returns a copy of the dead letter with id.
+func (s *InMemoryDeadLetterStore) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letter, ok := s.letters[id]
+	if !ok {
+		return nil, ErrDeadLetterNotFound
+	}
+	letterCopy := *letter
+	letterCopy.Payload = append([]byte(nil), letter.Payload...)
+	return &letterCopy, nil
+}
+
+// RecordFailure updates the dead letter's error and attempt count.
+func (s *InMemoryDeadLetterStore) RecordFailure(ctx context.Context, id, errMsg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letter, ok := s.letters[id]
+	if !ok {
+		return ErrDeadLetterNotFound
+	}
+	letter.Error = errMsg
+	letter.Attempts++
+	letter.UpdatedAt = s.timeNow()
+	return nil
+}
+
+// Delete removes the dead letter with id.
+func (s *InMemoryDeadLetterStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.letters[id]; !ok {
+		return ErrDeadLetterNotFound
+	}
+	delete(s.letters, id)
+	return nil
+}
@@ -0,0 +1,146 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// flakyRecordRepository fails upserts while failing is set.
+type flakyRecordRepository struct {
+	*InMemoryRecordRepository
+	failing bool
+}
+
+func (r *flakyRecordRepository) UpsertRecord(ctx context.Context, record *FilterResult) (string, bool, error) {
+	if r.failing {
+		return "", false, errors.New("database unavailable")
+	}
+	return r.InMemoryRecordRepository.UpsertRecord(ctx, record)
+}
+
+// newDeadLetterTest creates a processor whose repository fails upserts, runs
+// one scene record through it, and returns the resulting dead letter's ID.
+func newDeadLetterTest(t *testing.T) (*Processor, *flakyRecordRepository, *InMemoryDeadLetterStore, string) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := &flakyRecordRepository{InMemoryRecordRepository: NewInMemoryRecordRepository(logger), failing: true}
+	store := NewInMemoryDeadLetterStore()
+	processor := NewProcessor(NewRecordFilter(NewFilterMetrics()), repo, NewInMemorySequenceTracker(logger), NewMetrics(), logger)
+	processor.SetDeadLetterStore(store)
+
+	if err := processor.Handle(context.Background(), encodeCommit(t, "create", "scene1", "rev1")); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if len(store.letters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(store.letters))
+	}
+	for id := range store.letters {
+		return processor, repo, store, id
+	}
+	return nil, nil, nil, ""
+}
+
+func postReplay(handler http.Handler, id, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/admin/indexer/deadletter/"+id+"/replay", nil)
+	if token != "" {
+		req.Header.Set("X-Internal-Token", token)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeadLetterReplay_Success(t *testing.T) {
+	processor, repo, store, id := newDeadLetterTest(t)
+	handler := InternalAuthMiddleware("secret")(DeadLetterReplayHandler(processor))
+
+	letter, _ := store.Get(context.Background(), id)
+	if letter.Error != "database unavailable" || letter.Collection != "app.subcult.scene" {
+		t.Errorf("unexpected dead letter: %+v", letter)
+	}
+
+	// The cause is fixed; replay ingests the record and clears the dead letter
+	repo.failing = false
+	w := postReplay(handler, id, "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response ReplayResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Status != "replayed" {
+		t.Errorf("expected status replayed, got %+v", response)
+	}
+	if _, err := store.Get(context.Background(), id); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Errorf("expected dead letter to be removed, got %v", err)
+	}
+	if processed, _ := repo.CheckIdempotencyKey(context.Background(), generateIdempotencyKey("did:plc:correlate", "app.subcult.scene", "scene1", "rev1")); !processed {
+		t.Error("expected record to be ingested")
+	}
+
+	// Replaying a dead letter for an already-ingested record is a no-op success
+	replayID, err := store.Add(context.Background(), &DeadLetter{Payload: encodeCommit(t, "create", "scene1", "rev1"), Error: "stale"})
+	if err != nil {
+		t.Fatalf("failed to add dead letter: %v", err)
+	}
+	if w := postReplay(handler, replayID, "secret"); w.Code != http.StatusOK {
+		t.Errorf("expected idempotent replay to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := postReplay(handler, id, "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for replayed dead letter, got %d", w.Code)
+	}
+}
+
+func TestDeadLetterReplay_StillFailing(t *testing.T) {
+	processor, _, store, id := newDeadLetterTest(t)
+	handler := InternalAuthMiddleware("secret")(DeadLetterReplayHandler(processor))
+	replayedAt := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+	store.timeNow = func() time.Time { return replayedAt }
+
+	w := postReplay(handler, id, "secret")
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d: %s", w.Code, w.Body.String())
+	}
+	var response ReplayResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Status != "failed" || response.Error == "" {
+		t.Errorf("expected failed status with error, got %+v", response)
+	}
+
+	letter, err := store.Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("expected dead letter to be kept, got %v", err)
+	}
+	if letter.Attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", letter.Attempts)
+	}
+	if !letter.UpdatedAt.Equal(replayedAt) {
+		t.Errorf("expected updated_at %v, got %v", replayedAt, letter.UpdatedAt)
+	}
+}
+
+func TestDeadLetterReplay_RequiresInternalToken(t *testing.T) {
+	processor, repo, store, id := newDeadLetterTest(t)
+	handler := InternalAuthMiddleware("secret")(DeadLetterReplayHandler(processor))
+	repo.failing = false
+
+	for _, token := range []string{"", "wrong"} {
+		if w := postReplay(handler, id, token); w.Code != http.StatusForbidden {
+			t.Errorf("token %q: expected status 403, got %d", token, w.Code)
+		}
+	}
+	if _, err := store.Get(context.Background(), id); err != nil {
+		t.Errorf("expected rejected replay to leave dead letter untouched, got %v", err)
+	}
+}
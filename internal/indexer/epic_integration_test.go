@@ -128,7 +128,7 @@ func TestJetstreamIndexerEpic_EndToEnd(t *testing.T) {
 		// A different revision is tracked separately via idempotency, but uses the same record ID.
 
 		// Verify deletion atomicity
-		err = repo.DeleteRecord(ctx, record.DID, record.Collection, record.RKey)
+		err = repo.DeleteRecord(ctx, record.DID, record.Collection, record.RKey, "")
 		if err != nil {
 			t.Fatalf("Delete failed: %v", err)
 		}
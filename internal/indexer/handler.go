@@ -4,7 +4,10 @@ package indexer
 
 import (
 	"crypto/subtle"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -40,3 +43,57 @@ func InternalAuthMiddleware(token string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// deadLetterReplayPrefix is the path prefix for dead-letter replay requests.
+const deadLetterReplayPrefix = "/admin/indexer/deadletter/"
+
+// ReplayResponse is the response body for a dead-letter replay.
+type ReplayResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`          // "replayed" or "failed"
+	Error  string `json:"error,omitempty"` // Why the replay failed
+}
+
+// DeadLetterReplayHandler handles POST /admin/indexer/deadletter/{id}/replay.
+// It responds 200 when the record was ingested (or already had been), 422 when
+// it still fails and stays dead-lettered, and 404 for an unknown ID.
+// Callers must wrap it in InternalAuthMiddleware; it performs no auth itself.
+func DeadLetterReplayHandler(processor *Processor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, deadLetterReplayPrefix), "/")
+		if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] != "replay" {
+			http.NotFound(w, r)
+			return
+		}
+		id := pathParts[0]
+
+		response := ReplayResponse{ID: id, Status: "replayed"}
+		status := http.StatusOK
+		if err := processor.Replay(r.Context(), id); err != nil {
+			switch {
+			case errors.Is(err, ErrDeadLetterNotFound):
+				http.Error(w, "Dead letter not found", http.StatusNotFound)
+				return
+			case errors.Is(err, ErrReplayFailed):
+				response.Status = "failed"
+				response.Error = err.Error()
+				status = http.StatusUnprocessableEntity
+			default:
+				processor.logger.Error("failed to replay dead letter", "error", err, "dead_letter_id", id)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			processor.logger.Error("failed to encode replay response", "error", err)
+		}
+	})
+}
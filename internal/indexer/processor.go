@@ -0,0 +1,302 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// processingIDKey is the context key for a message's processing ID.
+type processingIDKey struct{}
+
+// processingIDLength is how many hex characters of the idempotency key are used
+// as a processing ID.
+const processingIDLength = 16
+
+// WithProcessingID returns a context carrying the processing ID used to
+// correlate log lines for one Jetstream message.
+func WithProcessingID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, processingIDKey{}, id)
+}
+
+// GetProcessingID returns the processing ID carried by ctx, or "" if none.
+func GetProcessingID(ctx context.Context) string {
+	if id, ok := ctx.Value(processingIDKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// loggerWithProcessingID tags logger with the processing ID carried by ctx.
+func loggerWithProcessingID(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	if id := GetProcessingID(ctx); id != "" {
+		return logger.With(slog.String("processing_id", id))
+	}
+	return logger
+}
+
+// processingIDFor derives a processing ID for a filtered message. Records with
+// an identity use a prefix of their idempotency key, so replays of the same
+// revision share an ID; anything else gets a random one.
+func processingIDFor(result FilterResult) string {
+	if result.Matched && result.DID != "" && result.Collection != "" && result.RKey != "" {
+		return generateIdempotencyKey(result.DID, result.Collection, result.RKey, result.Rev)[:processingIDLength]
+	}
+	return uuid.New().String()
+}
+
+// Processor filters Jetstream messages and persists matching records, tagging
+// every log line and span for a message with the same processing ID.
+type Processor struct {
+	filter          *RecordFilter
+	repo            RecordRepository
+	sequenceTracker SequenceTracker
+	metrics         *Metrics
+	logger          *slog.Logger
+	deadLetters     DeadLetterStore // Optional; failed records are dropped without one
+}
+
+// NewProcessor creates a new message processor.
+func NewProcessor(filter *RecordFilter, repo RecordRepository, sequenceTracker SequenceTracker, metrics *Metrics, logger *slog.Logger) *Processor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Processor{
+		filter:          filter,
+		repo:            repo,
+		sequenceTracker: sequenceTracker,
+		metrics:         metrics,
+		logger:          logger,
+	}
+}
+
+// SetDeadLetterStore installs the store that receives messages that fail
+// validation or persistence, so they can be replayed later.
+func (p *Processor) SetDeadLetterStore(store DeadLetterStore) {
+	p.deadLetters = store
+}
+
+// Handle processes one Jetstream message with transactional persistence and
+// sequence tracking. Per-record failures are logged and counted but never
+// returned, so one bad record doesn't stop the stream.
+func (p *Processor) Handle(ctx context.Context, payload []byte) error {
+	start := time.Now()
+
+	ctx, endSpan := tracing.StartSpan(ctx, "indexer.process_message")
+	var spanErr error
+	defer func() { endSpan(spanErr) }()
+
+	// Decode CBOR message to extract timestamp for lag calculation
+	msg, decodeErr := DecodeCBORMessage(payload)
+
+	// Filter and validate the record
+	result := p.filter.FilterCBOR(payload)
+	p.metrics.IncMessagesProcessed()
+
+	processingID := processingIDFor(result)
+	ctx = WithProcessingID(ctx, processingID)
+	logger := loggerWithProcessingID(ctx, p.logger)
+	tracing.SetAttributes(ctx,
+		attribute.String("processing_id", processingID),
+		attribute.String("collection", result.Collection),
+		attribute.String("operation", result.Operation),
+	)
+
+	if decodeErr != nil {
+		// Log error with context but don't fail - continue processing
+		logger.Debug("failed to decode message for lag calculation",
+			slog.String("error", decodeErr.Error()))
+	} else if msg != nil && msg.TimeUS > 0 {
+		// Calculate processing lag: current time - message timestamp
+		messageTime := time.Unix(0, msg.TimeUS*1000) // Convert microseconds to nanoseconds
+		lag := time.Since(messageTime)
+		p.metrics.SetProcessingLag(lag.Seconds())
+
+		logger.Debug("processing message",
+			slog.String("kind", msg.Kind),
+			slog.Duration("lag", lag))
+	}
+
+	logger.Debug("record filtered",
+		slog.Bool("matched", result.Matched),
+		slog.Bool("valid", result.Valid),
+		slog.String("collection", result.Collection),
+		slog.String("did", result.DID),
+		slog.String("rkey", result.RKey))
+
+	// If record doesn't match our lexicon, skip (but still update sequence)
+	if !result.Matched {
+		p.updateSequence(ctx, logger, msg, slog.LevelWarn, "for non-matched record")
+		return nil
+	}
+
+	// If record failed validation, log and increment error counter
+	if !result.Valid {
+		p.metrics.IncMessagesError()
+		logger.Warn("record validation failed",
+			slog.String("collection", result.Collection),
+			slog.String("did", result.DID),
+			slog.String("rkey", result.RKey),
+			slog.String("error", result.Error.Error()))
+		p.deadLetter(ctx, logger, payload, result, result.Error)
+		// Update sequence even for invalid records to avoid re-processing
+		p.updateSequence(ctx, logger, msg, slog.LevelWarn, "for invalid record")
+		return nil // Don't fail the entire stream for validation errors
+	}
+
+	// Handle delete operations
+	if result.Operation == "delete" {
+		if err := p.repo.DeleteRecord(ctx, result.DID, result.Collection, result.RKey, result.Rev); err != nil {
+			spanErr = err
+			p.metrics.IncDatabaseWritesFailed()
+			logger.Error("failed to delete record",
+				slog.String("collection", result.Collection),
+				slog.String("did", result.DID),
+				slog.String("rkey", result.RKey),
+				slog.String("error", err.Error()))
+			p.deadLetter(ctx, logger, payload, result, err)
+			return nil // Don't fail stream on delete errors
+		}
+		logger.Info("record deleted",
+			slog.String("collection", result.Collection),
+			slog.String("did", result.DID),
+			slog.String("rkey", result.RKey))
+
+		// Update sequence after successful delete
+		p.updateSequence(ctx, logger, msg, slog.LevelError, "after delete")
+		return nil
+	}
+
+	// Upsert record with transaction support
+	recordID, isNew, err := p.repo.UpsertRecord(ctx, &result)
+	if err != nil {
+		spanErr = err
+		p.metrics.IncDatabaseWritesFailed()
+		logger.Error("failed to upsert record",
+			slog.String("collection", result.Collection),
+			slog.String("did", result.DID),
+			slog.String("rkey", result.RKey),
+			slog.String("error", err.Error()))
+		p.deadLetter(ctx, logger, payload, result, err)
+		return nil // Don't fail stream on upsert errors
+	}
+
+	// If record was skipped due to idempotency, don't count as upsert
+	if recordID == "" {
+		logger.Debug("record skipped (idempotent)",
+			slog.String("collection", result.Collection),
+			slog.String("did", result.DID),
+			slog.String("rkey", result.RKey))
+		// Update sequence even for idempotent records to avoid re-processing
+		p.updateSequence(ctx, logger, msg, slog.LevelWarn, "for idempotent record")
+		return nil
+	}
+
+	// Record successful upsert
+	p.metrics.IncUpserts()
+	logger.Info("record upserted",
+		slog.String("record_id", recordID),
+		slog.String("collection", result.Collection),
+		slog.String("did", result.DID),
+		slog.String("rkey", result.RKey),
+		slog.Bool("is_new", isNew))
+
+	// Record ingestion latency
+	p.metrics.ObserveIngestLatency(time.Since(start).Seconds())
+
+	// Update sequence after successful processing
+	p.updateSequence(ctx, logger, msg, slog.LevelError, "after upsert")
+	return nil
+}
+
+// updateSequence records the message's cursor so it isn't re-processed after a
+// restart, logging failures at level with the given message suffix.
+func (p *Processor) updateSequence(ctx context.Context, logger *slog.Logger, msg *JetstreamMessage, level slog.Level, suffix string) {
+	if msg == nil || msg.TimeUS <= 0 {
+		return
+	}
+	if err := p.sequenceTracker.UpdateSequence(ctx, msg.TimeUS); err != nil {
+		logger.Log(ctx, level, "failed to update sequence "+suffix,
+			slog.String("error", err.Error()))
+	}
+}
+
+// deadLetter stores a message that failed validation or persistence.
+func (p *Processor) deadLetter(ctx context.Context, logger *slog.Logger, payload []byte, result FilterResult, cause error) {
+	if p.deadLetters == nil {
+		return
+	}
+	id, err := p.deadLetters.Add(ctx, &DeadLetter{
+		Payload:    payload,
+		DID:        result.DID,
+		Collection: result.Collection,
+		RKey:       result.RKey,
+		Rev:        result.Rev,
+		Error:      cause.Error(),
+	})
+	if err != nil {
+		logger.Error("failed to dead-letter record",
+			slog.String("error", err.Error()))
+		return
+	}
+	logger.Warn("record dead-lettered",
+		slog.String("dead_letter_id", id))
+}
+
+// Replay re-runs a dead-lettered message through filter, validation and
+// persistence. On success the dead letter is removed; on failure it is kept with
+// the new error and ErrReplayFailed is returned. Replaying a record that has
+// already been ingested is a no-op success, since upserts are idempotent.
+// Returns ErrDeadLetterNotFound if id doesn't exist.
+func (p *Processor) Replay(ctx context.Context, id string) error {
+	if p.deadLetters == nil {
+		return ErrDeadLetterNotFound
+	}
+	letter, err := p.deadLetters.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ctx, endSpan := tracing.StartSpan(ctx, "indexer.replay_dead_letter")
+	var spanErr error
+	defer func() { endSpan(spanErr) }()
+
+	result := p.filter.FilterCBOR(letter.Payload)
+	ctx = WithProcessingID(ctx, processingIDFor(result))
+	logger := loggerWithProcessingID(ctx, p.logger).With(slog.String("dead_letter_id", id))
+
+	switch {
+	case !result.Matched:
+		spanErr = errors.New("record no longer matches a subcult collection")
+	case !result.Valid:
+		spanErr = result.Error
+	case result.Operation == "delete":
+		spanErr = p.repo.DeleteRecord(ctx, result.DID, result.Collection, result.RKey, result.Rev)
+	default:
+		_, _, spanErr = p.repo.UpsertRecord(ctx, &result)
+	}
+
+	if spanErr != nil {
+		logger.Warn("dead letter replay failed",
+			slog.String("error", spanErr.Error()))
+		if err := p.deadLetters.RecordFailure(ctx, id, spanErr.Error()); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrReplayFailed, spanErr)
+	}
+
+	if err := p.deadLetters.Delete(ctx, id); err != nil {
+		return err
+	}
+	logger.Info("dead letter replayed",
+		slog.String("collection", result.Collection),
+		slog.String("did", result.DID),
+		slog.String("rkey", result.RKey))
+	return nil
+}
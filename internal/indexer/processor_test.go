@@ -0,0 +1,128 @@
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// newProcessorTest creates a processor whose logs (from both the processor and
+// its in-memory repository) are written as JSON lines to the returned buffer.
+func newProcessorTest(t *testing.T) (*Processor, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	processor := NewProcessor(
+		NewRecordFilter(NewFilterMetrics()),
+		NewInMemoryRecordRepository(logger),
+		NewInMemorySequenceTracker(logger),
+		NewMetrics(),
+		logger,
+	)
+	return processor, &buf
+}
+
+// encodeCommit builds a CBOR Jetstream commit message for a scene record.
+func encodeCommit(t *testing.T, operation, rkey, rev string) []byte {
+	t.Helper()
+	commit := &AtProtoCommit{
+		DID:        "did:plc:correlate",
+		Rev:        rev,
+		Operation:  operation,
+		Collection: "app.subcult.scene",
+		RKey:       rkey,
+	}
+	if operation != "delete" {
+		record, err := EncodeCBOR(map[string]interface{}{"name": "Correlated Scene"})
+		if err != nil {
+			t.Fatalf("failed to encode record: %v", err)
+		}
+		commit.Record = record
+	}
+	payload, err := EncodeCBOR(JetstreamMessage{DID: commit.DID, TimeUS: 1234567890, Kind: "commit", Commit: commit})
+	if err != nil {
+		t.Fatalf("failed to encode message: %v", err)
+	}
+	return payload
+}
+
+// processingIDsByMessage parses JSON log lines and maps each message to its processing_id.
+func processingIDsByMessage(t *testing.T, buf *bytes.Buffer) map[string]string {
+	t.Helper()
+	ids := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("failed to parse log line %q: %v", line, err)
+		}
+		id, _ := entry["processing_id"].(string)
+		ids[entry["msg"].(string)] = id
+	}
+	buf.Reset()
+	return ids
+}
+
+func TestProcessor_CorrelatesFilterAndUpsertLogs(t *testing.T) {
+	processor, buf := newProcessorTest(t)
+
+	if err := processor.Handle(context.Background(), encodeCommit(t, "create", "scene1", "rev1")); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	ids := processingIDsByMessage(t, buf)
+	want := generateIdempotencyKey("did:plc:correlate", "app.subcult.scene", "scene1", "rev1")[:processingIDLength]
+	for _, msg := range []string{"record filtered", "record upserted in memory", "record upserted"} {
+		got, ok := ids[msg]
+		if !ok {
+			t.Errorf("expected log line %q, got %v", msg, ids)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: processing_id = %q, want %q", msg, got, want)
+		}
+	}
+
+	// A different revision of the same record is a different unit of work
+	if err := processor.Handle(context.Background(), encodeCommit(t, "delete", "scene1", "rev2")); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	ids = processingIDsByMessage(t, buf)
+	deleteID := ids["record deleted"]
+	if deleteID == "" || deleteID == want {
+		t.Errorf("expected a distinct processing_id for the delete, got %q", deleteID)
+	}
+	if ids["record deleted from memory"] != deleteID || ids["record filtered"] != deleteID {
+		t.Errorf("expected delete log lines to share processing_id %q, got %v", deleteID, ids)
+	}
+}
+
+func TestProcessor_UnmatchedMessageGetsGeneratedID(t *testing.T) {
+	processor, buf := newProcessorTest(t)
+
+	payload, err := EncodeCBOR(JetstreamMessage{DID: "did:plc:other", TimeUS: 1234567890, Kind: "identity"})
+	if err != nil {
+		t.Fatalf("failed to encode message: %v", err)
+	}
+
+	if err := processor.Handle(context.Background(), payload); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	ids := processingIDsByMessage(t, buf)
+	if ids["record filtered"] == "" {
+		t.Errorf("expected generated processing_id on filter log line, got %v", ids)
+	}
+}
+
+func TestGetProcessingID(t *testing.T) {
+	if id := GetProcessingID(context.Background()); id != "" {
+		t.Errorf("expected empty processing ID, got %q", id)
+	}
+	ctx := WithProcessingID(context.Background(), "abc123")
+	if id := GetProcessingID(ctx); id != "abc123" {
+		t.Errorf("GetProcessingID() = %q, want %q", id, "abc123")
+	}
+}
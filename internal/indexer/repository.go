@@ -32,8 +32,10 @@ type RecordRepository interface {
 	// Returns the record ID and a boolean indicating if it was newly created (true) or updated (false).
 	UpsertRecord(ctx context.Context, record *FilterResult) (string, bool, error)
 
-	// DeleteRecord atomically removes a record.
-	DeleteRecord(ctx context.Context, did, collection, rkey string) error
+	// DeleteRecord atomically removes a record. A delete whose rev is older than the
+	// latest applied update is ignored, so edits win over stale deletes regardless of
+	// arrival order. An empty rev is always applied.
+	DeleteRecord(ctx context.Context, did, collection, rkey, rev string) error
 
 	// CheckIdempotencyKey verifies if an operation has already been processed.
 	CheckIdempotencyKey(ctx context.Context, key string) (bool, error)
@@ -56,6 +58,11 @@ func NewPostgresRecordRepository(db *sql.DB, logger *slog.Logger) *PostgresRecor
 	}
 }
 
+// loggerFor returns the repository logger tagged with the processing ID carried by ctx.
+func (r *PostgresRecordRepository) loggerFor(ctx context.Context) *slog.Logger {
+	return loggerWithProcessingID(ctx, r.logger)
+}
+
 // UpsertRecord atomically inserts or updates a record with full transaction support.
 // This implements the all-or-nothing requirement with idempotency.
 func (r *PostgresRecordRepository) UpsertRecord(ctx context.Context, record *FilterResult) (string, bool, error) {
@@ -89,7 +96,7 @@ func (r *PostgresRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 		Isolation: sql.LevelReadCommitted,
 	})
 	if err != nil {
-		r.logger.Error("failed to begin transaction",
+		r.loggerFor(ctx).Error("failed to begin transaction",
 			slog.String("error", err.Error()),
 			slog.String("did", record.DID),
 			slog.String("collection", record.Collection),
@@ -101,7 +108,7 @@ func (r *PostgresRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 	// Always attempt rollback on function exit (no-op after successful commit)
 	defer func() {
 		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
-			r.logger.Warn("failed to rollback transaction",
+			r.loggerFor(ctx).Warn("failed to rollback transaction",
 				slog.String("error", err.Error()))
 		}
 	}()
@@ -116,12 +123,12 @@ func (r *PostgresRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 	if err == nil {
 		// Record already processed, commit and return existing state
 		if err := tx.Commit(); err != nil {
-			r.logger.Error("failed to commit idempotency check",
+			r.loggerFor(ctx).Error("failed to commit idempotency check",
 				slog.String("error", err.Error()))
 			endSpan(err)
 			return "", false, fmt.Errorf("failed to commit: %w", err)
 		}
-		r.logger.Debug("skipping duplicate record (already processed)",
+		r.loggerFor(ctx).Debug("skipping duplicate record (already processed)",
 			slog.String("idempotency_key", idempotencyKey),
 			slog.String("did", record.DID),
 			slog.String("collection", record.Collection),
@@ -131,12 +138,36 @@ func (r *PostgresRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 		return "", false, nil // Not an error, just already processed
 	} else if err != sql.ErrNoRows {
 		// Unexpected error
-		r.logger.Error("failed to check idempotency",
+		r.loggerFor(ctx).Error("failed to check idempotency",
 			slog.String("error", err.Error()))
 		endSpan(err)
 		return "", false, fmt.Errorf("failed to check idempotency: %w", err)
 	}
 
+	// Skip updates older than a delete that has already been applied
+	latest, err := selectRecordRevision(ctx, tx, record.DID, record.Collection, record.RKey)
+	if err != nil {
+		r.loggerFor(ctx).Error("failed to check record revision",
+			slog.String("error", err.Error()))
+		endSpan(err)
+		return "", false, err
+	}
+	if latest.deleted && revIsOlder(record.Rev, latest.rev) {
+		if err := tx.Commit(); err != nil {
+			endSpan(err)
+			return "", false, fmt.Errorf("failed to commit: %w", err)
+		}
+		r.loggerFor(ctx).Info("skipping update older than applied delete",
+			slog.String("did", record.DID),
+			slog.String("collection", record.Collection),
+			slog.String("rkey", record.RKey),
+			slog.String("rev", record.Rev),
+			slog.String("delete_rev", latest.rev))
+		tracing.AddEvent(ctx, "stale_update_skipped")
+		endSpan(nil)
+		return "", false, nil
+	}
+
 	// Route to appropriate table based on collection
 	var recordID string
 	var isNew bool
@@ -157,7 +188,7 @@ func (r *PostgresRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 	}
 
 	if err != nil {
-		r.logger.Error("failed to upsert record",
+		r.loggerFor(ctx).Error("failed to upsert record",
 			slog.String("error", err.Error()),
 			slog.String("collection", record.Collection))
 		endSpan(err)
@@ -171,22 +202,29 @@ func (r *PostgresRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 	`
 	_, err = tx.ExecContext(ctx, insertIdempotency, idempotencyKey, record.DID, record.Collection, record.RKey, record.Rev, recordID)
 	if err != nil {
-		r.logger.Error("failed to store idempotency key",
+		r.loggerFor(ctx).Error("failed to store idempotency key",
 			slog.String("error", err.Error()))
 		endSpan(err)
 		return "", false, fmt.Errorf("failed to store idempotency key: %w", err)
 	}
 
+	if err := storeRecordRevision(ctx, tx, record.DID, record.Collection, record.RKey, record.Rev, false); err != nil {
+		r.loggerFor(ctx).Error("failed to store record revision",
+			slog.String("error", err.Error()))
+		endSpan(err)
+		return "", false, err
+	}
+
 	// Commit transaction
 	if err = tx.Commit(); err != nil {
-		r.logger.Error("failed to commit transaction",
+		r.loggerFor(ctx).Error("failed to commit transaction",
 			slog.String("error", err.Error()))
 		endSpan(err)
 		return "", false, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	// Log successful transaction
-	r.logger.Info("record upserted successfully",
+	r.loggerFor(ctx).Info("record upserted successfully",
 		slog.String("record_id", recordID),
 		slog.String("collection", record.Collection),
 		slog.String("did", record.DID),
@@ -209,7 +247,9 @@ func (r *PostgresRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 // is replayed from Jetstream, it will be correctly skipped due to the existing
 // idempotency key. This protects against accidental re-ingestion of deleted content.
 // Uses soft delete (UPDATE ... SET deleted_at = NOW()) to match repository patterns.
-func (r *PostgresRecordRepository) DeleteRecord(ctx context.Context, did, collection, rkey string) error {
+// A delete older than the latest applied update is ignored; otherwise its rev is
+// recorded so that older updates arriving afterwards are skipped.
+func (r *PostgresRecordRepository) DeleteRecord(ctx context.Context, did, collection, rkey, rev string) error {
 	// Start tracing span
 	ctx, endSpan := tracing.StartDBSpan(ctx, collection, tracing.DBOperationDelete)
 	defer func() {
@@ -231,7 +271,7 @@ func (r *PostgresRecordRepository) DeleteRecord(ctx context.Context, did, collec
 		Isolation: sql.LevelReadCommitted,
 	})
 	if err != nil {
-		r.logger.Error("failed to begin transaction for delete",
+		r.loggerFor(ctx).Error("failed to begin transaction for delete",
 			slog.String("error", err.Error()))
 		endSpan(err)
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -240,7 +280,7 @@ func (r *PostgresRecordRepository) DeleteRecord(ctx context.Context, did, collec
 	// Always attempt rollback on function exit (no-op after successful commit)
 	defer func() {
 		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
-			r.logger.Warn("failed to rollback delete transaction",
+			r.loggerFor(ctx).Warn("failed to rollback delete transaction",
 				slog.String("error", err.Error()))
 		}
 	}()
@@ -262,9 +302,32 @@ func (r *PostgresRecordRepository) DeleteRecord(ctx context.Context, did, collec
 		return err
 	}
 
+	latest, err := selectRecordRevision(ctx, tx, did, collection, rkey)
+	if err != nil {
+		r.loggerFor(ctx).Error("failed to check record revision",
+			slog.String("error", err.Error()))
+		endSpan(err)
+		return err
+	}
+	if !latest.deleted && revIsOlder(rev, latest.rev) {
+		if err := tx.Commit(); err != nil {
+			endSpan(err)
+			return fmt.Errorf("failed to commit: %w", err)
+		}
+		r.loggerFor(ctx).Info("ignoring delete older than applied update",
+			slog.String("collection", collection),
+			slog.String("did", did),
+			slog.String("rkey", rkey),
+			slog.String("rev", rev),
+			slog.String("update_rev", latest.rev))
+		tracing.AddEvent(ctx, "stale_delete_ignored")
+		endSpan(nil)
+		return nil
+	}
+
 	result, err := tx.ExecContext(ctx, query, did, rkey)
 	if err != nil {
-		r.logger.Error("failed to delete record",
+		r.loggerFor(ctx).Error("failed to delete record",
 			slog.String("error", err.Error()))
 		endSpan(err)
 		return fmt.Errorf("failed to delete record: %w", err)
@@ -272,15 +335,24 @@ func (r *PostgresRecordRepository) DeleteRecord(ctx context.Context, did, collec
 
 	rowsAffected, _ := result.RowsAffected()
 
+	// Record the delete even if no row exists yet, so an older create arriving
+	// later does not resurrect the record
+	if err := storeRecordRevision(ctx, tx, did, collection, rkey, rev, true); err != nil {
+		r.loggerFor(ctx).Error("failed to store record revision",
+			slog.String("error", err.Error()))
+		endSpan(err)
+		return err
+	}
+
 	// Commit transaction
 	if err = tx.Commit(); err != nil {
-		r.logger.Error("failed to commit delete transaction",
+		r.loggerFor(ctx).Error("failed to commit delete transaction",
 			slog.String("error", err.Error()))
 		endSpan(err)
 		return fmt.Errorf("failed to commit: %w", err)
 	}
 
-	r.logger.Info("record soft-deleted successfully",
+	r.loggerFor(ctx).Info("record soft-deleted successfully",
 		slog.String("collection", collection),
 		slog.String("did", did),
 		slog.String("rkey", rkey),
@@ -818,6 +890,59 @@ func (r *PostgresRecordRepository) upsertAlliance(ctx context.Context, tx *sql.T
 	return existingID, false, nil
 }
 
+// recordRevision is the latest revision applied to a record and whether it was a delete.
+type recordRevision struct {
+	rev     string
+	deleted bool
+}
+
+// revIsOlder reports whether rev is known to precede than. AT Protocol revs are
+// TIDs, which sort lexicographically in commit order; an empty rev is unknown
+// and never considered older.
+func revIsOlder(rev, than string) bool {
+	return rev != "" && than != "" && rev < than
+}
+
+// maxRev returns the later of two revs, treating an empty rev as unknown.
+func maxRev(a, b string) string {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// selectRecordRevision loads and locks the latest applied revision for a record.
+// Returns the zero value if nothing has been applied yet.
+func selectRecordRevision(ctx context.Context, tx *sql.Tx, did, collection, rkey string) (recordRevision, error) {
+	var latest recordRevision
+	query := `
+		SELECT rev, deleted FROM record_revisions
+		WHERE did = $1 AND collection = $2 AND rkey = $3
+		FOR UPDATE
+	`
+	err := tx.QueryRowContext(ctx, query, did, collection, rkey).Scan(&latest.rev, &latest.deleted)
+	if err != nil && err != sql.ErrNoRows {
+		return recordRevision{}, fmt.Errorf("failed to load record revision: %w", err)
+	}
+	return latest, nil
+}
+
+// storeRecordRevision records an applied upsert or delete, keeping the highest rev seen.
+func storeRecordRevision(ctx context.Context, tx *sql.Tx, did, collection, rkey, rev string, deleted bool) error {
+	query := `
+		INSERT INTO record_revisions (did, collection, rkey, rev, deleted, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (did, collection, rkey) DO UPDATE SET
+			rev = GREATEST(record_revisions.rev, EXCLUDED.rev),
+			deleted = EXCLUDED.deleted,
+			updated_at = NOW()
+	`
+	if _, err := tx.ExecContext(ctx, query, did, collection, rkey, rev, deleted); err != nil {
+		return fmt.Errorf("failed to store record revision: %w", err)
+	}
+	return nil
+}
+
 // generateIdempotencyKey creates a deterministic key from record metadata.
 // Format: SHA256(did + "\x00" + collection + "\x00" + rkey + "\x00" + rev)
 // Uses NUL (\x00) separators to ensure unambiguous parsing (components cannot contain NUL bytes).
@@ -842,6 +967,7 @@ type InMemoryRecordRepository struct {
 	records         map[string]*FilterResult
 	recordIDs       map[string]string // Maps composite key to stable record ID
 	idempotencyKeys map[string]bool
+	revisions       map[string]recordRevision // Maps composite key to latest applied revision
 	logger          *slog.Logger
 }
 
@@ -854,10 +980,16 @@ func NewInMemoryRecordRepository(logger *slog.Logger) *InMemoryRecordRepository
 		records:         make(map[string]*FilterResult),
 		recordIDs:       make(map[string]string),
 		idempotencyKeys: make(map[string]bool),
+		revisions:       make(map[string]recordRevision),
 		logger:          logger,
 	}
 }
 
+// loggerFor returns the repository logger tagged with the processing ID carried by ctx.
+func (r *InMemoryRecordRepository) loggerFor(ctx context.Context) *slog.Logger {
+	return loggerWithProcessingID(ctx, r.logger)
+}
+
 // UpsertRecord implements the interface for in-memory storage.
 func (r *InMemoryRecordRepository) UpsertRecord(ctx context.Context, record *FilterResult) (string, bool, error) {
 	if !record.Valid || !record.Matched {
@@ -871,7 +1003,7 @@ func (r *InMemoryRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 
 	// Check idempotency
 	if r.idempotencyKeys[idempotencyKey] {
-		r.logger.Debug("skipping duplicate record",
+		r.loggerFor(ctx).Debug("skipping duplicate record",
 			slog.String("idempotency_key", idempotencyKey))
 		return "", false, nil
 	}
@@ -879,6 +1011,15 @@ func (r *InMemoryRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 	// Generate composite key
 	key := fmt.Sprintf("%s:%s:%s", record.DID, record.Collection, record.RKey)
 
+	// Skip updates older than a delete that has already been applied
+	latest := r.revisions[key]
+	if latest.deleted && revIsOlder(record.Rev, latest.rev) {
+		r.loggerFor(ctx).Info("skipping update older than applied delete",
+			slog.String("rev", record.Rev),
+			slog.String("delete_rev", latest.rev))
+		return "", false, nil
+	}
+
 	// Check if record exists and get/create stable ID
 	recordID, exists := r.recordIDs[key]
 	if !exists {
@@ -893,8 +1034,9 @@ func (r *InMemoryRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 	}
 	r.records[key] = &copyRecord
 	r.idempotencyKeys[idempotencyKey] = true
+	r.revisions[key] = recordRevision{rev: maxRev(latest.rev, record.Rev)}
 
-	r.logger.Info("record upserted in memory",
+	r.loggerFor(ctx).Info("record upserted in memory",
 		slog.String("record_id", recordID),
 		slog.Bool("is_new", !exists))
 
@@ -904,13 +1046,23 @@ func (r *InMemoryRecordRepository) UpsertRecord(ctx context.Context, record *Fil
 // DeleteRecord implements the interface for in-memory storage.
 // Note: Idempotency keys are NOT cleaned up on delete, consistent with Postgres behavior.
 // This prevents re-ingestion of deleted records if the same revision is replayed.
-func (r *InMemoryRecordRepository) DeleteRecord(ctx context.Context, did, collection, rkey string) error {
+// Deletes older than the latest applied update are ignored, matching Postgres.
+func (r *InMemoryRecordRepository) DeleteRecord(ctx context.Context, did, collection, rkey, rev string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	key := fmt.Sprintf("%s:%s:%s", did, collection, rkey)
+	latest := r.revisions[key]
+	if !latest.deleted && revIsOlder(rev, latest.rev) {
+		r.loggerFor(ctx).Info("ignoring delete older than applied update",
+			slog.String("rev", rev),
+			slog.String("update_rev", latest.rev))
+		return nil
+	}
+
 	delete(r.records, key)
-	r.logger.Info("record deleted from memory",
+	r.revisions[key] = recordRevision{rev: maxRev(latest.rev, rev), deleted: true}
+	r.loggerFor(ctx).Info("record deleted from memory",
 		slog.String("did", did),
 		slog.String("collection", collection),
 		slog.String("rkey", rkey))
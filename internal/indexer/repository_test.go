@@ -164,7 +164,7 @@ func TestInMemoryRepository_DeleteRecord(t *testing.T) {
 	}
 
 	// Delete record
-	err = repo.DeleteRecord(ctx, record.DID, record.Collection, record.RKey)
+	err = repo.DeleteRecord(ctx, record.DID, record.Collection, record.RKey, "")
 	if err != nil {
 		t.Fatalf("DeleteRecord() error = %v", err)
 	}
@@ -452,3 +452,86 @@ func TestTransactionAtomicity_Simulation(t *testing.T) {
 func generateRKey(goroutineID, index int) string {
 	return fmt.Sprintf("g%d-r%d", goroutineID, index)
 }
+
+// hasRecord reports whether the in-memory repository currently holds a record.
+func hasRecord(repo *InMemoryRecordRepository, record *FilterResult) bool {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	_, ok := repo.records[record.DID+":"+record.Collection+":"+record.RKey]
+	return ok
+}
+
+// TestInMemoryRepository_DeleteRevisionOrdering verifies that a delete and an edit
+// of the same record resolve by rev, independent of arrival order.
+func TestInMemoryRepository_DeleteRevisionOrdering(t *testing.T) {
+	ctx := context.Background()
+	newRecord := func(rev string) *FilterResult {
+		return &FilterResult{
+			DID:        "did:plc:reorder",
+			Collection: CollectionPost,
+			RKey:       "post1",
+			Rev:        rev,
+			Operation:  "update",
+			Valid:      true,
+			Matched:    true,
+			Record:     []byte(`{"text":"Edited post","sceneId":"scene1"}`),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		deleteRev   string
+		editRev     string
+		deleteFirst bool
+		wantVisible bool
+	}{
+		// Deleted at rev 3kbz, then edited at rev 3kc2: the edit wins
+		{"delete then edit, in order", "3kbzaaaaaaa22", "3kc2aaaaaaa22", true, true},
+		{"delete then edit, edit arrives first", "3kbzaaaaaaa22", "3kc2aaaaaaa22", false, true},
+		// Edited at rev 3kbz, then deleted at rev 3kc2: the delete wins
+		{"edit then delete, in order", "3kc2aaaaaaa22", "3kbzaaaaaaa22", false, false},
+		{"edit then delete, delete arrives first", "3kc2aaaaaaa22", "3kbzaaaaaaa22", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewInMemoryRecordRepository(newTestLogger())
+
+			// The record exists at an initial revision before either operation
+			initial := newRecord("3kbaaaaaaaa22")
+			if _, _, err := repo.UpsertRecord(ctx, initial); err != nil {
+				t.Fatalf("UpsertRecord() initial error = %v", err)
+			}
+
+			edit := newRecord(tt.editRev)
+			apply := []func() error{
+				func() error { return repo.DeleteRecord(ctx, edit.DID, edit.Collection, edit.RKey, tt.deleteRev) },
+				func() error { _, _, err := repo.UpsertRecord(ctx, edit); return err },
+			}
+			if !tt.deleteFirst {
+				apply[0], apply[1] = apply[1], apply[0]
+			}
+			for _, fn := range apply {
+				if err := fn(); err != nil {
+					t.Fatalf("apply error = %v", err)
+				}
+			}
+
+			if got := hasRecord(repo, edit); got != tt.wantVisible {
+				t.Errorf("record visible = %v, want %v", got, tt.wantVisible)
+			}
+		})
+	}
+}
+
+func TestRevIsOlder(t *testing.T) {
+	if !revIsOlder("3kbzaaaaaaa22", "3kc2aaaaaaa22") {
+		t.Error("expected earlier TID to be older")
+	}
+	if revIsOlder("3kc2aaaaaaa22", "3kbzaaaaaaa22") {
+		t.Error("expected later TID not to be older")
+	}
+	if revIsOlder("", "3kc2aaaaaaa22") || revIsOlder("3kbzaaaaaaa22", "") {
+		t.Error("expected unknown revs never to be older")
+	}
+}
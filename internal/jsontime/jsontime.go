@@ -0,0 +1,48 @@
+// Package jsontime provides a time type with a consistent JSON wire format for
+// API responses: RFC3339 in UTC, with zero values omitted rather than
+// serialized as 0001-01-01T00:00:00Z.
+package jsontime
+
+import (
+	"bytes"
+	"time"
+)
+
+// Time wraps time.Time so it always marshals as RFC3339 UTC.
+//
+// Use it with the omitzero tag option so zero values are dropped from the
+// response: `json:"created_at,omitzero"`. Without omitzero a zero Time
+// marshals as null, never as the year-1 timestamp.
+type Time struct {
+	time.Time
+}
+
+// New wraps t.
+func New(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// FromPtr wraps *t, returning the zero Time when t is nil.
+func FromPtr(t *time.Time) Time {
+	if t == nil {
+		return Time{}
+	}
+	return Time{Time: *t}
+}
+
+// MarshalJSON encodes the time as an RFC3339 UTC string, or null when zero.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.UTC().Format(time.RFC3339) + `"`), nil
+}
+
+// UnmarshalJSON decodes an RFC3339 string; null leaves the zero Time.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+	return t.Time.UnmarshalJSON(data)
+}
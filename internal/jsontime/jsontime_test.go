@@ -0,0 +1,64 @@
+package jsontime
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type response struct {
+	StartsAt  Time `json:"starts_at"`
+	CreatedAt Time `json:"created_at,omitzero"`
+}
+
+func TestTime_ZeroOmitted(t *testing.T) {
+	data, err := json.Marshal(response{})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	got := string(data)
+	if strings.Contains(got, "0001-01-01") {
+		t.Errorf("zero time serialized as year 1: %s", got)
+	}
+	if strings.Contains(got, "created_at") {
+		t.Errorf("expected omitzero field to be omitted: %s", got)
+	}
+	if got != `{"starts_at":null}` {
+		t.Errorf("expected zero time without omitzero to marshal as null, got %s", got)
+	}
+}
+
+func TestTime_MarshalsRFC3339UTC(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	ts := time.Date(2026, 3, 14, 9, 30, 15, 123456789, loc)
+
+	data, err := json.Marshal(response{StartsAt: New(ts), CreatedAt: FromPtr(&ts)})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	want := `{"starts_at":"2026-03-14T14:30:15Z","created_at":"2026-03-14T14:30:15Z"}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestTime_UnmarshalRoundTrip(t *testing.T) {
+	var decoded response
+	if err := json.Unmarshal([]byte(`{"starts_at":"2026-03-14T09:30:15-05:00","created_at":null}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	want := time.Date(2026, 3, 14, 14, 30, 15, 0, time.UTC)
+	if !decoded.StartsAt.Equal(want) {
+		t.Errorf("StartsAt = %v, want %v", decoded.StartsAt, want)
+	}
+	if !decoded.CreatedAt.IsZero() {
+		t.Errorf("expected null to decode as zero, got %v", decoded.CreatedAt)
+	}
+	if !FromPtr(nil).IsZero() {
+		t.Error("expected FromPtr(nil) to be zero")
+	}
+}
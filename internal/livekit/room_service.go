@@ -104,6 +104,24 @@ func (s *RoomService) GetRoom(ctx context.Context, roomName string) (*livekit.Ro
 	return resp.Rooms[0], nil
 }
 
+// ValidateCredentials verifies the configured URL and API credentials with a
+// lightweight authenticated ListRooms call. Used by the startup self-check so
+// invalid credentials surface before the first stream request.
+func (s *RoomService) ValidateCredentials(ctx context.Context) error {
+	if s.roomClient == nil {
+		return ErrRoomServiceNotConfigured
+	}
+
+	_, err := s.roomClient.ListRooms(ctx, &livekit.ListRoomsRequest{
+		Names: []string{"subcults-selfcheck"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to validate credentials: %w", err)
+	}
+
+	return nil
+}
+
 // MuteParticipantTrack mutes a specific participant's track in a room.
 func (s *RoomService) MuteParticipantTrack(ctx context.Context, roomName, participantIdentity string, trackSID string, muted bool) error {
 	if s.roomClient == nil {
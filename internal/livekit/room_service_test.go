@@ -92,6 +92,14 @@ func TestRoomService_GetRoom_NilClient(t *testing.T) {
 	}
 }
 
+func TestRoomService_ValidateCredentials_NilClient(t *testing.T) {
+	svc := nilRoomService()
+	err := svc.ValidateCredentials(context.Background())
+	if err != ErrRoomServiceNotConfigured {
+		t.Errorf("expected ErrRoomServiceNotConfigured, got %v", err)
+	}
+}
+
 func TestRoomService_MuteParticipantTrack_NilClient(t *testing.T) {
 	svc := nilRoomService()
 	err := svc.MuteParticipantTrack(context.Background(), "room", "participant", "track-sid", true)
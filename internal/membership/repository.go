@@ -29,6 +29,10 @@ type Membership struct {
 	RecordDID  *string `json:"record_did,omitempty"`
 	RecordRKey *string `json:"record_rkey,omitempty"`
 
+	// WelcomedAt is when the scene's welcome was sent to this member; nil if
+	// it hasn't been. Members are welcomed at most once per scene.
+	WelcomedAt *time.Time `json:"welcomed_at,omitempty"`
+
 	Since     time.Time `json:"since"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -104,6 +108,11 @@ type MembershipRepository interface {
 	// This affects the effective trust weight via the role multiplier.
 	UpdateRole(id, role string) error
 
+	// MarkWelcomed records that the scene's welcome was sent to a member.
+	// Returns true only for the call that first marks the membership, so
+	// concurrent approvals can't welcome a member twice.
+	MarkWelcomed(id string) (bool, error)
+
 	// ListByScene retrieves all memberships for a scene, optionally filtered by status.
 	// If status is empty, returns all memberships regardless of status.
 	ListByScene(sceneID, status string) ([]*Membership, error)
@@ -292,6 +301,26 @@ func (r *InMemoryMembershipRepository) UpdateRole(id, role string) error {
 	return nil
 }
 
+// MarkWelcomed records that the scene's welcome was sent to a member.
+func (r *InMemoryMembershipRepository) MarkWelcomed(id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	membership, ok := r.memberships[id]
+	if !ok {
+		return false, ErrMembershipNotFound
+	}
+	if membership.WelcomedAt != nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	membership.WelcomedAt = &now
+	membership.UpdatedAt = now
+
+	return true, nil
+}
+
 // ListByScene retrieves all memberships for a scene, optionally filtered by status.
 func (r *InMemoryMembershipRepository) ListByScene(sceneID, status string) ([]*Membership, error) {
 	r.mu.RLock()
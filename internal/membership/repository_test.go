@@ -541,6 +541,55 @@ func TestMembershipRepository_UpdateRole(t *testing.T) {
 	}
 }
 
+func TestMembershipRepository_MarkWelcomed(t *testing.T) {
+	repo := NewInMemoryMembershipRepository()
+
+	result, err := repo.Upsert(&Membership{
+		SceneID:     "scene-1",
+		UserDID:     "user1",
+		Role:        "member",
+		TrustWeight: 0.5,
+		Status:      "active",
+	})
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	// The first call marks the membership
+	first, err := repo.MarkWelcomed(result.ID)
+	if err != nil {
+		t.Fatalf("MarkWelcomed failed: %v", err)
+	}
+	if !first {
+		t.Error("Expected the first MarkWelcomed to report true")
+	}
+
+	marked, err := repo.GetByID(result.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if marked.WelcomedAt == nil {
+		t.Fatal("Expected welcomed_at to be set")
+	}
+
+	// Later calls leave it unchanged
+	again, err := repo.MarkWelcomed(result.ID)
+	if err != nil {
+		t.Fatalf("MarkWelcomed failed: %v", err)
+	}
+	if again {
+		t.Error("Expected a repeat MarkWelcomed to report false")
+	}
+	if unchanged, _ := repo.GetByID(result.ID); !unchanged.WelcomedAt.Equal(*marked.WelcomedAt) {
+		t.Errorf("Expected welcomed_at to stay %v, got %v", marked.WelcomedAt, unchanged.WelcomedAt)
+	}
+
+	// Try non-existent membership
+	if _, err := repo.MarkWelcomed("non-existent-id"); err != ErrMembershipNotFound {
+		t.Errorf("Expected ErrMembershipNotFound, got %v", err)
+	}
+}
+
 func TestMembership_EffectiveWeight(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -146,8 +146,12 @@ func (cr *CanaryRouter) assignCohort(r *http.Request) string {
 	return "stable"
 }
 
-// getClientIP extracts the client IP address from the request.
+// getClientIP extracts the client IP address from the request, preferring the
+// address resolved by RealIP.
 func getClientIP(r *http.Request) string {
+	if ip := GetRealIP(r.Context()); ip != "" {
+		return ip
+	}
 	// Check X-Forwarded-For header first (for proxied requests)
 	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
 		return xff
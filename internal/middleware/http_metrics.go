@@ -17,24 +17,26 @@ var pathNormalizer = regexp.MustCompile(`/[^/]+`)
 func normalizePath(path string) string {
 	// Exact matches for static routes (no normalization needed)
 	staticRoutes := map[string]bool{
-		"/":                  true,
-		"/events":            true,
-		"/scenes":            true,
-		"/posts":             true,
-		"/streams":           true,
-		"/search/events":     true,
-		"/search/scenes":     true,
-		"/search/posts":      true,
-		"/search/global":     true,
-		"/livekit/token":     true,
-		"/uploads/sign":      true,
-		"/payments/onboard":  true,
-		"/payments/checkout": true,
-		"/payments/status":   true,
-		"/internal/stripe":   true,
-		"/health":            true,
-		"/ready":             true,
-		"/metrics":           true,
+		"/":                      true,
+		"/events":                true,
+		"/scenes":                true,
+		"/scenes/spotlight":      true,
+		"/posts":                 true,
+		"/streams":               true,
+		"/search/events":         true,
+		"/search/scenes":         true,
+		"/search/posts":          true,
+		"/search/global":         true,
+		"/livekit/token":         true,
+		"/uploads/sign":          true,
+		"/payments/onboard":      true,
+		"/payments/checkout":     true,
+		"/payments/status":       true,
+		"/payments/status/batch": true,
+		"/internal/stripe":       true,
+		"/health":                true,
+		"/ready":                 true,
+		"/metrics":               true,
 	}
 
 	if staticRoutes[path] {
@@ -214,9 +214,14 @@ func (s *InMemoryRateLimitStore) Cleanup() {
 // KeyFunc extracts a rate limit key from an HTTP request.
 type KeyFunc func(r *http.Request) string
 
-// IPKeyFunc returns a KeyFunc that uses the client's IP address.
+// IPKeyFunc returns a KeyFunc that uses the client's IP address as resolved by
+// RealIP. Without RealIP in the chain, it falls back to reading the forwarding
+// headers directly, which clients can spoof.
 func IPKeyFunc() KeyFunc {
 	return func(r *http.Request) string {
+		if ip := GetRealIP(r.Context()); ip != "" {
+			return ip
+		}
 		// Check X-Forwarded-For header first (for proxied requests)
 		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
 			// Use the last (rightmost) IP — the one added by the trusted reverse proxy
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// realIPKey is the context key for the resolved client IP.
+type realIPKey struct{}
+
+// RealIP is a middleware that resolves the client IP and stores it in the
+// request context for rate limiting, canary cohorts, and audit logging.
+//
+// X-Forwarded-For and X-Real-IP are only honored when the immediate peer
+// (RemoteAddr) is in trustedProxies; otherwise anyone could spoof their IP by
+// sending the headers. X-Forwarded-For is walked from the right, skipping
+// trusted proxies, and the first untrusted address is the client. If the walk
+// reaches a malformed entry, it stops at the nearest trusted hop rather than
+// trusting anything further left. With no trusted proxies, the peer address is
+// always used.
+func RealIP(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), realIPKey{}, resolveClientIP(r, trustedProxies))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetRealIP returns the client IP resolved by RealIP. Returns empty string if
+// RealIP did not run.
+func GetRealIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(realIPKey{}).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+// resolveClientIP determines the client IP for a request; see RealIP.
+func resolveClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	peer, ok := parseIPHop(r.RemoteAddr)
+	if !ok {
+		// Not an IP (e.g. a unix socket); nothing to resolve against
+		return r.RemoteAddr
+	}
+	if !isTrustedProxy(peer, trustedProxies) {
+		return peer.String()
+	}
+
+	if xff := strings.Join(r.Header.Values("X-Forwarded-For"), ","); xff != "" {
+		client := peer
+		hops := strings.Split(xff, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, ok := parseIPHop(hops[i])
+			if !ok {
+				break
+			}
+			client = hop
+			if !isTrustedProxy(hop, trustedProxies) {
+				break
+			}
+		}
+		return client.String()
+	}
+
+	if realIP, ok := parseIPHop(r.Header.Get("X-Real-IP")); ok {
+		return realIP.String()
+	}
+	return peer.String()
+}
+
+// parseIPHop parses an address as it appears in RemoteAddr or a forwarding
+// header: an IPv4 or IPv6 address, optionally with a port.
+func parseIPHop(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// isTrustedProxy reports whether addr is within any of the trusted prefixes.
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestRealIP(t *testing.T) {
+	trusted := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("fd00::/8"),
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		xRealIP    string
+		want       string
+	}{
+		{
+			name:       "no proxy headers",
+			remoteAddr: "203.0.113.7:5000",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy chain",
+			remoteAddr: "10.0.0.2:443",
+			xff:        []string{"198.51.100.1, 203.0.113.7, 10.0.0.5"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "chain split across headers",
+			remoteAddr: "10.0.0.2:443",
+			xff:        []string{"203.0.113.7", "10.0.0.5"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "every hop trusted uses leftmost",
+			remoteAddr: "10.0.0.2:443",
+			xff:        []string{"10.1.2.3, 10.0.0.5"},
+			want:       "10.1.2.3",
+		},
+		{
+			name:       "IPv6 client through IPv6 proxy",
+			remoteAddr: "[fd00::2]:443",
+			xff:        []string{"2001:db8::7"},
+			want:       "2001:db8::7",
+		},
+		{
+			name:       "hop with port",
+			remoteAddr: "10.0.0.2:443",
+			xff:        []string{"203.0.113.7:51234"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "untrusted peer ignores X-Forwarded-For",
+			remoteAddr: "203.0.113.7:5000",
+			xff:        []string{"198.51.100.1"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "untrusted peer ignores X-Real-IP",
+			remoteAddr: "203.0.113.7:5000",
+			xRealIP:    "198.51.100.1",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted peer uses X-Real-IP",
+			remoteAddr: "10.0.0.2:443",
+			xRealIP:    "203.0.113.7",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "malformed header falls back to peer",
+			remoteAddr: "10.0.0.2:443",
+			xff:        []string{"not-an-ip"},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "malformed hop stops at nearest trusted hop",
+			remoteAddr: "10.0.0.2:443",
+			xff:        []string{"198.51.100.1, garbage, 10.0.0.5"},
+			want:       "10.0.0.5",
+		},
+		{
+			name:       "malformed X-Real-IP falls back to peer",
+			remoteAddr: "10.0.0.2:443",
+			xRealIP:    "203.0.113",
+			want:       "10.0.0.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := RealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = GetRealIP(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, xff := range tt.xff {
+				req.Header.Add("X-Forwarded-For", xff)
+			}
+			if tt.xRealIP != "" {
+				req.Header.Set("X-Real-IP", tt.xRealIP)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("GetRealIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRealIP_NoTrustedProxies(t *testing.T) {
+	var got string
+	handler := RealIP(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = GetRealIP(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.RemoteAddr = "127.0.0.1:5000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "127.0.0.1" {
+		t.Errorf("GetRealIP() = %q, want the peer address", got)
+	}
+}
+
+func TestIPKeyFunc_PrefersRealIP(t *testing.T) {
+	var got string
+	handler := RealIP(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = IPKeyFunc()(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.RemoteAddr = "203.0.113.7:5000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "203.0.113.7" {
+		t.Errorf("IPKeyFunc() = %q, want the resolved IP rather than the spoofed header", got)
+	}
+}
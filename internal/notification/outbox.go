@@ -0,0 +1,92 @@
+// Package notification provides an outbox for user-facing notifications.
+// Producers enqueue notifications alongside the state change that caused them;
+// a delivery worker drains the outbox asynchronously.
+package notification
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification types.
+const (
+	TypeEventSeriesCancelled = "event_series_cancelled"
+	TypeSceneWelcome         = "scene_welcome"
+)
+
+// Notification is a pending message for a single recipient.
+type Notification struct {
+	ID           string            `json:"id"`
+	RecipientDID string            `json:"recipient_did"`
+	Type         string            `json:"type"`
+	SubjectID    string            `json:"subject_id"` // ID of the entity the notification is about
+	Payload      map[string]string `json:"payload,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// Outbox defines the interface for notification outbox operations.
+type Outbox interface {
+	// Enqueue adds a notification to the outbox.
+	Enqueue(n *Notification) error
+
+	// ListByRecipient returns notifications for a recipient, oldest first.
+	ListByRecipient(recipientDID string) ([]*Notification, error)
+}
+
+// InMemoryOutbox is an in-memory implementation of Outbox.
+// Used for testing and development. Thread-safe via RWMutex.
+type InMemoryOutbox struct {
+	mu            sync.RWMutex
+	notifications []*Notification
+}
+
+// NewInMemoryOutbox creates a new in-memory notification outbox.
+func NewInMemoryOutbox() *InMemoryOutbox {
+	return &InMemoryOutbox{
+		notifications: make([]*Notification, 0),
+	}
+}
+
+// Enqueue adds a notification to the outbox, assigning ID and CreatedAt if unset.
+func (o *InMemoryOutbox) Enqueue(n *Notification) error {
+	nCopy := copyNotification(n)
+	if nCopy.ID == "" {
+		nCopy.ID = uuid.New().String()
+	}
+	if nCopy.CreatedAt.IsZero() {
+		nCopy.CreatedAt = time.Now()
+	}
+
+	o.mu.Lock()
+	o.notifications = append(o.notifications, nCopy)
+	o.mu.Unlock()
+	return nil
+}
+
+// ListByRecipient returns notifications for a recipient, oldest first.
+func (o *InMemoryOutbox) ListByRecipient(recipientDID string) ([]*Notification, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	results := make([]*Notification, 0)
+	for _, n := range o.notifications {
+		if n.RecipientDID == recipientDID {
+			results = append(results, copyNotification(n))
+		}
+	}
+	return results, nil
+}
+
+// copyNotification returns a deep copy of n, including its payload map.
+func copyNotification(n *Notification) *Notification {
+	nCopy := *n
+	if n.Payload != nil {
+		nCopy.Payload = make(map[string]string, len(n.Payload))
+		for k, v := range n.Payload {
+			nCopy.Payload[k] = v
+		}
+	}
+	return &nCopy
+}
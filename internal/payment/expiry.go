@@ -0,0 +1,78 @@
+// Package payment provides expiry of abandoned checkout payments.
+package payment
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// DefaultPendingExpiry is how long a payment may stay pending before it is
+// treated as abandoned. Stripe Checkout Sessions expire at most 24 hours after
+// creation, so a pending payment older than that can no longer complete.
+const DefaultPendingExpiry = 24 * time.Hour
+
+// ExpireAbandonedPayments cancels pending payments older than the specified duration.
+// Each Checkout Session is expired at Stripe before the payment is canceled, so
+// a payer can't complete a checkout that was already canceled here. Sessions
+// the payer completed are left pending for the webhook to mark succeeded.
+// Returns the number of payments canceled and any error encountered.
+func ExpireAbandonedPayments(repo PaymentRepository, client Client, expiry time.Duration) (int64, error) {
+	pending, err := repo.ListPendingOlderThan(expiry)
+	if err != nil {
+		slog.Error("failed to expire abandoned payments", "error", err)
+		return 0, err
+	}
+
+	var canceled int64
+	for _, record := range pending {
+		if err := ExpireCheckout(client, record.SessionID); err != nil {
+			if !errors.Is(err, ErrCheckoutCompleted) {
+				// Retried on the next pass
+				slog.Warn("failed to expire abandoned checkout session", "session_id", record.SessionID, "error", err)
+			}
+			continue
+		}
+
+		if err := repo.MarkCanceled(record.SessionID); err != nil {
+			if errors.Is(err, ErrInvalidStatusTransition) {
+				// Settled by a webhook since it was listed
+				continue
+			}
+			slog.Error("failed to expire abandoned payments", "session_id", record.SessionID, "error", err)
+			return canceled, err
+		}
+		canceled++
+	}
+
+	if canceled > 0 {
+		slog.Info("canceled abandoned pending payments", "canceled", canceled, "older_than", expiry)
+	}
+
+	return canceled, nil
+}
+
+// RunPeriodicExpiry runs ExpireAbandonedPayments at the specified interval.
+// This function blocks and should typically be run in a goroutine.
+// It will continue running until the provided stop channel is closed.
+func RunPeriodicExpiry(repo PaymentRepository, client Client, interval time.Duration, expiry time.Duration, stopChan <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Run expiry immediately on start
+	if _, err := ExpireAbandonedPayments(repo, client, expiry); err != nil {
+		slog.Error("initial payment expiry failed", "error", err)
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := ExpireAbandonedPayments(repo, client, expiry); err != nil {
+				slog.Error("periodic payment expiry failed", "error", err)
+			}
+		case <-stopChan:
+			slog.Info("stopping periodic payment expiry")
+			return
+		}
+	}
+}
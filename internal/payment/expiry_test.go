@@ -0,0 +1,115 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v81"
+)
+
+// fakeCheckoutClient serves Checkout Sessions with fixed statuses. Expiring an
+// open session expires it; Stripe refuses to expire any other session.
+type fakeCheckoutClient struct {
+	Client
+	statuses map[string]stripe.CheckoutSessionStatus
+	expired  []string
+}
+
+func (c *fakeCheckoutClient) ExpireCheckoutSession(sessionID string) (*stripe.CheckoutSession, error) {
+	if status, ok := c.statuses[sessionID]; ok && status != stripe.CheckoutSessionStatusOpen {
+		return nil, errors.New("only open checkout sessions can be expired")
+	}
+	c.expired = append(c.expired, sessionID)
+	return &stripe.CheckoutSession{ID: sessionID, Status: stripe.CheckoutSessionStatusExpired}, nil
+}
+
+func (c *fakeCheckoutClient) GetCheckoutSession(sessionID string) (*stripe.CheckoutSession, error) {
+	status, ok := c.statuses[sessionID]
+	if !ok {
+		status = stripe.CheckoutSessionStatusOpen
+	}
+	return &stripe.CheckoutSession{ID: sessionID, Status: status}, nil
+}
+
+func TestExpireAbandonedPayments(t *testing.T) {
+	repo := NewInMemoryPaymentRepository()
+
+	oldTime := time.Now().Add(-25 * time.Hour)
+	recentTime := time.Now().Add(-1 * time.Hour)
+
+	records := []*PaymentRecord{
+		{SessionID: "cs_old_pending", UserDID: "did:plc:user", SceneID: "scene-1", CreatedAt: &oldTime},
+		{SessionID: "cs_recent_pending", UserDID: "did:plc:user", SceneID: "scene-1", CreatedAt: &recentTime},
+		{SessionID: "cs_old_succeeded", UserDID: "did:plc:user", SceneID: "scene-1", CreatedAt: &oldTime},
+		{SessionID: "cs_stripe_expired", UserDID: "did:plc:user", SceneID: "scene-1", CreatedAt: &oldTime},
+		{SessionID: "cs_paid_late", UserDID: "did:plc:user", SceneID: "scene-1", CreatedAt: &oldTime},
+	}
+	for _, record := range records {
+		if err := repo.CreatePending(record); err != nil {
+			t.Fatalf("CreatePending() error = %v", err)
+		}
+	}
+	if err := repo.MarkCompleted("cs_old_succeeded", "pi_test"); err != nil {
+		t.Fatalf("MarkCompleted() error = %v", err)
+	}
+
+	client := &fakeCheckoutClient{statuses: map[string]stripe.CheckoutSessionStatus{
+		"cs_stripe_expired": stripe.CheckoutSessionStatusExpired,
+		"cs_paid_late":      stripe.CheckoutSessionStatusComplete,
+	}}
+	canceled, err := ExpireAbandonedPayments(repo, client, DefaultPendingExpiry)
+	if err != nil {
+		t.Fatalf("ExpireAbandonedPayments() error = %v", err)
+	}
+	if canceled != 2 {
+		t.Errorf("expected 2 payments canceled, got %d", canceled)
+	}
+	if len(client.expired) != 1 || client.expired[0] != "cs_old_pending" {
+		t.Errorf("expected only the open session to be expired at Stripe, got %v", client.expired)
+	}
+
+	// A session the payer completed stays pending for the webhook to settle
+	want := map[string]string{
+		"cs_old_pending":    StatusCanceled,
+		"cs_recent_pending": StatusPending,
+		"cs_old_succeeded":  StatusSucceeded,
+		"cs_stripe_expired": StatusCanceled,
+		"cs_paid_late":      StatusPending,
+	}
+	for sessionID, status := range want {
+		record, err := repo.GetBySessionID(sessionID)
+		if err != nil {
+			t.Fatalf("GetBySessionID(%s) error = %v", sessionID, err)
+		}
+		if record.Status != status {
+			t.Errorf("%s: expected status %q, got %q", sessionID, status, record.Status)
+		}
+	}
+	if err := repo.MarkCompleted("cs_paid_late", "pi_late"); err != nil {
+		t.Errorf("MarkCompleted() on the late payment error = %v", err)
+	}
+
+	// A second pass finds nothing left to expire
+	if canceled, _ := ExpireAbandonedPayments(repo, client, DefaultPendingExpiry); canceled != 0 {
+		t.Errorf("expected 0 payments canceled on second pass, got %d", canceled)
+	}
+}
+
+func TestRunPeriodicExpiry_Stops(t *testing.T) {
+	repo := NewInMemoryPaymentRepository()
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		RunPeriodicExpiry(repo, &fakeCheckoutClient{}, time.Millisecond, DefaultPendingExpiry, stop)
+		close(done)
+	}()
+
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunPeriodicExpiry did not stop")
+	}
+}
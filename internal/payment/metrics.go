@@ -0,0 +1,85 @@
+package payment
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics names as constants for consistency.
+const (
+	MetricOnboardingAttempts = "payment_onboarding_attempts_total"
+	MetricOnboardingFailures = "payment_onboarding_failures_total"
+	MetricOnboardingReused   = "payment_onboarding_reused_total"
+)
+
+// Onboarding failure reasons used as the "reason" label.
+const (
+	OnboardingFailureConnectAccount = "connect_account"
+	OnboardingFailureAccountLink    = "account_link"
+	OnboardingFailureSceneUpdate    = "scene_update"
+)
+
+// Metrics contains Prometheus metrics for Stripe Connect onboarding.
+// Used to spot scripted onboarding attempts that would otherwise leave
+// orphaned Connect accounts. All operations are thread-safe.
+type Metrics struct {
+	onboardingAttempts prometheus.Counter
+	onboardingFailures *prometheus.CounterVec
+	onboardingReused   prometheus.Counter
+}
+
+// NewMetrics creates and returns a new Metrics instance with all collectors initialized.
+// The metrics are not registered; call Register to register them with a registry.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		onboardingAttempts: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: MetricOnboardingAttempts,
+				Help: "Total number of payment onboarding requests that passed authorization checks",
+			},
+		),
+		onboardingFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: MetricOnboardingFailures,
+				Help: "Total number of payment onboarding failures, by reason",
+			},
+			[]string{"reason"},
+		),
+		onboardingReused: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: MetricOnboardingReused,
+				Help: "Total number of onboarding retries served an in-progress link instead of a new Connect account",
+			},
+		),
+	}
+}
+
+// Register registers all metrics with the given registry.
+// Returns an error if registration fails.
+func (m *Metrics) Register(reg prometheus.Registerer) error {
+	for _, c := range m.Collectors() {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IncOnboardingAttempt increments the onboarding attempt counter.
+func (m *Metrics) IncOnboardingAttempt() {
+	m.onboardingAttempts.Inc()
+}
+
+// IncOnboardingFailure increments the onboarding failure counter for a reason.
+func (m *Metrics) IncOnboardingFailure(reason string) {
+	m.onboardingFailures.WithLabelValues(reason).Inc()
+}
+
+// IncOnboardingReused increments the counter for retries that reused an in-progress link.
+func (m *Metrics) IncOnboardingReused() {
+	m.onboardingReused.Inc()
+}
+
+// Collectors returns all Prometheus collectors for testing.
+func (m *Metrics) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.onboardingAttempts, m.onboardingFailures, m.onboardingReused}
+}
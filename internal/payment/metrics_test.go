@@ -0,0 +1,42 @@
+package payment
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics_Register(t *testing.T) {
+	m := NewMetrics()
+	reg := prometheus.NewRegistry()
+
+	if err := m.Register(reg); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+	if err := m.Register(reg); err == nil {
+		t.Error("expected error on duplicate registration")
+	}
+	if len(m.Collectors()) != 3 {
+		t.Errorf("expected 3 collectors, got %d", len(m.Collectors()))
+	}
+}
+
+func TestMetrics_Onboarding(t *testing.T) {
+	m := NewMetrics()
+
+	m.IncOnboardingAttempt()
+	m.IncOnboardingAttempt()
+	m.IncOnboardingReused()
+	m.IncOnboardingFailure(OnboardingFailureConnectAccount)
+
+	if got := testutil.ToFloat64(m.onboardingAttempts); got != 2 {
+		t.Errorf("onboarding attempts = %f, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.onboardingReused); got != 1 {
+		t.Errorf("onboarding reused = %f, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.onboardingFailures.WithLabelValues(OnboardingFailureConnectAccount)); got != 1 {
+		t.Errorf("connect_account failures = %f, want 1", got)
+	}
+}
@@ -0,0 +1,111 @@
+package payment
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used for amounts recorded without a currency.
+const DefaultCurrency = "usd"
+
+// zeroDecimalCurrencies have no minor unit, so their amounts are already in
+// whole units. Matches Stripe's list of zero-decimal currencies.
+var zeroDecimalCurrencies = map[string]bool{
+	"bif": true, "clp": true, "djf": true, "gnf": true, "jpy": true, "kmf": true,
+	"krw": true, "mga": true, "pyg": true, "rwf": true, "ugx": true, "vnd": true,
+	"vuv": true, "xaf": true, "xof": true, "xpf": true,
+}
+
+// currencySymbols are prefixed to display strings; other currencies are
+// suffixed with their uppercase code instead.
+var currencySymbols = map[string]string{
+	"usd": "$",
+	"eur": "€",
+	"gbp": "£",
+	"jpy": "¥",
+}
+
+// Money is an amount in a specific currency. Cents is in the currency's
+// smallest unit, as Stripe reports it: cents for USD, whole yen for JPY.
+//
+// Money marshals to JSON as {"cents", "currency", "display"}, where display is
+// a formatted string for showing to users, e.g. "$1,234.50" or "¥1,000".
+// Display is ignored when unmarshaling.
+type Money struct {
+	Cents    int64
+	Currency string // Lowercase ISO 4217 code
+}
+
+// NewMoney creates a Money value, normalizing the currency code to lowercase
+// and defaulting an empty currency to DefaultCurrency.
+func NewMoney(cents int64, currency string) Money {
+	currency = strings.ToLower(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{Cents: cents, Currency: currency}
+}
+
+// IsZeroDecimal reports whether the currency has no minor unit.
+func (m Money) IsZeroDecimal() bool {
+	return zeroDecimalCurrencies[m.Currency]
+}
+
+// String formats the amount for display, e.g. "$1,234.50", "-€5.00",
+// "¥1,000" or "1,234.50 CHF".
+func (m Money) String() string {
+	cents := m.Cents
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	var amount string
+	if m.IsZeroDecimal() {
+		amount = groupThousands(cents)
+	} else {
+		amount = groupThousands(cents/100) + "." + strconv.FormatInt(cents%100+100, 10)[1:]
+	}
+
+	if symbol, ok := currencySymbols[m.Currency]; ok {
+		return sign + symbol + amount
+	}
+	return sign + amount + " " + strings.ToUpper(m.Currency)
+}
+
+// groupThousands formats a non-negative integer with comma separators.
+func groupThousands(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+// moneyJSON is the wire format of Money.
+type moneyJSON struct {
+	Cents    int64  `json:"cents"`
+	Currency string `json:"currency"`
+	Display  string `json:"display,omitempty"`
+}
+
+// MarshalJSON encodes the amount with its formatted display string.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Cents: m.Cents, Currency: m.Currency, Display: m.String()})
+}
+
+// UnmarshalJSON decodes cents and currency; display is derived and ignored.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var decoded moneyJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*m = NewMoney(decoded.Cents, decoded.Currency)
+	return nil
+}
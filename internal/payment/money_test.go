@@ -0,0 +1,73 @@
+package payment
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{NewMoney(10000, "usd"), "$100.00"},
+		{NewMoney(123450, "USD"), "$1,234.50"},
+		{NewMoney(5, "usd"), "$0.05"},
+		{NewMoney(0, ""), "$0.00"},
+		{NewMoney(-500, "eur"), "-€5.00"},
+		{NewMoney(1000, "jpy"), "¥1,000"},
+		{NewMoney(1234567, "krw"), "1,234,567 KRW"},
+		{NewMoney(123450, "chf"), "1,234.50 CHF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.money.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_JSONShape(t *testing.T) {
+	data, err := json.Marshal(NewMoney(10050, "usd"))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"cents":10050,"currency":"usd","display":"$100.50"}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	data, err = json.Marshal(NewMoney(1000, "jpy"))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"cents":1000,"currency":"jpy","display":"¥1,000"}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestMoney_RoundTrip(t *testing.T) {
+	for _, original := range []Money{NewMoney(10050, "usd"), NewMoney(1000, "jpy"), NewMoney(-250, "gbp")} {
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		var decoded Money
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if decoded != original {
+			t.Errorf("round trip of %s = %+v, want %+v", data, decoded, original)
+		}
+	}
+
+	// A stale or forged display string does not override the amount
+	var decoded Money
+	if err := json.Unmarshal([]byte(`{"cents":500,"currency":"USD","display":"$999.00"}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded != NewMoney(500, "usd") || decoded.String() != "$5.00" {
+		t.Errorf("Unmarshal() = %+v (%s), want $5.00", decoded, decoded)
+	}
+}
@@ -60,6 +60,10 @@ type PaymentRepository interface {
 	// Returns ErrInvalidStatusTransition if the payment is not in succeeded status.
 	// Idempotent: returns nil if already in refunded status.
 	MarkRefunded(sessionID string) error
+
+	// ListPendingOlderThan returns every pending payment created more than age
+	// ago.
+	ListPendingOlderThan(age time.Duration) ([]*PaymentRecord, error)
 }
 
 // InMemoryPaymentRepository implements PaymentRepository with in-memory storage.
@@ -347,3 +351,21 @@ func (r *InMemoryPaymentRepository) MarkRefunded(sessionID string) error {
 
 	return nil
 }
+
+// ListPendingOlderThan returns every pending payment created more than age
+// ago.
+func (r *InMemoryPaymentRepository) ListPendingOlderThan(age time.Duration) ([]*PaymentRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cutoff := time.Now().Add(-age)
+	var pending []*PaymentRecord
+	for _, record := range r.records {
+		if record.Status != StatusPending || record.CreatedAt == nil || !record.CreatedAt.Before(cutoff) {
+			continue
+		}
+		pending = append(pending, record.DeepCopy())
+	}
+
+	return pending, nil
+}
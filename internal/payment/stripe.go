@@ -2,6 +2,8 @@
 package payment
 
 import (
+	"errors"
+
 	"github.com/stripe/stripe-go/v81"
 	"github.com/stripe/stripe-go/v81/account"
 	"github.com/stripe/stripe-go/v81/accountlink"
@@ -29,8 +31,14 @@ type Client interface {
 	CreateConnectAccount() (*stripe.Account, error)
 	CreateAccountLink(accountID, returnURL, refreshURL string) (*stripe.AccountLink, error)
 	CreateCheckoutSession(params *CheckoutSessionParams) (*stripe.CheckoutSession, error)
+	ExpireCheckoutSession(sessionID string) (*stripe.CheckoutSession, error)
+	GetCheckoutSession(sessionID string) (*stripe.CheckoutSession, error)
 }
 
+// ErrCheckoutCompleted is returned by ExpireCheckout when the payer has already
+// completed the Checkout Session, so it can no longer be expired.
+var ErrCheckoutCompleted = errors.New("checkout session already completed")
+
 // StripeClient implements the Client interface using the real Stripe SDK.
 type StripeClient struct{}
 
@@ -96,3 +104,37 @@ func (c *StripeClient) CreateCheckoutSession(params *CheckoutSessionParams) (*st
 
 	return sess, nil
 }
+
+// ExpireCheckoutSession expires an open Checkout Session so it can no longer be paid.
+// Stripe rejects the call if the session has already completed or expired.
+func (c *StripeClient) ExpireCheckoutSession(sessionID string) (*stripe.CheckoutSession, error) {
+	return session.Expire(sessionID, &stripe.CheckoutSessionExpireParams{})
+}
+
+// GetCheckoutSession retrieves a Checkout Session.
+func (c *StripeClient) GetCheckoutSession(sessionID string) (*stripe.CheckoutSession, error) {
+	return session.Get(sessionID, nil)
+}
+
+// ExpireCheckout expires a Checkout Session so it can no longer be paid.
+// Stripe refuses to expire a session that isn't open, so on failure the session
+// is fetched: one that has already expired counts as expired, and one the payer
+// completed returns ErrCheckoutCompleted.
+func ExpireCheckout(client Client, sessionID string) error {
+	_, err := client.ExpireCheckoutSession(sessionID)
+	if err == nil {
+		return nil
+	}
+
+	sess, getErr := client.GetCheckoutSession(sessionID)
+	if getErr != nil {
+		return err
+	}
+	switch sess.Status {
+	case stripe.CheckoutSessionStatusExpired:
+		return nil
+	case stripe.CheckoutSessionStatusComplete:
+		return ErrCheckoutCompleted
+	}
+	return err
+}
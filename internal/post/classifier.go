@@ -0,0 +1,114 @@
+package post
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ContentClassifier analyzes image attachments at post ingestion so mature
+// content can be labeled automatically. Implementations typically call out to
+// an image moderation service.
+type ContentClassifier interface {
+	// IsMature reports whether the image attachment contains adult content.
+	IsMature(ctx context.Context, attachment Attachment) (bool, error)
+}
+
+// NoopClassifier never flags content. It is the default, so automated
+// labeling stays opt-in per deployment.
+type NoopClassifier struct{}
+
+// IsMature always reports false.
+func (NoopClassifier) IsMature(context.Context, Attachment) (bool, error) {
+	return false, nil
+}
+
+// HTTPClassifier classifies images with an external moderation service. Each
+// image is POSTed to the endpoint as JSON {"key", "url", "type"}, and the
+// service answers {"mature": bool}.
+type HTTPClassifier struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPClassifier creates a classifier for the service at endpoint.
+// If client is nil, a client with a 10 second timeout is used.
+func NewHTTPClassifier(endpoint string, client *http.Client) *HTTPClassifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HTTPClassifier{endpoint: endpoint, client: client}
+}
+
+// classifyRequest is the body sent to the moderation service.
+type classifyRequest struct {
+	Key  string `json:"key,omitempty"`
+	URL  string `json:"url,omitempty"`
+	Type string `json:"type"`
+}
+
+// classifyResponse is the moderation service's verdict.
+type classifyResponse struct {
+	Mature bool `json:"mature"`
+}
+
+// IsMature asks the moderation service whether the image is mature.
+func (c *HTTPClassifier) IsMature(ctx context.Context, attachment Attachment) (bool, error) {
+	body, err := json.Marshal(classifyRequest{Key: attachment.Key, URL: attachment.URL, Type: attachment.Type})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode classify request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to build classify request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to query content classifier: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("content classifier returned status %d", resp.StatusCode)
+	}
+	var verdict classifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&verdict); err != nil {
+		return false, fmt.Errorf("failed to decode classifier response: %w", err)
+	}
+	return verdict.Mature, nil
+}
+
+// ApplyMaturityLabel classifies each image attachment and returns labels with
+// LabelNSFW added if any image is mature. Classification fails open: an image
+// the classifier cannot analyze is treated as not mature, and the remaining
+// images are still checked. The returned error joins every classifier failure
+// so callers can log them; the returned labels are valid either way.
+func ApplyMaturityLabel(ctx context.Context, classifier ContentClassifier, labels []string, attachments []Attachment) ([]string, error) {
+	if slices.Contains(labels, LabelNSFW) {
+		return labels, nil
+	}
+
+	var errs []error
+	for i, att := range attachments {
+		if !strings.HasPrefix(att.Type, "image/") {
+			continue
+		}
+		mature, err := classifier.IsMature(ctx, att)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("attachment %d: %w", i, err))
+			continue
+		}
+		if mature {
+			return append(slices.Clone(labels), LabelNSFW), errors.Join(errs...)
+		}
+	}
+	return labels, errors.Join(errs...)
+}
@@ -0,0 +1,42 @@
+package post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClassifier_IsMature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req classifyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch req.Key {
+		case "posts/mature.jpg":
+			_, _ = w.Write([]byte(`{"mature":true}`))
+		case "posts/clean.jpg":
+			_, _ = w.Write([]byte(`{"mature":false}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	classifier := NewHTTPClassifier(server.URL, nil)
+
+	mature, err := classifier.IsMature(context.Background(), Attachment{Key: "posts/mature.jpg", Type: "image/jpeg"})
+	if err != nil || !mature {
+		t.Errorf("mature image: got (%v, %v), want (true, nil)", mature, err)
+	}
+	mature, err = classifier.IsMature(context.Background(), Attachment{Key: "posts/clean.jpg", Type: "image/jpeg"})
+	if err != nil || mature {
+		t.Errorf("clean image: got (%v, %v), want (false, nil)", mature, err)
+	}
+	if _, err := classifier.IsMature(context.Background(), Attachment{Key: "posts/broken.jpg", Type: "image/jpeg"}); err == nil {
+		t.Error("expected an error when the service fails")
+	}
+}
@@ -1,6 +1,7 @@
 package post
 
 import (
+	"slices"
 	"testing"
 	"time"
 )
@@ -25,7 +26,7 @@ func TestListByScene_BasicFunctionality(t *testing.T) {
 	}
 
 	// Retrieve all posts
-	posts, nextCursor, err := repo.ListByScene(sceneID, 10, nil)
+	posts, nextCursor, err := repo.ListByScene(sceneID, FeedWindow{}, 10, nil)
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -66,7 +67,7 @@ func TestListByScene_Pagination(t *testing.T) {
 	}
 
 	// Get first page
-	page1, cursor1, err := repo.ListByScene(sceneID, 10, nil)
+	page1, cursor1, err := repo.ListByScene(sceneID, FeedWindow{}, 10, nil)
 	if err != nil {
 		t.Fatalf("ListByScene page 1 failed: %v", err)
 	}
@@ -80,7 +81,7 @@ func TestListByScene_Pagination(t *testing.T) {
 	}
 
 	// Get second page
-	page2, cursor2, err := repo.ListByScene(sceneID, 10, cursor1)
+	page2, cursor2, err := repo.ListByScene(sceneID, FeedWindow{}, 10, cursor1)
 	if err != nil {
 		t.Fatalf("ListByScene page 2 failed: %v", err)
 	}
@@ -94,7 +95,7 @@ func TestListByScene_Pagination(t *testing.T) {
 	}
 
 	// Get third page
-	page3, cursor3, err := repo.ListByScene(sceneID, 10, cursor2)
+	page3, cursor3, err := repo.ListByScene(sceneID, FeedWindow{}, 10, cursor2)
 	if err != nil {
 		t.Fatalf("ListByScene page 3 failed: %v", err)
 	}
@@ -152,7 +153,7 @@ func TestListByScene_HiddenExcluded(t *testing.T) {
 	}
 
 	// Retrieve posts
-	posts, _, err := repo.ListByScene(sceneID, 10, nil)
+	posts, _, err := repo.ListByScene(sceneID, FeedWindow{}, 10, nil)
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -170,6 +171,76 @@ func TestListByScene_HiddenExcluded(t *testing.T) {
 	}
 }
 
+// TestListSceneFeed_AnnouncementFilter tests including, excluding, and
+// isolating announcements in a scene feed.
+func TestListSceneFeed_AnnouncementFilter(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+
+	// Interleave 2 announcements with 3 regular posts
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		post := &Post{
+			SceneID:        &sceneID,
+			AuthorDID:      "did:example:owner",
+			Text:           "Post " + string(rune('A'+i)),
+			IsAnnouncement: i%2 == 1,
+			CreatedAt:      now.Add(-time.Duration(i) * time.Hour),
+		}
+		if err := repo.Create(post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		filter        AnnouncementFilter
+		want          int
+		announcements int
+	}{
+		{name: "include", filter: AnnouncementsInclude, want: 5, announcements: 2},
+		{name: "exclude", filter: AnnouncementsExclude, want: 3, announcements: 0},
+		{name: "only", filter: AnnouncementsOnly, want: 2, announcements: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts, _, err := repo.ListSceneFeed(sceneID, tt.filter, FeedWindow{}, 10, nil)
+			if err != nil {
+				t.Fatalf("ListSceneFeed failed: %v", err)
+			}
+			if len(posts) != tt.want {
+				t.Errorf("expected %d posts, got %d", tt.want, len(posts))
+			}
+			announcements := 0
+			for _, p := range posts {
+				if p.IsAnnouncement {
+					announcements++
+				}
+			}
+			if announcements != tt.announcements {
+				t.Errorf("expected %d announcements, got %d", tt.announcements, announcements)
+			}
+		})
+	}
+
+	// Pagination stays within the filtered channel
+	first, cursor, err := repo.ListSceneFeed(sceneID, AnnouncementsOnly, FeedWindow{}, 1, nil)
+	if err != nil {
+		t.Fatalf("ListSceneFeed failed: %v", err)
+	}
+	if len(first) != 1 || cursor == nil {
+		t.Fatalf("expected one announcement and a next cursor, got %d posts, cursor %v", len(first), cursor)
+	}
+	second, cursor, err := repo.ListSceneFeed(sceneID, AnnouncementsOnly, FeedWindow{}, 1, cursor)
+	if err != nil {
+		t.Fatalf("ListSceneFeed failed: %v", err)
+	}
+	if len(second) != 1 || !second[0].IsAnnouncement || second[0].ID == first[0].ID || cursor != nil {
+		t.Errorf("expected the second and last announcement, got %+v with cursor %v", second, cursor)
+	}
+}
+
 // TestListByScene_DeletedExcluded tests that soft-deleted posts are excluded.
 func TestListByScene_DeletedExcluded(t *testing.T) {
 	repo := NewInMemoryPostRepository()
@@ -200,7 +271,7 @@ func TestListByScene_DeletedExcluded(t *testing.T) {
 	}
 
 	// Retrieve posts
-	posts, _, err := repo.ListByScene(sceneID, 10, nil)
+	posts, _, err := repo.ListByScene(sceneID, FeedWindow{}, 10, nil)
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -239,7 +310,7 @@ func TestListByEvent_BasicFunctionality(t *testing.T) {
 	}
 
 	// Retrieve all posts
-	posts, nextCursor, err := repo.ListByEvent(eventID, 10, nil)
+	posts, nextCursor, err := repo.ListByEvent(eventID, FeedWindow{}, 10, nil)
 	if err != nil {
 		t.Fatalf("ListByEvent failed: %v", err)
 	}
@@ -265,7 +336,7 @@ func TestListByScene_EmptyResult(t *testing.T) {
 	repo := NewInMemoryPostRepository()
 	sceneID := "scene123"
 
-	posts, cursor, err := repo.ListByScene(sceneID, 10, nil)
+	posts, cursor, err := repo.ListByScene(sceneID, FeedWindow{}, 10, nil)
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -311,7 +382,7 @@ func TestListByScene_TieBreaking(t *testing.T) {
 	}
 
 	// Retrieve posts
-	posts, _, err := repo.ListByScene(sceneID, 10, nil)
+	posts, _, err := repo.ListByScene(sceneID, FeedWindow{}, 10, nil)
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -369,7 +440,7 @@ func TestListByScene_OtherSceneExcluded(t *testing.T) {
 	}
 
 	// Retrieve posts for target scene
-	posts, _, err := repo.ListByScene(targetScene, 10, nil)
+	posts, _, err := repo.ListByScene(targetScene, FeedWindow{}, 10, nil)
 	if err != nil {
 		t.Fatalf("ListByScene failed: %v", err)
 	}
@@ -415,7 +486,7 @@ func TestCursorIntegrity_DeletedPostAfterCursor(t *testing.T) {
 	}
 
 	// Get first page (limit 4)
-	page1, cursor1, err := repo.ListByScene(sceneID, 4, nil)
+	page1, cursor1, err := repo.ListByScene(sceneID, FeedWindow{}, 4, nil)
 	if err != nil {
 		t.Fatalf("ListByScene page 1 failed: %v", err)
 	}
@@ -436,7 +507,7 @@ func TestCursorIntegrity_DeletedPostAfterCursor(t *testing.T) {
 	}
 
 	// Get second page using cursor1 - should skip deleted post
-	page2, cursor2, err := repo.ListByScene(sceneID, 4, cursor1)
+	page2, cursor2, err := repo.ListByScene(sceneID, FeedWindow{}, 4, cursor1)
 	if err != nil {
 		t.Fatalf("ListByScene page 2 failed: %v", err)
 	}
@@ -456,7 +527,7 @@ func TestCursorIntegrity_DeletedPostAfterCursor(t *testing.T) {
 	}
 
 	// Get third page
-	page3, cursor3, err := repo.ListByScene(sceneID, 4, cursor2)
+	page3, cursor3, err := repo.ListByScene(sceneID, FeedWindow{}, 4, cursor2)
 	if err != nil {
 		t.Fatalf("ListByScene page 3 failed: %v", err)
 	}
@@ -509,7 +580,7 @@ func TestCursorIntegrity_HiddenPostAfterCursor(t *testing.T) {
 	}
 
 	// Get first page (limit 4)
-	page1, cursor1, err := repo.ListByScene(sceneID, 4, nil)
+	page1, cursor1, err := repo.ListByScene(sceneID, FeedWindow{}, 4, nil)
 	if err != nil {
 		t.Fatalf("ListByScene page 1 failed: %v", err)
 	}
@@ -529,7 +600,7 @@ func TestCursorIntegrity_HiddenPostAfterCursor(t *testing.T) {
 	}
 
 	// Get second page using cursor1 - should skip hidden post
-	page2, cursor2, err := repo.ListByScene(sceneID, 4, cursor1)
+	page2, cursor2, err := repo.ListByScene(sceneID, FeedWindow{}, 4, cursor1)
 	if err != nil {
 		t.Fatalf("ListByScene page 2 failed: %v", err)
 	}
@@ -557,7 +628,7 @@ func TestCursorIntegrity_HiddenPostAfterCursor(t *testing.T) {
 	}
 
 	// Get third page
-	page3, cursor3, err := repo.ListByScene(sceneID, 4, cursor2)
+	page3, cursor3, err := repo.ListByScene(sceneID, FeedWindow{}, 4, cursor2)
 	if err != nil {
 		t.Fatalf("ListByScene page 3 failed: %v", err)
 	}
@@ -615,7 +686,7 @@ func TestCursorIntegrity_NewPostBeforeCursor(t *testing.T) {
 	}
 
 	// Get first page (limit 4) - should return posts 0,1,2,3
-	page1, cursor1, err := repo.ListByScene(sceneID, 4, nil)
+	page1, cursor1, err := repo.ListByScene(sceneID, FeedWindow{}, 4, nil)
 	if err != nil {
 		t.Fatalf("ListByScene page 1 failed: %v", err)
 	}
@@ -649,7 +720,7 @@ func TestCursorIntegrity_NewPostBeforeCursor(t *testing.T) {
 
 	// Get second page using cursor1 - should NOT include the new post
 	// because new post timestamp is newer than cursor1 timestamp
-	page2, cursor2, err := repo.ListByScene(sceneID, 4, cursor1)
+	page2, cursor2, err := repo.ListByScene(sceneID, FeedWindow{}, 4, cursor1)
 	if err != nil {
 		t.Fatalf("ListByScene page 2 failed: %v", err)
 	}
@@ -676,7 +747,7 @@ func TestCursorIntegrity_NewPostBeforeCursor(t *testing.T) {
 	}
 
 	// Now refresh from the beginning - new post SHOULD appear
-	refreshPage1, _, err := repo.ListByScene(sceneID, 10, nil)
+	refreshPage1, _, err := repo.ListByScene(sceneID, FeedWindow{}, 10, nil)
 	if err != nil {
 		t.Fatalf("ListByScene refresh failed: %v", err)
 	}
@@ -728,7 +799,7 @@ func TestCursorIntegrity_MultipleMutations(t *testing.T) {
 	}
 
 	// Get first page (limit 5)
-	page1, cursor1, err := repo.ListByScene(sceneID, 5, nil)
+	page1, cursor1, err := repo.ListByScene(sceneID, FeedWindow{}, 5, nil)
 	if err != nil {
 		t.Fatalf("ListByScene page 1 failed: %v", err)
 	}
@@ -774,7 +845,7 @@ func TestCursorIntegrity_MultipleMutations(t *testing.T) {
 	repo.mu.Unlock()
 
 	// Get second page - should skip deleted and hidden, not show new post
-	page2, cursor2, err := repo.ListByScene(sceneID, 5, cursor1)
+	page2, cursor2, err := repo.ListByScene(sceneID, FeedWindow{}, 5, cursor1)
 	if err != nil {
 		t.Fatalf("ListByScene page 2 failed: %v", err)
 	}
@@ -803,7 +874,7 @@ func TestCursorIntegrity_MultipleMutations(t *testing.T) {
 	}
 
 	// Get third page
-	page3, cursor3, err := repo.ListByScene(sceneID, 5, cursor2)
+	page3, cursor3, err := repo.ListByScene(sceneID, FeedWindow{}, 5, cursor2)
 	if err != nil {
 		t.Fatalf("ListByScene page 3 failed: %v", err)
 	}
@@ -870,7 +941,7 @@ func TestCursorIntegrity_EventFeedDeletedPost(t *testing.T) {
 	}
 
 	// Get first page (limit 4)
-	page1, cursor1, err := repo.ListByEvent(eventID, 4, nil)
+	page1, cursor1, err := repo.ListByEvent(eventID, FeedWindow{}, 4, nil)
 	if err != nil {
 		t.Fatalf("ListByEvent page 1 failed: %v", err)
 	}
@@ -885,7 +956,7 @@ func TestCursorIntegrity_EventFeedDeletedPost(t *testing.T) {
 	}
 
 	// Get second page - should skip deleted post
-	page2, cursor2, err := repo.ListByEvent(eventID, 4, cursor1)
+	page2, cursor2, err := repo.ListByEvent(eventID, FeedWindow{}, 4, cursor1)
 	if err != nil {
 		t.Fatalf("ListByEvent page 2 failed: %v", err)
 	}
@@ -905,7 +976,7 @@ func TestCursorIntegrity_EventFeedDeletedPost(t *testing.T) {
 	}
 
 	// Get third page
-	page3, cursor3, err := repo.ListByEvent(eventID, 4, cursor2)
+	page3, cursor3, err := repo.ListByEvent(eventID, FeedWindow{}, 4, cursor2)
 	if err != nil {
 		t.Fatalf("ListByEvent page 3 failed: %v", err)
 	}
@@ -956,7 +1027,7 @@ func TestCursorIntegrity_IdenticalTimestampsPagination(t *testing.T) {
 	}
 
 	// Get first page (limit 5)
-	page1, cursor1, err := repo.ListByScene(sceneID, 5, nil)
+	page1, cursor1, err := repo.ListByScene(sceneID, FeedWindow{}, 5, nil)
 	if err != nil {
 		t.Fatalf("ListByScene page 1 failed: %v", err)
 	}
@@ -980,7 +1051,7 @@ func TestCursorIntegrity_IdenticalTimestampsPagination(t *testing.T) {
 	}
 
 	// Get second page using cursor1
-	page2, cursor2, err := repo.ListByScene(sceneID, 5, cursor1)
+	page2, cursor2, err := repo.ListByScene(sceneID, FeedWindow{}, 5, cursor1)
 	if err != nil {
 		t.Fatalf("ListByScene page 2 failed: %v", err)
 	}
@@ -1022,7 +1093,7 @@ func TestCursorIntegrity_IdenticalTimestampsPagination(t *testing.T) {
 	}
 
 	// Get third page
-	page3, cursor3, err := repo.ListByScene(sceneID, 5, cursor2)
+	page3, cursor3, err := repo.ListByScene(sceneID, FeedWindow{}, 5, cursor2)
 	if err != nil {
 		t.Fatalf("ListByScene page 3 failed: %v", err)
 	}
@@ -1054,3 +1125,371 @@ func TestCursorIntegrity_IdenticalTimestampsPagination(t *testing.T) {
 		t.Errorf("expected 12 unique posts, got %d", len(uniqueIDs))
 	}
 }
+
+// TestLastActivityAt tracks create, update and delete activity per scene and event.
+func TestLastActivityAt(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene-activity"
+	eventID := "event-activity"
+
+	initial, err := repo.SceneLastActivityAt(sceneID)
+	if err != nil {
+		t.Fatalf("SceneLastActivityAt failed: %v", err)
+	}
+	if !initial.IsZero() {
+		t.Errorf("expected zero time for scene without posts, got %v", initial)
+	}
+
+	p := &Post{SceneID: &sceneID, EventID: &eventID, AuthorDID: "did:plc:author", Text: "first"}
+	if err := repo.Create(p); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	afterCreate, _ := repo.SceneLastActivityAt(sceneID)
+	if !afterCreate.Equal(p.CreatedAt) {
+		t.Errorf("expected scene activity %v, got %v", p.CreatedAt, afterCreate)
+	}
+	eventAfterCreate, _ := repo.EventLastActivityAt(eventID)
+	if !eventAfterCreate.Equal(p.CreatedAt) {
+		t.Errorf("expected event activity %v, got %v", p.CreatedAt, eventAfterCreate)
+	}
+
+	p.Text = "edited"
+	if err := repo.Update(p); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	afterUpdate, _ := repo.SceneLastActivityAt(sceneID)
+	if afterUpdate.Before(afterCreate) {
+		t.Errorf("expected activity to advance on update: %v < %v", afterUpdate, afterCreate)
+	}
+
+	if err := repo.Delete(p.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	afterDelete, _ := repo.EventLastActivityAt(eventID)
+	if afterDelete.Before(afterUpdate) {
+		t.Errorf("expected activity to advance on delete: %v < %v", afterDelete, afterUpdate)
+	}
+
+	other, _ := repo.SceneLastActivityAt("other-scene")
+	if !other.IsZero() {
+		t.Errorf("expected unrelated scene to have no activity, got %v", other)
+	}
+}
+
+// createHourlyPosts creates n posts an hour apart in sceneID and eventID, the
+// newest at now, and returns their IDs newest first.
+func createHourlyPosts(t *testing.T, repo *InMemoryPostRepository, sceneID, eventID string, now time.Time, n int) []string {
+	t.Helper()
+	ids := make([]string, n)
+	for i := 0; i < n; i++ {
+		post := &Post{
+			SceneID:   &sceneID,
+			EventID:   &eventID,
+			AuthorDID: "did:example:user1",
+			Text:      "Post " + string(rune('A'+i)),
+		}
+		if err := repo.Create(post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+		repo.mu.Lock()
+		repo.posts[post.ID].CreatedAt = now.Add(-time.Duration(i) * time.Hour)
+		repo.mu.Unlock()
+		ids[i] = post.ID
+	}
+	return ids
+}
+
+// TestFeedWindow_BoundsPosts tests that only posts created within the window are listed.
+func TestFeedWindow_BoundsPosts(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	now := time.Now().Truncate(time.Second)
+	ids := createHourlyPosts(t, repo, "scene123", "event123", now, 10)
+
+	// Since is inclusive and until exclusive: hours 2 through 5 ago
+	window := FeedWindow{Since: now.Add(-5 * time.Hour), Until: now.Add(-time.Hour)}
+	want := ids[2:6]
+
+	scenePosts, cursor, err := repo.ListByScene("scene123", window, 10, nil)
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if got := postIDs(scenePosts); !slices.Equal(got, want) {
+		t.Errorf("ListByScene window = %v, want %v", got, want)
+	}
+	if cursor != nil {
+		t.Errorf("expected nil cursor when the window fits in one page, got %+v", cursor)
+	}
+
+	eventPosts, _, err := repo.ListByEvent("event123", window, 10, nil)
+	if err != nil {
+		t.Fatalf("ListByEvent failed: %v", err)
+	}
+	if got := postIDs(eventPosts); !slices.Equal(got, want) {
+		t.Errorf("ListByEvent window = %v, want %v", got, want)
+	}
+
+	// Open-ended bounds
+	recent, _, err := repo.ListByScene("scene123", FeedWindow{Since: now.Add(-2 * time.Hour)}, 10, nil)
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if got := postIDs(recent); !slices.Equal(got, ids[:3]) {
+		t.Errorf("since-only window = %v, want %v", got, ids[:3])
+	}
+	older, _, err := repo.ListByEvent("event123", FeedWindow{Until: now.Add(-7 * time.Hour)}, 10, nil)
+	if err != nil {
+		t.Fatalf("ListByEvent failed: %v", err)
+	}
+	if got := postIDs(older); !slices.Equal(got, ids[8:]) {
+		t.Errorf("until-only window = %v, want %v", got, ids[8:])
+	}
+}
+
+// TestFeedWindow_Pagination tests that the cursor pages through the window
+// without leaking posts from outside it.
+func TestFeedWindow_Pagination(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	now := time.Now().Truncate(time.Second)
+	ids := createHourlyPosts(t, repo, "scene123", "event123", now, 12)
+
+	window := FeedWindow{Since: now.Add(-8 * time.Hour), Until: now.Add(-time.Hour)}
+	want := ids[2:9]
+
+	list := map[string]func(*FeedCursor) ([]*Post, *FeedCursor, error){
+		"ListByScene": func(c *FeedCursor) ([]*Post, *FeedCursor, error) {
+			return repo.ListByScene("scene123", window, 3, c)
+		},
+		"ListByEvent": func(c *FeedCursor) ([]*Post, *FeedCursor, error) {
+			return repo.ListByEvent("event123", window, 3, c)
+		},
+	}
+	for name, fetch := range list {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			var cursor *FeedCursor
+			for page := 0; ; page++ {
+				if page > len(want) {
+					t.Fatal("pagination did not terminate")
+				}
+				posts, next, err := fetch(cursor)
+				if err != nil {
+					t.Fatalf("page %d failed: %v", page, err)
+				}
+				if len(posts) > 3 {
+					t.Errorf("page %d has %d posts, want at most 3", page, len(posts))
+				}
+				got = append(got, postIDs(posts)...)
+				if next == nil {
+					break
+				}
+				cursor = next
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("paged through %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestFeedWindow_Empty tests that an empty or inverted window lists nothing.
+func TestFeedWindow_Empty(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	now := time.Now().Truncate(time.Second)
+	createHourlyPosts(t, repo, "scene123", "event123", now, 5)
+
+	windows := map[string]FeedWindow{
+		"since equals until": {Since: now.Add(-2 * time.Hour), Until: now.Add(-2 * time.Hour)},
+		"since after until":  {Since: now, Until: now.Add(-3 * time.Hour)},
+		"before all posts":   {Until: now.Add(-10 * time.Hour)},
+		"after all posts":    {Since: now.Add(time.Minute)},
+	}
+	for name, window := range windows {
+		t.Run(name, func(t *testing.T) {
+			posts, cursor, err := repo.ListByScene("scene123", window, 10, nil)
+			if err != nil {
+				t.Fatalf("ListByScene failed: %v", err)
+			}
+			if len(posts) != 0 || cursor != nil {
+				t.Errorf("ListByScene = %d posts, cursor %+v; want none", len(posts), cursor)
+			}
+
+			posts, cursor, err = repo.ListByEvent("event123", window, 10, nil)
+			if err != nil {
+				t.Fatalf("ListByEvent failed: %v", err)
+			}
+			if len(posts) != 0 || cursor != nil {
+				t.Errorf("ListByEvent = %d posts, cursor %+v; want none", len(posts), cursor)
+			}
+		})
+	}
+}
+
+// createFilterTestPosts creates ten posts an hour apart in scene123, newest
+// first, alternating between two authors. Every third post is labeled nsfw.
+func createFilterTestPosts(t *testing.T, repo *InMemoryPostRepository) []string {
+	t.Helper()
+	sceneID := "scene123"
+	now := time.Now()
+	var ids []string
+	for i := 0; i < 10; i++ {
+		post := &Post{
+			SceneID:   &sceneID,
+			AuthorDID: []string{"did:example:alice", "did:example:bob"}[i%2],
+			Text:      "Post " + string(rune('A'+i)),
+		}
+		if i%3 == 0 {
+			post.Labels = []string{LabelNSFW}
+		}
+		if err := repo.Create(post); err != nil {
+			t.Fatalf("failed to create post: %v", err)
+		}
+		repo.mu.Lock()
+		repo.posts[post.ID].CreatedAt = now.Add(-time.Duration(i) * time.Hour)
+		repo.mu.Unlock()
+		ids = append(ids, post.ID)
+	}
+	return ids
+}
+
+// hidePost labels a post hidden.
+func hidePost(t *testing.T, repo *InMemoryPostRepository, id string) {
+	t.Helper()
+	p, err := repo.GetByID(id)
+	if err != nil {
+		t.Fatalf("failed to get post to hide: %v", err)
+	}
+	p.Labels = []string{LabelHidden}
+	if err := repo.Update(p); err != nil {
+		t.Fatalf("failed to hide post: %v", err)
+	}
+}
+
+// TestListBySceneFiltered_Author tests that filtering by author returns only
+// that author's posts, across pages.
+func TestListBySceneFiltered_Author(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	ids := createFilterTestPosts(t, repo)
+
+	author := "did:example:alice"
+	opts := FeedOptions{AuthorDID: &author}
+	page1, cursor, err := repo.ListBySceneFiltered("scene123", opts, 3, nil)
+	if err != nil {
+		t.Fatalf("ListBySceneFiltered failed: %v", err)
+	}
+	if cursor == nil {
+		t.Fatal("expected a next cursor")
+	}
+	page2, cursor, err := repo.ListBySceneFiltered("scene123", opts, 3, cursor)
+	if err != nil {
+		t.Fatalf("ListBySceneFiltered page 2 failed: %v", err)
+	}
+	if cursor != nil {
+		t.Error("expected nil cursor on last page")
+	}
+
+	var got []string
+	for _, p := range append(page1, page2...) {
+		if p.AuthorDID != author {
+			t.Errorf("post %s is by %s, want %s", p.ID, p.AuthorDID, author)
+		}
+		got = append(got, p.ID)
+	}
+	want := []string{ids[0], ids[2], ids[4], ids[6], ids[8]}
+	if !slices.Equal(got, want) {
+		t.Errorf("got posts %v, want %v", got, want)
+	}
+}
+
+// TestListBySceneFiltered_Labels tests that exclude-labels drops NSFW-tagged
+// posts and include-labels keeps only them.
+func TestListBySceneFiltered_Labels(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	createFilterTestPosts(t, repo)
+
+	posts, _, err := repo.ListBySceneFiltered("scene123", FeedOptions{ExcludeLabels: []string{LabelNSFW}}, 20, nil)
+	if err != nil {
+		t.Fatalf("ListBySceneFiltered failed: %v", err)
+	}
+	if len(posts) != 6 {
+		t.Errorf("expected 6 posts without nsfw, got %d", len(posts))
+	}
+	for _, p := range posts {
+		if p.HasLabel(LabelNSFW) {
+			t.Errorf("nsfw post %s was not excluded", p.ID)
+		}
+	}
+
+	posts, _, err = repo.ListBySceneFiltered("scene123", FeedOptions{IncludeLabels: []string{LabelNSFW, LabelSpam}}, 20, nil)
+	if err != nil {
+		t.Fatalf("ListBySceneFiltered failed: %v", err)
+	}
+	if len(posts) != 4 {
+		t.Errorf("expected 4 nsfw posts, got %d", len(posts))
+	}
+	for _, p := range posts {
+		if !p.HasLabel(LabelNSFW) {
+			t.Errorf("post %s without nsfw was included", p.ID)
+		}
+	}
+}
+
+// TestListBySceneFiltered_HiddenStillExcluded tests that hidden posts stay out
+// of the feed even when a filter asks for their label.
+func TestListBySceneFiltered_HiddenStillExcluded(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	ids := createFilterTestPosts(t, repo)
+	hidePost(t, repo, ids[1])
+
+	posts, _, err := repo.ListBySceneFiltered("scene123", FeedOptions{IncludeLabels: []string{LabelHidden}}, 20, nil)
+	if err != nil {
+		t.Fatalf("ListBySceneFiltered failed: %v", err)
+	}
+	if len(posts) != 0 {
+		t.Errorf("expected hidden posts to stay excluded, got %d posts", len(posts))
+	}
+}
+
+// TestCursorIntegrity_FilteredFeedMutations tests that a filtered feed's cursor
+// stays stable when posts are deleted, hidden, and created after it is captured.
+func TestCursorIntegrity_FilteredFeedMutations(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	ids := createFilterTestPosts(t, repo)
+
+	// Non-nsfw posts, newest first: 1, 2, 4, 5, 7, 8
+	opts := FeedOptions{ExcludeLabels: []string{LabelNSFW}}
+	page1, cursor1, err := repo.ListBySceneFiltered("scene123", opts, 2, nil)
+	if err != nil {
+		t.Fatalf("ListBySceneFiltered page 1 failed: %v", err)
+	}
+	if len(page1) != 2 || page1[0].ID != ids[1] || page1[1].ID != ids[2] {
+		t.Fatalf("unexpected page 1: %v", page1)
+	}
+
+	// Delete one post past the cursor, hide another, and add a newer post
+	if err := repo.Delete(ids[4]); err != nil {
+		t.Fatalf("failed to delete post: %v", err)
+	}
+	hidePost(t, repo, ids[7])
+	sceneID := "scene123"
+	if err := repo.Create(&Post{SceneID: &sceneID, AuthorDID: "did:example:alice", Text: "New"}); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	page2, cursor2, err := repo.ListBySceneFiltered("scene123", opts, 2, cursor1)
+	if err != nil {
+		t.Fatalf("ListBySceneFiltered page 2 failed: %v", err)
+	}
+	if cursor2 != nil {
+		t.Error("expected nil cursor on last page")
+	}
+
+	var got []string
+	for _, p := range page2 {
+		got = append(got, p.ID)
+	}
+	want := []string{ids[5], ids[8]}
+	if !slices.Equal(got, want) {
+		t.Errorf("got posts %v after the cursor, want %v", got, want)
+	}
+}
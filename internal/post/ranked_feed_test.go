@@ -0,0 +1,271 @@
+package post
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// quoteFromElsewhere gives quotedID n quote reposts from another scene, so the
+// engagement lands on quotedID without adding posts to its scene's feed.
+func quoteFromElsewhere(t *testing.T, repo *InMemoryPostRepository, quotedID string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		createQuote(t, repo, "elsewhere", quotedID)
+	}
+}
+
+func postIDs(posts []*Post) []string {
+	ids := make([]string, len(posts))
+	for i, p := range posts {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+// TestListRankedSceneFeed_BoostsEngagedOlderPost tests that a well-reposted older
+// post outranks a newer one, while the chronological feed is unchanged.
+func TestListRankedSceneFeed_BoostsEngagedOlderPost(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+	base := time.Now()
+
+	repo.timeNow = func() time.Time { return base }
+	older := createPost(t, repo, sceneID, "older")
+	quoteFromElsewhere(t, repo, older, 10)
+
+	repo.timeNow = func() time.Time { return base.Add(10 * time.Hour) }
+	newer := createPost(t, repo, sceneID, "newer")
+
+	repo.timeNow = func() time.Time { return base.Add(12 * time.Hour) }
+
+	chronological, _, err := repo.ListSceneFeed(sceneID, AnnouncementsInclude, FeedWindow{}, 10, nil)
+	if err != nil {
+		t.Fatalf("ListSceneFeed failed: %v", err)
+	}
+	if got, want := postIDs(chronological), []string{newer, older}; !reflect.DeepEqual(got, want) {
+		t.Errorf("chronological order = %v, want %v", got, want)
+	}
+
+	ranked, next, err := repo.ListRankedSceneFeed(sceneID, AnnouncementsInclude, FeedWindow{}, 10, nil)
+	if err != nil {
+		t.Fatalf("ListRankedSceneFeed failed: %v", err)
+	}
+	if got, want := postIDs(ranked), []string{older, newer}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ranked order = %v, want %v", got, want)
+	}
+	if next != nil {
+		t.Errorf("expected no next cursor, got %+v", next)
+	}
+}
+
+// TestListRankedSceneFeed_PaginationStable tests that paging through a ranked feed
+// yields the first page's ranking without duplicates or gaps, even as time passes
+// and new posts arrive between pages.
+func TestListRankedSceneFeed_PaginationStable(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+	base := time.Now()
+
+	for i, reposts := range []int{4, 0, 2, 0, 7, 1, 0} {
+		repo.timeNow = func() time.Time { return base.Add(time.Duration(i) * 3 * time.Hour) }
+		id := createPost(t, repo, sceneID, "post")
+		quoteFromElsewhere(t, repo, id, reposts)
+	}
+
+	asOf := base.Add(24 * time.Hour)
+	repo.timeNow = func() time.Time { return asOf }
+	all, _, err := repo.ListRankedSceneFeed(sceneID, AnnouncementsInclude, FeedWindow{}, 100, nil)
+	if err != nil {
+		t.Fatalf("ListRankedSceneFeed failed: %v", err)
+	}
+	if len(all) != 7 {
+		t.Fatalf("expected 7 posts, got %d", len(all))
+	}
+
+	var paged []string
+	var cursor *RankedFeedCursor
+	for page := 0; ; page++ {
+		if page > 10 {
+			t.Fatal("pagination did not terminate")
+		}
+		posts, next, err := repo.ListRankedSceneFeed(sceneID, AnnouncementsInclude, FeedWindow{}, 2, cursor)
+		if err != nil {
+			t.Fatalf("ListRankedSceneFeed page %d failed: %v", page, err)
+		}
+		paged = append(paged, postIDs(posts)...)
+		if next == nil {
+			break
+		}
+
+		// Round-trip the cursor as a client would, then let time pass and a post arrive
+		cursor, err = DecodeRankedFeedCursor(EncodeRankedFeedCursor(next))
+		if err != nil {
+			t.Fatalf("DecodeRankedFeedCursor failed: %v", err)
+		}
+		asOf = asOf.Add(6 * time.Hour)
+		createPost(t, repo, sceneID, "late arrival")
+	}
+
+	if want := postIDs(all); !reflect.DeepEqual(paged, want) {
+		t.Errorf("paged order = %v, want %v", paged, want)
+	}
+}
+
+func TestDecodeRankedFeedCursor_Invalid(t *testing.T) {
+	for _, encoded := range []string{"not-base64!", "bm90LWpzb24", "e30="} {
+		if _, err := DecodeRankedFeedCursor(encoded); err == nil {
+			t.Errorf("DecodeRankedFeedCursor(%q) expected error", encoded)
+		}
+	}
+}
+
+// TestListRankedSceneFeed_Window tests that the ranked feed only ranks posts
+// created within the window.
+func TestListRankedSceneFeed_Window(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+	base := time.Now()
+
+	repo.timeNow = func() time.Time { return base }
+	old := createPost(t, repo, sceneID, "old")
+	quoteFromElsewhere(t, repo, old, 10)
+
+	repo.timeNow = func() time.Time { return base.Add(48 * time.Hour) }
+	recent := createPost(t, repo, sceneID, "recent")
+
+	repo.timeNow = func() time.Time { return base.Add(50 * time.Hour) }
+	window := FeedWindow{Since: base.Add(24 * time.Hour)}
+	ranked, next, err := repo.ListRankedSceneFeed(sceneID, AnnouncementsInclude, window, 10, nil)
+	if err != nil {
+		t.Fatalf("ListRankedSceneFeed failed: %v", err)
+	}
+	if got, want := postIDs(ranked), []string{recent}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ranked window = %v, want only %v", got, want)
+	}
+	if next != nil {
+		t.Errorf("expected no next cursor, got %+v", next)
+	}
+}
+
+// TestListRankedSceneFeed_CursorRoundTripRealClock tests that a decoded cursor
+// pages on from the first page under the real clock, whose times carry a
+// monotonic reading that JSON drops.
+func TestListRankedSceneFeed_CursorRoundTripRealClock(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		repo := NewInMemoryPostRepository()
+		sceneID := "scene123"
+		createPost(t, repo, sceneID, "first")
+		createPost(t, repo, sceneID, "second")
+
+		first, next, err := repo.ListRankedSceneFeed(sceneID, AnnouncementsInclude, FeedWindow{}, 1, nil)
+		if err != nil {
+			t.Fatalf("ListRankedSceneFeed failed: %v", err)
+		}
+		if next == nil {
+			t.Fatal("expected a next cursor")
+		}
+		cursor, err := DecodeRankedFeedCursor(EncodeRankedFeedCursor(next))
+		if err != nil {
+			t.Fatalf("DecodeRankedFeedCursor failed: %v", err)
+		}
+		if !cursor.AsOf.Equal(next.AsOf) || cursor.Score != next.Score {
+			t.Fatalf("cursor changed in round trip: %+v -> %+v", next, cursor)
+		}
+
+		second, last, err := repo.ListRankedSceneFeed(sceneID, AnnouncementsInclude, FeedWindow{}, 1, cursor)
+		if err != nil {
+			t.Fatalf("ListRankedSceneFeed page 2 failed: %v", err)
+		}
+		if len(second) != 1 || second[0].ID == first[0].ID || last != nil {
+			t.Fatalf("page 2 = %v with cursor %+v; want the other post and no cursor", postIDs(second), last)
+		}
+	}
+}
+
+// TestListRankedSceneFeed_CountsReactions tests that reactions lift a post in the
+// ranked feed just as quote reposts do.
+func TestListRankedSceneFeed_CountsReactions(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+	base := time.Now()
+
+	repo.timeNow = func() time.Time { return base }
+	older := createPost(t, repo, sceneID, "older")
+	for i := 0; i < 10; i++ {
+		if err := repo.AdjustReactionCount(older, 1); err != nil {
+			t.Fatalf("AdjustReactionCount failed: %v", err)
+		}
+	}
+
+	repo.timeNow = func() time.Time { return base.Add(10 * time.Hour) }
+	newer := createPost(t, repo, sceneID, "newer")
+
+	repo.timeNow = func() time.Time { return base.Add(12 * time.Hour) }
+	ranked, _, err := repo.ListRankedSceneFeed(sceneID, AnnouncementsInclude, FeedWindow{}, 10, nil)
+	if err != nil {
+		t.Fatalf("ListRankedSceneFeed failed: %v", err)
+	}
+	if got, want := postIDs(ranked), []string{older, newer}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ranked order = %v, want %v", got, want)
+	}
+}
+
+// TestAdjustReactionCount tests that reaction changes are counted, clamped at
+// zero, touch the post's scene activity, and are refused for deleted posts.
+func TestAdjustReactionCount(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	base := time.Now()
+	repo.timeNow = func() time.Time { return base }
+	postID := createPost(t, repo, "scene123", "hello")
+
+	repo.timeNow = func() time.Time { return base.Add(time.Minute) }
+	if err := repo.AdjustReactionCount(postID, 1); err != nil {
+		t.Fatalf("AdjustReactionCount failed: %v", err)
+	}
+	if got, _ := repo.SceneLastActivityAt("scene123"); !got.Equal(base.Add(time.Minute)) {
+		t.Errorf("scene activity = %v, want %v", got, base.Add(time.Minute))
+	}
+
+	if err := repo.AdjustReactionCount(postID, -2); err != nil {
+		t.Fatalf("AdjustReactionCount failed: %v", err)
+	}
+	p, err := repo.GetByID(postID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if p.ReactionCount != 0 {
+		t.Errorf("ReactionCount = %d, want 0", p.ReactionCount)
+	}
+
+	if err := repo.Delete(postID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.AdjustReactionCount(postID, 1); err != ErrPostNotFound {
+		t.Errorf("expected ErrPostNotFound for a deleted post, got %v", err)
+	}
+}
+
+// TestQuoteTouchesQuotedScene tests that quoting a post from another scene, and
+// deleting the quote, count as activity in the quoted post's scene, whose feed
+// shows the changed repost count.
+func TestQuoteTouchesQuotedScene(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	base := time.Now()
+	repo.timeNow = func() time.Time { return base }
+	quotedID := createPost(t, repo, "scene123", "quote me")
+
+	repo.timeNow = func() time.Time { return base.Add(time.Minute) }
+	quoteID := createQuote(t, repo, "elsewhere", quotedID)
+	if got, _ := repo.SceneLastActivityAt("scene123"); !got.Equal(base.Add(time.Minute)) {
+		t.Errorf("scene activity after quote = %v, want %v", got, base.Add(time.Minute))
+	}
+
+	repo.timeNow = func() time.Time { return base.Add(2 * time.Minute) }
+	if err := repo.Delete(quoteID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if got, _ := repo.SceneLastActivityAt("scene123"); !got.Equal(base.Add(2 * time.Minute)) {
+		t.Errorf("scene activity after unquote = %v, want %v", got, base.Add(2*time.Minute))
+	}
+}
@@ -0,0 +1,156 @@
+package post
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// MaxReactionKindsPerPost caps how many distinct emoji a single post can
+// collect, so a post's reaction bar stays readable.
+const MaxReactionKindsPerPost = 6
+
+// AllowedReactions is the emoji allowlist for post reactions. Free-form emoji
+// would let reactions carry arbitrary text.
+var AllowedReactions = map[string]bool{
+	"🔥":  true,
+	"❤️": true,
+	"👍":  true,
+	"😂":  true,
+	"😮":  true,
+	"😢":  true,
+	"🙌":  true,
+	"🎶":  true,
+	"✨":  true,
+	"💯":  true,
+}
+
+// Reaction errors.
+var (
+	ErrReactionNotAllowed   = errors.New("reaction emoji is not allowed")
+	ErrReactionNotFound     = errors.New("reaction not found")
+	ErrTooManyReactionKinds = errors.New("post has reached the maximum number of distinct reactions")
+)
+
+// ValidateReaction returns ErrReactionNotAllowed unless emoji is in AllowedReactions.
+func ValidateReaction(emoji string) error {
+	if !AllowedReactions[emoji] {
+		return ErrReactionNotAllowed
+	}
+	return nil
+}
+
+// Reaction is a user's emoji reaction to a post. A user can react to a post
+// with several emoji, but with each emoji at most once.
+type Reaction struct {
+	PostID    string    `json:"post_id"`
+	UserDID   string    `json:"user_did"`
+	Emoji     string    `json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReactionRepository stores post reactions keyed by (post ID, user DID, emoji).
+type ReactionRepository interface {
+	// Add records userDID's emoji reaction to a post.
+	// Returns true if the reaction was added, false if it already existed.
+	// Returns ErrTooManyReactionKinds if emoji would be the post's
+	// (MaxReactionKindsPerPost+1)th distinct emoji.
+	Add(postID, userDID, emoji string) (bool, error)
+
+	// Remove deletes userDID's emoji reaction to a post.
+	// Returns ErrReactionNotFound if the reaction doesn't exist.
+	Remove(postID, userDID, emoji string) error
+
+	// CountsForPosts returns each post's reaction counts by emoji. Posts
+	// without reactions are omitted. This is a batch operation to avoid N+1 queries.
+	CountsForPosts(postIDs []string) (map[string]map[string]int, error)
+}
+
+// reactionKey identifies a single reaction.
+type reactionKey struct {
+	postID  string
+	userDID string
+	emoji   string
+}
+
+// InMemoryReactionRepository is an in-memory implementation of ReactionRepository.
+// Thread-safe via RWMutex.
+type InMemoryReactionRepository struct {
+	mu        sync.RWMutex
+	reactions map[reactionKey]*Reaction
+	counts    map[string]map[string]int // post ID -> emoji -> count
+}
+
+// NewInMemoryReactionRepository creates a new in-memory reaction repository.
+func NewInMemoryReactionRepository() *InMemoryReactionRepository {
+	return &InMemoryReactionRepository{
+		reactions: make(map[reactionKey]*Reaction),
+		counts:    make(map[string]map[string]int),
+	}
+}
+
+// Add records a reaction. Idempotent: re-adding an existing reaction returns false.
+func (r *InMemoryReactionRepository) Add(postID, userDID, emoji string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := reactionKey{postID: postID, userDID: userDID, emoji: emoji}
+	if _, exists := r.reactions[key]; exists {
+		return false, nil
+	}
+
+	byEmoji := r.counts[postID]
+	if _, seen := byEmoji[emoji]; !seen && len(byEmoji) >= MaxReactionKindsPerPost {
+		return false, ErrTooManyReactionKinds
+	}
+	if byEmoji == nil {
+		byEmoji = make(map[string]int)
+		r.counts[postID] = byEmoji
+	}
+
+	r.reactions[key] = &Reaction{PostID: postID, UserDID: userDID, Emoji: emoji, CreatedAt: time.Now()}
+	byEmoji[emoji]++
+	return true, nil
+}
+
+// Remove deletes a reaction, freeing its emoji slot once no one uses it.
+func (r *InMemoryReactionRepository) Remove(postID, userDID, emoji string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := reactionKey{postID: postID, userDID: userDID, emoji: emoji}
+	if _, exists := r.reactions[key]; !exists {
+		return ErrReactionNotFound
+	}
+	delete(r.reactions, key)
+
+	byEmoji := r.counts[postID]
+	byEmoji[emoji]--
+	if byEmoji[emoji] <= 0 {
+		delete(byEmoji, emoji)
+	}
+	if len(byEmoji) == 0 {
+		delete(r.counts, postID)
+	}
+	return nil
+}
+
+// CountsForPosts returns reaction counts by emoji for each post that has any.
+func (r *InMemoryReactionRepository) CountsForPosts(postIDs []string) (map[string]map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]map[string]int)
+	for _, postID := range postIDs {
+		byEmoji, ok := r.counts[postID]
+		if !ok {
+			continue
+		}
+		counts := make(map[string]int, len(byEmoji))
+		for emoji, count := range byEmoji {
+			counts[emoji] = count
+		}
+		result[postID] = counts
+	}
+	return result, nil
+}
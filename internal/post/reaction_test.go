@@ -0,0 +1,105 @@
+package post
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReactionRepository_AddIdempotent(t *testing.T) {
+	repo := NewInMemoryReactionRepository()
+
+	added, err := repo.Add("post-1", "did:example:user1", "🔥")
+	if err != nil || !added {
+		t.Fatalf("expected first Add to add, got added=%v err=%v", added, err)
+	}
+	added, err = repo.Add("post-1", "did:example:user1", "🔥")
+	if err != nil || added {
+		t.Fatalf("expected repeated Add to be a no-op, got added=%v err=%v", added, err)
+	}
+	if _, err := repo.Add("post-1", "did:example:user1", "👍"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := repo.Add("post-1", "did:example:user2", "🔥"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	counts, err := repo.CountsForPosts([]string{"post-1", "post-2"})
+	if err != nil {
+		t.Fatalf("CountsForPosts failed: %v", err)
+	}
+	want := map[string]map[string]int{"post-1": {"🔥": 2, "👍": 1}}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("CountsForPosts = %v, want %v", counts, want)
+	}
+}
+
+func TestReactionRepository_Remove(t *testing.T) {
+	repo := NewInMemoryReactionRepository()
+	_, _ = repo.Add("post-1", "did:example:user1", "🔥")
+	_, _ = repo.Add("post-1", "did:example:user2", "🔥")
+
+	if err := repo.Remove("post-1", "did:example:user1", "🔥"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := repo.Remove("post-1", "did:example:user1", "🔥"); err != ErrReactionNotFound {
+		t.Errorf("expected ErrReactionNotFound removing twice, got %v", err)
+	}
+
+	counts, _ := repo.CountsForPosts([]string{"post-1"})
+	if counts["post-1"]["🔥"] != 1 {
+		t.Errorf("expected 1 🔥 left, got %v", counts)
+	}
+
+	if err := repo.Remove("post-1", "did:example:user2", "🔥"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	counts, _ = repo.CountsForPosts([]string{"post-1"})
+	if len(counts) != 0 {
+		t.Errorf("expected no counts once every reaction is removed, got %v", counts)
+	}
+}
+
+func TestReactionRepository_DistinctEmojiCap(t *testing.T) {
+	repo := NewInMemoryReactionRepository()
+
+	var emoji []string
+	for e := range AllowedReactions {
+		emoji = append(emoji, e)
+	}
+	for i := 0; i < MaxReactionKindsPerPost; i++ {
+		if _, err := repo.Add("post-1", "did:example:user1", emoji[i]); err != nil {
+			t.Fatalf("Add %d failed: %v", i, err)
+		}
+	}
+
+	extra := emoji[MaxReactionKindsPerPost]
+	if _, err := repo.Add("post-1", "did:example:user2", extra); err != ErrTooManyReactionKinds {
+		t.Errorf("expected ErrTooManyReactionKinds, got %v", err)
+	}
+
+	// Existing emoji can still be added to
+	if added, err := repo.Add("post-1", "did:example:user2", emoji[0]); err != nil || !added {
+		t.Errorf("expected an existing emoji to accept new reactions, got added=%v err=%v", added, err)
+	}
+
+	// Removing the last use of an emoji frees its slot
+	if err := repo.Remove("post-1", "did:example:user1", emoji[1]); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := repo.Add("post-1", "did:example:user2", extra); err != nil {
+		t.Errorf("expected a freed slot to accept a new emoji, got %v", err)
+	}
+}
+
+func TestValidateReaction(t *testing.T) {
+	for _, emoji := range []string{"🔥", "❤️", "💯"} {
+		if err := ValidateReaction(emoji); err != nil {
+			t.Errorf("ValidateReaction(%q) = %v, want nil", emoji, err)
+		}
+	}
+	for _, emoji := range []string{"", "fire", "🍆", "🔥🔥", "<script>"} {
+		if err := ValidateReaction(emoji); err != ErrReactionNotAllowed {
+			t.Errorf("ValidateReaction(%q) = %v, want ErrReactionNotAllowed", emoji, err)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package post
+
+import (
+	"testing"
+	"time"
+)
+
+// createThread creates a parent post in sceneID and n replies to it, one
+// minute apart starting at base. Returns the parent and the replies in order.
+func createThread(t *testing.T, repo *InMemoryPostRepository, sceneID string, n int, base time.Time) (*Post, []*Post) {
+	t.Helper()
+	parent := &Post{SceneID: &sceneID, AuthorDID: "did:example:user1", Text: "Parent"}
+	if err := repo.Create(parent); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+	var replies []*Post
+	for i := 0; i < n; i++ {
+		reply := &Post{
+			SceneID:   &sceneID,
+			AuthorDID: "did:example:user2",
+			Text:      "Reply " + string(rune('A'+i)),
+			ParentID:  &parent.ID,
+		}
+		if err := repo.Create(reply); err != nil {
+			t.Fatalf("failed to create reply: %v", err)
+		}
+		repo.mu.Lock()
+		repo.posts[reply.ID].CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		repo.mu.Unlock()
+		replies = append(replies, reply)
+	}
+	return parent, replies
+}
+
+func TestListReplies_OldestFirst(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	parent, replies := createThread(t, repo, "scene123", 3, time.Now())
+
+	// Replies to other posts are not part of the thread
+	other := &Post{SceneID: strPtr("scene123"), AuthorDID: "did:example:user1", Text: "Unrelated"}
+	if err := repo.Create(other); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	page, cursor, err := repo.ListReplies(parent.ID, 10, nil)
+	if err != nil {
+		t.Fatalf("ListReplies failed: %v", err)
+	}
+	if cursor != nil {
+		t.Error("expected nil cursor (no more replies)")
+	}
+	if len(page) != len(replies) {
+		t.Fatalf("expected %d replies, got %d", len(replies), len(page))
+	}
+	for i, reply := range page {
+		if reply.ID != replies[i].ID {
+			t.Errorf("reply %d: expected %s, got %s", i, replies[i].Text, reply.Text)
+		}
+		if reply.Orphaned {
+			t.Errorf("reply %d: unexpectedly orphaned", i)
+		}
+	}
+}
+
+func TestListReplies_ReplyCount(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	parent, replies := createThread(t, repo, "scene123", 3, time.Now())
+
+	got, err := repo.GetByID(parent.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.ReplyCount != 3 {
+		t.Errorf("expected reply count 3, got %d", got.ReplyCount)
+	}
+
+	if err := repo.Delete(replies[0].ID); err != nil {
+		t.Fatalf("failed to delete reply: %v", err)
+	}
+	got, _ = repo.GetByID(parent.ID)
+	if got.ReplyCount != 2 {
+		t.Errorf("expected reply count 2 after deleting a reply, got %d", got.ReplyCount)
+	}
+}
+
+func TestListReplies_DeletedParentOrphansReplies(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	parent, replies := createThread(t, repo, "scene123", 2, time.Now())
+
+	if err := repo.Delete(parent.ID); err != nil {
+		t.Fatalf("failed to delete parent: %v", err)
+	}
+
+	// Children survive their parent, marked orphaned
+	page, _, err := repo.ListReplies(parent.ID, 10, nil)
+	if err != nil {
+		t.Fatalf("ListReplies failed: %v", err)
+	}
+	if len(page) != 2 {
+		t.Fatalf("expected 2 replies to survive the parent, got %d", len(page))
+	}
+	for _, reply := range page {
+		if !reply.Orphaned {
+			t.Errorf("expected reply %s to be orphaned", reply.Text)
+		}
+	}
+
+	got, err := repo.GetByID(replies[0].ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if !got.Orphaned {
+		t.Error("expected GetByID to mark the reply orphaned")
+	}
+
+	feed, _, err := repo.ListByScene("scene123", FeedWindow{}, 10, nil)
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if len(feed) != 2 || !feed[0].Orphaned || !feed[1].Orphaned {
+		t.Errorf("expected both replies in the feed, orphaned, got %d posts", len(feed))
+	}
+}
+
+func TestListReplies_UnknownParent(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+
+	if _, _, err := repo.ListReplies("does-not-exist", 10, nil); err != ErrPostNotFound {
+		t.Errorf("expected ErrPostNotFound, got %v", err)
+	}
+}
+
+func TestListReplies_Pagination(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	parent, replies := createThread(t, repo, "scene123", 25, time.Now())
+
+	var collected []*Post
+	var cursor *FeedCursor
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("pagination exceeded max pages, possible infinite loop")
+		}
+		page, next, err := repo.ListReplies(parent.ID, 10, cursor)
+		if err != nil {
+			t.Fatalf("ListReplies failed: %v", err)
+		}
+		collected = append(collected, page...)
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+
+	if len(collected) != len(replies) {
+		t.Fatalf("expected %d replies across pages, got %d", len(replies), len(collected))
+	}
+	seen := make(map[string]bool)
+	for i, reply := range collected {
+		if seen[reply.ID] {
+			t.Errorf("duplicate reply %s", reply.ID)
+		}
+		seen[reply.ID] = true
+		if reply.ID != replies[i].ID {
+			t.Errorf("position %d: expected %s, got %s", i, replies[i].Text, reply.Text)
+		}
+	}
+}
+
+func TestListReplies_CursorIntegrity_DeletedReplyAfterCursor(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	parent, replies := createThread(t, repo, "scene123", 10, time.Now())
+
+	page1, cursor1, err := repo.ListReplies(parent.ID, 4, nil)
+	if err != nil {
+		t.Fatalf("ListReplies page 1 failed: %v", err)
+	}
+	if len(page1) != 4 {
+		t.Fatalf("expected 4 replies on page 1, got %d", len(page1))
+	}
+
+	// Delete a reply that would be on page 2
+	if err := repo.Delete(replies[5].ID); err != nil {
+		t.Fatalf("failed to delete reply: %v", err)
+	}
+
+	page2, cursor2, err := repo.ListReplies(parent.ID, 4, cursor1)
+	if err != nil {
+		t.Fatalf("ListReplies page 2 failed: %v", err)
+	}
+	if len(page2) != 4 {
+		t.Errorf("expected 4 replies on page 2 (skipping deleted), got %d", len(page2))
+	}
+
+	page3, cursor3, err := repo.ListReplies(parent.ID, 4, cursor2)
+	if err != nil {
+		t.Fatalf("ListReplies page 3 failed: %v", err)
+	}
+	if len(page3) != 1 {
+		t.Errorf("expected 1 reply on page 3, got %d", len(page3))
+	}
+	if cursor3 != nil {
+		t.Error("expected nil cursor on last page")
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range append(append(page1, page2...), page3...) {
+		if seen[p.ID] {
+			t.Errorf("duplicate reply %s across pages", p.ID)
+		}
+		seen[p.ID] = true
+		if p.ID == replies[5].ID {
+			t.Errorf("deleted reply %s appeared in results", p.ID)
+		}
+	}
+}
+
+func TestListReplies_CursorIntegrity_HiddenReplyAfterCursor(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	parent, replies := createThread(t, repo, "scene123", 8, time.Now())
+
+	page1, cursor1, err := repo.ListReplies(parent.ID, 4, nil)
+	if err != nil {
+		t.Fatalf("ListReplies page 1 failed: %v", err)
+	}
+
+	hidden := *replies[6]
+	hidden.Labels = []string{LabelHidden}
+	if err := repo.Update(&hidden); err != nil {
+		t.Fatalf("failed to hide reply: %v", err)
+	}
+
+	page2, cursor2, err := repo.ListReplies(parent.ID, 4, cursor1)
+	if err != nil {
+		t.Fatalf("ListReplies page 2 failed: %v", err)
+	}
+	if len(page2) != 3 {
+		t.Errorf("expected 3 replies on page 2 (skipping hidden), got %d", len(page2))
+	}
+	if cursor2 != nil {
+		t.Error("expected nil cursor on last page")
+	}
+	for _, p := range append(page1, page2...) {
+		if p.ID == hidden.ID {
+			t.Errorf("hidden reply %s appeared in results", p.ID)
+		}
+	}
+}
+
+func TestListReplies_CursorIntegrity_IdenticalTimestamps(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+	parent, replies := createThread(t, repo, sceneID, 12, time.Now())
+
+	// Put every reply at the same instant so only IDs order them
+	now := time.Now()
+	repo.mu.Lock()
+	for _, reply := range replies {
+		repo.posts[reply.ID].CreatedAt = now
+	}
+	repo.mu.Unlock()
+
+	var collected []*Post
+	var cursor *FeedCursor
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("pagination exceeded max pages, possible infinite loop")
+		}
+		page, next, err := repo.ListReplies(parent.ID, 5, cursor)
+		if err != nil {
+			t.Fatalf("ListReplies failed: %v", err)
+		}
+		collected = append(collected, page...)
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+
+	if len(collected) != 12 {
+		t.Fatalf("expected 12 replies, got %d", len(collected))
+	}
+	for i := 1; i < len(collected); i++ {
+		if collected[i-1].ID >= collected[i].ID {
+			t.Errorf("IDs not in ascending order: %s >= %s", collected[i-1].ID, collected[i].ID)
+		}
+	}
+}
@@ -14,12 +14,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/ranking"
 )
 
 // Common errors for post operations.
 var (
-	ErrPostNotFound = errors.New("post not found")
-	ErrPostDeleted  = errors.New("post has been deleted")
+	ErrPostNotFound     = errors.New("post not found")
+	ErrPostDeleted      = errors.New("post has been deleted")
+	ErrEmptySearchQuery = errors.New("search query has no searchable terms")
 )
 
 // PostScoreCursor represents the pagination cursor for post search results.
@@ -52,6 +54,40 @@ func DecodePostScoreCursor(encoded string) (*PostScoreCursor, error) {
 	return &cursor, nil
 }
 
+// RankedFeedCursor represents the pagination cursor for a ranked scene feed.
+// AsOf pins the time used to score recency so every page ranks posts identically.
+type RankedFeedCursor struct {
+	AsOf  time.Time `json:"as_of"` // Reference time for recency scoring
+	Score float64   `json:"score"` // Ranked score of last post
+	ID    string    `json:"id"`    // Post ID for stable ordering
+}
+
+// EncodeRankedFeedCursor encodes a ranked feed cursor to a base64 string.
+func EncodeRankedFeedCursor(cursor *RankedFeedCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// DecodeRankedFeedCursor decodes a base64 ranked feed cursor string.
+// Returns (nil, nil) for empty input, or an error for invalid cursors.
+func DecodeRankedFeedCursor(encoded string) (*RankedFeedCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	data, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	var cursor RankedFeedCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor format: %w", err)
+	}
+	if cursor.AsOf.IsZero() || cursor.ID == "" {
+		return nil, errors.New("invalid cursor format: missing position")
+	}
+	return &cursor, nil
+}
+
 // Attachment represents a media attachment on a post with sanitized metadata.
 // Supports both legacy URL-based attachments and new key-based attachments with metadata.
 type Attachment struct {
@@ -81,6 +117,26 @@ type Post struct {
 	Attachments []Attachment `json:"attachments,omitempty"`
 	Labels      []string     `json:"labels,omitempty"`
 
+	// Quote reposts: QuotedPostID references the post being quoted; RepostCount is a
+	// denormalized count of live posts quoting this one, maintained by the repository.
+	QuotedPostID *string `json:"quoted_post_id,omitempty"`
+	RepostCount  int     `json:"repost_count"`
+
+	// Threaded replies: ParentID references the post being replied to; ReplyCount is a
+	// denormalized count of live replies, maintained by the repository. Orphaned is set
+	// on reads when the parent has since been deleted; replies outlive their parent.
+	ParentID   *string `json:"parent_id,omitempty"`
+	ReplyCount int     `json:"reply_count"`
+	Orphaned   bool    `json:"orphaned,omitempty"`
+
+	// ReactionCount is a denormalized count of emoji reactions, kept in step with
+	// the ReactionRepository through AdjustReactionCount.
+	ReactionCount int `json:"reaction_count"`
+
+	// IsAnnouncement marks a high-signal post from a scene's owner or a curator,
+	// listed in the scene's announcements channel rather than its regular feed.
+	IsAnnouncement bool `json:"is_announcement"`
+
 	// AT Protocol record tracking
 	RecordDID  *string `json:"record_did,omitempty"`
 	RecordRKey *string `json:"record_rkey,omitempty"`
@@ -88,6 +144,10 @@ type Post struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Relevance is the text match score in [0, 1], set only on SearchByScene
+	// results; it is the ranking package's Text component.
+	Relevance float64 `json:"relevance,omitempty"`
 }
 
 // UpsertResult tracks statistics for upsert operations.
@@ -103,6 +163,79 @@ type FeedCursor struct {
 	ID        string    `json:"id"`
 }
 
+// FeedWindow bounds a feed by post creation time. Since is inclusive and Until
+// is exclusive; a zero bound is open, so the zero FeedWindow lists everything.
+type FeedWindow struct {
+	Since time.Time
+	Until time.Time
+}
+
+// contains reports whether t falls within the window.
+func (w FeedWindow) contains(t time.Time) bool {
+	if !w.Since.IsZero() && t.Before(w.Since) {
+		return false
+	}
+	if !w.Until.IsZero() && !t.Before(w.Until) {
+		return false
+	}
+	return true
+}
+
+// AnnouncementFilter selects how announcements are treated when listing a scene feed.
+type AnnouncementFilter int
+
+const (
+	// AnnouncementsInclude lists announcements inline with regular posts.
+	AnnouncementsInclude AnnouncementFilter = iota
+	// AnnouncementsExclude lists only regular posts.
+	AnnouncementsExclude
+	// AnnouncementsOnly lists only announcements.
+	AnnouncementsOnly
+)
+
+// matches reports whether a post passes the filter.
+func (f AnnouncementFilter) matches(post *Post) bool {
+	switch f {
+	case AnnouncementsExclude:
+		return !post.IsAnnouncement
+	case AnnouncementsOnly:
+		return post.IsAnnouncement
+	default:
+		return true
+	}
+}
+
+// FeedOptions narrows a scene feed. The zero FeedOptions lists the same posts
+// as ListByScene; soft-deleted and hidden posts are excluded regardless.
+type FeedOptions struct {
+	Window        FeedWindow
+	Announcements AnnouncementFilter
+	AuthorDID     *string  // Only posts by this author
+	IncludeLabels []string // Only posts with at least one of these labels
+	ExcludeLabels []string // No posts with any of these labels
+}
+
+// matches reports whether a post passes the author and label filters.
+func (o FeedOptions) matches(post *Post) bool {
+	if o.AuthorDID != nil && post.AuthorDID != *o.AuthorDID {
+		return false
+	}
+	for _, label := range o.ExcludeLabels {
+		if post.HasLabel(label) {
+			return false
+		}
+	}
+	if len(o.IncludeLabels) == 0 {
+		return true
+	}
+	for _, label := range o.IncludeLabels {
+		if post.HasLabel(label) {
+			return true
+		}
+	}
+	return false
+}
+
 // PostRepository defines the interface for post data operations.
 type PostRepository interface {
 	// Upsert inserts a new post or updates existing one based on (record_did, record_rkey).
@@ -121,22 +254,53 @@ type PostRepository interface {
 	// GetByID retrieves a post by its UUID, excluding soft-deleted posts.
 	GetByID(id string) (*Post, error)
 
+	// GetByIDIncludingDeleted retrieves a post by its UUID, soft-deleted or not,
+	// for callers that still act on a deleted post's scene, such as its thread.
+	GetByIDIncludingDeleted(id string) (*Post, error)
+
 	// GetByRecordKey retrieves a post by its AT Protocol record key.
 	GetByRecordKey(did, rkey string) (*Post, error)
 
 	// ListByScene retrieves posts for a scene with cursor-based pagination.
 	// Returns posts ordered by created_at DESC, id ASC (tie-breaker).
 	// Excludes soft-deleted posts and posts with 'hidden' label.
+	// Only posts created within window are listed; the cursor pages within it.
 	// If cursor is nil, starts from the most recent post.
 	// Returns posts, next cursor (nil if no more), and error.
-	ListByScene(sceneID string, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error)
+	ListByScene(sceneID string, window FeedWindow, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error)
+
+	// ListSceneFeed is ListByScene with announcements included, excluded, or
+	// listed on their own according to announcements.
+	ListSceneFeed(sceneID string, announcements AnnouncementFilter, window FeedWindow, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error)
+
+	// ListBySceneFiltered is ListSceneFeed narrowed by author and labels as set
+	// in opts. Ordering and cursors are the same as ListByScene's, so a cursor
+	// pages a filtered feed as stably as an unfiltered one.
+	ListBySceneFiltered(sceneID string, opts FeedOptions, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error)
+
+	// ListRankedSceneFeed is ListSceneFeed ordered by a recency and engagement blend
+	// (score DESC, id ASC) instead of strictly newest-first. Engagement is the post's
+	// RepostCount plus its ReactionCount. Scores are computed as of the cursor's AsOf time, or now for the
+	// first page, so pages do not shift as posts age.
+	// Returns posts, next cursor (nil if no more), and error.
+	ListRankedSceneFeed(sceneID string, announcements AnnouncementFilter, window FeedWindow, limit int, cursor *RankedFeedCursor) ([]*Post, *RankedFeedCursor, error)
 
 	// ListByEvent retrieves posts for an event with cursor-based pagination.
 	// Returns posts ordered by created_at DESC, id ASC (tie-breaker).
 	// Excludes soft-deleted posts and posts with 'hidden' label.
+	// Only posts created within window are listed; the cursor pages within it.
 	// If cursor is nil, starts from the most recent post.
 	// Returns posts, next cursor (nil if no more), and error.
-	ListByEvent(eventID string, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error)
+	ListByEvent(eventID string, window FeedWindow, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error)
+
+	// ListReplies retrieves the replies to a post with cursor-based pagination.
+	// Returns replies ordered by created_at ASC, id ASC (tie-breaker), so threads
+	// read oldest first. Excludes soft-deleted replies and replies with 'hidden'
+	// label. Replies to a deleted parent are still listed, marked Orphaned.
+	// Returns ErrPostNotFound if the parent never existed.
+	// If cursor is nil, starts from the oldest reply.
+	// Returns replies, next cursor (nil if no more), and error.
+	ListReplies(parentID string, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error)
 
 	// SearchPosts searches for posts by text query with optional scene filter.
 	// Returns posts ordered by (score DESC, id ASC) for stable pagination.
@@ -144,24 +308,154 @@ type PostRepository interface {
 	// If cursor is empty, starts from the highest scored post.
 	// Returns posts, next cursor (empty if no more), and error.
 	SearchPosts(query string, sceneID *string, limit int, cursor string, trustScores map[string]float64) ([]*Post, string, error)
+
+	// SearchByScene searches a scene's posts for the query's terms, matched as
+	// whole words case-insensitively. Posts matching any term are returned with
+	// Relevance set to the fraction of terms they contain, ordered by
+	// (Relevance DESC, id ASC). Excludes soft-deleted posts and posts with
+	// 'hidden' label, like the scene feed. Returns ErrEmptySearchQuery if the
+	// query has no terms. If cursor is nil, starts from the most relevant post.
+	// Returns posts, next cursor (nil if no more), and error.
+	SearchByScene(sceneID, query string, limit int, cursor *PostScoreCursor) ([]*Post, *PostScoreCursor, error)
+
+	// SceneLastActivityAt returns the time of the most recent post create, update,
+	// or delete within a scene. Returns the zero time if no activity is recorded.
+	SceneLastActivityAt(sceneID string) (time.Time, error)
+
+	// EventLastActivityAt returns the time of the most recent post create, update,
+	// or delete within an event. Returns the zero time if no activity is recorded.
+	EventLastActivityAt(eventID string) (time.Time, error)
+
+	// AdjustReactionCount adds delta to a post's ReactionCount, clamped at zero, and
+	// records the change as activity in the post's scene and event.
+	// Returns ErrPostNotFound if the post doesn't exist or is soft-deleted.
+	AdjustReactionCount(postID string, delta int) error
+
+	// ListTrending retrieves a scene's most-quoted posts by repost velocity: the number
+	// of quote reposts created within window. Ties are broken by total RepostCount, then ID.
+	// Excludes soft-deleted posts, posts with 'hidden' label, and posts with no reposts in window.
+	ListTrending(sceneID string, window time.Duration, limit int) ([]*Post, error)
 }
 
 // InMemoryPostRepository is an in-memory implementation of PostRepository.
 // Thread-safe via RWMutex.
 type InMemoryPostRepository struct {
-	mu    sync.RWMutex
-	posts map[string]*Post  // UUID -> Post
-	keys  map[string]string // "did:rkey" -> UUID
+	mu            sync.RWMutex
+	posts         map[string]*Post     // UUID -> Post
+	keys          map[string]string    // "did:rkey" -> UUID
+	sceneActivity map[string]time.Time // scene ID -> last post activity
+	eventActivity map[string]time.Time // event ID -> last post activity
+
+	// quotes tracks live quote reposts: quoted post ID -> quoting post ID -> quoted at
+	quotes  map[string]map[string]time.Time
+	timeNow func() time.Time // For testability
 }
 
 // NewInMemoryPostRepository creates a new in-memory post repository.
 func NewInMemoryPostRepository() *InMemoryPostRepository {
 	return &InMemoryPostRepository{
-		posts: make(map[string]*Post),
-		keys:  make(map[string]string),
+		posts:         make(map[string]*Post),
+		keys:          make(map[string]string),
+		sceneActivity: make(map[string]time.Time),
+		eventActivity: make(map[string]time.Time),
+		quotes:        make(map[string]map[string]time.Time),
+		timeNow:       time.Now,
+	}
+}
+
+// touchActivity records post activity for the post's scene and event.
+// Caller must hold the write lock.
+func (r *InMemoryPostRepository) touchActivity(post *Post, at time.Time) {
+	if post.SceneID != nil {
+		r.sceneActivity[*post.SceneID] = at
+	}
+	if post.EventID != nil {
+		r.eventActivity[*post.EventID] = at
 	}
 }
 
+// addQuote records quoting as a quote repost of its QuotedPostID and increments the
+// quoted post's RepostCount, touching the quoted post's scene and event, which may
+// differ from the quoting post's. No-op if the quoted post does not exist, is
+// deleted, or is the post itself. Caller must hold the write lock.
+func (r *InMemoryPostRepository) addQuote(quoting *Post, at time.Time) {
+	if quoting.QuotedPostID == nil || *quoting.QuotedPostID == quoting.ID {
+		return
+	}
+	quoted, ok := r.posts[*quoting.QuotedPostID]
+	if !ok || quoted.DeletedAt != nil {
+		return
+	}
+	byQuoter, ok := r.quotes[quoted.ID]
+	if !ok {
+		byQuoter = make(map[string]time.Time)
+		r.quotes[quoted.ID] = byQuoter
+	}
+	if _, exists := byQuoter[quoting.ID]; exists {
+		return
+	}
+	byQuoter[quoting.ID] = at
+	quoted.RepostCount++
+	r.touchActivity(quoted, at)
+}
+
+// removeQuote reverses addQuote for quoting, decrementing the quoted post's RepostCount.
+// Caller must hold the write lock.
+func (r *InMemoryPostRepository) removeQuote(quoting *Post, at time.Time) {
+	if quoting.QuotedPostID == nil {
+		return
+	}
+	byQuoter, ok := r.quotes[*quoting.QuotedPostID]
+	if !ok {
+		return
+	}
+	if _, exists := byQuoter[quoting.ID]; !exists {
+		return
+	}
+	delete(byQuoter, quoting.ID)
+	if len(byQuoter) == 0 {
+		delete(r.quotes, *quoting.QuotedPostID)
+	}
+	if quoted, ok := r.posts[*quoting.QuotedPostID]; ok && quoted.RepostCount > 0 {
+		quoted.RepostCount--
+		r.touchActivity(quoted, at)
+	}
+}
+
+// addReply increments the ReplyCount of reply's parent. No-op if the post is not a
+// reply, replies to itself, or the parent does not exist.
+// Caller must hold the write lock.
+func (r *InMemoryPostRepository) addReply(reply *Post) {
+	if reply.ParentID == nil || *reply.ParentID == reply.ID {
+		return
+	}
+	if parent, ok := r.posts[*reply.ParentID]; ok {
+		parent.ReplyCount++
+	}
+}
+
+// removeReply reverses addReply for reply, decrementing its parent's ReplyCount.
+// Caller must hold the write lock.
+func (r *InMemoryPostRepository) removeReply(reply *Post) {
+	if reply.ParentID == nil || *reply.ParentID == reply.ID {
+		return
+	}
+	if parent, ok := r.posts[*reply.ParentID]; ok && parent.ReplyCount > 0 {
+		parent.ReplyCount--
+	}
+}
+
+// copyPost returns a copy of p for callers, with Orphaned set if p replies to a
+// post that has since been deleted. Caller must hold the read lock.
+func (r *InMemoryPostRepository) copyPost(p *Post) *Post {
+	postCopy := *p
+	if p.ParentID != nil {
+		parent, ok := r.posts[*p.ParentID]
+		postCopy.Orphaned = !ok || parent.DeletedAt != nil
+	}
+	return &postCopy
+}
+
 // makeKey creates a composite key from DID and rkey using a null byte separator to avoid collisions.
 // AT Protocol DIDs contain colons (e.g., "did:plc:abc123"), so using a null byte prevents
 // collisions like did="a:b" + rkey="c" vs did="a" + rkey="b:c" both producing "a:b:c".
@@ -174,7 +468,7 @@ func (r *InMemoryPostRepository) Upsert(post *Post) (*UpsertResult, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	now := time.Now()
+	now := r.timeNow()
 	var inserted bool
 	var id string
 
@@ -186,6 +480,8 @@ func (r *InMemoryPostRepository) Upsert(post *Post) (*UpsertResult, error) {
 		if exists {
 			// Update existing post
 			existing := r.posts[existingID]
+			// Touch the previous scene/event too, since the post may have moved
+			r.touchActivity(existing, now)
 			existing.SceneID = post.SceneID
 			existing.EventID = post.EventID
 			existing.AuthorDID = post.AuthorDID
@@ -193,6 +489,17 @@ func (r *InMemoryPostRepository) Upsert(post *Post) (*UpsertResult, error) {
 			existing.Attachments = post.Attachments
 			existing.Labels = post.Labels
 			existing.UpdatedAt = now
+			r.touchActivity(existing, now)
+			if !sameQuotedPost(existing.QuotedPostID, post.QuotedPostID) {
+				r.removeQuote(existing, now)
+				existing.QuotedPostID = post.QuotedPostID
+				r.addQuote(existing, now)
+			}
+			if !sameQuotedPost(existing.ParentID, post.ParentID) {
+				r.removeReply(existing)
+				existing.ParentID = post.ParentID
+				r.addReply(existing)
+			}
 			inserted = false
 			id = existingID
 		} else {
@@ -202,10 +509,16 @@ func (r *InMemoryPostRepository) Upsert(post *Post) (*UpsertResult, error) {
 			}
 			post.CreatedAt = now
 			post.UpdatedAt = now
+			post.RepostCount = 0
+			post.ReplyCount = 0
+			post.ReactionCount = 0
 
 			postCopy := *post
 			r.posts[post.ID] = &postCopy
 			r.keys[key] = post.ID
+			r.touchActivity(post, now)
+			r.addQuote(&postCopy, now)
+			r.addReply(&postCopy)
 			inserted = true
 			id = post.ID
 		}
@@ -215,9 +528,15 @@ func (r *InMemoryPostRepository) Upsert(post *Post) (*UpsertResult, error) {
 		post.ID = newID
 		post.CreatedAt = now
 		post.UpdatedAt = now
+		post.RepostCount = 0
+		post.ReplyCount = 0
+		post.ReactionCount = 0
 
 		postCopy := *post
 		r.posts[newID] = &postCopy
+		r.touchActivity(post, now)
+		r.addQuote(&postCopy, now)
+		r.addReply(&postCopy)
 		inserted = true
 		id = newID
 	}
@@ -233,13 +552,19 @@ func (r *InMemoryPostRepository) Create(post *Post) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	now := time.Now()
+	now := r.timeNow()
 	post.ID = uuid.New().String()
 	post.CreatedAt = now
 	post.UpdatedAt = now
+	post.RepostCount = 0
+	post.ReplyCount = 0
+	post.ReactionCount = 0
 
 	postCopy := *post
 	r.posts[post.ID] = &postCopy
+	r.touchActivity(post, now)
+	r.addQuote(&postCopy, now)
+	r.addReply(&postCopy)
 
 	// If record key is provided, track it
 	if post.RecordDID != nil && post.RecordRKey != nil {
@@ -270,6 +595,7 @@ func (r *InMemoryPostRepository) Update(post *Post) error {
 	existing.Attachments = post.Attachments
 	existing.Labels = post.Labels
 	existing.UpdatedAt = time.Now()
+	r.touchActivity(existing, existing.UpdatedAt)
 
 	return nil
 }
@@ -289,8 +615,30 @@ func (r *InMemoryPostRepository) Delete(id string) error {
 		return ErrPostNotFound
 	}
 
-	now := time.Now()
+	now := r.timeNow()
 	post.DeletedAt = &now
+	r.touchActivity(post, now)
+	r.removeQuote(post, now)
+	r.removeReply(post)
+
+	return nil
+}
+
+// AdjustReactionCount adds delta to a post's ReactionCount and touches its activity.
+func (r *InMemoryPostRepository) AdjustReactionCount(postID string, delta int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.posts[postID]
+	if !ok || post.DeletedAt != nil {
+		return ErrPostNotFound
+	}
+
+	post.ReactionCount += delta
+	if post.ReactionCount < 0 {
+		post.ReactionCount = 0
+	}
+	r.touchActivity(post, r.timeNow())
 
 	return nil
 }
@@ -310,8 +658,19 @@ func (r *InMemoryPostRepository) GetByID(id string) (*Post, error) {
 		return nil, ErrPostNotFound
 	}
 
-	postCopy := *post
-	return &postCopy, nil
+	return r.copyPost(post), nil
+}
+
+// GetByIDIncludingDeleted retrieves a post by its UUID, soft-deleted or not.
+func (r *InMemoryPostRepository) GetByIDIncludingDeleted(id string) (*Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	post, ok := r.posts[id]
+	if !ok {
+		return nil, ErrPostNotFound
+	}
+	return r.copyPost(post), nil
 }
 
 // GetByRecordKey retrieves a post by its AT Protocol record key.
@@ -325,31 +684,30 @@ func (r *InMemoryPostRepository) GetByRecordKey(did, rkey string) (*Post, error)
 		return nil, ErrPostNotFound
 	}
 
-	post := r.posts[id]
-	postCopy := *post
-	return &postCopy, nil
+	return r.copyPost(r.posts[id]), nil
 }
 
 // ListByScene retrieves posts for a scene with cursor-based pagination.
-func (r *InMemoryPostRepository) ListByScene(sceneID string, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error) {
+func (r *InMemoryPostRepository) ListByScene(sceneID string, window FeedWindow, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error) {
+	return r.ListSceneFeed(sceneID, AnnouncementsInclude, window, limit, cursor)
+}
+
+// ListSceneFeed retrieves posts for a scene, filtered by announcement status,
+// with cursor-based pagination.
+func (r *InMemoryPostRepository) ListSceneFeed(sceneID string, announcements AnnouncementFilter, window FeedWindow, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error) {
+	return r.ListBySceneFiltered(sceneID, FeedOptions{Window: window, Announcements: announcements}, limit, cursor)
+}
+
+// ListBySceneFiltered retrieves posts for a scene, filtered by opts, with
+// cursor-based pagination.
+func (r *InMemoryPostRepository) ListBySceneFiltered(sceneID string, opts FeedOptions, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	// Collect all non-deleted posts for this scene
 	var candidates []*Post
 	for _, post := range r.posts {
-		// Skip deleted posts
-		if post.DeletedAt != nil {
-			continue
-		}
-
-		// Skip posts not in this scene
-		if post.SceneID == nil || *post.SceneID != sceneID {
-			continue
-		}
-
-		// Skip hidden posts
-		if post.HasLabel(LabelHidden) {
+		if !inSceneFeed(post, sceneID, opts.Announcements) || !opts.Window.contains(post.CreatedAt) || !opts.matches(post) {
 			continue
 		}
 
@@ -391,15 +749,94 @@ func (r *InMemoryPostRepository) ListByScene(sceneID string, limit int, cursor *
 	// Return deep copies to prevent external mutation
 	copies := make([]*Post, len(results))
 	for i, p := range results {
-		postCopy := *p
-		copies[i] = &postCopy
+		copies[i] = r.copyPost(p)
 	}
 
 	return copies, nextCursor, nil
 }
 
+// ListRankedSceneFeed retrieves posts for a scene ordered by ranked score,
+// filtered by announcement status, with cursor-based pagination.
+func (r *InMemoryPostRepository) ListRankedSceneFeed(sceneID string, announcements AnnouncementFilter, window FeedWindow, limit int, cursor *RankedFeedCursor) ([]*Post, *RankedFeedCursor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Strip the monotonic clock reading: the cursor's AsOf round-trips through
+	// JSON without one, and later pages must score posts on the same clock
+	asOf := r.timeNow().Round(0)
+	if cursor != nil {
+		asOf = cursor.AsOf
+	}
+
+	type rankedPost struct {
+		post  *Post
+		score float64
+	}
+	var candidates []rankedPost
+	for _, post := range r.posts {
+		if !inSceneFeed(post, sceneID, announcements) || !window.contains(post.CreatedAt) {
+			continue
+		}
+
+		// Posts created after the first page was served would reshuffle earlier pages
+		if post.CreatedAt.After(asOf) {
+			continue
+		}
+
+		score := ranking.CompositeScoreFeedPost(post.CreatedAt, asOf, post.RepostCount+post.ReactionCount)
+
+		// In (score DESC, id ASC) order, skip posts at or before the cursor position
+		if cursor != nil {
+			if score > cursor.Score || (score == cursor.Score && post.ID <= cursor.ID) {
+				continue
+			}
+		}
+
+		candidates = append(candidates, rankedPost{post: post, score: score})
+	}
+
+	// Sort by score DESC, then by ID ASC for tie-breaking
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].post.ID < candidates[j].post.ID
+	})
+
+	// Apply limit and determine next cursor
+	var nextCursor *RankedFeedCursor
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+		last := candidates[len(candidates)-1]
+		nextCursor = &RankedFeedCursor{AsOf: asOf, Score: last.score, ID: last.post.ID}
+	}
+
+	// Return deep copies to prevent external mutation
+	copies := make([]*Post, len(candidates))
+	for i, c := range candidates {
+		copies[i] = r.copyPost(c.post)
+	}
+
+	return copies, nextCursor, nil
+}
+
+// inSceneFeed reports whether post is listed in sceneID's feed under the
+// announcement filter: live, in the scene, and not hidden.
+func inSceneFeed(post *Post, sceneID string, announcements AnnouncementFilter) bool {
+	if post.DeletedAt != nil {
+		return false
+	}
+	if post.SceneID == nil || *post.SceneID != sceneID {
+		return false
+	}
+	if post.HasLabel(LabelHidden) {
+		return false
+	}
+	return announcements.matches(post)
+}
+
 // ListByEvent retrieves posts for an event with cursor-based pagination.
-func (r *InMemoryPostRepository) ListByEvent(eventID string, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error) {
+func (r *InMemoryPostRepository) ListByEvent(eventID string, window FeedWindow, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -421,6 +858,11 @@ func (r *InMemoryPostRepository) ListByEvent(eventID string, limit int, cursor *
 			continue
 		}
 
+		// Skip posts outside the time window
+		if !window.contains(post.CreatedAt) {
+			continue
+		}
+
 		// Apply cursor filter if provided
 		if cursor != nil {
 			// Skip posts that are newer or at/before the cursor position
@@ -458,8 +900,64 @@ func (r *InMemoryPostRepository) ListByEvent(eventID string, limit int, cursor *
 	// Return deep copies to prevent external mutation
 	copies := make([]*Post, len(results))
 	for i, p := range results {
-		postCopy := *p
-		copies[i] = &postCopy
+		copies[i] = r.copyPost(p)
+	}
+
+	return copies, nextCursor, nil
+}
+
+// ListReplies retrieves the replies to a post, oldest first, with cursor-based pagination.
+func (r *InMemoryPostRepository) ListReplies(parentID string, limit int, cursor *FeedCursor) ([]*Post, *FeedCursor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Deleted parents keep their thread; only unknown parents are an error
+	if _, ok := r.posts[parentID]; !ok {
+		return nil, nil, ErrPostNotFound
+	}
+
+	var candidates []*Post
+	for _, post := range r.posts {
+		if post.ParentID == nil || *post.ParentID != parentID || post.ID == parentID {
+			continue
+		}
+		if post.DeletedAt != nil || post.HasLabel(LabelHidden) {
+			continue
+		}
+
+		// Skip replies that are older or at/before the cursor position
+		if cursor != nil {
+			if post.CreatedAt.Before(cursor.CreatedAt) {
+				continue
+			}
+			if post.CreatedAt.Equal(cursor.CreatedAt) && post.ID <= cursor.ID {
+				continue
+			}
+		}
+
+		candidates = append(candidates, post)
+	}
+
+	// Sort by created_at ASC, then by ID ASC for tie-breaking
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].CreatedAt.Equal(candidates[j].CreatedAt) {
+			return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	// Apply limit and determine next cursor
+	var nextCursor *FeedCursor
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+		last := candidates[len(candidates)-1]
+		nextCursor = &FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	// Return deep copies to prevent external mutation
+	copies := make([]*Post, len(candidates))
+	for i, p := range candidates {
+		copies[i] = r.copyPost(p)
 	}
 
 	return copies, nextCursor, nil
@@ -620,13 +1118,95 @@ func (r *InMemoryPostRepository) SearchPosts(query string, sceneID *string, limi
 	// Return deep copies to prevent external mutation
 	copies := make([]*Post, len(results))
 	for i, p := range results {
-		postCopy := *p
-		copies[i] = &postCopy
+		copies[i] = r.copyPost(p)
 	}
 
 	return copies, nextCursor, nil
 }
 
+// SceneLastActivityAt returns the time of the most recent post activity within a scene.
+func (r *InMemoryPostRepository) SceneLastActivityAt(sceneID string) (time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sceneActivity[sceneID], nil
+}
+
+// EventLastActivityAt returns the time of the most recent post activity within an event.
+func (r *InMemoryPostRepository) EventLastActivityAt(eventID string) (time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.eventActivity[eventID], nil
+}
+
+// ListTrending retrieves a scene's posts ranked by quote repost velocity within window.
+func (r *InMemoryPostRepository) ListTrending(sceneID string, window time.Duration, limit int) ([]*Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cutoff := r.timeNow().Add(-window)
+
+	type trendingPost struct {
+		post     *Post
+		velocity int
+	}
+	var candidates []trendingPost
+
+	for id, byQuoter := range r.quotes {
+		post, ok := r.posts[id]
+		if !ok || post.DeletedAt != nil || post.HasLabel(LabelHidden) {
+			continue
+		}
+		if post.SceneID == nil || *post.SceneID != sceneID {
+			continue
+		}
+
+		velocity := 0
+		for _, quotedAt := range byQuoter {
+			if quotedAt.After(cutoff) {
+				velocity++
+			}
+		}
+		if velocity == 0 {
+			continue
+		}
+
+		candidates = append(candidates, trendingPost{post: post, velocity: velocity})
+	}
+
+	// Sort by velocity DESC, then total reposts DESC, then ID ASC for stable ordering
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].velocity != candidates[j].velocity {
+			return candidates[i].velocity > candidates[j].velocity
+		}
+		if candidates[i].post.RepostCount != candidates[j].post.RepostCount {
+			return candidates[i].post.RepostCount > candidates[j].post.RepostCount
+		}
+		return candidates[i].post.ID < candidates[j].post.ID
+	})
+
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	// Return deep copies to prevent external mutation
+	copies := make([]*Post, len(candidates))
+	for i, c := range candidates {
+		copies[i] = r.copyPost(c.post)
+	}
+
+	return copies, nil
+}
+
+// sameQuotedPost reports whether two optional quoted post IDs reference the same post.
+func sameQuotedPost(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // sortPostsByCreatedDesc sorts posts by created_at DESC, then by ID ASC for tie-breaking.
 // This provides stable ordering for cursor-based pagination.
 // Uses sort.Slice with O(n log n) introsort for efficient sorting of large result sets.
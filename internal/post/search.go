@@ -0,0 +1,87 @@
+package post
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// searchTerms splits text into lowercase word tokens: maximal runs of letters
+// and digits. The SQL implementation gets the equivalent from to_tsvector.
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// SearchByScene searches a scene's posts for the query's terms.
+// Relevance is the fraction of distinct query terms a post contains, so it
+// can be passed to the ranking package as the Text component unchanged.
+func (r *InMemoryPostRepository) SearchByScene(sceneID, query string, limit int, cursor *PostScoreCursor) ([]*Post, *PostScoreCursor, error) {
+	terms := make(map[string]bool)
+	for _, term := range searchTerms(query) {
+		terms[term] = true
+	}
+	if len(terms) == 0 {
+		return nil, nil, ErrEmptySearchQuery
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type scoredPost struct {
+		post      *Post
+		relevance float64
+	}
+	var candidates []scoredPost
+	for _, post := range r.posts {
+		if !inSceneFeed(post, sceneID, AnnouncementsInclude) {
+			continue
+		}
+
+		matched := make(map[string]bool)
+		for _, token := range searchTerms(post.Text) {
+			if terms[token] {
+				matched[token] = true
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		relevance := float64(len(matched)) / float64(len(terms))
+
+		// In (relevance DESC, id ASC) order, skip posts at or before the cursor position
+		if cursor != nil {
+			if relevance > cursor.Score || (relevance == cursor.Score && post.ID <= cursor.ID) {
+				continue
+			}
+		}
+
+		candidates = append(candidates, scoredPost{post: post, relevance: relevance})
+	}
+
+	// Sort by relevance DESC, then by ID ASC for tie-breaking
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].relevance != candidates[j].relevance {
+			return candidates[i].relevance > candidates[j].relevance
+		}
+		return candidates[i].post.ID < candidates[j].post.ID
+	})
+
+	// Apply limit and determine next cursor
+	var nextCursor *PostScoreCursor
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+		last := candidates[len(candidates)-1]
+		nextCursor = &PostScoreCursor{Score: last.relevance, ID: last.post.ID}
+	}
+
+	// Return deep copies to prevent external mutation
+	copies := make([]*Post, len(candidates))
+	for i, c := range candidates {
+		copies[i] = r.copyPost(c.post)
+		copies[i].Relevance = c.relevance
+	}
+
+	return copies, nextCursor, nil
+}
@@ -0,0 +1,141 @@
+package post
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// createSearchPost stores a post in sceneID with the given text.
+func createSearchPost(t *testing.T, repo *InMemoryPostRepository, sceneID, text string) *Post {
+	t.Helper()
+	post := &Post{
+		SceneID:   &sceneID,
+		AuthorDID: "did:example:user1",
+		Text:      text,
+		CreatedAt: time.Now(),
+	}
+	if err := repo.Create(post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	return post
+}
+
+func TestSearchByScene_MultiTermRelevance(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+
+	both := createSearchPost(t, repo, sceneID, "Warehouse TECHNO night, bring earplugs")
+	techno := createSearchPost(t, repo, sceneID, "More techno next week")
+	warehouse := createSearchPost(t, repo, sceneID, "The warehouse roof leaks")
+	createSearchPost(t, repo, sceneID, "Nothing to see here")
+	createSearchPost(t, repo, sceneID, "technology talk") // Tokens match whole words only
+	createSearchPost(t, repo, "other-scene", "warehouse techno elsewhere")
+
+	posts, next, err := repo.SearchByScene(sceneID, "techno Warehouse techno", 10, nil)
+	if err != nil {
+		t.Fatalf("SearchByScene failed: %v", err)
+	}
+	if next != nil {
+		t.Errorf("expected nil cursor, got %+v", next)
+	}
+	if len(posts) != 3 {
+		t.Fatalf("expected 3 matching posts, got %d", len(posts))
+	}
+
+	if posts[0].ID != both.ID || posts[0].Relevance != 1.0 {
+		t.Errorf("expected the post matching both terms first with relevance 1, got %q (%v)", posts[0].Text, posts[0].Relevance)
+	}
+	for _, p := range posts[1:] {
+		if p.ID != techno.ID && p.ID != warehouse.ID {
+			t.Errorf("unexpected post %q in results", p.Text)
+		}
+		if p.Relevance != 0.5 {
+			t.Errorf("expected relevance 0.5 for %q, got %v", p.Text, p.Relevance)
+		}
+	}
+	if posts[1].ID > posts[2].ID {
+		t.Error("expected equally relevant posts ordered by ID")
+	}
+}
+
+func TestSearchByScene_Pagination(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+
+	for i := 0; i < 5; i++ {
+		createSearchPost(t, repo, sceneID, "jungle set")
+	}
+	for i := 0; i < 3; i++ {
+		createSearchPost(t, repo, sceneID, "jungle")
+	}
+
+	seen := make(map[string]bool)
+	var cursor *PostScoreCursor
+	lastRelevance := 2.0
+	for page := 0; ; page++ {
+		if page > 10 {
+			t.Fatal("pagination did not terminate")
+		}
+		posts, next, err := repo.SearchByScene(sceneID, "jungle set", 3, cursor)
+		if err != nil {
+			t.Fatalf("SearchByScene failed: %v", err)
+		}
+		for _, p := range posts {
+			if seen[p.ID] {
+				t.Errorf("post %s returned twice", p.ID)
+			}
+			if p.Relevance > lastRelevance {
+				t.Errorf("relevance increased across pages: %v after %v", p.Relevance, lastRelevance)
+			}
+			seen[p.ID] = true
+			lastRelevance = p.Relevance
+		}
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+	if len(seen) != 8 {
+		t.Errorf("expected 8 posts across pages, got %d", len(seen))
+	}
+}
+
+func TestSearchByScene_EmptyQuery(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	createSearchPost(t, repo, "scene123", "anything")
+
+	for _, query := range []string{"", "   ", "!!! --- ..."} {
+		posts, _, err := repo.SearchByScene("scene123", query, 10, nil)
+		if !errors.Is(err, ErrEmptySearchQuery) {
+			t.Errorf("query %q: expected ErrEmptySearchQuery, got %v", query, err)
+		}
+		if posts != nil {
+			t.Errorf("query %q: expected no posts, got %d", query, len(posts))
+		}
+	}
+}
+
+func TestSearchByScene_ExcludesHiddenAndDeleted(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+
+	visible := createSearchPost(t, repo, sceneID, "dub plates for sale")
+	hidden := createSearchPost(t, repo, sceneID, "dub plates hidden by a moderator")
+	hidden.Labels = []string{LabelHidden}
+	if err := repo.Update(hidden); err != nil {
+		t.Fatalf("failed to hide post: %v", err)
+	}
+	deleted := createSearchPost(t, repo, sceneID, "dub plates deleted by the author")
+	if err := repo.Delete(deleted.ID); err != nil {
+		t.Fatalf("failed to delete post: %v", err)
+	}
+
+	posts, _, err := repo.SearchByScene(sceneID, "dub plates", 10, nil)
+	if err != nil {
+		t.Fatalf("SearchByScene failed: %v", err)
+	}
+	if len(posts) != 1 || posts[0].ID != visible.ID {
+		t.Fatalf("expected only the visible post, got %d posts", len(posts))
+	}
+}
@@ -0,0 +1,216 @@
+package post
+
+import (
+	"testing"
+	"time"
+)
+
+// createQuote creates a post in sceneID quoting quotedID and returns its ID.
+func createQuote(t *testing.T, repo *InMemoryPostRepository, sceneID, quotedID string) string {
+	t.Helper()
+	quote := &Post{
+		SceneID:      &sceneID,
+		AuthorDID:    "did:example:quoter",
+		Text:         "quoting",
+		QuotedPostID: &quotedID,
+	}
+	if err := repo.Create(quote); err != nil {
+		t.Fatalf("failed to create quote: %v", err)
+	}
+	return quote.ID
+}
+
+func createPost(t *testing.T, repo *InMemoryPostRepository, sceneID, text string) string {
+	t.Helper()
+	post := &Post{SceneID: &sceneID, AuthorDID: "did:example:author", Text: text}
+	if err := repo.Create(post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	return post.ID
+}
+
+func repostCount(t *testing.T, repo *InMemoryPostRepository, id string) int {
+	t.Helper()
+	post, err := repo.GetByID(id)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	return post.RepostCount
+}
+
+// TestRepostCount_IncrementAndDecrement tests that quote creation and deletion
+// keep the quoted post's denormalized RepostCount in sync.
+func TestRepostCount_IncrementAndDecrement(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+
+	original := createPost(t, repo, sceneID, "original")
+	if got := repostCount(t, repo, original); got != 0 {
+		t.Fatalf("expected initial repost count 0, got %d", got)
+	}
+
+	quote1 := createQuote(t, repo, sceneID, original)
+	createQuote(t, repo, sceneID, original)
+	if got := repostCount(t, repo, original); got != 2 {
+		t.Errorf("expected repost count 2 after two quotes, got %d", got)
+	}
+
+	if err := repo.Delete(quote1); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if got := repostCount(t, repo, original); got != 1 {
+		t.Errorf("expected repost count 1 after deleting a quote, got %d", got)
+	}
+
+	// Deleting again is a no-op for the counter
+	_ = repo.Delete(quote1)
+	if got := repostCount(t, repo, original); got != 1 {
+		t.Errorf("expected repost count to stay 1, got %d", got)
+	}
+}
+
+// TestRepostCount_IgnoresClientSuppliedCount tests that RepostCount cannot be set on create.
+func TestRepostCount_IgnoresClientSuppliedCount(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+
+	post := &Post{SceneID: &sceneID, AuthorDID: "did:example:author", Text: "hi", RepostCount: 99}
+	if err := repo.Create(post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	if got := repostCount(t, repo, post.ID); got != 0 {
+		t.Errorf("expected repost count 0, got %d", got)
+	}
+}
+
+// TestRepostCount_IgnoresDeletedQuotedPost tests that quoting a deleted post
+// doesn't count as a repost of it.
+func TestRepostCount_IgnoresDeletedQuotedPost(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+	original := createPost(t, repo, sceneID, "original")
+	if err := repo.Delete(original); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	createQuote(t, repo, sceneID, original)
+	deleted, err := repo.GetByIDIncludingDeleted(original)
+	if err != nil {
+		t.Fatalf("GetByIDIncludingDeleted failed: %v", err)
+	}
+	if deleted.RepostCount != 0 {
+		t.Errorf("expected repost count 0 on a deleted post, got %d", deleted.RepostCount)
+	}
+}
+
+// TestRepostCount_UpsertChangesQuotedPost tests that re-pointing a quote via Upsert
+// moves the repost from the old quoted post to the new one.
+func TestRepostCount_UpsertChangesQuotedPost(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+	first := createPost(t, repo, sceneID, "first")
+	second := createPost(t, repo, sceneID, "second")
+
+	did, rkey := "did:example:quoter", "rkey1"
+	quote := &Post{SceneID: &sceneID, AuthorDID: did, Text: "q", QuotedPostID: &first, RecordDID: &did, RecordRKey: &rkey}
+	if _, err := repo.Upsert(quote); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if got := repostCount(t, repo, first); got != 1 {
+		t.Fatalf("expected first repost count 1, got %d", got)
+	}
+
+	updated := &Post{SceneID: &sceneID, AuthorDID: did, Text: "q", QuotedPostID: &second, RecordDID: &did, RecordRKey: &rkey}
+	if _, err := repo.Upsert(updated); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if got := repostCount(t, repo, first); got != 0 {
+		t.Errorf("expected first repost count 0, got %d", got)
+	}
+	if got := repostCount(t, repo, second); got != 1 {
+		t.Errorf("expected second repost count 1, got %d", got)
+	}
+}
+
+// TestListTrending_OrdersByRecentVelocity tests that trending reflects reposts within
+// the window rather than all-time totals.
+func TestListTrending_OrdersByRecentVelocity(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+	base := time.Now()
+	repo.timeNow = func() time.Time { return base }
+
+	// "old" gathers more reposts overall, but all of them long ago
+	old := createPost(t, repo, sceneID, "old favourite")
+	for i := 0; i < 5; i++ {
+		createQuote(t, repo, sceneID, old)
+	}
+
+	// Two hours later, "fresh" and "steady" gain reposts
+	repo.timeNow = func() time.Time { return base.Add(2 * time.Hour) }
+	fresh := createPost(t, repo, sceneID, "fresh")
+	steady := createPost(t, repo, sceneID, "steady")
+	for i := 0; i < 3; i++ {
+		createQuote(t, repo, sceneID, fresh)
+	}
+	createQuote(t, repo, sceneID, steady)
+
+	// Posts in other scenes are excluded
+	otherScene := "other"
+	elsewhere := createPost(t, repo, otherScene, "elsewhere")
+	createQuote(t, repo, otherScene, elsewhere)
+
+	trending, err := repo.ListTrending(sceneID, time.Hour, 10)
+	if err != nil {
+		t.Fatalf("ListTrending failed: %v", err)
+	}
+
+	want := []string{fresh, steady}
+	if len(trending) != len(want) {
+		t.Fatalf("expected %d trending posts, got %d", len(want), len(trending))
+	}
+	for i, id := range want {
+		if trending[i].ID != id {
+			t.Errorf("trending[%d] = %s (%q), want %s", i, trending[i].ID, trending[i].Text, id)
+		}
+	}
+
+	// With a window covering everything, all-time reposts count too
+	trending, err = repo.ListTrending(sceneID, 24*time.Hour, 1)
+	if err != nil {
+		t.Fatalf("ListTrending failed: %v", err)
+	}
+	if len(trending) != 1 || trending[0].ID != old {
+		t.Errorf("expected old post to lead a 24h window, got %v", trending)
+	}
+}
+
+// TestListTrending_ExcludesHiddenAndDeleted tests moderation and deletion filtering.
+func TestListTrending_ExcludesHiddenAndDeleted(t *testing.T) {
+	repo := NewInMemoryPostRepository()
+	sceneID := "scene123"
+
+	hidden := createPost(t, repo, sceneID, "hidden")
+	deleted := createPost(t, repo, sceneID, "deleted")
+	visible := createPost(t, repo, sceneID, "visible")
+	for _, id := range []string{hidden, deleted, visible} {
+		createQuote(t, repo, sceneID, id)
+	}
+
+	post, _ := repo.GetByID(hidden)
+	post.Labels = []string{LabelHidden}
+	if err := repo.Update(post); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := repo.Delete(deleted); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	trending, err := repo.ListTrending(sceneID, time.Hour, 10)
+	if err != nil {
+		t.Fatalf("ListTrending failed: %v", err)
+	}
+	if len(trending) != 1 || trending[0].ID != visible {
+		t.Errorf("expected only the visible post, got %v", trending)
+	}
+}
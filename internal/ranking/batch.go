@@ -0,0 +1,73 @@
+package ranking
+
+import "math"
+
+// sceneWeightSet holds scene weights with NaN/Inf already replaced, so a batch
+// sanitizes each weight once instead of once per result.
+type sceneWeightSet struct {
+	text, proximity, trust, engagement float64
+}
+
+// eventWeightSet is the event counterpart of sceneWeightSet.
+type eventWeightSet struct {
+	recency, text, proximity, trust, engagement float64
+}
+
+// ScoreScenes computes CompositeScoreScene for each element of params using w,
+// returning the scores in the same order. Scores are identical to individual
+// calls, tag boosts and the freshness floor included; the batch only avoids re-sanitizing the weights
+// and building a breakdown for every result. Pass *GetActiveWeights() to use the calibrated
+// weights, fetched once for the whole batch.
+func ScoreScenes(params []SceneParams, w Weights) []float64 {
+	ws := sceneWeightSet{
+		text:       sanitize(ComponentWeight, w.Scene.TextMatch),
+		proximity:  sanitize(ComponentWeight, w.Scene.Proximity),
+		trust:      sanitize(ComponentWeight, w.Scene.Trust),
+		engagement: sanitize(ComponentWeight, w.Scene.Engagement),
+	}
+	floor := clampUnit(sanitize(ComponentWeight, w.FreshnessFloor))
+
+	scores := make([]float64, len(params))
+	for i, p := range params {
+		score := clampUnit(sanitize(ComponentText, p.Text))*ws.text +
+			clampUnit(sanitize(ComponentProximity, p.Proximity))*ws.proximity
+		if p.TrustEnabled {
+			score += clampUnit(sanitize(ComponentTrust, p.Trust)) * ws.trust
+		}
+		if ws.engagement != 0 {
+			score += clampUnit(sanitize(ComponentEngagement, p.Engagement)) * ws.engagement
+		}
+		score *= tagBoostMultiplier(p.Tags, w.TagBoosts, w.TagBoostCeiling)
+		scores[i] = math.Max(score, freshnessMinimum(p.Freshness, floor))
+	}
+	return scores
+}
+
+// ScoreEvents computes CompositeScoreEvent for each element of params using w,
+// returning the scores in the same order. See ScoreScenes.
+func ScoreEvents(params []EventParams, w Weights) []float64 {
+	ws := eventWeightSet{
+		recency:    sanitize(ComponentWeight, w.Event.Recency),
+		text:       sanitize(ComponentWeight, w.Event.TextMatch),
+		proximity:  sanitize(ComponentWeight, w.Event.Proximity),
+		trust:      sanitize(ComponentWeight, w.Event.Trust),
+		engagement: sanitize(ComponentWeight, w.Event.Engagement),
+	}
+	floor := clampUnit(sanitize(ComponentWeight, w.FreshnessFloor))
+
+	scores := make([]float64, len(params))
+	for i, p := range params {
+		score := clampUnit(sanitize(ComponentRecency, p.Recency))*ws.recency +
+			clampUnit(sanitize(ComponentText, p.Text))*ws.text +
+			clampUnit(sanitize(ComponentProximity, p.Proximity))*ws.proximity
+		if p.TrustEnabled {
+			score += clampUnit(sanitize(ComponentTrust, p.Trust)) * ws.trust
+		}
+		if ws.engagement != 0 {
+			score += clampUnit(sanitize(ComponentEngagement, p.Engagement)) * ws.engagement
+		}
+		score *= tagBoostMultiplier(p.Tags, w.TagBoosts, w.TagBoostCeiling)
+		scores[i] = math.Max(score, freshnessMinimum(p.Freshness, floor))
+	}
+	return scores
+}
@@ -0,0 +1,80 @@
+package ranking
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestScoreScenes_MatchesCompositeScoreScene(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		weights := randomWeights(r)
+		if r.Intn(2) == 0 {
+			weights.Scene.Engagement = 0
+		}
+
+		params := make([]SceneParams, r.Intn(20))
+		for j := range params {
+			params[j] = SceneParams{
+				Text:         randomComponent(r),
+				Proximity:    randomComponent(r),
+				Trust:        randomComponent(r),
+				TrustEnabled: r.Intn(2) == 0,
+				Engagement:   randomComponent(r),
+				Tags:         randomTags(r),
+			}
+		}
+
+		scores := ScoreScenes(params, *weights)
+		if len(scores) != len(params) {
+			t.Fatalf("ScoreScenes returned %d scores for %d params", len(scores), len(params))
+		}
+		for j, p := range params {
+			if want := CompositeScoreScene(p, weights); scores[j] != want {
+				t.Fatalf("iteration %d: ScoreScenes[%d] = %v, CompositeScoreScene(%+v) = %v", i, j, scores[j], p, want)
+			}
+		}
+	}
+}
+
+func TestScoreEvents_MatchesCompositeScoreEvent(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		weights := randomWeights(r)
+		if r.Intn(2) == 0 {
+			weights.Event.Engagement = 0
+		}
+
+		params := make([]EventParams, r.Intn(20))
+		for j := range params {
+			params[j] = EventParams{
+				Text:         randomComponent(r),
+				Proximity:    randomComponent(r),
+				Recency:      randomComponent(r),
+				Trust:        randomComponent(r),
+				TrustEnabled: r.Intn(2) == 0,
+				Engagement:   randomComponent(r),
+				Tags:         randomTags(r),
+			}
+		}
+
+		scores := ScoreEvents(params, *weights)
+		if len(scores) != len(params) {
+			t.Fatalf("ScoreEvents returned %d scores for %d params", len(scores), len(params))
+		}
+		for j, p := range params {
+			if want := CompositeScoreEvent(p, weights); scores[j] != want {
+				t.Fatalf("iteration %d: ScoreEvents[%d] = %v, CompositeScoreEvent(%+v) = %v", i, j, scores[j], p, want)
+			}
+		}
+	}
+}
+
+func TestScoreBatch_Empty(t *testing.T) {
+	if scores := ScoreScenes(nil, *DefaultWeights()); len(scores) != 0 {
+		t.Errorf("ScoreScenes(nil) = %v, want empty", scores)
+	}
+	if scores := ScoreEvents([]EventParams{}, *DefaultWeights()); len(scores) != 0 {
+		t.Errorf("ScoreEvents(empty) = %v, want empty", scores)
+	}
+}
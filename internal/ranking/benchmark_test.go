@@ -19,6 +19,18 @@ func BenchmarkProximityWeight(b *testing.B) {
 	}
 }
 
+// BenchmarkProximityWeightWithConfig compares the proximity decay models.
+func BenchmarkProximityWeightWithConfig(b *testing.B) {
+	for _, model := range []string{ProximityModelHyperbolic, ProximityModelExponential, ProximityModelLinear} {
+		cfg := ProximityConfig{HalfLifeMeters: DefaultProximityHalfLifeMeters, Model: model}
+		b.Run(model, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ProximityWeightWithConfig(1500.0, cfg)
+			}
+		})
+	}
+}
+
 // BenchmarkRecencyWeight benchmarks the recency weight calculation.
 func BenchmarkRecencyWeight(b *testing.B) {
 	startTime := time.Now().Add(6 * time.Hour)
@@ -30,6 +42,17 @@ func BenchmarkRecencyWeight(b *testing.B) {
 	}
 }
 
+// BenchmarkRecencyWeightWithHalfLife benchmarks recency with an explicit clock.
+func BenchmarkRecencyWeightWithHalfLife(b *testing.B) {
+	now := time.Now()
+	startTime := now.Add(6 * time.Hour)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RecencyWeightWithHalfLife(startTime, now, DefaultRecencyHalfLife)
+	}
+}
+
 // BenchmarkTrustWeight benchmarks the trust weight calculation.
 func BenchmarkTrustWeight(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -149,3 +172,65 @@ func BenchmarkFullSceneRanking(b *testing.B) {
 		_ = CompositeScoreScene(params, weights)
 	}
 }
+
+// benchmarkBatchSize is a typical number of search results scored per request.
+const benchmarkBatchSize = 500
+
+// BenchmarkScoreScenes compares batch scene scoring against one
+// CompositeScoreScene call per result.
+func BenchmarkScoreScenes(b *testing.B) {
+	params := make([]SceneParams, benchmarkBatchSize)
+	for i := range params {
+		params[i] = SceneParams{
+			Text:         float64(i%100) / 100,
+			Proximity:    ProximityWeight(float64(i * 10)),
+			Trust:        0.7,
+			TrustEnabled: true,
+		}
+	}
+	weights := DefaultWeights()
+
+	b.Run("individual", func(b *testing.B) {
+		scores := make([]float64, len(params))
+		for i := 0; i < b.N; i++ {
+			for j, p := range params {
+				scores[j] = CompositeScoreScene(p, weights)
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ScoreScenes(params, *weights)
+		}
+	})
+}
+
+// BenchmarkScoreEvents compares batch event scoring against one
+// CompositeScoreEvent call per result.
+func BenchmarkScoreEvents(b *testing.B) {
+	params := make([]EventParams, benchmarkBatchSize)
+	for i := range params {
+		params[i] = EventParams{
+			Recency:      float64(i%24) / 24,
+			Text:         float64(i%100) / 100,
+			Proximity:    ProximityWeight(float64(i * 10)),
+			Trust:        0.7,
+			TrustEnabled: true,
+		}
+	}
+	weights := DefaultWeights()
+
+	b.Run("individual", func(b *testing.B) {
+		scores := make([]float64, len(params))
+		for i := 0; i < b.N; i++ {
+			for j, p := range params {
+				scores[j] = CompositeScoreEvent(p, weights)
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ScoreEvents(params, *weights)
+		}
+	})
+}
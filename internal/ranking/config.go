@@ -1,17 +1,27 @@
 package ranking
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math"
 	"os"
+	"slices"
 	"sync"
 )
 
-// activeWeightsCache holds the process-wide calibration weights set at startup.
+// strictWeightSumEpsilon is how far a strict calibration's per-entity weight
+// sum may stray from 1.0, to absorb decimal rounding in the file.
+const strictWeightSumEpsilon = 1e-6
+
+// activeWeightsCache holds the process-wide calibration weights set at startup,
+// or the watcher that keeps them current.
 var activeWeightsCache struct {
 	mu      sync.RWMutex
 	weights *Weights
+	watcher *CalibrationWatcher
 }
 
 // SetActiveWeights stores calibrated weights for process-wide use.
@@ -23,12 +33,25 @@ func SetActiveWeights(w *Weights) {
 	activeWeightsCache.weights = w
 }
 
-// GetActiveWeights returns the active calibration weights.
-// Falls back to DefaultWeights() when SetActiveWeights has not been called.
+// SetActiveWatcher makes w the source of process-wide weights, so that
+// GetActiveWeights returns its current weights as the file is reloaded. It takes
+// precedence over SetActiveWeights. Pass nil to go back to static weights.
+func SetActiveWatcher(w *CalibrationWatcher) {
+	activeWeightsCache.mu.Lock()
+	defer activeWeightsCache.mu.Unlock()
+	activeWeightsCache.watcher = w
+}
+
+// GetActiveWeights returns the active calibration weights: the active
+// watcher's current weights if one is set, otherwise those passed to
+// SetActiveWeights, falling back to DefaultWeights().
 // Thread-safe via mutex.
 func GetActiveWeights() *Weights {
 	activeWeightsCache.mu.RLock()
 	defer activeWeightsCache.mu.RUnlock()
+	if activeWeightsCache.watcher != nil {
+		return activeWeightsCache.watcher.Weights()
+	}
 	if activeWeightsCache.weights != nil {
 		return activeWeightsCache.weights
 	}
@@ -37,29 +60,103 @@ func GetActiveWeights() *Weights {
 
 // SceneWeights defines the ranking weights for scene search.
 type SceneWeights struct {
-	TextMatch float64 `json:"text_match"` // Weight for text relevance (default: 0.4)
-	Proximity float64 `json:"proximity"`  // Weight for geographic proximity (default: 0.3)
-	Trust     float64 `json:"trust"`      // Weight for trust score (default: 0.1)
+	TextMatch  float64 `json:"text_match"` // Weight for text relevance (default: 0.4)
+	Proximity  float64 `json:"proximity"`  // Weight for geographic proximity (default: 0.3)
+	Trust      float64 `json:"trust"`      // Weight for trust score (default: 0.1)
+	Engagement float64 `json:"engagement"` // Weight for recent activity (default: 0, opt-in)
 }
 
 // EventWeights defines the ranking weights for event search.
 type EventWeights struct {
-	Recency   float64 `json:"recency"`    // Weight for time recency (default: 0.3)
-	TextMatch float64 `json:"text_match"` // Weight for text relevance (default: 0.4)
-	Proximity float64 `json:"proximity"`  // Weight for geographic proximity (default: 0.2)
-	Trust     float64 `json:"trust"`      // Weight for trust score (default: 0.1)
+	Recency    float64 `json:"recency"`    // Weight for time recency (default: 0.3)
+	TextMatch  float64 `json:"text_match"` // Weight for text relevance (default: 0.4)
+	Proximity  float64 `json:"proximity"`  // Weight for geographic proximity (default: 0.2)
+	Trust      float64 `json:"trust"`      // Weight for trust score (default: 0.1)
+	Engagement float64 `json:"engagement"` // Weight for recent activity (default: 0, opt-in)
 }
 
 // Weights holds all ranking weight configurations.
 type Weights struct {
 	Scene SceneWeights `json:"scene"` // Scene search weights
 	Event EventWeights `json:"event"` // Event search weights
+
+	// TagBoosts multiplies the composite score of scenes and events carrying
+	// these lowercase tags, e.g. {"accessible": 1.2}. Shared between copies of
+	// Weights, so treat it as read-only.
+	TagBoosts       map[string]float64 `json:"tag_boosts,omitempty"`
+	TagBoostCeiling float64            `json:"tag_boost_ceiling,omitempty"` // Cap on the combined boost (default: 2.0)
+
+	// FreshnessFloor is the minimum composite score granted to a brand-new
+	// scene or event, so new content can surface before it earns trust or
+	// engagement. The floor decays to 0 over FreshnessWindowHours; see
+	// FreshnessWeight. 0 (the default) disables it.
+	FreshnessFloor       float64 `json:"freshness_floor,omitempty"`
+	FreshnessWindowHours float64 `json:"freshness_window_hours,omitempty"` // Hours until the floor reaches 0 (default: 72)
 }
 
 // CalibrationConfig represents the JSON structure of the calibration file.
 type CalibrationConfig struct {
-	Version string  `json:"version"` // Config version for future compatibility
-	Weights Weights `json:"weights"` // Weight configurations
+	Version string  `json:"version"`          // Config version for future compatibility
+	Strict  bool    `json:"strict,omitempty"` // Require each entity's weights to sum to 1.0
+	Weights Weights `json:"weights"`          // Weight configurations
+}
+
+// weightFields returns every weight with its calibration file key.
+func (w *Weights) weightFields() []struct {
+	name  string
+	value float64
+} {
+	return []struct {
+		name  string
+		value float64
+	}{
+		{"scene.text_match", w.Scene.TextMatch},
+		{"scene.proximity", w.Scene.Proximity},
+		{"scene.trust", w.Scene.Trust},
+		{"scene.engagement", w.Scene.Engagement},
+		{"event.recency", w.Event.Recency},
+		{"event.text_match", w.Event.TextMatch},
+		{"event.proximity", w.Event.Proximity},
+		{"event.trust", w.Event.Trust},
+		{"event.engagement", w.Event.Engagement},
+	}
+}
+
+// Validate reports an error naming the first weight that is NaN, infinite,
+// or outside [0, 1], the first invalid tag boost, or an invalid freshness floor.
+func (w *Weights) Validate() error {
+	if w == nil {
+		return fmt.Errorf("weights are nil")
+	}
+	for _, f := range w.weightFields() {
+		if math.IsNaN(f.value) || math.IsInf(f.value, 0) {
+			return fmt.Errorf("invalid calibration weight %s: %v (must be a finite number)", f.name, f.value)
+		}
+		if f.value < 0 || f.value > 1 {
+			return fmt.Errorf("invalid calibration weight %s: %v (must be between 0 and 1)", f.name, f.value)
+		}
+	}
+	if err := validateTagBoosts(w.TagBoosts, w.TagBoostCeiling); err != nil {
+		return err
+	}
+	return validateFreshness(w.FreshnessFloor, w.FreshnessWindowHours)
+}
+
+// ValidateStrict runs Validate and additionally requires the scene weights and
+// the event weights, trust and engagement included, to each sum to 1.0 within a small epsilon.
+func (w *Weights) ValidateStrict() error {
+	if err := w.Validate(); err != nil {
+		return err
+	}
+	sceneSum := w.Scene.TextMatch + w.Scene.Proximity + w.Scene.Trust + w.Scene.Engagement
+	if math.Abs(sceneSum-1) > strictWeightSumEpsilon {
+		return fmt.Errorf("invalid calibration: scene weights sum to %v (strict mode requires 1.0)", sceneSum)
+	}
+	eventSum := w.Event.Recency + w.Event.TextMatch + w.Event.Proximity + w.Event.Trust + w.Event.Engagement
+	if math.Abs(eventSum-1) > strictWeightSumEpsilon {
+		return fmt.Errorf("invalid calibration: event weights sum to %v (strict mode requires 1.0)", eventSum)
+	}
+	return nil
 }
 
 // DefaultWeights returns the default ranking weight configuration.
@@ -77,6 +174,9 @@ type CalibrationConfig struct {
 // - Geographic proximity for local discovery
 // - Trust as a reputation signal
 // - Max score without trust: 0.9, with trust: 1.0
+//
+// Engagement and the freshness floor default to 0 so that enabling them is an
+// explicit calibration change. No tags are boosted by default.
 func DefaultWeights() *Weights {
 	return &Weights{
 		Scene: SceneWeights{
@@ -90,6 +190,8 @@ func DefaultWeights() *Weights {
 			Proximity: 0.2,
 			Trust:     0.1,
 		},
+		TagBoostCeiling:      DefaultTagBoostCeiling,
+		FreshnessWindowHours: DefaultFreshnessWindowHours,
 	}
 }
 
@@ -97,6 +199,8 @@ func DefaultWeights() *Weights {
 // If the file doesn't exist or can't be read, returns default weights with an error.
 // The file is expected to be in JSON format matching CalibrationConfig structure.
 // Partial configurations are merged with defaults for graceful degradation.
+// Unknown keys are rejected, and the merged weights must pass Validate, or
+// ValidateStrict when the file sets "strict": true.
 //
 // Parameters:
 //   - filePath: Path to the calibration JSON file
@@ -118,9 +222,11 @@ func LoadCalibration(filePath string) (*Weights, error) {
 		return DefaultWeights(), fmt.Errorf("failed to read calibration file: %w", err)
 	}
 
-	// Parse JSON
+	// Parse JSON, rejecting unknown keys so typos don't silently fall back to defaults
 	var config CalibrationConfig
-	if err := json.Unmarshal(data, &config); err != nil {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
 		slog.Warn("failed to parse calibration file, using defaults",
 			"path", filePath,
 			"error", err)
@@ -130,6 +236,17 @@ func LoadCalibration(filePath string) (*Weights, error) {
 	// Merge loaded weights with defaults to handle partial configurations
 	defaults := DefaultWeights()
 	merged := MergeCalibration(defaults, &config.Weights)
+
+	validate := merged.Validate
+	if config.Strict {
+		validate = merged.ValidateStrict
+	}
+	if err := validate(); err != nil {
+		slog.Warn("invalid calibration file, using defaults",
+			"path", filePath,
+			"error", err)
+		return DefaultWeights(), err
+	}
 	logCalibrationOverrides(defaults, merged)
 
 	return merged, nil
@@ -168,6 +285,9 @@ func MergeCalibration(base *Weights, override *Weights) *Weights {
 	if override.Scene.Trust != 0 {
 		result.Scene.Trust = override.Scene.Trust
 	}
+	if override.Scene.Engagement != 0 {
+		result.Scene.Engagement = override.Scene.Engagement
+	}
 
 	// Merge event weights
 	if override.Event.Recency != 0 {
@@ -182,6 +302,25 @@ func MergeCalibration(base *Weights, override *Weights) *Weights {
 	if override.Event.Trust != 0 {
 		result.Event.Trust = override.Event.Trust
 	}
+	if override.Event.Engagement != 0 {
+		result.Event.Engagement = override.Event.Engagement
+	}
+
+	// Tag boosts replace the base set as a whole
+	if override.TagBoosts != nil {
+		result.TagBoosts = override.TagBoosts
+	}
+	if override.TagBoostCeiling != 0 {
+		result.TagBoostCeiling = override.TagBoostCeiling
+	}
+
+	// Merge freshness floor
+	if override.FreshnessFloor != 0 {
+		result.FreshnessFloor = override.FreshnessFloor
+	}
+	if override.FreshnessWindowHours != 0 {
+		result.FreshnessWindowHours = override.FreshnessWindowHours
+	}
 
 	return &result
 }
@@ -203,6 +342,10 @@ func logCalibrationOverrides(defaults *Weights, loaded *Weights) {
 		overrides = append(overrides, fmt.Sprintf("scene.trust: %.2f -> %.2f",
 			defaults.Scene.Trust, loaded.Scene.Trust))
 	}
+	if loaded.Scene.Engagement != defaults.Scene.Engagement {
+		overrides = append(overrides, fmt.Sprintf("scene.engagement: %.2f -> %.2f",
+			defaults.Scene.Engagement, loaded.Scene.Engagement))
+	}
 
 	// Check event weight overrides
 	if loaded.Event.Recency != defaults.Event.Recency {
@@ -221,6 +364,29 @@ func logCalibrationOverrides(defaults *Weights, loaded *Weights) {
 		overrides = append(overrides, fmt.Sprintf("event.trust: %.2f -> %.2f",
 			defaults.Event.Trust, loaded.Event.Trust))
 	}
+	if loaded.Event.Engagement != defaults.Event.Engagement {
+		overrides = append(overrides, fmt.Sprintf("event.engagement: %.2f -> %.2f",
+			defaults.Event.Engagement, loaded.Event.Engagement))
+	}
+
+	// Check tag boost overrides
+	for _, tag := range slices.Sorted(maps.Keys(loaded.TagBoosts)) {
+		overrides = append(overrides, fmt.Sprintf("tag_boosts.%s: %.2f", tag, loaded.TagBoosts[tag]))
+	}
+	if loaded.TagBoostCeiling != defaults.TagBoostCeiling {
+		overrides = append(overrides, fmt.Sprintf("tag_boost_ceiling: %.2f -> %.2f",
+			defaults.TagBoostCeiling, loaded.TagBoostCeiling))
+	}
+
+	// Check freshness floor overrides
+	if loaded.FreshnessFloor != defaults.FreshnessFloor {
+		overrides = append(overrides, fmt.Sprintf("freshness_floor: %.2f -> %.2f",
+			defaults.FreshnessFloor, loaded.FreshnessFloor))
+	}
+	if loaded.FreshnessWindowHours != defaults.FreshnessWindowHours {
+		overrides = append(overrides, fmt.Sprintf("freshness_window_hours: %.2f -> %.2f",
+			defaults.FreshnessWindowHours, loaded.FreshnessWindowHours))
+	}
 
 	if len(overrides) > 0 {
 		slog.Info("loaded ranking calibration with overrides",
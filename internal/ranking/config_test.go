@@ -5,6 +5,8 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -36,6 +38,12 @@ func TestDefaultWeights(t *testing.T) {
 	if weights.Event.Trust != 0.1 {
 		t.Errorf("expected event trust 0.1, got %f", weights.Event.Trust)
 	}
+
+	// Engagement is opt-in
+	if weights.Scene.Engagement != 0 || weights.Event.Engagement != 0 {
+		t.Errorf("expected engagement weights 0, got scene %f, event %f",
+			weights.Scene.Engagement, weights.Event.Engagement)
+	}
 }
 
 // TestLoadCalibration_DefaultFile tests loading the actual default calibration file.
@@ -174,6 +182,235 @@ func TestLoadCalibration_InvalidJSON(t *testing.T) {
 	}
 }
 
+// TestWeightsValidate tests that each invalid weight is rejected and named.
+func TestWeightsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(w *Weights)
+		wantErr string // Substring of the error; empty means valid
+	}{
+		{name: "defaults", modify: func(w *Weights) {}},
+		{name: "zero weight", modify: func(w *Weights) { w.Scene.Trust = 0 }},
+		{name: "negative scene weight", modify: func(w *Weights) { w.Scene.Proximity = -0.1 }, wantErr: "scene.proximity"},
+		{name: "negative event weight", modify: func(w *Weights) { w.Event.Recency = -1 }, wantErr: "event.recency"},
+		{name: "weight above one", modify: func(w *Weights) { w.Event.Trust = 1.5 }, wantErr: "event.trust"},
+		{name: "NaN", modify: func(w *Weights) { w.Scene.TextMatch = math.NaN() }, wantErr: "scene.text_match"},
+		{name: "positive infinity", modify: func(w *Weights) { w.Event.Proximity = math.Inf(1) }, wantErr: "event.proximity"},
+		{name: "negative infinity", modify: func(w *Weights) { w.Event.TextMatch = math.Inf(-1) }, wantErr: "event.text_match"},
+		{name: "scene engagement above one", modify: func(w *Weights) { w.Scene.Engagement = 2 }, wantErr: "scene.engagement"},
+		{name: "negative event engagement", modify: func(w *Weights) { w.Event.Engagement = -0.1 }, wantErr: "event.engagement"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := DefaultWeights()
+			tt.modify(w)
+			err := w.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error naming %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	var nilWeights *Weights
+	if err := nilWeights.Validate(); err == nil {
+		t.Error("Validate() on nil weights expected error")
+	}
+}
+
+// TestWeightsValidateStrict tests the per-entity sum requirement.
+func TestWeightsValidateStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights Weights
+		wantErr string
+	}{
+		{
+			name: "both sum to one",
+			weights: Weights{
+				Scene: SceneWeights{TextMatch: 0.5, Proximity: 0.3, Trust: 0.2},
+				Event: EventWeights{Recency: 0.3, TextMatch: 0.4, Proximity: 0.2, Trust: 0.1},
+			},
+		},
+		{
+			name: "scene sum short",
+			weights: Weights{
+				Scene: SceneWeights{TextMatch: 0.4, Proximity: 0.3, Trust: 0.1},
+				Event: EventWeights{Recency: 0.3, TextMatch: 0.4, Proximity: 0.2, Trust: 0.1},
+			},
+			wantErr: "scene weights sum",
+		},
+		{
+			name: "event sum over",
+			weights: Weights{
+				Scene: SceneWeights{TextMatch: 0.5, Proximity: 0.3, Trust: 0.2},
+				Event: EventWeights{Recency: 0.4, TextMatch: 0.4, Proximity: 0.2, Trust: 0.1},
+			},
+			wantErr: "event weights sum",
+		},
+		{
+			name: "engagement counts toward the sum",
+			weights: Weights{
+				Scene: SceneWeights{TextMatch: 0.4, Proximity: 0.3, Trust: 0.1, Engagement: 0.2},
+				Event: EventWeights{Recency: 0.3, TextMatch: 0.3, Proximity: 0.2, Trust: 0.1, Engagement: 0.1},
+			},
+		},
+		{
+			name: "invalid weight reported before sums",
+			weights: Weights{
+				Scene: SceneWeights{TextMatch: 1.2, Proximity: -0.2},
+				Event: EventWeights{Recency: 0.3, TextMatch: 0.4, Proximity: 0.2, Trust: 0.1},
+			},
+			wantErr: "scene.text_match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.weights.ValidateStrict()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateStrict() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateStrict() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestLoadCalibration_Rejects tests that invalid calibration files fall back
+// to defaults with an error naming the problem.
+func TestLoadCalibration_Rejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		wantErr  string // Substring of the error; empty means the file loads
+	}{
+		{
+			name:     "unknown weight key",
+			contents: `{"weights": {"scene": {"proximty": 0.3}}}`,
+			wantErr:  `"proximty"`,
+		},
+		{
+			name:     "unknown entity",
+			contents: `{"weights": {"post": {"text_match": 0.3}}}`,
+			wantErr:  `"post"`,
+		},
+		{
+			name:     "unknown top-level key",
+			contents: `{"version": "1.0", "wieghts": {}}`,
+			wantErr:  `"wieghts"`,
+		},
+		{
+			name:     "negative weight",
+			contents: `{"weights": {"scene": {"proximity": -0.3}}}`,
+			wantErr:  "scene.proximity",
+		},
+		{
+			name:     "weight above one",
+			contents: `{"weights": {"event": {"recency": 3}}}`,
+			wantErr:  "event.recency",
+		},
+		{
+			name:     "strict sum mismatch",
+			contents: `{"strict": true, "weights": {"scene": {"text_match": 0.6}, "event": {"recency": 0.5}}}`,
+			wantErr:  "event weights sum",
+		},
+		{
+			name: "strict sums to one",
+			contents: `{"strict": true, "weights": {
+				"scene": {"text_match": 0.5, "proximity": 0.3, "trust": 0.2},
+				"event": {"recency": 0.3, "text_match": 0.4, "proximity": 0.2, "trust": 0.1}}}`,
+		},
+		{
+			name:     "engagement above one",
+			contents: `{"weights": {"event": {"engagement": 1.5}}}`,
+			wantErr:  "event.engagement",
+		},
+		{
+			name:     "non-strict partial sum",
+			contents: `{"weights": {"scene": {"text_match": 0.2}}}`,
+		},
+		{
+			name:     "non-positive tag boost",
+			contents: `{"weights": {"tag_boosts": {"sober": 0}}}`,
+			wantErr:  `"sober"`,
+		},
+		{
+			name:     "tag boost ceiling below one",
+			contents: `{"weights": {"tag_boost_ceiling": 0.5}}`,
+			wantErr:  "tag_boost_ceiling",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "calibration.json")
+			if err := os.WriteFile(path, []byte(tt.contents), 0644); err != nil {
+				t.Fatalf("failed to write temp file: %v", err)
+			}
+
+			weights, err := LoadCalibration(path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("LoadCalibration() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadCalibration() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if !weightsEqual(weights, DefaultWeights()) {
+				t.Errorf("LoadCalibration() = %+v, want defaults on error", weights)
+			}
+		})
+	}
+}
+
+func TestLoadCalibration_TagBoosts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+	contents := `{"version": "1.0", "weights": {
+		"scene": {"text_match": 0.5},
+		"tag_boosts": {"accessible": 1.25, "sober": 1.1},
+		"tag_boost_ceiling": 1.5}}`
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	weights, err := LoadCalibration(path)
+	if err != nil {
+		t.Fatalf("LoadCalibration() error = %v", err)
+	}
+	want := map[string]float64{"accessible": 1.25, "sober": 1.1}
+	if !reflect.DeepEqual(weights.TagBoosts, want) {
+		t.Errorf("TagBoosts = %v, want %v", weights.TagBoosts, want)
+	}
+	if weights.TagBoostCeiling != 1.5 {
+		t.Errorf("TagBoostCeiling = %v, want 1.5", weights.TagBoostCeiling)
+	}
+	if weights.Scene.TextMatch != 0.5 || weights.Event != DefaultWeights().Event {
+		t.Errorf("weights = %+v, want scene.text_match 0.5 merged over defaults", weights)
+	}
+
+	// Files without tag boosts keep the default ceiling and boost nothing
+	defaults, err := LoadCalibration("")
+	if err != nil {
+		t.Fatalf("LoadCalibration(\"\") error = %v", err)
+	}
+	if defaults.TagBoosts != nil || defaults.TagBoostCeiling != DefaultTagBoostCeiling {
+		t.Errorf("default tag boosts = %v, ceiling %v; want none, %v", defaults.TagBoosts, defaults.TagBoostCeiling, DefaultTagBoostCeiling)
+	}
+}
+
 // TestMergeCalibration tests merging override weights with defaults.
 func TestMergeCalibration(t *testing.T) {
 	base := DefaultWeights()
@@ -255,6 +492,24 @@ func TestMergeCalibration(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "engagement opt-in",
+			override: &Weights{
+				Scene: SceneWeights{Engagement: 0.15},
+				Event: EventWeights{Engagement: 0.05},
+			},
+			validate: func(t *testing.T, result *Weights) {
+				if result.Scene.Engagement != 0.15 {
+					t.Errorf("expected scene engagement 0.15, got %f", result.Scene.Engagement)
+				}
+				if result.Event.Engagement != 0.05 {
+					t.Errorf("expected event engagement 0.05, got %f", result.Event.Engagement)
+				}
+				if result.Scene.TextMatch != 0.4 {
+					t.Errorf("expected scene text_match unchanged at 0.4, got %f", result.Scene.TextMatch)
+				}
+			},
+		},
 		{
 			name:     "no override (all zeros)",
 			override: &Weights{},
@@ -344,5 +599,7 @@ func weightsEqual(a, b *Weights) bool {
 		math.Abs(a.Event.Recency-b.Event.Recency) < epsilon &&
 		math.Abs(a.Event.TextMatch-b.Event.TextMatch) < epsilon &&
 		math.Abs(a.Event.Proximity-b.Event.Proximity) < epsilon &&
-		math.Abs(a.Event.Trust-b.Event.Trust) < epsilon
+		math.Abs(a.Event.Trust-b.Event.Trust) < epsilon &&
+		math.Abs(a.Scene.Engagement-b.Scene.Engagement) < epsilon &&
+		math.Abs(a.Event.Engagement-b.Event.Engagement) < epsilon
 }
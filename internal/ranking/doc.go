@@ -28,17 +28,96 @@
 //	}
 //	score := ranking.CompositeScoreEvent(eventParams, weights)
 //
+// Batch Scoring:
+//
+// ScoreScenes and ScoreEvents score a whole result set in one call and return
+// the same scores as calling the composite functions per result. Prefer them
+// when ranking search results: they sanitize the weights once and skip the
+// per-result breakdown, which makes them an order of magnitude faster for a
+// few hundred results.
+//
+//	scores := ranking.ScoreScenes(params, *ranking.GetActiveWeights())
+//
+// Ordering Results:
+//
+// SortScored orders Scored results by descending score with ties broken by
+// ascending ID, the same order score cursors paginate in. Use it (or
+// StableLess in a custom sort) so tied results keep a stable position across
+// pages.
+//
+// Debugging Scores:
+//
+// CompositeScoreSceneExplain and CompositeScoreEventExplain return the same
+// score along with a ScoreBreakdown listing each component's value, weight,
+// and contribution, which is useful when a result ranks unexpectedly. The
+// contributions sum to the composite score.
+//
 // Weight Functions:
 //
 // All weight functions return values in the [0, 1] range and are designed
 // to be composable. Use them to calculate individual ranking components
 // before combining them with composite score functions.
 //
+// Engagement (EngagementWeight, from recent posts and RSVPs) is weighted 0 by
+// default. Set "engagement" under "scene" or "event" in the calibration file
+// to fold it into composite scores.
+//
+// RecencyWeight halves every half of the search window between now and the
+// event start, for past and upcoming events alike. RecencyWeightWithHalfLife
+// takes an explicit clock and half-life, and RecencyWeightWithConfig can
+// score events that already started as 0.
+//
+// Streams rank with CompositeScoreEvent as well. Pass the session's
+// StartTime, which is the announced go-live time while a stream is
+// scheduled, so upcoming streams rank like upcoming events:
+//
+//	params.Recency = ranking.RecencyWeight(session.StartTime(), windowSpan)
+//
+// Tag Boosts:
+//
+// The calibration file's "tag_boosts" section maps lowercase tags to score
+// multipliers, e.g. {"accessible": 1.2, "sober": 1.2}. When SceneParams.Tags
+// or EventParams.Tags match, the composite score is multiplied by the product
+// of the matching boosts, capped at "tag_boost_ceiling" (default 2.0). Unknown
+// tags are ignored. ApplyTagBoost applies the same rule to any score.
+//
+// Freshness Floor:
+//
+// A brand-new scene or event has little trust or engagement to rank on, so it
+// can be buried before anyone sees it. Setting "freshness_floor" gives new
+// content a minimum composite score that decays linearly to 0 over
+// "freshness_window_hours" (default 72). Callers opt items in by setting
+// Freshness from the item's creation time; established items leave it at 0:
+//
+//	params.Freshness = ranking.FreshnessWeight(scene.CreatedAt, time.Now(), weights.FreshnessWindow())
+//
+// Weight Experiments:
+//
+// LoadCalibrationVariants reads a file of named weight variants for an A/B
+// experiment, and SelectVariant deterministically assigns a bucket key such as
+// the user's DID to one of them. Record the chosen name so results can be
+// attributed to their variant:
+//
+//	name, weights := ranking.SelectVariant(variants, userDID)
+//	ranking.RecordVariantAssignment(name)
+//	slog.InfoContext(ctx, "ranked search results", "ranking_variant", name)
+//	scores := ranking.ScoreScenes(params, weights)
+//
 // Calibration:
 //
-// The calibration system allows deploy-time tuning of ranking weights via
-// JSON configuration files loaded at startup. This enables A/B testing and
-// optimization without code changes (but requires a redeploy or restart to
-// pick up new configuration). See configs/ranking.calibration.json for the
-// default configuration.
+// The calibration system allows tuning of ranking weights via JSON
+// configuration files. This enables A/B testing and optimization without code
+// changes. See configs/ranking.calibration.json for the default configuration.
+//
+// LoadCalibration reads the file once. To pick up edits without a restart,
+// use a CalibrationWatcher, which polls the file and atomically swaps in new
+// weights when it changes and validates; invalid edits are logged and the
+// last-good weights are kept:
+//
+//	watcher := ranking.NewCalibrationWatcher("configs/ranking.calibration.json")
+//	defer watcher.Close()
+//	ranking.SetActiveWatcher(watcher) // GetActiveWeights now follows the file
+//
+//	// Fetch the current weights once per request
+//	score := ranking.CompositeScoreScene(sceneParams, watcher.Weights())
 package ranking
@@ -0,0 +1,125 @@
+package ranking
+
+// ComponentScore is one component's part in a composite score.
+type ComponentScore struct {
+	Name         string  `json:"name"`         // One of the Component* labels
+	Value        float64 `json:"value"`        // Component score after sanitizing and clamping to [0, 1]
+	Weight       float64 `json:"weight"`       // Calibrated weight applied to the value
+	Contribution float64 `json:"contribution"` // Value * Weight
+}
+
+// ScoreBreakdown explains how a composite score was computed, for debugging
+// unexpected rankings. The contributions of Components sum to Score divided by
+// TagBoost, unless the freshness floor raised the score, in which case Score
+// equals FreshnessFloor. Engagement is omitted from Components while its weight
+// is 0, the default.
+type ScoreBreakdown struct {
+	Score          float64          `json:"score"`
+	Components     []ComponentScore `json:"components"`
+	TrustApplied   bool             `json:"trust_applied"`             // False when trust ranking is disabled; trust is then omitted from Components
+	TagBoost       float64          `json:"tag_boost"`                 // Multiplier from matching tag boosts; 1 when none match
+	FreshnessFloor float64          `json:"freshness_floor,omitempty"` // Minimum the score was raised to for new content; 0 when the floor didn't apply
+}
+
+// applyFreshnessFloor raises score to the item's share of the calibrated
+// freshness floor, recording the floor in the breakdown when it applies. A nil
+// breakdown records nothing.
+func (b *ScoreBreakdown) applyFreshnessFloor(score, freshness float64, weights *Weights) float64 {
+	floor := freshnessMinimum(freshness, clampUnit(sanitize(ComponentWeight, weights.FreshnessFloor)))
+	if floor <= score {
+		return score
+	}
+	if b != nil {
+		b.FreshnessFloor = floor
+	}
+	return floor
+}
+
+// add records a component and returns its contribution. A nil breakdown
+// records nothing, so scoring without an explanation doesn't allocate.
+func (b *ScoreBreakdown) add(name string, value, weight float64) float64 {
+	contribution := value * weight
+	if b == nil {
+		return contribution
+	}
+	b.Components = append(b.Components, ComponentScore{
+		Name:         name,
+		Value:        value,
+		Weight:       weight,
+		Contribution: contribution,
+	})
+	return contribution
+}
+
+// CompositeScoreSceneExplain computes the same score as CompositeScoreScene
+// and also returns a breakdown of each component's contribution.
+func CompositeScoreSceneExplain(params SceneParams, weights *Weights) (float64, ScoreBreakdown) {
+	var b ScoreBreakdown
+	score := compositeScoreScene(params, weights, &b)
+	return score, b
+}
+
+// CompositeScoreEventExplain computes the same score as CompositeScoreEvent
+// and also returns a breakdown of each component's contribution.
+func CompositeScoreEventExplain(params EventParams, weights *Weights) (float64, ScoreBreakdown) {
+	var b ScoreBreakdown
+	score := compositeScoreEvent(params, weights, &b)
+	return score, b
+}
+
+// compositeScoreScene computes a scene's composite score, filling in b when
+// it is non-nil.
+func compositeScoreScene(params SceneParams, weights *Weights, b *ScoreBreakdown) float64 {
+	if weights == nil {
+		weights = GetActiveWeights()
+	}
+
+	score := b.add(ComponentText, clampUnit(sanitize(ComponentText, params.Text)), sanitize(ComponentWeight, weights.Scene.TextMatch)) +
+		b.add(ComponentProximity, clampUnit(sanitize(ComponentProximity, params.Proximity)), sanitize(ComponentWeight, weights.Scene.Proximity))
+
+	if params.TrustEnabled {
+		score += b.add(ComponentTrust, clampUnit(sanitize(ComponentTrust, params.Trust)), sanitize(ComponentWeight, weights.Scene.Trust))
+	}
+	if w := sanitize(ComponentWeight, weights.Scene.Engagement); w != 0 {
+		score += b.add(ComponentEngagement, clampUnit(sanitize(ComponentEngagement, params.Engagement)), w)
+	}
+
+	tagBoost := tagBoostMultiplier(params.Tags, weights.TagBoosts, weights.TagBoostCeiling)
+	score = b.applyFreshnessFloor(score*tagBoost, params.Freshness, weights)
+
+	if b != nil {
+		b.Score = score
+		b.TrustApplied = params.TrustEnabled
+		b.TagBoost = tagBoost
+	}
+	return score
+}
+
+// compositeScoreEvent computes an event's composite score, filling in b when
+// it is non-nil.
+func compositeScoreEvent(params EventParams, weights *Weights, b *ScoreBreakdown) float64 {
+	if weights == nil {
+		weights = GetActiveWeights()
+	}
+
+	score := b.add(ComponentRecency, clampUnit(sanitize(ComponentRecency, params.Recency)), sanitize(ComponentWeight, weights.Event.Recency)) +
+		b.add(ComponentText, clampUnit(sanitize(ComponentText, params.Text)), sanitize(ComponentWeight, weights.Event.TextMatch)) +
+		b.add(ComponentProximity, clampUnit(sanitize(ComponentProximity, params.Proximity)), sanitize(ComponentWeight, weights.Event.Proximity))
+
+	if params.TrustEnabled {
+		score += b.add(ComponentTrust, clampUnit(sanitize(ComponentTrust, params.Trust)), sanitize(ComponentWeight, weights.Event.Trust))
+	}
+	if w := sanitize(ComponentWeight, weights.Event.Engagement); w != 0 {
+		score += b.add(ComponentEngagement, clampUnit(sanitize(ComponentEngagement, params.Engagement)), w)
+	}
+
+	tagBoost := tagBoostMultiplier(params.Tags, weights.TagBoosts, weights.TagBoostCeiling)
+	score = b.applyFreshnessFloor(score*tagBoost, params.Freshness, weights)
+
+	if b != nil {
+		b.Score = score
+		b.TrustApplied = params.TrustEnabled
+		b.TagBoost = tagBoost
+	}
+	return score
+}
@@ -0,0 +1,202 @@
+package ranking
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const breakdownEpsilon = 1e-9
+
+// randomComponent returns a component score, mostly in [0, 1] but sometimes
+// out of range or non-finite to exercise clamping and sanitizing.
+func randomComponent(r *rand.Rand) float64 {
+	switch r.Intn(20) {
+	case 0:
+		return math.NaN()
+	case 1:
+		return math.Inf(1)
+	case 2:
+		return -r.Float64()
+	case 3:
+		return 1 + r.Float64()
+	default:
+		return r.Float64()
+	}
+}
+
+func randomWeights(r *rand.Rand) *Weights {
+	return &Weights{
+		Scene: SceneWeights{TextMatch: r.Float64(), Proximity: r.Float64(), Trust: r.Float64(), Engagement: r.Float64()},
+		Event: EventWeights{Recency: r.Float64(), TextMatch: r.Float64(), Proximity: r.Float64(), Trust: r.Float64(), Engagement: r.Float64()},
+		TagBoosts: map[string]float64{
+			"accessible": 1 + r.Float64(),
+			"sober":      1 + r.Float64(),
+			"late-night": r.Float64(),
+		},
+		TagBoostCeiling: 1 + 2*r.Float64(),
+	}
+}
+
+// randomTags returns up to three tags, some boosted by randomWeights.
+func randomTags(r *rand.Rand) []string {
+	pool := []string{"accessible", "Sober", "late-night", "techno", "accessible"}
+	tags := make([]string, r.Intn(4))
+	for i := range tags {
+		tags[i] = pool[r.Intn(len(pool))]
+	}
+	return tags
+}
+
+// checkBreakdown asserts that a breakdown's contributions times its tag boost
+// equal score and that each contribution is its value times its weight.
+func checkBreakdown(t *testing.T, score float64, b ScoreBreakdown) {
+	t.Helper()
+	if b.Score != score {
+		t.Errorf("breakdown score = %v, want %v", b.Score, score)
+	}
+	sum := 0.0
+	for _, c := range b.Components {
+		if math.Abs(c.Value*c.Weight-c.Contribution) > breakdownEpsilon {
+			t.Errorf("%s contribution = %v, want value %v * weight %v", c.Name, c.Contribution, c.Value, c.Weight)
+		}
+		if c.Value < 0 || c.Value > 1 {
+			t.Errorf("%s value = %v, want clamped to [0, 1]", c.Name, c.Value)
+		}
+		sum += c.Contribution
+	}
+	if math.Abs(sum*b.TagBoost-score) > breakdownEpsilon {
+		t.Errorf("contributions sum to %v, want composite score %v (breakdown %+v)", sum, score, b)
+	}
+}
+
+func TestCompositeScoreExplain_ContributionsSumToScore(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		weights := randomWeights(r)
+
+		sceneParams := SceneParams{
+			Text:         randomComponent(r),
+			Proximity:    randomComponent(r),
+			Trust:        randomComponent(r),
+			TrustEnabled: r.Intn(2) == 0,
+			Engagement:   randomComponent(r),
+			Tags:         randomTags(r),
+		}
+		score, b := CompositeScoreSceneExplain(sceneParams, weights)
+		checkBreakdown(t, score, b)
+		if want := CompositeScoreScene(sceneParams, weights); score != want {
+			t.Errorf("CompositeScoreSceneExplain(%+v) = %v, CompositeScoreScene = %v", sceneParams, score, want)
+		}
+
+		eventParams := EventParams{
+			Text:         randomComponent(r),
+			Proximity:    randomComponent(r),
+			Recency:      randomComponent(r),
+			Trust:        randomComponent(r),
+			TrustEnabled: r.Intn(2) == 0,
+			Engagement:   randomComponent(r),
+			Tags:         randomTags(r),
+		}
+		score, b = CompositeScoreEventExplain(eventParams, weights)
+		checkBreakdown(t, score, b)
+		if want := CompositeScoreEvent(eventParams, weights); score != want {
+			t.Errorf("CompositeScoreEventExplain(%+v) = %v, CompositeScoreEvent = %v", eventParams, score, want)
+		}
+
+		if t.Failed() {
+			t.Fatalf("failed on iteration %d", i)
+		}
+	}
+}
+
+func TestCompositeScore_DoesNotAllocate(t *testing.T) {
+	weights := DefaultWeights()
+	weights.TagBoosts = map[string]float64{"accessible": 1.2}
+	sceneParams := SceneParams{Text: 0.8, Proximity: 0.6, Trust: 0.7, TrustEnabled: true, Tags: []string{"accessible"}, Freshness: 1}
+	eventParams := EventParams{Text: 0.8, Proximity: 0.6, Recency: 0.5, Trust: 0.7, TrustEnabled: true, Tags: []string{"accessible"}, Freshness: 1}
+
+	// Only the Explain variants build a breakdown
+	if allocs := testing.AllocsPerRun(100, func() { CompositeScoreScene(sceneParams, weights) }); allocs != 0 {
+		t.Errorf("CompositeScoreScene allocated %v times per call, want 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { CompositeScoreEvent(eventParams, weights) }); allocs != 0 {
+		t.Errorf("CompositeScoreEvent allocated %v times per call, want 0", allocs)
+	}
+}
+
+func TestCompositeScoreSceneExplain_Components(t *testing.T) {
+	weights := DefaultWeights()
+
+	_, b := CompositeScoreSceneExplain(SceneParams{Text: 0.5, Proximity: 1, Trust: 1}, weights)
+	if b.TrustApplied || len(b.Components) != 2 {
+		t.Errorf("trust disabled: breakdown = %+v, want text and proximity only", b)
+	}
+
+	_, b = CompositeScoreSceneExplain(SceneParams{Text: 0.5, Proximity: 1, Trust: 1, TrustEnabled: true}, weights)
+	if !b.TrustApplied || len(b.Components) != 3 {
+		t.Fatalf("trust enabled: breakdown = %+v, want three components", b)
+	}
+	want := []ComponentScore{
+		{Name: ComponentText, Value: 0.5, Weight: 0.4, Contribution: 0.2},
+		{Name: ComponentProximity, Value: 1, Weight: 0.3, Contribution: 0.3},
+		{Name: ComponentTrust, Value: 1, Weight: 0.1, Contribution: 0.1},
+	}
+	for i, c := range b.Components {
+		if c.Name != want[i].Name || c.Value != want[i].Value || c.Weight != want[i].Weight ||
+			math.Abs(c.Contribution-want[i].Contribution) > breakdownEpsilon {
+			t.Errorf("component %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+}
+
+func TestCompositeScoreEventExplain_Components(t *testing.T) {
+	_, b := CompositeScoreEventExplain(EventParams{Recency: 1, Text: 1, Proximity: 1, Trust: 1, TrustEnabled: true}, DefaultWeights())
+	names := make([]string, len(b.Components))
+	for i, c := range b.Components {
+		names[i] = c.Name
+	}
+	want := []string{ComponentRecency, ComponentText, ComponentProximity, ComponentTrust}
+	if len(names) != len(want) {
+		t.Fatalf("components = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("components = %v, want %v", names, want)
+			break
+		}
+	}
+}
+
+func TestCompositeScoreExplain_Engagement(t *testing.T) {
+	params := EventParams{Recency: 1, Text: 1, Proximity: 1, Engagement: 0.5}
+
+	// Default weights leave engagement out of the score and the breakdown
+	score, b := CompositeScoreEventExplain(params, DefaultWeights())
+	withoutEngagement := CompositeScoreEvent(EventParams{Recency: 1, Text: 1, Proximity: 1}, DefaultWeights())
+	if score != withoutEngagement {
+		t.Errorf("score with default weights = %v, want %v regardless of engagement", score, withoutEngagement)
+	}
+	for _, c := range b.Components {
+		if c.Name == ComponentEngagement {
+			t.Errorf("breakdown includes engagement with a zero weight: %+v", b)
+		}
+	}
+
+	weights := DefaultWeights()
+	weights.Event.Engagement = 0.2
+	score, b = CompositeScoreEventExplain(params, weights)
+	if math.Abs(score-(withoutEngagement+0.1)) > breakdownEpsilon {
+		t.Errorf("score = %v, want %v plus engagement 0.5 * 0.2", score, withoutEngagement)
+	}
+	last := b.Components[len(b.Components)-1]
+	if last.Name != ComponentEngagement || math.Abs(last.Contribution-0.1) > breakdownEpsilon {
+		t.Errorf("last component = %+v, want engagement contributing 0.1", last)
+	}
+
+	weights.Scene.Engagement = 0.5
+	sceneScore := CompositeScoreScene(SceneParams{Engagement: 1}, weights)
+	if math.Abs(sceneScore-0.5) > breakdownEpsilon {
+		t.Errorf("scene score = %v, want 0.5 from engagement alone", sceneScore)
+	}
+}
@@ -0,0 +1,51 @@
+package ranking
+
+import (
+	"math"
+	"time"
+)
+
+// ComponentEngagement labels the engagement component when reporting invalid inputs.
+const ComponentEngagement = "engagement"
+
+// Feed ranking blend. Recency dominates so a ranked feed stays close to
+// chronological; engagement lifts well-received posts by up to a day or so.
+const (
+	FeedRecencyHalfLife        = 24 * time.Hour
+	FeedEngagementSaturation   = 5.0
+	FeedRecencyWeightFactor    = 0.7
+	FeedEngagementWeightFactor = 0.3
+)
+
+// AgeDecayWeight computes an exponential decay score for an item of the given age.
+//
+// Returns 1.0 for items with no age (or future timestamps), 0.5 at one half-life,
+// 0.25 at two, and so on. A non-positive halfLife yields 1.0.
+func AgeDecayWeight(age, halfLife time.Duration) float64 {
+	if age <= 0 || halfLife <= 0 {
+		return 1.0
+	}
+	return math.Exp(-math.Ln2 * float64(age) / float64(halfLife))
+}
+
+// InteractionWeight maps an interaction count to [0, 1) with diminishing returns.
+// Formula: count / (count + saturation) - gives 0.5 when count equals saturation.
+// Negative counts and a non-positive saturation yield 0.
+func InteractionWeight(count int, saturation float64) float64 {
+	saturation = sanitize(ComponentEngagement, saturation)
+	if count <= 0 || saturation <= 0 {
+		return 0.0
+	}
+	return float64(count) / (float64(count) + saturation)
+}
+
+// CompositeScoreFeedPost computes the ranked feed score for a post.
+//
+// Formula: score = (recency * 0.7) + (engagement * 0.3), where recency decays with a
+// 24h half-life from createdAt to asOf and engagement saturates at 5 interactions.
+// Scores are deterministic for a fixed asOf, so callers can paginate over them.
+func CompositeScoreFeedPost(createdAt, asOf time.Time, engagement int) float64 {
+	recency := clampUnit(sanitize(ComponentRecency, AgeDecayWeight(asOf.Sub(createdAt), FeedRecencyHalfLife)))
+	engaged := clampUnit(InteractionWeight(engagement, FeedEngagementSaturation))
+	return (recency * FeedRecencyWeightFactor) + (engaged * FeedEngagementWeightFactor)
+}
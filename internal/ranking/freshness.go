@@ -0,0 +1,62 @@
+package ranking
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ComponentFreshness labels freshness scores when reporting invalid inputs.
+const ComponentFreshness = "freshness"
+
+// DefaultFreshnessWindowHours is how long new scenes and events keep part of
+// the freshness floor when the calibration file does not set
+// freshness_window_hours.
+const DefaultFreshnessWindowHours = 72.0
+
+// FreshnessWeight computes how much of the freshness floor an item created at
+// createdAt still receives at now, in [0, 1]: 1.0 at creation, decaying
+// linearly to 0 at the end of window. Items stamped after now (clock skew)
+// score 1.0, and a non-positive window scores everything 0.
+func FreshnessWeight(createdAt time.Time, now time.Time, window time.Duration) float64 {
+	if window <= 0 {
+		return 0.0
+	}
+	age := now.Sub(createdAt)
+	if age < 0 {
+		age = 0
+	}
+	return clampUnit(1 - float64(age)/float64(window))
+}
+
+// FreshnessWindow returns the calibrated freshness window, for passing to
+// FreshnessWeight, falling back to DefaultFreshnessWindowHours when unset.
+func (w *Weights) FreshnessWindow() time.Duration {
+	hours := w.FreshnessWindowHours
+	if math.IsNaN(hours) || math.IsInf(hours, 0) || hours <= 0 {
+		hours = DefaultFreshnessWindowHours
+	}
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// freshnessMinimum returns the lowest score an item with the given freshness
+// may receive: its share of floor, which is expected to be sanitized already.
+func freshnessMinimum(freshness, floor float64) float64 {
+	if floor == 0 {
+		return 0
+	}
+	return clampUnit(sanitize(ComponentFreshness, freshness)) * floor
+}
+
+// validateFreshness reports an error for a freshness floor that is not a
+// finite number in [0, 1] or a window that is not a finite, non-negative
+// number of hours. A zero window means the default.
+func validateFreshness(floor, windowHours float64) error {
+	if math.IsNaN(floor) || math.IsInf(floor, 0) || floor < 0 || floor > 1 {
+		return fmt.Errorf("invalid calibration freshness_floor: %v (must be between 0 and 1)", floor)
+	}
+	if math.IsNaN(windowHours) || math.IsInf(windowHours, 0) || windowHours < 0 {
+		return fmt.Errorf("invalid calibration freshness_window_hours: %v (must be a finite, non-negative number)", windowHours)
+	}
+	return nil
+}
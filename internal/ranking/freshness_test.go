@@ -0,0 +1,163 @@
+package ranking
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFreshnessWeight(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	window := 72 * time.Hour
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		window    time.Duration
+		want      float64
+	}{
+		{name: "just created", createdAt: now, window: window, want: 1},
+		{name: "created in the future", createdAt: now.Add(time.Hour), window: window, want: 1},
+		{name: "half the window", createdAt: now.Add(-36 * time.Hour), window: window, want: 0.5},
+		{name: "end of the window", createdAt: now.Add(-72 * time.Hour), window: window, want: 0},
+		{name: "older than the window", createdAt: now.Add(-30 * 24 * time.Hour), window: window, want: 0},
+		{name: "zero window", createdAt: now, window: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FreshnessWeight(tt.createdAt, now, tt.window); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("FreshnessWeight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompositeScore_FreshnessFloor(t *testing.T) {
+	weights := DefaultWeights()
+	weights.FreshnessFloor = 0.6
+	now := time.Now()
+	window := weights.FreshnessWindow()
+
+	// A brand-new scene with no signals gets the full floor
+	newScene := SceneParams{Freshness: FreshnessWeight(now, now, window)}
+	if got := CompositeScoreScene(newScene, weights); math.Abs(got-0.6) > 1e-9 {
+		t.Errorf("brand-new scene score = %v, want 0.6", got)
+	}
+
+	// A scene older than the window gets nothing extra
+	oldScene := SceneParams{Freshness: FreshnessWeight(now.Add(-2*window), now, window)}
+	if got := CompositeScoreScene(oldScene, weights); got != 0 {
+		t.Errorf("old scene score = %v, want 0", got)
+	}
+
+	// The floor decays over the window
+	midEvent := EventParams{Freshness: FreshnessWeight(now.Add(-window/2), now, window)}
+	if got := CompositeScoreEvent(midEvent, weights); math.Abs(got-0.3) > 1e-9 {
+		t.Errorf("half-window event score = %v, want 0.3", got)
+	}
+
+	// A score already above the floor is left alone
+	strong := SceneParams{Text: 1, Proximity: 1, Trust: 1, Freshness: 1}
+	if got, want := CompositeScoreScene(strong, weights), CompositeScoreScene(strong, DefaultWeights()); got != want {
+		t.Errorf("strong scene score = %v, want %v", got, want)
+	}
+
+	// The floor is disabled by default
+	if got := CompositeScoreScene(newScene, DefaultWeights()); got != 0 {
+		t.Errorf("brand-new scene score with default weights = %v, want 0", got)
+	}
+}
+
+func TestCompositeScoreExplain_FreshnessFloor(t *testing.T) {
+	weights := DefaultWeights()
+	weights.FreshnessFloor = 0.5
+
+	score, b := CompositeScoreSceneExplain(SceneParams{Text: 0.1, Freshness: 1}, weights)
+	if score != 0.5 || b.Score != 0.5 || b.FreshnessFloor != 0.5 {
+		t.Errorf("explain score = %v, breakdown = %+v, want score and floor 0.5", score, b)
+	}
+
+	_, b = CompositeScoreEventExplain(EventParams{Text: 1, Proximity: 1, Recency: 1}, weights)
+	if b.FreshnessFloor != 0 {
+		t.Errorf("breakdown freshness floor for an established event = %v, want 0", b.FreshnessFloor)
+	}
+}
+
+func TestScoreBatch_FreshnessFloor(t *testing.T) {
+	weights := DefaultWeights()
+	weights.FreshnessFloor = 0.4
+
+	scenes := []SceneParams{{Freshness: 1}, {Text: 0.2, Freshness: 0.25}, {Text: 1, Proximity: 1}}
+	for i, got := range ScoreScenes(scenes, *weights) {
+		if want := CompositeScoreScene(scenes[i], weights); got != want {
+			t.Errorf("ScoreScenes[%d] = %v, CompositeScoreScene = %v", i, got, want)
+		}
+	}
+
+	events := []EventParams{{Freshness: 1}, {Recency: 0.1, Freshness: 0.5}}
+	for i, got := range ScoreEvents(events, *weights) {
+		if want := CompositeScoreEvent(events[i], weights); got != want {
+			t.Errorf("ScoreEvents[%d] = %v, CompositeScoreEvent = %v", i, got, want)
+		}
+	}
+}
+
+func TestWeightsValidate_FreshnessFloor(t *testing.T) {
+	tests := []struct {
+		name        string
+		floor       float64
+		windowHours float64
+		wantErr     bool
+	}{
+		{name: "valid", floor: 0.3, windowHours: 48},
+		{name: "zero window means default", floor: 0.3},
+		{name: "negative floor", floor: -0.1, wantErr: true},
+		{name: "floor above one", floor: 1.5, wantErr: true},
+		{name: "NaN floor", floor: math.NaN(), wantErr: true},
+		{name: "negative window", floor: 0.3, windowHours: -1, wantErr: true},
+		{name: "infinite window", floor: 0.3, windowHours: math.Inf(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights := DefaultWeights()
+			weights.FreshnessFloor = tt.floor
+			weights.FreshnessWindowHours = tt.windowHours
+			if err := weights.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadCalibration_FreshnessFloor(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "freshness.json")
+	data, err := json.Marshal(CalibrationConfig{
+		Version: "1.0",
+		Weights: Weights{FreshnessFloor: 0.25, FreshnessWindowHours: 24},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	weights, err := LoadCalibration(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadCalibration() error = %v", err)
+	}
+	if weights.FreshnessFloor != 0.25 {
+		t.Errorf("expected freshness_floor 0.25, got %v", weights.FreshnessFloor)
+	}
+	if weights.FreshnessWindow() != 24*time.Hour {
+		t.Errorf("expected freshness window 24h, got %v", weights.FreshnessWindow())
+	}
+	if weights.Scene != DefaultWeights().Scene {
+		t.Errorf("expected default scene weights, got %+v", weights.Scene)
+	}
+}
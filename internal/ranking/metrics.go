@@ -0,0 +1,120 @@
+package ranking
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics names as constants for consistency.
+const (
+	MetricRankingInvalidInputs = "ranking_invalid_inputs_total"
+	MetricRankingTrustDegraded = "ranking_trust_degraded_total"
+	MetricRankingVariant       = "ranking_variant_assignments_total"
+)
+
+// Metrics contains Prometheus metrics for ranking input sanitization, trust
+// component availability and calibration variant assignment. All operations
+// are thread-safe.
+type Metrics struct {
+	invalidInputs *prometheus.CounterVec
+	trustDegraded *prometheus.CounterVec
+	variants      *prometheus.CounterVec
+}
+
+// NewMetrics creates and returns a new Metrics instance with all collectors initialized.
+// The metrics are not registered; call Register to register them with a registry.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		invalidInputs: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: MetricRankingInvalidInputs,
+				Help: "Total number of NaN or infinite ranking inputs replaced with 0, by component",
+			},
+			[]string{"component"},
+		),
+		trustDegraded: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: MetricRankingTrustDegraded,
+				Help: "Total number of search requests ranked without trust because trust lookup failed, by search type",
+			},
+			[]string{"search"},
+		),
+		variants: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: MetricRankingVariant,
+				Help: "Total number of requests ranked with each calibration variant",
+			},
+			[]string{"variant"},
+		),
+	}
+}
+
+// Register registers all metrics with the given registry.
+// Returns an error if registration fails.
+func (m *Metrics) Register(reg prometheus.Registerer) error {
+	for _, c := range m.Collectors() {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IncInvalidInput increments the invalid input counter for a ranking component.
+func (m *Metrics) IncInvalidInput(component string) {
+	m.invalidInputs.WithLabelValues(component).Inc()
+}
+
+// IncTrustDegraded increments the counter for searches ranked without trust.
+func (m *Metrics) IncTrustDegraded(search string) {
+	m.trustDegraded.WithLabelValues(search).Inc()
+}
+
+// IncVariant increments the counter for requests ranked with a calibration variant.
+func (m *Metrics) IncVariant(variant string) {
+	m.variants.WithLabelValues(variant).Inc()
+}
+
+// Collectors returns all Prometheus collectors for testing.
+func (m *Metrics) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.invalidInputs, m.trustDegraded, m.variants}
+}
+
+// activeMetrics holds the process-wide ranking metrics set at startup.
+var activeMetrics struct {
+	mu      sync.RWMutex
+	metrics *Metrics
+}
+
+// SetMetrics installs the process-wide metrics used by the package-level
+// ranking functions. Passing nil disables metric collection.
+// Thread-safe via mutex.
+func SetMetrics(m *Metrics) {
+	activeMetrics.mu.Lock()
+	defer activeMetrics.mu.Unlock()
+	activeMetrics.metrics = m
+}
+
+// getMetrics returns the active metrics, or nil if none are installed.
+func getMetrics() *Metrics {
+	activeMetrics.mu.RLock()
+	defer activeMetrics.mu.RUnlock()
+	return activeMetrics.metrics
+}
+
+// RecordTrustDegraded records that a search of the given type fell back to
+// trust-free ranking. No-op if no metrics are installed.
+func RecordTrustDegraded(search string) {
+	if m := getMetrics(); m != nil {
+		m.IncTrustDegraded(search)
+	}
+}
+
+// RecordVariantAssignment records that a request was ranked with the named
+// calibration variant, as chosen by SelectVariant. No-op if no metrics are installed.
+func RecordVariantAssignment(variant string) {
+	if m := getMetrics(); m != nil {
+		m.IncVariant(variant)
+	}
+}
@@ -0,0 +1,177 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// withTestMetrics installs fresh metrics for the duration of a test.
+func withTestMetrics(t *testing.T) *Metrics {
+	t.Helper()
+	m := NewMetrics()
+	SetMetrics(m)
+	t.Cleanup(func() { SetMetrics(nil) })
+	return m
+}
+
+func invalidInputCount(m *Metrics, component string) float64 {
+	return testutil.ToFloat64(m.invalidInputs.WithLabelValues(component))
+}
+
+func TestMetrics_Register(t *testing.T) {
+	m := NewMetrics()
+	reg := prometheus.NewRegistry()
+
+	if err := m.Register(reg); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+	if err := m.Register(reg); err == nil {
+		t.Error("expected error on duplicate registration")
+	}
+	if len(m.Collectors()) != 3 {
+		t.Errorf("expected 3 collectors, got %d", len(m.Collectors()))
+	}
+}
+
+func TestSanitization_TextWeight(t *testing.T) {
+	m := withTestMetrics(t)
+
+	if got := TextWeight(math.NaN(), 0.5); got != 0.0 {
+		t.Errorf("TextWeight(NaN, 0.5) = %f, want 0", got)
+	}
+	if got := TextWeight(0.5, math.Inf(1)); got != 0.0 {
+		t.Errorf("TextWeight(0.5, +Inf) = %f, want 0", got)
+	}
+
+	if got := invalidInputCount(m, ComponentText); got != 1 {
+		t.Errorf("text invalid input count = %f, want 1", got)
+	}
+	if got := invalidInputCount(m, ComponentWeight); got != 1 {
+		t.Errorf("weight invalid input count = %f, want 1", got)
+	}
+}
+
+func TestSanitization_ProximityAndTrust(t *testing.T) {
+	m := withTestMetrics(t)
+
+	if got := ProximityWeight(math.NaN()); got != 0.0 {
+		t.Errorf("ProximityWeight(NaN) = %f, want 0", got)
+	}
+	if got := ProximityWeight(math.Inf(1)); got != 0.0 {
+		t.Errorf("ProximityWeight(+Inf) = %f, want 0", got)
+	}
+	if got := TrustWeight(math.Inf(1), true); got != 0.0 {
+		t.Errorf("TrustWeight(+Inf) = %f, want 0", got)
+	}
+	// Disabled trust short-circuits before sanitization.
+	if got := TrustWeight(math.NaN(), false); got != 0.0 {
+		t.Errorf("TrustWeight(NaN, disabled) = %f, want 0", got)
+	}
+
+	if got := invalidInputCount(m, ComponentProximity); got != 2 {
+		t.Errorf("proximity invalid input count = %f, want 2", got)
+	}
+	if got := invalidInputCount(m, ComponentTrust); got != 1 {
+		t.Errorf("trust invalid input count = %f, want 1", got)
+	}
+}
+
+func TestSanitization_CompositeScoreScene(t *testing.T) {
+	m := withTestMetrics(t)
+
+	score := CompositeScoreScene(SceneParams{
+		Text:         math.NaN(),
+		Proximity:    math.Inf(1),
+		Trust:        1.0,
+		TrustEnabled: true,
+	}, DefaultWeights())
+
+	if math.IsNaN(score) || math.IsInf(score, 0) {
+		t.Fatalf("expected finite score, got %f", score)
+	}
+	// Only the trust component survives: 1.0 * 0.1
+	if math.Abs(score-0.1) > 0.001 {
+		t.Errorf("expected 0.1, got %f", score)
+	}
+
+	if got := invalidInputCount(m, ComponentText); got != 1 {
+		t.Errorf("text invalid input count = %f, want 1", got)
+	}
+	if got := invalidInputCount(m, ComponentProximity); got != 1 {
+		t.Errorf("proximity invalid input count = %f, want 1", got)
+	}
+}
+
+func TestSanitization_CompositeScoreEvent(t *testing.T) {
+	m := withTestMetrics(t)
+
+	score := CompositeScoreEvent(EventParams{
+		Recency:      math.Inf(1),
+		Text:         5.0, // out of range, clamped to 1
+		Proximity:    math.NaN(),
+		Trust:        math.Inf(-1),
+		TrustEnabled: true,
+	}, DefaultWeights())
+
+	if math.IsNaN(score) || math.IsInf(score, 0) {
+		t.Fatalf("expected finite score, got %f", score)
+	}
+	// Only the clamped text component survives: 1.0 * 0.4
+	if math.Abs(score-0.4) > 0.001 {
+		t.Errorf("expected 0.4, got %f", score)
+	}
+
+	for _, component := range []string{ComponentRecency, ComponentProximity, ComponentTrust} {
+		if got := invalidInputCount(m, component); got != 1 {
+			t.Errorf("%s invalid input count = %f, want 1", component, got)
+		}
+	}
+}
+
+func TestSanitization_NaNWeights(t *testing.T) {
+	m := withTestMetrics(t)
+
+	weights := DefaultWeights()
+	weights.Scene.TextMatch = math.NaN()
+
+	score := CompositeScoreScene(SceneParams{Text: 1.0, Proximity: 1.0}, weights)
+	if math.Abs(score-weights.Scene.Proximity) > 0.001 {
+		t.Errorf("expected %f, got %f", weights.Scene.Proximity, score)
+	}
+	if got := invalidInputCount(m, ComponentWeight); got != 1 {
+		t.Errorf("weight invalid input count = %f, want 1", got)
+	}
+}
+
+func TestRecordTrustDegraded(t *testing.T) {
+	// No-op without installed metrics
+	RecordTrustDegraded("scenes")
+
+	m := withTestMetrics(t)
+	RecordTrustDegraded("scenes")
+	RecordTrustDegraded("scenes")
+
+	if got := testutil.ToFloat64(m.trustDegraded.WithLabelValues("scenes")); got != 2 {
+		t.Errorf("trust degraded count = %f, want 2", got)
+	}
+}
+
+func TestRecordVariantAssignment(t *testing.T) {
+	// No-op without installed metrics
+	RecordVariantAssignment("control")
+
+	m := withTestMetrics(t)
+	RecordVariantAssignment("control")
+	RecordVariantAssignment("proximity")
+	RecordVariantAssignment("control")
+
+	if got := testutil.ToFloat64(m.variants.WithLabelValues("control")); got != 2 {
+		t.Errorf("control variant count = %f, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.variants.WithLabelValues("proximity")); got != 1 {
+		t.Errorf("proximity variant count = %f, want 1", got)
+	}
+}
@@ -0,0 +1,37 @@
+package ranking
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Scored pairs a result's ID with its ranking score for ordering.
+type Scored struct {
+	ID    string
+	Score float64
+}
+
+// StableLess reports whether a ranks before b: higher scores first, with equal
+// scores broken by ascending ID. This is the same order score cursors paginate
+// in, so results with tied scores keep their positions across pages. NaN scores
+// rank after every other score.
+func StableLess(a, b Scored) bool {
+	return compareScored(a, b) < 0
+}
+
+// SortScored sorts results into StableLess order. Results with equal IDs and
+// scores are interchangeable, so the order is fully determined by the input set.
+func SortScored(results []Scored) {
+	slices.SortFunc(results, compareScored)
+}
+
+// compareScored orders by score descending, then by ID ascending.
+func compareScored(a, b Scored) int {
+	// cmp.Compare orders NaN before all numbers; reversing the arguments for a
+	// descending sort puts it last
+	if c := cmp.Compare(b.Score, a.Score); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
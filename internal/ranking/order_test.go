@@ -0,0 +1,82 @@
+package ranking
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestStableLess(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Scored
+		want bool
+	}{
+		{name: "higher score first", a: Scored{ID: "b", Score: 0.9}, b: Scored{ID: "a", Score: 0.5}, want: true},
+		{name: "lower score after", a: Scored{ID: "a", Score: 0.5}, b: Scored{ID: "b", Score: 0.9}, want: false},
+		{name: "tie broken by ID", a: Scored{ID: "a", Score: 0.5}, b: Scored{ID: "b", Score: 0.5}, want: true},
+		{name: "tie with greater ID after", a: Scored{ID: "b", Score: 0.5}, b: Scored{ID: "a", Score: 0.5}, want: false},
+		{name: "identical", a: Scored{ID: "a", Score: 0.5}, b: Scored{ID: "a", Score: 0.5}, want: false},
+		{name: "NaN after numbers", a: Scored{ID: "a", Score: math.NaN()}, b: Scored{ID: "b", Score: 0}, want: false},
+		{name: "numbers before NaN", a: Scored{ID: "b", Score: -1}, b: Scored{ID: "a", Score: math.NaN()}, want: true},
+		{name: "NaN ties broken by ID", a: Scored{ID: "a", Score: math.NaN()}, b: Scored{ID: "b", Score: math.NaN()}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StableLess(tt.a, tt.b); got != tt.want {
+				t.Errorf("StableLess(%+v, %+v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortScored_IdenticalScores(t *testing.T) {
+	const n = 500
+	want := make([]string, n)
+	results := make([]Scored, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("scene-%04d", i)
+		want[i] = id
+		results[i] = Scored{ID: id, Score: 0.75}
+	}
+
+	// Every input order yields the same lexicographic ID order
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 20; round++ {
+		rng.Shuffle(len(results), func(i, j int) { results[i], results[j] = results[j], results[i] })
+		SortScored(results)
+
+		got := make([]string, n)
+		for i, r := range results {
+			got[i] = r.ID
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("round %d: SortScored() IDs not in lexicographic order: %v", round, got[:10])
+		}
+	}
+}
+
+func TestSortScored_MixedScores(t *testing.T) {
+	results := []Scored{
+		{ID: "c", Score: 0.5},
+		{ID: "nan", Score: math.NaN()},
+		{ID: "a", Score: 0.5},
+		{ID: "top", Score: 0.9},
+		{ID: "b", Score: 0.5},
+		{ID: "low", Score: 0.1},
+	}
+	SortScored(results)
+
+	want := []string{"top", "a", "b", "c", "low", "nan"}
+	for i, r := range results {
+		if r.ID != want[i] {
+			t.Errorf("position %d = %q, want %q", i, r.ID, want[i])
+		}
+	}
+	if !slices.IsSortedFunc(results, compareScored) {
+		t.Error("SortScored() result is not in StableLess order")
+	}
+}
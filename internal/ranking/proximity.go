@@ -0,0 +1,64 @@
+package ranking
+
+import "math"
+
+// Proximity decay models accepted by ProximityConfig.Model.
+const (
+	ProximityModelHyperbolic  = "hyperbolic"
+	ProximityModelExponential = "exponential"
+	ProximityModelLinear      = "linear"
+)
+
+// DefaultProximityHalfLifeMeters is the distance at which every model scores 0.5.
+const DefaultProximityHalfLifeMeters = 1000.0
+
+// ProximityConfig selects how proximity scores decay with distance. Every
+// model scores 1.0 at 0m and 0.5 at HalfLifeMeters:
+//   - hyperbolic:  1 / (1 + d/h), a long tail that never reaches 0
+//   - exponential: 0.5^(d/h), falls off faster than hyperbolic past the half-life
+//   - linear:      1 - d/(2h), reaching 0 at twice the half-life
+type ProximityConfig struct {
+	HalfLifeMeters float64 `json:"half_life_meters"`
+	Model          string  `json:"model"`
+}
+
+// DefaultProximityConfig returns the hyperbolic model with a 1km half-life,
+// matching the historical ProximityWeight curve.
+func DefaultProximityConfig() ProximityConfig {
+	return ProximityConfig{
+		HalfLifeMeters: DefaultProximityHalfLifeMeters,
+		Model:          ProximityModelHyperbolic,
+	}
+}
+
+// ProximityWeightWithConfig computes a distance-based proximity score in
+// [0, 1] using the decay model in cfg. The score is 1.0 at 0m and never
+// increases with distance. Negative distances are treated as 0 and a NaN or
+// infinite distance yields 0. A non-positive or non-finite half-life and an
+// empty or unknown model fall back to their DefaultProximityConfig values.
+func ProximityWeightWithConfig(distanceMeters float64, cfg ProximityConfig) float64 {
+	if math.IsNaN(distanceMeters) || math.IsInf(distanceMeters, 0) {
+		return sanitize(ComponentProximity, distanceMeters)
+	}
+	if distanceMeters < 0 {
+		distanceMeters = 0 // Clamp negative distances
+	}
+
+	halfLife := cfg.HalfLifeMeters
+	if !(halfLife > 0) || math.IsInf(halfLife, 0) {
+		halfLife = DefaultProximityHalfLifeMeters
+	}
+	x := distanceMeters / halfLife
+
+	var score float64
+	switch cfg.Model {
+	case ProximityModelExponential:
+		score = math.Exp(-math.Ln2 * x)
+	case ProximityModelLinear:
+		score = 1.0 - x/2.0
+	default:
+		score = 1.0 / (1.0 + x)
+	}
+
+	return clampUnit(score)
+}
@@ -0,0 +1,99 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+)
+
+var proximityModels = []string{ProximityModelHyperbolic, ProximityModelExponential, ProximityModelLinear}
+
+func TestProximityWeightWithConfig_Invariants(t *testing.T) {
+	for _, model := range proximityModels {
+		for _, halfLife := range []float64{250, 1000, 5000} {
+			cfg := ProximityConfig{HalfLifeMeters: halfLife, Model: model}
+
+			if got := ProximityWeightWithConfig(0, cfg); got != 1.0 {
+				t.Errorf("%s/%v: weight at 0m = %v, want 1.0", model, halfLife, got)
+			}
+			if got := ProximityWeightWithConfig(halfLife, cfg); math.Abs(got-0.5) > 1e-12 {
+				t.Errorf("%s/%v: weight at half-life = %v, want 0.5", model, halfLife, got)
+			}
+			if got := ProximityWeightWithConfig(-100, cfg); got != 1.0 {
+				t.Errorf("%s/%v: weight at -100m = %v, want 1.0", model, halfLife, got)
+			}
+
+			prev := 1.0
+			for d := 0.0; d <= 100*halfLife; d += halfLife / 20 {
+				got := ProximityWeightWithConfig(d, cfg)
+				if got < 0 || got > 1 {
+					t.Fatalf("%s/%v: weight at %vm = %v, outside [0, 1]", model, halfLife, d, got)
+				}
+				if got > prev {
+					t.Fatalf("%s/%v: weight increased from %v to %v at %vm", model, halfLife, prev, got, d)
+				}
+				prev = got
+			}
+		}
+	}
+}
+
+func TestProximityWeightWithConfig_Models(t *testing.T) {
+	tests := []struct {
+		model    string
+		distance float64
+		want     float64
+	}{
+		{ProximityModelHyperbolic, 2000, 1.0 / 3.0},
+		{ProximityModelExponential, 2000, 0.25},
+		{ProximityModelExponential, 3000, 0.125},
+		{ProximityModelLinear, 500, 0.75},
+		{ProximityModelLinear, 2000, 0},
+		{ProximityModelLinear, 10000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			cfg := ProximityConfig{HalfLifeMeters: 1000, Model: tt.model}
+			if got := ProximityWeightWithConfig(tt.distance, cfg); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("weight at %vm = %v, want %v", tt.distance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProximityWeightWithConfig_Fallbacks(t *testing.T) {
+	want := ProximityWeightWithConfig(1500, DefaultProximityConfig())
+	tests := []struct {
+		name string
+		cfg  ProximityConfig
+	}{
+		{name: "zero value", cfg: ProximityConfig{}},
+		{name: "unknown model", cfg: ProximityConfig{HalfLifeMeters: 1000, Model: "gaussian"}},
+		{name: "negative half-life", cfg: ProximityConfig{HalfLifeMeters: -1, Model: ProximityModelHyperbolic}},
+		{name: "NaN half-life", cfg: ProximityConfig{HalfLifeMeters: math.NaN(), Model: ProximityModelHyperbolic}},
+		{name: "infinite half-life", cfg: ProximityConfig{HalfLifeMeters: math.Inf(1), Model: ProximityModelHyperbolic}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProximityWeightWithConfig(1500, tt.cfg); got != want {
+				t.Errorf("weight = %v, want default %v", got, want)
+			}
+		})
+	}
+
+	for _, model := range proximityModels {
+		cfg := ProximityConfig{HalfLifeMeters: 1000, Model: model}
+		for _, d := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+			if got := ProximityWeightWithConfig(d, cfg); got != 0 {
+				t.Errorf("%s: weight at %v = %v, want 0", model, d, got)
+			}
+		}
+	}
+}
+
+func TestProximityWeight_UsesDefaultConfig(t *testing.T) {
+	for _, d := range []float64{0, 100, 1000, 2500, 10000} {
+		if got, want := ProximityWeight(d), 1.0/(1.0+d/1000.0); got != want {
+			t.Errorf("ProximityWeight(%v) = %v, want %v", d, got, want)
+		}
+	}
+}
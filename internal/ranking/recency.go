@@ -0,0 +1,48 @@
+package ranking
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultRecencyHalfLife is the distance from now at which an event scores 0.5
+// when RecencyConfig.HalfLife is not set.
+const DefaultRecencyHalfLife = 12 * time.Hour
+
+// RecencyConfig selects how recency scores decay with an event's distance in
+// time from now. Scores are 1.0 at now and 0.5 at HalfLife either side:
+// 0.5^(|now - start| / HalfLife). Events that already started decay like
+// upcoming ones unless PastScoresZero is set.
+type RecencyConfig struct {
+	HalfLife       time.Duration `json:"half_life"`
+	PastScoresZero bool          `json:"past_scores_zero"`
+}
+
+// RecencyWeightWithHalfLife computes a time-based recency score in [0, 1]
+// that halves every halfLife between startTime and now, treating past and
+// future events symmetrically. A non-positive halfLife falls back to
+// DefaultRecencyHalfLife.
+func RecencyWeightWithHalfLife(startTime time.Time, now time.Time, halfLife time.Duration) float64 {
+	return RecencyWeightWithConfig(startTime, now, RecencyConfig{HalfLife: halfLife})
+}
+
+// RecencyWeightWithConfig computes a time-based recency score in [0, 1] using
+// cfg. An event starting exactly at now scores 1.0; with PastScoresZero, any
+// event that started before now scores 0. A non-positive half-life falls back
+// to DefaultRecencyHalfLife.
+func RecencyWeightWithConfig(startTime time.Time, now time.Time, cfg RecencyConfig) float64 {
+	diff := startTime.Sub(now)
+	if diff < 0 {
+		if cfg.PastScoresZero {
+			return 0.0
+		}
+		diff = -diff
+	}
+
+	halfLife := cfg.HalfLife
+	if halfLife <= 0 {
+		halfLife = DefaultRecencyHalfLife
+	}
+
+	return clampUnit(math.Exp(-math.Ln2 * float64(diff) / float64(halfLife)))
+}
@@ -0,0 +1,65 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRecencyWeightWithHalfLife_Symmetric(t *testing.T) {
+	now := time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)
+	for _, halfLife := range []time.Duration{time.Hour, 12 * time.Hour, 7 * 24 * time.Hour} {
+		if got := RecencyWeightWithHalfLife(now.Add(halfLife), now, halfLife); math.Abs(got-0.5) > 1e-12 {
+			t.Errorf("%v: weight at half-life = %v, want 0.5", halfLife, got)
+		}
+
+		prev := 1.0
+		for d := time.Duration(0); d <= 50*halfLife; d += halfLife / 10 {
+			future := RecencyWeightWithHalfLife(now.Add(d), now, halfLife)
+			past := RecencyWeightWithHalfLife(now.Add(-d), now, halfLife)
+			if future != past {
+				t.Fatalf("%v: weight %v before now = %v, after = %v, want equal", halfLife, d, past, future)
+			}
+			if future < 0 || future > 1 {
+				t.Fatalf("%v: weight at %v = %v, outside [0, 1]", halfLife, d, future)
+			}
+			if future > prev {
+				t.Fatalf("%v: weight increased from %v to %v at %v", halfLife, prev, future, d)
+			}
+			prev = future
+		}
+	}
+}
+
+func TestRecencyWeightWithConfig_PastScoresZero(t *testing.T) {
+	now := time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)
+	cfg := RecencyConfig{HalfLife: 12 * time.Hour, PastScoresZero: true}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  float64
+	}{
+		{name: "started a second ago", start: now.Add(-time.Second), want: 0},
+		{name: "started a day ago", start: now.Add(-24 * time.Hour), want: 0},
+		{name: "starting now", start: now, want: 1},
+		{name: "starting in one half-life", start: now.Add(12 * time.Hour), want: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecencyWeightWithConfig(tt.start, now, cfg); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("weight = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecencyWeight_UsesHalfWindow(t *testing.T) {
+	window := 24 * time.Hour
+	start := time.Now().Add(6 * time.Hour)
+	got := RecencyWeight(start, window)
+	want := RecencyWeightWithHalfLife(start, time.Now(), window/2)
+	if math.Abs(got-want) > 1e-6 {
+		t.Errorf("RecencyWeight() = %v, want about %v", got, want)
+	}
+}
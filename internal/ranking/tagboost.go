@@ -0,0 +1,77 @@
+package ranking
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultTagBoostCeiling is the largest multiplier tag boosts may apply to a
+// score when the calibration file does not set tag_boost_ceiling.
+const DefaultTagBoostCeiling = 2.0
+
+// ApplyTagBoost multiplies base by the boosts of the tags it matches, capped at
+// DefaultTagBoostCeiling. See ApplyTagBoostWithCeiling.
+func ApplyTagBoost(base float64, tags []string, boosts map[string]float64) float64 {
+	return ApplyTagBoostWithCeiling(base, tags, boosts, DefaultTagBoostCeiling)
+}
+
+// ApplyTagBoostWithCeiling multiplies base by the product of the boosts for
+// each distinct tag found in boosts, with the product capped at ceiling.
+//
+// Tags are trimmed and lowercased before lookup, so boosts keys are expected
+// to be lowercase. Tags without a boost are ignored, and empty tags or boosts
+// leave base unchanged. Non-positive or non-finite boosts are skipped, and a
+// ceiling below 1 or non-finite is replaced with DefaultTagBoostCeiling.
+func ApplyTagBoostWithCeiling(base float64, tags []string, boosts map[string]float64, ceiling float64) float64 {
+	return base * tagBoostMultiplier(tags, boosts, ceiling)
+}
+
+// tagBoostMultiplier returns the capped product of the matching tag boosts, or
+// 1 when no tag matches.
+func tagBoostMultiplier(tags []string, boosts map[string]float64, ceiling float64) float64 {
+	if len(tags) == 0 || len(boosts) == 0 {
+		return 1.0
+	}
+	if math.IsNaN(ceiling) || math.IsInf(ceiling, 0) || ceiling < 1 {
+		ceiling = DefaultTagBoostCeiling
+	}
+
+	multiplier := 1.0
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+
+		boost, ok := boosts[tag]
+		if !ok {
+			continue
+		}
+		if boost = sanitize(ComponentTagBoost, boost); boost <= 0 {
+			continue
+		}
+		multiplier *= boost
+	}
+	return math.Min(multiplier, ceiling)
+}
+
+// validateTagBoosts reports an error naming the first tag boost that is not a
+// positive finite number or whose tag is not trimmed lowercase, or a ceiling
+// that is not a finite number of at least 1. A zero ceiling means the default.
+func validateTagBoosts(boosts map[string]float64, ceiling float64) error {
+	if math.IsNaN(ceiling) || math.IsInf(ceiling, 0) || (ceiling != 0 && ceiling < 1) {
+		return fmt.Errorf("invalid calibration tag_boost_ceiling: %v (must be a finite number of at least 1)", ceiling)
+	}
+	for tag, boost := range boosts {
+		if tag == "" || tag != strings.ToLower(strings.TrimSpace(tag)) {
+			return fmt.Errorf("invalid calibration tag boost %q: tags must be non-empty, lowercase and trimmed", tag)
+		}
+		if math.IsNaN(boost) || math.IsInf(boost, 0) || boost <= 0 {
+			return fmt.Errorf("invalid calibration tag boost %q: %v (must be a positive finite number)", tag, boost)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+)
+
+func TestApplyTagBoost(t *testing.T) {
+	boosts := map[string]float64{"accessible": 1.5, "sober": 1.2, "late-night": 0.8}
+
+	tests := []struct {
+		name string
+		tags []string
+		want float64
+	}{
+		{name: "nil tags", tags: nil, want: 0.5},
+		{name: "empty tags", tags: []string{}, want: 0.5},
+		{name: "unknown tags ignored", tags: []string{"techno", "warehouse"}, want: 0.5},
+		{name: "single boost", tags: []string{"accessible"}, want: 0.75},
+		{name: "boosts multiply", tags: []string{"accessible", "sober"}, want: 0.5 * 1.5 * 1.2},
+		{name: "unknown mixed with boosted", tags: []string{"techno", "sober"}, want: 0.6},
+		{name: "case and whitespace", tags: []string{"  Accessible "}, want: 0.75},
+		{name: "duplicate tag counted once", tags: []string{"accessible", "ACCESSIBLE"}, want: 0.75},
+		{name: "boost below one demotes", tags: []string{"late-night"}, want: 0.4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyTagBoost(0.5, tt.tags, boosts); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ApplyTagBoost(0.5, %q) = %v, want %v", tt.tags, got, tt.want)
+			}
+		})
+	}
+
+	// The default ceiling caps a large product
+	big := map[string]float64{"a": 1.9, "b": 1.9}
+	if got := ApplyTagBoost(0.5, []string{"a", "b"}, big); got != 0.5*DefaultTagBoostCeiling {
+		t.Errorf("ApplyTagBoost() = %v, want %v at the default ceiling", got, 0.5*DefaultTagBoostCeiling)
+	}
+}
+
+func TestApplyTagBoostWithCeiling(t *testing.T) {
+	boosts := map[string]float64{"accessible": 1.5, "sober": 1.5}
+	tags := []string{"accessible", "sober"}
+
+	tests := []struct {
+		name    string
+		ceiling float64
+		want    float64
+	}{
+		{name: "below product", ceiling: 1.8, want: 1.8},
+		{name: "above product", ceiling: 3, want: 2.25},
+		{name: "one disables boosting", ceiling: 1, want: 1},
+		{name: "below one uses default", ceiling: 0.5, want: DefaultTagBoostCeiling},
+		{name: "zero uses default", ceiling: 0, want: DefaultTagBoostCeiling},
+		{name: "NaN uses default", ceiling: math.NaN(), want: DefaultTagBoostCeiling},
+		{name: "Inf uses default", ceiling: math.Inf(1), want: DefaultTagBoostCeiling},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyTagBoostWithCeiling(1, tags, boosts, tt.ceiling); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ApplyTagBoostWithCeiling(ceiling=%v) = %v, want %v", tt.ceiling, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTagBoost_InvalidBoostsSkipped(t *testing.T) {
+	boosts := map[string]float64{
+		"nan":      math.NaN(),
+		"inf":      math.Inf(1),
+		"zero":     0,
+		"negative": -1.5,
+		"sober":    1.2,
+	}
+	got := ApplyTagBoost(0.5, []string{"nan", "inf", "zero", "negative", "sober"}, boosts)
+	if math.Abs(got-0.6) > 1e-9 {
+		t.Errorf("ApplyTagBoost() = %v, want 0.6 from the one valid boost", got)
+	}
+}
+
+func TestCompositeScore_TagBoosts(t *testing.T) {
+	weights := DefaultWeights()
+	weights.TagBoosts = map[string]float64{"accessible": 1.5}
+
+	sceneParams := SceneParams{Text: 0.5, Proximity: 0.5}
+	plainScene := CompositeScoreScene(sceneParams, weights)
+	sceneParams.Tags = []string{"accessible", "techno"}
+	if got, want := CompositeScoreScene(sceneParams, weights), plainScene*1.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("boosted scene score = %v, want %v", got, want)
+	}
+
+	eventParams := EventParams{Text: 0.5, Proximity: 0.5, Recency: 0.5}
+	plainEvent := CompositeScoreEvent(eventParams, weights)
+	eventParams.Tags = []string{"Accessible"}
+	if got, want := CompositeScoreEvent(eventParams, weights), plainEvent*1.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("boosted event score = %v, want %v", got, want)
+	}
+
+	// Tags are a no-op without configured boosts
+	if got := CompositeScoreScene(sceneParams, DefaultWeights()); got != plainScene {
+		t.Errorf("scene score with tags but no boosts = %v, want %v", got, plainScene)
+	}
+
+	_, b := CompositeScoreEventExplain(eventParams, weights)
+	if b.TagBoost != 1.5 {
+		t.Errorf("breakdown tag boost = %v, want 1.5", b.TagBoost)
+	}
+	_, b = CompositeScoreEventExplain(EventParams{Text: 0.5}, weights)
+	if b.TagBoost != 1 {
+		t.Errorf("breakdown tag boost without tags = %v, want 1", b.TagBoost)
+	}
+}
+
+func TestWeightsValidate_TagBoosts(t *testing.T) {
+	tests := []struct {
+		name    string
+		boosts  map[string]float64
+		ceiling float64
+		wantErr bool
+	}{
+		{name: "valid", boosts: map[string]float64{"accessible": 1.2, "late-night": 0.8}, ceiling: 1.5},
+		{name: "zero ceiling means default", boosts: map[string]float64{"sober": 1.2}},
+		{name: "zero boost", boosts: map[string]float64{"sober": 0}, wantErr: true},
+		{name: "negative boost", boosts: map[string]float64{"sober": -1}, wantErr: true},
+		{name: "NaN boost", boosts: map[string]float64{"sober": math.NaN()}, wantErr: true},
+		{name: "uppercase tag", boosts: map[string]float64{"Sober": 1.2}, wantErr: true},
+		{name: "empty tag", boosts: map[string]float64{"": 1.2}, wantErr: true},
+		{name: "ceiling below one", ceiling: 0.5, wantErr: true},
+		{name: "infinite ceiling", ceiling: math.Inf(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights := DefaultWeights()
+			weights.TagBoosts = tt.boosts
+			weights.TagBoostCeiling = tt.ceiling
+			if err := weights.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package ranking
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"slices"
+	"strings"
+)
+
+// CalibrationVariantsConfig represents the JSON structure of a calibration
+// variants file, which names several weight sets for an A/B experiment.
+type CalibrationVariantsConfig struct {
+	Version  string             `json:"version"`          // Config version for future compatibility
+	Strict   bool               `json:"strict,omitempty"` // Require each variant's entity weights to sum to 1.0
+	Variants map[string]Weights `json:"variants"`         // Weight configurations by variant name
+}
+
+// LoadCalibrationVariants loads named weight variants from a JSON file such as
+//
+//	{"version": "1.0", "variants": {
+//		"control":   {},
+//		"proximity": {"scene": {"proximity": 0.5}}}}
+//
+// Each variant is merged with the default weights like a calibration file's
+// weights, so an empty variant is the defaults. Unknown keys are rejected, as
+// are files without variants, blank variant names, and variants that fail
+// Validate (or ValidateStrict when the file sets "strict": true). Unlike
+// LoadCalibration there is no fallback: an experiment that cannot be loaded
+// should not run.
+func LoadCalibrationVariants(path string) (map[string]Weights, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calibration variants file: %w", err)
+	}
+
+	var config CalibrationVariantsConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse calibration variants file: %w", err)
+	}
+	if len(config.Variants) == 0 {
+		return nil, fmt.Errorf("invalid calibration variants file: no variants defined")
+	}
+
+	variants := make(map[string]Weights, len(config.Variants))
+	for name, override := range config.Variants {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid calibration variants file: variant names must not be blank")
+		}
+		merged := MergeCalibration(DefaultWeights(), &override)
+		validate := merged.Validate
+		if config.Strict {
+			validate = merged.ValidateStrict
+		}
+		if err := validate(); err != nil {
+			return nil, fmt.Errorf("invalid calibration variant %q: %w", name, err)
+		}
+		variants[name] = *merged
+	}
+	return variants, nil
+}
+
+// SelectVariant deterministically assigns bucketKey, typically the user's DID,
+// to one of variants and returns the variant's name and weights. The same key
+// always gets the same variant for a given set of variant names, and keys are
+// spread evenly across variants. Log or record the returned name (see
+// RecordVariantAssignment) so results can be attributed to their variant.
+//
+// Returns "" and the default weights if variants is empty.
+func SelectVariant(variants map[string]Weights, bucketKey string) (string, Weights) {
+	if len(variants) == 0 {
+		return "", *DefaultWeights()
+	}
+
+	// Sort names so assignment does not depend on map iteration order
+	names := make([]string, 0, len(variants))
+	for name := range variants {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	h := fnv.New64a()
+	h.Write([]byte(bucketKey))
+	name := names[h.Sum64()%uint64(len(names))]
+	return name, variants[name]
+}
@@ -0,0 +1,168 @@
+package ranking
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeVariants(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "variants.json")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	return path
+}
+
+func TestLoadCalibrationVariants(t *testing.T) {
+	path := writeVariants(t, `{"version": "1.0", "variants": {
+		"control": {},
+		"proximity": {"scene": {"proximity": 0.5}, "event": {"proximity": 0.35}},
+		"accessible": {"tag_boosts": {"accessible": 1.3}}}}`)
+
+	variants, err := LoadCalibrationVariants(path)
+	if err != nil {
+		t.Fatalf("LoadCalibrationVariants() error = %v", err)
+	}
+	if len(variants) != 3 {
+		t.Fatalf("expected 3 variants, got %d", len(variants))
+	}
+
+	if control := variants["control"]; !reflect.DeepEqual(&control, DefaultWeights()) {
+		t.Errorf("control = %+v, want defaults", control)
+	}
+
+	// Overrides are merged over the defaults per variant
+	proximity := variants["proximity"]
+	if proximity.Scene.Proximity != 0.5 || proximity.Event.Proximity != 0.35 {
+		t.Errorf("proximity variant = %+v, want scene 0.5 and event 0.35 proximity", proximity)
+	}
+	if proximity.Scene.TextMatch != DefaultWeights().Scene.TextMatch {
+		t.Errorf("proximity variant scene.text_match = %v, want default", proximity.Scene.TextMatch)
+	}
+
+	if boost := variants["accessible"].TagBoosts["accessible"]; boost != 1.3 {
+		t.Errorf("accessible variant boost = %v, want 1.3", boost)
+	}
+}
+
+func TestLoadCalibrationVariants_Rejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		wantErr  string
+	}{
+		{
+			name:     "invalid JSON",
+			contents: `{"variants": `,
+			wantErr:  "failed to parse",
+		},
+		{
+			name:     "no variants",
+			contents: `{"version": "1.0", "variants": {}}`,
+			wantErr:  "no variants",
+		},
+		{
+			name:     "blank variant name",
+			contents: `{"variants": {" ": {}}}`,
+			wantErr:  "blank",
+		},
+		{
+			name:     "unknown key",
+			contents: `{"variants": {"control": {"scene": {"proximty": 0.3}}}}`,
+			wantErr:  `"proximty"`,
+		},
+		{
+			name:     "weight out of range names the variant",
+			contents: `{"variants": {"control": {}, "broken": {"event": {"recency": 2}}}}`,
+			wantErr:  `variant "broken"`,
+		},
+		{
+			name:     "strict sum mismatch",
+			contents: `{"strict": true, "variants": {"control": {}}}`,
+			wantErr:  "scene weights sum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variants, err := LoadCalibrationVariants(writeVariants(t, tt.contents))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadCalibrationVariants() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if variants != nil {
+				t.Errorf("LoadCalibrationVariants() = %v, want nil on error", variants)
+			}
+		})
+	}
+
+	if _, err := LoadCalibrationVariants(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
+
+func testVariants() map[string]Weights {
+	proximity := *DefaultWeights()
+	proximity.Scene.Proximity = 0.5
+	recency := *DefaultWeights()
+	recency.Event.Recency = 0.5
+	return map[string]Weights{
+		"control":   *DefaultWeights(),
+		"proximity": proximity,
+		"recency":   recency,
+	}
+}
+
+func TestSelectVariant_Deterministic(t *testing.T) {
+	variants := testVariants()
+
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("did:plc:user%d", i)
+		name, weights := SelectVariant(variants, key)
+		if _, ok := variants[name]; !ok {
+			t.Fatalf("SelectVariant(%q) = %q, not a variant", key, name)
+		}
+		if !reflect.DeepEqual(weights, variants[name]) {
+			t.Errorf("SelectVariant(%q) weights = %+v, want variant %q's", key, weights, name)
+		}
+
+		// Repeated calls, and an equal map built separately, give the same variant
+		for j := 0; j < 5; j++ {
+			if again, _ := SelectVariant(testVariants(), key); again != name {
+				t.Fatalf("SelectVariant(%q) = %q then %q, want a stable assignment", key, name, again)
+			}
+		}
+	}
+}
+
+func TestSelectVariant_EvenDistribution(t *testing.T) {
+	variants := testVariants()
+
+	const keys = 30000
+	counts := make(map[string]int)
+	for i := 0; i < keys; i++ {
+		name, _ := SelectVariant(variants, fmt.Sprintf("did:plc:user%d", i))
+		counts[name]++
+	}
+
+	expected := float64(keys) / float64(len(variants))
+	for name := range variants {
+		if got := float64(counts[name]); got < expected*0.95 || got > expected*1.05 {
+			t.Errorf("variant %q got %v of %d keys, want within 5%% of %v", name, got, keys, expected)
+		}
+	}
+}
+
+func TestSelectVariant_NoVariants(t *testing.T) {
+	name, weights := SelectVariant(nil, "did:plc:user1")
+	if name != "" {
+		t.Errorf("SelectVariant(nil) name = %q, want empty", name)
+	}
+	if !reflect.DeepEqual(&weights, DefaultWeights()) {
+		t.Errorf("SelectVariant(nil) weights = %+v, want defaults", weights)
+	}
+}
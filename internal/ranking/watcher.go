@@ -0,0 +1,131 @@
+package ranking
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCalibrationPollInterval is how often a CalibrationWatcher checks its
+// file for changes.
+const DefaultCalibrationPollInterval = 10 * time.Second
+
+// CalibrationWatcher holds the current ranking weights and reloads them when
+// the calibration file changes, so weights can be tuned without a restart.
+// The file is polled rather than watched with inotify so that editors and
+// config management tools that replace the file by renaming are picked up.
+//
+// Weights is safe to call concurrently with a reload: each reload builds a
+// fresh Weights and swaps the pointer, so readers always see a complete set.
+type CalibrationWatcher struct {
+	path     string
+	interval time.Duration
+	current  atomic.Pointer[Weights]
+
+	// Last observed file state, owned by the polling goroutine
+	modTime time.Time
+	size    int64
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewCalibrationWatcher loads the calibration file at path and starts polling
+// it for changes. If the initial load fails, default weights are used until
+// the file becomes valid. Call Close to stop polling.
+func NewCalibrationWatcher(path string) *CalibrationWatcher {
+	return newCalibrationWatcher(path, DefaultCalibrationPollInterval)
+}
+
+func newCalibrationWatcher(path string, interval time.Duration) *CalibrationWatcher {
+	w := &CalibrationWatcher{
+		path:     path,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	w.current.Store(DefaultWeights())
+	w.modTime, w.size = w.stat()
+	if err := w.reload(); err != nil {
+		slog.Warn("failed to load ranking calibration, using defaults until the file is fixed",
+			"path", path,
+			"error", err)
+	}
+
+	go w.run()
+	return w
+}
+
+// Weights returns the current ranking weights. The returned value must not be
+// modified; fetch it once per request so a request ranks with a single set.
+func (w *CalibrationWatcher) Weights() *Weights {
+	return w.current.Load()
+}
+
+// Close stops polling and waits for the polling goroutine to exit. The last
+// loaded weights remain available. Safe to call more than once.
+func (w *CalibrationWatcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+}
+
+// run polls the calibration file until Close is called.
+func (w *CalibrationWatcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.poll()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// poll reloads the calibration file if its modification time or size changed.
+// On failure the last-good weights are kept.
+func (w *CalibrationWatcher) poll() {
+	modTime, size := w.stat()
+	if modTime.Equal(w.modTime) && size == w.size {
+		return
+	}
+	w.modTime, w.size = modTime, size
+
+	if err := w.reload(); err != nil {
+		slog.Warn("failed to reload ranking calibration, keeping last-good weights",
+			"path", w.path,
+			"error", err)
+		return
+	}
+	slog.Info("ranking calibration reloaded", "path", w.path)
+}
+
+// stat returns the calibration file's modification time and size, or zero
+// values if it cannot be read, so a deleted file registers as a change.
+func (w *CalibrationWatcher) stat() (time.Time, int64) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return time.Time{}, 0
+	}
+	return info.ModTime(), info.Size()
+}
+
+// reload loads the calibration file, which LoadCalibration validates, and
+// swaps in the result.
+func (w *CalibrationWatcher) reload() error {
+	weights, err := LoadCalibration(w.path)
+	if err != nil {
+		return err
+	}
+	w.current.Store(weights)
+	return nil
+}
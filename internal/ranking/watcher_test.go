@@ -0,0 +1,183 @@
+package ranking
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// writeCalibration writes contents to path and bumps its modification time so
+// the change is seen even on filesystems with coarse timestamps.
+func writeCalibration(t *testing.T, path, contents string) {
+	t.Helper()
+	mtime := time.Now()
+	if info, err := os.Stat(path); err == nil {
+		mtime = info.ModTime().Add(time.Second)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write calibration file: %v", err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatalf("failed to set calibration file mtime: %v", err)
+	}
+}
+
+func calibrationJSON(sceneTextMatch string) string {
+	return `{"version": "1.0", "weights": {"scene": {"text_match": ` + sceneTextMatch + `}}}`
+}
+
+func TestCalibrationWatcher_ReloadsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+	writeCalibration(t, path, calibrationJSON("0.5"))
+
+	w := newCalibrationWatcher(path, 10*time.Millisecond)
+	defer w.Close()
+
+	if got := w.Weights().Scene.TextMatch; got != 0.5 {
+		t.Fatalf("initial scene.text_match = %v, want 0.5", got)
+	}
+
+	writeCalibration(t, path, calibrationJSON("0.65"))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for w.Weights().Scene.TextMatch != 0.65 {
+		if time.Now().After(deadline) {
+			t.Fatalf("scene.text_match = %v, want 0.65 after the file changed", w.Weights().Scene.TextMatch)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	// Fields absent from the file keep their defaults
+	if got := w.Weights().Event; got != DefaultWeights().Event {
+		t.Errorf("event weights = %+v, want defaults", got)
+	}
+}
+
+func TestCalibrationWatcher_KeepsLastGoodWeights(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{name: "invalid JSON", contents: `{"weights": `},
+		{name: "weight out of range", contents: calibrationJSON("1.5")},
+		{name: "negative weight", contents: calibrationJSON("-0.2")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "calibration.json")
+			writeCalibration(t, path, calibrationJSON("0.5"))
+
+			// Poll by hand so the test controls when reloads happen
+			w := newCalibrationWatcher(path, time.Hour)
+			defer w.Close()
+			good := w.Weights()
+
+			writeCalibration(t, path, tt.contents)
+			w.poll()
+			if got := w.Weights(); got != good {
+				t.Errorf("weights = %+v, want last-good %+v", got, good)
+			}
+
+			// A later valid file is still picked up
+			writeCalibration(t, path, calibrationJSON("0.7"))
+			w.poll()
+			if got := w.Weights().Scene.TextMatch; got != 0.7 {
+				t.Errorf("scene.text_match = %v, want 0.7 after fixing the file", got)
+			}
+		})
+	}
+}
+
+func TestCalibrationWatcher_MissingFileUsesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+
+	w := newCalibrationWatcher(path, time.Hour)
+	defer w.Close()
+
+	if got := w.Weights(); !reflect.DeepEqual(got, DefaultWeights()) {
+		t.Errorf("weights = %+v, want defaults", got)
+	}
+
+	writeCalibration(t, path, calibrationJSON("0.55"))
+	w.poll()
+	if got := w.Weights().Scene.TextMatch; got != 0.55 {
+		t.Errorf("scene.text_match = %v, want 0.55 once the file exists", got)
+	}
+}
+
+func TestCalibrationWatcher_ConcurrentReads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+	writeCalibration(t, path, calibrationJSON("0.5"))
+
+	w := newCalibrationWatcher(path, time.Millisecond)
+	defer w.Close()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				weights := w.Weights()
+				if weights.Scene.TextMatch != 0.5 && weights.Scene.TextMatch != 0.6 {
+					t.Errorf("read torn scene.text_match %v", weights.Scene.TextMatch)
+					return
+				}
+				CompositeScoreScene(SceneParams{Text: 1, Proximity: 1}, weights)
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		if i%2 == 0 {
+			writeCalibration(t, path, calibrationJSON("0.6"))
+		} else {
+			writeCalibration(t, path, calibrationJSON("0.5"))
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestCalibrationWatcher_CloseIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+	writeCalibration(t, path, calibrationJSON("0.5"))
+
+	w := newCalibrationWatcher(path, time.Millisecond)
+	w.Close()
+	w.Close()
+
+	if got := w.Weights().Scene.TextMatch; got != 0.5 {
+		t.Errorf("scene.text_match = %v after Close, want last loaded 0.5", got)
+	}
+}
+
+func TestSetActiveWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+	writeCalibration(t, path, calibrationJSON("0.45"))
+
+	w := newCalibrationWatcher(path, time.Hour)
+	defer w.Close()
+
+	SetActiveWatcher(w)
+	defer SetActiveWatcher(nil)
+
+	if got := GetActiveWeights().Scene.TextMatch; got != 0.45 {
+		t.Errorf("active scene.text_match = %v, want 0.45 from the watcher", got)
+	}
+
+	writeCalibration(t, path, calibrationJSON("0.35"))
+	w.poll()
+	if got := GetActiveWeights().Scene.TextMatch; got != 0.35 {
+		t.Errorf("active scene.text_match = %v, want 0.35 after reload", got)
+	}
+}
@@ -3,17 +3,57 @@
 package ranking
 
 import (
+	"log/slog"
+	"math"
 	"time"
 )
 
+// Ranking component labels used when reporting invalid inputs.
+const (
+	ComponentText      = "text"
+	ComponentProximity = "proximity"
+	ComponentRecency   = "recency"
+	ComponentTrust     = "trust"
+	ComponentWeight    = "weight"
+	ComponentTagBoost  = "tag_boost"
+)
+
+// sanitize replaces NaN and ±Inf with 0 so a single bad input cannot poison
+// a composite score. Each replacement is logged and counted via the active Metrics.
+func sanitize(component string, v float64) float64 {
+	if !math.IsNaN(v) && !math.IsInf(v, 0) {
+		return v
+	}
+	slog.Warn("ranking: non-finite input replaced with 0", "component", component, "value", v)
+	if m := getMetrics(); m != nil {
+		m.IncInvalidInput(component)
+	}
+	return 0.0
+}
+
+// clampUnit clamps v to the [0, 1] range.
+func clampUnit(v float64) float64 {
+	if v < 0.0 {
+		return 0.0
+	}
+	if v > 1.0 {
+		return 1.0
+	}
+	return v
+}
+
 // TextWeight computes a weighted text ranking score.
 // Parameters:
 //   - rawRank: The raw text match score (typically from database ts_rank or similar)
 //   - w: The weight to apply to the raw rank
 //
 // Returns a weighted score normalized to the [0, 1] range. The raw rank and weight
-// are expected to be in the [0, 1] range; any out-of-range inputs are clamped.
+// are expected to be in the [0, 1] range; any out-of-range inputs are clamped and
+// NaN/Inf inputs are treated as 0.
 func TextWeight(rawRank float64, w float64) float64 {
+	rawRank = sanitize(ComponentText, rawRank)
+	w = sanitize(ComponentWeight, w)
+
 	// Clamp rawRank to [0, 1]
 	if rawRank < 0.0 {
 		rawRank = 0.0
@@ -31,63 +71,34 @@ func TextWeight(rawRank float64, w float64) float64 {
 	return rawRank * w
 }
 
-// ProximityWeight computes a distance-based proximity score normalized to [0, 1].
-// Uses a hyperbolic decay function to convert distance to a proximity score.
+// ProximityWeight computes a distance-based proximity score normalized to [0, 1]
+// using DefaultProximityConfig.
 //
 // Parameters:
 //   - distanceMeters: The distance in meters from the reference point
 //
 // Returns a value between 0.0 (far) and 1.0 (very close).
 // Formula: 1 / (1 + (distance / 1000)) - gives 1.0 at 0m, 0.5 at ~1km, 0.33 at ~2km, decays gradually
+// A NaN or infinite distance yields 0.
 func ProximityWeight(distanceMeters float64) float64 {
-	if distanceMeters < 0 {
-		distanceMeters = 0 // Clamp negative distances
-	}
-
-	// Normalize distance to kilometers and apply hyperbolic decay function
-	// This gives: 1.0 at 0m, 0.5 at 1000m, 0.33 at 2000m, etc.
-	distanceKm := distanceMeters / 1000.0
-	score := 1.0 / (1.0 + distanceKm)
-
-	return score
+	return ProximityWeightWithConfig(distanceMeters, DefaultProximityConfig())
 }
 
 // RecencyWeight computes a time-based recency score normalized to [0, 1].
-// Events happening sooner receive higher scores.
+// Events closer to now, before or after, receive higher scores.
 //
 // Parameters:
 //   - startTime: The start time of the event
 //   - windowSpan: The total time window duration being searched
 //
-// Returns a value between 0.0 (furthest in future) and 1.0 (happening now/past).
-// Formula: 1 - ((event_start - now) / window_span) clamped to [0, 1]
+// Returns RecencyWeightWithHalfLife(startTime, time.Now(), windowSpan/2): 1.0 for
+// an event starting now, 0.5 at half the window span away and 0.25 at the full span.
+// If windowSpan is not positive, all events are considered equally recent (1.0).
 func RecencyWeight(startTime time.Time, windowSpan time.Duration) float64 {
-	now := time.Now()
-
 	if windowSpan <= 0 {
 		return 1.0 // If no window span, consider all events equally recent
 	}
-
-	// Calculate time difference from now to event start
-	timeDiff := startTime.Sub(now)
-
-	// If event is in the past or happening now, it's maximally recent
-	if timeDiff <= 0 {
-		return 1.0
-	}
-
-	// Calculate weight: 1 - (timeDiff / windowSpan)
-	weight := 1.0 - (float64(timeDiff) / float64(windowSpan))
-
-	// Clamp to [0, 1] range
-	if weight < 0.0 {
-		return 0.0
-	}
-	if weight > 1.0 {
-		return 1.0
-	}
-
-	return weight
+	return RecencyWeightWithHalfLife(startTime, time.Now(), windowSpan/2)
 }
 
 // TrustWeight computes the trust component score with feature flag support.
@@ -98,11 +109,14 @@ func RecencyWeight(startTime time.Time, windowSpan time.Duration) float64 {
 //   - enabled: Whether trust-based ranking is enabled
 //
 // Returns trustScore (clamped to [0, 1]) if enabled is true, otherwise 0.
+// A NaN or infinite trustScore is treated as 0.
 func TrustWeight(trustScore float64, enabled bool) float64 {
 	if !enabled {
 		return 0.0
 	}
 
+	trustScore = sanitize(ComponentTrust, trustScore)
+
 	// Clamp trustScore to [0, 1] to ensure contract compliance
 	if trustScore < 0.0 {
 		return 0.0
@@ -114,21 +128,65 @@ func TrustWeight(trustScore float64, enabled bool) float64 {
 	return trustScore
 }
 
+// engagementHalfSaturation is the activity rate, in weighted actions per day,
+// that scores 0.5 in EngagementWeight.
+const engagementHalfSaturation = 5.0
+
+// engagementRSVPWeight is how many posts an RSVP counts as, since committing
+// to attend is a stronger signal than posting.
+const engagementRSVPWeight = 2.0
+
+// EngagementWeight computes an activity-based engagement score normalized to [0, 1].
+// Recent posts and RSVPs are combined into a daily activity rate over the window,
+// which saturates so that a busy scene cannot dominate on engagement alone.
+//
+// Parameters:
+//   - recentPosts: Posts made within the window
+//   - recentRSVPs: RSVPs made within the window (each counts as two posts)
+//   - window: The period the counts cover
+//
+// Returns a value between 0.0 (no activity) and 1.0 (approached as activity grows).
+// Formula: rate / (rate + 5) where rate = (posts + 2 * rsvps) / window_days,
+// giving 0.5 at 5 actions/day and 0.8 at 20 actions/day.
+// Negative counts are treated as 0, and a non-positive window yields 0.
+func EngagementWeight(recentPosts, recentRSVPs int, window time.Duration) float64 {
+	if window <= 0 {
+		return 0.0
+	}
+	if recentPosts < 0 {
+		recentPosts = 0
+	}
+	if recentRSVPs < 0 {
+		recentRSVPs = 0
+	}
+
+	activity := float64(recentPosts) + engagementRSVPWeight*float64(recentRSVPs)
+	rate := activity / (window.Hours() / 24.0)
+
+	return clampUnit(rate / (rate + engagementHalfSaturation))
+}
+
 // SceneParams holds the parameters for computing a scene composite score.
 type SceneParams struct {
-	Text         float64 // Text match score [0, 1]
-	Proximity    float64 // Proximity score [0, 1]
-	Trust        float64 // Trust score [0, 1]
-	TrustEnabled bool    // Whether trust ranking is enabled
+	Text         float64  // Text match score [0, 1]
+	Proximity    float64  // Proximity score [0, 1]
+	Trust        float64  // Trust score [0, 1]
+	TrustEnabled bool     // Whether trust ranking is enabled
+	Engagement   float64  // Engagement score [0, 1], see EngagementWeight
+	Tags         []string // Scene tags, matched against the calibrated tag boosts
+	Freshness    float64  // Share of the freshness floor [0, 1], see FreshnessWeight; 0 for established scenes
 }
 
 // EventParams holds the parameters for computing an event composite score.
 type EventParams struct {
-	Text         float64 // Text match score [0, 1]
-	Proximity    float64 // Proximity score [0, 1]
-	Recency      float64 // Recency score [0, 1]
-	Trust        float64 // Trust score [0, 1]
-	TrustEnabled bool    // Whether trust ranking is enabled
+	Text         float64  // Text match score [0, 1]
+	Proximity    float64  // Proximity score [0, 1]
+	Recency      float64  // Recency score [0, 1]
+	Trust        float64  // Trust score [0, 1]
+	TrustEnabled bool     // Whether trust ranking is enabled
+	Engagement   float64  // Engagement score [0, 1], see EngagementWeight
+	Tags         []string // Event tags, matched against the calibrated tag boosts
+	Freshness    float64  // Share of the freshness floor [0, 1], see FreshnessWeight; 0 for established events
 }
 
 // CompositeScoreScene computes the final composite ranking score for a scene.
@@ -136,25 +194,22 @@ type EventParams struct {
 //
 // Default formula (without trust): composite_score = (text * 0.4) + (proximity * 0.3) + (trust_weight * 0.1)
 // When trust is disabled, the trust component is 0, making max score 0.7 instead of 0.8.
+// Engagement adds params.Engagement * weights.Scene.Engagement, which is 0 by default.
+// When params.Tags match weights.TagBoosts, the sum is then multiplied as in
+// ApplyTagBoostWithCeiling, so a boosted score can exceed the ranges below.
+// Finally, a score below params.Freshness * weights.FreshnessFloor is raised
+// to it, so brand-new scenes get a temporary minimum score.
 //
 // Parameters:
 //   - params: The component scores and feature flags
 //   - weights: The calibrated weight configuration (optional, uses default if nil)
 //
+// Components are clamped to [0, 1] and NaN/Inf components or weights are treated as 0,
+// so the result is always finite.
+//
 // Returns the composite score (typically in [0, 0.7-0.8] range depending on trust flag).
 func CompositeScoreScene(params SceneParams, weights *Weights) float64 {
-	if weights == nil {
-		weights = GetActiveWeights()
-	}
-
-	score := (params.Text * weights.Scene.TextMatch) +
-		(params.Proximity * weights.Scene.Proximity)
-
-	if params.TrustEnabled {
-		score += params.Trust * weights.Scene.Trust
-	}
-
-	return score
+	return compositeScoreScene(params, weights, nil)
 }
 
 // CompositeScoreEvent computes the final composite ranking score for an event.
@@ -162,24 +217,17 @@ func CompositeScoreScene(params SceneParams, weights *Weights) float64 {
 //
 // Default formula: composite_score = (recency * 0.3) + (text * 0.4) + (proximity * 0.2) + (trust_weight * 0.1)
 // When trust is disabled, the trust component is 0, making max score 0.9 instead of 1.0.
+// Engagement adds params.Engagement * weights.Event.Engagement, which is 0 by default.
+// Tag boosts and the freshness floor apply as in CompositeScoreScene.
 //
 // Parameters:
 //   - params: The component scores and feature flags
 //   - weights: The calibrated weight configuration (optional, uses default if nil)
 //
+// Components are clamped to [0, 1] and NaN/Inf components or weights are treated as 0,
+// so the result is always finite.
+//
 // Returns the composite score (typically in [0, 0.9-1.0] range depending on trust flag).
 func CompositeScoreEvent(params EventParams, weights *Weights) float64 {
-	if weights == nil {
-		weights = GetActiveWeights()
-	}
-
-	score := (params.Recency * weights.Event.Recency) +
-		(params.Text * weights.Event.TextMatch) +
-		(params.Proximity * weights.Event.Proximity)
-
-	if params.TrustEnabled {
-		score += params.Trust * weights.Event.Trust
-	}
-
-	return score
+	return compositeScoreEvent(params, weights, nil)
 }
@@ -141,106 +141,83 @@ func TestProximityWeight(t *testing.T) {
 // TestRecencyWeight tests the time-based recency scoring.
 func TestRecencyWeight(t *testing.T) {
 	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
-	windowSpan := 24 * time.Hour // 24 hour window
+	halfLife := 12 * time.Hour // Default for a 24 hour window
 
 	tests := []struct {
 		name        string
 		startTime   time.Time
-		windowSpan  time.Duration
+		halfLife    time.Duration
 		expectedMin float64
 		expectedMax float64
 	}{
 		{
-			name:        "event in the past",
+			name:        "event 1 hour in the past",
 			startTime:   now.Add(-1 * time.Hour),
-			windowSpan:  windowSpan,
-			expectedMin: 1.0,
-			expectedMax: 1.0,
+			halfLife:    halfLife,
+			expectedMin: 0.94,
+			expectedMax: 0.95,
 		},
 		{
 			name:        "event happening now",
 			startTime:   now,
-			windowSpan:  windowSpan,
+			halfLife:    halfLife,
 			expectedMin: 1.0,
 			expectedMax: 1.0,
 		},
 		{
-			name:        "event 6 hours in future (75% through window)",
+			name:        "event 6 hours in future (half a half-life)",
 			startTime:   now.Add(6 * time.Hour),
-			windowSpan:  windowSpan,
-			expectedMin: 0.74,
-			expectedMax: 0.76,
+			halfLife:    halfLife,
+			expectedMin: 0.70,
+			expectedMax: 0.71,
 		},
 		{
-			name:        "event 12 hours in future (50% through window)",
+			name:        "event 12 hours in future (one half-life)",
 			startTime:   now.Add(12 * time.Hour),
-			windowSpan:  windowSpan,
-			expectedMin: 0.49,
-			expectedMax: 0.51,
+			halfLife:    halfLife,
+			expectedMin: 0.5,
+			expectedMax: 0.5,
 		},
 		{
-			name:        "event 18 hours in future (25% through window)",
-			startTime:   now.Add(18 * time.Hour),
-			windowSpan:  windowSpan,
-			expectedMin: 0.24,
-			expectedMax: 0.26,
-		},
-		{
-			name:        "event 24 hours in future (end of window)",
+			name:        "event 24 hours in future (two half-lives)",
 			startTime:   now.Add(24 * time.Hour),
-			windowSpan:  windowSpan,
-			expectedMin: 0.0,
-			expectedMax: 0.01,
+			halfLife:    halfLife,
+			expectedMin: 0.25,
+			expectedMax: 0.25,
 		},
 		{
-			name:        "event beyond window",
+			name:        "event 30 hours in future",
 			startTime:   now.Add(30 * time.Hour),
-			windowSpan:  windowSpan,
+			halfLife:    halfLife,
+			expectedMin: 0.17,
+			expectedMax: 0.18,
+		},
+		{
+			name:        "event a year away",
+			startTime:   now.Add(365 * 24 * time.Hour),
+			halfLife:    halfLife,
 			expectedMin: 0.0,
-			expectedMax: 0.0,
+			expectedMax: 0.0001,
 		},
 		{
-			name:        "zero window span",
-			startTime:   now.Add(1 * time.Hour),
-			windowSpan:  0,
-			expectedMin: 1.0,
-			expectedMax: 1.0,
+			name:        "zero half-life uses default",
+			startTime:   now.Add(12 * time.Hour),
+			halfLife:    0,
+			expectedMin: 0.5,
+			expectedMax: 0.5,
 		},
 		{
-			name:        "negative window span (edge case)",
-			startTime:   now.Add(1 * time.Hour),
-			windowSpan:  -1 * time.Hour,
-			expectedMin: 1.0,
-			expectedMax: 1.0,
+			name:        "negative half-life uses default",
+			startTime:   now.Add(12 * time.Hour),
+			halfLife:    -1 * time.Hour,
+			expectedMin: 0.5,
+			expectedMax: 0.5,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Since RecencyWeight uses time.Now() internally, we'll just test it directly.
-			// The function will use the current time, so we adjust our test startTime
-			// relative to when the test runs. For precise testing, we simulate the calculation.
-
-			// Create a test helper that simulates RecencyWeight with a fixed "now"
-			testRecencyWeight := func(startTime time.Time, now time.Time, windowSpan time.Duration) float64 {
-				if windowSpan <= 0 {
-					return 1.0
-				}
-				timeDiff := startTime.Sub(now)
-				if timeDiff <= 0 {
-					return 1.0
-				}
-				weight := 1.0 - (float64(timeDiff) / float64(windowSpan))
-				if weight < 0.0 {
-					return 0.0
-				}
-				if weight > 1.0 {
-					return 1.0
-				}
-				return weight
-			}
-
-			result := testRecencyWeight(tt.startTime, now, tt.windowSpan)
+			result := RecencyWeightWithHalfLife(tt.startTime, now, tt.halfLife)
 
 			// Verify result is in expected range
 			if result < tt.expectedMin || result > tt.expectedMax {
@@ -272,8 +249,8 @@ func TestRecencyWeight_ActualFunction(t *testing.T) {
 			name:        "event in the past",
 			startTime:   time.Now().Add(-1 * time.Hour),
 			windowSpan:  windowSpan,
-			expectedMin: 1.0,
-			expectedMax: 1.0,
+			expectedMin: 0.94,
+			expectedMax: 0.95,
 		},
 		{
 			name:        "event happening very soon",
@@ -286,8 +263,8 @@ func TestRecencyWeight_ActualFunction(t *testing.T) {
 			name:        "event far in future",
 			startTime:   time.Now().Add(30 * time.Hour),
 			windowSpan:  windowSpan,
-			expectedMin: 0.0,
-			expectedMax: 0.0,
+			expectedMin: 0.17,
+			expectedMax: 0.18,
 		},
 		{
 			name:        "zero window span",
@@ -296,6 +273,13 @@ func TestRecencyWeight_ActualFunction(t *testing.T) {
 			expectedMin: 1.0,
 			expectedMax: 1.0,
 		},
+		{
+			name:        "negative window span (edge case)",
+			startTime:   time.Now().Add(1 * time.Hour),
+			windowSpan:  -1 * time.Hour,
+			expectedMin: 1.0,
+			expectedMax: 1.0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -378,6 +362,44 @@ func TestTrustWeight(t *testing.T) {
 	}
 }
 
+// TestEngagementWeight tests the activity-based engagement scoring.
+func TestEngagementWeight(t *testing.T) {
+	week := 7 * 24 * time.Hour
+	tests := []struct {
+		name     string
+		posts    int
+		rsvps    int
+		window   time.Duration
+		expected float64
+	}{
+		{name: "no activity", posts: 0, rsvps: 0, window: week, expected: 0},
+		{name: "half saturation from posts", posts: 35, rsvps: 0, window: week, expected: 0.5},
+		{name: "RSVPs count double", posts: 0, rsvps: 5, window: 24 * time.Hour, expected: 10.0 / 15.0},
+		{name: "same activity over a longer window scores lower", posts: 35, rsvps: 0, window: 2 * week, expected: 2.5 / 7.5},
+		{name: "mixed activity", posts: 10, rsvps: 5, window: 24 * time.Hour, expected: 0.8},
+		{name: "negative counts clamped", posts: -10, rsvps: -3, window: week, expected: 0},
+		{name: "zero window", posts: 10, rsvps: 10, window: 0, expected: 0},
+		{name: "negative window", posts: 10, rsvps: 10, window: -time.Hour, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EngagementWeight(tt.posts, tt.rsvps, tt.window)
+			if math.Abs(result-tt.expected) > 0.001 {
+				t.Errorf("expected %f, got %f", tt.expected, result)
+			}
+			if result < 0.0 || result > 1.0 {
+				t.Errorf("result %f is outside valid range [0.0, 1.0]", result)
+			}
+		})
+	}
+
+	// Heavy activity approaches but never exceeds 1
+	if result := EngagementWeight(1_000_000, 1_000_000, time.Hour); result > 1.0 || result < 0.99 {
+		t.Errorf("expected heavy activity to approach 1.0, got %f", result)
+	}
+}
+
 // TestCompositeScoreScene tests the scene composite scoring.
 func TestCompositeScoreScene(t *testing.T) {
 	tests := []struct {
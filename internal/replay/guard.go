@@ -0,0 +1,91 @@
+// Package replay rejects stale and replayed webhook deliveries. Signature
+// checks prove a delivery came from the provider, but not that it is being
+// delivered for the first time; a captured request stays validly signed
+// forever. A Guard closes that gap for the Stripe and LiveKit receivers.
+package replay
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Replay check errors.
+var (
+	ErrStale    = errors.New("webhook timestamp outside replay window")
+	ErrReplayed = errors.New("webhook delivery already seen")
+)
+
+// DefaultWindow is how far a webhook's signed timestamp may drift from the
+// server clock by default. Matches Stripe's own signature tolerance.
+const DefaultWindow = 5 * time.Minute
+
+// sweepThreshold is the cache size at which expired IDs are pruned.
+const sweepThreshold = 10000
+
+// Guard accepts a signed webhook delivery once, and only while its signed
+// timestamp is within the window of the current time. Seen IDs are only
+// remembered until their timestamp leaves the window, since the timestamp
+// check rejects them from then on. Thread-safe.
+type Guard struct {
+	window  time.Duration
+	mu      sync.Mutex
+	seen    map[string]time.Time // ID -> when it can be forgotten
+	timeNow func() time.Time     // For testability
+}
+
+// NewGuard creates a Guard with the given window. A non-positive window
+// uses DefaultWindow.
+func NewGuard(window time.Duration) *Guard {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Guard{
+		window:  window,
+		seen:    make(map[string]time.Time),
+		timeNow: time.Now,
+	}
+}
+
+// Window returns the timestamp tolerance.
+func (g *Guard) Window() time.Duration {
+	return g.window
+}
+
+// Check records a delivery identified by id and signed at signedAt.
+// Returns ErrStale if signedAt is more than the window before or after now,
+// and ErrReplayed if id was already checked within the window.
+//
+// Callers must verify the signature first, so unsigned requests cannot fill
+// the cache, and should namespace id by receiver (e.g. "stripe:evt_123").
+func (g *Guard) Check(id string, signedAt time.Time) error {
+	now := g.timeNow()
+	if signedAt.Before(now.Add(-g.window)) || signedAt.After(now.Add(g.window)) {
+		return ErrStale
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if forgetAt, ok := g.seen[id]; ok && now.Before(forgetAt) {
+		return ErrReplayed
+	}
+	if len(g.seen) >= sweepThreshold {
+		for key, forgetAt := range g.seen {
+			if !now.Before(forgetAt) {
+				delete(g.seen, key)
+			}
+		}
+	}
+	g.seen[id] = signedAt.Add(g.window)
+	return nil
+}
+
+// Forget removes id, so a delivery that was checked but could not be
+// processed is accepted when the provider redelivers it.
+func (g *Guard) Forget(id string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.seen, id)
+}
@@ -0,0 +1,62 @@
+package replay
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGuard_Check(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		signedAt time.Time
+		wantErr  error
+	}{
+		{name: "fresh", signedAt: now.Add(-30 * time.Second)},
+		{name: "at window edge", signedAt: now.Add(-5 * time.Minute)},
+		{name: "too old", signedAt: now.Add(-6 * time.Minute), wantErr: ErrStale},
+		{name: "too far in the future", signedAt: now.Add(6 * time.Minute), wantErr: ErrStale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := NewGuard(5 * time.Minute)
+			guard.timeNow = func() time.Time { return now }
+
+			if err := guard.Check("stripe:evt_1", tt.signedAt); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGuard_Replay(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	guard := NewGuard(5 * time.Minute)
+	guard.timeNow = func() time.Time { return now }
+	signedAt := now.Add(-time.Minute)
+
+	if err := guard.Check("stripe:evt_1", signedAt); err != nil {
+		t.Fatalf("first delivery: %v", err)
+	}
+	if err := guard.Check("stripe:evt_1", signedAt); !errors.Is(err, ErrReplayed) {
+		t.Errorf("replay within window: error = %v, want ErrReplayed", err)
+	}
+	if err := guard.Check("livekit:evt_1", signedAt); err != nil {
+		t.Errorf("same ID from another receiver: %v", err)
+	}
+
+	// A forgotten delivery is accepted again
+	guard.Forget("stripe:evt_1")
+	if err := guard.Check("stripe:evt_1", signedAt); err != nil {
+		t.Errorf("delivery after Forget: %v", err)
+	}
+
+	// Once the timestamp leaves the window the replay is rejected as stale
+	now = now.Add(10 * time.Minute)
+	if err := guard.Check("stripe:evt_1", signedAt); !errors.Is(err, ErrStale) {
+		t.Errorf("replay after window: error = %v, want ErrStale", err)
+	}
+}
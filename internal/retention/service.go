@@ -9,6 +9,8 @@ import (
 	"log/slog"
 	"sync"
 	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
 )
 
 // RetentionTier defines how long different entity types are kept.
@@ -90,13 +92,14 @@ func DefaultConfig() ServiceConfig {
 
 // Service runs periodic retention enforcement.
 type Service struct {
-	repo     Repository
-	config   ServiceConfig
-	logger   *slog.Logger
-	stopChan chan struct{}
-	doneChan chan struct{}
-	mu       sync.Mutex
-	running  bool
+	repo      Repository
+	config    ServiceConfig
+	logger    *slog.Logger
+	auditRepo audit.Repository // Optional; account deletions are not audited without one
+	stopChan  chan struct{}
+	doneChan  chan struct{}
+	mu        sync.Mutex
+	running   bool
 }
 
 // NewService creates a new retention service.
@@ -125,6 +128,12 @@ func NewService(repo Repository, config ServiceConfig) *Service {
 	}
 }
 
+// SetAuditRepo installs the audit repository that records account deletions
+// executed by the service, attributed to the retention system actor.
+func (s *Service) SetAuditRepo(repo audit.Repository) {
+	s.auditRepo = repo
+}
+
 // Start begins the periodic retention enforcement loop.
 func (s *Service) Start(ctx context.Context) {
 	s.mu.Lock()
@@ -249,6 +258,14 @@ func (s *Service) processAccountDeletions(ctx context.Context) (int64, error) {
 		s.logger.Info("account permanently deleted",
 			slog.String("user_did", p.UserDID))
 		deleted++
+
+		if s.auditRepo != nil {
+			if err := audit.LogSystemAction(ctx, s.auditRepo, audit.SystemActorRetention, "user", p.UserDID, audit.ActionAccountDelete); err != nil {
+				s.logger.Error("failed to audit account deletion",
+					slog.String("user_did", p.UserDID),
+					slog.String("error", err.Error()))
+			}
+		}
 	}
 	return deleted, nil
 }
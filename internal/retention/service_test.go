@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"testing"
 	"time"
+
+	"github.com/onnwee/subcults/internal/audit"
 )
 
 func TestService_RunCycle_DeletesExpiredRecords(t *testing.T) {
@@ -68,6 +70,37 @@ func TestService_ProcessAccountDeletions_PastGrace(t *testing.T) {
 	}
 }
 
+func TestService_ProcessAccountDeletions_AuditedAsSystem(t *testing.T) {
+	repo := NewInMemoryRepository(slog.Default())
+	repo.AddPendingDeletion(PendingDeletion{
+		UserDID:     "did:plc:test123",
+		ScheduledAt: time.Now().Add(-31 * 24 * time.Hour),
+		GraceEndsAt: time.Now().Add(-1 * time.Hour),
+	})
+	auditRepo := audit.NewInMemoryRepository()
+
+	svc := NewService(repo, ServiceConfig{
+		Logger: slog.Default(),
+	})
+	svc.SetAuditRepo(auditRepo)
+
+	svc.runCycle(context.Background())
+
+	logs, err := auditRepo.QueryByEntity("user", "did:plc:test123", 0)
+	if err != nil {
+		t.Fatalf("QueryByEntity() error = %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(logs))
+	}
+	if logs[0].ActorType != audit.ActorSystem || logs[0].ActorID != audit.SystemActorRetention {
+		t.Errorf("actor = %q/%q, want %q/%q", logs[0].ActorType, logs[0].ActorID, audit.ActorSystem, audit.SystemActorRetention)
+	}
+	if logs[0].UserDID != "" || logs[0].Action != audit.ActionAccountDelete {
+		t.Errorf("unexpected audit entry: %+v", logs[0])
+	}
+}
+
 func TestService_ProcessAccountDeletions_WithinGrace(t *testing.T) {
 	repo := NewInMemoryRepository(slog.Default())
 	repo.AddPendingDeletion(PendingDeletion{
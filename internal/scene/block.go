@@ -0,0 +1,120 @@
+package scene
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrBlockNotFound is returned when a DID is not blocked in a scene.
+var ErrBlockNotFound = errors.New("scene block not found")
+
+// Block records that a DID may not post, join streams, or RSVP in a scene.
+type Block struct {
+	SceneID    string    `json:"scene_id"`
+	BlockedDID string    `json:"blocked_did"`
+	BlockedBy  string    `json:"blocked_by"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BlockRepository defines the interface for per-scene block list operations.
+type BlockRepository interface {
+	// Add blocks block.BlockedDID in block.SceneID and sets CreatedAt.
+	// Returns false, leaving the existing block unchanged, if the DID is already blocked.
+	Add(block *Block) (bool, error)
+
+	// Remove unblocks a DID in a scene.
+	// Returns ErrBlockNotFound if the DID is not blocked.
+	Remove(sceneID, did string) error
+
+	// IsBlocked reports whether a DID is blocked in a scene.
+	IsBlocked(sceneID, did string) (bool, error)
+
+	// ListByScene returns a scene's blocks, oldest first.
+	ListByScene(sceneID string) ([]*Block, error)
+}
+
+// InMemoryBlockRepository is an in-memory implementation of BlockRepository.
+// Used for testing and development. Thread-safe via RWMutex.
+type InMemoryBlockRepository struct {
+	mu     sync.RWMutex
+	blocks map[string]*Block // key: sceneID + "\x00" + DID
+}
+
+// NewInMemoryBlockRepository creates a new in-memory block repository.
+func NewInMemoryBlockRepository() *InMemoryBlockRepository {
+	return &InMemoryBlockRepository{
+		blocks: make(map[string]*Block),
+	}
+}
+
+// makeBlockKey creates a composite key from scene ID and DID. DIDs contain
+// colons, so a null byte separates the parts.
+func makeBlockKey(sceneID, did string) string {
+	return sceneID + "\x00" + did
+}
+
+// Add blocks block.BlockedDID in block.SceneID and sets CreatedAt.
+// Returns false, leaving the existing block unchanged, if the DID is already blocked.
+func (r *InMemoryBlockRepository) Add(block *Block) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := makeBlockKey(block.SceneID, block.BlockedDID)
+	if existing, exists := r.blocks[key]; exists {
+		*block = *existing
+		return false, nil
+	}
+
+	block.CreatedAt = time.Now()
+	blockCopy := *block
+	r.blocks[key] = &blockCopy
+	return true, nil
+}
+
+// Remove unblocks a DID in a scene.
+// Returns ErrBlockNotFound if the DID is not blocked.
+func (r *InMemoryBlockRepository) Remove(sceneID, did string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := makeBlockKey(sceneID, did)
+	if _, exists := r.blocks[key]; !exists {
+		return ErrBlockNotFound
+	}
+	delete(r.blocks, key)
+	return nil
+}
+
+// IsBlocked reports whether a DID is blocked in a scene.
+func (r *InMemoryBlockRepository) IsBlocked(sceneID, did string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.blocks[makeBlockKey(sceneID, did)]
+	return exists, nil
+}
+
+// ListByScene returns a scene's blocks, oldest first, with ties broken by DID.
+func (r *InMemoryBlockRepository) ListByScene(sceneID string) ([]*Block, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Block, 0)
+	for _, block := range r.blocks {
+		if block.SceneID == sceneID {
+			blockCopy := *block
+			result = append(result, &blockCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].BlockedDID < result[j].BlockedDID
+	})
+	return result, nil
+}
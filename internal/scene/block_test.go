@@ -0,0 +1,84 @@
+package scene
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBlockRepository_AddAndRemove(t *testing.T) {
+	repo := NewInMemoryBlockRepository()
+
+	block := &Block{SceneID: "scene-1", BlockedDID: "did:plc:troll", BlockedBy: "did:plc:owner", Reason: "harassment"}
+	added, err := repo.Add(block)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if !added {
+		t.Error("Expected first Add to report a new block")
+	}
+	if block.CreatedAt.IsZero() {
+		t.Error("Expected CreatedAt to be set")
+	}
+
+	// Blocks are per scene
+	if blocked, _ := repo.IsBlocked("scene-1", "did:plc:troll"); !blocked {
+		t.Error("Expected DID to be blocked in scene-1")
+	}
+	if blocked, _ := repo.IsBlocked("scene-2", "did:plc:troll"); blocked {
+		t.Error("Expected DID not to be blocked in scene-2")
+	}
+
+	// Re-adding keeps the original block
+	again := &Block{SceneID: "scene-1", BlockedDID: "did:plc:troll", BlockedBy: "did:plc:other", Reason: "spam"}
+	added, err = repo.Add(again)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if added {
+		t.Error("Expected repeat Add to report an existing block")
+	}
+	if again.Reason != "harassment" || !again.CreatedAt.Equal(block.CreatedAt) {
+		t.Errorf("Expected existing block to be returned, got %+v", again)
+	}
+
+	if err := repo.Remove("scene-1", "did:plc:troll"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if blocked, _ := repo.IsBlocked("scene-1", "did:plc:troll"); blocked {
+		t.Error("Expected DID to be unblocked after Remove")
+	}
+	if err := repo.Remove("scene-1", "did:plc:troll"); !errors.Is(err, ErrBlockNotFound) {
+		t.Errorf("Expected ErrBlockNotFound, got %v", err)
+	}
+}
+
+func TestBlockRepository_ListByScene(t *testing.T) {
+	repo := NewInMemoryBlockRepository()
+
+	for _, did := range []string{"did:plc:a", "did:plc:b"} {
+		if _, err := repo.Add(&Block{SceneID: "scene-1", BlockedDID: did, BlockedBy: "did:plc:owner"}); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if _, err := repo.Add(&Block{SceneID: "scene-2", BlockedDID: "did:plc:c", BlockedBy: "did:plc:owner"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	blocks, err := repo.ListByScene("scene-1")
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if len(blocks) != 2 || blocks[0].BlockedDID != "did:plc:a" || blocks[1].BlockedDID != "did:plc:b" {
+		t.Fatalf("Expected blocks for did:plc:a and did:plc:b in order, got %+v", blocks)
+	}
+
+	// Returned blocks are copies
+	blocks[0].Reason = "changed"
+	if again, _ := repo.ListByScene("scene-1"); again[0].Reason != "" {
+		t.Error("Expected ListByScene to return copies")
+	}
+
+	if empty, _ := repo.ListByScene("scene-3"); len(empty) != 0 {
+		t.Errorf("Expected no blocks for scene-3, got %d", len(empty))
+	}
+}
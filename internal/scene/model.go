@@ -11,6 +11,26 @@ const (
 	VisibilityHidden      = "unlisted" // Visible only to owner, exempt from search (DB uses "unlisted")
 )
 
+// Content ratings for scenes
+const (
+	ContentRatingGeneral = "general" // Suitable for all viewers (default)
+	ContentRatingMature  = "mature"  // Adult content; viewers must opt in before seeing it
+)
+
+// Feed sort orders for scenes
+const (
+	FeedSortChronological = "chronological" // Newest posts first (default)
+	FeedSortRanked        = "ranked"        // Recency blended with engagement
+)
+
+// Event statuses beyond the scheduled/live/ended/cancelled lifecycle. Events
+// proposed by members of a scene that requires approval start pending and are
+// excluded from discovery until the owner approves them.
+const (
+	EventStatusPendingApproval = "pending_approval" // Proposed by a member, awaiting the owner's review
+	EventStatusRejected        = "rejected"         // Proposal declined by the owner; never published
+)
+
 // Point represents a geographic coordinate with latitude and longitude.
 type Point struct {
 	Lat float64 `json:"lat"`
@@ -46,6 +66,20 @@ type Scene struct {
 	Visibility  string   `json:"visibility,omitempty"`
 	Palette     *Palette `json:"palette,omitempty"`       // Color scheme
 	OwnerUserID *string  `json:"owner_user_id,omitempty"` // FK to users table
+	// ContentRating is "general" or "mature". Enforced by database CHECK constraint.
+	ContentRating string `json:"content_rating,omitempty"`
+	// FeedSort is the default feed order: "chronological" or "ranked".
+	// Enforced by database CHECK constraint.
+	FeedSort string `json:"feed_sort,omitempty"`
+	// PinnedEventID is the owner's flagship event, listed first among the scene's events
+	// while it is neither cancelled nor deleted.
+	PinnedEventID *string `json:"pinned_event_id,omitempty"`
+	// EventApprovalRequired lets active members propose events, which stay
+	// pending until the owner approves them. Owners' own events skip approval.
+	EventApprovalRequired bool `json:"event_approval_required"`
+	// WelcomePostTemplate, when set, is rendered with RenderWelcome and sent
+	// privately to each member the first time their membership becomes active.
+	WelcomePostTemplate string `json:"welcome_post_template,omitempty"`
 
 	// Payments
 	ConnectedAccountID     *string `json:"connected_account_id,omitempty"`      // Stripe Connect Express account ID
@@ -79,7 +113,7 @@ type Event struct {
 	PrecisePoint  *Point     `json:"precise_point,omitempty"`
 	CoarseGeohash string     `json:"coarse_geohash"` // Required for location-based discovery
 	Tags          []string   `json:"tags,omitempty"`
-	Status        string     `json:"status,omitempty"` // scheduled, live, ended, cancelled
+	Status        string     `json:"status,omitempty"` // scheduled, live, ended, cancelled, pending_approval, rejected
 	StartsAt      time.Time  `json:"starts_at"`
 	EndsAt        *time.Time `json:"ends_at,omitempty"`
 
@@ -92,6 +126,20 @@ type Event struct {
 	// Cancellation details
 	CancellationReason *string `json:"cancellation_reason,omitempty"`
 
+	// Approval workflow: set when a member proposed the event rather than the owner
+	ProposedByDID   *string `json:"proposed_by_did,omitempty"`
+	RejectionReason *string `json:"rejection_reason,omitempty"`
+
+	// Recurring series membership; all occurrences of a series share a SeriesID and scene
+	SeriesID *string `json:"series_id,omitempty"`
+
+	// Maximum number of going RSVPs; further RSVPs are waitlisted. Nil means unlimited
+	Capacity *int `json:"capacity,omitempty"`
+
+	// Recurrence rule (RFC 5545 subset, see ParseRRule); occurrences are
+	// expanded on read with ExpandRecurrence rather than stored
+	RRule *string `json:"rrule,omitempty"`
+
 	// AT Protocol record tracking
 	RecordDID  *string `json:"record_did,omitempty"`
 	RecordRKey *string `json:"record_rkey,omitempty"`
@@ -125,19 +173,51 @@ func (s *Scene) IsOwner(userDID string) bool {
 	return s.OwnerDID == userDID
 }
 
+// IsMature reports whether the scene hosts adult content that viewers must opt in to see.
+func (s *Scene) IsMature() bool {
+	return s.ContentRating == ContentRatingMature
+}
+
+// RanksFeed reports whether the scene's feed defaults to ranked rather than chronological order.
+func (s *Scene) RanksFeed() bool {
+	return s.FeedSort == FeedSortRanked
+}
+
+// IsCancelled reports whether the event has been cancelled.
+func (e *Event) IsCancelled() bool {
+	return e.Status == "cancelled" || e.CancelledAt != nil
+}
+
+// IsPublished reports whether the event is visible in discovery, i.e. it is not
+// awaiting approval and was not rejected.
+func (e *Event) IsPublished() bool {
+	return e.Status != EventStatusPendingApproval && e.Status != EventStatusRejected
+}
+
+// RSVP statuses. Users choose going, maybe or not going; a going RSVP to an
+// event at capacity is recorded as waitlisted until a place frees up.
+const (
+	RSVPStatusGoing      = "going"
+	RSVPStatusMaybe      = "maybe"
+	RSVPStatusNotGoing   = "not_going"
+	RSVPStatusWaitlisted = "waitlisted"
+)
+
 // RSVP represents a user's attendance intent for an event.
 type RSVP struct {
 	EventID string `json:"event_id"`
 	// UserID stores the user's DID (Decentralized Identifier), not a UUID or FK to a users table.
 	// This allows guest RSVPs and aligns with the database schema (see migration 000012 comment).
-	UserID    string     `json:"user_id"`
-	Status    string     `json:"status"` // "going" or "maybe"
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UserID       string     `json:"user_id"`
+	Status       string     `json:"status"` // "going", "maybe", "not_going" or "waitlisted"
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	WaitlistedAt *time.Time `json:"waitlisted_at,omitempty"` // When the RSVP joined the waitlist; orders promotion
 }
 
 // RSVPCounts represents aggregated RSVP counts by status.
 type RSVPCounts struct {
-	Going int `json:"going"`
-	Maybe int `json:"maybe"`
+	Going      int `json:"going"`
+	Maybe      int `json:"maybe"`
+	Waitlisted int `json:"waitlisted,omitempty"`
 }
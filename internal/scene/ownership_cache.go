@@ -0,0 +1,176 @@
+package scene
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// OwnershipChecker answers scene ownership questions without loading the scene.
+// Handlers use it when their SceneRepository provides it.
+type OwnershipChecker interface {
+	// IsOwner reports whether userDID owns the scene.
+	// Returns ErrSceneNotFound or ErrSceneDeleted if the scene is unavailable.
+	IsOwner(sceneID, userDID string) (bool, error)
+}
+
+// CheckOwnership reports whether userDID owns the scene, using repo's
+// OwnershipChecker when it has one (e.g. an OwnershipCache) and loading the
+// scene otherwise.
+func CheckOwnership(repo SceneRepository, sceneID, userDID string) (bool, error) {
+	if checker, ok := repo.(OwnershipChecker); ok {
+		return checker.IsOwner(sceneID, userDID)
+	}
+	scene, err := repo.GetByID(sceneID)
+	if err != nil {
+		return false, err
+	}
+	return scene.IsOwner(userDID), nil
+}
+
+// batchGetter is the optional batch lookup a SceneRepository may provide.
+type batchGetter interface {
+	GetByIDs(ids []string) ([]*Scene, error)
+}
+
+// ownershipSweepThreshold is the cache size at which expired entries are pruned.
+const ownershipSweepThreshold = 10000
+
+// ownershipKey identifies a cached ownership answer.
+type ownershipKey struct {
+	sceneID string
+	userDID string
+}
+
+// ownershipEntry is a cached ownership answer and when it stops being trusted.
+type ownershipEntry struct {
+	isOwner   bool
+	expiresAt time.Time
+}
+
+// OwnershipCache wraps a SceneRepository with a short-TTL cache of
+// (sceneID, userDID) ownership answers. Writes through the wrapper (Update,
+// Upsert, Delete) invalidate the affected scene, so an ownership transfer is
+// seen immediately by this instance and within the TTL by any other.
+// Lookup errors are never cached. Thread-safe.
+type OwnershipCache struct {
+	SceneRepository
+
+	ttl         time.Duration
+	mu          sync.Mutex
+	entries     map[ownershipKey]ownershipEntry
+	generations map[string]uint64 // Bumped on invalidation so in-flight lookups don't store stale answers
+	timeNow     func() time.Time  // For testability
+}
+
+// NewOwnershipCache wraps repo with an ownership cache. A non-positive ttl
+// disables caching, leaving every IsOwner call to hit the repository.
+func NewOwnershipCache(repo SceneRepository, ttl time.Duration) *OwnershipCache {
+	return &OwnershipCache{
+		SceneRepository: repo,
+		ttl:             ttl,
+		entries:         make(map[ownershipKey]ownershipEntry),
+		generations:     make(map[string]uint64),
+		timeNow:         time.Now,
+	}
+}
+
+// IsOwner reports whether userDID owns the scene, serving unexpired answers from the cache.
+func (c *OwnershipCache) IsOwner(sceneID, userDID string) (bool, error) {
+	key := ownershipKey{sceneID: sceneID, userDID: userDID}
+	now := c.timeNow()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generations[sceneID]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.isOwner, nil
+	}
+
+	scene, err := c.SceneRepository.GetByID(sceneID)
+	if err != nil {
+		return false, err
+	}
+	isOwner := scene.IsOwner(userDID)
+
+	if c.ttl > 0 {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.generations[sceneID] != generation {
+			// The scene changed while we were loading it
+			return isOwner, nil
+		}
+		if len(c.entries) >= ownershipSweepThreshold {
+			for k, e := range c.entries {
+				if !now.Before(e.expiresAt) {
+					delete(c.entries, k)
+				}
+			}
+		}
+		c.entries[key] = ownershipEntry{isOwner: isOwner, expiresAt: now.Add(c.ttl)}
+	}
+	return isOwner, nil
+}
+
+// Invalidate drops every cached answer for the scene.
+func (c *OwnershipCache) Invalidate(sceneID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[sceneID]++
+	for key := range c.entries {
+		if key.sceneID == sceneID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Update updates the scene and invalidates its cached ownership.
+func (c *OwnershipCache) Update(scene *Scene) error {
+	err := c.SceneRepository.Update(scene)
+	c.Invalidate(scene.ID)
+	return err
+}
+
+// Upsert upserts the scene and invalidates its cached ownership.
+func (c *OwnershipCache) Upsert(scene *Scene) (*UpsertResult, error) {
+	result, err := c.SceneRepository.Upsert(scene)
+	if result != nil {
+		c.Invalidate(result.ID)
+	}
+	if scene.ID != "" {
+		c.Invalidate(scene.ID)
+	}
+	return result, err
+}
+
+// Delete soft-deletes the scene and invalidates its cached ownership.
+func (c *OwnershipCache) Delete(id string) error {
+	err := c.SceneRepository.Delete(id)
+	c.Invalidate(id)
+	return err
+}
+
+// GetByIDs retrieves scenes by their IDs, using the wrapped repository's batch
+// lookup when it has one and loading each scene otherwise, so callers that
+// detect batch support still find it behind the cache.
+// Returns only existing, non-deleted scenes.
+func (c *OwnershipCache) GetByIDs(ids []string) ([]*Scene, error) {
+	if repo, ok := c.SceneRepository.(batchGetter); ok {
+		return repo.GetByIDs(ids)
+	}
+
+	result := make([]*Scene, 0, len(ids))
+	for _, id := range ids {
+		scene, err := c.SceneRepository.GetByID(id)
+		if err != nil {
+			if errors.Is(err, ErrSceneNotFound) || errors.Is(err, ErrSceneDeleted) {
+				continue
+			}
+			return nil, err
+		}
+		result = append(result, scene)
+	}
+	return result, nil
+}
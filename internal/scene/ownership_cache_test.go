@@ -0,0 +1,158 @@
+package scene
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingSceneRepository counts GetByID calls to observe cache hits.
+type countingSceneRepository struct {
+	SceneRepository
+	getByIDCalls int
+}
+
+func (r *countingSceneRepository) GetByID(id string) (*Scene, error) {
+	r.getByIDCalls++
+	return r.SceneRepository.GetByID(id)
+}
+
+func newOwnershipCacheTest(t *testing.T, ttl time.Duration) (*OwnershipCache, *countingSceneRepository) {
+	t.Helper()
+	repo := &countingSceneRepository{SceneRepository: NewInMemorySceneRepository()}
+	if err := repo.Insert(&Scene{ID: "scene-1", Name: "Cached", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	return NewOwnershipCache(repo, ttl), repo
+}
+
+func TestOwnershipCache_HitAvoidsRepoCall(t *testing.T) {
+	cache, repo := newOwnershipCacheTest(t, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		isOwner, err := cache.IsOwner("scene-1", "did:plc:owner")
+		if err != nil {
+			t.Fatalf("IsOwner failed: %v", err)
+		}
+		if !isOwner {
+			t.Fatal("expected owner")
+		}
+	}
+	if repo.getByIDCalls != 1 {
+		t.Errorf("expected 1 repo call, got %d", repo.getByIDCalls)
+	}
+
+	// Entries expire after the TTL
+	now := time.Now().Add(2 * time.Minute)
+	cache.timeNow = func() time.Time { return now }
+	if _, err := cache.IsOwner("scene-1", "did:plc:owner"); err != nil {
+		t.Fatalf("IsOwner failed: %v", err)
+	}
+	if repo.getByIDCalls != 2 {
+		t.Errorf("expected expired entry to be reloaded, got %d calls", repo.getByIDCalls)
+	}
+}
+
+func TestOwnershipCache_NonOwnerNegative(t *testing.T) {
+	cache, repo := newOwnershipCacheTest(t, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		isOwner, err := cache.IsOwner("scene-1", "did:plc:intruder")
+		if err != nil {
+			t.Fatalf("IsOwner failed: %v", err)
+		}
+		if isOwner {
+			t.Fatal("expected non-owner to be rejected")
+		}
+	}
+	if repo.getByIDCalls != 1 {
+		t.Errorf("expected cached negative, got %d repo calls", repo.getByIDCalls)
+	}
+
+	// A cached negative for one user does not leak into another user's answer
+	isOwner, err := cache.IsOwner("scene-1", "did:plc:owner")
+	if err != nil || !isOwner {
+		t.Errorf("expected owner to be accepted, got %v, %v", isOwner, err)
+	}
+}
+
+func TestOwnershipCache_InvalidatedOnOwnershipTransfer(t *testing.T) {
+	cache, _ := newOwnershipCacheTest(t, time.Hour)
+
+	if isOwner, _ := cache.IsOwner("scene-1", "did:plc:owner"); !isOwner {
+		t.Fatal("expected original owner")
+	}
+	if isOwner, _ := cache.IsOwner("scene-1", "did:plc:new-owner"); isOwner {
+		t.Fatal("expected new owner to be rejected before transfer")
+	}
+
+	transferred, err := cache.GetByID("scene-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	transferred.OwnerDID = "did:plc:new-owner"
+	if err := cache.Update(transferred); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if isOwner, _ := cache.IsOwner("scene-1", "did:plc:owner"); isOwner {
+		t.Error("expected previous owner to lose ownership after transfer")
+	}
+	if isOwner, _ := cache.IsOwner("scene-1", "did:plc:new-owner"); !isOwner {
+		t.Error("expected new owner after transfer")
+	}
+}
+
+func TestOwnershipCache_ErrorsNotCached(t *testing.T) {
+	cache, repo := newOwnershipCacheTest(t, time.Minute)
+
+	if _, err := cache.IsOwner("missing", "did:plc:owner"); !errors.Is(err, ErrSceneNotFound) {
+		t.Fatalf("expected ErrSceneNotFound, got %v", err)
+	}
+	if _, err := cache.IsOwner("missing", "did:plc:owner"); !errors.Is(err, ErrSceneNotFound) {
+		t.Fatalf("expected ErrSceneNotFound, got %v", err)
+	}
+	if repo.getByIDCalls != 2 {
+		t.Errorf("expected lookup errors to bypass the cache, got %d calls", repo.getByIDCalls)
+	}
+
+	if err := cache.Delete("scene-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := cache.IsOwner("scene-1", "did:plc:owner"); err == nil {
+		t.Error("expected deleted scene to be unavailable")
+	}
+}
+
+func TestOwnershipCache_GetByIDs(t *testing.T) {
+	inner := NewInMemorySceneRepository()
+	for _, sc := range []*Scene{
+		{ID: "scene-1", Name: "First", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"},
+		{ID: "scene-2", Name: "Second", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"},
+		{ID: "scene-deleted", Name: "Deleted", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"},
+	} {
+		if err := inner.Insert(sc); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	if err := inner.Delete("scene-deleted"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// Both a batch-capable repository and one without batch lookups are served
+	for name, repo := range map[string]SceneRepository{
+		"batch":    inner,
+		"fallback": &countingSceneRepository{SceneRepository: inner},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cache := NewOwnershipCache(repo, time.Minute)
+			scenes, err := cache.GetByIDs([]string{"scene-1", "missing", "scene-deleted", "scene-2"})
+			if err != nil {
+				t.Fatalf("GetByIDs failed: %v", err)
+			}
+			if len(scenes) != 2 || scenes[0].ID != "scene-1" || scenes[1].ID != "scene-2" {
+				t.Errorf("expected scene-1 and scene-2, got %v", scenes)
+			}
+		})
+	}
+}
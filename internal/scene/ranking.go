@@ -108,27 +108,28 @@ func CalculateTextMatchScore(event *Event, query string) float64 {
 	return 0.0 // No match
 }
 
+// proximityDegreeMeters is the great-circle length of one degree of arc.
+// Proximity decays per degree of distance.
+const proximityDegreeMeters = geo.EarthRadiusMeters * math.Pi / 180
+
+// distanceProximityScore scores the great-circle distance between two points
+// with a simple decay: 1 / (1 + degrees of arc). This gives 1.0 at the point
+// itself and 0.5 one degree (~111km) away. Using geo.DistanceMeters keeps
+// points on either side of the antimeridian or near a pole close together.
+func distanceProximityScore(lat1, lng1, lat2, lng2 float64) float64 {
+	distance := geo.DistanceMeters(lat1, lng1, lat2, lng2) / proximityDegreeMeters
+	return 1.0 / (1.0 + distance)
+}
+
 // CalculateProximityScore computes a distance-based proximity score.
-// Returns a value between 0.0 (far) and 1.0 (close) based on distance from reference point.
+// Returns a value between 0.0 (far) and 1.0 (close) based on the great-circle
+// distance from the reference point.
 // For simplicity in in-memory implementation, we use the center of the bbox as reference.
-// In a real PostGIS implementation, this would use ST_Distance with proper geo calculations.
 func CalculateProximityScore(event *Event, centerLat, centerLng float64) float64 {
 	if event.PrecisePoint == nil {
 		return 0.5 // Default score if no location
 	}
-
-	// Calculate simple Euclidean distance (not great circle, but good enough for in-memory)
-	// For production with PostGIS, use ST_Distance with proper geodesic calculations
-	latDiff := event.PrecisePoint.Lat - centerLat
-	lngDiff := event.PrecisePoint.Lng - centerLng
-	distance := math.Sqrt(latDiff*latDiff + lngDiff*lngDiff)
-
-	// Normalize distance to [0, 1] range
-	// Use a simple decay function: 1 / (1 + distance)
-	// This gives 1.0 for distance=0, 0.5 for distance=1, etc.
-	score := 1.0 / (1.0 + distance)
-
-	return score
+	return distanceProximityScore(event.PrecisePoint.Lat, event.PrecisePoint.Lng, centerLat, centerLng)
 }
 
 // CalculateCompositeScore computes the final composite ranking score for an event.
@@ -225,7 +226,6 @@ func CalculateSceneTextMatchScore(scene *Scene, query string) float64 {
 // CalculateSceneProximityScore computes a distance-based proximity score for a scene.
 // Returns a value between 0.0 (far) and 1.0 (close) based on distance from reference point.
 // For simplicity in in-memory implementation, we use the center of the bbox as reference.
-// In a real PostGIS implementation, this would use ST_Distance with proper geo calculations.
 func CalculateSceneProximityScore(scene *Scene, centerLat, centerLng float64) float64 {
 	// Geohash prefix similarity scoring, used even when precise location is not available.
 	proximityScore := 0.5 // default
@@ -243,10 +243,7 @@ func CalculateSceneProximityScore(scene *Scene, centerLat, centerLng float64) fl
 
 	// If precise coordinates are available, blend geohash score with distance score.
 	if scene.PrecisePoint != nil {
-		latDiff := scene.PrecisePoint.Lat - centerLat
-		lngDiff := scene.PrecisePoint.Lng - centerLng
-		distance := math.Sqrt(latDiff*latDiff + lngDiff*lngDiff)
-		distanceScore := 1.0 / (1.0 + distance)
+		distanceScore := distanceProximityScore(scene.PrecisePoint.Lat, scene.PrecisePoint.Lng, centerLat, centerLng)
 		proximityScore = (proximityScore * 0.5) + (distanceScore * 0.5)
 	}
 
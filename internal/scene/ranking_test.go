@@ -207,6 +207,21 @@ func TestCalculateProximityScore(t *testing.T) {
 	}
 }
 
+// TestProximityScore_AcrossAntimeridian tests that points on either side of
+// the antimeridian score as close together.
+func TestProximityScore_AcrossAntimeridian(t *testing.T) {
+	centerLat, centerLng := -17.7, 179.95 // Fiji
+	point := &Point{Lat: -17.7, Lng: -179.95}
+
+	// 0.1 degrees of longitude apart, so both score well above 0.9
+	if score := CalculateProximityScore(&Event{PrecisePoint: point}, centerLat, centerLng); score < 0.9 {
+		t.Errorf("event score = %f, want >= 0.9", score)
+	}
+	if score := CalculateSceneProximityScore(&Scene{PrecisePoint: point}, centerLat, centerLng); score < 0.7 {
+		t.Errorf("scene score = %f, want >= 0.7", score)
+	}
+}
+
 // TestCalculateCompositeScore tests the composite ranking score calculation.
 func TestCalculateCompositeScore(t *testing.T) {
 	weights := DefaultEventRankingWeights
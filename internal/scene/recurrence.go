@@ -0,0 +1,263 @@
+package scene
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxExpandedOccurrences caps how many occurrences ExpandRecurrence will
+// materialize for a single window.
+const MaxExpandedOccurrences = 500
+
+// Recurrence frequencies supported in an event's RRULE.
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+var (
+	// ErrInvalidRRule is returned for an RRULE outside the supported subset of RFC 5545.
+	ErrInvalidRRule = errors.New("invalid rrule")
+	// ErrTooManyOccurrences is returned when a window would expand to more than MaxExpandedOccurrences.
+	ErrTooManyOccurrences = errors.New("recurrence expands to too many occurrences")
+)
+
+// rruleWeekdays maps RFC 5545 BYDAY codes to weekdays.
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RecurrenceRule is a parsed event RRULE. Count and Until are mutually
+// exclusive; a rule with neither recurs indefinitely.
+type RecurrenceRule struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    *time.Time
+	ByDay    []time.Weekday // Sorted Monday first, as weeks start on Monday
+}
+
+// ParseRRule parses the subset of RFC 5545 recurrence rules events support:
+// FREQ (DAILY, WEEKLY or MONTHLY, required), INTERVAL, COUNT, UNTIL and BYDAY
+// with plain weekday codes, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH". An
+// optional "RRULE:" prefix is accepted. Errors wrap ErrInvalidRRule.
+func ParseRRule(s string) (*RecurrenceRule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: rule is empty", ErrInvalidRRule)
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRRule, part)
+		}
+		key = strings.ToUpper(key)
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s given more than once", ErrInvalidRRule, key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			switch freq := strings.ToUpper(value); freq {
+			case FreqDaily, FreqWeekly, FreqMonthly:
+				rule.Freq = freq
+			default:
+				return nil, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRRule, value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRRule)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRRule)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseRRuleUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			days := make(map[time.Weekday]bool)
+			for _, code := range strings.Split(value, ",") {
+				day, ok := rruleWeekdays[strings.ToUpper(code)]
+				if !ok {
+					return nil, fmt.Errorf("%w: unsupported BYDAY value %q", ErrInvalidRRule, code)
+				}
+				if !days[day] {
+					days[day] = true
+					rule.ByDay = append(rule.ByDay, day)
+				}
+			}
+			sort.Slice(rule.ByDay, func(i, j int) bool {
+				return mondayOffset(rule.ByDay[i]) < mondayOffset(rule.ByDay[j])
+			})
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %s", ErrInvalidRRule, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRRule)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot both be set", ErrInvalidRRule)
+	}
+	return rule, nil
+}
+
+// parseRRuleUntil parses an UNTIL value, either a UTC date-time such as
+// 20260301T200000Z or a date such as 20260301, which includes that whole day.
+func parseRRuleUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL must be a UTC date-time (20060102T150405Z) or a date (20060102)", ErrInvalidRRule)
+}
+
+// mondayOffset returns how many days day falls after Monday.
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// ExpandRecurrence materializes the occurrences of e that start in [from, to),
+// without persisting them. Each occurrence is a copy of e keeping its ID, with
+// StartsAt moved to the occurrence (at e's wall-clock time in e's location),
+// EndsAt moved to keep the event's duration, and RRule cleared. The rule counts
+// occurrences from e.StartsAt, which is the first occurrence when it matches
+// the rule. An event without an RRule expands to itself if it starts in the
+// window. Returns an error wrapping ErrInvalidRRule for a bad rule and
+// ErrTooManyOccurrences when the window holds more than MaxExpandedOccurrences.
+func ExpandRecurrence(e *Event, from, to time.Time) ([]Event, error) {
+	if e.RRule == nil {
+		if e.StartsAt.Before(from) || !e.StartsAt.Before(to) {
+			return []Event{}, nil
+		}
+		return []Event{*copyEvent(e)}, nil
+	}
+
+	rule, err := ParseRRule(*e.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	start := e.StartsAt
+	loc := start.Location()
+	hour, minute, sec := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, sec, start.Nanosecond(), loc)
+	}
+
+	occurrences := []Event{}
+	count := 0
+	for period := 0; ; period++ {
+		var periodStart time.Time
+		var candidates []time.Time
+		switch rule.Freq {
+		case FreqDaily:
+			periodStart = time.Date(start.Year(), start.Month(), start.Day()+period*rule.Interval, 0, 0, 0, 0, loc)
+			if rule.matchesDay(periodStart.Weekday()) {
+				candidates = append(candidates, at(periodStart.Date()))
+			}
+		case FreqWeekly:
+			weekStart := start.Day() - mondayOffset(start.Weekday()) + period*rule.Interval*7
+			periodStart = time.Date(start.Year(), start.Month(), weekStart, 0, 0, 0, 0, loc)
+			days := rule.ByDay
+			if len(days) == 0 {
+				days = []time.Weekday{start.Weekday()}
+			}
+			for _, day := range days {
+				candidates = append(candidates, at(start.Year(), start.Month(), weekStart+mondayOffset(day)))
+			}
+		case FreqMonthly:
+			periodStart = time.Date(start.Year(), start.Month()+time.Month(period*rule.Interval), 1, 0, 0, 0, 0, loc)
+			year, month, _ := periodStart.Date()
+			if len(rule.ByDay) == 0 {
+				// Months without the start's day of month are skipped, as RFC 5545 requires
+				if t := at(year, month, start.Day()); t.Month() == month {
+					candidates = append(candidates, t)
+				}
+			} else {
+				for d := periodStart; d.Month() == month; d = d.AddDate(0, 0, 1) {
+					if rule.matchesDay(d.Weekday()) {
+						candidates = append(candidates, at(d.Date()))
+					}
+				}
+			}
+		}
+		if !periodStart.Before(to) {
+			return occurrences, nil
+		}
+
+		for _, t := range candidates {
+			if t.Before(start) {
+				continue
+			}
+			if rule.Until != nil && t.After(*rule.Until) {
+				return occurrences, nil
+			}
+			count++
+			if rule.Count > 0 && count > rule.Count {
+				return occurrences, nil
+			}
+			if !t.Before(to) {
+				return occurrences, nil
+			}
+			if t.Before(from) {
+				continue
+			}
+			if len(occurrences) == MaxExpandedOccurrences {
+				return nil, fmt.Errorf("%w: more than %d in the requested window", ErrTooManyOccurrences, MaxExpandedOccurrences)
+			}
+			occurrences = append(occurrences, e.occurrenceAt(t))
+		}
+	}
+}
+
+// matchesDay reports whether day is allowed by the rule's BYDAY, if any.
+func (r *RecurrenceRule) matchesDay(day time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// occurrenceAt returns a copy of the event moved to start at startsAt.
+func (e *Event) occurrenceAt(startsAt time.Time) Event {
+	occurrence := copyEvent(e)
+	occurrence.RRule = nil
+	occurrence.StartsAt = startsAt
+	if e.EndsAt != nil {
+		endsAt := startsAt.Add(e.EndsAt.Sub(e.StartsAt))
+		occurrence.EndsAt = &endsAt
+	}
+	return *occurrence
+}
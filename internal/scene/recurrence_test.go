@@ -0,0 +1,232 @@
+package scene
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	rule, err := ParseRRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TH,TU;COUNT=6")
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	if rule.Freq != FreqWeekly || rule.Interval != 2 || rule.Count != 6 {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+	if len(rule.ByDay) != 2 || rule.ByDay[0] != time.Tuesday || rule.ByDay[1] != time.Thursday {
+		t.Errorf("expected BYDAY [Tuesday Thursday], got %v", rule.ByDay)
+	}
+
+	rule, err = ParseRRule("FREQ=DAILY;UNTIL=20260301")
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	if want := time.Date(2026, 3, 1, 23, 59, 59, 999999999, time.UTC); !rule.Until.Equal(want) {
+		t.Errorf("expected date UNTIL to cover the whole day, got %v", rule.Until)
+	}
+
+	invalid := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=YEARLY",
+		"FREQ=WEEKLY;INTERVAL=0",
+		"FREQ=WEEKLY;COUNT=-1",
+		"FREQ=WEEKLY;COUNT=3;UNTIL=20260301T000000Z",
+		"FREQ=WEEKLY;UNTIL=next-week",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTH=3",
+		"FREQ=WEEKLY;FREQ=DAILY",
+		"FREQ",
+	}
+	for _, s := range invalid {
+		if _, err := ParseRRule(s); !errors.Is(err, ErrInvalidRRule) {
+			t.Errorf("ParseRRule(%q) error = %v, want ErrInvalidRRule", s, err)
+		}
+	}
+}
+
+func TestExpandRecurrence(t *testing.T) {
+	// Thursday 20:00 UTC
+	start := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 20, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		rrule string
+		start time.Time // Defaults to start
+		from  time.Time
+		to    time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "weekly",
+			rrule: "FREQ=WEEKLY",
+			from:  start,
+			to:    date(1, 29),
+			want:  []time.Time{date(1, 1), date(1, 8), date(1, 15), date(1, 22)},
+		},
+		{
+			name:  "window excludes earlier occurrences",
+			rrule: "FREQ=WEEKLY",
+			from:  date(1, 10),
+			to:    date(1, 23),
+			want:  []time.Time{date(1, 15), date(1, 22)},
+		},
+		{
+			name:  "biweekly on two days",
+			rrule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			from:  start,
+			to:    date(2, 1),
+			want:  []time.Time{date(1, 1), date(1, 13), date(1, 15), date(1, 27), date(1, 29)},
+		},
+		{
+			name:  "count is counted from the start, not the window",
+			rrule: "FREQ=DAILY;COUNT=5",
+			from:  date(1, 3),
+			to:    date(2, 1),
+			want:  []time.Time{date(1, 3), date(1, 4), date(1, 5)},
+		},
+		{
+			name:  "until is inclusive",
+			rrule: "FREQ=WEEKLY;UNTIL=20260115T200000Z",
+			from:  start,
+			to:    date(3, 1),
+			want:  []time.Time{date(1, 1), date(1, 8), date(1, 15)},
+		},
+		{
+			name:  "daily on weekdays",
+			rrule: "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			from:  start,
+			to:    date(1, 7),
+			want:  []time.Time{date(1, 1), date(1, 2), date(1, 5), date(1, 6)},
+		},
+		{
+			name:  "monthly skips months without the day",
+			rrule: "FREQ=MONTHLY;COUNT=3",
+			start: date(1, 31),
+			from:  start,
+			to:    date(12, 31),
+			want:  []time.Time{date(1, 31), date(3, 31), date(5, 31)},
+		},
+		{
+			name:  "monthly on a weekday",
+			rrule: "FREQ=MONTHLY;BYDAY=TH;COUNT=6",
+			from:  start,
+			to:    date(12, 31),
+			want:  []time.Time{date(1, 1), date(1, 8), date(1, 15), date(1, 22), date(1, 29), date(2, 5)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rrule := tt.rrule
+			event := &Event{ID: "event-1", Title: "Meetup", StartsAt: start, RRule: &rrule}
+			if !tt.start.IsZero() {
+				event.StartsAt = tt.start
+			}
+
+			occurrences, err := ExpandRecurrence(event, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("ExpandRecurrence() error = %v", err)
+			}
+			if len(occurrences) != len(tt.want) {
+				t.Fatalf("expected %d occurrences, got %d: %v", len(tt.want), len(occurrences), occurrences)
+			}
+			for i, occurrence := range occurrences {
+				if !occurrence.StartsAt.Equal(tt.want[i]) {
+					t.Errorf("occurrence %d starts at %v, want %v", i, occurrence.StartsAt, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExpandRecurrence_Occurrences(t *testing.T) {
+	start := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	endsAt := start.Add(3 * time.Hour)
+	rrule := "FREQ=WEEKLY"
+	event := &Event{
+		ID:           "event-1",
+		SceneID:      "scene-1",
+		Title:        "Meetup",
+		StartsAt:     start,
+		EndsAt:       &endsAt,
+		RRule:        &rrule,
+		PrecisePoint: &Point{Lat: 1, Lng: 2},
+	}
+
+	occurrences, err := ExpandRecurrence(event, start.AddDate(0, 0, 7), start.AddDate(0, 0, 8))
+	if err != nil {
+		t.Fatalf("ExpandRecurrence() error = %v", err)
+	}
+	if len(occurrences) != 1 {
+		t.Fatalf("expected 1 occurrence, got %d", len(occurrences))
+	}
+	occurrence := occurrences[0]
+	if occurrence.ID != "event-1" || occurrence.Title != "Meetup" || occurrence.RRule != nil {
+		t.Errorf("unexpected occurrence %+v", occurrence)
+	}
+	if want := endsAt.AddDate(0, 0, 7); occurrence.EndsAt == nil || !occurrence.EndsAt.Equal(want) {
+		t.Errorf("expected occurrence to end at %v, got %v", want, occurrence.EndsAt)
+	}
+	if !event.StartsAt.Equal(start) || !event.EndsAt.Equal(endsAt) {
+		t.Error("expected the master event to be unchanged")
+	}
+	occurrence.PrecisePoint.Lat = 50
+	if event.PrecisePoint.Lat != 1 {
+		t.Error("expected the occurrence's precise point to be a copy")
+	}
+}
+
+func TestExpandRecurrence_KeepsWallClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	start := time.Date(2026, 3, 5, 19, 0, 0, 0, loc)
+	rrule := "FREQ=WEEKLY;COUNT=2"
+	occurrences, err := ExpandRecurrence(&Event{StartsAt: start, RRule: &rrule}, start, start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("ExpandRecurrence() error = %v", err)
+	}
+	if len(occurrences) != 2 || occurrences[1].StartsAt.Hour() != 19 {
+		t.Errorf("expected the second occurrence at 19:00 local time, got %v", occurrences)
+	}
+}
+
+func TestExpandRecurrence_NonRecurring(t *testing.T) {
+	start := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	event := &Event{ID: "event-1", StartsAt: start}
+
+	occurrences, err := ExpandRecurrence(event, start, start.Add(time.Hour))
+	if err != nil || len(occurrences) != 1 || occurrences[0].ID != "event-1" {
+		t.Errorf("expected the event itself, got %v (error %v)", occurrences, err)
+	}
+	occurrences, err = ExpandRecurrence(event, start.Add(time.Hour), start.Add(2*time.Hour))
+	if err != nil || len(occurrences) != 0 {
+		t.Errorf("expected no occurrences outside the window, got %v (error %v)", occurrences, err)
+	}
+}
+
+func TestExpandRecurrence_Cap(t *testing.T) {
+	start := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	rrule := "FREQ=DAILY"
+	event := &Event{StartsAt: start, RRule: &rrule}
+
+	if _, err := ExpandRecurrence(event, start, start.AddDate(2, 0, 0)); !errors.Is(err, ErrTooManyOccurrences) {
+		t.Errorf("expected ErrTooManyOccurrences for two years of daily occurrences, got %v", err)
+	}
+	occurrences, err := ExpandRecurrence(event, start, start.AddDate(0, 0, MaxExpandedOccurrences))
+	if err != nil || len(occurrences) != MaxExpandedOccurrences {
+		t.Errorf("expected exactly %d occurrences, got %d (error %v)", MaxExpandedOccurrences, len(occurrences), err)
+	}
+
+	invalid := "FREQ=SECONDLY"
+	if _, err := ExpandRecurrence(&Event{StartsAt: start, RRule: &invalid}, start, start.Add(time.Hour)); !errors.Is(err, ErrInvalidRRule) {
+		t.Errorf("expected ErrInvalidRRule, got %v", err)
+	}
+}
@@ -21,6 +21,7 @@ var (
 	ErrEventNotFound      = errors.New("event not found")
 	ErrDuplicateSceneName = errors.New("scene name already exists for this owner")
 	ErrRSVPNotFound       = errors.New("rsvp not found")
+	ErrSeriesNotFound     = errors.New("event series not found")
 )
 
 // UpsertResult tracks statistics for upsert operations.
@@ -29,6 +30,23 @@ type UpsertResult struct {
 	ID       string // The UUID of the upserted record
 }
 
+// OwnerScope restricts a listing to entities owned by DID. IncludeDeleted adds
+// the owner's own soft-deleted entities; it never exposes anyone else's, and an
+// empty DID matches nothing.
+type OwnerScope struct {
+	DID            string
+	IncludeDeleted bool
+}
+
+// Allows reports whether an entity owned by ownerDID with the given deletion time
+// is visible under the scope.
+func (s OwnerScope) Allows(ownerDID string, deletedAt *time.Time) bool {
+	if s.DID == "" || ownerDID != s.DID {
+		return false
+	}
+	return deletedAt == nil || s.IncludeDeleted
+}
+
 // SceneRepository defines the interface for scene data operations.
 // All implementations must enforce location consent before persisting data.
 type SceneRepository interface {
@@ -64,10 +82,18 @@ type SceneRepository interface {
 	// Returns empty slice if no scenes found.
 	ListByOwner(ownerDID string) ([]*Scene, error)
 
+	// ListByOwnerScope retrieves the scenes visible under scope, sorted by ID.
+	// Returns empty slice if no scenes found.
+	ListByOwnerScope(scope OwnerScope) ([]*Scene, error)
+
 	// ListByConnectedAccountID retrieves all non-deleted scenes with the given Stripe
 	// connected account ID. Returns empty slice if no scenes found.
 	ListByConnectedAccountID(connectedAccountID string) ([]*Scene, error)
 
+	// ListPublic retrieves all non-deleted public scenes that moderation has not
+	// hidden or suspended, sorted by ID. Returns empty slice if no scenes found.
+	ListPublic() ([]*Scene, error)
+
 	// SearchScenes searches for scenes with text matching, geo filtering, ranking, and pagination.
 	// Filters out deleted and hidden scenes, applies text search if query is provided,
 	// and ranks results by composite score (text + proximity + trust).
@@ -138,14 +164,37 @@ type EventRepository interface {
 	// Idempotent: returns nil if event is already cancelled.
 	Cancel(id string, reason *string) error
 
+	// ListBySeries retrieves all non-deleted occurrences of a recurring series,
+	// sorted by starts_at ascending.
+	// Returns ErrSeriesNotFound if the series has no occurrences.
+	ListBySeries(seriesID string) ([]*Event, error)
+
+	// ListByOwnerScope retrieves events in scenes visible under scope, sorted by
+	// starts_at ascending. Events inherit the owner of their scene, so events in
+	// scenes not owned by scope.DID are never returned; scenes is typically the
+	// result of SceneRepository.ListByOwnerScope with the same scope.
+	ListByOwnerScope(scope OwnerScope, scenes []*Scene) ([]*Event, error)
+
+	// CancelSeriesFrom atomically cancels every occurrence of a series starting after from,
+	// leaving earlier occurrences untouched. Returns only the occurrences newly cancelled
+	// by this call, so repeat calls return an empty slice.
+	// Returns ErrSeriesNotFound if the series has no occurrences.
+	CancelSeriesFrom(seriesID string, from time.Time, reason *string) ([]*Event, error)
+
+	// ListByScene retrieves a scene's events, excluding soft-deleted ones,
+	// sorted by starts_at ascending. Unpublished events are included; callers
+	// showing the list to anyone but the scene owner must filter them out.
+	ListByScene(sceneID string) ([]*Event, error)
+
 	// SearchByBboxAndTime searches for events within a bounding box and time range.
-	// Filters out cancelled events and applies pagination.
+	// Filters out cancelled and unpublished events and applies pagination.
 	// Returns events sorted by starts_at ascending.
 	// DEPRECATED: Use SearchEvents instead for more advanced search features.
 	SearchByBboxAndTime(minLng, minLat, maxLng, maxLat float64, from, to time.Time, limit int, cursor string) ([]*Event, string, error)
 
 	// SearchEvents searches for events with text matching, ranking, and pagination.
-	// Filters out cancelled events, applies text search if query is provided,
+	// Filters out cancelled events and always excludes unpublished ones (pending
+	// approval or rejected), applies text search if query is provided,
 	// and ranks results by composite score (recency + text + proximity + trust).
 	// Returns events sorted by composite score descending, then by ID for stable ordering.
 	SearchEvents(opts EventSearchOptions) ([]*Event, string, error)
@@ -155,7 +204,24 @@ type EventRepository interface {
 type RSVPRepository interface {
 	// Upsert inserts or updates an RSVP for an event.
 	// Idempotent: if RSVP exists with same status, returns without error.
-	Upsert(rsvp *RSVP) error
+	// Returns true if a new RSVP was inserted, false if an existing one was updated.
+	Upsert(rsvp *RSVP) (bool, error)
+
+	// UpsertWithCapacity inserts or updates an RSVP like Upsert, but records a
+	// going RSVP as waitlisted while the event's going RSVPs are at capacity.
+	// An RSVP that is already going keeps its place, and a waitlisted one keeps
+	// its waitlist position. A nil capacity behaves like Upsert.
+	UpsertWithCapacity(rsvp *RSVP, capacity *int) (bool, error)
+
+	// PromoteWaitlisted moves the event's oldest waitlisted RSVPs to going
+	// until its going RSVPs reach capacity, returning the promoted RSVPs in
+	// promotion order. A nil capacity promotes the whole waitlist.
+	PromoteWaitlisted(eventID string, capacity *int) ([]*RSVP, error)
+
+	// WaitlistPosition returns the 1-based position of a user's RSVP on the
+	// event's waitlist, or 0 if the RSVP is not waitlisted.
+	// Returns ErrRSVPNotFound if RSVP doesn't exist.
+	WaitlistPosition(eventID, userID string) (int, error)
 
 	// Delete removes an RSVP for a user and event.
 	// Returns ErrRSVPNotFound if RSVP doesn't exist.
@@ -168,9 +234,26 @@ type RSVPRepository interface {
 	// GetCountsByEvent returns aggregated RSVP counts by status for an event.
 	GetCountsByEvent(eventID string) (*RSVPCounts, error)
 
+	// CountByStatus returns the number of RSVPs for an event keyed by status,
+	// aggregated in the store rather than by loading RSVPs. Statuses with no
+	// RSVPs are omitted.
+	CountByStatus(eventID string) (map[string]int, error)
+
 	// GetCountsForEvents returns a map of event IDs to their RSVP counts.
 	// This is a batch operation to avoid N+1 queries.
 	GetCountsForEvents(eventIDs []string) (map[string]*RSVPCounts, error)
+
+	// GetCountsByGroup sums RSVP counts across events, grouped by the key each
+	// event ID maps to in groups. Every key in groups appears in the result,
+	// with zero counts if its events have no RSVPs.
+	GetCountsByGroup(groups map[string]string) (map[string]*RSVPCounts, error)
+
+	// ListByEvent returns all RSVPs for an event.
+	ListByEvent(eventID string) ([]*RSVP, error)
+
+	// ListByUser returns all RSVPs made by a user, sorted by event ID.
+	// If status is non-empty, only RSVPs with that status are returned.
+	ListByUser(userID, status string) ([]*RSVP, error)
 }
 
 // InMemorySceneRepository is an in-memory implementation of SceneRepository.
@@ -395,6 +478,32 @@ func (r *InMemorySceneRepository) ListByOwner(ownerDID string) ([]*Scene, error)
 	return result, nil
 }
 
+// ListByOwnerScope retrieves the scenes visible under scope, sorted by ID.
+// Returns empty slice if no scenes found.
+func (r *InMemorySceneRepository) ListByOwnerScope(scope OwnerScope) ([]*Scene, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Scene, 0)
+	for _, scene := range r.scenes {
+		if scope.Allows(scene.OwnerDID, scene.DeletedAt) {
+			// Return a copy to avoid external modification
+			sceneCopy := *scene
+			if scene.PrecisePoint != nil {
+				pointCopy := *scene.PrecisePoint
+				sceneCopy.PrecisePoint = &pointCopy
+			}
+			result = append(result, &sceneCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
 // ListByConnectedAccountID retrieves all non-deleted scenes with the given Stripe connected account ID.
 // Returns empty slice if no scenes found.
 func (r *InMemorySceneRepository) ListByConnectedAccountID(connectedAccountID string) ([]*Scene, error) {
@@ -416,6 +525,34 @@ func (r *InMemorySceneRepository) ListByConnectedAccountID(connectedAccountID st
 	return result, nil
 }
 
+// ListPublic retrieves all non-deleted public scenes that moderation has not
+// hidden or suspended, sorted by ID.
+func (r *InMemorySceneRepository) ListPublic() ([]*Scene, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Scene, 0)
+	for _, scene := range r.scenes {
+		if scene.DeletedAt != nil || scene.Visibility != VisibilityPublic {
+			continue
+		}
+		if scene.ModerationStatus == "hidden" || scene.ModerationStatus == "suspended" {
+			continue
+		}
+		sceneCopy := *scene
+		if scene.PrecisePoint != nil {
+			pointCopy := *scene.PrecisePoint
+			sceneCopy.PrecisePoint = &pointCopy
+		}
+		result = append(result, &sceneCopy)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
 // GetByIDs retrieves scenes by their IDs in a single call.
 // Returns only existing, non-deleted scenes.
 func (r *InMemorySceneRepository) GetByIDs(ids []string) ([]*Scene, error) {
@@ -799,6 +936,131 @@ func (r *InMemoryEventRepository) Cancel(id string, reason *string) error {
 	return nil
 }
 
+// ListBySeries retrieves all non-deleted occurrences of a recurring series,
+// sorted by starts_at ascending.
+func (r *InMemoryEventRepository) ListBySeries(seriesID string) ([]*Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*Event
+	for _, event := range r.events {
+		if event.SeriesID == nil || *event.SeriesID != seriesID || event.DeletedAt != nil {
+			continue
+		}
+		eventCopy := *event
+		if event.PrecisePoint != nil {
+			pointCopy := *event.PrecisePoint
+			eventCopy.PrecisePoint = &pointCopy
+		}
+		results = append(results, &eventCopy)
+	}
+	if len(results) == 0 {
+		return nil, ErrSeriesNotFound
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].StartsAt.Equal(results[j].StartsAt) {
+			return results[i].ID < results[j].ID
+		}
+		return results[i].StartsAt.Before(results[j].StartsAt)
+	})
+	return results, nil
+}
+
+// ListByScene retrieves a scene's events, excluding soft-deleted ones,
+// sorted by starts_at ascending.
+func (r *InMemoryEventRepository) ListByScene(sceneID string) ([]*Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Event, 0)
+	for _, event := range r.events {
+		if event.SceneID != sceneID || event.DeletedAt != nil {
+			continue
+		}
+		result = append(result, copyEvent(event))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StartsAt.Equal(result[j].StartsAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].StartsAt.Before(result[j].StartsAt)
+	})
+
+	return result, nil
+}
+
+// ListByOwnerScope retrieves events in scenes visible under scope, sorted by starts_at ascending.
+func (r *InMemoryEventRepository) ListByOwnerScope(scope OwnerScope, scenes []*Scene) ([]*Event, error) {
+	owners := make(map[string]string, len(scenes))
+	for _, sc := range scenes {
+		owners[sc.ID] = sc.OwnerDID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Event, 0)
+	for _, event := range r.events {
+		// Events inherit their scene's owner
+		ownerDID, ok := owners[event.SceneID]
+		if !ok || !scope.Allows(ownerDID, event.DeletedAt) {
+			continue
+		}
+		result = append(result, copyEvent(event))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StartsAt.Equal(result[j].StartsAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].StartsAt.Before(result[j].StartsAt)
+	})
+
+	return result, nil
+}
+
+// CancelSeriesFrom atomically cancels every occurrence of a series starting after from.
+// Occurrences that are already cancelled or start at or before from are left untouched.
+func (r *InMemoryEventRepository) CancelSeriesFrom(seriesID string, from time.Time, reason *string) ([]*Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := false
+	now := time.Now()
+	cancelled := make([]*Event, 0)
+	for _, event := range r.events {
+		if event.SeriesID == nil || *event.SeriesID != seriesID || event.DeletedAt != nil {
+			continue
+		}
+		found = true
+
+		if !event.StartsAt.After(from) {
+			continue
+		}
+		if event.Status == "cancelled" && event.CancelledAt != nil {
+			continue
+		}
+
+		event.Status = "cancelled"
+		event.CancelledAt = &now
+		event.CancellationReason = reason
+		event.UpdatedAt = &now
+
+		eventCopy := *event
+		cancelled = append(cancelled, &eventCopy)
+	}
+	if !found {
+		return nil, ErrSeriesNotFound
+	}
+
+	sort.Slice(cancelled, func(i, j int) bool {
+		return cancelled[i].StartsAt.Before(cancelled[j].StartsAt)
+	})
+	return cancelled, nil
+}
+
 // SearchByBboxAndTime searches for events within a bounding box and time range.
 // Filters out cancelled events and applies pagination.
 // Returns events sorted by starts_at ascending.
@@ -825,8 +1087,8 @@ func (r *InMemoryEventRepository) SearchByBboxAndTime(minLng, minLat, maxLng, ma
 
 	// Collect matching events
 	for _, event := range r.events {
-		// Skip cancelled events
-		if event.Status == "cancelled" {
+		// Skip cancelled and unpublished events
+		if event.Status == "cancelled" || !event.IsPublished() {
 			continue
 		}
 
@@ -954,6 +1216,11 @@ func (r *InMemoryEventRepository) SearchEvents(opts EventSearchOptions) ([]*Even
 			continue
 		}
 
+		// Unpublished events never appear in discovery
+		if !event.IsPublished() {
+			continue
+		}
+
 		// Skip deleted events
 		if event.DeletedAt != nil {
 			continue
@@ -1136,28 +1403,129 @@ func makeRSVPKey(eventID, userID string) string {
 
 // Upsert inserts or updates an RSVP for an event.
 // Idempotent: if RSVP exists with same status, returns without error.
-func (r *InMemoryRSVPRepository) Upsert(rsvp *RSVP) error {
+// Returns true if a new RSVP was inserted, false if an existing one was updated.
+func (r *InMemoryRSVPRepository) Upsert(rsvp *RSVP) (bool, error) {
+	return r.UpsertWithCapacity(rsvp, nil)
+}
+
+// UpsertWithCapacity inserts or updates an RSVP, waitlisting going RSVPs while
+// the event's going RSVPs are at capacity.
+func (r *InMemoryRSVPRepository) UpsertWithCapacity(rsvp *RSVP, capacity *int) (bool, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	key := makeRSVPKey(rsvp.EventID, rsvp.UserID)
 	now := time.Now()
+	existing, exists := r.rsvps[key]
+
+	status := rsvp.Status
+	alreadyGoing := exists && existing.Status == RSVPStatusGoing
+	if status == RSVPStatusGoing && capacity != nil && !alreadyGoing && r.countStatus(rsvp.EventID, RSVPStatusGoing) >= *capacity {
+		status = RSVPStatusWaitlisted
+	}
 
 	// Check if RSVP already exists
-	existing, exists := r.rsvps[key]
 	if exists {
 		// Update existing RSVP
-		existing.Status = rsvp.Status
+		if status == RSVPStatusWaitlisted && existing.WaitlistedAt == nil {
+			existing.WaitlistedAt = &now
+		} else if status != RSVPStatusWaitlisted {
+			existing.WaitlistedAt = nil
+		}
+		existing.Status = status
 		existing.UpdatedAt = &now
-	} else {
-		// Create new RSVP
+		return false, nil
+	}
+
+	// Create new RSVP
+	rsvpCopy := *rsvp
+	rsvpCopy.Status = status
+	rsvpCopy.CreatedAt = &now
+	rsvpCopy.UpdatedAt = &now
+	rsvpCopy.WaitlistedAt = nil
+	if status == RSVPStatusWaitlisted {
+		rsvpCopy.WaitlistedAt = &now
+	}
+	r.rsvps[key] = &rsvpCopy
+	return true, nil
+}
+
+// PromoteWaitlisted moves the oldest waitlisted RSVPs to going while the event
+// has room.
+func (r *InMemoryRSVPRepository) PromoteWaitlisted(eventID string, capacity *int) ([]*RSVP, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	waitlist := r.waitlist(eventID)
+	going := r.countStatus(eventID, RSVPStatusGoing)
+	now := time.Now()
+
+	promoted := make([]*RSVP, 0)
+	for _, rsvp := range waitlist {
+		if capacity != nil && going >= *capacity {
+			break
+		}
+		rsvp.Status = RSVPStatusGoing
+		rsvp.WaitlistedAt = nil
+		rsvp.UpdatedAt = &now
+		going++
+
 		rsvpCopy := *rsvp
-		rsvpCopy.CreatedAt = &now
-		rsvpCopy.UpdatedAt = &now
-		r.rsvps[key] = &rsvpCopy
+		promoted = append(promoted, &rsvpCopy)
 	}
+	return promoted, nil
+}
 
-	return nil
+// WaitlistPosition returns the 1-based waitlist position of a user's RSVP, or
+// 0 if it is not waitlisted.
+func (r *InMemoryRSVPRepository) WaitlistPosition(eventID, userID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rsvp, exists := r.rsvps[makeRSVPKey(eventID, userID)]
+	if !exists {
+		return 0, ErrRSVPNotFound
+	}
+	if rsvp.Status != RSVPStatusWaitlisted {
+		return 0, nil
+	}
+	for i, waiting := range r.waitlist(eventID) {
+		if waiting.UserID == userID {
+			return i + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// waitlist returns the event's waitlisted RSVPs, oldest first, with ties broken
+// by user ID. Callers must hold r.mu.
+func (r *InMemoryRSVPRepository) waitlist(eventID string) []*RSVP {
+	var waitlist []*RSVP
+	for _, rsvp := range r.rsvps {
+		if rsvp.EventID == eventID && rsvp.Status == RSVPStatusWaitlisted {
+			waitlist = append(waitlist, rsvp)
+		}
+	}
+	sort.Slice(waitlist, func(i, j int) bool {
+		a, b := waitlist[i].WaitlistedAt, waitlist[j].WaitlistedAt
+		if a != nil && b != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return waitlist[i].UserID < waitlist[j].UserID
+	})
+	return waitlist
+}
+
+// countStatus counts the event's RSVPs with the given status. Callers must
+// hold r.mu.
+func (r *InMemoryRSVPRepository) countStatus(eventID, status string) int {
+	count := 0
+	for _, rsvp := range r.rsvps {
+		if rsvp.EventID == eventID && rsvp.Status == status {
+			count++
+		}
+	}
+	return count
 }
 
 // Delete removes an RSVP for a user and event.
@@ -1209,6 +1577,8 @@ func (r *InMemoryRSVPRepository) GetCountsByEvent(eventID string) (*RSVPCounts,
 				counts.Going++
 			case "maybe":
 				counts.Maybe++
+			case RSVPStatusWaitlisted:
+				counts.Waitlisted++
 			}
 		}
 	}
@@ -1216,6 +1586,20 @@ func (r *InMemoryRSVPRepository) GetCountsByEvent(eventID string) (*RSVPCounts,
 	return counts, nil
 }
 
+// CountByStatus returns the number of RSVPs for an event keyed by status.
+func (r *InMemoryRSVPRepository) CountByStatus(eventID string) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, rsvp := range r.rsvps {
+		if rsvp.EventID == eventID {
+			counts[rsvp.Status]++
+		}
+	}
+	return counts, nil
+}
+
 // GetCountsForEvents returns a map of event IDs to their RSVP counts.
 // This is a batch operation to avoid N+1 queries.
 func (r *InMemoryRSVPRepository) GetCountsForEvents(eventIDs []string) (map[string]*RSVPCounts, error) {
@@ -1246,9 +1630,77 @@ func (r *InMemoryRSVPRepository) GetCountsForEvents(eventIDs []string) (map[stri
 				counts.Going++
 			case "maybe":
 				counts.Maybe++
+			case RSVPStatusWaitlisted:
+				counts.Waitlisted++
 			}
 		}
 	}
 
 	return result, nil
 }
+
+// GetCountsByGroup sums RSVP counts across events, grouped by the key each
+// event ID maps to in groups. Every key in groups appears in the result.
+func (r *InMemoryRSVPRepository) GetCountsByGroup(groups map[string]string) (map[string]*RSVPCounts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]*RSVPCounts)
+	for _, key := range groups {
+		if result[key] == nil {
+			result[key] = &RSVPCounts{}
+		}
+	}
+
+	for _, rsvp := range r.rsvps {
+		key, ok := groups[rsvp.EventID]
+		if !ok {
+			continue
+		}
+		switch rsvp.Status {
+		case "going":
+			result[key].Going++
+		case "maybe":
+			result[key].Maybe++
+		case RSVPStatusWaitlisted:
+			result[key].Waitlisted++
+		}
+	}
+
+	return result, nil
+}
+
+// ListByEvent returns all RSVPs for an event.
+func (r *InMemoryRSVPRepository) ListByEvent(eventID string) ([]*RSVP, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*RSVP, 0)
+	for _, rsvp := range r.rsvps {
+		if rsvp.EventID == eventID {
+			rsvpCopy := *rsvp
+			results = append(results, &rsvpCopy)
+		}
+	}
+	return results, nil
+}
+
+// ListByUser returns all RSVPs made by a user, sorted by event ID.
+// If status is non-empty, only RSVPs with that status are returned.
+func (r *InMemoryRSVPRepository) ListByUser(userID, status string) ([]*RSVP, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*RSVP, 0)
+	for _, rsvp := range r.rsvps {
+		if rsvp.UserID != userID || (status != "" && rsvp.Status != status) {
+			continue
+		}
+		rsvpCopy := *rsvp
+		results = append(results, &rsvpCopy)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].EventID < results[j].EventID
+	})
+	return results, nil
+}
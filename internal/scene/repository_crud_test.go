@@ -630,3 +630,117 @@ func TestSceneRepository_ModerationStatus_RemoveModeration(t *testing.T) {
 		t.Error("Expected ModerationTimestamp to be nil after removal")
 	}
 }
+
+// --- OwnerScope listings ---
+
+func TestSceneRepository_ListByOwnerScope(t *testing.T) {
+	repo := NewInMemorySceneRepository()
+	for _, s := range []*Scene{
+		{ID: "scene-a", Name: "Alpha", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"},
+		{ID: "scene-b", Name: "Beta", OwnerDID: "did:plc:owner", CoarseGeohash: "dr5regw"},
+		{ID: "scene-c", Name: "Gamma", OwnerDID: "did:plc:other", CoarseGeohash: "dr5regw"},
+	} {
+		if err := repo.Insert(s); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	if err := repo.Delete("scene-b"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		scope OwnerScope
+		want  []string
+	}{
+		{"owner without deleted", OwnerScope{DID: "did:plc:owner"}, []string{"scene-a"}},
+		{"owner with deleted", OwnerScope{DID: "did:plc:owner", IncludeDeleted: true}, []string{"scene-a", "scene-b"}},
+		{"other user with deleted", OwnerScope{DID: "did:plc:other", IncludeDeleted: true}, []string{"scene-c"}},
+		{"empty DID", OwnerScope{IncludeDeleted: true}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenes, err := repo.ListByOwnerScope(tt.scope)
+			if err != nil {
+				t.Fatalf("ListByOwnerScope failed: %v", err)
+			}
+			if len(scenes) != len(tt.want) {
+				t.Fatalf("expected %d scenes, got %d", len(tt.want), len(scenes))
+			}
+			for i, id := range tt.want {
+				if scenes[i].ID != id {
+					t.Errorf("scenes[%d] = %s, want %s", i, scenes[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestEventRepository_ListByOwnerScope(t *testing.T) {
+	repo := NewInMemoryEventRepository()
+	now := time.Now()
+	deletedAt := now.Add(-time.Hour)
+	for _, e := range []*Event{
+		{ID: "event-2", SceneID: "scene-a", Title: "Later", CoarseGeohash: "dr5regw", StartsAt: now.Add(2 * time.Hour)},
+		{ID: "event-1", SceneID: "scene-a", Title: "Sooner", CoarseGeohash: "dr5regw", StartsAt: now.Add(time.Hour)},
+		{ID: "event-3", SceneID: "scene-a", Title: "Deleted", CoarseGeohash: "dr5regw", StartsAt: now, DeletedAt: &deletedAt},
+		{ID: "event-4", SceneID: "scene-c", Title: "Not Mine", CoarseGeohash: "dr5regw", StartsAt: now},
+	} {
+		if err := repo.Insert(e); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	scenes := []*Scene{
+		{ID: "scene-a", OwnerDID: "did:plc:owner"},
+		{ID: "scene-c", OwnerDID: "did:plc:other"},
+	}
+
+	// Events in scenes owned by someone else are never returned
+	events, err := repo.ListByOwnerScope(OwnerScope{DID: "did:plc:owner"}, scenes)
+	if err != nil {
+		t.Fatalf("ListByOwnerScope failed: %v", err)
+	}
+	if len(events) != 2 || events[0].ID != "event-1" || events[1].ID != "event-2" {
+		t.Fatalf("expected [event-1 event-2], got %d events", len(events))
+	}
+
+	events, err = repo.ListByOwnerScope(OwnerScope{DID: "did:plc:owner", IncludeDeleted: true}, scenes)
+	if err != nil {
+		t.Fatalf("ListByOwnerScope failed: %v", err)
+	}
+	if len(events) != 3 || events[0].ID != "event-3" {
+		t.Fatalf("expected deleted event-3 first of 3 events, got %d events", len(events))
+	}
+
+	events, err = repo.ListByOwnerScope(OwnerScope{IncludeDeleted: true}, scenes)
+	if err != nil {
+		t.Fatalf("ListByOwnerScope failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no events for empty DID, got %d", len(events))
+	}
+}
+
+func TestEventRepository_ListByScene(t *testing.T) {
+	repo := NewInMemoryEventRepository()
+	now := time.Now()
+	deletedAt := now.Add(-time.Hour)
+	for _, e := range []*Event{
+		{ID: "event-2", SceneID: "scene-a", Title: "Later", CoarseGeohash: "dr5regw", StartsAt: now.Add(2 * time.Hour)},
+		{ID: "event-1", SceneID: "scene-a", Title: "Sooner", CoarseGeohash: "dr5regw", StartsAt: now.Add(time.Hour)},
+		{ID: "event-3", SceneID: "scene-a", Title: "Deleted", CoarseGeohash: "dr5regw", StartsAt: now, DeletedAt: &deletedAt},
+		{ID: "event-4", SceneID: "scene-b", Title: "Elsewhere", CoarseGeohash: "dr5regw", StartsAt: now},
+	} {
+		if err := repo.Insert(e); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	events, err := repo.ListByScene("scene-a")
+	if err != nil {
+		t.Fatalf("ListByScene failed: %v", err)
+	}
+	if len(events) != 2 || events[0].ID != "event-1" || events[1].ID != "event-2" {
+		t.Fatalf("expected [event-1 event-2], got %d events", len(events))
+	}
+}
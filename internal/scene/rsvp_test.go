@@ -1,6 +1,7 @@
 package scene
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -14,7 +15,7 @@ func TestRSVPRepository_Upsert_Create(t *testing.T) {
 	}
 
 	// Create new RSVP
-	if err := repo.Upsert(rsvp); err != nil {
+	if _, err := repo.Upsert(rsvp); err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
@@ -44,15 +45,23 @@ func TestRSVPRepository_Upsert_Update(t *testing.T) {
 		UserID:  "user-1",
 		Status:  "maybe",
 	}
-	if err := repo.Upsert(rsvp); err != nil {
+	created, err := repo.Upsert(rsvp)
+	if err != nil {
 		t.Fatalf("Initial Upsert failed: %v", err)
 	}
+	if !created {
+		t.Error("Expected initial Upsert to report a new RSVP")
+	}
 
 	// Update to "going" status
 	rsvp.Status = "going"
-	if err := repo.Upsert(rsvp); err != nil {
+	created, err = repo.Upsert(rsvp)
+	if err != nil {
 		t.Fatalf("Update Upsert failed: %v", err)
 	}
+	if created {
+		t.Error("Expected second Upsert to report an update")
+	}
 
 	// Verify status was updated
 	stored, err := repo.GetByEventAndUser("event-1", "user-1")
@@ -75,12 +84,12 @@ func TestRSVPRepository_Upsert_Idempotent(t *testing.T) {
 	}
 
 	// First upsert
-	if err := repo.Upsert(rsvp); err != nil {
+	if _, err := repo.Upsert(rsvp); err != nil {
 		t.Fatalf("First Upsert failed: %v", err)
 	}
 
 	// Second upsert with same status - should be idempotent
-	if err := repo.Upsert(rsvp); err != nil {
+	if _, err := repo.Upsert(rsvp); err != nil {
 		t.Fatalf("Second Upsert failed: %v", err)
 	}
 
@@ -104,7 +113,7 @@ func TestRSVPRepository_Delete_Success(t *testing.T) {
 		UserID:  "user-1",
 		Status:  "going",
 	}
-	if err := repo.Upsert(rsvp); err != nil {
+	if _, err := repo.Upsert(rsvp); err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
@@ -172,7 +181,7 @@ func TestRSVPRepository_GetCountsByEvent_Multiple(t *testing.T) {
 	}
 
 	for _, rsvp := range rsvps {
-		if err := repo.Upsert(rsvp); err != nil {
+		if _, err := repo.Upsert(rsvp); err != nil {
 			t.Fatalf("Upsert failed: %v", err)
 		}
 	}
@@ -202,7 +211,7 @@ func TestRSVPRepository_GetCountsByEvent_AfterDelete(t *testing.T) {
 	}
 
 	for _, rsvp := range rsvps {
-		if err := repo.Upsert(rsvp); err != nil {
+		if _, err := repo.Upsert(rsvp); err != nil {
 			t.Fatalf("Upsert failed: %v", err)
 		}
 	}
@@ -237,7 +246,7 @@ func TestRSVPRepository_GetCountsByEvent_AfterStatusChange(t *testing.T) {
 	}
 
 	for _, rsvp := range rsvps {
-		if err := repo.Upsert(rsvp); err != nil {
+		if _, err := repo.Upsert(rsvp); err != nil {
 			t.Fatalf("Upsert failed: %v", err)
 		}
 	}
@@ -248,7 +257,7 @@ func TestRSVPRepository_GetCountsByEvent_AfterStatusChange(t *testing.T) {
 		UserID:  "user-1",
 		Status:  "maybe",
 	}
-	if err := repo.Upsert(updatedRSVP); err != nil {
+	if _, err := repo.Upsert(updatedRSVP); err != nil {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
@@ -265,3 +274,119 @@ func TestRSVPRepository_GetCountsByEvent_AfterStatusChange(t *testing.T) {
 		t.Errorf("Expected Maybe count 2 after status change, got %d", counts.Maybe)
 	}
 }
+
+func TestRSVPRepository_ListByUser(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+
+	rsvps := []*RSVP{
+		{EventID: "event-2", UserID: "user-1", Status: "maybe"},
+		{EventID: "event-1", UserID: "user-1", Status: "going"},
+		{EventID: "event-3", UserID: "user-1", Status: "going"},
+		{EventID: "event-1", UserID: "user-2", Status: "going"},
+	}
+	for _, rsvp := range rsvps {
+		if _, err := repo.Upsert(rsvp); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		status string
+		want   []string
+	}{
+		{status: "", want: []string{"event-1", "event-2", "event-3"}},
+		{status: "going", want: []string{"event-1", "event-3"}},
+		{status: "maybe", want: []string{"event-2"}},
+	}
+
+	for _, tt := range tests {
+		results, err := repo.ListByUser("user-1", tt.status)
+		if err != nil {
+			t.Fatalf("ListByUser(%q) failed: %v", tt.status, err)
+		}
+		got := make([]string, len(results))
+		for i, rsvp := range results {
+			if rsvp.UserID != "user-1" {
+				t.Errorf("ListByUser(%q) returned RSVP for %s", tt.status, rsvp.UserID)
+			}
+			got[i] = rsvp.EventID
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ListByUser(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestRSVPRepository_GetCountsByGroup(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+
+	rsvps := []*RSVP{
+		{EventID: "event-1", UserID: "user-1", Status: "going"},
+		{EventID: "event-1", UserID: "user-2", Status: "maybe"},
+		{EventID: "event-2", UserID: "user-1", Status: "going"},
+		{EventID: "event-3", UserID: "user-3", Status: "maybe"},
+		// Not in any group
+		{EventID: "event-4", UserID: "user-4", Status: "going"},
+	}
+	for _, rsvp := range rsvps {
+		if _, err := repo.Upsert(rsvp); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	counts, err := repo.GetCountsByGroup(map[string]string{
+		"event-1": "week-1",
+		"event-2": "week-1",
+		"event-3": "week-2",
+		"event-5": "week-3", // No RSVPs
+	})
+	if err != nil {
+		t.Fatalf("GetCountsByGroup failed: %v", err)
+	}
+
+	want := map[string]RSVPCounts{
+		"week-1": {Going: 2, Maybe: 1},
+		"week-2": {Going: 0, Maybe: 1},
+		"week-3": {Going: 0, Maybe: 0},
+	}
+	if len(counts) != len(want) {
+		t.Fatalf("GetCountsByGroup returned %d groups, want %d: %v", len(counts), len(want), counts)
+	}
+	for key, w := range want {
+		if got := counts[key]; got == nil || *got != w {
+			t.Errorf("group %s = %+v, want %+v", key, got, w)
+		}
+	}
+}
+
+func TestRSVPRepository_CountByStatus(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+
+	rsvps := []*RSVP{
+		{EventID: "event-1", UserID: "did:plc:user1", Status: "going"},
+		{EventID: "event-1", UserID: "did:plc:user2", Status: "going"},
+		{EventID: "event-1", UserID: "did:plc:user3", Status: "maybe"},
+		{EventID: "event-2", UserID: "did:plc:user1", Status: "maybe"},
+	}
+	for _, rsvp := range rsvps {
+		if _, err := repo.Upsert(rsvp); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	counts, err := repo.CountByStatus("event-1")
+	if err != nil {
+		t.Fatalf("CountByStatus failed: %v", err)
+	}
+	if want := map[string]int{"going": 2, "maybe": 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("CountByStatus(event-1) = %v, want %v", counts, want)
+	}
+
+	counts, err = repo.CountByStatus("event-3")
+	if err != nil {
+		t.Fatalf("CountByStatus failed: %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("CountByStatus(event-3) = %v, want empty", counts)
+	}
+}
@@ -0,0 +1,164 @@
+package scene
+
+import "testing"
+
+func TestRSVPRepository_UpsertWithCapacity_FillsThenWaitlists(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+	capacity := 2
+
+	for _, userID := range []string{"did:plc:a1", "did:plc:a2", "did:plc:a3", "did:plc:a4"} {
+		if _, err := repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: userID, Status: RSVPStatusGoing}, &capacity); err != nil {
+			t.Fatalf("UpsertWithCapacity(%s) failed: %v", userID, err)
+		}
+	}
+
+	counts, err := repo.GetCountsByEvent("event-1")
+	if err != nil {
+		t.Fatalf("GetCountsByEvent failed: %v", err)
+	}
+	if counts.Going != 2 || counts.Waitlisted != 2 {
+		t.Errorf("expected 2 going and 2 waitlisted, got %+v", counts)
+	}
+
+	third, err := repo.GetByEventAndUser("event-1", "did:plc:a3")
+	if err != nil {
+		t.Fatalf("GetByEventAndUser failed: %v", err)
+	}
+	if third.Status != RSVPStatusWaitlisted || third.WaitlistedAt == nil {
+		t.Errorf("expected third RSVP to be waitlisted with a timestamp, got %+v", third)
+	}
+
+	for userID, want := range map[string]int{"did:plc:a1": 0, "did:plc:a3": 1, "did:plc:a4": 2} {
+		position, err := repo.WaitlistPosition("event-1", userID)
+		if err != nil {
+			t.Fatalf("WaitlistPosition(%s) failed: %v", userID, err)
+		}
+		if position != want {
+			t.Errorf("WaitlistPosition(%s) = %d, want %d", userID, position, want)
+		}
+	}
+
+	// Maybe RSVPs are never capped
+	if _, err := repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: "did:plc:m1", Status: RSVPStatusMaybe}, &capacity); err != nil {
+		t.Fatalf("UpsertWithCapacity(maybe) failed: %v", err)
+	}
+	maybe, _ := repo.GetByEventAndUser("event-1", "did:plc:m1")
+	if maybe.Status != RSVPStatusMaybe {
+		t.Errorf("expected maybe RSVP to stay maybe, got %q", maybe.Status)
+	}
+}
+
+func TestRSVPRepository_UpsertWithCapacity_KeepsPlaces(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+	capacity := 1
+
+	_, _ = repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: "did:plc:a1", Status: RSVPStatusGoing}, &capacity)
+	_, _ = repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: "did:plc:a2", Status: RSVPStatusGoing}, &capacity)
+	_, _ = repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: "did:plc:a3", Status: RSVPStatusGoing}, &capacity)
+
+	// Re-sending going at capacity keeps both the attendee's place and the
+	// waitlisted RSVP's position
+	if _, err := repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: "did:plc:a1", Status: RSVPStatusGoing}, &capacity); err != nil {
+		t.Fatalf("UpsertWithCapacity failed: %v", err)
+	}
+	if _, err := repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: "did:plc:a2", Status: RSVPStatusGoing}, &capacity); err != nil {
+		t.Fatalf("UpsertWithCapacity failed: %v", err)
+	}
+
+	first, _ := repo.GetByEventAndUser("event-1", "did:plc:a1")
+	if first.Status != RSVPStatusGoing {
+		t.Errorf("expected attendee to stay going, got %q", first.Status)
+	}
+	position, _ := repo.WaitlistPosition("event-1", "did:plc:a2")
+	if position != 1 {
+		t.Errorf("expected waitlisted RSVP to keep position 1, got %d", position)
+	}
+}
+
+func TestRSVPRepository_PromoteWaitlisted_OldestFirst(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+	capacity := 1
+
+	for _, userID := range []string{"did:plc:a1", "did:plc:a2", "did:plc:a3", "did:plc:a4"} {
+		_, _ = repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: userID, Status: RSVPStatusGoing}, &capacity)
+	}
+
+	// Nothing to promote while the event is full
+	promoted, err := repo.PromoteWaitlisted("event-1", &capacity)
+	if err != nil {
+		t.Fatalf("PromoteWaitlisted failed: %v", err)
+	}
+	if len(promoted) != 0 {
+		t.Fatalf("expected no promotions at capacity, got %d", len(promoted))
+	}
+
+	if err := repo.Delete("event-1", "did:plc:a1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	promoted, err = repo.PromoteWaitlisted("event-1", &capacity)
+	if err != nil {
+		t.Fatalf("PromoteWaitlisted failed: %v", err)
+	}
+	if len(promoted) != 1 || promoted[0].UserID != "did:plc:a2" {
+		t.Fatalf("expected did:plc:a2 to be promoted, got %+v", promoted)
+	}
+	if promoted[0].Status != RSVPStatusGoing || promoted[0].WaitlistedAt != nil {
+		t.Errorf("expected promoted RSVP to be going without a waitlist timestamp, got %+v", promoted[0])
+	}
+
+	position, _ := repo.WaitlistPosition("event-1", "did:plc:a3")
+	if position != 1 {
+		t.Errorf("expected did:plc:a3 to move up to position 1, got %d", position)
+	}
+
+	// Removing the cap promotes the rest of the waitlist in order
+	promoted, err = repo.PromoteWaitlisted("event-1", nil)
+	if err != nil {
+		t.Fatalf("PromoteWaitlisted failed: %v", err)
+	}
+	if len(promoted) != 2 || promoted[0].UserID != "did:plc:a3" || promoted[1].UserID != "did:plc:a4" {
+		t.Errorf("expected did:plc:a3 then did:plc:a4 to be promoted, got %+v", promoted)
+	}
+}
+
+func TestRSVPRepository_LoweredCapacity_KeepsAttendees(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+	capacity := 3
+
+	for _, userID := range []string{"did:plc:a1", "did:plc:a2", "did:plc:a3"} {
+		_, _ = repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: userID, Status: RSVPStatusGoing}, &capacity)
+	}
+
+	lowered := 1
+	promoted, err := repo.PromoteWaitlisted("event-1", &lowered)
+	if err != nil {
+		t.Fatalf("PromoteWaitlisted failed: %v", err)
+	}
+	if len(promoted) != 0 {
+		t.Errorf("expected no promotions over capacity, got %d", len(promoted))
+	}
+
+	if _, err := repo.UpsertWithCapacity(&RSVP{EventID: "event-1", UserID: "did:plc:a4", Status: RSVPStatusGoing}, &lowered); err != nil {
+		t.Fatalf("UpsertWithCapacity failed: %v", err)
+	}
+
+	counts, _ := repo.GetCountsByEvent("event-1")
+	if counts.Going != 3 || counts.Waitlisted != 1 {
+		t.Errorf("expected 3 going and 1 waitlisted after lowering capacity, got %+v", counts)
+	}
+
+	// A freed place is not refilled while attendance is still over capacity
+	_ = repo.Delete("event-1", "did:plc:a1")
+	promoted, _ = repo.PromoteWaitlisted("event-1", &lowered)
+	if len(promoted) != 0 {
+		t.Errorf("expected no promotions while over capacity, got %d", len(promoted))
+	}
+}
+
+func TestRSVPRepository_WaitlistPosition_NotFound(t *testing.T) {
+	repo := NewInMemoryRSVPRepository()
+
+	if _, err := repo.WaitlistPosition("event-1", "did:plc:nobody"); err != ErrRSVPNotFound {
+		t.Errorf("expected ErrRSVPNotFound, got %v", err)
+	}
+}
@@ -963,7 +963,7 @@ func TestRSVPRepository_GetCountsForEvents_BatchQuery(t *testing.T) {
 
 	// Insert all RSVPs
 	for _, rsvp := range append(rsvps1, rsvps2...) {
-		if err := repo.Upsert(rsvp); err != nil {
+		if _, err := repo.Upsert(rsvp); err != nil {
 			t.Fatalf("Upsert failed: %v", err)
 		}
 	}
@@ -0,0 +1,28 @@
+package scene
+
+import "strings"
+
+// Placeholders substituted into a scene's welcome template.
+const (
+	WelcomePlaceholderMember = "{member}" // The new member's DID
+	WelcomePlaceholderScene  = "{scene}"  // The scene's name
+)
+
+// MaxWelcomeTemplateLength is the maximum length of a scene's welcome template
+// in characters.
+const MaxWelcomeTemplateLength = 1000
+
+// RenderWelcome fills in a scene's welcome template for a new member, returning
+// "" if the scene has no template. Placeholders are replaced in a single pass,
+// so placeholders inside the substituted values are never expanded; any other
+// text in braces is left as written.
+func RenderWelcome(s *Scene, memberDID string) string {
+	template := strings.TrimSpace(s.WelcomePostTemplate)
+	if template == "" {
+		return ""
+	}
+	return strings.NewReplacer(
+		WelcomePlaceholderMember, memberDID,
+		WelcomePlaceholderScene, s.Name,
+	).Replace(template)
+}
@@ -0,0 +1,65 @@
+package scene
+
+import "testing"
+
+func TestRenderWelcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		sceneName string
+		member    string
+		want      string
+	}{
+		{
+			name:      "substitutes placeholders",
+			template:  "Welcome to {scene}, {member}!",
+			sceneName: "Night Owls",
+			member:    "did:plc:newbie",
+			want:      "Welcome to Night Owls, did:plc:newbie!",
+		},
+		{
+			name:      "repeated placeholders",
+			template:  "{member} {member}",
+			sceneName: "Night Owls",
+			member:    "did:plc:newbie",
+			want:      "did:plc:newbie did:plc:newbie",
+		},
+		{
+			name:      "substituted values are not expanded",
+			template:  "Welcome to {scene}",
+			sceneName: "{member}",
+			member:    "did:plc:newbie",
+			want:      "Welcome to {member}",
+		},
+		{
+			name:      "unknown placeholders left as written",
+			template:  "Hi {name}, see {{.Secret}}",
+			sceneName: "Night Owls",
+			member:    "did:plc:newbie",
+			want:      "Hi {name}, see {{.Secret}}",
+		},
+		{
+			name:      "empty template",
+			template:  "",
+			sceneName: "Night Owls",
+			member:    "did:plc:newbie",
+			want:      "",
+		},
+		{
+			name:      "whitespace-only template",
+			template:  "   \n\t",
+			sceneName: "Night Owls",
+			member:    "did:plc:newbie",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scene{Name: tt.sceneName, WelcomePostTemplate: tt.template}
+			if got := RenderWelcome(s, tt.member); got != tt.want {
+				t.Errorf("RenderWelcome() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Package selfcheck verifies critical dependencies at startup so misconfiguration
+// fails fast instead of surfacing on the first request.
+package selfcheck
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/onnwee/subcults/internal/ranking"
+)
+
+// DefaultTimeout bounds each individual check when Deps.Timeout is unset.
+const DefaultTimeout = 5 * time.Second
+
+// Check names reported in failures.
+const (
+	CheckDatabase    = "database"
+	CheckLiveKit     = "livekit"
+	CheckCalibration = "calibration"
+)
+
+// Pinger is satisfied by *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// CredentialValidator is satisfied by *livekit.RoomService.
+type CredentialValidator interface {
+	ValidateCredentials(ctx context.Context) error
+}
+
+// Deps lists the dependencies to verify. Nil or empty fields are skipped, so each
+// server only passes what it actually uses.
+type Deps struct {
+	// Env is the deployment environment; "production" or "prod" turns failures into a startup error.
+	Env string
+
+	DB              Pinger
+	LiveKit         CredentialValidator
+	CalibrationPath string
+
+	Timeout time.Duration // Per-check timeout, defaults to DefaultTimeout
+	Logger  *slog.Logger  // Defaults to slog.Default()
+}
+
+// Failure records a single failed check.
+type Failure struct {
+	Check string
+	Err   error
+}
+
+// Error aggregates every failed check into one startup error.
+type Error struct {
+	Failures []Failure
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	parts := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		parts[i] = fmt.Sprintf("%s: %v", f.Check, f.Err)
+	}
+	return fmt.Sprintf("startup self-check failed (%d): %s", len(e.Failures), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the underlying check errors for errors.Is and errors.As.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// Run executes every configured check and returns the failures found.
+// In production any failure also yields an *Error so the caller can abort startup;
+// in other environments failures are logged as warnings and the error is nil.
+func Run(ctx context.Context, deps Deps) ([]Failure, error) {
+	logger := deps.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	timeout := deps.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	var failures []Failure
+	check := func(name string, fn func(ctx context.Context) error) {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if err := fn(checkCtx); err != nil {
+			failures = append(failures, Failure{Check: name, Err: err})
+		}
+	}
+
+	if deps.DB != nil {
+		check(CheckDatabase, deps.DB.PingContext)
+	}
+	if deps.LiveKit != nil {
+		check(CheckLiveKit, deps.LiveKit.ValidateCredentials)
+	}
+	if deps.CalibrationPath != "" {
+		check(CheckCalibration, func(context.Context) error {
+			_, err := ranking.LoadCalibration(deps.CalibrationPath)
+			return err
+		})
+	}
+
+	if len(failures) == 0 {
+		logger.Info("startup self-check passed")
+		return nil, nil
+	}
+
+	if isProduction(deps.Env) {
+		return failures, &Error{Failures: failures}
+	}
+
+	for _, f := range failures {
+		logger.Warn("startup self-check failed", "check", f.Check, "error", f.Err)
+	}
+	return failures, nil
+}
+
+// isProduction matches the environment names used by the profiling guard.
+func isProduction(env string) bool {
+	return env == "production" || env == "prod"
+}
@@ -0,0 +1,105 @@
+package selfcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type fakeDB struct{ err error }
+
+func (f fakeDB) PingContext(context.Context) error { return f.err }
+
+type fakeLiveKit struct{ err error }
+
+func (f fakeLiveKit) ValidateCredentials(context.Context) error { return f.err }
+
+func quietLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func writeCalibration(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "calibration.json")
+	content := `{"weights": {
+		"scene": {"text_match": 0.6, "proximity": 0.3, "trust": 0.1},
+		"event": {"recency": 0.3, "text_match": 0.4, "proximity": 0.2, "trust": 0.1}
+	}}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write calibration: %v", err)
+	}
+	return path
+}
+
+func TestRun_Passes(t *testing.T) {
+	failures, err := Run(context.Background(), Deps{
+		Env:             "production",
+		DB:              fakeDB{},
+		LiveKit:         fakeLiveKit{},
+		CalibrationPath: writeCalibration(t),
+		Logger:          quietLogger(),
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(failures) != 0 {
+		t.Errorf("expected no failures, got %+v", failures)
+	}
+}
+
+func TestRun_FailingDBAbortsInProduction(t *testing.T) {
+	pingErr := errors.New("connection refused")
+
+	failures, err := Run(context.Background(), Deps{
+		Env:     "production",
+		DB:      fakeDB{err: pingErr},
+		LiveKit: fakeLiveKit{err: errors.New("unauthorized")},
+		Logger:  quietLogger(),
+	})
+	if err == nil {
+		t.Fatal("expected error in production")
+	}
+	if !errors.Is(err, pingErr) {
+		t.Errorf("expected aggregated error to wrap the ping error, got %v", err)
+	}
+
+	var selfcheckErr *Error
+	if !errors.As(err, &selfcheckErr) {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if len(selfcheckErr.Failures) != 2 || len(failures) != 2 {
+		t.Fatalf("expected 2 aggregated failures, got %d", len(selfcheckErr.Failures))
+	}
+	if failures[0].Check != CheckDatabase || failures[1].Check != CheckLiveKit {
+		t.Errorf("unexpected failure order: %+v", failures)
+	}
+}
+
+func TestRun_WarnsOnlyInDevelopment(t *testing.T) {
+	failures, err := Run(context.Background(), Deps{
+		Env:             "development",
+		DB:              fakeDB{err: errors.New("connection refused")},
+		CalibrationPath: filepath.Join(t.TempDir(), "missing.json"),
+		Logger:          quietLogger(),
+	})
+	if err != nil {
+		t.Fatalf("expected no error in development, got %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("expected 2 warnings, got %+v", failures)
+	}
+	if failures[1].Check != CheckCalibration {
+		t.Errorf("expected calibration failure, got %s", failures[1].Check)
+	}
+}
+
+func TestRun_SkipsUnconfiguredDeps(t *testing.T) {
+	failures, err := Run(context.Background(), Deps{Env: "production", Logger: quietLogger()})
+	if err != nil || len(failures) != 0 {
+		t.Errorf("expected nothing to check, got failures=%+v err=%v", failures, err)
+	}
+}
@@ -0,0 +1,115 @@
+// Package slo tracks latency service-level objectives over a rolling window,
+// so alerts can fire on error budget burn rather than raw latency.
+package slo
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metric names as constants for consistency.
+const (
+	MetricLatencyBudgetBurn = "slo_latency_budget_burn"
+)
+
+// DefaultWindow is the rolling window over which budget burn is computed.
+const DefaultWindow = 5 * time.Minute
+
+// bucketsPerWindow is how many time buckets the window is split into. Older
+// buckets fall out of the window one at a time, so the burn figure moves
+// smoothly instead of resetting all at once.
+const bucketsPerWindow = 30
+
+// bucket counts requests observed during one slice of the window.
+type bucket struct {
+	epoch int64 // Index of the slice since the Unix epoch; stale buckets are reset on reuse
+	total uint64
+	slow  uint64
+}
+
+// Tracker records request latencies against a target and reports the fraction
+// of requests in the rolling window that exceeded it. Thread-safe.
+type Tracker struct {
+	target  time.Duration
+	width   time.Duration // Duration covered by one bucket
+	mu      sync.Mutex
+	buckets [bucketsPerWindow]bucket
+	burn    prometheus.GaugeFunc
+	timeNow func() time.Time // For testability
+}
+
+// NewTracker creates a tracker for the named SLO. Requests slower than target
+// consume error budget; burn is computed over window. A non-positive window
+// uses DefaultWindow.
+func NewTracker(name string, target, window time.Duration) *Tracker {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	width := window / bucketsPerWindow
+	if width <= 0 {
+		width = 1
+	}
+	t := &Tracker{
+		target:  target,
+		width:   width,
+		timeNow: time.Now,
+	}
+	t.burn = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        MetricLatencyBudgetBurn,
+		Help:        "Fraction of requests in the rolling SLO window that exceeded the target latency",
+		ConstLabels: prometheus.Labels{"slo": name},
+	}, t.BurnFraction)
+	return t
+}
+
+// Register registers the tracker's budget burn gauge with the given registry.
+// Trackers with different names can share a registry.
+func (t *Tracker) Register(reg prometheus.Registerer) error {
+	return reg.Register(t.burn)
+}
+
+// Observe records one request's latency.
+func (t *Tracker) Observe(latency time.Duration) {
+	epoch := t.timeNow().UnixNano() / int64(t.width)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b := &t.buckets[epoch%bucketsPerWindow]
+	if b.epoch != epoch {
+		*b = bucket{epoch: epoch}
+	}
+	b.total++
+	if latency > t.target {
+		b.slow++
+	}
+}
+
+// ObserveSince records the latency of a request that started at start.
+// Intended for use with defer at the top of a handler.
+func (t *Tracker) ObserveSince(start time.Time) {
+	t.Observe(t.timeNow().Sub(start))
+}
+
+// BurnFraction returns the fraction of requests in the rolling window that
+// exceeded the target, or 0 if there were none.
+func (t *Tracker) BurnFraction() float64 {
+	epoch := t.timeNow().UnixNano() / int64(t.width)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var total, slow uint64
+	for _, b := range t.buckets {
+		if b.epoch > epoch-bucketsPerWindow && b.epoch <= epoch {
+			total += b.total
+			slow += b.slow
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(slow) / float64(total)
+}
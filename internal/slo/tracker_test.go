@@ -0,0 +1,133 @@
+package slo
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newTestTracker creates a tracker with a 300ms target over a 5 minute window
+// and a controllable clock.
+func newTestTracker() (*Tracker, *time.Time) {
+	tracker := NewTracker("search", 300*time.Millisecond, 5*time.Minute)
+	now := time.Unix(1_700_000_000, 0)
+	tracker.timeNow = func() time.Time { return now }
+	return tracker, &now
+}
+
+func TestTracker_BurnFraction(t *testing.T) {
+	tests := []struct {
+		name      string
+		latencies []time.Duration
+		want      float64
+	}{
+		{name: "no requests", want: 0},
+		{name: "all within target", latencies: []time.Duration{10 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond}, want: 0},
+		{name: "target itself is within budget", latencies: []time.Duration{300 * time.Millisecond}, want: 0},
+		{name: "all over target", latencies: []time.Duration{301 * time.Millisecond, time.Second}, want: 1},
+		{
+			name: "one in four over target",
+			latencies: []time.Duration{
+				50 * time.Millisecond, 120 * time.Millisecond, 200 * time.Millisecond, 800 * time.Millisecond,
+			},
+			want: 0.25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker, _ := newTestTracker()
+			for _, latency := range tt.latencies {
+				tracker.Observe(latency)
+			}
+			if got := tracker.BurnFraction(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("BurnFraction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTracker_RollingWindow(t *testing.T) {
+	tracker, now := newTestTracker()
+
+	// A burst of slow requests...
+	for i := 0; i < 10; i++ {
+		tracker.Observe(time.Second)
+	}
+
+	// ...followed three minutes later by fast ones, still inside the window
+	*now = now.Add(3 * time.Minute)
+	for i := 0; i < 30; i++ {
+		tracker.Observe(50 * time.Millisecond)
+	}
+	if got := tracker.BurnFraction(); math.Abs(got-0.25) > 1e-9 {
+		t.Errorf("BurnFraction() = %v, want 0.25 with both batches in the window", got)
+	}
+
+	// Once the slow burst ages out only the fast requests remain
+	*now = now.Add(2*time.Minute + time.Second)
+	if got := tracker.BurnFraction(); got != 0 {
+		t.Errorf("BurnFraction() = %v, want 0 after the slow burst left the window", got)
+	}
+
+	// And with everything aged out there is nothing to burn
+	*now = now.Add(5 * time.Minute)
+	if got := tracker.BurnFraction(); got != 0 {
+		t.Errorf("BurnFraction() = %v, want 0 with an empty window", got)
+	}
+}
+
+func TestTracker_ReusedBucketIsReset(t *testing.T) {
+	tracker, now := newTestTracker()
+
+	tracker.Observe(time.Second)
+
+	// Exactly one window later the same ring slot is reused
+	*now = now.Add(5 * time.Minute)
+	tracker.Observe(10 * time.Millisecond)
+
+	if got := tracker.BurnFraction(); got != 0 {
+		t.Errorf("BurnFraction() = %v, want 0 after the stale bucket was reset", got)
+	}
+}
+
+func TestTracker_ObserveSince(t *testing.T) {
+	tracker, now := newTestTracker()
+
+	start := *now
+	*now = now.Add(400 * time.Millisecond)
+	tracker.ObserveSince(start)
+
+	if got := tracker.BurnFraction(); got != 1 {
+		t.Errorf("BurnFraction() = %v, want 1 for a request over target", got)
+	}
+}
+
+func TestTracker_Register(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	search, _ := newTestTracker()
+	feed := NewTracker("feed", 250*time.Millisecond, DefaultWindow)
+
+	if err := search.Register(reg); err != nil {
+		t.Fatalf("Register(search) failed: %v", err)
+	}
+	if err := feed.Register(reg); err != nil {
+		t.Fatalf("Register(feed) failed: %v", err)
+	}
+
+	search.Observe(time.Second)
+	search.Observe(time.Millisecond)
+
+	if got := testutil.ToFloat64(search.burn); got != 0.5 {
+		t.Errorf("search gauge = %v, want 0.5", got)
+	}
+	if got := testutil.ToFloat64(feed.burn); got != 0 {
+		t.Errorf("feed gauge = %v, want 0", got)
+	}
+	if count := testutil.CollectAndCount(reg, MetricLatencyBudgetBurn); count != 2 {
+		t.Errorf("expected 2 %s series, got %d", MetricLatencyBudgetBurn, count)
+	}
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// InMemoryStore is an in-memory implementation of Store.
+// Used for testing and development. Thread-safe via RWMutex.
+type InMemoryStore struct {
+	mu      sync.RWMutex
+	objects map[string]*ObjectInfo
+	timeNow func() time.Time // For testability
+}
+
+// NewInMemoryStore creates a new in-memory object store.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		objects: make(map[string]*ObjectInfo),
+		timeNow: time.Now,
+	}
+}
+
+// Put records an object as uploaded, simulating a client PUT to a presigned URL.
+func (s *InMemoryStore) Put(key, contentType string, sizeBytes int64) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[key] = &ObjectInfo{
+		Key:          key,
+		ContentType:  contentType,
+		SizeBytes:    sizeBytes,
+		LastModified: s.timeNow(),
+	}
+	return nil
+}
+
+// Stat returns metadata for the object at key.
+func (s *InMemoryStore) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	obj, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	infoCopy := *obj
+	return &infoCopy, nil
+}
+
+// PresignUpload returns a fake upload URL for the object at key.
+func (s *InMemoryStore) PresignUpload(ctx context.Context, key, contentType string, sizeBytes int64, expiry time.Duration) (*PresignedURL, error) {
+	return s.presign(key, http.MethodPut, expiry)
+}
+
+// PresignDownload returns a fake download URL for the object at key.
+// Returns ErrNotFound if the object has not been uploaded.
+func (s *InMemoryStore) PresignDownload(ctx context.Context, key string, expiry time.Duration) (*PresignedURL, error) {
+	if _, err := s.Stat(ctx, key); err != nil {
+		return nil, err
+	}
+	return s.presign(key, http.MethodGet, expiry)
+}
+
+// Delete removes the object at key.
+func (s *InMemoryStore) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, key)
+	return nil
+}
+
+// presign builds a deterministic memory:// URL for the key and method.
+func (s *InMemoryStore) presign(key, method string, expiry time.Duration) (*PresignedURL, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	expiresAt := s.timeNow().Add(expiry)
+	u := url.URL{
+		Scheme:   "memory",
+		Host:     "store",
+		Path:     "/" + key,
+		RawQuery: fmt.Sprintf("method=%s&expires=%d", method, expiresAt.Unix()),
+	}
+	return &PresignedURL{
+		URL:       u.String(),
+		Key:       key,
+		Method:    method,
+		ExpiresAt: expiresAt,
+	}, nil
+}
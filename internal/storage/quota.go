@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is returned when a reservation would push a scene past its daily upload quota.
+var ErrQuotaExceeded = errors.New("daily upload quota exceeded")
+
+// QuotaTracker tracks attachment bytes committed per scene per UTC day.
+// Implementations must be safe for concurrent use.
+type QuotaTracker interface {
+	// Reserve atomically adds sizeBytes to the scene's usage for the current day.
+	// Returns ErrQuotaExceeded, leaving usage unchanged, if the total would exceed limit.
+	Reserve(sceneID string, sizeBytes, limit int64) error
+
+	// Release returns previously reserved bytes, e.g. when the post they were
+	// reserved for fails to persist. Usage never drops below zero.
+	Release(sceneID string, sizeBytes int64)
+
+	// Usage returns the bytes reserved for the scene on the current day.
+	Usage(sceneID string) int64
+}
+
+// quotaKey identifies one scene's usage bucket for one UTC day.
+type quotaKey struct {
+	sceneID string
+	day     string
+}
+
+// InMemoryQuotaTracker is an in-memory implementation of QuotaTracker.
+// Buckets from previous days are dropped lazily on the next reservation.
+type InMemoryQuotaTracker struct {
+	mu      sync.Mutex
+	usage   map[quotaKey]int64
+	timeNow func() time.Time // For testability
+}
+
+// NewInMemoryQuotaTracker creates a new in-memory quota tracker.
+func NewInMemoryQuotaTracker() *InMemoryQuotaTracker {
+	return &InMemoryQuotaTracker{
+		usage:   make(map[quotaKey]int64),
+		timeNow: time.Now,
+	}
+}
+
+// Reserve atomically adds sizeBytes to the scene's usage for the current UTC day.
+func (t *InMemoryQuotaTracker) Reserve(sceneID string, sizeBytes, limit int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := t.keyFor(sceneID)
+	for k := range t.usage {
+		if k.day != key.day {
+			delete(t.usage, k)
+		}
+	}
+
+	if t.usage[key]+sizeBytes > limit {
+		return ErrQuotaExceeded
+	}
+	t.usage[key] += sizeBytes
+	return nil
+}
+
+// Release returns previously reserved bytes for the current UTC day.
+func (t *InMemoryQuotaTracker) Release(sceneID string, sizeBytes int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := t.keyFor(sceneID)
+	remaining := t.usage[key] - sizeBytes
+	if remaining <= 0 {
+		delete(t.usage, key)
+		return
+	}
+	t.usage[key] = remaining
+}
+
+// Usage returns the bytes reserved for the scene on the current UTC day.
+func (t *InMemoryQuotaTracker) Usage(sceneID string) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.usage[t.keyFor(sceneID)]
+}
+
+// keyFor returns the usage bucket for the scene on the current UTC day.
+func (t *InMemoryQuotaTracker) keyFor(sceneID string) quotaKey {
+	return quotaKey{sceneID: sceneID, day: t.timeNow().UTC().Format("2006-01-02")}
+}
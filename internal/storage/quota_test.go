@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInMemoryQuotaTracker_Reserve(t *testing.T) {
+	tracker := NewInMemoryQuotaTracker()
+	now := time.Date(2024, 1, 15, 23, 0, 0, 0, time.UTC)
+	tracker.timeNow = func() time.Time { return now }
+
+	if err := tracker.Reserve("scene-1", 600, 1000); err != nil {
+		t.Fatalf("Reserve failed: %v", err)
+	}
+	if err := tracker.Reserve("scene-1", 500, 1000); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected ErrQuotaExceeded, got %v", err)
+	}
+	if got := tracker.Usage("scene-1"); got != 600 {
+		t.Errorf("expected rejected reservation to leave usage at 600, got %d", got)
+	}
+
+	// Other scenes have their own budget
+	if err := tracker.Reserve("scene-2", 1000, 1000); err != nil {
+		t.Errorf("expected scene-2 reservation at the limit to pass, got %v", err)
+	}
+
+	tracker.Release("scene-1", 600)
+	if got := tracker.Usage("scene-1"); got != 0 {
+		t.Errorf("expected usage 0 after release, got %d", got)
+	}
+
+	// A new UTC day starts a fresh budget
+	if err := tracker.Reserve("scene-2", 1, 1000); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected scene-2 to be exhausted today, got %v", err)
+	}
+	now = now.Add(2 * time.Hour)
+	if err := tracker.Reserve("scene-2", 1000, 1000); err != nil {
+		t.Errorf("expected fresh quota on the next day, got %v", err)
+	}
+}
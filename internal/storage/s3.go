@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3API abstracts the S3 object operations used by S3Store for testability.
+type S3API interface {
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// S3Presigner abstracts S3 request presigning for testability.
+type S3Presigner interface {
+	PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+// S3Store is a Store backed by an S3-compatible bucket (e.g. Cloudflare R2).
+type S3Store struct {
+	client     S3API
+	presigner  S3Presigner
+	bucketName string
+	timeNow    func() time.Time // For testability
+}
+
+// NewS3Store creates a Store for the given bucket using an existing S3 client.
+func NewS3Store(client *s3.Client, bucketName string) (*S3Store, error) {
+	if client == nil {
+		return nil, errors.New("s3 client is required")
+	}
+	return NewS3StoreWithPresigner(client, s3.NewPresignClient(client), bucketName)
+}
+
+// NewS3StoreWithPresigner creates a Store from explicit API and presigner implementations.
+func NewS3StoreWithPresigner(client S3API, presigner S3Presigner, bucketName string) (*S3Store, error) {
+	if client == nil {
+		return nil, errors.New("s3 client is required")
+	}
+	if presigner == nil {
+		return nil, errors.New("presigner is required")
+	}
+	if bucketName == "" {
+		return nil, errors.New("bucket name is required")
+	}
+	return &S3Store{
+		client:     client,
+		presigner:  presigner,
+		bucketName: bucketName,
+		timeNow:    time.Now,
+	}, nil
+}
+
+// Stat returns metadata for the object at key via HeadObject.
+func (s *S3Store) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	info := &ObjectInfo{Key: key}
+	if out.ContentType != nil {
+		info.ContentType = *out.ContentType
+	}
+	if out.ContentLength != nil {
+		info.SizeBytes = *out.ContentLength
+	}
+	if out.LastModified != nil {
+		info.LastModified = *out.LastModified
+	}
+	return info, nil
+}
+
+// PresignUpload returns a presigned PUT URL for the object at key.
+func (s *S3Store) PresignUpload(ctx context.Context, key, contentType string, sizeBytes int64, expiry time.Duration) (*PresignedURL, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	req, err := s.presigner.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucketName),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(sizeBytes),
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = expiry
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign upload: %w", err)
+	}
+
+	return &PresignedURL{
+		URL:       req.URL,
+		Key:       key,
+		Method:    http.MethodPut,
+		ExpiresAt: s.timeNow().Add(expiry),
+	}, nil
+}
+
+// PresignDownload returns a presigned GET URL for the object at key.
+func (s *S3Store) PresignDownload(ctx context.Context, key string, expiry time.Duration) (*PresignedURL, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	req, err := s.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = expiry
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign download: %w", err)
+	}
+
+	return &PresignedURL{
+		URL:       req.URL,
+		Key:       key,
+		Method:    http.MethodGet,
+		ExpiresAt: s.timeNow().Add(expiry),
+	}, nil
+}
+
+// Delete removes the object at key. S3 treats deletes of missing keys as success.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil && !isS3NotFound(err) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// isS3NotFound reports whether err indicates a missing object.
+func isS3NotFound(err error) bool {
+	var notFound *types.NotFound
+	var noSuchKey *types.NoSuchKey
+	return errors.As(err, &notFound) || errors.As(err, &noSuchKey)
+}
@@ -0,0 +1,89 @@
+// Package storage provides a pluggable object-storage abstraction for
+// post attachments and other user-uploaded media.
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"time"
+)
+
+// Common errors for storage operations.
+var (
+	ErrNotFound            = errors.New("object not found")
+	ErrInvalidKey          = errors.New("invalid object key")
+	ErrKeyOutsideNamespace = errors.New("object key outside caller namespace")
+)
+
+// AttachmentKeyPrefix is the root prefix for post attachment objects.
+const AttachmentKeyPrefix = "attachments"
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key          string
+	ContentType  string
+	SizeBytes    int64
+	LastModified time.Time
+}
+
+// PresignedURL is a time-limited URL granting direct access to a single object.
+type PresignedURL struct {
+	URL       string
+	Key       string
+	Method    string
+	ExpiresAt time.Time
+}
+
+// Store abstracts object storage operations.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Stat returns metadata for the object at key.
+	// Returns ErrNotFound if the object does not exist.
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+
+	// PresignUpload returns a URL that allows a single PUT of an object at key
+	// with the given content type and size, valid for expiry.
+	PresignUpload(ctx context.Context, key, contentType string, sizeBytes int64, expiry time.Duration) (*PresignedURL, error)
+
+	// PresignDownload returns a URL that allows GET of the object at key, valid for expiry.
+	PresignDownload(ctx context.Context, key string, expiry time.Duration) (*PresignedURL, error)
+
+	// Delete removes the object at key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// NamespaceForDID returns the key prefix owned by the given DID.
+// The prefix always ends with "/" so that one DID can never be a prefix of another.
+func NamespaceForDID(did string) string {
+	return AttachmentKeyPrefix + "/" + did + "/"
+}
+
+// ValidateKey checks that key is a clean, relative object key.
+// Rejects empty keys, absolute paths, and any ".." traversal segments.
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+		return ErrInvalidKey
+	}
+	if path.Clean(key) != key {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." || segment == "." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// ValidateKeyOwnership checks that key is valid and lies within the DID's namespace.
+func ValidateKeyOwnership(did, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	if did == "" || !strings.HasPrefix(key, NamespaceForDID(did)) {
+		return ErrKeyOutsideNamespace
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func TestValidateKeyOwnership(t *testing.T) {
+	did := "did:plc:alice"
+
+	tests := []struct {
+		name    string
+		did     string
+		key     string
+		wantErr error
+	}{
+		{"own namespace", did, "attachments/did:plc:alice/photo.jpg", nil},
+		{"other DID", did, "attachments/did:plc:bob/photo.jpg", ErrKeyOutsideNamespace},
+		{"DID prefix collision", did, "attachments/did:plc:alicex/photo.jpg", ErrKeyOutsideNamespace},
+		{"legacy posts prefix", did, "posts/temp/photo.jpg", ErrKeyOutsideNamespace},
+		{"traversal", did, "attachments/did:plc:alice/../did:plc:bob/photo.jpg", ErrInvalidKey},
+		{"absolute", did, "/attachments/did:plc:alice/photo.jpg", ErrInvalidKey},
+		{"empty", did, "", ErrInvalidKey},
+		{"no DID", "", "attachments//photo.jpg", ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateKeyOwnership(tt.did, tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateKeyOwnership(%q, %q) = %v, want %v", tt.did, tt.key, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInMemoryStore_PresignAndStat(t *testing.T) {
+	store := NewInMemoryStore()
+	ctx := context.Background()
+	key := NamespaceForDID("did:plc:alice") + "photo.jpg"
+
+	presigned, err := store.PresignUpload(ctx, key, "image/jpeg", 1024, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignUpload failed: %v", err)
+	}
+	if presigned.Method != http.MethodPut {
+		t.Errorf("expected PUT method, got %s", presigned.Method)
+	}
+	if !strings.Contains(presigned.URL, key) {
+		t.Errorf("expected URL to contain key, got %s", presigned.URL)
+	}
+
+	// Not uploaded yet
+	if _, err := store.Stat(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before upload, got %v", err)
+	}
+	if _, err := store.PresignDownload(ctx, key, time.Minute); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for download before upload, got %v", err)
+	}
+
+	// Simulate client upload
+	if err := store.Put(key, "image/jpeg", 1024); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	info, err := store.Stat(ctx, key)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.ContentType != "image/jpeg" || info.SizeBytes != 1024 {
+		t.Errorf("unexpected object info: %+v", info)
+	}
+
+	download, err := store.PresignDownload(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatalf("PresignDownload failed: %v", err)
+	}
+	if download.Method != http.MethodGet {
+		t.Errorf("expected GET method, got %s", download.Method)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Stat(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestS3Store_PresignUpload(t *testing.T) {
+	client := s3.New(s3.Options{
+		Region:       "auto",
+		Credentials:  aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider("test-key", "test-secret", "")),
+		BaseEndpoint: aws.String("https://test.r2.cloudflarestorage.com"),
+		UsePathStyle: true,
+	})
+	store, err := NewS3Store(client, "test-bucket")
+	if err != nil {
+		t.Fatalf("NewS3Store failed: %v", err)
+	}
+
+	fixedNow := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	store.timeNow = func() time.Time { return fixedNow }
+
+	key := NamespaceForDID("did:plc:alice") + "photo.jpg"
+	presigned, err := store.PresignUpload(context.Background(), key, "image/jpeg", 2048, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignUpload failed: %v", err)
+	}
+
+	if !strings.Contains(presigned.URL, "test-bucket") {
+		t.Errorf("expected URL to reference bucket, got %s", presigned.URL)
+	}
+	if !strings.Contains(presigned.URL, "X-Amz-Signature") {
+		t.Errorf("expected signed URL, got %s", presigned.URL)
+	}
+	if !presigned.ExpiresAt.Equal(fixedNow.Add(5 * time.Minute)) {
+		t.Errorf("expected expiry %v, got %v", fixedNow.Add(5*time.Minute), presigned.ExpiresAt)
+	}
+
+	if _, err := store.PresignUpload(context.Background(), "../escape.jpg", "image/jpeg", 1, time.Minute); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("expected ErrInvalidKey for traversal key, got %v", err)
+	}
+}
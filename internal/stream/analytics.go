@@ -40,6 +40,11 @@ type Analytics struct {
 	AvgListenDurationSeconds    *float64 `json:"avg_listen_duration_seconds,omitempty"`
 	MedianListenDurationSeconds *float64 `json:"median_listen_duration_seconds,omitempty"`
 
+	// Per-participant watch time: each participant's join/leave intervals summed
+	// across reconnections, with anyone still connected leaving at stream end
+	AvgWatchTimeSeconds    *float64 `json:"avg_watch_time_seconds,omitempty"`
+	MedianWatchTimeSeconds *float64 `json:"median_watch_time_seconds,omitempty"`
+
 	// Geographic distribution (privacy-safe aggregate)
 	// Map of 4-char geohash prefix -> count
 	GeographicDistribution map[string]int `json:"geographic_distribution"`
@@ -53,6 +58,10 @@ type AnalyticsRepository interface {
 	// geohashPrefix should be a 4-character prefix for privacy-safe geographic tracking (optional).
 	RecordParticipantEvent(streamSessionID, participantDID, eventType string, geohashPrefix *string) error
 
+	// RecordParticipantEventAt records a join or leave event that occurred at
+	// occurredAt, e.g. a leave whose recording was held back by the join debouncer.
+	RecordParticipantEventAt(streamSessionID, participantDID, eventType string, geohashPrefix *string, occurredAt time.Time) error
+
 	// GetParticipantEvents retrieves all participant events for a stream session, ordered by occurred_at.
 	GetParticipantEvents(streamSessionID string) ([]*ParticipantEvent, error)
 
@@ -60,6 +69,11 @@ type AnalyticsRepository interface {
 	// Should be called when a stream ends. Returns the computed analytics.
 	ComputeAnalytics(streamSessionID string) (*Analytics, error)
 
+	// RecomputeAnalytics recalculates analytics for a stream session, storing the
+	// result only if its metrics differ from the stored analytics. Returns the
+	// current analytics and whether they changed. Safe to retry.
+	RecomputeAnalytics(streamSessionID string) (*Analytics, bool, error)
+
 	// GetAnalytics retrieves the computed analytics for a stream session.
 	// Returns ErrAnalyticsNotFound if analytics have not been computed yet.
 	GetAnalytics(streamSessionID string) (*Analytics, error)
@@ -0,0 +1,94 @@
+package stream
+
+import (
+	"errors"
+	"time"
+)
+
+// Batch size limits for RecomputeAnalyticsWindow.
+const (
+	DefaultRecomputeBatchSize = 100
+	MaxRecomputeBatchSize     = 1000
+)
+
+// ErrInvalidRecomputeWindow is returned when a recompute window is missing a
+// bound or does not end after it starts.
+var ErrInvalidRecomputeWindow = errors.New("recompute window must have from before to")
+
+// RecomputeOptions selects one batch of ended streams to recompute analytics for.
+type RecomputeOptions struct {
+	From      time.Time // Streams that ended at or after From
+	To        time.Time // Streams that ended before To
+	BatchSize int       // Streams to examine in this batch; <= 0 uses DefaultRecomputeBatchSize
+	Cursor    string    // Checkpoint from a previous batch's NextCursor (optional)
+}
+
+// RecomputeFailure records a stream whose analytics could not be recomputed.
+type RecomputeFailure struct {
+	StreamSessionID string `json:"stream_session_id"`
+	Error           string `json:"error"`
+}
+
+// RecomputeProgress reports the outcome of one recompute batch.
+type RecomputeProgress struct {
+	Examined   int                // Ended streams examined in this batch, in or out of the window
+	Recomputed int                // Streams in the window whose stored analytics changed
+	Unchanged  int                // Streams in the window whose analytics were already correct
+	Failed     []RecomputeFailure // Streams in the window that failed; rerun the window to retry them
+	NextCursor string             // Checkpoint to resume from; empty when the window is done
+}
+
+// RecomputeAnalyticsWindow recomputes analytics for one batch of streams that
+// ended within [From, To), walking ended streams newest first by start time.
+// Pass the returned NextCursor back as Cursor to continue; the cursor is a
+// checkpoint, so an interrupted run resumes where the last completed batch
+// left off. Recomputation is idempotent: streams whose analytics are already
+// correct are left untouched, so rerunning a window or batch is safe.
+//
+// A stream that fails to recompute is reported in Failed and does not stop the
+// batch. Returns ErrInvalidRecomputeWindow for a bad window and ErrInvalidCursor
+// for a malformed cursor.
+func RecomputeAnalyticsWindow(sessions SessionRepository, analytics AnalyticsRepository, opts RecomputeOptions) (*RecomputeProgress, error) {
+	if opts.From.IsZero() || opts.To.IsZero() || !opts.From.Before(opts.To) {
+		return nil, ErrInvalidRecomputeWindow
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultRecomputeBatchSize
+	}
+	if batchSize > MaxRecomputeBatchSize {
+		batchSize = MaxRecomputeBatchSize
+	}
+
+	// A stream that ended before To must have started before it
+	page, nextCursor, err := sessions.ListStreams(ListOptions{
+		Status: StatusEnded,
+		To:     opts.To,
+		Limit:  batchSize,
+		Cursor: opts.Cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &RecomputeProgress{
+		Examined:   len(page),
+		Failed:     []RecomputeFailure{},
+		NextCursor: nextCursor,
+	}
+	for _, session := range page {
+		if session.EndedAt == nil || session.EndedAt.Before(opts.From) || !session.EndedAt.Before(opts.To) {
+			continue
+		}
+		_, changed, err := analytics.RecomputeAnalytics(session.ID)
+		switch {
+		case err != nil:
+			progress.Failed = append(progress.Failed, RecomputeFailure{StreamSessionID: session.ID, Error: err.Error()})
+		case changed:
+			progress.Recomputed++
+		default:
+			progress.Unchanged++
+		}
+	}
+	return progress, nil
+}
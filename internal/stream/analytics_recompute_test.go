@@ -0,0 +1,176 @@
+package stream
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newRecomputeTest creates ended streams that each ran for an hour and ended
+// at the given offsets from base, plus one still-active stream. It returns the
+// repositories and the ended stream IDs in the order given.
+func newRecomputeTest(t *testing.T, base time.Time, endOffsets ...time.Duration) (*InMemorySessionRepository, *InMemoryAnalyticsRepository, []string) {
+	t.Helper()
+
+	sessions := NewInMemorySessionRepository()
+	analytics := NewInMemoryAnalyticsRepository(sessions)
+	ids := make([]string, len(endOffsets))
+	for i, offset := range endOffsets {
+		endedAt := base.Add(offset)
+		result, err := sessions.Upsert(&Session{
+			RoomName:  "room",
+			HostDID:   "did:plc:host",
+			StartedAt: endedAt.Add(-time.Hour),
+			EndedAt:   &endedAt,
+		})
+		if err != nil {
+			t.Fatalf("failed to insert session: %v", err)
+		}
+		ids[i] = result.ID
+		if err := analytics.RecordParticipantEvent(result.ID, "did:plc:listener", "join", nil); err != nil {
+			t.Fatalf("failed to record join: %v", err)
+		}
+	}
+	if _, err := sessions.Upsert(&Session{RoomName: "live", HostDID: "did:plc:host", StartedAt: base}); err != nil {
+		t.Fatalf("failed to insert active session: %v", err)
+	}
+	return sessions, analytics, ids
+}
+
+func TestRecomputeAnalyticsWindow_SmallWindow(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sessions, analytics, ids := newRecomputeTest(t, base, -48*time.Hour, time.Hour, 2*time.Hour, 48*time.Hour)
+
+	progress, err := RecomputeAnalyticsWindow(sessions, analytics, RecomputeOptions{
+		From: base,
+		To:   base.Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("RecomputeAnalyticsWindow() error = %v", err)
+	}
+	if progress.Recomputed != 2 || progress.Unchanged != 0 || len(progress.Failed) != 0 {
+		t.Errorf("progress = %+v, want 2 recomputed", progress)
+	}
+	if progress.NextCursor != "" {
+		t.Errorf("NextCursor = %q, want empty once the window is done", progress.NextCursor)
+	}
+
+	for i, id := range ids {
+		_, err := analytics.GetAnalytics(id)
+		inWindow := i == 1 || i == 2
+		if inWindow && err != nil {
+			t.Errorf("stream %d: GetAnalytics() error = %v, want analytics", i, err)
+		}
+		if !inWindow && !errors.Is(err, ErrAnalyticsNotFound) {
+			t.Errorf("stream %d: GetAnalytics() error = %v, want ErrAnalyticsNotFound outside the window", i, err)
+		}
+	}
+}
+
+func TestRecomputeAnalyticsWindow_SkipsCorrectAnalytics(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sessions, analytics, ids := newRecomputeTest(t, base, time.Hour, 2*time.Hour)
+
+	correct, err := analytics.ComputeAnalytics(ids[0])
+	if err != nil {
+		t.Fatalf("ComputeAnalytics() error = %v", err)
+	}
+	stale, err := analytics.ComputeAnalytics(ids[1])
+	if err != nil {
+		t.Fatalf("ComputeAnalytics() error = %v", err)
+	}
+	// A late-recorded event leaves the second stream's analytics out of date
+	if err := analytics.RecordParticipantEvent(ids[1], "did:plc:late", "join", nil); err != nil {
+		t.Fatalf("failed to record join: %v", err)
+	}
+
+	opts := RecomputeOptions{From: base, To: base.Add(24 * time.Hour)}
+	progress, err := RecomputeAnalyticsWindow(sessions, analytics, opts)
+	if err != nil {
+		t.Fatalf("RecomputeAnalyticsWindow() error = %v", err)
+	}
+	if progress.Recomputed != 1 || progress.Unchanged != 1 {
+		t.Errorf("progress = %+v, want 1 recomputed and 1 unchanged", progress)
+	}
+
+	got, err := analytics.GetAnalytics(ids[0])
+	if err != nil {
+		t.Fatalf("GetAnalytics() error = %v", err)
+	}
+	if got.ID != correct.ID || !got.ComputedAt.Equal(correct.ComputedAt) {
+		t.Error("expected already-correct analytics to be left untouched")
+	}
+	got, err = analytics.GetAnalytics(ids[1])
+	if err != nil {
+		t.Fatalf("GetAnalytics() error = %v", err)
+	}
+	if got.ID == stale.ID || got.TotalUniqueParticipants != 2 {
+		t.Errorf("expected stale analytics to be replaced, got %+v", got)
+	}
+
+	// Rerunning the window is a no-op
+	progress, err = RecomputeAnalyticsWindow(sessions, analytics, opts)
+	if err != nil {
+		t.Fatalf("RecomputeAnalyticsWindow() error = %v", err)
+	}
+	if progress.Recomputed != 0 || progress.Unchanged != 2 {
+		t.Errorf("rerun progress = %+v, want 2 unchanged", progress)
+	}
+}
+
+func TestRecomputeAnalyticsWindow_ResumeFromCheckpoint(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sessions, analytics, ids := newRecomputeTest(t, base, time.Hour, 2*time.Hour, 3*time.Hour, 4*time.Hour, 5*time.Hour)
+	opts := RecomputeOptions{From: base, To: base.Add(24 * time.Hour), BatchSize: 2}
+
+	first, err := RecomputeAnalyticsWindow(sessions, analytics, opts)
+	if err != nil {
+		t.Fatalf("RecomputeAnalyticsWindow() error = %v", err)
+	}
+	if first.Examined != 2 || first.Recomputed != 2 || first.NextCursor == "" {
+		t.Fatalf("first batch = %+v, want 2 recomputed and a checkpoint", first)
+	}
+
+	// Simulate an interruption: resume from the checkpoint in a fresh run
+	recomputed := first.Recomputed
+	opts.Cursor = first.NextCursor
+	for batches := 0; opts.Cursor != ""; batches++ {
+		if batches > len(ids) {
+			t.Fatal("recompute did not finish")
+		}
+		progress, err := RecomputeAnalyticsWindow(sessions, analytics, opts)
+		if err != nil {
+			t.Fatalf("RecomputeAnalyticsWindow() error = %v", err)
+		}
+		if progress.Unchanged != 0 {
+			t.Errorf("resumed batch re-examined finished streams: %+v", progress)
+		}
+		recomputed += progress.Recomputed
+		opts.Cursor = progress.NextCursor
+	}
+	if recomputed != len(ids) {
+		t.Errorf("recomputed %d streams across batches, want %d", recomputed, len(ids))
+	}
+
+	for i, id := range ids {
+		if _, err := analytics.GetAnalytics(id); err != nil {
+			t.Errorf("stream %d: GetAnalytics() error = %v, want analytics", i, err)
+		}
+	}
+}
+
+func TestRecomputeAnalyticsWindow_InvalidWindow(t *testing.T) {
+	sessions := NewInMemorySessionRepository()
+	analytics := NewInMemoryAnalyticsRepository(sessions)
+	now := time.Now()
+
+	for _, opts := range []RecomputeOptions{
+		{To: now},
+		{From: now},
+		{From: now, To: now.Add(-time.Hour)},
+	} {
+		if _, err := RecomputeAnalyticsWindow(sessions, analytics, opts); !errors.Is(err, ErrInvalidRecomputeWindow) {
+			t.Errorf("RecomputeAnalyticsWindow(%+v) error = %v, want ErrInvalidRecomputeWindow", opts, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package stream
 
 import (
 	"errors"
+	"reflect"
 	"sort"
 	"sync"
 	"time"
@@ -35,6 +36,11 @@ func NewInMemoryAnalyticsRepository(sessionRepo SessionRepository) *InMemoryAnal
 
 // RecordParticipantEvent records a join or leave event for a participant.
 func (r *InMemoryAnalyticsRepository) RecordParticipantEvent(streamSessionID, participantDID, eventType string, geohashPrefix *string) error {
+	return r.RecordParticipantEventAt(streamSessionID, participantDID, eventType, geohashPrefix, time.Now())
+}
+
+// RecordParticipantEventAt records a join or leave event that occurred at occurredAt.
+func (r *InMemoryAnalyticsRepository) RecordParticipantEventAt(streamSessionID, participantDID, eventType string, geohashPrefix *string, occurredAt time.Time) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -49,7 +55,7 @@ func (r *InMemoryAnalyticsRepository) RecordParticipantEvent(streamSessionID, pa
 		ParticipantDID:  participantDID,
 		EventType:       eventType,
 		GeohashPrefix:   geohashPrefix,
-		OccurredAt:      time.Now(),
+		OccurredAt:      occurredAt,
 	}
 
 	r.events[streamSessionID] = append(r.events[streamSessionID], event)
@@ -90,6 +96,35 @@ func (r *InMemoryAnalyticsRepository) ComputeAnalytics(streamSessionID string) (
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	analytics, err := r.computeLocked(streamSessionID)
+	if err != nil {
+		return nil, err
+	}
+	r.analytics[streamSessionID] = analytics
+	return analytics, nil
+}
+
+// RecomputeAnalytics recalculates analytics for a stream session and stores the
+// result only if it differs from the stored analytics.
+func (r *InMemoryAnalyticsRepository) RecomputeAnalytics(streamSessionID string) (*Analytics, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	analytics, err := r.computeLocked(streamSessionID)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing, ok := r.analytics[streamSessionID]; ok && sameMetrics(existing, analytics) {
+		existingCopy := *existing
+		return &existingCopy, false, nil
+	}
+	r.analytics[streamSessionID] = analytics
+	return analytics, true, nil
+}
+
+// computeLocked calculates analytics for a stream session without storing them.
+// The caller must hold r.mu.
+func (r *InMemoryAnalyticsRepository) computeLocked(streamSessionID string) (*Analytics, error) {
 	// Get the stream session
 	session, err := r.sessionRepo.GetByID(streamSessionID)
 	if err != nil {
@@ -185,27 +220,14 @@ func (r *InMemoryAnalyticsRepository) ComputeAnalytics(streamSessionID string) (
 	}
 
 	// Calculate retention metrics
-	var avgDuration *float64
-	var medianDuration *float64
-	if len(listenDurations) > 0 {
-		// Calculate average
-		sum := 0.0
-		for _, d := range listenDurations {
-			sum += d
-		}
-		avg := sum / float64(len(listenDurations))
-		avgDuration = &avg
-
-		// Calculate median
-		sort.Float64s(listenDurations)
-		mid := len(listenDurations) / 2
-		if len(listenDurations)%2 == 0 {
-			median := (listenDurations[mid-1] + listenDurations[mid]) / 2
-			medianDuration = &median
-		} else {
-			medianDuration = &listenDurations[mid]
-		}
+	avgDuration, medianDuration := meanAndMedian(listenDurations)
+
+	// Calculate per-participant watch time; still-connected participants leave at stream end
+	streamEnd := time.Now()
+	if session.EndedAt != nil {
+		streamEnd = *session.EndedAt
 	}
+	avgWatchTime, medianWatchTime := meanAndMedian(participantWatchTimes(sortedEvents, streamEnd))
 
 	// Create analytics object
 	analytics := &Analytics{
@@ -218,16 +240,24 @@ func (r *InMemoryAnalyticsRepository) ComputeAnalytics(streamSessionID string) (
 		EngagementLagSeconds:        engagementLag,
 		AvgListenDurationSeconds:    avgDuration,
 		MedianListenDurationSeconds: medianDuration,
+		AvgWatchTimeSeconds:         avgWatchTime,
+		MedianWatchTimeSeconds:      medianWatchTime,
 		GeographicDistribution:      geoDistribution,
 		ComputedAt:                  time.Now(),
 	}
 
-	// Store analytics
-	r.analytics[streamSessionID] = analytics
-
 	return analytics, nil
 }
 
+// sameMetrics reports whether two analytics records hold the same metrics,
+// ignoring their IDs and computation times.
+func sameMetrics(a, b *Analytics) bool {
+	aMetrics, bMetrics := *a, *b
+	aMetrics.ID, bMetrics.ID = "", ""
+	aMetrics.ComputedAt, bMetrics.ComputedAt = time.Time{}, time.Time{}
+	return reflect.DeepEqual(aMetrics, bMetrics)
+}
+
 // GetAnalytics retrieves the computed analytics for a stream session.
 func (r *InMemoryAnalyticsRepository) GetAnalytics(streamSessionID string) (*Analytics, error) {
 	r.mu.RLock()
@@ -251,3 +281,65 @@ func (r *InMemoryAnalyticsRepository) GetAnalytics(streamSessionID string) (*Ana
 
 	return &analyticsCopy, nil
 }
+
+// participantWatchTimes returns each participant's total watch time in seconds,
+// summing every join/leave interval so reconnections add up. A join while
+// already connected continues the open interval; a participant still connected
+// at streamEnd is treated as leaving then. events must be sorted by time.
+func participantWatchTimes(events []*ParticipantEvent, streamEnd time.Time) []float64 {
+	totals := make(map[string]float64)
+	openSince := make(map[string]time.Time)
+
+	for _, event := range events {
+		switch event.EventType {
+		case "join":
+			if _, ok := totals[event.ParticipantDID]; !ok {
+				totals[event.ParticipantDID] = 0
+			}
+			if _, connected := openSince[event.ParticipantDID]; !connected {
+				openSince[event.ParticipantDID] = event.OccurredAt
+			}
+		case "leave":
+			if joinedAt, connected := openSince[event.ParticipantDID]; connected {
+				if d := event.OccurredAt.Sub(joinedAt).Seconds(); d > 0 {
+					totals[event.ParticipantDID] += d
+				}
+				delete(openSince, event.ParticipantDID)
+			}
+		}
+	}
+
+	for did, joinedAt := range openSince {
+		if d := streamEnd.Sub(joinedAt).Seconds(); d > 0 {
+			totals[did] += d
+		}
+	}
+
+	watchTimes := make([]float64, 0, len(totals))
+	for _, total := range totals {
+		watchTimes = append(watchTimes, total)
+	}
+	return watchTimes
+}
+
+// meanAndMedian returns the mean and median of values, or nils if it is empty.
+// values is sorted in place.
+func meanAndMedian(values []float64) (*float64, *float64) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	sort.Float64s(values)
+	mid := len(values) / 2
+	median := values[mid]
+	if len(values)%2 == 0 {
+		median = (values[mid-1] + values[mid]) / 2
+	}
+	return &mean, &median
+}
@@ -51,6 +51,31 @@ func TestInMemoryAnalyticsRepository_RecordParticipantEvent_InvalidType(t *testi
 	}
 }
 
+func TestInMemoryAnalyticsRepository_RecordParticipantEventAt(t *testing.T) {
+	sessionRepo := NewInMemorySessionRepository()
+	repo := NewInMemoryAnalyticsRepository(sessionRepo)
+
+	streamID := "stream-1"
+	leftAt := time.Now().Add(-5 * time.Second)
+
+	// A held-back leave recorded after a later join still sorts first
+	_ = repo.RecordParticipantEvent(streamID, "user2", "join", nil)
+	if err := repo.RecordParticipantEventAt(streamID, "user1", "leave", nil, leftAt); err != nil {
+		t.Fatalf("Failed to record event: %v", err)
+	}
+
+	events, err := repo.GetParticipantEvents(streamID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].EventType != "leave" || !events[0].OccurredAt.Equal(leftAt) {
+		t.Errorf("Expected the leave first at %v, got %s at %v", leftAt, events[0].EventType, events[0].OccurredAt)
+	}
+}
+
 func TestInMemoryAnalyticsRepository_GetParticipantEvents_Empty(t *testing.T) {
 	sessionRepo := NewInMemorySessionRepository()
 	repo := NewInMemoryAnalyticsRepository(sessionRepo)
@@ -448,3 +473,83 @@ func TestInMemoryAnalyticsRepository_ComputeAnalytics_ParticipantsNeverLeave(t *
 		t.Errorf("Expected median ~100ms (user2 only), got %.0fms", medianMs)
 	}
 }
+
+// watchTimeLog builds a constructed join/leave log ending at streamEnd:
+// - user1 watches 60s, reconnects and watches another 30s (90s total)
+// - user2 joins 270s before the end and never leaves (270s)
+// - user3 sends a duplicate join while connected and watches 30s
+func watchTimeLog(streamID string, streamEnd time.Time) []*ParticipantEvent {
+	at := func(secondsBeforeEnd int) time.Time {
+		return streamEnd.Add(-time.Duration(secondsBeforeEnd) * time.Second)
+	}
+	return []*ParticipantEvent{
+		{StreamSessionID: streamID, ParticipantDID: "user1", EventType: "join", OccurredAt: at(300)},
+		{StreamSessionID: streamID, ParticipantDID: "user3", EventType: "join", OccurredAt: at(290)},
+		{StreamSessionID: streamID, ParticipantDID: "user3", EventType: "join", OccurredAt: at(280)},
+		{StreamSessionID: streamID, ParticipantDID: "user2", EventType: "join", OccurredAt: at(270)},
+		{StreamSessionID: streamID, ParticipantDID: "user3", EventType: "leave", OccurredAt: at(260)},
+		{StreamSessionID: streamID, ParticipantDID: "user1", EventType: "leave", OccurredAt: at(240)},
+		{StreamSessionID: streamID, ParticipantDID: "user1", EventType: "join", OccurredAt: at(180)},
+		{StreamSessionID: streamID, ParticipantDID: "user1", EventType: "leave", OccurredAt: at(150)},
+	}
+}
+
+func TestParticipantWatchTimes(t *testing.T) {
+	streamEnd := time.Date(2026, 3, 14, 22, 0, 0, 0, time.UTC)
+
+	watchTimes := participantWatchTimes(watchTimeLog("stream-1", streamEnd), streamEnd)
+	mean, median := meanAndMedian(watchTimes)
+
+	want := []float64{30, 90, 270}
+	if len(watchTimes) != len(want) {
+		t.Fatalf("expected %d participants, got %v", len(want), watchTimes)
+	}
+	for i := range want {
+		if watchTimes[i] != want[i] {
+			t.Errorf("watch times = %v, want %v", watchTimes, want)
+			break
+		}
+	}
+	if *mean != 130 {
+		t.Errorf("mean = %v, want 130", *mean)
+	}
+	if *median != 90 {
+		t.Errorf("median = %v, want 90", *median)
+	}
+
+	if mean, median := meanAndMedian(participantWatchTimes(nil, streamEnd)); mean != nil || median != nil {
+		t.Errorf("expected nil stats with no participants, got %v, %v", mean, median)
+	}
+}
+
+func TestInMemoryAnalyticsRepository_ComputeAnalytics_WatchTime(t *testing.T) {
+	sessionRepo := NewInMemorySessionRepository()
+	repo := NewInMemoryAnalyticsRepository(sessionRepo)
+
+	sceneID := "scene-1"
+	streamID, _, err := sessionRepo.CreateStreamSession(&sceneID, nil, "did:plc:host")
+	if err != nil {
+		t.Fatalf("Failed to create stream: %v", err)
+	}
+	if err := sessionRepo.EndStreamSession(streamID); err != nil {
+		t.Fatalf("Failed to end stream: %v", err)
+	}
+	session, err := sessionRepo.GetByID(streamID)
+	if err != nil {
+		t.Fatalf("Failed to get stream: %v", err)
+	}
+
+	repo.events[streamID] = watchTimeLog(streamID, *session.EndedAt)
+
+	analytics, err := repo.ComputeAnalytics(streamID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if analytics.AvgWatchTimeSeconds == nil || *analytics.AvgWatchTimeSeconds != 130 {
+		t.Errorf("Expected average watch time 130s, got %v", analytics.AvgWatchTimeSeconds)
+	}
+	if analytics.MedianWatchTimeSeconds == nil || *analytics.MedianWatchTimeSeconds != 90 {
+		t.Errorf("Expected median watch time 90s, got %v", analytics.MedianWatchTimeSeconds)
+	}
+}
@@ -3,12 +3,18 @@ package stream
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
 
+// ErrSubscriberLimitReached is returned by Subscribe when a stream already has
+// the maximum number of subscribers.
+var ErrSubscriberLimitReached = errors.New("stream subscriber limit reached")
+
 // connWrapper wraps a WebSocket connection with a write mutex for safe concurrent writes.
 type connWrapper struct {
 	conn *websocket.Conn
@@ -16,28 +22,106 @@ type connWrapper struct {
 }
 
 // EventBroadcaster manages WebSocket connections and broadcasts participant events.
+//
+// A stream's subscriber registry is freed when its last subscriber disconnects.
+// Once a stream ends (see StreamEnded), subscribers that stay connected are
+// disconnected by Sweep after the ended retention period, so idle clients of
+// ended streams don't hold registry entries forever.
 type EventBroadcaster struct {
-	mu          sync.RWMutex
-	connections map[string]map[*connWrapper]bool // streamSessionID -> connections
+	mu             sync.RWMutex
+	connections    map[string]map[*connWrapper]bool // streamSessionID -> connections
+	ended          map[string]time.Time             // streamSessionID -> end time, for ended streams that still have subscribers
+	maxPerStream   int                              // 0 means unlimited
+	endedRetention time.Duration                    // How long an ended stream's subscribers are kept; 0 keeps them until they disconnect
+	metrics        *Metrics                         // Optional: exports per-stream subscriber counts
+	now            func() time.Time
 }
 
 // NewEventBroadcaster creates a new event broadcaster.
 func NewEventBroadcaster() *EventBroadcaster {
 	return &EventBroadcaster{
 		connections: make(map[string]map[*connWrapper]bool),
+		ended:       make(map[string]time.Time),
+		now:         time.Now,
+	}
+}
+
+// SetMaxSubscribersPerStream caps how many connections may subscribe to one
+// stream session. A value of 0 or less removes the cap. Existing subscribers
+// are not disconnected if the cap is lowered.
+func (b *EventBroadcaster) SetMaxSubscribersPerStream(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if n < 0 {
+		n = 0
+	}
+	b.maxPerStream = n
+}
+
+// SetEndedRetention sets how long subscribers of an ended stream are kept
+// before Sweep disconnects them. A value of 0 or less keeps them until they
+// disconnect on their own.
+func (b *EventBroadcaster) SetEndedRetention(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if d < 0 {
+		d = 0
 	}
+	b.endedRetention = d
+}
+
+// SetMetrics enables the per-stream subscriber gauge.
+func (b *EventBroadcaster) SetMetrics(m *Metrics) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.metrics = m
+}
+
+// HasCapacity reports whether a stream session can accept another subscriber.
+// Callers can check it before upgrading a connection so a full stream is
+// rejected with a normal HTTP error; Subscribe still enforces the limit.
+func (b *EventBroadcaster) HasCapacity(streamSessionID string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.maxPerStream == 0 || len(b.connections[streamSessionID]) < b.maxPerStream
 }
 
-// Subscribe registers a WebSocket connection for a stream session.
-func (b *EventBroadcaster) Subscribe(streamSessionID string, conn *websocket.Conn) {
+// Subscribe registers a WebSocket connection for a stream session. It returns
+// ErrSubscriberLimitReached, without registering the connection, when the
+// stream is already at the subscriber limit.
+func (b *EventBroadcaster) Subscribe(streamSessionID string, conn *websocket.Conn) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.maxPerStream > 0 && len(b.connections[streamSessionID]) >= b.maxPerStream {
+		return ErrSubscriberLimitReached
+	}
+
 	wrapper := &connWrapper{conn: conn}
 	if b.connections[streamSessionID] == nil {
 		b.connections[streamSessionID] = make(map[*connWrapper]bool)
 	}
 	b.connections[streamSessionID][wrapper] = true
+	b.recordCount(streamSessionID)
+	return nil
+}
+
+// recordCount updates the subscriber and tracked-stream gauges for a stream.
+// Must be called with b.mu held for writing.
+func (b *EventBroadcaster) recordCount(streamSessionID string) {
+	if b.metrics != nil {
+		b.metrics.SetEventSubscribers(streamSessionID, len(b.connections[streamSessionID]))
+		b.metrics.SetEventStreamsTracked(len(b.connections))
+	}
+}
+
+// release frees a stream's registry entry if it has no subscribers left.
+// Must be called with b.mu held for writing.
+func (b *EventBroadcaster) release(streamSessionID string) {
+	if len(b.connections[streamSessionID]) == 0 {
+		delete(b.connections, streamSessionID)
+		delete(b.ended, streamSessionID)
+	}
 }
 
 // Unsubscribe removes a WebSocket connection from all stream sessions.
@@ -46,13 +130,16 @@ func (b *EventBroadcaster) Unsubscribe(conn *websocket.Conn) {
 	defer b.mu.Unlock()
 
 	for streamID, conns := range b.connections {
+		removed := false
 		for wrapper := range conns {
 			if wrapper.conn == conn {
 				delete(conns, wrapper)
+				removed = true
 			}
 		}
-		if len(conns) == 0 {
-			delete(b.connections, streamID)
+		b.release(streamID)
+		if removed {
+			b.recordCount(streamID)
 		}
 	}
 }
@@ -102,13 +189,10 @@ func (b *EventBroadcaster) Broadcast(streamSessionID string, event *ParticipantS
 	if len(deadConns) > 0 {
 		b.mu.Lock()
 		for _, wrapper := range deadConns {
-			if conns, exists := b.connections[streamSessionID]; exists {
-				delete(conns, wrapper)
-				if len(conns) == 0 {
-					delete(b.connections, streamSessionID)
-				}
-			}
+			delete(b.connections[streamSessionID], wrapper)
 		}
+		b.release(streamSessionID)
+		b.recordCount(streamSessionID)
 		b.mu.Unlock()
 	}
 }
@@ -123,3 +207,90 @@ func (b *EventBroadcaster) ConnectionCount(streamSessionID string) int {
 	}
 	return 0
 }
+
+// TrackedStreams returns the number of streams with a subscriber registry.
+func (b *EventBroadcaster) TrackedStreams() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.connections)
+}
+
+// StreamEnded marks a stream as ended. Its registry is freed immediately if it
+// has no subscribers; otherwise it is freed when the last subscriber
+// disconnects, or by Sweep once the ended retention period has passed.
+func (b *EventBroadcaster) StreamEnded(streamSessionID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.connections[streamSessionID]) == 0 {
+		b.release(streamSessionID)
+		b.recordCount(streamSessionID)
+		return
+	}
+	if _, marked := b.ended[streamSessionID]; !marked {
+		b.ended[streamSessionID] = b.now()
+	}
+}
+
+// Sweep frees registry entries left without subscribers and disconnects the
+// subscribers of streams that ended longer ago than the ended retention
+// period. It returns the number of streams freed.
+func (b *EventBroadcaster) Sweep() int {
+	b.mu.Lock()
+	var evicted []*connWrapper
+	freed := 0
+	for streamID, conns := range b.connections {
+		if len(conns) == 0 {
+			b.release(streamID)
+			b.recordCount(streamID)
+			freed++
+		}
+	}
+	now := b.now()
+	for streamID, endedAt := range b.ended {
+		if b.endedRetention > 0 && now.Sub(endedAt) < b.endedRetention {
+			continue
+		}
+		if b.endedRetention <= 0 && len(b.connections[streamID]) > 0 {
+			continue
+		}
+		for wrapper := range b.connections[streamID] {
+			evicted = append(evicted, wrapper)
+		}
+		delete(b.connections, streamID)
+		delete(b.ended, streamID)
+		b.recordCount(streamID)
+		freed++
+	}
+	b.mu.Unlock()
+
+	// Close evicted connections outside the lock; their handlers' Unsubscribe
+	// calls find nothing left to remove
+	for _, wrapper := range evicted {
+		wrapper.mu.Lock()
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "stream ended")
+		_ = wrapper.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+		_ = wrapper.conn.Close()
+		wrapper.mu.Unlock()
+	}
+	if freed > 0 {
+		slog.Info("swept stream event subscriber registry", "streams_freed", freed, "subscribers_disconnected", len(evicted))
+	}
+	return freed
+}
+
+// RunSweeper calls Sweep every interval until stop is closed. It blocks and
+// should be run in a goroutine.
+func (b *EventBroadcaster) RunSweeper(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.Sweep()
+		case <-stop:
+			return
+		}
+	}
+}
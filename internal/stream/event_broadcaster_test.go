@@ -2,6 +2,7 @@ package stream
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 // testWSServer creates a test WebSocket server that upgrades HTTP connections.
@@ -211,3 +213,228 @@ func TestEventBroadcaster_ConnectionCount_EmptySession(t *testing.T) {
 		t.Errorf("expected 0 for nonexistent session, got %d", count)
 	}
 }
+
+func TestEventBroadcaster_SubscriberLimit(t *testing.T) {
+	server, dial := testWSServer(t)
+	defer server.Close()
+
+	b := NewEventBroadcaster()
+	b.SetMaxSubscribersPerStream(2)
+
+	conn1 := dial()
+	defer conn1.Close()
+	conn2 := dial()
+	defer conn2.Close()
+	conn3 := dial()
+	defer conn3.Close()
+
+	// Up to the limit is accepted
+	for i, conn := range []*websocket.Conn{conn1, conn2} {
+		if err := b.Subscribe("session-1", conn); err != nil {
+			t.Fatalf("subscriber %d: Subscribe() error = %v, want nil", i+1, err)
+		}
+	}
+	if b.HasCapacity("session-1") {
+		t.Error("HasCapacity() = true at the limit, want false")
+	}
+
+	// Beyond it is rejected and not registered
+	if err := b.Subscribe("session-1", conn3); !errors.Is(err, ErrSubscriberLimitReached) {
+		t.Errorf("Subscribe() beyond limit error = %v, want ErrSubscriberLimitReached", err)
+	}
+	if count := b.ConnectionCount("session-1"); count != 2 {
+		t.Errorf("expected 2 connections after rejected subscribe, got %d", count)
+	}
+
+	// The limit is per stream
+	if err := b.Subscribe("session-2", conn3); err != nil {
+		t.Errorf("Subscribe() to another stream error = %v, want nil", err)
+	}
+
+	// A disconnect frees a slot
+	b.Unsubscribe(conn1)
+	if !b.HasCapacity("session-1") {
+		t.Error("HasCapacity() = false after a disconnect, want true")
+	}
+	conn4 := dial()
+	defer conn4.Close()
+	if err := b.Subscribe("session-1", conn4); err != nil {
+		t.Errorf("Subscribe() after a disconnect error = %v, want nil", err)
+	}
+}
+
+func TestEventBroadcaster_NoSubscriberLimitByDefault(t *testing.T) {
+	server, dial := testWSServer(t)
+	defer server.Close()
+
+	b := NewEventBroadcaster()
+	for i := 0; i < 5; i++ {
+		conn := dial()
+		defer conn.Close()
+		if err := b.Subscribe("session-1", conn); err != nil {
+			t.Fatalf("subscriber %d: Subscribe() error = %v, want nil", i+1, err)
+		}
+	}
+	if !b.HasCapacity("session-1") {
+		t.Error("HasCapacity() = false without a limit, want true")
+	}
+}
+
+func TestEventBroadcaster_SubscriberGauge(t *testing.T) {
+	server, dial := testWSServer(t)
+	defer server.Close()
+
+	m := NewMetrics()
+	b := NewEventBroadcaster()
+	b.SetMetrics(m)
+
+	conn1 := dial()
+	defer conn1.Close()
+	conn2 := dial()
+	defer conn2.Close()
+
+	_ = b.Subscribe("session-1", conn1)
+	_ = b.Subscribe("session-1", conn2)
+	if got := testutil.ToFloat64(m.eventSubscribers.WithLabelValues("session-1")); got != 2 {
+		t.Errorf("gauge = %v after two subscribes, want 2", got)
+	}
+
+	b.Unsubscribe(conn1)
+	if got := testutil.ToFloat64(m.eventSubscribers.WithLabelValues("session-1")); got != 1 {
+		t.Errorf("gauge = %v after a disconnect, want 1", got)
+	}
+
+	// The last disconnect drops the stream's series
+	b.Unsubscribe(conn2)
+	if n := testutil.CollectAndCount(m.eventSubscribers); n != 0 {
+		t.Errorf("expected no gauge series once the stream is empty, got %d", n)
+	}
+}
+
+func TestEventBroadcaster_StreamEnded_FreesRegistry(t *testing.T) {
+	server, dial := testWSServer(t)
+	defer server.Close()
+
+	m := NewMetrics()
+	b := NewEventBroadcaster()
+	b.SetMetrics(m)
+
+	// Ending a stream with no subscribers frees it immediately
+	b.StreamEnded("session-1")
+	if n := b.TrackedStreams(); n != 0 {
+		t.Errorf("expected no tracked streams, got %d", n)
+	}
+
+	conn := dial()
+	defer conn.Close()
+	_ = b.Subscribe("session-2", conn)
+	if got := testutil.ToFloat64(m.eventStreamsTracked); got != 1 {
+		t.Errorf("tracked gauge = %v, want 1", got)
+	}
+
+	// A live subscriber keeps the registry until it disconnects
+	b.StreamEnded("session-2")
+	if freed := b.Sweep(); freed != 0 {
+		t.Errorf("Sweep() freed %d streams, want 0", freed)
+	}
+	if count := b.ConnectionCount("session-2"); count != 1 {
+		t.Errorf("expected the subscriber to stay connected, got %d", count)
+	}
+
+	b.Unsubscribe(conn)
+	if n := b.TrackedStreams(); n != 0 {
+		t.Errorf("expected no tracked streams after the last disconnect, got %d", n)
+	}
+	if n := len(b.ended); n != 0 {
+		t.Errorf("expected no ended markers after the last disconnect, got %d", n)
+	}
+	if got := testutil.ToFloat64(m.eventStreamsTracked); got != 0 {
+		t.Errorf("tracked gauge = %v, want 0", got)
+	}
+}
+
+func TestEventBroadcaster_Sweep_EndedRetention(t *testing.T) {
+	server, dial := testWSServer(t)
+	defer server.Close()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := NewEventBroadcaster()
+	b.now = func() time.Time { return now }
+	b.SetEndedRetention(5 * time.Minute)
+
+	ended := dial()
+	defer ended.Close()
+	live := dial()
+	defer live.Close()
+	_ = b.Subscribe("ended", ended)
+	_ = b.Subscribe("live", live)
+	b.StreamEnded("ended")
+
+	// Within the retention period nothing is freed
+	now = now.Add(4 * time.Minute)
+	if freed := b.Sweep(); freed != 0 {
+		t.Errorf("Sweep() within retention freed %d streams, want 0", freed)
+	}
+
+	// Past it the ended stream's subscribers are disconnected
+	now = now.Add(2 * time.Minute)
+	if freed := b.Sweep(); freed != 1 {
+		t.Errorf("Sweep() past retention freed %d streams, want 1", freed)
+	}
+	if n := b.TrackedStreams(); n != 1 {
+		t.Errorf("expected only the live stream to stay tracked, got %d", n)
+	}
+	if count := b.ConnectionCount("live"); count != 1 {
+		t.Errorf("expected the live stream's subscriber to stay, got %d", count)
+	}
+
+	_ = ended.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := ended.ReadMessage(); err == nil {
+		t.Error("expected the evicted subscriber's connection to be closed")
+	}
+
+	// The evicted connection's own disconnect is a no-op
+	b.Unsubscribe(ended)
+	if n := b.TrackedStreams(); n != 1 {
+		t.Errorf("expected 1 tracked stream, got %d", n)
+	}
+}
+
+func TestEventBroadcaster_Sweep_NoRetentionKeepsSubscribers(t *testing.T) {
+	server, dial := testWSServer(t)
+	defer server.Close()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := NewEventBroadcaster()
+	b.now = func() time.Time { return now }
+
+	conn := dial()
+	defer conn.Close()
+	_ = b.Subscribe("session-1", conn)
+	b.StreamEnded("session-1")
+
+	now = now.Add(24 * time.Hour)
+	if freed := b.Sweep(); freed != 0 {
+		t.Errorf("Sweep() freed %d streams, want 0", freed)
+	}
+	if count := b.ConnectionCount("session-1"); count != 1 {
+		t.Errorf("expected the subscriber to stay connected, got %d", count)
+	}
+}
+
+func TestEventBroadcaster_RunSweeper_Stops(t *testing.T) {
+	b := NewEventBroadcaster()
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		b.RunSweeper(time.Millisecond, stop)
+		close(done)
+	}()
+
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("RunSweeper did not return after stop was closed")
+	}
+}
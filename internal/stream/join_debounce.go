@@ -0,0 +1,95 @@
+package stream
+
+import (
+	"sync"
+	"time"
+)
+
+// JoinDebouncer holds back the side effects of a participant leaving a stream
+// (leave counts, analytics, the participant_left broadcast) for a short window.
+// A flaky client that leaves and re-joins within the window is treated as one
+// continuous session instead of a leave/join pair. Thread-safe.
+type JoinDebouncer struct {
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[string]*pendingLeave // makeKey(streamSessionID, participantID) -> pending leave
+	timeNow func() time.Time         // For testability
+}
+
+// pendingLeave is a leave whose side effects have not run yet.
+type pendingLeave struct {
+	leftAt   time.Time
+	timer    *time.Timer
+	finalize func()
+}
+
+// NewJoinDebouncer creates a debouncer that coalesces a leave and re-join
+// closer together than window. A zero window disables debouncing.
+func NewJoinDebouncer(window time.Duration) *JoinDebouncer {
+	return &JoinDebouncer{
+		window:  window,
+		pending: make(map[string]*pendingLeave),
+		timeNow: time.Now,
+	}
+}
+
+// Leave schedules finalize to run once the window elapses, unless the
+// participant re-joins first. With a zero window finalize runs immediately.
+func (d *JoinDebouncer) Leave(streamSessionID, participantID string, finalize func()) {
+	if d.window <= 0 {
+		finalize()
+		return
+	}
+
+	key := makeKey(streamSessionID, participantID)
+	p := &pendingLeave{leftAt: d.timeNow(), finalize: finalize}
+
+	d.mu.Lock()
+	previous := d.pending[key]
+	d.pending[key] = p
+	p.timer = time.AfterFunc(d.window, func() { d.fire(key, p) })
+	d.mu.Unlock()
+
+	// A leave can't normally follow another leave, but never drop side effects
+	if previous != nil && previous.timer.Stop() {
+		previous.finalize()
+	}
+}
+
+// fire runs a pending leave's side effects when its timer expires, unless a
+// re-join already claimed it.
+func (d *JoinDebouncer) fire(key string, p *pendingLeave) {
+	d.mu.Lock()
+	if d.pending[key] != p {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.pending, key)
+	d.mu.Unlock()
+	p.finalize()
+}
+
+// Rejoin reports whether a join continues a session that left within the
+// window. If so, the pending leave is cancelled and its side effects never run.
+// A pending leave older than the window is finalized before returning false,
+// so its side effects are recorded ahead of the new join.
+func (d *JoinDebouncer) Rejoin(streamSessionID, participantID string) bool {
+	key := makeKey(streamSessionID, participantID)
+
+	d.mu.Lock()
+	p, ok := d.pending[key]
+	if ok {
+		delete(d.pending, key)
+	}
+	d.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	stopped := p.timer.Stop()
+	if stopped && d.timeNow().Sub(p.leftAt) < d.window {
+		return true
+	}
+	p.finalize()
+	return false
+}
@@ -0,0 +1,89 @@
+package stream
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJoinDebouncer_RejoinWithinWindowCancelsLeave(t *testing.T) {
+	d := NewJoinDebouncer(time.Hour)
+
+	var finalized atomic.Int32
+	d.Leave("stream-1", "user-1", func() { finalized.Add(1) })
+
+	if !d.Rejoin("stream-1", "user-1") {
+		t.Fatal("expected re-join within the window to be coalesced")
+	}
+	if got := finalized.Load(); got != 0 {
+		t.Errorf("expected cancelled leave not to finalize, finalized %d times", got)
+	}
+
+	// The pending leave is consumed, so a second join is a fresh one
+	if d.Rejoin("stream-1", "user-1") {
+		t.Error("expected join with no pending leave not to be coalesced")
+	}
+}
+
+func TestJoinDebouncer_RejoinAfterWindowFinalizesLeave(t *testing.T) {
+	d := NewJoinDebouncer(time.Hour)
+	now := time.Now()
+	d.timeNow = func() time.Time { return now }
+
+	var finalized atomic.Int32
+	d.Leave("stream-1", "user-1", func() { finalized.Add(1) })
+
+	// The timer hasn't fired yet, but the window has elapsed by the clock
+	now = now.Add(2 * time.Hour)
+	if d.Rejoin("stream-1", "user-1") {
+		t.Fatal("expected re-join after the window not to be coalesced")
+	}
+	if got := finalized.Load(); got != 1 {
+		t.Errorf("expected leave to finalize once before the new join, finalized %d times", got)
+	}
+}
+
+func TestJoinDebouncer_TimerFinalizesLeave(t *testing.T) {
+	d := NewJoinDebouncer(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	d.Leave("stream-1", "user-1", func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected leave to finalize after the window")
+	}
+	if d.Rejoin("stream-1", "user-1") {
+		t.Error("expected join after a finalized leave not to be coalesced")
+	}
+}
+
+func TestJoinDebouncer_ZeroWindowFinalizesImmediately(t *testing.T) {
+	d := NewJoinDebouncer(0)
+
+	var finalized bool
+	d.Leave("stream-1", "user-1", func() { finalized = true })
+
+	if !finalized {
+		t.Error("expected leave to finalize immediately with a zero window")
+	}
+	if d.Rejoin("stream-1", "user-1") {
+		t.Error("expected no coalescing with a zero window")
+	}
+}
+
+func TestJoinDebouncer_KeysByStreamAndParticipant(t *testing.T) {
+	d := NewJoinDebouncer(time.Hour)
+	d.Leave("stream-1", "user-1", func() {})
+
+	if d.Rejoin("stream-2", "user-1") {
+		t.Error("expected join to another stream not to be coalesced")
+	}
+	if d.Rejoin("stream-1", "user-2") {
+		t.Error("expected another participant's join not to be coalesced")
+	}
+	if !d.Rejoin("stream-1", "user-1") {
+		t.Error("expected the leaving participant's re-join to be coalesced")
+	}
+}
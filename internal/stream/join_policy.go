@@ -0,0 +1,57 @@
+package stream
+
+import (
+	"errors"
+
+	"github.com/onnwee/subcults/internal/trust"
+)
+
+// ErrInvalidJoinPolicy is returned when a join policy is not one of the known values.
+var ErrInvalidJoinPolicy = errors.New("invalid join policy: must be public, members_only, or followers_only")
+
+// JoinPolicy controls who besides the host may join a stream session.
+// It sits between a public stream and a locked one (see Session.IsLocked).
+type JoinPolicy string
+
+const (
+	// JoinPolicyPublic lets any authenticated user join.
+	JoinPolicyPublic JoinPolicy = "public"
+
+	// JoinPolicyMembersOnly admits users with an active membership in the
+	// stream's scene as member, curator, or owner.
+	JoinPolicyMembersOnly JoinPolicy = "members_only"
+
+	// JoinPolicyFollowersOnly admits users with any active membership in the
+	// stream's scene, including guests.
+	JoinPolicyFollowersOnly JoinPolicy = "followers_only"
+)
+
+// Valid reports whether p is a known join policy.
+func (p JoinPolicy) Valid() bool {
+	switch p {
+	case JoinPolicyPublic, JoinPolicyMembersOnly, JoinPolicyFollowersOnly:
+		return true
+	}
+	return false
+}
+
+// RequiresMembership reports whether joining under p depends on the user's
+// scene membership. An empty policy is treated as public.
+func (p JoinPolicy) RequiresMembership() bool {
+	return p == JoinPolicyMembersOnly || p == JoinPolicyFollowersOnly
+}
+
+// Allows reports whether a scene membership with the given role and status
+// satisfies p. Pending and rejected memberships never qualify.
+func (p JoinPolicy) Allows(role, status string) bool {
+	if !p.RequiresMembership() {
+		return true
+	}
+	if status != "active" {
+		return false
+	}
+	if p == JoinPolicyMembersOnly {
+		return role == trust.RoleOwner || role == trust.RoleCurator || role == trust.RoleMember
+	}
+	return true
+}
@@ -19,6 +19,10 @@ const (
 	MetricNetworkRTT      = "stream_network_rtt_ms"
 	MetricQualityAlerts   = "stream_quality_alerts_total"
 	MetricHighPacketLoss  = "stream_high_packet_loss_total"
+
+	// Real-time event fan-out
+	MetricEventSubscribers    = "stream_event_subscribers"
+	MetricEventStreamsTracked = "stream_event_streams_tracked"
 )
 
 // Metrics contains Prometheus metrics for streaming sessions.
@@ -36,6 +40,10 @@ type Metrics struct {
 	networkRTT      prometheus.Histogram
 	qualityAlerts   prometheus.Counter
 	highPacketLoss  prometheus.Counter
+
+	// Real-time event fan-out
+	eventSubscribers    *prometheus.GaugeVec
+	eventStreamsTracked prometheus.Gauge
 }
 
 // NewMetrics creates and returns a new Metrics instance with all collectors initialized.
@@ -88,6 +96,14 @@ func NewMetrics() *Metrics {
 			Name: MetricHighPacketLoss,
 			Help: "Total number of high packet loss events (>5%)",
 		}),
+		eventSubscribers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: MetricEventSubscribers,
+			Help: "Current number of real-time participant event subscribers per stream",
+		}, []string{"stream_id"}),
+		eventStreamsTracked: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: MetricEventStreamsTracked,
+			Help: "Current number of streams with a real-time event subscriber registry",
+		}),
 	}
 }
 
@@ -105,6 +121,8 @@ func (m *Metrics) Register(reg prometheus.Registerer) error {
 		m.networkRTT,
 		m.qualityAlerts,
 		m.highPacketLoss,
+		m.eventSubscribers,
+		m.eventStreamsTracked,
 	}
 
 	for _, c := range collectors {
@@ -164,6 +182,21 @@ func (m *Metrics) IncQualityAlerts() {
 	m.qualityAlerts.Inc()
 }
 
+// SetEventSubscribers records a stream's current subscriber count. A count of 0
+// drops the stream's series so ended streams do not accumulate.
+func (m *Metrics) SetEventSubscribers(streamSessionID string, count int) {
+	if count <= 0 {
+		m.eventSubscribers.DeleteLabelValues(streamSessionID)
+		return
+	}
+	m.eventSubscribers.WithLabelValues(streamSessionID).Set(float64(count))
+}
+
+// SetEventStreamsTracked records how many streams have a subscriber registry.
+func (m *Metrics) SetEventStreamsTracked(count int) {
+	m.eventStreamsTracked.Set(float64(count))
+}
+
 // Collectors returns all Prometheus collectors for testing.
 func (m *Metrics) Collectors() []prometheus.Collector {
 	return []prometheus.Collector{
@@ -177,5 +210,7 @@ func (m *Metrics) Collectors() []prometheus.Collector {
 		m.networkRTT,
 		m.qualityAlerts,
 		m.highPacketLoss,
+		m.eventSubscribers,
+		m.eventStreamsTracked,
 	}
 }
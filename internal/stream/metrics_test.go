@@ -15,8 +15,8 @@ func TestNewMetrics(t *testing.T) {
 
 	// Verify all collectors are initialized (including new audio quality metrics)
 	collectors := m.Collectors()
-	if len(collectors) != 10 {
-		t.Errorf("expected 10 collectors, got %d", len(collectors))
+	if len(collectors) != 12 {
+		t.Errorf("expected 12 collectors, got %d", len(collectors))
 	}
 }
 
@@ -14,8 +14,10 @@ type Participant struct {
 	ParticipantID     string     `json:"participant_id"` // LiveKit participant identity
 	UserDID           string     `json:"user_did"`       // Decentralized Identifier
 	JoinedAt          time.Time  `json:"joined_at"`
-	LeftAt            *time.Time `json:"left_at,omitempty"`  // NULL while active
-	ReconnectionCount int        `json:"reconnection_count"` // Times rejoined after leaving
+	LeftAt            *time.Time `json:"left_at,omitempty"`        // NULL while active
+	ReconnectionCount int        `json:"reconnection_count"`       // Times rejoined after leaving
+	HandRaisedAt      *time.Time `json:"hand_raised_at,omitempty"` // Set while asking the host to speak
+	Muted             bool       `json:"muted"`                    // Audio muted by the host
 	CreatedAt         time.Time  `json:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at"`
 }
@@ -27,7 +29,7 @@ func (p *Participant) IsActive() bool {
 
 // ParticipantStateEvent represents a real-time event for WebSocket broadcasting.
 type ParticipantStateEvent struct {
-	Type            string    `json:"type"` // "participant_joined" or "participant_left"
+	Type            string    `json:"type"` // "participant_joined", "participant_left", "hand_raised" or "hand_lowered"
 	StreamSessionID string    `json:"stream_session_id"`
 	ParticipantID   string    `json:"participant_id"`
 	UserDID         string    `json:"user_did"`
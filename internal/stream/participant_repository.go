@@ -14,20 +14,31 @@ import (
 var (
 	ErrParticipantNotFound      = errors.New("participant not found")
 	ErrParticipantAlreadyActive = errors.New("participant already active in stream")
+	ErrHandAlreadyRaised        = errors.New("participant hand already raised")
+	ErrHandNotRaised            = errors.New("participant hand not raised")
 )
 
 // ParticipantRepository defines the interface for participant data operations.
 type ParticipantRepository interface {
 	// RecordJoin records a participant joining a stream.
 	// Returns the participant record and whether this is a reconnection.
+	// A participant who left within the repository's reconnection grace window
+	// has their previous record reopened instead of getting a new one.
 	// If the participant is already active, returns ErrParticipantAlreadyActive.
 	RecordJoin(streamSessionID, participantID, userDID string) (*Participant, bool, error)
 
 	// RecordLeave marks a participant as having left the stream.
-	// Sets left_at timestamp and updates the active participant count.
+	// Sets left_at timestamp and updates the active participant count. The
+	// left_at timestamp starts the reconnection grace window, if one is set.
 	// Returns ErrParticipantNotFound if participant doesn't exist or is already left.
 	RecordLeave(streamSessionID, participantID string) error
 
+	// ResumeParticipant reopens the participant's most recent record after a
+	// leave that was debounced away, incrementing its reconnection count.
+	// Returns ErrParticipantAlreadyActive if the participant is active, or
+	// ErrParticipantNotFound if they have never joined the stream.
+	ResumeParticipant(streamSessionID, participantID string) (*Participant, error)
+
 	// GetActiveParticipants returns all currently active participants for a stream.
 	// Active participants have left_at = NULL.
 	GetActiveParticipants(streamSessionID string) ([]*Participant, error)
@@ -42,6 +53,22 @@ type ParticipantRepository interface {
 	// UpdateSessionParticipantCount updates the denormalized active_participant_count
 	// on the stream_sessions table. Should be called after join/leave operations.
 	UpdateSessionParticipantCount(streamSessionID string, count int) error
+
+	// RaiseHand records that an active participant is asking the host to speak.
+	// Returns ErrParticipantNotFound if the participant isn't active, or
+	// ErrHandAlreadyRaised if their hand is already raised. Leaving the stream
+	// lowers the hand.
+	RaiseHand(streamSessionID, participantID string) (*Participant, error)
+
+	// LowerHand clears an active participant's raised hand.
+	// Returns ErrParticipantNotFound if the participant isn't active, or
+	// ErrHandNotRaised if their hand isn't raised.
+	LowerHand(streamSessionID, participantID string) (*Participant, error)
+
+	// SetMuted records whether the host has muted an active participant's audio.
+	// Returns ErrParticipantNotFound if the participant isn't active. Leaving
+	// the stream clears the flag, as LiveKit does.
+	SetMuted(streamSessionID, participantID string, muted bool) error
 }
 
 // InMemoryParticipantRepository is an in-memory implementation of ParticipantRepository.
@@ -50,17 +77,37 @@ type InMemoryParticipantRepository struct {
 	mu           sync.RWMutex
 	participants map[string]*Participant // participant.ID -> Participant
 	// Index for quick lookup of active participants by stream and participant_id
-	activeIndex map[string]map[string]string // streamSessionID -> participantID -> participant.ID
-	sessionRepo SessionRepository            // Reference for updating denormalized count
+	activeIndex       map[string]map[string]string // streamSessionID -> participantID -> participant.ID
+	sessionRepo       SessionRepository            // Reference for updating denormalized count
+	reconnectionGrace time.Duration                // Re-joins within this long of a leave reopen the previous record; 0 disables
+	timeNow           func() time.Time             // For testability
+}
+
+// ParticipantRepositoryOption configures an InMemoryParticipantRepository.
+type ParticipantRepositoryOption func(*InMemoryParticipantRepository)
+
+// WithReconnectionGrace treats a participant who re-joins within window of
+// leaving as reconnecting: their previous record is reopened rather than a new
+// one being created, so flaky mobile connections don't inflate join history.
+// A zero window disables the grace period.
+func WithReconnectionGrace(window time.Duration) ParticipantRepositoryOption {
+	return func(r *InMemoryParticipantRepository) {
+		r.reconnectionGrace = window
+	}
 }
 
 // NewInMemoryParticipantRepository creates a new in-memory participant repository.
-func NewInMemoryParticipantRepository(sessionRepo SessionRepository) *InMemoryParticipantRepository {
-	return &InMemoryParticipantRepository{
+func NewInMemoryParticipantRepository(sessionRepo SessionRepository, opts ...ParticipantRepositoryOption) *InMemoryParticipantRepository {
+	r := &InMemoryParticipantRepository{
 		participants: make(map[string]*Participant),
 		activeIndex:  make(map[string]map[string]string),
 		sessionRepo:  sessionRepo,
+		timeNow:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
 // RecordJoin records a participant joining a stream.
@@ -68,7 +115,7 @@ func (r *InMemoryParticipantRepository) RecordJoin(streamSessionID, participantI
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	now := time.Now()
+	now := r.timeNow()
 	isReconnection := false
 
 	// Check if participant is already active
@@ -81,15 +128,25 @@ func (r *InMemoryParticipantRepository) RecordJoin(streamSessionID, participantI
 	// Check if this participant has been in this stream before (reconnection)
 	// Find the maximum reconnection count from all previous records
 	var reconnectionCount int
+	var latest *Participant
 	for _, p := range r.participants {
 		if p.StreamSessionID == streamSessionID && p.ParticipantID == participantID {
 			isReconnection = true
 			if candidate := p.ReconnectionCount + 1; candidate > reconnectionCount {
 				reconnectionCount = candidate
 			}
+			if latest == nil || p.JoinedAt.After(latest.JoinedAt) {
+				latest = p
+			}
 		}
 	}
 
+	// A re-join within the grace window continues the previous record
+	if latest != nil && latest.LeftAt != nil && now.Sub(*latest.LeftAt) < r.reconnectionGrace {
+		participantCopy := *r.reopen(latest, now)
+		return &participantCopy, true, nil
+	}
+
 	// Create new participant record
 	participant := &Participant{
 		ID:                uuid.New().String(),
@@ -145,9 +202,11 @@ func (r *InMemoryParticipantRepository) RecordLeave(streamSessionID, participant
 		return ErrParticipantNotFound
 	}
 
-	// Mark as left
-	now := time.Now()
+	// Mark as left, lowering any raised hand and clearing the mute
+	now := r.timeNow()
 	participant.LeftAt = &now
+	participant.HandRaisedAt = nil
+	participant.Muted = false
 	participant.UpdatedAt = now
 
 	// Remove from active index
@@ -168,6 +227,56 @@ func (r *InMemoryParticipantRepository) RecordLeave(streamSessionID, participant
 	return nil
 }
 
+// ResumeParticipant reopens the participant's most recent record.
+func (r *InMemoryParticipantRepository) ResumeParticipant(streamSessionID, participantID string) (*Participant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if streamActive, exists := r.activeIndex[streamSessionID]; exists {
+		if _, active := streamActive[participantID]; active {
+			return nil, ErrParticipantAlreadyActive
+		}
+	}
+
+	// Find the most recent record for this participant
+	var latest *Participant
+	for _, p := range r.participants {
+		if p.StreamSessionID == streamSessionID && p.ParticipantID == participantID {
+			if latest == nil || p.JoinedAt.After(latest.JoinedAt) {
+				latest = p
+			}
+		}
+	}
+	if latest == nil {
+		return nil, ErrParticipantNotFound
+	}
+
+	participantCopy := *r.reopen(latest, r.timeNow())
+	return &participantCopy, nil
+}
+
+// reopen marks a participant's record active again and counts the
+// reconnection. Must be called with r.mu held for writing.
+func (r *InMemoryParticipantRepository) reopen(p *Participant, now time.Time) *Participant {
+	p.LeftAt = nil
+	p.ReconnectionCount++
+	p.UpdatedAt = now
+
+	// Update active index
+	if r.activeIndex[p.StreamSessionID] == nil {
+		r.activeIndex[p.StreamSessionID] = make(map[string]string)
+	}
+	r.activeIndex[p.StreamSessionID][p.ParticipantID] = p.ID
+
+	// Update denormalized count
+	activeCount := len(r.activeIndex[p.StreamSessionID])
+	if err := r.sessionRepo.UpdateActiveParticipantCount(p.StreamSessionID, activeCount); err != nil {
+		// Log but don't fail the operation
+	}
+
+	return p
+}
+
 // GetActiveParticipants returns all currently active participants for a stream.
 func (r *InMemoryParticipantRepository) GetActiveParticipants(streamSessionID string) ([]*Participant, error) {
 	r.mu.RLock()
@@ -225,3 +334,72 @@ func (r *InMemoryParticipantRepository) GetActiveCount(streamSessionID string) (
 func (r *InMemoryParticipantRepository) UpdateSessionParticipantCount(streamSessionID string, count int) error {
 	return r.sessionRepo.UpdateActiveParticipantCount(streamSessionID, count)
 }
+
+// RaiseHand records that an active participant is asking to speak.
+func (r *InMemoryParticipantRepository) RaiseHand(streamSessionID, participantID string) (*Participant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	participant, err := r.activeParticipant(streamSessionID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if participant.HandRaisedAt != nil {
+		return nil, ErrHandAlreadyRaised
+	}
+
+	now := time.Now()
+	participant.HandRaisedAt = &now
+	participant.UpdatedAt = now
+
+	participantCopy := *participant
+	return &participantCopy, nil
+}
+
+// LowerHand clears an active participant's raised hand.
+func (r *InMemoryParticipantRepository) LowerHand(streamSessionID, participantID string) (*Participant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	participant, err := r.activeParticipant(streamSessionID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if participant.HandRaisedAt == nil {
+		return nil, ErrHandNotRaised
+	}
+
+	participant.HandRaisedAt = nil
+	participant.UpdatedAt = time.Now()
+
+	participantCopy := *participant
+	return &participantCopy, nil
+}
+
+// SetMuted records whether the host has muted an active participant's audio.
+func (r *InMemoryParticipantRepository) SetMuted(streamSessionID, participantID string, muted bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	participant, err := r.activeParticipant(streamSessionID, participantID)
+	if err != nil {
+		return err
+	}
+	participant.Muted = muted
+	participant.UpdatedAt = time.Now()
+	return nil
+}
+
+// activeParticipant returns the stored record for an active participant.
+// Callers must hold r.mu.
+func (r *InMemoryParticipantRepository) activeParticipant(streamSessionID, participantID string) (*Participant, error) {
+	participantRecordID, active := r.activeIndex[streamSessionID][participantID]
+	if !active {
+		return nil, ErrParticipantNotFound
+	}
+	participant, exists := r.participants[participantRecordID]
+	if !exists {
+		return nil, ErrParticipantNotFound
+	}
+	return participant, nil
+}
@@ -166,6 +166,230 @@ func TestInMemoryParticipantRepository_RecordLeave(t *testing.T) {
 	})
 }
 
+func TestInMemoryParticipantRepository_ResumeParticipant(t *testing.T) {
+	sessionRepo := NewInMemorySessionRepository()
+	repo := NewInMemoryParticipantRepository(sessionRepo)
+
+	sceneID := "scene-123"
+	streamID, _, err := sessionRepo.CreateStreamSession(&sceneID, nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("Failed to create stream session: %v", err)
+	}
+
+	participantID := "user-abc123"
+
+	if _, err := repo.ResumeParticipant(streamID, participantID); err != ErrParticipantNotFound {
+		t.Errorf("Expected ErrParticipantNotFound before joining, got %v", err)
+	}
+
+	joined, _, err := repo.RecordJoin(streamID, participantID, "did:plc:abc123")
+	if err != nil {
+		t.Fatalf("Failed to join: %v", err)
+	}
+	if _, err := repo.ResumeParticipant(streamID, participantID); err != ErrParticipantAlreadyActive {
+		t.Errorf("Expected ErrParticipantAlreadyActive while active, got %v", err)
+	}
+
+	if err := repo.RecordLeave(streamID, participantID); err != nil {
+		t.Fatalf("Failed to leave: %v", err)
+	}
+	resumed, err := repo.ResumeParticipant(streamID, participantID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resumed.ID != joined.ID {
+		t.Errorf("Expected the original record %s to be reopened, got %s", joined.ID, resumed.ID)
+	}
+	if resumed.LeftAt != nil {
+		t.Error("Expected resumed participant to have no left_at")
+	}
+	if resumed.ReconnectionCount != 1 {
+		t.Errorf("Expected reconnection count 1, got %d", resumed.ReconnectionCount)
+	}
+
+	history, err := repo.GetParticipantHistory(streamID)
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	if len(history) != 1 {
+		t.Errorf("Expected a single participant record, got %d", len(history))
+	}
+	if count, _ := repo.GetActiveCount(streamID); count != 1 {
+		t.Errorf("Expected 1 active participant, got %d", count)
+	}
+}
+
+func TestInMemoryParticipantRepository_ReconnectionGrace(t *testing.T) {
+	tests := []struct {
+		name        string
+		grace       time.Duration
+		away        time.Duration
+		wantRecords int
+	}{
+		{name: "rejoin inside window reopens record", grace: 10 * time.Second, away: 5 * time.Second, wantRecords: 1},
+		{name: "rejoin at window edge creates record", grace: 10 * time.Second, away: 10 * time.Second, wantRecords: 2},
+		{name: "rejoin outside window creates record", grace: 10 * time.Second, away: time.Minute, wantRecords: 2},
+		{name: "no window creates record", grace: 0, away: time.Millisecond, wantRecords: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionRepo := NewInMemorySessionRepository()
+			repo := NewInMemoryParticipantRepository(sessionRepo, WithReconnectionGrace(tt.grace))
+			now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+			repo.timeNow = func() time.Time { return now }
+
+			sceneID := "scene-123"
+			streamID, _, err := sessionRepo.CreateStreamSession(&sceneID, nil, "did:plc:host123")
+			if err != nil {
+				t.Fatalf("Failed to create stream session: %v", err)
+			}
+			participantID := "user-abc123"
+
+			joined, _, err := repo.RecordJoin(streamID, participantID, "did:plc:abc123")
+			if err != nil {
+				t.Fatalf("Failed to join: %v", err)
+			}
+			if err := repo.RecordLeave(streamID, participantID); err != nil {
+				t.Fatalf("Failed to leave: %v", err)
+			}
+
+			now = now.Add(tt.away)
+			rejoined, isReconnection, err := repo.RecordJoin(streamID, participantID, "did:plc:abc123")
+			if err != nil {
+				t.Fatalf("Failed to rejoin: %v", err)
+			}
+			if !isReconnection {
+				t.Error("Expected isReconnection to be true")
+			}
+			if rejoined.ReconnectionCount != 1 {
+				t.Errorf("Expected reconnection count 1, got %d", rejoined.ReconnectionCount)
+			}
+			if !rejoined.IsActive() {
+				t.Error("Expected rejoined participant to be active")
+			}
+			if reopened := rejoined.ID == joined.ID; reopened != (tt.wantRecords == 1) {
+				t.Errorf("Expected record reopened = %v, got %v", tt.wantRecords == 1, reopened)
+			}
+
+			history, err := repo.GetParticipantHistory(streamID)
+			if err != nil {
+				t.Fatalf("Failed to get history: %v", err)
+			}
+			if len(history) != tt.wantRecords {
+				t.Errorf("Expected %d participant records, got %d", tt.wantRecords, len(history))
+			}
+			if count, _ := repo.GetActiveCount(streamID); count != 1 {
+				t.Errorf("Expected 1 active participant, got %d", count)
+			}
+			if session, _ := sessionRepo.GetByID(streamID); session.ActiveParticipantCount != 1 {
+				t.Errorf("Expected denormalized count 1, got %d", session.ActiveParticipantCount)
+			}
+		})
+	}
+}
+
+func TestInMemoryParticipantRepository_RaiseHand(t *testing.T) {
+	sessionRepo := NewInMemorySessionRepository()
+	repo := NewInMemoryParticipantRepository(sessionRepo)
+
+	sceneID := "scene-123"
+	streamID, _, err := sessionRepo.CreateStreamSession(&sceneID, nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("Failed to create stream session: %v", err)
+	}
+
+	participantID := "user-abc123"
+
+	if _, err := repo.RaiseHand(streamID, participantID); err != ErrParticipantNotFound {
+		t.Errorf("Expected ErrParticipantNotFound before joining, got %v", err)
+	}
+
+	if _, _, err := repo.RecordJoin(streamID, participantID, "did:plc:abc123"); err != nil {
+		t.Fatalf("Failed to join: %v", err)
+	}
+	if _, err := repo.LowerHand(streamID, participantID); err != ErrHandNotRaised {
+		t.Errorf("Expected ErrHandNotRaised before raising, got %v", err)
+	}
+
+	raised, err := repo.RaiseHand(streamID, participantID)
+	if err != nil {
+		t.Fatalf("RaiseHand failed: %v", err)
+	}
+	if raised.HandRaisedAt == nil {
+		t.Error("Expected hand_raised_at to be set")
+	}
+	if _, err := repo.RaiseHand(streamID, participantID); err != ErrHandAlreadyRaised {
+		t.Errorf("Expected ErrHandAlreadyRaised on second raise, got %v", err)
+	}
+
+	lowered, err := repo.LowerHand(streamID, participantID)
+	if err != nil {
+		t.Fatalf("LowerHand failed: %v", err)
+	}
+	if lowered.HandRaisedAt != nil {
+		t.Error("Expected hand_raised_at to be cleared")
+	}
+
+	// Leaving lowers a raised hand, and it stays lowered on return
+	if _, err := repo.RaiseHand(streamID, participantID); err != nil {
+		t.Fatalf("RaiseHand failed: %v", err)
+	}
+	if err := repo.RecordLeave(streamID, participantID); err != nil {
+		t.Fatalf("Failed to leave: %v", err)
+	}
+	resumed, err := repo.ResumeParticipant(streamID, participantID)
+	if err != nil {
+		t.Fatalf("Failed to resume: %v", err)
+	}
+	if resumed.HandRaisedAt != nil {
+		t.Error("Expected leaving to lower the raised hand")
+	}
+}
+
+func TestInMemoryParticipantRepository_SetMuted(t *testing.T) {
+	sessionRepo := NewInMemorySessionRepository()
+	repo := NewInMemoryParticipantRepository(sessionRepo)
+
+	sceneID := "scene-123"
+	streamID, _, err := sessionRepo.CreateStreamSession(&sceneID, nil, "did:plc:host123")
+	if err != nil {
+		t.Fatalf("Failed to create stream session: %v", err)
+	}
+
+	participantID := "user-abc123"
+
+	if err := repo.SetMuted(streamID, participantID, true); err != ErrParticipantNotFound {
+		t.Errorf("Expected ErrParticipantNotFound before joining, got %v", err)
+	}
+
+	if _, _, err := repo.RecordJoin(streamID, participantID, "did:plc:abc123"); err != nil {
+		t.Fatalf("Failed to join: %v", err)
+	}
+	if err := repo.SetMuted(streamID, participantID, true); err != nil {
+		t.Fatalf("SetMuted failed: %v", err)
+	}
+	active, err := repo.GetActiveParticipants(streamID)
+	if err != nil {
+		t.Fatalf("Failed to get active participants: %v", err)
+	}
+	if len(active) != 1 || !active[0].Muted {
+		t.Errorf("Expected one muted participant, got %+v", active)
+	}
+
+	// Leaving clears the mute
+	if err := repo.RecordLeave(streamID, participantID); err != nil {
+		t.Fatalf("Failed to leave: %v", err)
+	}
+	resumed, err := repo.ResumeParticipant(streamID, participantID)
+	if err != nil {
+		t.Fatalf("Failed to resume: %v", err)
+	}
+	if resumed.Muted {
+		t.Error("Expected leaving to clear the mute")
+	}
+}
+
 func TestInMemoryParticipantRepository_GetActiveParticipants(t *testing.T) {
 	sessionRepo := NewInMemorySessionRepository()
 	repo := NewInMemoryParticipantRepository(sessionRepo)
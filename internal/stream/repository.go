@@ -3,19 +3,71 @@
 package stream
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/onnwee/subcults/internal/jsontime"
 )
 
 // Common errors for stream session operations.
 var (
-	ErrStreamNotFound = errors.New("stream session not found")
+	ErrStreamNotFound     = errors.New("stream session not found")
+	ErrInvalidCursor      = errors.New("invalid stream cursor")
+	ErrStreamNotScheduled = errors.New("stream session is not scheduled")
 )
 
+// Session status values, also used as ListStreams filters.
+const (
+	StatusScheduled = "scheduled"
+	StatusActive    = "active"
+	StatusEnded     = "ended"
+)
+
+// ListOptions filters and paginates ListStreams.
+type ListOptions struct {
+	Status string    // StatusScheduled, StatusActive, StatusEnded, or empty for all
+	From   time.Time // Only sessions started at or after From (zero = unbounded)
+	To     time.Time // Only sessions started before To (zero = unbounded)
+	Limit  int       // Max results per page
+	Cursor string    // Pagination cursor from a previous page
+}
+
+// ListCursor is the pagination position for ListStreams: the start time and ID
+// of the last session on the previous page.
+type ListCursor struct {
+	StartedAt time.Time `json:"started_at"`
+	ID        string    `json:"id"`
+}
+
+// EncodeListCursor encodes a stream list cursor to a base64 string.
+func EncodeListCursor(startedAt time.Time, id string) string {
+	data, _ := json.Marshal(ListCursor{StartedAt: startedAt, ID: id})
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// DecodeListCursor decodes a base64 stream list cursor.
+// Returns (nil, nil) for empty input, or ErrInvalidCursor for malformed cursors.
+func DecodeListCursor(encoded string) (*ListCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	data, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var cursor ListCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
 // Session represents a LiveKit audio room streaming session.
 type Session struct {
 	ID               string  `json:"id"`
@@ -42,10 +94,59 @@ type Session struct {
 	IsLocked            bool    `json:"is_locked"`                      // When true, new participants cannot join
 	FeaturedParticipant *string `json:"featured_participant,omitempty"` // ParticipantID of featured/spotlighted speaker
 
+	// JoinPolicy restricts joins to scene members or followers.
+	// Backed by DB column `join_policy` (see migrations/000034_add_stream_join_policy.up.sql).
+	JoinPolicy JoinPolicy `json:"join_policy"`
+
+	// Region is the LiveKit region requested for the stream at creation; empty if none.
+	// Backed by DB column `region` (see migrations/000039_add_stream_region.up.sql).
+	Region string `json:"region,omitempty"`
+
+	// MaxParticipants caps how many participants may be active at once; nil means
+	// unlimited. The host can always join.
+	// Backed by DB column `max_participants` (see migrations/000048_add_stream_max_participants.up.sql).
+	MaxParticipants *int `json:"max_participants,omitempty"`
+
+	// ScheduledStartAt is the announced go-live time of a stream created ahead of
+	// time; nil for streams created live. It is cleared when the host starts the
+	// stream, and until then the session has no LiveKit room and can't be joined.
+	// Scheduled sessions still hold their scene's or event's open-stream slot.
+	// Backed by DB column `scheduled_start_at` (see migrations/000049_add_stream_scheduled_start.up.sql).
+	ScheduledStartAt *time.Time `json:"scheduled_start_at,omitempty"`
+
+	// RecordingURL is where LiveKit egress stored the stream's recording; nil
+	// if the stream wasn't recorded or the recording hasn't finished.
+	// Backed by DB column `recording_url` (see migrations/000055_add_stream_recording_url.up.sql).
+	RecordingURL *string `json:"recording_url,omitempty"`
+
+	// StartedAt is when the session was created, or when it went live if it
+	// was scheduled.
 	StartedAt time.Time  `json:"started_at"`
 	EndedAt   *time.Time `json:"ended_at,omitempty"`
 }
 
+// Status reports whether the session is scheduled, active, or ended.
+func (s *Session) Status() string {
+	switch {
+	case s.EndedAt != nil:
+		return StatusEnded
+	case s.ScheduledStartAt != nil:
+		return StatusScheduled
+	default:
+		return StatusActive
+	}
+}
+
+// StartTime returns when the stream starts or started: ScheduledStartAt while
+// the stream is scheduled, StartedAt otherwise. Discovery can pass it to
+// ranking.RecencyWeight so announced streams rank by their go-live time.
+func (s *Session) StartTime() time.Time {
+	if s.Status() == StatusScheduled {
+		return *s.ScheduledStartAt
+	}
+	return s.StartedAt
+}
+
 // UpsertResult tracks statistics for upsert operations.
 type UpsertResult struct {
 	Inserted bool   // True if new record was inserted
@@ -55,9 +156,12 @@ type UpsertResult struct {
 // ActiveStreamInfo represents active stream information for event payload serialization.
 // Only includes fields needed for frontend display.
 type ActiveStreamInfo struct {
-	StreamSessionID string    `json:"stream_session_id"`
-	RoomName        string    `json:"room_name"`
-	StartedAt       time.Time `json:"started_at"`
+	StreamSessionID string        `json:"stream_session_id"`
+	RoomName        string        `json:"room_name"`
+	StartedAt       jsontime.Time `json:"started_at,omitzero"`
+
+	// ScheduledStartAt is set while the stream is announced but not yet live.
+	ScheduledStartAt jsontime.Time `json:"scheduled_start_at,omitzero"`
 }
 
 // SessionRepository defines the interface for stream session data operations.
@@ -97,6 +201,33 @@ type SessionRepository interface {
 	// Returns ErrStreamNotFound if session doesn't exist.
 	SetLockStatus(id string, locked bool) error
 
+	// SetJoinPolicy updates the join_policy for a stream session.
+	// Returns ErrInvalidJoinPolicy for unknown policies and ErrStreamNotFound if session doesn't exist.
+	SetJoinPolicy(id string, policy JoinPolicy) error
+
+	// SetRegion records the LiveKit region requested for the session.
+	// Returns ErrStreamNotFound if session doesn't exist.
+	SetRegion(id string, region string) error
+
+	// SetMaxParticipants sets or clears the session's participant cap.
+	// Pass nil to remove the cap.
+	// Returns ErrStreamNotFound if session doesn't exist.
+	SetMaxParticipants(id string, maxParticipants *int) error
+
+	// SetRecordingURL records where the session's recording was stored.
+	// Returns ErrStreamNotFound if session doesn't exist.
+	SetRecordingURL(id string, url string) error
+
+	// SetScheduledStart marks a session as scheduled to go live at startAt.
+	// Returns ErrStreamNotFound if session doesn't exist.
+	SetScheduledStart(id string, startAt time.Time) error
+
+	// StartScheduledSession takes a scheduled session live: it clears
+	// scheduled_start_at and sets started_at to now.
+	// Returns ErrStreamNotFound if session doesn't exist and
+	// ErrStreamNotScheduled if it is already live or has ended.
+	StartScheduledSession(id string) error
+
 	// SetFeaturedParticipant sets or clears the featured participant for a stream session.
 	// Pass nil participantID to clear the featured participant.
 	// Returns ErrStreamNotFound if session doesn't exist.
@@ -106,8 +237,8 @@ type SessionRepository interface {
 	HasActiveStreamForScene(sceneID string) (bool, error)
 
 	// HasActiveStreamsForScenes returns a map of scene IDs to their active stream status.
-	// Returns true for scenes with at least one active stream (ended_at IS NULL).
-	// This is a batch operation to avoid N+1 queries.
+	// Returns true for scenes with at least one live stream; scheduled streams
+	// don't count. This is a batch operation to avoid N+1 queries.
 	HasActiveStreamsForScenes(sceneIDs []string) (map[string]bool, error)
 
 	// GetActiveStreamForEvent retrieves the active stream (ended_at IS NULL) for a given event.
@@ -118,21 +249,43 @@ type SessionRepository interface {
 	// Only includes events with active streams (ended_at IS NULL).
 	// This is a batch operation to avoid N+1 queries.
 	GetActiveStreamsForEvents(eventIDs []string) (map[string]*ActiveStreamInfo, error)
+
+	// ListActiveByHost returns all active sessions (ended_at IS NULL) hosted by hostDID,
+	// oldest first. Returns an empty slice if the host has no active sessions.
+	ListActiveByHost(hostDID string) ([]*Session, error)
+
+	// ListActiveForScene returns all live sessions attached to the scene directly or
+	// to one of eventIDs (the scene's events), oldest first, excluding scheduled
+	// sessions. Returns an empty slice if there are none.
+	ListActiveForScene(sceneID string, eventIDs []string) ([]*Session, error)
+
+	// ListStreams returns sessions matching opts, newest first by started_at with ID as
+	// a tie-breaker. Returns the page and a cursor for the next one (empty if no more).
+	// Returns ErrInvalidCursor if opts.Cursor is malformed.
+	ListStreams(opts ListOptions) ([]*Session, string, error)
+
+	// ListByScene returns active and ended sessions attached to the scene directly or
+	// to one of eventIDs, newest first by started_at with ID as a tie-breaker. Sessions
+	// at or before cursor are skipped. Returns the page and the cursor for the next one
+	// (nil if no more).
+	ListByScene(sceneID string, eventIDs []string, limit int, cursor *ListCursor) ([]*Session, *ListCursor, error)
 }
 
 // InMemorySessionRepository is an in-memory implementation of SessionRepository.
 // Thread-safe via RWMutex.
 type InMemorySessionRepository struct {
-	mu       sync.RWMutex
-	sessions map[string]*Session // UUID -> Session
-	keys     map[string]string   // "did:rkey" -> UUID
+	mu           sync.RWMutex
+	sessions     map[string]*Session           // UUID -> Session
+	keys         map[string]string             // "did:rkey" -> UUID
+	roomNameFunc func(sessionID string) string // For testability
 }
 
 // NewInMemorySessionRepository creates a new in-memory session repository.
 func NewInMemorySessionRepository() *InMemorySessionRepository {
 	return &InMemorySessionRepository{
-		sessions: make(map[string]*Session),
-		keys:     make(map[string]string),
+		sessions:     make(map[string]*Session),
+		keys:         make(map[string]string),
+		roomNameFunc: GenerateRoomName,
 	}
 }
 
@@ -246,8 +399,164 @@ func (r *InMemorySessionRepository) HasActiveStreamForScene(sceneID string) (boo
 	return false, nil
 }
 
+// ListActiveByHost returns all active sessions hosted by hostDID, oldest first.
+func (r *InMemorySessionRepository) ListActiveByHost(hostDID string) ([]*Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Session, 0)
+	for _, session := range r.sessions {
+		if session.HostDID == hostDID && session.EndedAt == nil {
+			sessionCopy := *session
+			result = append(result, &sessionCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StartedAt.Equal(result[j].StartedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+
+	return result, nil
+}
+
+// ListActiveForScene returns all active sessions for a scene or its events, oldest first.
+// Scheduled sessions aren't live yet and are excluded.
+func (r *InMemorySessionRepository) ListActiveForScene(sceneID string, eventIDs []string) ([]*Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	eventIDSet := make(map[string]bool, len(eventIDs))
+	for _, id := range eventIDs {
+		eventIDSet[id] = true
+	}
+
+	result := make([]*Session, 0)
+	for _, session := range r.sessions {
+		if session.Status() != StatusActive {
+			continue
+		}
+		inScene := session.SceneID != nil && *session.SceneID == sceneID
+		forEvent := session.EventID != nil && eventIDSet[*session.EventID]
+		if inScene || forEvent {
+			sessionCopy := *session
+			result = append(result, &sessionCopy)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StartedAt.Equal(result[j].StartedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+
+	return result, nil
+}
+
+// ListStreams returns sessions matching opts, newest first, with cursor pagination.
+func (r *InMemorySessionRepository) ListStreams(opts ListOptions) ([]*Session, string, error) {
+	cursor, err := DecodeListCursor(opts.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Session, 0)
+	for _, session := range r.sessions {
+		if opts.Status != "" && session.Status() != opts.Status {
+			continue
+		}
+		if !opts.From.IsZero() && session.StartedAt.Before(opts.From) {
+			continue
+		}
+		if !opts.To.IsZero() && !session.StartedAt.Before(opts.To) {
+			continue
+		}
+		// Skip everything up to and including the cursor position
+		if cursor != nil {
+			if session.StartedAt.After(cursor.StartedAt) {
+				continue
+			}
+			if session.StartedAt.Equal(cursor.StartedAt) && session.ID <= cursor.ID {
+				continue
+			}
+		}
+		sessionCopy := *session
+		result = append(result, &sessionCopy)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StartedAt.Equal(result[j].StartedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].StartedAt.After(result[j].StartedAt)
+	})
+
+	var nextCursor string
+	if opts.Limit > 0 && len(result) > opts.Limit {
+		result = result[:opts.Limit]
+		last := result[len(result)-1]
+		nextCursor = EncodeListCursor(last.StartedAt, last.ID)
+	}
+
+	return result, nextCursor, nil
+}
+
+// ListByScene returns a scene's sessions, newest first, with cursor pagination.
+func (r *InMemorySessionRepository) ListByScene(sceneID string, eventIDs []string, limit int, cursor *ListCursor) ([]*Session, *ListCursor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	eventIDSet := make(map[string]bool, len(eventIDs))
+	for _, id := range eventIDs {
+		eventIDSet[id] = true
+	}
+
+	result := make([]*Session, 0)
+	for _, session := range r.sessions {
+		inScene := session.SceneID != nil && *session.SceneID == sceneID
+		forEvent := session.EventID != nil && eventIDSet[*session.EventID]
+		if !inScene && !forEvent {
+			continue
+		}
+		// Skip everything up to and including the cursor position
+		if cursor != nil {
+			if session.StartedAt.After(cursor.StartedAt) {
+				continue
+			}
+			if session.StartedAt.Equal(cursor.StartedAt) && session.ID <= cursor.ID {
+				continue
+			}
+		}
+		sessionCopy := *session
+		result = append(result, &sessionCopy)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].StartedAt.Equal(result[j].StartedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].StartedAt.After(result[j].StartedAt)
+	})
+
+	var nextCursor *ListCursor
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+		last := result[len(result)-1]
+		nextCursor = &ListCursor{StartedAt: last.StartedAt, ID: last.ID}
+	}
+
+	return result, nextCursor, nil
+}
+
 // HasActiveStreamsForScenes returns a map of scene IDs to their active stream status.
-// Returns true for scenes with at least one active stream (ended_at IS NULL).
+// Returns true for scenes with at least one active stream; scheduled streams
+// that haven't gone live don't count.
 // This is a batch operation to avoid N+1 queries.
 func (r *InMemorySessionRepository) HasActiveStreamsForScenes(sceneIDs []string) (map[string]bool, error) {
 	r.mu.RLock()
@@ -267,7 +576,7 @@ func (r *InMemorySessionRepository) HasActiveStreamsForScenes(sceneIDs []string)
 
 	// Check for active streams
 	for _, session := range r.sessions {
-		if session.SceneID != nil && sceneIDSet[*session.SceneID] && session.EndedAt == nil {
+		if session.SceneID != nil && sceneIDSet[*session.SceneID] && session.Status() == StatusActive {
 			result[*session.SceneID] = true
 		}
 	}
@@ -291,18 +600,20 @@ func (r *InMemorySessionRepository) CreateStreamSession(sceneID *string, eventID
 		return "", "", errors.New("either scene_id or event_id must be provided")
 	}
 
-	// Generate room name using naming convention: scene-{sceneId}-{timestamp} or event-{eventId}-{timestamp}
-	now := time.Now()
-	timestamp := now.Unix()
-
-	if sceneID != nil && *sceneID != "" {
-		roomName = fmt.Sprintf("scene-%s-%d", *sceneID, timestamp)
-	} else {
-		roomName = fmt.Sprintf("event-%s-%d", *eventID, timestamp)
+	// Generate a room name no active room is using; see GenerateRoomName for the format
+	newID := uuid.New().String()
+	for attempt := 0; ; attempt++ {
+		if attempt == maxRoomNameAttempts {
+			return "", "", errors.New("failed to generate a unique room name")
+		}
+		roomName = r.roomNameFunc(newID)
+		if !r.activeRoomNameInUse(roomName) {
+			break
+		}
 	}
 
 	// Create new session
-	newID := uuid.New().String()
+	now := time.Now()
 	session := &Session{
 		ID:                     newID,
 		SceneID:                sceneID,
@@ -315,6 +626,7 @@ func (r *InMemorySessionRepository) CreateStreamSession(sceneID *string, eventID
 		LeaveCount:             0,
 		IsLocked:               false,
 		FeaturedParticipant:    nil,
+		JoinPolicy:             JoinPolicyPublic,
 		StartedAt:              now,
 		EndedAt:                nil, // Active stream
 	}
@@ -323,6 +635,17 @@ func (r *InMemorySessionRepository) CreateStreamSession(sceneID *string, eventID
 	return newID, roomName, nil
 }
 
+// activeRoomNameInUse reports whether an active session already uses roomName.
+// Caller must hold r.mu.
+func (r *InMemorySessionRepository) activeRoomNameInUse(roomName string) bool {
+	for _, session := range r.sessions {
+		if session.EndedAt == nil && session.RoomName == roomName {
+			return true
+		}
+	}
+	return false
+}
+
 // EndStreamSession marks a stream session as ended by setting ended_at timestamp.
 // Returns ErrStreamNotFound if session doesn't exist.
 // Idempotent: returns nil if session is already ended.
@@ -407,6 +730,109 @@ func (r *InMemorySessionRepository) SetLockStatus(id string, locked bool) error
 	return nil
 }
 
+// SetJoinPolicy updates the join_policy for a stream session.
+// Returns ErrInvalidJoinPolicy for unknown policies and ErrStreamNotFound if session doesn't exist.
+func (r *InMemorySessionRepository) SetJoinPolicy(id string, policy JoinPolicy) error {
+	if !policy.Valid() {
+		return ErrInvalidJoinPolicy
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return ErrStreamNotFound
+	}
+
+	session.JoinPolicy = policy
+	return nil
+}
+
+// SetRegion records the LiveKit region requested for the session.
+// Returns ErrStreamNotFound if session doesn't exist.
+func (r *InMemorySessionRepository) SetRegion(id string, region string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return ErrStreamNotFound
+	}
+
+	session.Region = region
+	return nil
+}
+
+// SetMaxParticipants sets or clears the session's participant cap.
+// Returns ErrStreamNotFound if session doesn't exist.
+func (r *InMemorySessionRepository) SetMaxParticipants(id string, maxParticipants *int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return ErrStreamNotFound
+	}
+
+	if maxParticipants != nil {
+		capCopy := *maxParticipants
+		maxParticipants = &capCopy
+	}
+	session.MaxParticipants = maxParticipants
+	return nil
+}
+
+// SetRecordingURL records where the session's recording was stored.
+// Returns ErrStreamNotFound if session doesn't exist.
+func (r *InMemorySessionRepository) SetRecordingURL(id string, url string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return ErrStreamNotFound
+	}
+
+	session.RecordingURL = &url
+	return nil
+}
+
+// SetScheduledStart marks a session as scheduled to go live at startAt.
+// Returns ErrStreamNotFound if session doesn't exist.
+func (r *InMemorySessionRepository) SetScheduledStart(id string, startAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return ErrStreamNotFound
+	}
+
+	session.ScheduledStartAt = &startAt
+	return nil
+}
+
+// StartScheduledSession takes a scheduled session live.
+// Returns ErrStreamNotFound if session doesn't exist and
+// ErrStreamNotScheduled if it is already live or has ended.
+func (r *InMemorySessionRepository) StartScheduledSession(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return ErrStreamNotFound
+	}
+	if session.Status() != StatusScheduled {
+		return ErrStreamNotScheduled
+	}
+
+	session.ScheduledStartAt = nil
+	session.StartedAt = time.Now()
+	return nil
+}
+
 // SetFeaturedParticipant sets or clears the featured participant for a stream session.
 // Pass nil participantID to clear the featured participant.
 // Returns ErrStreamNotFound if session doesn't exist.
@@ -435,11 +861,13 @@ func (r *InMemorySessionRepository) GetActiveStreamForEvent(eventID string) (*Ac
 	for _, session := range r.sessions {
 		if session.EventID != nil && *session.EventID == eventID && session.EndedAt == nil {
 			// If multiple active streams exist for this event, use the most recent one
-			if best == nil || session.StartedAt.After(best.StartedAt) {
+			if best == nil || session.StartedAt.After(best.StartedAt.Time) {
 				best = &ActiveStreamInfo{
 					StreamSessionID: session.ID,
 					RoomName:        session.RoomName,
-					StartedAt:       session.StartedAt,
+					StartedAt:       jsontime.New(session.StartedAt),
+
+					ScheduledStartAt: jsontime.FromPtr(session.ScheduledStartAt),
 				}
 			}
 		}
@@ -470,11 +898,13 @@ func (r *InMemorySessionRepository) GetActiveStreamsForEvents(eventIDs []string)
 			// If multiple active streams exist for an event, use the most recent one
 			eventID := *session.EventID
 			existing, exists := result[eventID]
-			if !exists || session.StartedAt.After(existing.StartedAt) {
+			if !exists || session.StartedAt.After(existing.StartedAt.Time) {
 				result[eventID] = &ActiveStreamInfo{
 					StreamSessionID: session.ID,
 					RoomName:        session.RoomName,
-					StartedAt:       session.StartedAt,
+					StartedAt:       jsontime.New(session.StartedAt),
+
+					ScheduledStartAt: jsontime.FromPtr(session.ScheduledStartAt),
 				}
 			}
 		}
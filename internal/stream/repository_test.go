@@ -1,7 +1,7 @@
 package stream
 
 import (
-	"strings"
+	"errors"
 	"testing"
 	"time"
 )
@@ -313,6 +313,101 @@ func timePtr() *time.Time {
 	return &t
 }
 
+func TestSessionRepository_ListActiveByHost(t *testing.T) {
+	repo := NewInMemorySessionRepository()
+	sceneID := "scene-1"
+	eventID := "event-1"
+
+	sceneStreamID, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host1")
+	if err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+	eventStreamID, _, err := repo.CreateStreamSession(nil, &eventID, "did:plc:host1")
+	if err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+	endedID, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host1")
+	if err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+	if err := repo.EndStreamSession(endedID); err != nil {
+		t.Fatalf("EndStreamSession failed: %v", err)
+	}
+	if _, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host2"); err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+
+	sessions, err := repo.ListActiveByHost("did:plc:host1")
+	if err != nil {
+		t.Fatalf("ListActiveByHost failed: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("Expected 2 active sessions, got %d", len(sessions))
+	}
+	got := map[string]bool{sessions[0].ID: true, sessions[1].ID: true}
+	if !got[sceneStreamID] || !got[eventStreamID] {
+		t.Errorf("Expected sessions %s and %s, got %v", sceneStreamID, eventStreamID, got)
+	}
+
+	none, err := repo.ListActiveByHost("did:plc:nobody")
+	if err != nil {
+		t.Fatalf("ListActiveByHost failed: %v", err)
+	}
+	if none == nil || len(none) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %v", none)
+	}
+}
+
+func TestSessionRepository_ListActiveForScene(t *testing.T) {
+	repo := NewInMemorySessionRepository()
+	sceneID := "scene-1"
+	otherSceneID := "scene-2"
+	eventID := "event-1"
+	otherEventID := "event-2"
+
+	sceneStreamID, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host1")
+	if err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+	eventStreamID, _, err := repo.CreateStreamSession(nil, &eventID, "did:plc:host2")
+	if err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+	endedID, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host1")
+	if err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+	if err := repo.EndStreamSession(endedID); err != nil {
+		t.Fatalf("EndStreamSession failed: %v", err)
+	}
+	if _, _, err := repo.CreateStreamSession(&otherSceneID, nil, "did:plc:host3"); err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+	if _, _, err := repo.CreateStreamSession(nil, &otherEventID, "did:plc:host3"); err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+
+	sessions, err := repo.ListActiveForScene(sceneID, []string{eventID})
+	if err != nil {
+		t.Fatalf("ListActiveForScene failed: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("Expected 2 active sessions, got %d", len(sessions))
+	}
+	got := map[string]bool{sessions[0].ID: true, sessions[1].ID: true}
+	if !got[sceneStreamID] || !got[eventStreamID] {
+		t.Errorf("Expected sessions %s and %s, got %v", sceneStreamID, eventStreamID, got)
+	}
+
+	none, err := repo.ListActiveForScene("scene-empty", nil)
+	if err != nil {
+		t.Fatalf("ListActiveForScene failed: %v", err)
+	}
+	if none == nil || len(none) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %v", none)
+	}
+}
+
 func TestSessionRepository_HasActiveStreamsForScenes(t *testing.T) {
 	repo := NewInMemorySessionRepository()
 
@@ -394,9 +489,9 @@ func TestSessionRepository_CreateStreamSession_WithSceneID(t *testing.T) {
 		t.Error("Expected non-empty session ID")
 	}
 
-	// Verify room name format: scene-{sceneId}-{timestamp}
-	if !strings.Contains(roomName, "scene-scene-123-") {
-		t.Errorf("Expected room name to contain 'scene-scene-123-', got %s", roomName)
+	// Verify room name format: room-{sessionId}-{token}
+	if sessionID, ok := SessionIDFromRoomName(roomName); !ok || sessionID != id {
+		t.Errorf("Expected room name to encode session ID %s, got %s", id, roomName)
 	}
 
 	// Verify session was created
@@ -436,9 +531,9 @@ func TestSessionRepository_CreateStreamSession_WithEventID(t *testing.T) {
 		t.Error("Expected non-empty session ID")
 	}
 
-	// Verify room name format: event-{eventId}-{timestamp}
-	if !strings.Contains(roomName, "event-event-789-") {
-		t.Errorf("Expected room name to contain 'event-event-789-', got %s", roomName)
+	// Verify room name format: room-{sessionId}-{token}
+	if sessionID, ok := SessionIDFromRoomName(roomName); !ok || sessionID != id {
+		t.Errorf("Expected room name to encode session ID %s, got %s", id, roomName)
 	}
 
 	// Verify session was created
@@ -807,6 +902,181 @@ func TestSessionRepository_SetLockStatus(t *testing.T) {
 	}
 }
 
+// TestSessionRepository_SetJoinPolicy tests the SetJoinPolicy method.
+func TestSessionRepository_SetJoinPolicy(t *testing.T) {
+	repo := NewInMemorySessionRepository()
+	sceneID := "scene-join-policy-test"
+	id, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host")
+	if err != nil {
+		t.Fatalf("CreateStreamSession() failed: %v", err)
+	}
+
+	session, _ := repo.GetByID(id)
+	if session.JoinPolicy != JoinPolicyPublic {
+		t.Errorf("JoinPolicy = %q, want %q for new session", session.JoinPolicy, JoinPolicyPublic)
+	}
+
+	if err := repo.SetJoinPolicy(id, JoinPolicyFollowersOnly); err != nil {
+		t.Fatalf("SetJoinPolicy() failed: %v", err)
+	}
+	session, _ = repo.GetByID(id)
+	if session.JoinPolicy != JoinPolicyFollowersOnly {
+		t.Errorf("JoinPolicy = %q, want %q", session.JoinPolicy, JoinPolicyFollowersOnly)
+	}
+
+	if err := repo.SetJoinPolicy(id, "invite_only"); err != ErrInvalidJoinPolicy {
+		t.Errorf("SetJoinPolicy() error = %v, want %v", err, ErrInvalidJoinPolicy)
+	}
+	if err := repo.SetJoinPolicy("nonexistent-stream-id", JoinPolicyPublic); err != ErrStreamNotFound {
+		t.Errorf("SetJoinPolicy() error = %v, want %v", err, ErrStreamNotFound)
+	}
+}
+
+// TestSessionRepository_SetMaxParticipants tests the SetMaxParticipants method.
+func TestSessionRepository_SetMaxParticipants(t *testing.T) {
+	repo := NewInMemorySessionRepository()
+	sceneID := "scene-max-participants-test"
+	id, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host")
+	if err != nil {
+		t.Fatalf("CreateStreamSession() failed: %v", err)
+	}
+
+	session, _ := repo.GetByID(id)
+	if session.MaxParticipants != nil {
+		t.Errorf("MaxParticipants = %d, want nil for new session", *session.MaxParticipants)
+	}
+
+	limit := 10
+	if err := repo.SetMaxParticipants(id, &limit); err != nil {
+		t.Fatalf("SetMaxParticipants() failed: %v", err)
+	}
+	limit = 99 // the repository keeps its own copy
+	session, _ = repo.GetByID(id)
+	if session.MaxParticipants == nil || *session.MaxParticipants != 10 {
+		t.Errorf("MaxParticipants = %v, want 10", session.MaxParticipants)
+	}
+
+	if err := repo.SetMaxParticipants(id, nil); err != nil {
+		t.Fatalf("SetMaxParticipants(nil) failed: %v", err)
+	}
+	session, _ = repo.GetByID(id)
+	if session.MaxParticipants != nil {
+		t.Errorf("MaxParticipants = %d, want nil after clearing", *session.MaxParticipants)
+	}
+
+	if err := repo.SetMaxParticipants("nonexistent-stream-id", nil); err != ErrStreamNotFound {
+		t.Errorf("SetMaxParticipants() error = %v, want %v", err, ErrStreamNotFound)
+	}
+}
+
+// TestSessionRepository_ScheduledStart tests scheduling a session and taking it live.
+func TestSessionRepository_ScheduledStart(t *testing.T) {
+	repo := NewInMemorySessionRepository()
+	sceneID := "scene-scheduled-test"
+	id, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host")
+	if err != nil {
+		t.Fatalf("CreateStreamSession() failed: %v", err)
+	}
+
+	startAt := time.Now().Add(48 * time.Hour)
+	if err := repo.SetScheduledStart(id, startAt); err != nil {
+		t.Fatalf("SetScheduledStart() failed: %v", err)
+	}
+	session, _ := repo.GetByID(id)
+	if session.Status() != StatusScheduled {
+		t.Errorf("Status() = %q, want %q", session.Status(), StatusScheduled)
+	}
+	if !session.StartTime().Equal(startAt) {
+		t.Errorf("StartTime() = %v, want the scheduled start %v", session.StartTime(), startAt)
+	}
+
+	for status, want := range map[string]int{StatusScheduled: 1, StatusActive: 0} {
+		sessions, _, err := repo.ListStreams(ListOptions{Status: status, Limit: 10})
+		if err != nil {
+			t.Fatalf("ListStreams(%s) failed: %v", status, err)
+		}
+		if len(sessions) != want {
+			t.Errorf("ListStreams(%s) returned %d sessions, want %d", status, len(sessions), want)
+		}
+	}
+
+	// A scheduled stream isn't live yet
+	if live, _ := repo.ListActiveForScene(sceneID, nil); len(live) != 0 {
+		t.Errorf("ListActiveForScene() returned %d sessions before the stream started, want 0", len(live))
+	}
+	if active, _ := repo.HasActiveStreamsForScenes([]string{sceneID}); active[sceneID] {
+		t.Error("HasActiveStreamsForScenes() reported a scheduled stream as active")
+	}
+
+	if err := repo.StartScheduledSession(id); err != nil {
+		t.Fatalf("StartScheduledSession() failed: %v", err)
+	}
+	session, _ = repo.GetByID(id)
+	if session.Status() != StatusActive || session.ScheduledStartAt != nil {
+		t.Errorf("Status() = %q, ScheduledStartAt = %v, want active with no schedule", session.Status(), session.ScheduledStartAt)
+	}
+	if !session.StartTime().Equal(session.StartedAt) {
+		t.Errorf("StartTime() = %v, want StartedAt %v once live", session.StartTime(), session.StartedAt)
+	}
+	if live, _ := repo.ListActiveForScene(sceneID, nil); len(live) != 1 {
+		t.Errorf("ListActiveForScene() returned %d sessions once live, want 1", len(live))
+	}
+
+	if err := repo.StartScheduledSession(id); err != ErrStreamNotScheduled {
+		t.Errorf("StartScheduledSession() error = %v, want %v", err, ErrStreamNotScheduled)
+	}
+	if err := repo.StartScheduledSession("nonexistent-stream-id"); err != ErrStreamNotFound {
+		t.Errorf("StartScheduledSession() error = %v, want %v", err, ErrStreamNotFound)
+	}
+}
+
+// TestSessionRepository_SetRegion tests the SetRegion method.
+func TestSessionRepository_SetRegion(t *testing.T) {
+	repo := NewInMemorySessionRepository()
+	sceneID := "scene-region-test"
+	id, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host")
+	if err != nil {
+		t.Fatalf("CreateStreamSession() failed: %v", err)
+	}
+
+	if err := repo.SetRegion(id, "eu-west"); err != nil {
+		t.Fatalf("SetRegion() failed: %v", err)
+	}
+	session, _ := repo.GetByID(id)
+	if session.Region != "eu-west" {
+		t.Errorf("Region = %q, want %q", session.Region, "eu-west")
+	}
+
+	if err := repo.SetRegion("nonexistent-stream-id", "eu-west"); err != ErrStreamNotFound {
+		t.Errorf("SetRegion() error = %v, want %v", err, ErrStreamNotFound)
+	}
+}
+
+// TestJoinPolicy_Allows tests membership eligibility under each join policy.
+func TestJoinPolicy_Allows(t *testing.T) {
+	tests := []struct {
+		policy JoinPolicy
+		role   string
+		status string
+		want   bool
+	}{
+		{JoinPolicyPublic, "", "", true},
+		{"", "", "", true},
+		{JoinPolicyMembersOnly, "member", "active", true},
+		{JoinPolicyMembersOnly, "curator", "active", true},
+		{JoinPolicyMembersOnly, "guest", "active", false},
+		{JoinPolicyMembersOnly, "member", "pending", false},
+		{JoinPolicyFollowersOnly, "guest", "active", true},
+		{JoinPolicyFollowersOnly, "member", "rejected", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.policy.Allows(tt.role, tt.status); got != tt.want {
+			t.Errorf("%q.Allows(%q, %q) = %v, want %v", tt.policy, tt.role, tt.status, got, tt.want)
+		}
+	}
+}
+
 // TestSessionRepository_SetFeaturedParticipant tests the SetFeaturedParticipant method.
 func TestSessionRepository_SetFeaturedParticipant(t *testing.T) {
 	tests := []struct {
@@ -1257,3 +1527,95 @@ func BenchmarkSetFeaturedParticipant(b *testing.B) {
 		}
 	}
 }
+
+func TestSessionRepository_ListStreams_CursorTieBreak(t *testing.T) {
+	repo := NewInMemorySessionRepository()
+	startedAt := time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	// Three sessions sharing a start time must page by ID without skips or repeats
+	for i := 0; i < 3; i++ {
+		if _, err := repo.Upsert(&Session{SceneID: strPtr("scene-1"), HostDID: "did:plc:host", StartedAt: startedAt}); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		sessions, next, err := repo.ListStreams(ListOptions{Limit: 1, Cursor: cursor})
+		if err != nil {
+			t.Fatalf("ListStreams failed: %v", err)
+		}
+		if len(sessions) != 1 {
+			t.Fatalf("page %d: expected 1 session, got %d", page, len(sessions))
+		}
+		if seen[sessions[0].ID] {
+			t.Errorf("page %d: session %s repeated", page, sessions[0].ID)
+		}
+		seen[sessions[0].ID] = true
+		if page < 2 && next == "" {
+			t.Fatalf("page %d: expected a next cursor", page)
+		}
+		if page == 2 && next != "" {
+			t.Errorf("expected no cursor after the last page, got %q", next)
+		}
+		cursor = next
+	}
+
+	if _, _, err := repo.ListStreams(ListOptions{Cursor: "%%%"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestSessionRepository_ListByScene(t *testing.T) {
+	repo := NewInMemorySessionRepository()
+	base := time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC)
+	endedAt := base.Add(3 * time.Hour)
+
+	// Scene and event sessions, active and ended, plus one in another scene
+	sessions := []*Session{
+		{SceneID: strPtr("scene-1"), HostDID: "did:plc:host", StartedAt: base, EndedAt: &endedAt},
+		{EventID: strPtr("event-1"), HostDID: "did:plc:host", StartedAt: base.Add(time.Hour), EndedAt: &endedAt},
+		{SceneID: strPtr("scene-1"), HostDID: "did:plc:host", StartedAt: base.Add(2 * time.Hour)},
+		{SceneID: strPtr("scene-2"), HostDID: "did:plc:other", StartedAt: base.Add(4 * time.Hour)},
+	}
+	for _, s := range sessions {
+		if _, err := repo.Upsert(s); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+	}
+
+	var got []*Session
+	var cursor *ListCursor
+	for page := 0; page < 3; page++ {
+		results, next, err := repo.ListByScene("scene-1", []string{"event-1"}, 2, cursor)
+		if err != nil {
+			t.Fatalf("ListByScene failed: %v", err)
+		}
+		got = append(got, results...)
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 sessions, got %d", len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if !got[i].StartedAt.Before(got[i-1].StartedAt) {
+			t.Errorf("Expected newest first, got %v before %v", got[i-1].StartedAt, got[i].StartedAt)
+		}
+	}
+	if got[0].EndedAt != nil || got[2].EndedAt == nil {
+		t.Error("Expected both active and ended sessions")
+	}
+
+	// A full page with nothing left returns no cursor
+	if _, next, err := repo.ListByScene("scene-1", []string{"event-1"}, 3, nil); err != nil || next != nil {
+		t.Errorf("Expected no next cursor, got %+v (err %v)", next, err)
+	}
+	if empty, _, _ := repo.ListByScene("scene-3", nil, 10, nil); len(empty) != 0 {
+		t.Errorf("Expected no sessions for scene-3, got %d", len(empty))
+	}
+}
@@ -0,0 +1,40 @@
+package stream
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// RoomNamePrefix starts every generated LiveKit room name.
+const RoomNamePrefix = "room-"
+
+// roomTokenLength is the length of the random token in a room name: 128 bits
+// of crypto/rand output in unpadded base32.
+const roomTokenLength = 26
+
+// maxRoomNameAttempts bounds how many names CreateStreamSession tries before
+// giving up on finding one that no active room uses.
+const maxRoomNameAttempts = 3
+
+// GenerateRoomName returns a LiveKit room name for a stream session, in the
+// format room-{sessionID}-{token}. The session ID makes the room traceable back
+// to its session in logs and LiveKit webhooks; the token is 128 random bits in
+// lowercase base32, so room names cannot be guessed from a session ID alone.
+func GenerateRoomName(sessionID string) string {
+	return RoomNamePrefix + sessionID + "-" + strings.ToLower(rand.Text())
+}
+
+// SessionIDFromRoomName extracts the session ID from a room name produced by
+// GenerateRoomName. Returns false for names in any other format, including the
+// legacy scene-{id}-{timestamp} and event-{id}-{timestamp} names.
+func SessionIDFromRoomName(roomName string) (string, bool) {
+	rest, ok := strings.CutPrefix(roomName, RoomNamePrefix)
+	if !ok {
+		return "", false
+	}
+	i := strings.LastIndex(rest, "-")
+	if i <= 0 || len(rest)-i-1 != roomTokenLength {
+		return "", false
+	}
+	return rest[:i], true
+}
@@ -0,0 +1,90 @@
+package stream
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRoomName_Unique(t *testing.T) {
+	const n = 10000
+	sessionID := "3f1c2a9e-7b4d-4c1e-9a2f-5d6e7f8a9b0c"
+
+	seen := make(map[string]bool, n)
+	for i := 0; i < n; i++ {
+		name := GenerateRoomName(sessionID)
+		if seen[name] {
+			t.Fatalf("duplicate room name after %d generations: %s", i, name)
+		}
+		seen[name] = true
+	}
+}
+
+func TestGenerateRoomName_NotGuessable(t *testing.T) {
+	sessionID := "3f1c2a9e-7b4d-4c1e-9a2f-5d6e7f8a9b0c"
+	name := GenerateRoomName(sessionID)
+
+	token := strings.TrimPrefix(name, RoomNamePrefix+sessionID+"-")
+	if token == name {
+		t.Fatalf("room name %s does not start with the session ID", name)
+	}
+	if len(token) != roomTokenLength {
+		t.Errorf("token length = %d, want %d", len(token), roomTokenLength)
+	}
+	if strings.Trim(token, "abcdefghijklmnopqrstuvwxyz234567") != "" {
+		t.Errorf("token %q is not lowercase base32", token)
+	}
+	// Knowing one room name for a session does not reveal another
+	if other := GenerateRoomName(sessionID); other == name {
+		t.Errorf("two generations for the same session produced %s", name)
+	}
+}
+
+func TestSessionIDFromRoomName(t *testing.T) {
+	sessionID := "3f1c2a9e-7b4d-4c1e-9a2f-5d6e7f8a9b0c"
+
+	got, ok := SessionIDFromRoomName(GenerateRoomName(sessionID))
+	if !ok || got != sessionID {
+		t.Errorf("SessionIDFromRoomName = %q, %v; want %q", got, ok, sessionID)
+	}
+
+	for _, name := range []string{
+		"scene-scene-123-1700000000",
+		"event-event-789-1700000000",
+		"room-" + sessionID,
+		"room--abcdefghijklmnopqrstuvwxyz",
+		"",
+	} {
+		if _, ok := SessionIDFromRoomName(name); ok {
+			t.Errorf("SessionIDFromRoomName(%q) accepted a non-generated name", name)
+		}
+	}
+}
+
+func TestCreateStreamSession_RoomNameCollision(t *testing.T) {
+	repo := NewInMemorySessionRepository()
+	sceneID := "scene-123"
+
+	// Every generated name collides with the first active session's room
+	repo.roomNameFunc = func(string) string { return "room-taken" }
+	if _, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host1"); err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+	if _, _, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host2"); err == nil {
+		t.Fatal("expected an error when every room name is taken by an active room")
+	}
+
+	// A retry picks the next name when only the first collides
+	names := []string{"room-taken", "room-free"}
+	repo.roomNameFunc = func(string) string {
+		name := names[0]
+		names = names[1:]
+		return name
+	}
+	_, roomName, err := repo.CreateStreamSession(&sceneID, nil, "did:plc:host2")
+	if err != nil {
+		t.Fatalf("CreateStreamSession failed: %v", err)
+	}
+	if roomName != "room-free" {
+		t.Errorf("room name = %q, want room-free", roomName)
+	}
+}
@@ -19,11 +19,22 @@ var (
 	ErrInvalidCharacters = errors.New("string contains invalid characters")
 	ErrSQLKeyword        = errors.New("string contains SQL keywords")
 	ErrEmpty             = errors.New("string is empty")
+	ErrTooManyTags       = errors.New("too many tags")
 )
 
+// MaxTagLength is the maximum length of a single tag, in characters.
+const MaxTagLength = 32
+
+// MaxDescriptionLength is the default maximum length of a scene or event
+// description, in characters.
+const MaxDescriptionLength = 5000
+
 // sceneNamePattern is a precompiled regex for allowed scene name characters.
 var sceneNamePattern = regexp.MustCompile(`^[A-Za-z0-9 _\-\.]+$`)
 
+// tagPattern is a precompiled regex for allowed tag characters.
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N} _\-]+$`)
+
 // StringConstraints defines validation constraints for a string.
 type StringConstraints struct {
 	MinLength        int              // Minimum length (0 = no minimum)
@@ -196,13 +207,53 @@ func PostContent(content string) (string, error) {
 
 // Description validates a description field:
 // - Optional (can be empty)
-// - Max 5000 characters
+// - Max MaxDescriptionLength characters
+// - HTML sanitized
 func Description(desc string) (string, error) {
+	return DescriptionWithMax(desc, MaxDescriptionLength)
+}
+
+// DescriptionWithMax validates a description like Description, but with a
+// caller-supplied maximum length in characters (0 = no maximum).
+func DescriptionWithMax(desc string, maxLength int) (string, error) {
 	return SanitizeString(desc, StringConstraints{
 		MinLength:        0,
-		MaxLength:        5000,
+		MaxLength:        maxLength,
 		CheckSQLKeywords: false, // Allow more freedom in descriptions
 		AllowEmpty:       true,
 		TrimSpace:        true,
 	})
 }
+
+// Tags validates and normalizes a tag list according to Subcults requirements:
+// - Each tag is trimmed and lowercased, with inner whitespace collapsed
+// - 1-32 characters each
+// - Letters, numbers, spaces, dash, underscore only
+// - Duplicates (after normalization) are dropped, keeping first occurrence order
+// - At most maxTags tags remain after deduplication (0 = no limit)
+// Errors name the offending tag.
+func Tags(tags []string, maxTags int) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+		validated, err := String(tag, StringConstraints{
+			MinLength:      1,
+			MaxLength:      MaxTagLength,
+			AllowedPattern: tagPattern,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("tag %q: %w", tag, err)
+		}
+		if _, ok := seen[validated]; ok {
+			continue
+		}
+		seen[validated] = struct{}{}
+		normalized = append(normalized, validated)
+	}
+
+	if maxTags > 0 && len(normalized) > maxTags {
+		return nil, fmt.Errorf("%w: got %d, maximum is %d", ErrTooManyTags, len(normalized), maxTags)
+	}
+	return normalized, nil
+}
@@ -389,6 +389,95 @@ func TestDescription(t *testing.T) {
 	}
 }
 
+func TestDescriptionWithMax(t *testing.T) {
+	if _, err := DescriptionWithMax(strings.Repeat("a", 11), 10); !errors.Is(err, ErrStringTooLong) {
+		t.Errorf("DescriptionWithMax() error = %v, want ErrStringTooLong", err)
+	}
+
+	// The limit counts characters before escaping
+	got, err := DescriptionWithMax("<b>hi</b>", 10)
+	if err != nil {
+		t.Fatalf("DescriptionWithMax() error = %v", err)
+	}
+	if got != "&lt;b&gt;hi&lt;/b&gt;" {
+		t.Errorf("DescriptionWithMax() = %q, want escaped HTML", got)
+	}
+}
+
+func TestTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []string
+		maxTags int
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "nil tags",
+			input:   nil,
+			maxTags: 3,
+			want:    []string{},
+		},
+		{
+			name:    "normalized",
+			input:   []string{"  Drum   and Bass ", "Música", "lo-fi_beats"},
+			maxTags: 3,
+			want:    []string{"drum and bass", "música", "lo-fi_beats"},
+		},
+		{
+			name:    "duplicates dropped before counting",
+			input:   []string{"House", "house", "HOUSE ", "techno"},
+			maxTags: 2,
+			want:    []string{"house", "techno"},
+		},
+		{
+			name:    "too many tags",
+			input:   []string{"a", "b", "c"},
+			maxTags: 2,
+			wantErr: ErrTooManyTags,
+		},
+		{
+			name:    "no limit",
+			input:   []string{"a", "b", "c"},
+			maxTags: 0,
+			want:    []string{"a", "b", "c"},
+		},
+		{
+			name:    "tag too long",
+			input:   []string{strings.Repeat("a", MaxTagLength+1)},
+			wantErr: ErrStringTooLong,
+		},
+		{
+			name:    "invalid characters",
+			input:   []string{"<b>house</b>"},
+			wantErr: ErrInvalidCharacters,
+		},
+		{
+			name:    "empty tag",
+			input:   []string{" "},
+			wantErr: ErrEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Tags(tt.input, tt.maxTags)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Tags() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Tags() unexpected error = %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") || len(got) != len(tt.want) {
+				t.Errorf("Tags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestSQLKeywordWordBoundary tests that SQL keyword detection uses word boundaries
 // to avoid false positives with legitimate names containing SQL keywords as substrings.
 // Note: SQL keyword checking is now disabled for scene names and event titles to avoid
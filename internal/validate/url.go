@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"syscall"
 	"time"
 )
 
@@ -203,6 +204,27 @@ func isPrivateIP(ip net.IP) bool {
 	return false
 }
 
+// SafeDialer returns a dialer that refuses to connect to private, loopback,
+// and link-local addresses. Unlike checkSSRF, the check runs on the address
+// actually dialed, after DNS resolution and on every redirect, so it can't be
+// bypassed by DNS rebinding. Use it for requests to user-controlled hosts.
+func SafeDialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{Timeout: timeout, Control: refusePrivateAddress}
+}
+
+// refusePrivateAddress is a net.Dialer Control function rejecting private addresses.
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSSRFRisk, err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isPrivateIP(ip) {
+		return fmt.Errorf("%w: private IP address %s", ErrSSRFRisk, host)
+	}
+	return nil
+}
+
 // AttachmentURL validates a URL for use in post attachments.
 // Uses default constraints with SSRF protection.
 func AttachmentURL(urlStr string) (string, error) {
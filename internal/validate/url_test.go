@@ -1,9 +1,11 @@
 package validate
 
 import (
+	"errors"
 	"net"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestURL(t *testing.T) {
@@ -331,6 +333,24 @@ func TestIsPrivateIP(t *testing.T) {
 	}
 }
 
+func TestSafeDialer_RefusesPrivateAddresses(t *testing.T) {
+	// A local listener stands in for an internal service
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	_, err = SafeDialer(time.Second).Dial("tcp", listener.Addr().String())
+	if !errors.Is(err, ErrSSRFRisk) {
+		t.Errorf("Dial(%s) error = %v, want ErrSSRFRisk", listener.Addr(), err)
+	}
+
+	if err := refusePrivateAddress("tcp", "8.8.8.8:443", nil); err != nil {
+		t.Errorf("refusePrivateAddress(8.8.8.8:443) error = %v, want nil", err)
+	}
+}
+
 // Helper to parse IP for testing
 func parseIP(s string) net.IP {
 	return net.ParseIP(s)
@@ -0,0 +1,3 @@
+-- Rollback migration for record_revisions table
+
+DROP TABLE IF EXISTS record_revisions;
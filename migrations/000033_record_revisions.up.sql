@@ -0,0 +1,17 @@
+-- Migration: Create record_revisions table for Jetstream indexer
+-- Purpose: Track the latest applied revision per AT Protocol record so that a
+-- delete and a later edit resolve by rev rather than by arrival order
+
+CREATE TABLE IF NOT EXISTS record_revisions (
+    did VARCHAR(255) NOT NULL,
+    collection VARCHAR(255) NOT NULL,
+    rkey VARCHAR(255) NOT NULL,
+    rev VARCHAR(255) NOT NULL,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (did, collection, rkey)
+);
+
+COMMENT ON TABLE record_revisions IS 'Latest applied revision per AT Protocol record; rows for deletes act as tombstones';
+COMMENT ON COLUMN record_revisions.rev IS 'Highest AT Protocol revision applied to the record (TIDs sort in commit order)';
+COMMENT ON COLUMN record_revisions.deleted IS 'Whether the latest applied operation was a delete';
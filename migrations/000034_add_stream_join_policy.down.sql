@@ -0,0 +1,3 @@
+-- Remove join policy from stream_sessions table
+ALTER TABLE stream_sessions
+DROP COLUMN IF EXISTS join_policy;
@@ -0,0 +1,4 @@
+-- Add join policy to stream_sessions so hosts can restrict joins to scene members or followers
+ALTER TABLE stream_sessions
+ADD COLUMN IF NOT EXISTS join_policy VARCHAR(20) NOT NULL DEFAULT 'public'
+    CHECK (join_policy IN ('public', 'members_only', 'followers_only'));
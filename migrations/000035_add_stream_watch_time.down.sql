@@ -0,0 +1,4 @@
+-- Remove per-participant watch time from stream_analytics table
+ALTER TABLE stream_analytics
+DROP COLUMN IF EXISTS avg_watch_time_seconds,
+DROP COLUMN IF EXISTS median_watch_time_seconds;
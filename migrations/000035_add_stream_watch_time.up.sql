@@ -0,0 +1,5 @@
+-- Add per-participant watch time to stream_analytics: each participant's join/leave
+-- intervals summed across reconnections, with still-connected participants leaving at stream end
+ALTER TABLE stream_analytics
+ADD COLUMN IF NOT EXISTS avg_watch_time_seconds FLOAT,
+ADD COLUMN IF NOT EXISTS median_watch_time_seconds FLOAT;
@@ -0,0 +1,2 @@
+-- Drop indexer dead-letter table
+DROP TABLE IF EXISTS indexer_dead_letters;
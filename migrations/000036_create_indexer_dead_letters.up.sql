@@ -0,0 +1,21 @@
+-- Dead-lettered Jetstream messages: records that failed validation or persistence,
+-- kept with their raw payload so operators can fix the cause and replay them
+CREATE TABLE IF NOT EXISTS indexer_dead_letters (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payload BYTEA NOT NULL,
+    did VARCHAR(255) NOT NULL DEFAULT '',
+    collection VARCHAR(255) NOT NULL DEFAULT '',
+    rkey VARCHAR(255) NOT NULL DEFAULT '',
+    rev VARCHAR(255) NOT NULL DEFAULT '',
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_indexer_dead_letters_created_at
+    ON indexer_dead_letters(created_at);
+
+COMMENT ON TABLE indexer_dead_letters IS 'Jetstream messages that failed ingestion, replayable via POST /admin/indexer/deadletter/{id}/replay';
+COMMENT ON COLUMN indexer_dead_letters.payload IS 'Raw CBOR Jetstream message as received';
+COMMENT ON COLUMN indexer_dead_letters.attempts IS 'Original ingestion attempt plus replays';
@@ -0,0 +1,7 @@
+-- Revert audit_logs actor columns
+
+DROP INDEX IF EXISTS idx_audit_logs_actor_type;
+
+-- Drop columns (constraint will be dropped automatically)
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS actor_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS actor_type;
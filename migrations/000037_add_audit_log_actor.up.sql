@@ -0,0 +1,19 @@
+-- Distinguish human actors from automated processes in audit_logs
+
+-- Who performed the action: a user, a moderator, an admin or the system
+ALTER TABLE audit_logs ADD COLUMN actor_type VARCHAR(20) NOT NULL DEFAULT 'user';
+
+-- Identifier of the system process for system actions (e.g. 'retention')
+ALTER TABLE audit_logs ADD COLUMN actor_id VARCHAR(64);
+
+-- Existing entries without a user were written by background jobs
+UPDATE audit_logs SET actor_type = 'system' WHERE user_did = '';
+
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_actor_type_check
+    CHECK (actor_type IN ('user', 'moderator', 'admin', 'system'));
+
+-- Add index for separating automated from human actions
+CREATE INDEX idx_audit_logs_actor_type ON audit_logs(actor_type, created_at DESC);
+
+COMMENT ON COLUMN audit_logs.actor_type IS 'Kind of actor: user, moderator, admin or system';
+COMMENT ON COLUMN audit_logs.actor_id IS 'System actor identifier for automated actions; NULL for human actors';
@@ -0,0 +1,5 @@
+-- Revert post announcements
+
+DROP INDEX IF EXISTS idx_posts_scene_announcements;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS is_announcement;
@@ -0,0 +1,10 @@
+-- Separate high-signal scene announcements from the regular post feed
+
+-- Set only on posts created by the scene owner or a curator
+ALTER TABLE posts ADD COLUMN is_announcement BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Add index for the announcements channel (GET /scenes/{id}/announcements)
+CREATE INDEX idx_posts_scene_announcements ON posts(scene_id, created_at DESC)
+    WHERE is_announcement AND deleted_at IS NULL;
+
+COMMENT ON COLUMN posts.is_announcement IS 'Listed in the scene announcements channel instead of the regular feed';
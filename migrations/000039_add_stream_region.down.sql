@@ -0,0 +1,3 @@
+-- Remove region from stream_sessions table
+ALTER TABLE stream_sessions
+DROP COLUMN IF EXISTS region;
@@ -0,0 +1,3 @@
+-- Add the LiveKit region a stream's room was pinned to at creation
+ALTER TABLE stream_sessions
+ADD COLUMN IF NOT EXISTS region VARCHAR(64);
@@ -0,0 +1,3 @@
+-- Remove content rating from scenes table
+ALTER TABLE scenes
+DROP COLUMN IF EXISTS content_rating;
@@ -0,0 +1,4 @@
+-- Add content rating to scenes so mature scenes can require viewer opt-in
+ALTER TABLE scenes
+ADD COLUMN IF NOT EXISTS content_rating VARCHAR(10) NOT NULL DEFAULT 'general'
+    CHECK (content_rating IN ('general', 'mature'));
@@ -0,0 +1,3 @@
+-- Remove default feed sort order from scenes table
+ALTER TABLE scenes
+DROP COLUMN IF EXISTS feed_sort;
@@ -0,0 +1,4 @@
+-- Add default feed sort order to scenes so engaged communities can opt in to a ranked feed
+ALTER TABLE scenes
+ADD COLUMN IF NOT EXISTS feed_sort VARCHAR(16) NOT NULL DEFAULT 'chronological'
+    CHECK (feed_sort IN ('chronological', 'ranked'));
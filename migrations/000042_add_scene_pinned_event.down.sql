@@ -0,0 +1,3 @@
+-- Remove pinned event from scenes table
+ALTER TABLE scenes
+DROP COLUMN IF EXISTS pinned_event_id;
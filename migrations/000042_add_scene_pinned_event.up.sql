@@ -0,0 +1,3 @@
+-- Add an owner-pinned flagship event to scenes, listed first among the scene's events
+ALTER TABLE scenes
+ADD COLUMN IF NOT EXISTS pinned_event_id UUID REFERENCES events(id) ON DELETE SET NULL;
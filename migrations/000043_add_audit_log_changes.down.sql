@@ -0,0 +1,3 @@
+-- Revert audit_logs changes column
+
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS changes;
@@ -0,0 +1,6 @@
+-- Record field-level diffs on audit_logs entries for update actions
+
+-- Changed fields as {"field": {"old": ..., "new": ...}}; sensitive values are redacted
+ALTER TABLE audit_logs ADD COLUMN changes JSONB;
+
+COMMENT ON COLUMN audit_logs.changes IS 'Fields modified by an update action with old/new values; NULL for other actions';
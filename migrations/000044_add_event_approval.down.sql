@@ -0,0 +1,13 @@
+-- Remove event approval workflow columns and statuses
+DELETE FROM events WHERE status IN ('pending_approval', 'rejected');
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_event_status;
+ALTER TABLE events ADD CONSTRAINT chk_event_status
+    CHECK (status IN ('scheduled', 'live', 'ended', 'cancelled'));
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS rejection_reason,
+DROP COLUMN IF EXISTS proposed_by_did;
+
+ALTER TABLE scenes
+DROP COLUMN IF EXISTS event_approval_required;
@@ -0,0 +1,12 @@
+-- Let scenes require owner approval for member-proposed events
+ALTER TABLE scenes
+ADD COLUMN IF NOT EXISTS event_approval_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS proposed_by_did TEXT,
+ADD COLUMN IF NOT EXISTS rejection_reason TEXT;
+
+-- Allow the approval statuses
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_event_status;
+ALTER TABLE events ADD CONSTRAINT chk_event_status
+    CHECK (status IN ('scheduled', 'live', 'ended', 'cancelled', 'pending_approval', 'rejected'));
@@ -0,0 +1,3 @@
+-- Remove raised hand tracking from stream participants
+ALTER TABLE stream_participants
+DROP COLUMN IF EXISTS hand_raised_at;
@@ -0,0 +1,3 @@
+-- Track participants asking the host for a turn to speak
+ALTER TABLE stream_participants
+ADD COLUMN IF NOT EXISTS hand_raised_at TIMESTAMPTZ;
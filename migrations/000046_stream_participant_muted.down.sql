@@ -0,0 +1,3 @@
+-- Remove host mute tracking from stream participants
+ALTER TABLE stream_participants
+DROP COLUMN IF EXISTS muted;
@@ -0,0 +1,3 @@
+-- Track host mutes so the stream roster can show them
+ALTER TABLE stream_participants
+ADD COLUMN IF NOT EXISTS muted BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+-- Remove scene welcome messages
+ALTER TABLE memberships
+DROP COLUMN IF EXISTS welcomed_at;
+
+ALTER TABLE scenes
+DROP COLUMN IF EXISTS welcome_post_template;
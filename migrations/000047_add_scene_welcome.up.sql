@@ -0,0 +1,6 @@
+-- Let scenes greet new members, and track who has been greeted
+ALTER TABLE scenes
+ADD COLUMN IF NOT EXISTS welcome_post_template TEXT;
+
+ALTER TABLE memberships
+ADD COLUMN IF NOT EXISTS welcomed_at TIMESTAMPTZ;
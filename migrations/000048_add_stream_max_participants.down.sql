@@ -0,0 +1,3 @@
+-- Remove stream participant caps
+ALTER TABLE stream_sessions
+DROP COLUMN IF EXISTS max_participants;
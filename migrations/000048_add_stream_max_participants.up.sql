@@ -0,0 +1,3 @@
+-- Let hosts cap how many participants may be in a stream at once
+ALTER TABLE stream_sessions
+ADD COLUMN IF NOT EXISTS max_participants INTEGER CHECK (max_participants > 0);
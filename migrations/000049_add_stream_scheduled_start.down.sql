@@ -0,0 +1,3 @@
+-- Remove scheduled stream start times
+ALTER TABLE stream_sessions
+DROP COLUMN IF EXISTS scheduled_start_at;
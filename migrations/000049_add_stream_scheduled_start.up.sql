@@ -0,0 +1,3 @@
+-- Let organizers announce a stream before it goes live
+ALTER TABLE stream_sessions
+ADD COLUMN IF NOT EXISTS scheduled_start_at TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+-- Remove event recurrence rules
+ALTER TABLE events
+DROP COLUMN IF EXISTS rrule;
@@ -0,0 +1,3 @@
+-- Let events recur; occurrences are expanded from the rule on read
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS rrule TEXT;
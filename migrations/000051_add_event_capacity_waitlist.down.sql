@@ -0,0 +1,14 @@
+-- Remove event capacity and the RSVP waitlist
+DROP INDEX IF EXISTS idx_event_rsvps_waitlist;
+
+DELETE FROM event_rsvps WHERE status = 'waitlisted';
+
+ALTER TABLE event_rsvps DROP CONSTRAINT IF EXISTS chk_rsvp_status;
+ALTER TABLE event_rsvps ADD CONSTRAINT chk_rsvp_status
+    CHECK (status IN ('going', 'maybe'));
+
+ALTER TABLE event_rsvps
+DROP COLUMN IF EXISTS waitlisted_at;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS capacity;
@@ -0,0 +1,16 @@
+-- Let events cap going RSVPs and waitlist the rest
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS capacity INTEGER CHECK (capacity > 0);
+
+ALTER TABLE event_rsvps
+ADD COLUMN IF NOT EXISTS waitlisted_at TIMESTAMPTZ;
+
+-- Allow the waitlisted status
+ALTER TABLE event_rsvps DROP CONSTRAINT IF EXISTS chk_rsvp_status;
+ALTER TABLE event_rsvps ADD CONSTRAINT chk_rsvp_status
+    CHECK (status IN ('going', 'maybe', 'waitlisted'));
+
+-- Waitlist promotion reads an event's waitlist oldest first
+CREATE INDEX IF NOT EXISTS idx_event_rsvps_waitlist
+    ON event_rsvps(event_id, waitlisted_at)
+    WHERE status = 'waitlisted';
@@ -0,0 +1,8 @@
+-- Remove the not_going RSVP status
+DELETE FROM event_rsvps WHERE status = 'not_going';
+
+ALTER TABLE event_rsvps DROP CONSTRAINT IF EXISTS chk_rsvp_status;
+ALTER TABLE event_rsvps ADD CONSTRAINT chk_rsvp_status
+    CHECK (status IN ('going', 'maybe', 'waitlisted'));
+
+COMMENT ON COLUMN event_rsvps.status IS 'RSVP status (going, maybe)';
@@ -0,0 +1,6 @@
+-- Allow users to record that they are not going to an event
+ALTER TABLE event_rsvps DROP CONSTRAINT IF EXISTS chk_rsvp_status;
+ALTER TABLE event_rsvps ADD CONSTRAINT chk_rsvp_status
+    CHECK (status IN ('going', 'maybe', 'not_going', 'waitlisted'));
+
+COMMENT ON COLUMN event_rsvps.status IS 'RSVP status (going, maybe, not_going, waitlisted)';
@@ -0,0 +1,4 @@
+-- Remove threaded replies
+DROP INDEX IF EXISTS idx_posts_parent;
+ALTER TABLE posts DROP COLUMN IF EXISTS reply_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS parent_id;
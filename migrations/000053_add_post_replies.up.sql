@@ -0,0 +1,12 @@
+-- Threaded replies: a post may reply to another post in the same scene/event
+
+-- Parents are soft-deleted, so replies keep a valid reference when orphaned
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES posts(id);
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reply_count INTEGER NOT NULL DEFAULT 0;
+
+-- Add index for listing a thread oldest first (GET /posts/{id}/replies)
+CREATE INDEX IF NOT EXISTS idx_posts_parent ON posts(parent_id, created_at ASC, id ASC)
+    WHERE parent_id IS NOT NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN posts.parent_id IS 'Post this post replies to';
+COMMENT ON COLUMN posts.reply_count IS 'Denormalized count of live replies';
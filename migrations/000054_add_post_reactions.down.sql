@@ -0,0 +1,4 @@
+-- Remove post reactions
+ALTER TABLE posts DROP COLUMN IF EXISTS reaction_count;
+DROP INDEX IF EXISTS idx_post_reactions_post_emoji;
+DROP TABLE IF EXISTS post_reactions;
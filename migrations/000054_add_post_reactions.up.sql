@@ -0,0 +1,18 @@
+-- Emoji reactions to posts: one per user per emoji
+
+CREATE TABLE IF NOT EXISTS post_reactions (
+    post_id UUID NOT NULL REFERENCES posts(id),
+    user_did TEXT NOT NULL,
+    emoji TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_did, emoji)
+);
+
+-- Add index for aggregating counts by emoji per post
+CREATE INDEX IF NOT EXISTS idx_post_reactions_post_emoji ON post_reactions(post_id, emoji);
+
+-- Total reactions per post, so ranked feeds score engagement without a join
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reaction_count INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON TABLE post_reactions IS 'Allowlisted emoji reactions to posts';
+COMMENT ON COLUMN posts.reaction_count IS 'Denormalized count of emoji reactions';
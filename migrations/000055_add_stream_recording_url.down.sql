@@ -0,0 +1,3 @@
+-- Remove stream recording URLs
+ALTER TABLE stream_sessions
+DROP COLUMN IF EXISTS recording_url;
@@ -0,0 +1,3 @@
+-- Record where LiveKit egress stored a stream's recording
+ALTER TABLE stream_sessions
+ADD COLUMN IF NOT EXISTS recording_url TEXT;